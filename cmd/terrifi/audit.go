@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/alexklibisz/terrifi/internal/audit"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/spf13/cobra"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+func auditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit",
+		Short: "Dump the site's security-relevant configuration as normalized JSON",
+		Long: "Connects to a UniFi controller using UNIFI_* environment variables and writes the site's " +
+			"security-relevant configuration to stdout as JSON: firewall zones, firewall policies (including " +
+			"the controller's built-in ones), port forwards, UPnP, and cloud access. Objects use the UniFi " +
+			"API's field names, and lists are sorted so that the output of an unchanged site is stable, which " +
+			"makes it suitable for diffing and compliance checks (e.g. `terrifi audit | jq '.port_forwards'`).\n\n" +
+			"Credentials such as cloud access certificates are not included. UPnP and cloud access are null " +
+			"when the controller doesn't have those settings.",
+		Args: cobra.NoArgs,
+		RunE: runAudit,
+	}
+}
+
+func runAudit(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	cfg := provider.ClientConfigFromEnv()
+	client, err := provider.NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to UniFi controller: %w", err)
+	}
+
+	site := cfg.Site
+
+	zones, err := client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing firewall zones: %w", err)
+	}
+	policies, err := client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing firewall policies: %w", err)
+	}
+	portForwards, err := client.ListPortForward(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing port forwards: %w", err)
+	}
+	usg, err := optionalSetting(client.GetSettingUsg(ctx, site))
+	if err != nil {
+		return fmt.Errorf("reading gateway setting: %w", err)
+	}
+	cloudAccess, err := optionalSetting(client.GetSettingSuperCloudaccess(ctx, site))
+	if err != nil {
+		return fmt.Errorf("reading cloud access setting: %w", err)
+	}
+
+	return audit.Write(os.Stdout, audit.Build(site, zones, policies, portForwards, usg, cloudAccess))
+}
+
+// optionalSetting turns a missing setting into nil instead of an error.
+func optionalSetting[T settings.Usg | settings.SuperCloudaccess](s *T, err error) (*T, error) {
+	var notFound *unifi.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	return s, err
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/alexklibisz/terrifi/internal/generate"
 	"github.com/alexklibisz/terrifi/internal/provider"
@@ -12,20 +14,46 @@ import (
 )
 
 var validResourceTypes = []string{
+	"terrifi_admin",
+	"terrifi_ap_group",
 	"terrifi_client_device",
 	"terrifi_client_group",
+	"terrifi_client_identity",
 	"terrifi_device",
+	"terrifi_dhcp_option",
+	"terrifi_dns_forwarding",
 	"terrifi_dns_record",
+	"terrifi_dpi_restriction",
 	"terrifi_firewall_group",
 	"terrifi_firewall_zone",
 	"terrifi_firewall_policy",
 	"terrifi_firewall_policy_order",
+	"terrifi_nat_rule",
 	"terrifi_network",
+	"terrifi_port_forward",
+	"terrifi_port_profile",
+	"terrifi_radius_user",
+	"terrifi_setting_connectivity",
+	"terrifi_setting_country",
+	"terrifi_setting_global_switch",
+	"terrifi_setting_guest_access",
+	"terrifi_setting_ips",
+	"terrifi_setting_locale",
+	"terrifi_setting_radius",
+	"terrifi_setting_rsyslog",
+	"terrifi_setting_teleport",
+	"terrifi_setting_usg",
+	"terrifi_site_vpn",
+	"terrifi_traffic_rule",
+	"terrifi_traffic_route",
+	"terrifi_user_group",
+	"terrifi_vpn_server",
+	"terrifi_wan",
 	"terrifi_wlan",
 }
 
 func generateImportsCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:       "generate-imports <resource_type>",
 		Short:     "Generate Terraform import blocks and resource definitions from live UniFi data",
 		Long:      "Connects to a UniFi controller using UNIFI_* environment variables and generates Terraform import {} + resource {} blocks for all resources of the given type.",
@@ -33,27 +61,127 @@ func generateImportsCmd() *cobra.Command {
 		ValidArgs: validResourceTypes,
 		RunE:      runGenerateImports,
 	}
+	cmd.Flags().String("active-within", "", "Only generate terrifi_client_device and terrifi_client_identity blocks for clients seen within this window, e.g. 30d or 72h")
+	return cmd
 }
 
 func runGenerateImports(cmd *cobra.Command, args []string) error {
 	resourceType := args[0]
 	ctx := context.Background()
 
+	var activeSince time.Time
+	if v, _ := cmd.Flags().GetString("active-within"); v != "" {
+		if resourceType != "terrifi_client_device" && resourceType != "terrifi_client_identity" {
+			return fmt.Errorf("--active-within only applies to terrifi_client_device and terrifi_client_identity")
+		}
+		window, err := generate.ParseActiveWithin(v)
+		if err != nil {
+			return err
+		}
+		activeSince = time.Now().Add(-window)
+	}
+
 	cfg := provider.ClientConfigFromEnv()
 	client, err := provider.NewClient(ctx, cfg)
 	if err != nil {
 		return fmt.Errorf("connecting to UniFi controller: %w", err)
 	}
 
-	site := cfg.Site
+	refs, err := newReferences(ctx, client, cfg.Site, []string{resourceType})
+	if err != nil {
+		return err
+	}
+
+	blocks, err := generateBlocks(ctx, client, cfg.Site, resourceType, refs, activeSince)
+	if err != nil {
+		return err
+	}
+
+	if len(blocks) == 0 {
+		fmt.Fprintf(os.Stderr, "No %s resources found.\n", resourceType)
+		return nil
+	}
+
+	if err := generate.WriteDataSourceBlocks(os.Stdout, refs.DataSourceBlocks()); err != nil {
+		return err
+	}
+	return generate.WriteBlocks(os.Stdout, blocks)
+}
 
+// referencingTypes are the resource types whose generated blocks refer to
+// zones or networks by ID.
+var referencingTypes = []string{
+	"terrifi_firewall_zone",
+	"terrifi_firewall_policy",
+	"terrifi_firewall_policy_order",
+	"terrifi_nat_rule",
+	"terrifi_port_profile",
+}
+
+// newReferences lists the site's zones and networks so that generated blocks
+// can refer to them by name instead of by ID. It returns nil (no resolution)
+// when none of the resource types need it, which avoids listing firewall
+// zones on controllers that do not have them.
+func newReferences(ctx context.Context, client *provider.Client, site string, resourceTypes []string) (*generate.References, error) {
+	if !slices.ContainsFunc(resourceTypes, func(t string) bool { return slices.Contains(referencingTypes, t) }) {
+		return nil, nil
+	}
+	zones, err := client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing firewall zones: %w", err)
+	}
+	networks, err := client.ListNetwork(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing networks: %w", err)
+	}
+	return generate.NewReferences(zones, networks), nil
+}
+
+// generateBlocks reads all resources of the given type from the controller
+// and converts them to import + resource blocks. Zone and network IDs are
+// resolved through refs, which may be nil. Unless activeSince is zero,
+// client devices and client identities of clients last seen before it are
+// skipped.
+func generateBlocks(ctx context.Context, client *provider.Client, site, resourceType string, refs *generate.References, activeSince time.Time) ([]generate.ResourceBlock, error) {
 	var blocks []generate.ResourceBlock
 
 	switch resourceType {
+	case "terrifi_admin":
+		admins, err := client.ListAdmins(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing admins: %w", err)
+		}
+		items := make([]generate.Admin, 0, len(admins))
+		for _, a := range admins {
+			items = append(items, generate.Admin{
+				ID:          a.ID,
+				Name:        a.Name,
+				Email:       a.Email,
+				Role:        a.Role,
+				Permissions: a.Permissions,
+				IsSuper:     a.IsSuper,
+			})
+		}
+		blocks = generate.AdminBlocks(items)
+
+	case "terrifi_ap_group":
+		groups, err := client.ListAPGroup(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing AP groups: %w", err)
+		}
+		blocks = generate.APGroupBlocks(groups)
+
 	case "terrifi_client_device":
 		clients, err := client.ListClientDevices(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing client devices: %w", err)
+			return nil, fmt.Errorf("listing client devices: %w", err)
+		}
+		if !activeSince.IsZero() {
+			active := generate.ActiveClients(clients, activeSince)
+			if stale := len(clients) - len(active); stale > 0 {
+				fmt.Fprintf(os.Stderr, "Skipped %d client devices not seen since %s.\n", stale, activeSince.Format(time.RFC3339))
+			}
+			clients = active
 		}
 		// Enrich with fingerprint overrides from the v2 API.
 		overrides := map[string]int64{}
@@ -72,7 +200,7 @@ func runGenerateImports(cmd *cobra.Command, args []string) error {
 	case "terrifi_client_group":
 		groups, err := client.ListNetworkMembersGroups(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing client groups: %w", err)
+			return nil, fmt.Errorf("listing client groups: %w", err)
 		}
 		// Only include CLIENTS-type groups. The network-members-group endpoint
 		// also returns USERS-type groups (legacy QoS user groups), which don't
@@ -85,70 +213,336 @@ func runGenerateImports(cmd *cobra.Command, args []string) error {
 		}
 		blocks = generate.ClientGroupBlocks(filtered)
 
+	case "terrifi_client_identity":
+		clients, err := client.ListClientDevices(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing client devices: %w", err)
+		}
+		if !activeSince.IsZero() {
+			active := generate.ActiveClients(clients, activeSince)
+			if stale := len(clients) - len(active); stale > 0 {
+				fmt.Fprintf(os.Stderr, "Skipped %d client devices not seen since %s.\n", stale, activeSince.Format(time.RFC3339))
+			}
+			clients = active
+		}
+		// Identities are fingerprint overrides from the v2 API.
+		fingerprints := map[string]*unifi.ClientInfoFingerprint{}
+		for _, c := range clients {
+			if c.MAC == "" {
+				continue
+			}
+			fp, err := client.GetClientFingerprint(ctx, site, c.MAC)
+			if err != nil {
+				continue
+			}
+			fingerprints[c.MAC] = fp
+		}
+		blocks = generate.ClientIdentityBlocks(clients, fingerprints)
+
 	case "terrifi_device":
 		devices, err := client.ListDevice(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing devices: %w", err)
+			return nil, fmt.Errorf("listing devices: %w", err)
 		}
 		blocks = generate.DeviceBlocks(devices)
 
+	case "terrifi_dhcp_option":
+		options, err := client.ListDHCPOption(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing DHCP options: %w", err)
+		}
+		blocks = generate.DHCPOptionBlocks(options)
+
+	case "terrifi_dns_forwarding":
+		records, err := client.ListDNSRecord(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing DNS forwarding entries: %w", err)
+		}
+		blocks = generate.DNSForwardingBlocks(records)
+
 	case "terrifi_dns_record":
 		records, err := client.ListDNSRecord(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing DNS records: %w", err)
+			return nil, fmt.Errorf("listing DNS records: %w", err)
 		}
 		blocks = generate.DNSRecordBlocks(records)
 
+	case "terrifi_dpi_restriction":
+		apps, err := client.ListDpiApp(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing DPI restrictions: %w", err)
+		}
+		blocks = generate.DPIRestrictionBlocks(apps)
+
 	case "terrifi_firewall_group":
 		groups, err := client.ListFirewallGroup(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing firewall groups: %w", err)
+			return nil, fmt.Errorf("listing firewall groups: %w", err)
 		}
 		blocks = generate.FirewallGroupBlocks(groups)
 
 	case "terrifi_firewall_zone":
 		zones, err := client.ListFirewallZone(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing firewall zones: %w", err)
+			return nil, fmt.Errorf("listing firewall zones: %w", err)
 		}
-		blocks = generate.FirewallZoneBlocks(zones)
+		blocks = generate.FirewallZoneBlocks(zones, refs)
 
 	case "terrifi_firewall_policy":
 		policies, err := client.ListFirewallPolicies(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing firewall policies: %w", err)
+			return nil, fmt.Errorf("listing firewall policies: %w", err)
 		}
-		blocks = generate.FirewallPolicyBlocks(policies)
+		blocks = generate.FirewallPolicyBlocks(policies, refs)
 
 	case "terrifi_firewall_policy_order":
 		policies, err := client.ListFirewallPolicies(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing firewall policies: %w", err)
+			return nil, fmt.Errorf("listing firewall policies: %w", err)
+		}
+		blocks = generate.FirewallPolicyOrderBlocks(policies, refs)
+
+	case "terrifi_nat_rule":
+		rules, err := client.ListNat(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing NAT rules: %w", err)
 		}
-		blocks = generate.FirewallPolicyOrderBlocks(policies)
+		blocks = generate.NATRuleBlocks(rules, refs)
 
 	case "terrifi_network":
 		networks, err := client.ListNetwork(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing networks: %w", err)
+			return nil, fmt.Errorf("listing networks: %w", err)
 		}
 		blocks = generate.NetworkBlocks(networks)
 
+	case "terrifi_port_forward":
+		forwards, err := client.ListPortForward(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing port forwards: %w", err)
+		}
+		blocks = generate.PortForwardBlocks(forwards)
+
+	case "terrifi_port_profile":
+		profiles, err := client.ListPortProfile(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing port profiles: %w", err)
+		}
+		blocks = generate.PortProfileBlocks(profiles, refs)
+
+	case "terrifi_radius_user":
+		accounts, err := client.ListAccount(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing RADIUS users: %w", err)
+		}
+		blocks = generate.RADIUSUserBlocks(accounts)
+
+	case "terrifi_setting_connectivity":
+		connectivity, err := client.GetSettingConnectivity(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading connectivity setting: %w", err)
+		}
+		blocks = generate.SettingConnectivityBlocks(site, connectivity)
+
+	case "terrifi_setting_country":
+		country, err := client.GetSettingCountry(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading country setting: %w", err)
+		}
+		blocks = generate.SettingCountryBlocks(site, country)
+
+	case "terrifi_setting_global_switch":
+		globalSwitch, err := client.GetSettingGlobalSwitch(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading global switch setting: %w", err)
+		}
+		blocks = generate.SettingGlobalSwitchBlocks(site, globalSwitch)
+
+	case "terrifi_setting_guest_access":
+		guestAccess, err := client.GetSettingGuestAccess(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading guest access setting: %w", err)
+		}
+		blocks = generate.SettingGuestAccessBlocks(site, guestAccess)
+
+	case "terrifi_setting_ips":
+		ips, err := client.GetSettingIps(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading IPS setting: %w", err)
+		}
+		blocks = generate.SettingIPSBlocks(site, ips)
+
+	case "terrifi_setting_locale":
+		locale, err := client.GetSettingLocale(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading locale setting: %w", err)
+		}
+		blocks = generate.SettingLocaleBlocks(site, locale)
+
+	case "terrifi_setting_radius":
+		radius, err := client.GetSettingRadius(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading RADIUS setting: %w", err)
+		}
+		blocks = generate.SettingRadiusBlocks(site, radius)
+
+	case "terrifi_setting_rsyslog":
+		rsyslog, err := client.GetSettingRsyslogd(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading remote syslog setting: %w", err)
+		}
+		blocks = generate.SettingRsyslogBlocks(site, rsyslog)
+
+	case "terrifi_setting_teleport":
+		teleport, err := client.GetSettingTeleport(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading Teleport setting: %w", err)
+		}
+		blocks = generate.SettingTeleportBlocks(site, teleport)
+
+	case "terrifi_setting_usg":
+		usg, err := client.GetSettingUsg(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("reading gateway setting: %w", err)
+		}
+		blocks = generate.SettingUSGBlocks(site, usg)
+
+	case "terrifi_site_vpn":
+		networks, err := client.ListNetwork(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing networks: %w", err)
+		}
+		blocks = generate.SiteVPNBlocks(networks)
+
+	case "terrifi_traffic_rule":
+		rules, err := client.ListTrafficRule(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing traffic rules: %w", err)
+		}
+		generateRules := make([]generate.TrafficRule, len(rules))
+		for i, r := range rules {
+			g := generate.TrafficRule{
+				ID:             r.ID,
+				Description:    r.Description,
+				Action:         r.Action,
+				Enabled:        r.Enabled,
+				MatchingTarget: r.MatchingTarget,
+				AppIDs:         r.AppIDs,
+				AppCategoryIDs: r.AppCategoryIDs,
+				Regions:        r.Regions,
+				Schedule: &unifi.FirewallPolicySchedule{
+					Mode:           r.Schedule.Mode,
+					Date:           r.Schedule.Date,
+					TimeAllDay:     r.Schedule.TimeAllDay != nil && *r.Schedule.TimeAllDay,
+					TimeRangeStart: r.Schedule.TimeRangeStart,
+					TimeRangeEnd:   r.Schedule.TimeRangeEnd,
+					RepeatOnDays:   r.Schedule.RepeatOnDays,
+				},
+				DateStart: r.Schedule.DateStart,
+				DateEnd:   r.Schedule.DateEnd,
+			}
+			for _, t := range r.TargetDevices {
+				switch t.Type {
+				case "CLIENT":
+					g.ClientMACs = append(g.ClientMACs, t.ClientMAC)
+				case "NETWORK":
+					g.NetworkIDs = append(g.NetworkIDs, t.NetworkID)
+				}
+			}
+			for _, d := range r.Domains {
+				g.Domains = append(g.Domains, d.Domain)
+			}
+			generateRules[i] = g
+		}
+		blocks = generate.TrafficRuleBlocks(generateRules)
+
+	case "terrifi_traffic_route":
+		routes, err := client.ListTrafficRoute(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing traffic routes: %w", err)
+		}
+		generateRoutes := make([]generate.TrafficRoute, len(routes))
+		for i, r := range routes {
+			g := generate.TrafficRoute{
+				ID:             r.ID,
+				Description:    r.Description,
+				Enabled:        r.Enabled,
+				KillSwitch:     r.KillSwitchEnabled,
+				NetworkID:      r.NetworkID,
+				NextHop:        r.NextHop,
+				MatchingTarget: r.MatchingTarget,
+				Regions:        r.Regions,
+			}
+			for _, t := range r.TargetDevices {
+				switch t.Type {
+				case "CLIENT":
+					g.ClientMACs = append(g.ClientMACs, t.ClientMAC)
+				case "NETWORK":
+					g.NetworkIDs = append(g.NetworkIDs, t.NetworkID)
+				}
+			}
+			for _, d := range r.Domains {
+				g.Domains = append(g.Domains, d.Domain)
+			}
+			for _, a := range r.IPAddresses {
+				g.IPs = append(g.IPs, a.IPOrSubnet)
+			}
+			for _, rng := range r.IPRanges {
+				g.IPs = append(g.IPs, rng.IPStart+"-"+rng.IPStop)
+			}
+			generateRoutes[i] = g
+		}
+		blocks = generate.TrafficRouteBlocks(generateRoutes)
+
+	case "terrifi_user_group":
+		groups, err := client.ListClientGroup(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing user groups: %w", err)
+		}
+		blocks = generate.UserGroupBlocks(groups)
+
+	case "terrifi_vpn_server":
+		networks, err := client.ListNetwork(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing networks: %w", err)
+		}
+		peers := map[string][]generate.WireGuardPeer{}
+		for _, n := range networks {
+			if n.Purpose != unifi.PurposeUserVPN || n.VPNType == nil || *n.VPNType != "wireguard-server" {
+				continue
+			}
+			serverPeers, err := client.ListWireGuardPeers(ctx, site, n.ID)
+			if err != nil {
+				return nil, fmt.Errorf("listing peers of VPN server %s: %w", n.ID, err)
+			}
+			for _, p := range serverPeers {
+				peers[n.ID] = append(peers[n.ID], generate.WireGuardPeer{
+					Name:         p.Name,
+					InterfaceIP:  p.InterfaceIP,
+					PublicKey:    p.PublicKey,
+					PresharedKey: p.PresharedKey,
+				})
+			}
+		}
+		blocks = generate.VPNServerBlocks(networks, peers)
+
+	case "terrifi_wan":
+		networks, err := client.ListNetwork(ctx, site)
+		if err != nil {
+			return nil, fmt.Errorf("listing networks: %w", err)
+		}
+		blocks = generate.WANBlocks(networks)
+
 	case "terrifi_wlan":
 		wlans, err := client.ListWLAN(ctx, site)
 		if err != nil {
-			return fmt.Errorf("listing WLANs: %w", err)
+			return nil, fmt.Errorf("listing WLANs: %w", err)
 		}
 		blocks = generate.WLANBlocks(wlans)
 
 	default:
-		return fmt.Errorf("unknown resource type: %s\nValid types: %v", resourceType, validResourceTypes)
+		return nil, fmt.Errorf("unknown resource type: %s\nValid types: %v", resourceType, validResourceTypes)
 	}
 
-	if len(blocks) == 0 {
-		fmt.Fprintf(os.Stderr, "No %s resources found.\n", resourceType)
-		return nil
-	}
-
-	return generate.WriteBlocks(os.Stdout, blocks)
+	return blocks, nil
 }
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alexklibisz/terrifi/internal/graph"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+func graphCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Render a diagram of firewall zones, their networks, and the policies between them",
+		Long: "Connects to a UniFi controller using UNIFI_* environment variables and writes a diagram of " +
+			"the zone-based firewall to stdout: one group per zone containing its networks, and one edge per " +
+			"firewall policy between zones. Allow policies are green, block/reject policies red, and disabled " +
+			"policies dashed.\n\n" +
+			"Use --format dot for Graphviz (e.g. `terrifi graph | dot -Tsvg > firewall.svg`) or " +
+			"--format mermaid to embed the diagram in Markdown.",
+		Args: cobra.NoArgs,
+		RunE: runGraph,
+	}
+	cmd.Flags().String("format", "dot", "Output format: dot or mermaid")
+	cmd.Flags().Bool("include-predefined", false, "Include the controller's built-in policies")
+	return cmd
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	includePredefined, _ := cmd.Flags().GetBool("include-predefined")
+
+	var write func(io.Writer, graph.Graph) error
+	switch format {
+	case "dot":
+		write = graph.WriteDOT
+	case "mermaid":
+		write = graph.WriteMermaid
+	default:
+		return fmt.Errorf("unknown format: %s\nValid formats: dot, mermaid", format)
+	}
+
+	ctx := context.Background()
+
+	cfg := provider.ClientConfigFromEnv()
+	client, err := provider.NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to UniFi controller: %w", err)
+	}
+
+	site := cfg.Site
+
+	zones, err := client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing firewall zones: %w", err)
+	}
+	networks, err := client.ListNetwork(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing networks: %w", err)
+	}
+	policies, err := client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing firewall policies: %w", err)
+	}
+
+	g := graph.Build(zones, networks, policies, graph.Options{IncludePredefined: includePredefined})
+	if len(g.Zones) == 0 {
+		fmt.Fprintln(os.Stderr, "No firewall zones found. Is the zone-based firewall enabled?")
+		return nil
+	}
+
+	return write(os.Stdout, g)
+}
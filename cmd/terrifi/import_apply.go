@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexklibisz/terrifi/internal/generate"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/spf13/cobra"
+)
+
+func importApplyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-apply <resource_type>...",
+		Short: "Generate import blocks for live UniFi resources and import them into Terraform state",
+		Long: "Connects to a UniFi controller using UNIFI_* environment variables, generates the same import {} " +
+			"and resource {} blocks as generate-imports, and runs them through the Terraform CLI in an already " +
+			"initialized configuration directory.\n\n" +
+			"Resources already tracked in state are skipped. The blocks are written to --out and a plan targeting " +
+			"only the new resources is shown; after confirmation the plan is applied. Once the imports succeed, " +
+			"the import {} blocks are removed from --out, leaving only the resource definitions.",
+		Args:      cobra.MinimumNArgs(1),
+		ValidArgs: validResourceTypes,
+		RunE:      runImportApply,
+	}
+	cmd.Flags().String("chdir", ".", "Terraform configuration directory")
+	cmd.Flags().String("out", "terrifi_imports.tf", "File (relative to --chdir) to write the generated configuration to; must not exist")
+	cmd.Flags().String("binary", "", "Terraform CLI to run (default: terraform, or tofu if terraform is not on PATH)")
+	cmd.Flags().Bool("auto-approve", false, "Apply the imports without asking for confirmation")
+	return cmd
+}
+
+func runImportApply(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("chdir")
+	out, _ := cmd.Flags().GetString("out")
+	binary, _ := cmd.Flags().GetString("binary")
+	autoApprove, _ := cmd.Flags().GetBool("auto-approve")
+
+	for _, resourceType := range args {
+		if !slices.Contains(validResourceTypes, resourceType) {
+			return fmt.Errorf("unknown resource type: %s\nValid types: %v", resourceType, validResourceTypes)
+		}
+	}
+
+	binary, err := terraformBinary(binary)
+	if err != nil {
+		return err
+	}
+
+	outPath := filepath.Join(dir, out)
+	if _, err := os.Stat(outPath); err == nil {
+		return fmt.Errorf("%s already exists; remove it or choose another file with --out", outPath)
+	}
+
+	ctx := context.Background()
+
+	cfg := provider.ClientConfigFromEnv()
+	client, err := provider.NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to UniFi controller: %w", err)
+	}
+
+	var stateJSON bytes.Buffer
+	if err := runTerraform(ctx, binary, dir, &stateJSON, "show", "-json"); err != nil {
+		return fmt.Errorf("reading Terraform state (has `%s init` been run in %s?): %w", filepath.Base(binary), dir, err)
+	}
+	managed, err := generate.ParseState(&stateJSON)
+	if err != nil {
+		return err
+	}
+
+	refs, err := newReferences(ctx, client, cfg.Site, args)
+	if err != nil {
+		return err
+	}
+
+	// Networks and zones are generated first so that blocks referring to them
+	// can use the new resources instead of data source lookups. Resources
+	// already in state are filtered out before registering, since their
+	// addresses in the existing configuration are unknown.
+	var blocks []generate.ResourceBlock
+	skipped := 0
+	for _, resourceType := range referencedFirst(args) {
+		typeBlocks, err := generateBlocks(ctx, client, cfg.Site, resourceType, refs, time.Time{})
+		if err != nil {
+			return err
+		}
+		typeBlocks, typeSkipped := generate.FilterUnmanaged(typeBlocks, managed)
+		skipped += len(typeSkipped)
+		refs.AddResources(typeBlocks)
+		blocks = append(blocks, typeBlocks...)
+	}
+
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d resource(s) already in state.\n", skipped)
+	}
+	if len(blocks) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing to import.")
+		return nil
+	}
+
+	dataBlocks := refs.DataSourceBlocks()
+	if err := writeBlocksFile(outPath, dataBlocks, blocks, generate.WriteBlocks); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d import block(s) to %s.\n", len(blocks), outPath)
+
+	planFile, err := os.CreateTemp("", "terrifi-import-*.tfplan")
+	if err != nil {
+		return fmt.Errorf("creating plan file: %w", err)
+	}
+	planFile.Close()
+	defer os.Remove(planFile.Name())
+
+	// Target only the imported resources so that unrelated pending changes in
+	// the configuration are not applied along with the imports.
+	planArgs := []string{"plan", "-input=false", "-out=" + planFile.Name()}
+	for _, b := range blocks {
+		planArgs = append(planArgs, "-target="+b.ResourceType+"."+b.ResourceName)
+	}
+	if err := runTerraform(ctx, binary, dir, os.Stdout, planArgs...); err != nil {
+		return fmt.Errorf("plan failed; the generated configuration was left in %s for inspection: %w", outPath, err)
+	}
+
+	if !autoApprove {
+		fmt.Printf("\nImport %d resource(s) into state? Only 'yes' will be accepted: ", len(blocks))
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(answer) != "yes" {
+			return fmt.Errorf("import cancelled; the generated configuration was left in %s", outPath)
+		}
+	}
+
+	if err := runTerraform(ctx, binary, dir, os.Stdout, "apply", "-input=false", planFile.Name()); err != nil {
+		return fmt.Errorf("apply failed; the generated configuration was left in %s: %w", outPath, err)
+	}
+
+	// The import blocks have done their job; keep only the resources.
+	if err := writeBlocksFile(outPath, dataBlocks, blocks, generate.WriteResourceBlocks); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Imported %d resource(s). Resource definitions are in %s.\n", len(blocks), outPath)
+	return nil
+}
+
+// terraformBinary resolves the Terraform CLI to run. An explicit name or path
+// is used as-is; otherwise terraform is preferred, falling back to tofu.
+func terraformBinary(name string) (string, error) {
+	if name != "" {
+		return exec.LookPath(name)
+	}
+	for _, candidate := range []string{"terraform", "tofu"} {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("neither terraform nor tofu found on PATH; pass --binary")
+}
+
+// runTerraform runs the Terraform CLI in dir, writing its stdout to stdout
+// and passing stderr through.
+func runTerraform(ctx context.Context, binary, dir string, stdout io.Writer, args ...string) error {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// referencedFirst returns the resource types with networks and zones moved to
+// the front, in that order, keeping the rest in their given order.
+func referencedFirst(resourceTypes []string) []string {
+	rank := func(t string) int {
+		switch t {
+		case "terrifi_network":
+			return 0
+		case "terrifi_firewall_zone":
+			return 1
+		}
+		return 2
+	}
+	sorted := slices.Clone(resourceTypes)
+	slices.SortStableFunc(sorted, func(a, b string) int { return rank(a) - rank(b) })
+	return sorted
+}
+
+func writeBlocksFile(path string, dataBlocks []generate.DataSourceBlock, blocks []generate.ResourceBlock, write func(io.Writer, []generate.ResourceBlock) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := generate.WriteDataSourceBlocks(f, dataBlocks); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := write(f, blocks); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
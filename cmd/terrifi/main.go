@@ -32,8 +32,14 @@ func main() {
 	}
 
 	rootCmd.AddCommand(generateImportsCmd())
+	rootCmd.AddCommand(importApplyCmd())
+	rootCmd.AddCommand(verifyImportsCmd())
 	rootCmd.AddCommand(checkConnectionCmd())
 	rootCmd.AddCommand(listDeviceTypesCmd())
+	rootCmd.AddCommand(graphCmd())
+	rootCmd.AddCommand(auditCmd())
+	rootCmd.AddCommand(seedCmd())
+	rootCmd.AddCommand(refactorCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
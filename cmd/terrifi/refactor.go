@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexklibisz/terrifi/internal/refactor"
+	"github.com/spf13/cobra"
+)
+
+func refactorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refactor",
+		Short: "Rewrite Terraform configuration without replacing resources",
+	}
+	cmd.AddCommand(refactorRenameCmd())
+	return cmd
+}
+
+func refactorRenameCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rename <from> <to>",
+		Short: "Rename a resource in the configuration and record a moved block",
+		Long: "Renames a resource, e.g. `terrifi refactor rename terrifi_client_device.printer " +
+			"terrifi_client_device.office_printer`, in the .tf files of a configuration directory: the " +
+			"resource block is relabeled and every reference to it, including import block targets, is " +
+			"updated. A matching moved {} block is appended to --moved-file so that Terraform moves the " +
+			"existing state entry instead of destroying and recreating the resource.\n\n" +
+			"Use it to adopt the names generate-imports produces after objects are renamed on the controller.",
+		Args: cobra.ExactArgs(2),
+		RunE: runRefactorRename,
+	}
+	cmd.Flags().String("chdir", ".", "Terraform configuration directory")
+	cmd.Flags().String("moved-file", "moved.tf", "File (relative to --chdir) to append the moved block to")
+	cmd.Flags().Bool("dry-run", false, "Print the files that would change and the moved block without writing anything")
+	return cmd
+}
+
+func runRefactorRename(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("chdir")
+	movedFile, _ := cmd.Flags().GetString("moved-file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	from, err := refactor.ParseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	to, err := refactor.ParseAddress(args[1])
+	if err != nil {
+		return err
+	}
+	if from == to {
+		return fmt.Errorf("%s and %s are the same address", from, to)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return err
+	}
+
+	// Parse and rewrite every file before writing any, so a parse error or a
+	// name clash leaves the configuration untouched.
+	type rewrite struct {
+		path string
+		src  []byte
+	}
+	var rewrites []rewrite
+	declared := false
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		clash, err := refactor.Declares(src, path, to)
+		if err != nil {
+			return err
+		}
+		if clash {
+			return fmt.Errorf("%s is already declared in %s", to, path)
+		}
+		if ok, _ := refactor.Declares(src, path, from); ok {
+			declared = true
+		}
+		out, changed, err := refactor.Rename(src, path, from, to)
+		if err != nil {
+			return err
+		}
+		if changed {
+			rewrites = append(rewrites, rewrite{path, out})
+		}
+	}
+	if !declared {
+		return fmt.Errorf("no resource block for %s in %s", from, dir)
+	}
+
+	moved := refactor.MovedBlock(from, to)
+	movedPath := filepath.Join(dir, movedFile)
+
+	if dryRun {
+		for _, r := range rewrites {
+			fmt.Fprintf(os.Stderr, "Would update %s.\n", r.path)
+		}
+		fmt.Fprintf(os.Stderr, "Would append to %s:\n", movedPath)
+		_, err := os.Stdout.Write(moved)
+		return err
+	}
+
+	for _, r := range rewrites {
+		info, err := os.Stat(r.path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(r.path, r.src, info.Mode().Perm()); err != nil {
+			return fmt.Errorf("writing %s: %w", r.path, err)
+		}
+		fmt.Fprintf(os.Stderr, "Updated %s.\n", r.path)
+	}
+
+	if err := appendMovedBlock(movedPath, moved); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Appended moved block to %s. Run `terraform plan` to confirm %s is moved, not replaced.\n", movedPath, from)
+	return nil
+}
+
+// appendMovedBlock appends a moved block to path, creating the file if
+// needed and separating the block from any existing content by a blank line.
+func appendMovedBlock(path string, block []byte) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(existing)
+	if len(existing) > 0 {
+		if !bytes.HasSuffix(existing, []byte("\n")) {
+			buf.WriteString("\n")
+		}
+		buf.WriteString("\n")
+	}
+	buf.Write(block)
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/alexklibisz/terrifi/internal/generate"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/spf13/cobra"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// seedProfile is a standard set of objects to create on a fresh controller.
+// Zones and WLANs refer to networks by name.
+type seedProfile struct {
+	Networks []seedNetwork
+	Zones    []seedZone
+	WLANs    []seedWLAN
+}
+
+type seedNetwork struct {
+	Name   string
+	VLAN   int64
+	Subnet string // gateway address and prefix, e.g. 10.0.10.1/24
+}
+
+type seedZone struct {
+	Name     string
+	Networks []string
+}
+
+type seedWLAN struct {
+	Name    string
+	Network string
+	Guest   bool
+}
+
+// seedProfiles are the profiles available to terrifi seed. homelab has
+// trusted, IoT, guest, and camera VLANs, a zone for the IoT devices, and an
+// SSID for each network that has wireless clients.
+var seedProfiles = map[string]seedProfile{
+	"homelab": {
+		Networks: []seedNetwork{
+			{Name: "Trusted", VLAN: 10, Subnet: "10.0.10.1/24"},
+			{Name: "IoT", VLAN: 20, Subnet: "10.0.20.1/24"},
+			{Name: "Guest", VLAN: 30, Subnet: "10.0.30.1/24"},
+			{Name: "Cameras", VLAN: 40, Subnet: "10.0.40.1/24"},
+		},
+		Zones: []seedZone{
+			{Name: "IoT", Networks: []string{"IoT", "Cameras"}},
+		},
+		WLANs: []seedWLAN{
+			{Name: "Home", Network: "Trusted"},
+			{Name: "Home-IoT", Network: "IoT"},
+			{Name: "Home-Guest", Network: "Guest", Guest: true},
+		},
+	},
+}
+
+func seedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Create a standard set of networks, zones, and SSIDs on a demo or test controller",
+		Long: "Connects to a UniFi controller using UNIFI_* environment variables and creates the networks, " +
+			"firewall zones, and WLANs of the chosen profile. Objects whose names already exist are left alone, " +
+			"so it is safe to re-run. Zones are only created on controllers with the zone-based firewall.\n\n" +
+			"Intended for fresh controllers, such as the Docker simulation environment used for acceptance " +
+			"tests and demos. With --hcl, the equivalent import {} and resource {} blocks are written to a file.",
+		Args: cobra.NoArgs,
+		RunE: runSeed,
+	}
+	cmd.Flags().String("profile", "homelab", fmt.Sprintf("Profile to create (one of %v)", seedProfileNames()))
+	cmd.Flags().String("hcl", "", "Also write import and resource blocks for the seeded objects to this file; must not exist")
+	cmd.Flags().String("passphrase", "", "WLAN passphrase (default: randomly generated and printed)")
+	return cmd
+}
+
+func runSeed(cmd *cobra.Command, args []string) error {
+	profileName, _ := cmd.Flags().GetString("profile")
+	hclPath, _ := cmd.Flags().GetString("hcl")
+	passphrase, _ := cmd.Flags().GetString("passphrase")
+
+	profile, ok := seedProfiles[profileName]
+	if !ok {
+		return fmt.Errorf("unknown profile: %s\nValid profiles: %v", profileName, seedProfileNames())
+	}
+	if err := profile.validate(); err != nil {
+		return fmt.Errorf("profile %s: %w", profileName, err)
+	}
+
+	if hclPath != "" {
+		if _, err := os.Stat(hclPath); err == nil {
+			return fmt.Errorf("%s already exists; remove it or choose another file with --hcl", hclPath)
+		}
+	}
+
+	if passphrase == "" && len(profile.WLANs) > 0 {
+		buf := make([]byte, 8)
+		if _, err := rand.Read(buf); err != nil {
+			return fmt.Errorf("generating passphrase: %w", err)
+		}
+		passphrase = hex.EncodeToString(buf)
+		fmt.Fprintf(os.Stderr, "WLAN passphrase: %s\n", passphrase)
+	}
+
+	ctx := context.Background()
+
+	cfg := provider.ClientConfigFromEnv()
+	client, err := provider.NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to UniFi controller: %w", err)
+	}
+
+	networks, err := seedNetworks(ctx, client, cfg.Site, profile.Networks)
+	if err != nil {
+		return err
+	}
+	networkIDs := map[string]string{}
+	for _, n := range networks {
+		networkIDs[*n.Name] = n.ID
+	}
+
+	zoneBased, err := client.HasZoneBasedFirewall(ctx, cfg.Site)
+	if err != nil {
+		return fmt.Errorf("checking for the zone-based firewall: %w", err)
+	}
+	var zones []unifi.FirewallZone
+	if zoneBased {
+		zones, err = seedZones(ctx, client, cfg.Site, profile.Zones, networkIDs)
+		if err != nil {
+			return err
+		}
+	} else if len(profile.Zones) > 0 {
+		fmt.Fprintln(os.Stderr, "Zone-based firewall not available; skipping zones.")
+	}
+
+	wlans, err := seedWLANs(ctx, client, cfg.Site, profile.WLANs, networkIDs, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if hclPath == "" {
+		return nil
+	}
+
+	refs := generate.NewReferences(zones, networks)
+	blocks := generate.NetworkBlocks(networks)
+	refs.AddResources(blocks)
+	zoneBlocks := generate.FirewallZoneBlocks(zones, refs)
+	refs.AddResources(zoneBlocks)
+	blocks = append(blocks, zoneBlocks...)
+	blocks = append(blocks, referenceWLANNetworks(generate.WLANBlocks(wlans), wlans, refs)...)
+
+	if err := writeBlocksFile(hclPath, refs.DataSourceBlocks(), blocks, generate.WriteBlocks); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d import block(s) to %s.\n", len(blocks), hclPath)
+	return nil
+}
+
+// seedNetworks creates the profile's networks that do not exist yet and
+// returns all of them, existing ones included.
+func seedNetworks(ctx context.Context, client *provider.Client, site string, want []seedNetwork) ([]unifi.Network, error) {
+	existing, err := client.ListNetwork(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing networks: %w", err)
+	}
+
+	result := make([]unifi.Network, 0, len(want))
+	for _, w := range want {
+		idx := slices.IndexFunc(existing, func(n unifi.Network) bool { return n.Name != nil && *n.Name == w.Name })
+		if idx >= 0 {
+			fmt.Fprintf(os.Stderr, "Network %q already exists; skipping.\n", w.Name)
+			result = append(result, existing[idx])
+			continue
+		}
+
+		name, vlan, subnet := w.Name, w.VLAN, w.Subnet
+		group := "LAN"
+		// The SDK defaults setting_preference to "auto", which lets the
+		// controller override the settings below; see terrifi_network.
+		preference := "manual"
+		created, err := client.CreateNetwork(ctx, site, &unifi.Network{
+			Name:              &name,
+			Purpose:           "corporate",
+			Enabled:           true,
+			NetworkGroup:      &group,
+			VLAN:              &vlan,
+			VLANEnabled:       true,
+			IPSubnet:          &subnet,
+			DHCPDEnabled:      true,
+			SettingPreference: &preference,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating network %q: %w", w.Name, err)
+		}
+		fmt.Fprintf(os.Stderr, "Created network %q (VLAN %d).\n", w.Name, w.VLAN)
+		result = append(result, *created)
+	}
+	return result, nil
+}
+
+// seedZones creates the profile's firewall zones that do not exist yet and
+// returns all of them, existing ones included.
+func seedZones(ctx context.Context, client *provider.Client, site string, want []seedZone, networkIDs map[string]string) ([]unifi.FirewallZone, error) {
+	existing, err := client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing firewall zones: %w", err)
+	}
+
+	result := make([]unifi.FirewallZone, 0, len(want))
+	for _, w := range want {
+		idx := slices.IndexFunc(existing, func(z unifi.FirewallZone) bool { return z.Name == w.Name })
+		if idx >= 0 {
+			fmt.Fprintf(os.Stderr, "Firewall zone %q already exists; skipping.\n", w.Name)
+			result = append(result, existing[idx])
+			continue
+		}
+
+		ids := make([]string, len(w.Networks))
+		for i, n := range w.Networks {
+			ids[i] = networkIDs[n]
+		}
+		created, err := client.CreateFirewallZone(ctx, site, &unifi.FirewallZone{Name: w.Name, NetworkIDs: ids})
+		if err != nil {
+			return nil, fmt.Errorf("creating firewall zone %q: %w", w.Name, err)
+		}
+		fmt.Fprintf(os.Stderr, "Created firewall zone %q.\n", w.Name)
+		result = append(result, *created)
+	}
+	return result, nil
+}
+
+// seedWLANs creates the profile's WLANs that do not exist yet and returns all
+// of them, existing ones included.
+func seedWLANs(ctx context.Context, client *provider.Client, site string, want []seedWLAN, networkIDs map[string]string, passphrase string) ([]unifi.WLAN, error) {
+	if len(want) == 0 {
+		return nil, nil
+	}
+
+	existing, err := client.ListWLAN(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing WLANs: %w", err)
+	}
+
+	// The API requires a WLAN group, user group, and AP group; use the
+	// defaults, as terrifi_wlan does.
+	wlanGroups, err := client.ListWLANGroup(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing WLAN groups: %w", err)
+	}
+	userGroups, err := client.ListClientGroup(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing user groups: %w", err)
+	}
+	apGroups, err := client.ListAPGroup(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing AP groups: %w", err)
+	}
+	if len(wlanGroups) == 0 || len(userGroups) == 0 || len(apGroups) == 0 {
+		return nil, fmt.Errorf("site %q is missing a default WLAN group, user group, or AP group", site)
+	}
+
+	result := make([]unifi.WLAN, 0, len(want))
+	for _, w := range want {
+		idx := slices.IndexFunc(existing, func(e unifi.WLAN) bool { return e.Name == w.Name })
+		if idx >= 0 {
+			fmt.Fprintf(os.Stderr, "WLAN %q already exists; skipping.\n", w.Name)
+			result = append(result, existing[idx])
+			continue
+		}
+
+		created, err := client.CreateWLAN(ctx, site, &unifi.WLAN{
+			Name:        w.Name,
+			NetworkID:   networkIDs[w.Network],
+			Enabled:     true,
+			Security:    "wpapsk",
+			WPAMode:     "wpa2",
+			WLANBand:    "both",
+			XPassphrase: passphrase,
+			IsGuest:     w.Guest,
+			WLANGroupID: wlanGroups[0].ID,
+			UserGroupID: userGroups[0].ID,
+			ApGroupIDs:  []string{apGroups[0].ID},
+			ApGroupMode: "all",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("creating WLAN %q: %w", w.Name, err)
+		}
+		fmt.Fprintf(os.Stderr, "Created WLAN %q.\n", w.Name)
+		result = append(result, *created)
+	}
+	return result, nil
+}
+
+// referenceWLANNetworks replaces the literal network IDs in generated WLAN
+// blocks with references to the seeded networks.
+func referenceWLANNetworks(blocks []generate.ResourceBlock, wlans []unifi.WLAN, refs *generate.References) []generate.ResourceBlock {
+	networks := map[string]string{}
+	for _, w := range wlans {
+		networks[w.ID] = w.NetworkID
+	}
+	for i := range blocks {
+		for j, a := range blocks[i].Attributes {
+			if a.Key != "network_id" {
+				continue
+			}
+			if ref, ok := refs.Network(networks[blocks[i].ImportID]); ok {
+				blocks[i].Attributes[j] = generate.Attr{Key: "network_id", Value: ref}
+			}
+		}
+	}
+	return blocks
+}
+
+// validate checks that the zones and WLANs only refer to networks defined in
+// the profile, and that names and VLANs are unique.
+func (p seedProfile) validate() error {
+	networks := map[string]bool{}
+	vlans := map[int64]bool{}
+	for _, n := range p.Networks {
+		if networks[n.Name] {
+			return fmt.Errorf("duplicate network %q", n.Name)
+		}
+		if vlans[n.VLAN] {
+			return fmt.Errorf("duplicate VLAN %d", n.VLAN)
+		}
+		networks[n.Name] = true
+		vlans[n.VLAN] = true
+	}
+	for _, z := range p.Zones {
+		for _, n := range z.Networks {
+			if !networks[n] {
+				return fmt.Errorf("zone %q refers to unknown network %q", z.Name, n)
+			}
+		}
+	}
+	for _, w := range p.WLANs {
+		if !networks[w.Network] {
+			return fmt.Errorf("WLAN %q refers to unknown network %q", w.Name, w.Network)
+		}
+	}
+	return nil
+}
+
+func seedProfileNames() []string {
+	names := make([]string, 0, len(seedProfiles))
+	for name := range seedProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
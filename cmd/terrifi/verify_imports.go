@@ -0,0 +1,403 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/alexklibisz/terrifi/internal/imports"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/spf13/cobra"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func verifyImportsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify-imports",
+		Short: "Check that the IDs in import blocks exist on the UniFi controller",
+		Long: "Reads the import {} blocks of the .tf files in a configuration directory and checks, using UNIFI_* " +
+			"environment variables, that every ID refers to an existing object of the target's resource type. " +
+			"IDs are parsed the way the resource's import does, so site:id and the MAC and site-name IDs of " +
+			"devices and settings are supported.\n\n" +
+			"Each mismatch is reported with its file and line, along with the resource type the ID belongs to " +
+			"when it is found under another type, instead of the \"not found\" error terraform plan would " +
+			"produce. The command exits non-zero when any import block is wrong. Blocks whose ID is not a " +
+			"literal string, that use for_each, or whose type isn't supported are skipped with a warning.",
+		Args: cobra.NoArgs,
+		RunE: runVerifyImports,
+	}
+	cmd.Flags().String("chdir", ".", "Terraform configuration directory")
+	return cmd
+}
+
+func runVerifyImports(cmd *cobra.Command, args []string) error {
+	dir, _ := cmd.Flags().GetString("chdir")
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return err
+	}
+	var blocks []imports.Block
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fileBlocks, err := imports.Parse(src, path)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, fileBlocks...)
+	}
+	if len(blocks) == 0 {
+		fmt.Fprintf(os.Stderr, "No import blocks found in %s.\n", dir)
+		return nil
+	}
+
+	ctx := context.Background()
+
+	cfg := provider.ClientConfigFromEnv()
+	client, err := provider.NewClient(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("connecting to UniFi controller: %w", err)
+	}
+
+	checked, problems := 0, 0
+	for _, b := range blocks {
+		if !strings.HasPrefix(b.Type, "terrifi_") {
+			continue
+		}
+		pos := fmt.Sprintf("%s:%d", b.Range.Filename, b.Range.Start.Line)
+		lookup, ok := importLookups[b.Type]
+		switch {
+		case b.ID == "":
+			fmt.Fprintf(os.Stderr, "%s: %s: skipped, id is not a literal string\n", pos, b.Address())
+			continue
+		case !ok:
+			fmt.Fprintf(os.Stderr, "%s: %s: skipped, %s is not supported\n", pos, b.Address(), b.Type)
+			continue
+		}
+
+		checked++
+		err := lookup(ctx, client, cfg.Site, b.ID)
+		if err == nil {
+			continue
+		}
+		problems++
+		var notFound *unifi.NotFoundError
+		var wrongType importTypeError
+		switch {
+		case errors.As(err, &wrongType):
+			fmt.Printf("%s: %s: %s\n", pos, b.Address(), wrongType)
+			continue
+		case !errors.As(err, &notFound):
+			fmt.Printf("%s: %s: could not check id %q: %s\n", pos, b.Address(), b.ID, err)
+			continue
+		}
+		msg := fmt.Sprintf("%s: %s: no %s with id %q", pos, b.Address(), b.Type, b.ID)
+		if owner := importIDOwner(ctx, client, cfg.Site, b.ID, b.Type); owner != "" {
+			msg += fmt.Sprintf(" (the id belongs to a %s)", owner)
+		}
+		fmt.Println(msg)
+	}
+
+	fmt.Fprintf(os.Stderr, "Checked %d import block(s): %d problem(s).\n", checked, problems)
+	if problems > 0 {
+		return fmt.Errorf("%d import block(s) do not match the controller", problems)
+	}
+	return nil
+}
+
+// importLookup checks that id, in the resource type's import ID format,
+// refers to an existing object of that type. It returns *unifi.NotFoundError
+// when there is no such object, and an importTypeError when the object exists
+// but belongs to another resource type.
+type importLookup func(ctx context.Context, client *provider.Client, site, id string) error
+
+// importTypeError describes an import ID that refers to an object of the
+// wrong resource type.
+type importTypeError string
+
+func (e importTypeError) Error() string { return string(e) }
+
+// importLookups holds a lookup for every resource type that verify-imports
+// supports.
+var importLookups = map[string]importLookup{
+	"terrifi_admin": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetAdmin(ctx, site, id)
+		return err
+	},
+	"terrifi_ap_group": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetAPGroup(ctx, site, id)
+		return err
+	},
+	"terrifi_client_device": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetClientDevice(ctx, site, id)
+		return err
+	},
+	"terrifi_client_group": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		group, err := client.GetNetworkMembersGroup(ctx, site, id)
+		if err != nil {
+			return err
+		}
+		if group.Type != "CLIENTS" {
+			return importTypeError(fmt.Sprintf("%q is a %s group, not a client group", id, group.Type))
+		}
+		return nil
+	},
+	"terrifi_client_identity": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, mac := splitDeviceImportID(site, id)
+		_, err := client.GetClientFingerprint(ctx, site, mac)
+		return err
+	},
+	"terrifi_device": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, mac := splitDeviceImportID(site, id)
+		_, err := client.GetDeviceByMAC(ctx, site, mac)
+		return err
+	},
+	"terrifi_dhcp_option": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetDHCPOption(ctx, site, id)
+		return err
+	},
+	"terrifi_dns_forwarding": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetDNSRecord(ctx, site, id)
+		return err
+	},
+	"terrifi_dns_record": func(ctx context.Context, client *provider.Client, site, id string) error {
+		// Records with several values are imported as a comma-separated list
+		// of record IDs.
+		site, id = splitImportID(site, id)
+		for _, recordID := range strings.Split(id, ",") {
+			if _, err := client.GetDNSRecord(ctx, site, recordID); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	"terrifi_dpi_restriction": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetDpiApp(ctx, site, id)
+		return err
+	},
+	"terrifi_firewall_group": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetFirewallGroup(ctx, site, id)
+		return err
+	},
+	"terrifi_firewall_policy": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetFirewallPolicy(ctx, site, id)
+		return err
+	},
+	"terrifi_firewall_policy_order": func(ctx context.Context, client *provider.Client, site, id string) error {
+		parts := strings.Split(id, ":")
+		if len(parts) == 3 {
+			site, parts = parts[0], parts[1:]
+		}
+		if len(parts) != 2 {
+			return fmt.Errorf("expected source_zone_id:destination_zone_id or site:source_zone_id:destination_zone_id, got %q", id)
+		}
+		for _, zoneID := range parts {
+			if _, err := client.GetFirewallZone(ctx, site, zoneID); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	"terrifi_firewall_zone": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetFirewallZone(ctx, site, id)
+		return err
+	},
+	"terrifi_nat_rule": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetNat(ctx, site, id)
+		return err
+	},
+	"terrifi_network": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		network, err := client.GetNetwork(ctx, site, id)
+		if err != nil {
+			return err
+		}
+		switch network.Purpose {
+		case unifi.PurposeWAN:
+			return importTypeError(fmt.Sprintf("%q is a WAN network; import it as terrifi_wan", id))
+		case unifi.PurposeSiteVPN:
+			return importTypeError(fmt.Sprintf("%q is a site-to-site VPN; import it as terrifi_site_vpn", id))
+		case unifi.PurposeUserVPN:
+			return importTypeError(fmt.Sprintf("%q is a VPN server; import it as terrifi_vpn_server", id))
+		}
+		return nil
+	},
+	"terrifi_port_forward": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetPortForward(ctx, site, id)
+		return err
+	},
+	"terrifi_port_profile": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetPortProfile(ctx, site, id)
+		return err
+	},
+	"terrifi_radius_user": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetAccount(ctx, site, id)
+		return err
+	},
+	"terrifi_setting_connectivity": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingConnectivity(ctx, site)
+		return err
+	},
+	"terrifi_setting_country": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingCountry(ctx, site)
+		return err
+	},
+	"terrifi_setting_global_switch": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingGlobalSwitch(ctx, site)
+		return err
+	},
+	"terrifi_setting_guest_access": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingGuestAccess(ctx, site)
+		return err
+	},
+	"terrifi_setting_ips": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingIps(ctx, site)
+		return err
+	},
+	"terrifi_setting_locale": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingLocale(ctx, site)
+		return err
+	},
+	"terrifi_setting_radius": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingRadius(ctx, site)
+		return err
+	},
+	"terrifi_setting_rsyslog": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingRsyslogd(ctx, site)
+		return err
+	},
+	"terrifi_setting_teleport": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingTeleport(ctx, site)
+		return err
+	},
+	"terrifi_setting_usg": func(ctx context.Context, client *provider.Client, _, site string) error {
+		_, err := client.GetSettingUsg(ctx, site)
+		return err
+	},
+	"terrifi_site_vpn": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		network, err := client.GetNetwork(ctx, site, id)
+		if err != nil {
+			return err
+		}
+		if network.Purpose != unifi.PurposeSiteVPN {
+			return importTypeError(fmt.Sprintf("%q is a %s network, not a site-to-site VPN", id, network.Purpose))
+		}
+		return nil
+	},
+	"terrifi_traffic_rule": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetTrafficRule(ctx, site, id)
+		return err
+	},
+	"terrifi_traffic_route": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetTrafficRoute(ctx, site, id)
+		return err
+	},
+	"terrifi_user_group": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetClientGroup(ctx, site, id)
+		return err
+	},
+	"terrifi_vpn_server": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		network, err := client.GetNetwork(ctx, site, id)
+		if err != nil {
+			return err
+		}
+		if network.Purpose != unifi.PurposeUserVPN {
+			return importTypeError(fmt.Sprintf("%q is a %s network, not a VPN server", id, network.Purpose))
+		}
+		return nil
+	},
+	"terrifi_wan": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		network, err := client.GetNetwork(ctx, site, id)
+		if err != nil {
+			return err
+		}
+		if network.Purpose != unifi.PurposeWAN {
+			return importTypeError(fmt.Sprintf("%q is a %s network, not a WAN; import it as terrifi_network", id, network.Purpose))
+		}
+		return nil
+	},
+	"terrifi_wlan": func(ctx context.Context, client *provider.Client, site, id string) error {
+		site, id = splitImportID(site, id)
+		_, err := client.GetWLAN(ctx, site, id)
+		return err
+	},
+}
+
+// importIDOwner returns the resource type, other than exclude, that has an
+// object with the given ID, or "" when there is none. Only types imported by
+// object ID are searched; lookup errors are ignored.
+func importIDOwner(ctx context.Context, client *provider.Client, site, id, exclude string) string {
+	var candidates []string
+	for resourceType := range importLookups {
+		switch {
+		case resourceType == exclude,
+			resourceType == "terrifi_device",
+			resourceType == "terrifi_client_identity",
+			resourceType == "terrifi_firewall_policy_order",
+			strings.HasPrefix(resourceType, "terrifi_setting_"):
+			continue
+		}
+		candidates = append(candidates, resourceType)
+	}
+	sort.Strings(candidates)
+
+	for _, resourceType := range candidates {
+		if importLookups[resourceType](ctx, client, site, id) == nil {
+			return resourceType
+		}
+	}
+	return ""
+}
+
+// splitImportID splits a "site:id" import ID, returning defaultSite for an ID
+// without a site.
+func splitImportID(defaultSite, importID string) (site, id string) {
+	if site, id, ok := strings.Cut(importID, ":"); ok {
+		return site, id
+	}
+	return defaultSite, importID
+}
+
+// macRegexp matches a colon-separated MAC address.
+var macRegexp = regexp.MustCompile(`^([0-9A-Fa-f]{2}:){5}[0-9A-Fa-f]{2}$`)
+
+// splitDeviceImportID splits a terrifi_device import ID, which is a MAC
+// address or "site:mac". Since MACs contain colons, the whole ID is checked
+// for a MAC first.
+func splitDeviceImportID(defaultSite, importID string) (site, mac string) {
+	if macRegexp.MatchString(importID) {
+		return defaultSite, strings.ToLower(importID)
+	}
+	if i := strings.Index(importID, ":"); i > 0 {
+		return importID[:i], strings.ToLower(importID[i+1:])
+	}
+	return defaultSite, strings.ToLower(importID)
+}
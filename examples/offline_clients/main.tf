@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    terrifi = {
+      source = "alexklibisz/terrifi"
+    }
+  }
+}
+
+provider "terrifi" {}
+
+# Named clients that have not been seen for a week.
+data "terrifi_offline_clients" "stale" {
+  since = "168h"
+}
+
+output "stale_clients" {
+  value = {
+    for c in data.terrifi_offline_clients.stale.clients : c.mac => {
+      name      = c.name
+      last_seen = c.last_seen
+    }
+  }
+}
@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    terrifi = {
+      source = "alexklibisz/terrifi"
+    }
+  }
+}
+
+provider "terrifi" {}
+
+# Create the networks the profile carries.
+resource "terrifi_network" "office" {
+  name    = "Office"
+  purpose = "corporate"
+  vlan_id = 20
+  subnet  = "192.168.20.1/24"
+}
+
+resource "terrifi_network" "voice" {
+  name    = "Voice"
+  purpose = "corporate"
+  vlan_id = 21
+  subnet  = "192.168.21.1/24"
+}
+
+# Put untagged traffic on the office network, offer the voice network to
+# phones, and carry no other VLANs.
+resource "terrifi_port_profile" "desk" {
+  name              = "Desk"
+  native_network_id = terrifi_network.office.id
+  voice_network_id  = terrifi_network.voice.id
+  tagged_vlan_mgmt  = "block_all"
+}
+
+# Authenticate each device by its MAC address and put it on the VLAN its
+# RADIUS user returns. Requires 802.1X control to be enabled in the site's
+# switch settings.
+resource "terrifi_radius_user" "printer" {
+  name     = "aabbccddeeff"
+  password = "aabbccddeeff"
+  vlan     = 20
+}
+
+resource "terrifi_port_profile" "mac_vlans" {
+  name              = "MAC-based VLANs"
+  native_network_id = terrifi_network.office.id
+  dot1x_ctrl        = "mac_based"
+}
+
+output "port_profile_id" {
+  value = terrifi_port_profile.desk.id
+}
@@ -0,0 +1,19 @@
+terraform {
+  required_providers {
+    terrifi = {
+      source = "alexklibisz/terrifi"
+    }
+  }
+}
+
+provider "terrifi" {}
+
+# Set the site's regulatory domain. This controls which channels and
+# transmit powers the access points are allowed to use.
+resource "terrifi_setting_country" "this" {
+  code = 840 # United States (ISO 3166-1 numeric)
+}
+
+output "country_code" {
+  value = terrifi_setting_country.this.code
+}
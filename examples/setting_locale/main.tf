@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    terrifi = {
+      source = "alexklibisz/terrifi"
+    }
+  }
+}
+
+provider "terrifi" {}
+
+# Set the site's timezone, used for schedules and log timestamps.
+resource "terrifi_setting_locale" "this" {
+  timezone = "America/New_York"
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    terrifi = {
+      source = "alexklibisz/terrifi"
+    }
+  }
+}
+
+provider "terrifi" {}
+
+variable "radius_secret" {
+  type      = string
+  sensitive = true
+}
+
+# Enable the built-in RADIUS server for WPA-Enterprise WLANs, with tunneled
+# replies so PEAP/TTLS clients can be assigned VLANs dynamically.
+resource "terrifi_setting_radius" "this" {
+  enabled            = true
+  secret             = var.radius_secret
+  accounting_enabled = true
+  tunneled_reply     = true
+}
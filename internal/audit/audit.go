@@ -0,0 +1,118 @@
+// Package audit assembles the security-relevant configuration of a UniFi
+// site — firewall zones and policies, port forwards, UPnP and cloud access —
+// into a normalized JSON report for compliance tooling. It is used by the
+// terrifi CLI's audit command.
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+	"sort"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// Report is the audit output. Objects are the controller's own response
+// structs, so field names match the UniFi API. Lists are sorted so that two
+// reports of an unchanged site are identical, and are never null.
+type Report struct {
+	Site             string                  `json:"site"`
+	FirewallZones    []unifi.FirewallZone    `json:"firewall_zones"`
+	FirewallPolicies []*unifi.FirewallPolicy `json:"firewall_policies"`
+	PortForwards     []unifi.PortForward     `json:"port_forwards"`
+	UPnP             *UPnP                   `json:"upnp"`
+	CloudAccess      *CloudAccess            `json:"cloud_access"`
+}
+
+// UPnP is the gateway's UPnP / NAT-PMP configuration, from the usg setting.
+type UPnP struct {
+	Enabled       bool   `json:"enabled"`
+	NATPMPEnabled bool   `json:"nat_pmp_enabled"`
+	SecureMode    bool   `json:"secure_mode"`
+	WANInterface  string `json:"wan_interface"`
+}
+
+// CloudAccess is the controller's remote access (UniFi cloud) setting. Its
+// device credentials and certificates are deliberately left out.
+type CloudAccess struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Build assembles a Report from API data. usg and cloudAccess may be nil when
+// the controller doesn't have the setting, in which case the corresponding
+// section is null. Zones are sorted by name, policies by index, and port
+// forwards by name; ties are broken by ID. The input slices are not modified.
+func Build(
+	site string,
+	zones []unifi.FirewallZone,
+	policies []*unifi.FirewallPolicy,
+	portForwards []unifi.PortForward,
+	usg *settings.Usg,
+	cloudAccess *settings.SuperCloudaccess,
+) Report {
+	r := Report{
+		Site:             site,
+		FirewallZones:    make([]unifi.FirewallZone, 0, len(zones)),
+		FirewallPolicies: make([]*unifi.FirewallPolicy, 0, len(policies)),
+		PortForwards:     append(make([]unifi.PortForward, 0, len(portForwards)), portForwards...),
+	}
+
+	for _, z := range zones {
+		z.NetworkIDs = slices.Clone(z.NetworkIDs)
+		slices.Sort(z.NetworkIDs)
+		r.FirewallZones = append(r.FirewallZones, z)
+	}
+	sort.Slice(r.FirewallZones, func(i, j int) bool {
+		a, b := r.FirewallZones[i], r.FirewallZones[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	r.FirewallPolicies = append(r.FirewallPolicies, policies...)
+	sort.Slice(r.FirewallPolicies, func(i, j int) bool {
+		a, b := r.FirewallPolicies[i], r.FirewallPolicies[j]
+		if policyIndex(a) != policyIndex(b) {
+			return policyIndex(a) < policyIndex(b)
+		}
+		return a.ID < b.ID
+	})
+
+	sort.Slice(r.PortForwards, func(i, j int) bool {
+		a, b := r.PortForwards[i], r.PortForwards[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	if usg != nil {
+		r.UPnP = &UPnP{
+			Enabled:       usg.UPnPEnabled,
+			NATPMPEnabled: usg.UPnPNATPmpEnabled,
+			SecureMode:    usg.UPnPSecureMode,
+			WANInterface:  usg.UPnPWANInterface,
+		}
+	}
+	if cloudAccess != nil {
+		r.CloudAccess = &CloudAccess{Enabled: cloudAccess.Enabled}
+	}
+	return r
+}
+
+func policyIndex(p *unifi.FirewallPolicy) int64 {
+	if p.Index == nil {
+		return 0
+	}
+	return *p.Index
+}
+
+// Write renders r as indented JSON followed by a newline.
+func Write(w io.Writer, r Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
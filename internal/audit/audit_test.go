@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func TestBuild(t *testing.T) {
+	zones := []unifi.FirewallZone{
+		{ID: "z2", Name: "Internal", NetworkIDs: []string{"nmgmt", "nlan"}},
+		{ID: "z1", Name: "External"},
+		{ID: "z0", Name: "Internal"},
+	}
+	policies := []*unifi.FirewallPolicy{
+		{ID: "p2", Name: "Block IoT", Index: int64Ptr(10002)},
+		{ID: "p3", Name: "Built-in"},
+		{ID: "p1", Name: "Allow LAN", Index: int64Ptr(10001)},
+	}
+	portForwards := []unifi.PortForward{
+		{ID: "f2", Name: "SSH"},
+		{ID: "f1", Name: "HTTPS"},
+	}
+	usg := &settings.Usg{UPnPEnabled: true, UPnPSecureMode: true, UPnPWANInterface: "wan"}
+	cloudAccess := &settings.SuperCloudaccess{Enabled: true, XPrivateKey: "secret", DeviceAuth: "token"}
+
+	r := Build("default", zones, policies, portForwards, usg, cloudAccess)
+
+	assert.Equal(t, "default", r.Site)
+
+	var zoneIDs []string
+	for _, z := range r.FirewallZones {
+		zoneIDs = append(zoneIDs, z.ID)
+	}
+	assert.Equal(t, []string{"z1", "z0", "z2"}, zoneIDs, "sorted by name, then ID")
+	assert.Equal(t, []string{"nlan", "nmgmt"}, r.FirewallZones[2].NetworkIDs)
+	assert.Equal(t, []string{"nmgmt", "nlan"}, zones[0].NetworkIDs, "input is not modified")
+
+	var policyIDs []string
+	for _, p := range r.FirewallPolicies {
+		policyIDs = append(policyIDs, p.ID)
+	}
+	assert.Equal(t, []string{"p3", "p1", "p2"}, policyIDs, "sorted by index")
+	assert.Equal(t, "p2", policies[0].ID, "input is not modified")
+
+	require.Len(t, r.PortForwards, 2)
+	assert.Equal(t, "HTTPS", r.PortForwards[0].Name)
+	assert.Equal(t, "SSH", r.PortForwards[1].Name)
+
+	require.NotNil(t, r.UPnP)
+	assert.Equal(t, UPnP{Enabled: true, SecureMode: true, WANInterface: "wan"}, *r.UPnP)
+	require.NotNil(t, r.CloudAccess)
+	assert.True(t, r.CloudAccess.Enabled)
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("empty site", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, Build("default", nil, nil, nil, nil, nil)))
+
+		var got map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, []any{}, got["firewall_zones"], "lists are never null")
+		assert.Equal(t, []any{}, got["firewall_policies"])
+		assert.Equal(t, []any{}, got["port_forwards"])
+		assert.Nil(t, got["upnp"])
+		assert.Nil(t, got["cloud_access"])
+	})
+
+	t.Run("secrets are omitted", func(t *testing.T) {
+		cloudAccess := &settings.SuperCloudaccess{
+			Enabled:         true,
+			DeviceAuth:      "device-auth",
+			XCertificatePem: "certificate",
+			XPrivateKey:     "private-key",
+		}
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, Build("default", nil, nil, nil, nil, cloudAccess)))
+
+		assert.Contains(t, buf.String(), `"enabled": true`)
+		assert.NotContains(t, buf.String(), "device-auth")
+		assert.NotContains(t, buf.String(), "certificate")
+		assert.NotContains(t, buf.String(), "private-key")
+	})
+}
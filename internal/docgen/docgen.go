@@ -0,0 +1,229 @@
+// Package docgen renders the Schema section of the provider's registry docs
+// from the resource and data source schemas. It is used by tools/gen-docs so
+// that the documented attributes, their types, defaults and valid values come
+// from the schema rather than being copied by hand.
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Marker follows the "## Schema" heading of docs whose Schema section is
+// generated. Docs without it are written by hand and only checked for
+// undocumented attributes.
+const Marker = "<!-- schema generated by gen-docs; edit the schema's MarkdownDescription instead -->"
+
+// Doc is the documentation of one resource or data source.
+type Doc struct {
+	// Name is the type name, e.g. terrifi_network.
+	Name string
+
+	// Kind is "Resource" or "Data Source".
+	Kind string
+
+	Description string
+	Attributes  []Attribute
+}
+
+// Attribute is one attribute or block of a schema.
+type Attribute struct {
+	Name        string
+	Type        string
+	Description string
+
+	Required  bool
+	Optional  bool
+	Computed  bool
+	Sensitive bool
+
+	// Default is the schema default, formatted for display, or empty.
+	Default string
+
+	// Enum holds the values of a OneOf validator, if the attribute has one.
+	Enum []string
+
+	// ForcesNew reports whether changing the attribute replaces the resource.
+	ForcesNew bool
+
+	// Nested holds the attributes of a nested attribute or block.
+	Nested []Attribute
+}
+
+// Render returns a new doc page for d: the front matter, the schema's
+// description and a generated Schema section. Examples and import
+// instructions are added by hand.
+func (d Doc) Render() string {
+	var b strings.Builder
+	summary := firstSentence(d.Description)
+	fmt.Fprintf(&b, "---\npage_title: %q\nsubcategory: \"\"\ndescription: |-\n  %s\n---\n\n",
+		d.Name+" "+d.Kind+" - Terrifi", summary)
+	fmt.Fprintf(&b, "# %s (%s)\n\n%s\n\n", d.Name, d.Kind, d.Description)
+	b.WriteString(d.RenderSchema())
+	return b.String()
+}
+
+// RenderSchema returns the Schema section of d's doc page, from the "## Schema"
+// heading up to but not including the next section.
+func (d Doc) RenderSchema() string {
+	var b strings.Builder
+	b.WriteString("## Schema\n\n" + Marker + "\n")
+
+	required, optional, readOnly := partition(d.Attributes)
+	writeGroup(&b, "### Required", required, "")
+	writeGroup(&b, "### Optional", optional, "")
+	writeGroup(&b, "### Read-Only", readOnly, "")
+
+	var nested func(prefix string, attrs []Attribute)
+	nested = func(prefix string, attrs []Attribute) {
+		for _, a := range attrs {
+			if len(a.Nested) == 0 {
+				continue
+			}
+			path := prefix + a.Name
+			fmt.Fprintf(&b, "\n### Nested Schema for `%s`\n", path)
+			required, optional, readOnly := partition(a.Nested)
+			writeGroup(&b, "Required:", required, path+".")
+			writeGroup(&b, "Optional:", optional, path+".")
+			writeGroup(&b, "Read-Only:", readOnly, path+".")
+			nested(path+".", a.Nested)
+		}
+	}
+	nested("", d.Attributes)
+	return b.String()
+}
+
+// Update replaces the Schema section of a generated doc page with d's. It
+// reports false, and returns page unchanged, when the page has no Marker.
+func Update(page []byte, d Doc) ([]byte, bool) {
+	start := bytes.Index(page, []byte("## Schema\n"))
+	if start < 0 || !bytes.Contains(page[start:], []byte(Marker)) {
+		return page, false
+	}
+	end := len(page)
+	if i := bytes.Index(page[start+1:], []byte("\n## ")); i >= 0 {
+		end = start + 1 + i + 1
+	}
+
+	var out bytes.Buffer
+	out.Write(page[:start])
+	out.WriteString(d.RenderSchema())
+	if end < len(page) {
+		out.WriteString("\n")
+		out.Write(page[end:])
+	}
+	return out.Bytes(), true
+}
+
+// Undocumented returns the paths of d's attributes, e.g. nat_outbound or
+// nat_outbound.ip_address, that a hand-written doc page doesn't mention as
+// `name`. The id attribute is not required to be documented.
+func Undocumented(page []byte, d Doc) []string {
+	var missing []string
+	var walk func(prefix string, attrs []Attribute)
+	walk = func(prefix string, attrs []Attribute) {
+		for _, a := range attrs {
+			if prefix == "" && a.Name == "id" {
+				continue
+			}
+			if !bytes.Contains(page, []byte("`"+a.Name+"`")) {
+				missing = append(missing, prefix+a.Name)
+			}
+			walk(prefix+a.Name+".", a.Nested)
+		}
+	}
+	walk("", d.Attributes)
+	return missing
+}
+
+// partition splits attributes into the doc's Required, Optional and Read-Only
+// groups, each sorted by name.
+func partition(attrs []Attribute) (required, optional, readOnly []Attribute) {
+	for _, a := range attrs {
+		switch {
+		case a.Required:
+			required = append(required, a)
+		case a.Optional:
+			optional = append(optional, a)
+		default:
+			readOnly = append(readOnly, a)
+		}
+	}
+	for _, group := range [][]Attribute{required, optional, readOnly} {
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+	}
+	return required, optional, readOnly
+}
+
+func writeGroup(b *strings.Builder, heading string, attrs []Attribute, prefix string) {
+	if len(attrs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n%s\n\n", heading)
+	for _, a := range attrs {
+		fmt.Fprintf(b, "- %s\n", a.line(prefix))
+	}
+}
+
+// line renders the attribute's list item. The valid values, the default and
+// the forces-new note are appended unless the description already says so.
+func (a Attribute) line(prefix string) string {
+	typ := a.Type
+	if a.Sensitive {
+		typ += ", Sensitive"
+	}
+	parts := []string{fmt.Sprintf("`%s` (%s) —", a.Name, typ)}
+	if desc := strings.TrimSpace(a.Description); desc != "" {
+		parts = append(parts, ensurePeriod(desc))
+	}
+	if len(a.Enum) > 0 && !mentionsAll(a.Description, a.Enum) {
+		values := make([]string, len(a.Enum))
+		for i, v := range a.Enum {
+			values[i] = "`" + v + "`"
+		}
+		parts = append(parts, "Valid values: "+strings.Join(values, ", ")+".")
+	}
+	if a.Default != "" && !defaultRegexp.MatchString(a.Description) {
+		parts = append(parts, "Defaults to `"+a.Default+"`.")
+	}
+	if a.ForcesNew && !strings.Contains(a.Description, "forces a new resource") {
+		parts = append(parts, "Changing this forces a new resource.")
+	}
+	if len(a.Nested) > 0 {
+		anchor := "nested-schema-for-" + strings.ReplaceAll(prefix+a.Name, ".", "--")
+		parts = append(parts, fmt.Sprintf("See [below for nested schema](#%s).", anchor))
+	}
+	return strings.Join(parts, " ")
+}
+
+// defaultRegexp matches descriptions that already state the default.
+var defaultRegexp = regexp.MustCompile(`(?i)\bdefault(:|s to)`)
+
+// mentionsAll reports whether desc mentions every value in backticks.
+func mentionsAll(desc string, values []string) bool {
+	for _, v := range values {
+		if !strings.Contains(desc, "`"+v+"`") {
+			return false
+		}
+	}
+	return true
+}
+
+func ensurePeriod(s string) string {
+	if strings.HasSuffix(s, ".") || strings.HasSuffix(s, ")") || strings.HasSuffix(s, "`") {
+		return s
+	}
+	return s + "."
+}
+
+// firstSentence returns the first sentence of a description, for the page's
+// front matter.
+func firstSentence(s string) string {
+	if i := strings.Index(s, ". "); i >= 0 {
+		return s[:i+1]
+	}
+	return s
+}
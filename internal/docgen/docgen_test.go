@@ -0,0 +1,181 @@
+package docgen
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema() schema.Schema {
+	return schema.Schema{
+		MarkdownDescription: "Manages a widget. Widgets are great.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the widget.",
+				Computed:            true,
+			},
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site of the widget.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"color": schema.StringAttribute{
+				MarkdownDescription: "The widget's color",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("red", "blue"),
+				},
+			},
+			"days": schema.SetAttribute{
+				MarkdownDescription: "Days the widget works: `mon` or `tue`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(stringvalidator.OneOf("mon", "tue")),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the widget is enabled.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"size": schema.Int64Attribute{
+				MarkdownDescription: "The widget's size. Default: `3`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(3),
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The widget's secret.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"part": schema.ListNestedAttribute{
+				MarkdownDescription: "The widget's parts.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The part's name.",
+							Required:            true,
+						},
+						"weight": schema.Float64Attribute{
+							MarkdownDescription: "The part's weight.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResourceDoc(t *testing.T) {
+	d := ResourceDoc("terrifi_widget", testSchema())
+	assert.Equal(t, "Resource", d.Kind)
+
+	byName := map[string]Attribute{}
+	for _, a := range d.Attributes {
+		byName[a.Name] = a
+	}
+	require.Len(t, byName, 8)
+
+	assert.Equal(t, []string{"red", "blue"}, byName["color"].Enum)
+	assert.Equal(t, []string{"mon", "tue"}, byName["days"].Enum, "ValueStringsAre is unwrapped")
+	assert.Equal(t, "Set of String", byName["days"].Type)
+	assert.Equal(t, "true", byName["enabled"].Default)
+	assert.Equal(t, "3", byName["size"].Default)
+	assert.True(t, byName["site"].ForcesNew)
+	assert.False(t, byName["color"].ForcesNew)
+	assert.True(t, byName["secret"].Sensitive)
+	assert.Equal(t, "Attributes List", byName["part"].Type)
+	require.Len(t, byName["part"].Nested, 2)
+	assert.Equal(t, "Number", byName["part"].Nested[1].Type)
+}
+
+func TestRenderSchema(t *testing.T) {
+	got := ResourceDoc("terrifi_widget", testSchema()).RenderSchema()
+	assert.Equal(t, "## Schema\n\n"+Marker+`
+
+### Required
+
+- `+"`color` (String) — The widget's color. Valid values: `red`, `blue`."+`
+
+### Optional
+
+- `+"`days` (Set of String) — Days the widget works: `mon` or `tue`."+`
+- `+"`enabled` (Boolean) — Whether the widget is enabled. Defaults to `true`."+`
+- `+"`part` (Attributes List) — The widget's parts. See [below for nested schema](#nested-schema-for-part)."+`
+- `+"`secret` (String, Sensitive) — The widget's secret."+`
+- `+"`site` (String) — The site of the widget. Changing this forces a new resource."+`
+- `+"`size` (Number) — The widget's size. Default: `3`."+`
+
+### Read-Only
+
+- `+"`id` (String) — The ID of the widget."+`
+
+### Nested Schema for `+"`part`"+`
+
+Required:
+
+- `+"`name` (String) — The part's name."+`
+
+Optional:
+
+- `+"`weight` (Number) — The part's weight."+`
+`, got)
+}
+
+func TestRender(t *testing.T) {
+	got := ResourceDoc("terrifi_widget", testSchema()).Render()
+	assert.Contains(t, got, "page_title: \"terrifi_widget Resource - Terrifi\"\n")
+	assert.Contains(t, got, "description: |-\n  Manages a widget.\n---\n")
+	assert.Contains(t, got, "# terrifi_widget (Resource)\n\nManages a widget. Widgets are great.\n\n## Schema\n")
+}
+
+func TestUpdate(t *testing.T) {
+	d := ResourceDoc("terrifi_widget", testSchema())
+
+	t.Run("generated", func(t *testing.T) {
+		page := "# terrifi_widget\n\n## Schema\n\n" + Marker + "\n\n- stale\n\n## Import\n\nImport it.\n"
+		got, ok := Update([]byte(page), d)
+		require.True(t, ok)
+		assert.Equal(t, "# terrifi_widget\n\n"+d.RenderSchema()+"\n## Import\n\nImport it.\n", string(got))
+
+		again, _ := Update(got, d)
+		assert.Equal(t, string(got), string(again), "updating is idempotent")
+	})
+
+	t.Run("last section", func(t *testing.T) {
+		got, ok := Update([]byte("# terrifi_widget\n\n## Schema\n\n"+Marker+"\n- stale\n"), d)
+		require.True(t, ok)
+		assert.Equal(t, "# terrifi_widget\n\n"+d.RenderSchema(), string(got))
+	})
+
+	t.Run("hand-written", func(t *testing.T) {
+		page := "# terrifi_widget\n\n## Schema\n\n- `color`\n"
+		got, ok := Update([]byte(page), d)
+		assert.False(t, ok)
+		assert.Equal(t, page, string(got))
+	})
+}
+
+func TestUndocumented(t *testing.T) {
+	d := ResourceDoc("terrifi_widget", testSchema())
+	page := "`color` `days` `enabled` `secret` `site` `size` `part` `name`"
+	assert.Equal(t, []string{"part.weight"}, Undocumented([]byte(page), d))
+}
@@ -0,0 +1,307 @@
+package docgen
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// ResourceDoc converts a resource schema to a Doc.
+func ResourceDoc(name string, s rschema.Schema) Doc {
+	return Doc{
+		Name:        name,
+		Kind:        "Resource",
+		Description: s.MarkdownDescription,
+		Attributes:  sorted(append(resourceAttributes(s.Attributes), resourceBlocks(s.Blocks)...)),
+	}
+}
+
+// DataSourceDoc converts a data source schema to a Doc.
+func DataSourceDoc(name string, s dschema.Schema) Doc {
+	return Doc{
+		Name:        name,
+		Kind:        "Data Source",
+		Description: s.MarkdownDescription,
+		Attributes:  sorted(append(dataSourceAttributes(s.Attributes), dataSourceBlocks(s.Blocks)...)),
+	}
+}
+
+func resourceAttributes(attrs map[string]rschema.Attribute) []Attribute {
+	var out []Attribute
+	for name, a := range attrs {
+		typ, nested := "", []Attribute(nil)
+		switch a := a.(type) {
+		case rschema.ListNestedAttribute:
+			typ, nested = "Attributes List", resourceAttributes(a.NestedObject.Attributes)
+		case rschema.SetNestedAttribute:
+			typ, nested = "Attributes Set", resourceAttributes(a.NestedObject.Attributes)
+		case rschema.MapNestedAttribute:
+			typ, nested = "Attributes Map", resourceAttributes(a.NestedObject.Attributes)
+		case rschema.SingleNestedAttribute:
+			typ, nested = "Attributes", resourceAttributes(a.Attributes)
+		}
+		out = append(out, attribute(name, a, typ, nested))
+	}
+	return sorted(out)
+}
+
+func resourceBlocks(blocks map[string]rschema.Block) []Attribute {
+	var out []Attribute
+	for name, b := range blocks {
+		var typ string
+		var nested []Attribute
+		switch b := b.(type) {
+		case rschema.ListNestedBlock:
+			typ = "Block List"
+			nested = append(resourceAttributes(b.NestedObject.Attributes), resourceBlocks(b.NestedObject.Blocks)...)
+		case rschema.SetNestedBlock:
+			typ = "Block Set"
+			nested = append(resourceAttributes(b.NestedObject.Attributes), resourceBlocks(b.NestedObject.Blocks)...)
+		case rschema.SingleNestedBlock:
+			typ = "Block"
+			nested = append(resourceAttributes(b.Attributes), resourceBlocks(b.Blocks)...)
+		}
+		out = append(out, block(name, b, typ, nested))
+	}
+	return sorted(out)
+}
+
+func dataSourceAttributes(attrs map[string]dschema.Attribute) []Attribute {
+	var out []Attribute
+	for name, a := range attrs {
+		typ, nested := "", []Attribute(nil)
+		switch a := a.(type) {
+		case dschema.ListNestedAttribute:
+			typ, nested = "Attributes List", dataSourceAttributes(a.NestedObject.Attributes)
+		case dschema.SetNestedAttribute:
+			typ, nested = "Attributes Set", dataSourceAttributes(a.NestedObject.Attributes)
+		case dschema.MapNestedAttribute:
+			typ, nested = "Attributes Map", dataSourceAttributes(a.NestedObject.Attributes)
+		case dschema.SingleNestedAttribute:
+			typ, nested = "Attributes", dataSourceAttributes(a.Attributes)
+		}
+		out = append(out, attribute(name, a, typ, nested))
+	}
+	return sorted(out)
+}
+
+func dataSourceBlocks(blocks map[string]dschema.Block) []Attribute {
+	var out []Attribute
+	for name, b := range blocks {
+		var typ string
+		var nested []Attribute
+		switch b := b.(type) {
+		case dschema.ListNestedBlock:
+			typ = "Block List"
+			nested = append(dataSourceAttributes(b.NestedObject.Attributes), dataSourceBlocks(b.NestedObject.Blocks)...)
+		case dschema.SetNestedBlock:
+			typ = "Block Set"
+			nested = append(dataSourceAttributes(b.NestedObject.Attributes), dataSourceBlocks(b.NestedObject.Blocks)...)
+		case dschema.SingleNestedBlock:
+			typ = "Block"
+			nested = append(dataSourceAttributes(b.Attributes), dataSourceBlocks(b.Blocks)...)
+		}
+		out = append(out, block(name, b, typ, nested))
+	}
+	return sorted(out)
+}
+
+// schemaAttribute is implemented by the attributes of both resource and data
+// source schemas.
+type schemaAttribute interface {
+	GetType() attr.Type
+	GetMarkdownDescription() string
+	IsRequired() bool
+	IsOptional() bool
+	IsComputed() bool
+	IsSensitive() bool
+}
+
+// attribute converts a schema attribute. typ overrides the type derived from
+// the attribute's value type, for nested attributes.
+func attribute(name string, a schemaAttribute, typ string, nested []Attribute) Attribute {
+	if typ == "" {
+		typ = typeName(a.GetType())
+	}
+	return Attribute{
+		Name:        name,
+		Type:        typ,
+		Description: a.GetMarkdownDescription(),
+		Required:    a.IsRequired(),
+		Optional:    a.IsOptional(),
+		Computed:    a.IsComputed(),
+		Sensitive:   a.IsSensitive(),
+		Default:     defaultValue(a),
+		Enum:        enumValues(validatorDescriptions(a)),
+		ForcesNew:   forcesNew(a),
+		Nested:      sorted(nested),
+	}
+}
+
+// block converts a schema block. Blocks are always optional.
+func block(name string, b interface{ GetMarkdownDescription() string }, typ string, nested []Attribute) Attribute {
+	return Attribute{
+		Name:        name,
+		Type:        typ,
+		Description: b.GetMarkdownDescription(),
+		Optional:    true,
+		ForcesNew:   forcesNew(b),
+		Nested:      sorted(nested),
+	}
+}
+
+// typeName returns the registry docs' name for a value type, e.g. "Set of
+// String".
+func typeName(t attr.Type) string {
+	switch t := t.(type) {
+	case basetypes.StringType:
+		return "String"
+	case basetypes.BoolType:
+		return "Boolean"
+	case basetypes.Int64Type, basetypes.Int32Type, basetypes.Float64Type, basetypes.Float32Type, basetypes.NumberType:
+		return "Number"
+	case basetypes.ListType:
+		return "List of " + typeName(t.ElemType)
+	case basetypes.SetType:
+		return "Set of " + typeName(t.ElemType)
+	case basetypes.MapType:
+		return "Map of " + typeName(t.ElemType)
+	case basetypes.ObjectType:
+		return "Object"
+	}
+	return t.String()
+}
+
+// defaultValue returns a resource attribute's static default, formatted for
+// display, or "" if it has none.
+func defaultValue(a any) string {
+	ctx := context.Background()
+	switch a := a.(type) {
+	case interface{ StringDefaultValue() defaults.String }:
+		if d := a.StringDefaultValue(); d != nil {
+			var resp defaults.StringResponse
+			d.DefaultString(ctx, defaults.StringRequest{}, &resp)
+			return resp.PlanValue.ValueString()
+		}
+	case interface{ BoolDefaultValue() defaults.Bool }:
+		if d := a.BoolDefaultValue(); d != nil {
+			var resp defaults.BoolResponse
+			d.DefaultBool(ctx, defaults.BoolRequest{}, &resp)
+			return strconv.FormatBool(resp.PlanValue.ValueBool())
+		}
+	case interface{ Int64DefaultValue() defaults.Int64 }:
+		if d := a.Int64DefaultValue(); d != nil {
+			var resp defaults.Int64Response
+			d.DefaultInt64(ctx, defaults.Int64Request{}, &resp)
+			return strconv.FormatInt(resp.PlanValue.ValueInt64(), 10)
+		}
+	case interface{ Float64DefaultValue() defaults.Float64 }:
+		if d := a.Float64DefaultValue(); d != nil {
+			var resp defaults.Float64Response
+			d.DefaultFloat64(ctx, defaults.Float64Request{}, &resp)
+			return strconv.FormatFloat(resp.PlanValue.ValueFloat64(), 'g', -1, 64)
+		}
+	}
+	return ""
+}
+
+// describer is implemented by validators and plan modifiers.
+type describer interface {
+	MarkdownDescription(context.Context) string
+}
+
+// validatorDescriptions returns the descriptions of an attribute's
+// validators.
+func validatorDescriptions(a any) []string {
+	switch a := a.(type) {
+	case interface{ StringValidators() []validator.String }:
+		return descriptions(a.StringValidators())
+	case interface{ Int64Validators() []validator.Int64 }:
+		return descriptions(a.Int64Validators())
+	case interface{ SetValidators() []validator.Set }:
+		return descriptions(a.SetValidators())
+	case interface{ ListValidators() []validator.List }:
+		return descriptions(a.ListValidators())
+	}
+	return nil
+}
+
+// forcesNew reports whether any of an attribute's plan modifiers replaces the
+// resource when the attribute changes.
+func forcesNew(a any) bool {
+	var descs []string
+	switch a := a.(type) {
+	case interface{ StringPlanModifiers() []planmodifier.String }:
+		descs = descriptions(a.StringPlanModifiers())
+	case interface{ BoolPlanModifiers() []planmodifier.Bool }:
+		descs = descriptions(a.BoolPlanModifiers())
+	case interface{ Int64PlanModifiers() []planmodifier.Int64 }:
+		descs = descriptions(a.Int64PlanModifiers())
+	case interface{ Float64PlanModifiers() []planmodifier.Float64 }:
+		descs = descriptions(a.Float64PlanModifiers())
+	case interface{ SetPlanModifiers() []planmodifier.Set }:
+		descs = descriptions(a.SetPlanModifiers())
+	case interface{ ListPlanModifiers() []planmodifier.List }:
+		descs = descriptions(a.ListPlanModifiers())
+	case interface{ ObjectPlanModifiers() []planmodifier.Object }:
+		descs = descriptions(a.ObjectPlanModifiers())
+	}
+	for _, d := range descs {
+		if strings.Contains(d, "destroy and recreate") {
+			return true
+		}
+	}
+	return false
+}
+
+func descriptions[T describer](items []T) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[i] = item.MarkdownDescription(context.Background())
+	}
+	return out
+}
+
+var (
+	// oneOfRegexp matches the description of stringvalidator.OneOf, also
+	// when wrapped by ValueStringsAre, e.g. `value must be one of: ["a" "b"]`.
+	oneOfRegexp = regexp.MustCompile(`value must be one of: \[(.*?)\]`)
+
+	quotedRegexp = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// enumValues returns the values of the first OneOf validator among the
+// validator descriptions.
+func enumValues(descs []string) []string {
+	for _, d := range descs {
+		m := oneOfRegexp.FindStringSubmatch(d)
+		if m == nil {
+			continue
+		}
+		var values []string
+		for _, q := range quotedRegexp.FindAllString(m[1], -1) {
+			if v, err := strconv.Unquote(q); err == nil {
+				values = append(values, v)
+			}
+		}
+		if len(values) > 0 {
+			return values
+		}
+	}
+	return nil
+}
+
+func sorted(attrs []Attribute) []Attribute {
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+	return attrs
+}
@@ -0,0 +1,50 @@
+package generate
+
+// Admin is a controller administrator with access to a site. The provider
+// reads admins into its own type, so callers copy them into this one.
+type Admin struct {
+	ID          string
+	Name        string
+	Email       string
+	Role        string
+	Permissions []string
+	IsSuper     bool
+}
+
+// AdminBlocks generates import + resource blocks for a site's admins. Super
+// admins are managed in the UniFi UI and are skipped. The controller never
+// reports passwords, so a password placeholder is only written for admins
+// without an email address, which need one of the two.
+func AdminBlocks(admins []Admin) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(admins))
+	for _, a := range admins {
+		if a.IsSuper {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_admin",
+			ResourceName: ToTerraformName(a.Name),
+			ImportID:     a.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(a.Name)})
+		if a.Email != "" {
+			block.Attributes = append(block.Attributes, Attr{Key: "email", Value: HCLString(a.Email)})
+		} else {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "password",
+				Value:   HCLString("REPLACE_ME"),
+				Comment: "SENSITIVE: not reported by the controller; applying sets the admin's password to this value",
+			})
+		}
+		block.Attributes = append(block.Attributes, Attr{Key: "role", Value: HCLString(a.Role)})
+		if len(a.Permissions) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "permissions", Value: HCLStringList(a.Permissions)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
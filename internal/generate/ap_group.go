@@ -0,0 +1,41 @@
+package generate
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// APGroupBlocks generates import + resource blocks for AP groups. Groups the
+// controller marks as hidden, read-only or undeletable are its own (such as
+// the group of all access points) and are skipped. Device MACs are written in
+// lower case and sorted, as the resource reads them.
+func APGroupBlocks(groups []unifi.APGroup) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(groups))
+	for _, g := range groups {
+		if g.Hidden || g.NoEdit || g.NoDelete {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_ap_group",
+			ResourceName: ToTerraformName(g.Name),
+			ImportID:     g.ID,
+		}
+
+		macs := make([]string, len(g.DeviceMacs))
+		for i, mac := range g.DeviceMacs {
+			macs[i] = strings.ToLower(mac)
+		}
+		slices.Sort(macs)
+		macs = slices.Compact(macs)
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(g.Name)})
+		block.Attributes = append(block.Attributes, Attr{Key: "device_macs", Value: HCLStringList(macs)})
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
@@ -2,6 +2,9 @@ package generate
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
@@ -77,3 +80,34 @@ func ClientDeviceBlocks(clients []unifi.Client, overrides map[string]int64) []Re
 	DeduplicateNames(blocks)
 	return blocks
 }
+
+// ParseActiveWithin parses a client activity window such as "30d" or "72h".
+// It accepts Go durations plus a whole number of days with a "d" suffix,
+// since windows are usually counted in days.
+func ParseActiveWithin(s string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		var n int
+		n, err = strconv.Atoi(days)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(s)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid activity window %q: expected a positive duration such as 30d or 72h", s)
+	}
+	return d, nil
+}
+
+// ActiveClients returns the clients last seen at or after cutoff, keeping
+// their order. Clients the controller has never seen online are dropped too.
+func ActiveClients(clients []unifi.Client, cutoff time.Time) []unifi.Client {
+	active := make([]unifi.Client, 0, len(clients))
+	for _, c := range clients {
+		if c.LastSeen != nil && !time.Unix(*c.LastSeen, 0).Before(cutoff) {
+			active = append(active, c)
+		}
+	}
+	return active
+}
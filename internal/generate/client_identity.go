@@ -0,0 +1,57 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ClientIdentityBlocks generates import + resource blocks for the client
+// devices whose fingerprint has an override. fingerprints maps a client's MAC
+// to its fingerprint from the v2 client info API. The fingerprint doesn't say
+// which values are overridden, so the category, family and OS it reports are
+// all written, as on import.
+func ClientIdentityBlocks(clients []unifi.Client, fingerprints map[string]*unifi.ClientInfoFingerprint) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(clients))
+	for _, c := range clients {
+		fp := fingerprints[c.MAC]
+		if fp == nil || !fp.HasOverride {
+			continue
+		}
+
+		var ids []Attr
+		for _, id := range []struct {
+			key   string
+			value *int64
+		}{
+			{"category_id", fp.DevCat},
+			{"family_id", fp.DevFamily},
+			{"os_id", fp.OsName},
+		} {
+			if id.value != nil && *id.value > 0 {
+				ids = append(ids, Attr{Key: id.key, Value: HCLInt64(*id.value)})
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+
+		name := c.Name
+		if name == "" {
+			name = c.MAC
+		}
+		mac := strings.ToLower(c.MAC)
+		block := ResourceBlock{
+			ResourceType: "terrifi_client_identity",
+			ResourceName: ToTerraformName(name),
+			ImportID:     mac,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "mac", Value: HCLString(mac)})
+		block.Attributes = append(block.Attributes, ids...)
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
@@ -0,0 +1,39 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// DHCPOptionBlocks generates import + resource blocks for custom DHCP
+// options. Options the controller marks as hidden or read-only are its own
+// and are skipped.
+func DHCPOptionBlocks(options []unifi.DHCPOption) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(options))
+	for _, o := range options {
+		if o.Hidden || o.NoEdit {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_dhcp_option",
+			ResourceName: ToTerraformName(o.Name),
+			ImportID:     o.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(o.Name)})
+		block.Attributes = append(block.Attributes, Attr{Key: "code", Value: o.Code})
+		block.Attributes = append(block.Attributes, Attr{Key: "type", Value: HCLString(o.Type)})
+		if o.Type == "integer" {
+			if o.Width != nil {
+				block.Attributes = append(block.Attributes, Attr{Key: "width", Value: HCLInt64(*o.Width)})
+			}
+			if o.Signed {
+				block.Attributes = append(block.Attributes, Attr{Key: "signed", Value: HCLBool(true)})
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
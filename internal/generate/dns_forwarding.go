@@ -0,0 +1,30 @@
+package generate
+
+import "github.com/ubiquiti-community/go-unifi/unifi"
+
+// DNSForwardingBlocks generates import + resource blocks for conditional DNS
+// forwarding entries. The controller stores them alongside DNS records, so
+// records of other types are skipped.
+func DNSForwardingBlocks(records []unifi.DNSRecord) []ResourceBlock {
+	var blocks []ResourceBlock
+	for _, r := range records {
+		if r.RecordType != "FORWARD" {
+			continue
+		}
+		block := ResourceBlock{
+			ResourceType: "terrifi_dns_forwarding",
+			ResourceName: ToTerraformName(r.Key),
+			ImportID:     r.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "domain", Value: HCLString(r.Key)})
+		block.Attributes = append(block.Attributes, Attr{Key: "server", Value: HCLString(r.Value)})
+		if !r.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
@@ -1,13 +1,19 @@
 package generate
 
 import (
+	"github.com/alexklibisz/terrifi/internal/txtrecord"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
 // DNSRecordBlocks generates import + resource blocks for DNS records.
+// Forwarding entries, which the controller stores as FORWARD records, are
+// skipped; see DNSForwardingBlocks.
 func DNSRecordBlocks(records []unifi.DNSRecord) []ResourceBlock {
 	blocks := make([]ResourceBlock, 0, len(records))
 	for _, r := range records {
+		if r.RecordType == "FORWARD" {
+			continue
+		}
 		block := ResourceBlock{
 			ResourceType: "terrifi_dns_record",
 			ResourceName: ToTerraformName(r.Key),
@@ -15,7 +21,11 @@ func DNSRecordBlocks(records []unifi.DNSRecord) []ResourceBlock {
 		}
 
 		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(r.Key)})
-		block.Attributes = append(block.Attributes, Attr{Key: "value", Value: HCLString(r.Value)})
+		value := r.Value
+		if r.RecordType == "TXT" {
+			value = txtrecord.Decode(value)
+		}
+		block.Attributes = append(block.Attributes, Attr{Key: "value", Value: HCLString(value)})
 		block.Attributes = append(block.Attributes, Attr{Key: "record_type", Value: HCLString(r.RecordType)})
 
 		if !r.Enabled {
@@ -0,0 +1,44 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// DPIRestrictionBlocks generates import + resource blocks for legacy DPI
+// restrictions. Restrictions the controller marks as hidden or read-only are
+// its own and are skipped.
+func DPIRestrictionBlocks(apps []unifi.DpiApp) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(apps))
+	for _, a := range apps {
+		if a.Hidden || a.NoEdit {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_dpi_restriction",
+			ResourceName: ToTerraformName(a.Name),
+			ImportID:     a.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(a.Name)})
+		if !a.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if !a.Blocked {
+			block.Attributes = append(block.Attributes, Attr{Key: "blocked", Value: HCLBool(false)})
+		}
+		if a.Log {
+			block.Attributes = append(block.Attributes, Attr{Key: "log", Value: HCLBool(true)})
+		}
+		if len(a.Apps) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "app_ids", Value: HCLInt64List(a.Apps)})
+		}
+		if len(a.Cats) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "app_category_ids", Value: HCLInt64List(a.Cats)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
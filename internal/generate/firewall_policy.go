@@ -5,7 +5,8 @@ import (
 )
 
 // FirewallPolicyBlocks generates import + resource blocks for firewall policies.
-func FirewallPolicyBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock {
+// Zone and network IDs are resolved through refs, which may be nil.
+func FirewallPolicyBlocks(policies []*unifi.FirewallPolicy, refs *References) []ResourceBlock {
 	blocks := make([]ResourceBlock, 0, len(policies))
 	for _, p := range policies {
 		if p.Predefined {
@@ -50,10 +51,10 @@ func FirewallPolicyBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock {
 			block.Attributes = append(block.Attributes, Attr{Key: "create_allow_respond", Value: HCLBool(true)})
 		}
 		if p.Source != nil {
-			block.Blocks = append(block.Blocks, buildEndpointBlock("source", p.Source.ZoneID, p.Source.MatchingTarget, p.Source.IPs, p.Source.PortMatchingType, p.Source.Port, p.Source.PortGroupID, p.Source.MatchOppositePorts, p.Source.MatchOppositeIPs))
+			block.Blocks = append(block.Blocks, buildEndpointBlock(refs, "source", p.Source.ZoneID, p.Source.MatchingTarget, p.Source.IPs, p.Source.PortMatchingType, p.Source.Port, p.Source.PortGroupID, p.Source.MatchOppositePorts, p.Source.MatchOppositeIPs))
 		}
 		if p.Destination != nil {
-			block.Blocks = append(block.Blocks, buildEndpointBlock("destination", p.Destination.ZoneID, p.Destination.MatchingTarget, p.Destination.IPs, p.Destination.PortMatchingType, p.Destination.Port, p.Destination.PortGroupID, p.Destination.MatchOppositePorts, p.Destination.MatchOppositeIPs))
+			block.Blocks = append(block.Blocks, buildEndpointBlock(refs, "destination", p.Destination.ZoneID, p.Destination.MatchingTarget, p.Destination.IPs, p.Destination.PortMatchingType, p.Destination.Port, p.Destination.PortGroupID, p.Destination.MatchOppositePorts, p.Destination.MatchOppositeIPs))
 		}
 
 		if p.Schedule != nil && p.Schedule.Mode != "" && p.Schedule.Mode != "ALWAYS" {
@@ -66,14 +67,10 @@ func FirewallPolicyBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock {
 	return blocks
 }
 
-func buildEndpointBlock(name, zoneID, matchingTarget string, ips []string, portMatchingType string, port *int64, portGroupID string, matchOppositePorts, matchOppositeIPs bool) NestedBlock {
+func buildEndpointBlock(refs *References, name, zoneID, matchingTarget string, ips []string, portMatchingType string, port *int64, portGroupID string, matchOppositePorts, matchOppositeIPs bool) NestedBlock {
 	nb := NestedBlock{Name: name}
 
-	nb.Attributes = append(nb.Attributes, Attr{
-		Key:     "zone_id",
-		Value:   HCLString(zoneID),
-		Comment: "TODO: find and reference corresponding terrifi_firewall_zone resource",
-	})
+	nb.Attributes = append(nb.Attributes, zoneAttr(refs, "zone_id", zoneID))
 
 	if matchingTarget != "" && matchingTarget != "ANY" && len(ips) > 0 {
 		switch matchingTarget {
@@ -82,11 +79,15 @@ func buildEndpointBlock(name, zoneID, matchingTarget string, ips []string, portM
 		case "IID", "MAC":
 			nb.Attributes = append(nb.Attributes, Attr{Key: "mac_addresses", Value: HCLStringList(ips)})
 		case "NETWORK":
-			nb.Attributes = append(nb.Attributes, Attr{
-				Key:     "network_ids",
-				Value:   HCLStringList(ips),
-				Comment: "TODO: find and reference corresponding terrifi_network resources",
-			})
+			if ref, ok := refs.Networks(ips); ok {
+				nb.Attributes = append(nb.Attributes, Attr{Key: "network_ids", Value: ref})
+			} else {
+				nb.Attributes = append(nb.Attributes, Attr{
+					Key:     "network_ids",
+					Value:   HCLStringList(ips),
+					Comment: "TODO: find and reference corresponding terrifi_network resources",
+				})
+			}
 		case "CLIENT":
 			nb.Attributes = append(nb.Attributes, Attr{
 				Key:     "device_ids",
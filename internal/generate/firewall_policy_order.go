@@ -9,7 +9,8 @@ import (
 // FirewallPolicyOrderBlocks generates import + resource blocks for firewall
 // policy ordering. It groups policies by (source_zone_id, destination_zone_id)
 // pairs and generates one block per zone pair with the ordered policy IDs.
-func FirewallPolicyOrderBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock {
+// Zone IDs are resolved through refs, which may be nil.
+func FirewallPolicyOrderBlocks(policies []*unifi.FirewallPolicy, refs *References) []ResourceBlock {
 	// Group non-predefined policies by zone pair.
 	type zonePair struct {
 		sourceZoneID string
@@ -65,16 +66,8 @@ func FirewallPolicyOrderBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock
 			ImportID:     zp.sourceZoneID + ":" + zp.destZoneID,
 		}
 
-		block.Attributes = append(block.Attributes, Attr{
-			Key:     "source_zone_id",
-			Value:   HCLString(zp.sourceZoneID),
-			Comment: "TODO: find and reference corresponding terrifi_firewall_zone resource",
-		})
-		block.Attributes = append(block.Attributes, Attr{
-			Key:     "destination_zone_id",
-			Value:   HCLString(zp.destZoneID),
-			Comment: "TODO: find and reference corresponding terrifi_firewall_zone resource",
-		})
+		block.Attributes = append(block.Attributes, zoneAttr(refs, "source_zone_id", zp.sourceZoneID))
+		block.Attributes = append(block.Attributes, zoneAttr(refs, "destination_zone_id", zp.destZoneID))
 		block.Attributes = append(block.Attributes, Attr{
 			Key:   "policy_ids",
 			Value: HCLStringList(ids),
@@ -86,3 +79,16 @@ func FirewallPolicyOrderBlocks(policies []*unifi.FirewallPolicy) []ResourceBlock
 	DeduplicateNames(blocks)
 	return blocks
 }
+
+// zoneAttr returns a zone ID attribute, referencing the zone when refs can
+// resolve it.
+func zoneAttr(refs *References, key, zoneID string) Attr {
+	if ref, ok := refs.Zone(zoneID); ok {
+		return Attr{Key: key, Value: ref}
+	}
+	return Attr{
+		Key:     key,
+		Value:   HCLString(zoneID),
+		Comment: "TODO: find and reference corresponding terrifi_firewall_zone resource",
+	}
+}
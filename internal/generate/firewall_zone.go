@@ -5,7 +5,8 @@ import (
 )
 
 // FirewallZoneBlocks generates import + resource blocks for firewall zones.
-func FirewallZoneBlocks(zones []unifi.FirewallZone) []ResourceBlock {
+// Network IDs are resolved through refs, which may be nil.
+func FirewallZoneBlocks(zones []unifi.FirewallZone, refs *References) []ResourceBlock {
 	blocks := make([]ResourceBlock, 0, len(zones))
 	for _, z := range zones {
 		block := ResourceBlock{
@@ -17,11 +18,15 @@ func FirewallZoneBlocks(zones []unifi.FirewallZone) []ResourceBlock {
 		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(z.Name)})
 
 		if len(z.NetworkIDs) > 0 {
-			block.Attributes = append(block.Attributes, Attr{
-				Key:     "network_ids",
-				Value:   HCLStringList(z.NetworkIDs),
-				Comment: "TODO: find and reference corresponding terrifi_network resources",
-			})
+			if ref, ok := refs.Networks(z.NetworkIDs); ok {
+				block.Attributes = append(block.Attributes, Attr{Key: "network_ids", Value: ref})
+			} else {
+				block.Attributes = append(block.Attributes, Attr{
+					Key:     "network_ids",
+					Value:   HCLStringList(z.NetworkIDs),
+					Comment: "TODO: find and reference corresponding terrifi_network resources",
+				})
+			}
 		}
 
 		blocks = append(blocks, block)
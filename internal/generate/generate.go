@@ -62,6 +62,14 @@ func HCLStringList(vals []string) string {
 	return fmt.Sprintf("[%s]", strings.Join(quoted, ", "))
 }
 
+func HCLInt64List(vals []int64) string {
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		strs[i] = HCLInt64(v)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(strs, ", "))
+}
+
 // ToTerraformName converts a display name (e.g. "My Device") to a valid
 // Terraform resource name (e.g. "my_device"). Returns "SET_NAME" for empty input.
 func ToTerraformName(name string) string {
@@ -98,15 +106,18 @@ func DeduplicateNames(blocks []ResourceBlock) {
 	}
 }
 
-var blockTemplate = template.Must(template.New("blocks").Parse(`{{- range . }}
+var blockTemplate = template.Must(template.New("blocks").Parse(`{{- $imports := .Imports }}
+{{- range .Blocks }}
 {{- if .Comment }}
 # {{ .Comment }}
 {{ end -}}
+{{- if $imports -}}
 import {
   to = {{ .ResourceType }}.{{ .ResourceName }}
   id = "{{ .ImportID }}"
 }
 
+{{ end -}}
 resource "{{ .ResourceType }}" "{{ .ResourceName }}" {
 {{- range .Attributes }}
 {{- if .Comment }}
@@ -131,7 +142,19 @@ resource "{{ .ResourceType }}" "{{ .ResourceName }}" {
 
 {{ end }}`))
 
+type templateData struct {
+	Blocks  []ResourceBlock
+	Imports bool
+}
+
 // WriteBlocks renders the given ResourceBlocks as HCL to the writer.
 func WriteBlocks(w io.Writer, blocks []ResourceBlock) error {
-	return blockTemplate.Execute(w, blocks)
+	return blockTemplate.Execute(w, templateData{Blocks: blocks, Imports: true})
+}
+
+// WriteResourceBlocks renders only the resource {} blocks, without the
+// import {} blocks. Used once the imports have been applied and the import
+// blocks are no longer needed.
+func WriteResourceBlocks(w io.Writer, blocks []ResourceBlock) error {
+	return blockTemplate.Execute(w, templateData{Blocks: blocks})
 }
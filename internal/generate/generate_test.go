@@ -2,11 +2,14 @@ package generate
 
 import (
 	"bytes"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
 )
 
 // ---------------------------------------------------------------------------
@@ -156,10 +159,137 @@ func TestWriteBlocksWithComment(t *testing.T) {
 	assert.Contains(t, output, `name = "test" # inline comment`)
 }
 
+func TestWriteResourceBlocks(t *testing.T) {
+	blocks := []ResourceBlock{
+		{
+			ResourceType: "terrifi_dns_record",
+			ResourceName: "example",
+			ImportID:     "abc123",
+			Attributes:   []Attr{{Key: "name", Value: `"example.com"`}},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteResourceBlocks(&buf, blocks)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.NotContains(t, output, "import {")
+	assert.NotContains(t, output, "abc123")
+	assert.Contains(t, output, `resource "terrifi_dns_record" "example"`)
+	assert.Contains(t, output, `name = "example.com"`)
+}
+
+// ---------------------------------------------------------------------------
+// State filtering
+// ---------------------------------------------------------------------------
+
+func TestParseState(t *testing.T) {
+	t.Run("resources from all modules", func(t *testing.T) {
+		managed, err := ParseState(strings.NewReader(`{
+			"format_version": "1.0",
+			"values": {
+				"root_module": {
+					"resources": [
+						{"mode": "managed", "type": "terrifi_network", "values": {"id": "net1", "site": "default"}},
+						{"mode": "data", "type": "terrifi_device", "values": {"id": "dev1"}}
+					],
+					"child_modules": [
+						{"resources": [{"mode": "managed", "type": "terrifi_wlan", "values": {"id": "wlan1"}}]}
+					]
+				}
+			}
+		}`))
+		require.NoError(t, err)
+		require.Len(t, managed, 2)
+		assert.Equal(t, "terrifi_network", managed[0].Type)
+		assert.Equal(t, "net1", managed[0].Values["id"])
+		assert.Equal(t, "terrifi_wlan", managed[1].Type)
+	})
+
+	t.Run("empty state", func(t *testing.T) {
+		managed, err := ParseState(strings.NewReader(`{"format_version": "1.0"}`))
+		require.NoError(t, err)
+		assert.Empty(t, managed)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseState(strings.NewReader(`not json`))
+		assert.Error(t, err)
+	})
+}
+
+func TestFilterUnmanaged(t *testing.T) {
+	blocks := []ResourceBlock{
+		{ResourceType: "terrifi_network", ResourceName: "lan", ImportID: "net1"},
+		{ResourceType: "terrifi_network", ResourceName: "iot", ImportID: "net2"},
+		{ResourceType: "terrifi_device", ResourceName: "switch", ImportID: "aa:bb:cc:dd:ee:ff"},
+		{ResourceType: "terrifi_setting_locale", ResourceName: "default", ImportID: "default"},
+		{ResourceType: "terrifi_wlan", ResourceName: "home", ImportID: "net1"},
+	}
+	managed := []ManagedResource{
+		{Type: "terrifi_network", Values: map[string]any{"id": "net1"}},
+		{Type: "terrifi_device", Values: map[string]any{"id": "dev1", "mac": "aa:bb:cc:dd:ee:ff"}},
+		{Type: "terrifi_setting_locale", Values: map[string]any{"id": "set1", "site": "default"}},
+	}
+
+	unmanaged, skipped := FilterUnmanaged(blocks, managed)
+
+	names := func(bs []ResourceBlock) []string {
+		var out []string
+		for _, b := range bs {
+			out = append(out, b.ResourceType+"."+b.ResourceName)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"terrifi_network.iot", "terrifi_wlan.home"}, names(unmanaged))
+	assert.Equal(t, []string{"terrifi_network.lan", "terrifi_device.switch", "terrifi_setting_locale.default"}, names(skipped))
+}
+
 // ---------------------------------------------------------------------------
 // ClientDeviceBlocks
 // ---------------------------------------------------------------------------
 
+func TestParseActiveWithin(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"1d":  24 * time.Hour,
+		"72h": 72 * time.Hour,
+		"90m": 90 * time.Minute,
+	} {
+		got, err := ParseActiveWithin(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "d", "0d", "-1d", "1.5d", "30", "0s", "-2h", "month"} {
+		_, err := ParseActiveWithin(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestActiveClients(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	seen := func(ago time.Duration) *int64 {
+		v := now.Add(-ago).Unix()
+		return &v
+	}
+	clients := []unifi.Client{
+		{MAC: "00:00:00:00:00:01", LastSeen: seen(time.Hour)},
+		{MAC: "00:00:00:00:00:02", LastSeen: seen(60 * 24 * time.Hour)},
+		{MAC: "00:00:00:00:00:03"},
+		{MAC: "00:00:00:00:00:04", LastSeen: seen(30 * 24 * time.Hour)},
+	}
+
+	active := ActiveClients(clients, now.Add(-30*24*time.Hour))
+
+	macs := make([]string, len(active))
+	for i, c := range active {
+		macs[i] = c.MAC
+	}
+	assert.Equal(t, []string{"00:00:00:00:00:01", "00:00:00:00:00:04"}, macs)
+}
+
 func TestClientDeviceBlocks(t *testing.T) {
 	blocked := true
 	overrideEnabled := true
@@ -279,6 +409,197 @@ func TestClientDeviceBlocks_deduplication(t *testing.T) {
 // DNSRecordBlocks
 // ---------------------------------------------------------------------------
 
+func TestDHCPOptionBlocks(t *testing.T) {
+	width := int64(16)
+	options := []unifi.DHCPOption{
+		{ID: "o1", Name: "tftp-servers", Code: "150", Type: "ipaddress"},
+		{ID: "o2", Name: "offset", Code: "200", Type: "integer", Width: &width, Signed: true},
+		{ID: "o3", Name: "builtin", Code: "230", Type: "text", NoEdit: true},
+	}
+
+	blocks := DHCPOptionBlocks(options)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, "terrifi_dhcp_option", blocks[0].ResourceType)
+	assert.Equal(t, "tftp_servers", blocks[0].ResourceName)
+	assert.Equal(t, "o1", blocks[0].ImportID)
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, `"tftp-servers"`, attrs["name"])
+	assert.Equal(t, "150", attrs["code"])
+	assert.Equal(t, `"ipaddress"`, attrs["type"])
+	assert.NotContains(t, attrs, "width")
+	assert.NotContains(t, attrs, "signed")
+
+	attrs = attrMapFromBlock(blocks[1])
+	assert.Equal(t, "16", attrs["width"])
+	assert.Equal(t, "true", attrs["signed"])
+}
+
+func TestRADIUSUserBlocks(t *testing.T) {
+	vlan, tunnelType, mediumType := int64(30), int64(13), int64(6)
+	otherType := int64(3)
+	accounts := []unifi.Account{
+		{ID: "a1", Name: "alice", XPassword: "s3cret", VLAN: &vlan, TunnelType: &tunnelType, TunnelMediumType: &mediumType},
+		{ID: "a2", Name: "bob", TunnelType: &otherType},
+		{ID: "a3", Name: "hidden", Hidden: true},
+	}
+
+	blocks := RADIUSUserBlocks(accounts)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, "terrifi_radius_user", blocks[0].ResourceType)
+	assert.Equal(t, "alice", blocks[0].ResourceName)
+	assert.Equal(t, "a1", blocks[0].ImportID)
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, `"alice"`, attrs["name"])
+	assert.Equal(t, `"REPLACE_ME"`, attrs["password"], "the password is never written out")
+	assert.Equal(t, "30", attrs["vlan"])
+	assert.NotContains(t, attrs, "tunnel_type")
+	assert.NotContains(t, attrs, "tunnel_medium_type")
+
+	attrs = attrMapFromBlock(blocks[1])
+	assert.NotContains(t, attrs, "vlan")
+	assert.Equal(t, "3", attrs["tunnel_type"])
+}
+
+func TestUserGroupBlocks(t *testing.T) {
+	down, up, unlimited := int64(20000), int64(5000), int64(-1)
+	groups := []unifi.ClientGroup{
+		{ID: "g1", Name: "Default", QOSRateMaxDown: &unlimited, QOSRateMaxUp: &unlimited, NoDelete: true},
+		{ID: "g2", Name: "Kids", QOSRateMaxDown: &down, QOSRateMaxUp: &up},
+		{ID: "g3", Name: "hidden", Hidden: true},
+	}
+
+	blocks := UserGroupBlocks(groups)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, "terrifi_user_group", blocks[0].ResourceType)
+	assert.Equal(t, "default", blocks[0].ResourceName)
+	assert.Equal(t, "g1", blocks[0].ImportID)
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, `"Default"`, attrs["name"])
+	assert.NotContains(t, attrs, "download_limit_kbps")
+	assert.NotContains(t, attrs, "upload_limit_kbps")
+
+	attrs = attrMapFromBlock(blocks[1])
+	assert.Equal(t, "20000", attrs["download_limit_kbps"])
+	assert.Equal(t, "5000", attrs["upload_limit_kbps"])
+}
+
+func TestAPGroupBlocks(t *testing.T) {
+	groups := []unifi.APGroup{
+		{ID: "all", Name: "All APs", DeviceMacs: []string{"aa:bb:cc:00:00:01"}, NoDelete: true},
+		{ID: "g1", Name: "Upstairs", DeviceMacs: []string{"AA:BB:CC:00:00:02", "aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"}},
+		{ID: "g2", Name: "Empty"},
+	}
+
+	blocks := APGroupBlocks(groups)
+	require.Len(t, blocks, 2, "the built-in group is skipped")
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_ap_group", b.ResourceType)
+	assert.Equal(t, "upstairs", b.ResourceName)
+	assert.Equal(t, "g1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"name":        `"Upstairs"`,
+		"device_macs": `["aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"]`,
+	}, attrMapFromBlock(b))
+
+	assert.Equal(t, "[]", attrMapFromBlock(blocks[1])["device_macs"], "device_macs is required")
+}
+
+func TestAdminBlocks(t *testing.T) {
+	admins := []Admin{
+		{ID: "owner", Name: "owner", Email: "owner@example.com", Role: "admin", IsSuper: true},
+		{ID: "a1", Name: "Jane Doe", Email: "jane@example.com", Role: "readonly", Permissions: []string{"API_DEVICE_ADOPT"}},
+		{ID: "a2", Name: "local", Role: "admin"},
+	}
+
+	blocks := AdminBlocks(admins)
+	require.Len(t, blocks, 2, "super admins are skipped")
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_admin", b.ResourceType)
+	assert.Equal(t, "jane_doe", b.ResourceName)
+	assert.Equal(t, "a1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"name":        `"Jane Doe"`,
+		"email":       `"jane@example.com"`,
+		"role":        `"readonly"`,
+		"permissions": `["API_DEVICE_ADOPT"]`,
+	}, attrMapFromBlock(b))
+
+	assert.Equal(t, map[string]string{
+		"name":     `"local"`,
+		"password": `"REPLACE_ME"`,
+		"role":     `"admin"`,
+	}, attrMapFromBlock(blocks[1]), "an admin without an email needs a password")
+}
+
+func TestClientIdentityBlocks(t *testing.T) {
+	cat, family, os, zero := int64(9), int64(12), int64(24), int64(0)
+	clients := []unifi.Client{
+		{ID: "c1", MAC: "AA:BB:CC:00:00:01", Name: "Living Room TV"},
+		{ID: "c2", MAC: "aa:bb:cc:00:00:02"},
+		{ID: "c3", MAC: "aa:bb:cc:00:00:03", Name: "Identified"},
+		{ID: "c4", MAC: "aa:bb:cc:00:00:04", Name: "Unknown"},
+	}
+	fingerprints := map[string]*unifi.ClientInfoFingerprint{
+		"AA:BB:CC:00:00:01": {HasOverride: true, DevCat: &cat, DevFamily: &family, OsName: &os},
+		"aa:bb:cc:00:00:02": {HasOverride: true, DevCat: &cat, OsName: &zero},
+		"aa:bb:cc:00:00:03": {DevCat: &cat},
+	}
+
+	blocks := ClientIdentityBlocks(clients, fingerprints)
+	require.Len(t, blocks, 2, "only clients with an override are generated")
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_client_identity", b.ResourceType)
+	assert.Equal(t, "living_room_tv", b.ResourceName)
+	assert.Equal(t, "aa:bb:cc:00:00:01", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"mac":         `"aa:bb:cc:00:00:01"`,
+		"category_id": "9",
+		"family_id":   "12",
+		"os_id":       "24",
+	}, attrMapFromBlock(b))
+
+	b = blocks[1]
+	assert.Equal(t, "aa_bb_cc_00_00_02", b.ResourceName, "unnamed clients are named after their MAC")
+	assert.Equal(t, map[string]string{
+		"mac":         `"aa:bb:cc:00:00:02"`,
+		"category_id": "9",
+	}, attrMapFromBlock(b))
+}
+
+func TestDPIRestrictionBlocks(t *testing.T) {
+	apps := []unifi.DpiApp{
+		{ID: "d1", Name: "No Games", Enabled: true, Blocked: true, Cats: []int64{4}},
+		{ID: "d2", Name: "Watch Video", Log: true, Apps: []int64{589885, 655871}},
+		{ID: "d3", Name: "hidden", Hidden: true},
+	}
+
+	blocks := DPIRestrictionBlocks(apps)
+	require.Len(t, blocks, 2)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_dpi_restriction", b.ResourceType)
+	assert.Equal(t, "no_games", b.ResourceName)
+	assert.Equal(t, "d1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"name":             `"No Games"`,
+		"app_category_ids": "[4]",
+	}, attrMapFromBlock(b), "defaults are omitted")
+
+	assert.Equal(t, map[string]string{
+		"name":    `"Watch Video"`,
+		"enabled": "false",
+		"blocked": "false",
+		"log":     "true",
+		"app_ids": "[589885, 655871]",
+	}, attrMapFromBlock(blocks[1]))
+}
+
 func TestDNSRecordBlocks(t *testing.T) {
 	port := int64(443)
 	records := []unifi.DNSRecord{
@@ -300,10 +621,20 @@ func TestDNSRecordBlocks(t *testing.T) {
 			Ttl:        3600,
 			Weight:     5,
 		},
+		{
+			ID:         "dns3",
+			Key:        "txt.example.com",
+			Value:      `"v=spf1 ","-all"`,
+			RecordType: "TXT",
+			Enabled:    true,
+		},
 	}
 
 	blocks := DNSRecordBlocks(records)
-	require.Len(t, blocks, 2)
+	require.Len(t, blocks, 3)
+
+	// TXT values are emitted in their unquoted form.
+	assert.Equal(t, `"v=spf1 -all"`, attrMapFromBlock(blocks[2])["value"])
 
 	// Simple A record
 	b := blocks[0]
@@ -326,6 +657,38 @@ func TestDNSRecordBlocks(t *testing.T) {
 	assert.Equal(t, "5", attrs2["weight"])
 }
 
+// ---------------------------------------------------------------------------
+// DNSForwardingBlocks
+// ---------------------------------------------------------------------------
+
+func TestDNSForwardingBlocks(t *testing.T) {
+	records := []unifi.DNSRecord{
+		{ID: "dns1", Key: "example.com", Value: "1.2.3.4", RecordType: "A", Enabled: true},
+		{ID: "fwd1", Key: "corp.example", Value: "10.0.0.53", RecordType: "FORWARD", Enabled: true},
+		{ID: "fwd2", Key: "lab.example", Value: "10.0.1.53", RecordType: "FORWARD", Enabled: false},
+	}
+
+	blocks := DNSForwardingBlocks(records)
+	require.Len(t, blocks, 2)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_dns_forwarding", b.ResourceType)
+	assert.Equal(t, "corp_example", b.ResourceName)
+	assert.Equal(t, "fwd1", b.ImportID)
+	attrs := attrMapFromBlock(b)
+	assert.Equal(t, `"corp.example"`, attrs["domain"])
+	assert.Equal(t, `"10.0.0.53"`, attrs["server"])
+	_, hasEnabled := attrs["enabled"]
+	assert.False(t, hasEnabled)
+
+	assert.Equal(t, "false", attrMapFromBlock(blocks[1])["enabled"])
+
+	// Forwarding entries are not DNS records.
+	dnsBlocks := DNSRecordBlocks(records)
+	require.Len(t, dnsBlocks, 1)
+	assert.Equal(t, "dns1", dnsBlocks[0].ImportID)
+}
+
 // ---------------------------------------------------------------------------
 // FirewallZoneBlocks
 // ---------------------------------------------------------------------------
@@ -343,7 +706,7 @@ func TestFirewallZoneBlocks(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallZoneBlocks(zones)
+	blocks := FirewallZoneBlocks(zones, nil)
 	require.Len(t, blocks, 2)
 
 	b := blocks[0]
@@ -392,7 +755,7 @@ func TestFirewallPolicyBlocks(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	b := blocks[0]
@@ -445,7 +808,7 @@ func TestFirewallPolicyBlocks_deviceIDs(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	b := blocks[0]
@@ -481,7 +844,7 @@ func TestFirewallPolicyBlocks_macAddresses(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	srcAttrs := nestedAttrMap(blocks[0].Blocks[0])
@@ -511,7 +874,7 @@ func TestFirewallPolicyBlocks_networkIDs(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	srcAttrs := nestedAttrMap(blocks[0].Blocks[0])
@@ -535,7 +898,7 @@ func TestFirewallPolicyBlocks_schedule(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 	require.Len(t, blocks[0].Blocks, 1)
 
@@ -560,7 +923,7 @@ func TestFirewallPolicyBlocks_alwaysScheduleOmitted(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 	assert.Empty(t, blocks[0].Blocks)
 }
@@ -582,7 +945,7 @@ func TestFirewallPolicyBlocks_predefinedSkipped(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 	assert.Equal(t, "user_policy", blocks[0].ResourceName)
 }
@@ -607,7 +970,7 @@ func TestFirewallPolicyBlocks_customConnectionStateType(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	attrs := attrMapFromBlock(blocks[0])
@@ -635,7 +998,7 @@ func TestFirewallPolicyBlocks_icmpv6Protocol(t *testing.T) {
 		},
 	}
 
-	blocks := FirewallPolicyBlocks(policies)
+	blocks := FirewallPolicyBlocks(policies, nil)
 	require.Len(t, blocks, 1)
 
 	attrs := attrMapFromBlock(blocks[0])
@@ -643,6 +1006,110 @@ func TestFirewallPolicyBlocks_icmpv6Protocol(t *testing.T) {
 	assert.Equal(t, `"IPV6"`, attrs["ip_version"])
 }
 
+// ---------------------------------------------------------------------------
+// References
+// ---------------------------------------------------------------------------
+
+func TestReferences(t *testing.T) {
+	lan, iot, iot2 := "LAN", "IoT", "IoT!"
+	refs := NewReferences(
+		[]unifi.FirewallZone{
+			{ID: "zone1", Name: "Internal"},
+			{ID: "zone2", Name: "External"},
+		},
+		[]unifi.Network{
+			{ID: "net-001", Name: &lan},
+			{ID: "net-002", Name: &iot},
+			{ID: "net-003", Name: &iot2},
+		},
+	)
+
+	t.Run("unmanaged zone uses data source", func(t *testing.T) {
+		ref, ok := refs.Zone("zone2")
+		require.True(t, ok)
+		assert.Equal(t, "data.terrifi_firewall_zone.external.id", ref)
+	})
+
+	t.Run("generated resource uses resource address", func(t *testing.T) {
+		refs.AddResources([]ResourceBlock{
+			{ResourceType: "terrifi_firewall_zone", ResourceName: "internal", ImportID: "zone1"},
+		})
+		ref, ok := refs.Zone("zone1")
+		require.True(t, ok)
+		assert.Equal(t, "terrifi_firewall_zone.internal.id", ref)
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, ok := refs.Zone("zone9")
+		assert.False(t, ok)
+		_, ok = refs.Networks([]string{"net-001", "net-009"})
+		assert.False(t, ok)
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		ref, ok := refs.Networks([]string{"net-002", "net-003"})
+		require.True(t, ok)
+		assert.Equal(t, "[data.terrifi_network.iot.id, data.terrifi_network.iot_2.id]", ref)
+	})
+
+	// net-001 was only part of an unresolvable list, so it has no block.
+	blocks := refs.DataSourceBlocks()
+	require.Len(t, blocks, 3)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDataSourceBlocks(&buf, blocks))
+	assert.Equal(t, `data "terrifi_firewall_zone" "external" {
+  name = "External"
+}
+
+data "terrifi_network" "iot" {
+  name = "IoT"
+}
+
+data "terrifi_network" "iot_2" {
+  name = "IoT!"
+}
+
+`, buf.String())
+}
+
+func TestFirewallPolicyBlocks_references(t *testing.T) {
+	lan := "LAN"
+	refs := NewReferences(
+		[]unifi.FirewallZone{{ID: "zone1", Name: "Internal"}},
+		[]unifi.Network{{ID: "net-001", Name: &lan}},
+	)
+	policies := []*unifi.FirewallPolicy{
+		{
+			ID:      "pol1",
+			Name:    "Allow LAN",
+			Enabled: true,
+			Action:  "ALLOW",
+			Source: &unifi.FirewallPolicySource{
+				ZoneID:         "zone1",
+				MatchingTarget: "NETWORK",
+				IPs:            []string{"net-001"},
+			},
+			Destination: &unifi.FirewallPolicyDestination{
+				ZoneID:         "zone2",
+				MatchingTarget: "ANY",
+			},
+		},
+	}
+
+	blocks := FirewallPolicyBlocks(policies, refs)
+	require.Len(t, blocks, 1)
+
+	srcAttrs := nestedAttrMap(blocks[0].Blocks[0])
+	assert.Equal(t, "data.terrifi_firewall_zone.internal.id", srcAttrs["zone_id"])
+	assert.Equal(t, "[data.terrifi_network.lan.id]", srcAttrs["network_ids"])
+
+	// zone2 is unknown, so it stays a literal with a TODO comment.
+	dst := blocks[0].Blocks[1]
+	assert.Equal(t, `"zone2"`, dst.Attributes[0].Value)
+	assert.Contains(t, dst.Attributes[0].Comment, "TODO")
+}
+
 // ---------------------------------------------------------------------------
 // NetworkBlocks
 // ---------------------------------------------------------------------------
@@ -655,6 +1122,10 @@ func TestNetworkBlocks(t *testing.T) {
 	dhcpStart := "192.168.33.100"
 	dhcpStop := "192.168.33.200"
 	lease := int64(3600)
+	gateway := "192.168.33.254"
+	ntp := "192.168.33.1"
+	bootFile := "pxelinux.0"
+	controller := "192.168.33.10"
 
 	networks := []unifi.Network{
 		{
@@ -668,8 +1139,18 @@ func TestNetworkBlocks(t *testing.T) {
 			DHCPDStart:            &dhcpStart,
 			DHCPDStop:             &dhcpStop,
 			DHCPDLeaseTime:        &lease,
+			DHCPDDNSEnabled:       true,
 			DHCPDDNS1:             "1.1.1.1",
 			DHCPDDNS2:             "8.8.8.8",
+			DHCPDConflictChecking: true,
+			DHCPDGatewayEnabled:   true,
+			DHCPDGateway:          &gateway,
+			DHCPDNtpEnabled:       true,
+			DHCPDNtp1:             &ntp,
+			DHCPDBootEnabled:      true,
+			DHCPDBootServer:       "192.168.33.5",
+			DHCPDBootFilename:     &bootFile,
+			DHCPDUnifiController:  &controller,
 			InternetAccessEnabled: false,
 		},
 		{
@@ -696,9 +1177,240 @@ func TestNetworkBlocks(t *testing.T) {
 	assert.Equal(t, `"192.168.33.200"`, attrs["dhcp_stop"])
 	assert.Equal(t, "3600", attrs["dhcp_lease"])
 	assert.Equal(t, `["1.1.1.1", "8.8.8.8"]`, attrs["dhcp_dns"])
+	assert.Equal(t, "true", attrs["dhcp_ping_check"])
+	assert.Equal(t, `"192.168.33.254"`, attrs["dhcp_gateway"])
+	assert.Equal(t, `["192.168.33.1"]`, attrs["dhcp_ntp"])
+	assert.Equal(t, `"192.168.33.5"`, attrs["dhcp_boot_server"])
+	assert.Equal(t, `"pxelinux.0"`, attrs["dhcp_boot_filename"])
+	assert.Equal(t, `"192.168.33.10"`, attrs["dhcp_unifi_controller"])
 	assert.Equal(t, "false", attrs["internet_access_enabled"])
 }
 
+func TestNetworkBlocks_natOutbound(t *testing.T) {
+	name := "Servers"
+	wan, wan2 := "WAN", "WAN2"
+	all, ip, pool := "all", "ip_address", "ip_address_pool"
+	networks := []unifi.Network{
+		{
+			ID:                    "net1",
+			Purpose:               "corporate",
+			Name:                  &name,
+			InternetAccessEnabled: true,
+			NATOutboundIPAddresses: []unifi.NetworkNATOutboundIPAddresses{
+				{WANNetworkGroup: &wan, Mode: &ip, IPAddress: "203.0.113.10"},
+				{WANNetworkGroup: &wan2, Mode: &pool, IPAddressPool: []string{"198.51.100.2-198.51.100.5"}},
+				{WANNetworkGroup: &wan2, Mode: &all},
+			},
+		},
+	}
+
+	blocks := NetworkBlocks(networks)
+	require.Len(t, blocks, 1)
+
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, `[{ wan_network_group = "WAN", ip_address = "203.0.113.10" }, `+
+		`{ wan_network_group = "WAN2", ip_address_pool = ["198.51.100.2-198.51.100.5"] }]`, attrs["nat_outbound"])
+	assert.Empty(t, natOutboundHCL([]unifi.NetworkNATOutboundIPAddresses{{WANNetworkGroup: &wan, Mode: &all}}))
+}
+
+func TestWANBlocks(t *testing.T) {
+	wan, wan2, wan3 := "WAN", "WAN2", "WAN3"
+	name := "Fiber"
+	dhcp, static, pppoe, dslite := "dhcp", "static", "pppoe", "dslite"
+	ip, netmask, gateway := "203.0.113.10", "255.255.255.248", "203.0.113.9"
+	manual, dns1 := "manual", "1.1.1.1"
+	vlan, down, up := int64(35), int64(500000), int64(50000)
+
+	networks := []unifi.Network{
+		{
+			ID:                "wan1",
+			Purpose:           "wan",
+			Name:              &name,
+			WANNetworkGroup:   &wan,
+			WANType:           &pppoe,
+			WANVLANEnabled:    true,
+			WANVLAN:           &vlan,
+			WANUsername:       "user@isp",
+			XWANPassword:      "secret",
+			WANSmartQEnabled:  true,
+			WANSmartQDownRate: &down,
+			WANSmartQUpRate:   &up,
+		},
+		{
+			ID:               "wan2",
+			Purpose:          "wan",
+			WANNetworkGroup:  &wan2,
+			WANType:          &static,
+			WANIP:            &ip,
+			WANNetmask:       &netmask,
+			WANGateway:       &gateway,
+			WANDNSPreference: &manual,
+			WANDNS1:          &dns1,
+		},
+		{ID: "wan3", Purpose: "wan", WANNetworkGroup: &wan3, WANType: &dslite},
+		{ID: "net1", Purpose: "corporate", WANType: &dhcp},
+	}
+
+	blocks := WANBlocks(networks)
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, "terrifi_wan", blocks[0].ResourceType)
+	assert.Equal(t, "fiber", blocks[0].ResourceName)
+	assert.Equal(t, "wan1", blocks[0].ImportID)
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, `"WAN"`, attrs["network_group"])
+	assert.Equal(t, `"pppoe"`, attrs["type"])
+	assert.Equal(t, "35", attrs["vlan_id"])
+	assert.Equal(t, `"user@isp"`, attrs["pppoe_username"])
+	assert.NotContains(t, attrs, "pppoe_password")
+	assert.Equal(t, "{ download_kbps = 500000, upload_kbps = 50000 }", attrs["smart_queues"])
+
+	assert.Equal(t, "wan2", blocks[1].ResourceName)
+	attrs = attrMapFromBlock(blocks[1])
+	assert.Equal(t, `"static"`, attrs["type"])
+	assert.Equal(t, `"203.0.113.10/29"`, attrs["ip_address"])
+	assert.Equal(t, `"203.0.113.9"`, attrs["gateway"])
+	assert.Equal(t, `["1.1.1.1"]`, attrs["dns_servers"])
+	assert.NotContains(t, attrs, "vlan_id")
+}
+
+func TestSiteVPNBlocks(t *testing.T) {
+	office, cloud, legacy := "Office", "Cloud", "Legacy"
+	ipsec, wireguard, openvpn := "ipsec-vpn", "wireguard-client", "openvpn-vpn"
+	peer, wan, wan2 := "198.51.100.1", "wan", "wan2"
+	ikev1, aes128, sha256 := "ikev1", "aes128", "sha256"
+	tunnelIP, peerKey := "10.255.0.1/30", "yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="
+	dh, distance, port := int64(14), int64(40), int64(51821)
+
+	networks := []unifi.Network{
+		{
+			ID:                  "vpn1",
+			Purpose:             unifi.PurposeSiteVPN,
+			Name:                &office,
+			VPNType:             &ipsec,
+			Enabled:             true,
+			IPSecPeerIP:         &peer,
+			IPSecInterface:      &wan,
+			XIPSecPreSharedKey:  &peerKey,
+			RemoteVPNSubnets:    []string{"10.1.0.0/16"},
+			RouteDistance:       &distance,
+			IPSecDynamicRouting: true,
+			IPSecKeyExchange:    &ikev1,
+			IPSecIkeEncryption:  &aes128,
+			IPSecIkeHash:        &sha256,
+			IPSecIkeDhGroup:     &dh,
+			IPSecPfs:            true,
+		},
+		{
+			ID:                           "vpn2",
+			Purpose:                      unifi.PurposeSiteVPN,
+			Name:                         &cloud,
+			VPNType:                      &wireguard,
+			WireguardClientPeerIP:        &peer,
+			WireguardClientPeerPort:      &port,
+			WireguardClientPeerPublicKey: &peerKey,
+			WireguardInterface:           &wan2,
+			RemoteVPNSubnets:             []string{"10.2.0.0/16"},
+			IPSubnet:                     &tunnelIP,
+		},
+		{ID: "vpn3", Purpose: unifi.PurposeSiteVPN, Name: &legacy, VPNType: &openvpn},
+		{ID: "net1", Purpose: "corporate", Name: &office},
+	}
+
+	blocks := SiteVPNBlocks(networks)
+	require.Len(t, blocks, 2, "only IPsec and WireGuard site-to-site VPNs are generated")
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_site_vpn", b.ResourceType)
+	assert.Equal(t, "office", b.ResourceName)
+	assert.Equal(t, "vpn1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"name":           `"Office"`,
+		"remote_gateway": `"198.51.100.1"`,
+		"pre_shared_key": `"REPLACE_ME"`,
+		"remote_subnets": `["10.1.0.0/16"]`,
+		"route_distance": "40",
+		"ike_version":    `"ikev1"`,
+		"encryption":     `"aes128"`,
+	}, attrMapFromBlock(b), "defaults are omitted")
+
+	b = blocks[1]
+	assert.Equal(t, map[string]string{
+		"name":              `"Cloud"`,
+		"type":              `"wireguard"`,
+		"enabled":           "false",
+		"remote_gateway":    `"198.51.100.1"`,
+		"wan_interface":     `"wan2"`,
+		"remote_subnets":    `["10.2.0.0/16"]`,
+		"remote_port":       "51821",
+		"remote_public_key": `"yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk="`,
+		"tunnel_ip":         `"10.255.0.1/30"`,
+	}, attrMapFromBlock(b), "no pre-shared key without one on the controller")
+}
+
+func TestVPNServerBlocks(t *testing.T) {
+	home, legacy, other := "Home", "Legacy", "Other"
+	wireguard, l2tp, openvpn := "wireguard-server", "l2tp-server", "openvpn-server"
+	wgSubnet, l2tpSubnet := "192.168.3.1/24", "192.168.4.1/24"
+	wan, wan2, port := "wan", "wan2", int64(51820)
+
+	networks := []unifi.Network{
+		{
+			ID:                 "srv1",
+			Purpose:            unifi.PurposeUserVPN,
+			Name:               &home,
+			VPNType:            &wireguard,
+			Enabled:            true,
+			IPSubnet:           &wgSubnet,
+			WireguardInterface: &wan,
+			LocalPort:          &port,
+		},
+		{
+			ID:                   "srv2",
+			Purpose:              unifi.PurposeUserVPN,
+			Name:                 &legacy,
+			VPNType:              &l2tp,
+			IPSubnet:             &l2tpSubnet,
+			L2TpInterface:        &wan2,
+			RequireMschapv2:      true,
+			L2TpAllowWeakCiphers: false,
+		},
+		{ID: "srv3", Purpose: unifi.PurposeUserVPN, Name: &other, VPNType: &openvpn},
+	}
+	peers := map[string][]WireGuardPeer{
+		"srv1": {
+			{Name: "laptop", InterfaceIP: "192.168.3.2", PublicKey: "key1"},
+			{Name: "phone", InterfaceIP: "192.168.3.3", PublicKey: "key2", PresharedKey: "psk"},
+		},
+	}
+
+	blocks := VPNServerBlocks(networks, peers)
+	require.Len(t, blocks, 2, "only WireGuard and L2TP servers are generated")
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_vpn_server", b.ResourceType)
+	assert.Equal(t, "home", b.ResourceName)
+	assert.Equal(t, "srv1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"name":   `"Home"`,
+		"type":   `"wireguard"`,
+		"subnet": `"192.168.3.1/24"`,
+		"peers": `[{ name = "laptop", interface_ip = "192.168.3.2", public_key = "key1" }, ` +
+			`{ name = "phone", interface_ip = "192.168.3.3", public_key = "key2", preshared_key = "REPLACE_ME" }]`,
+	}, attrMapFromBlock(b), "defaults are omitted")
+
+	b = blocks[1]
+	assert.Equal(t, map[string]string{
+		"name":             `"Legacy"`,
+		"type":             `"l2tp"`,
+		"enabled":          "false",
+		"subnet":           `"192.168.4.1/24"`,
+		"wan_interface":    `"wan2"`,
+		"pre_shared_key":   `"REPLACE_ME"`,
+		"require_mschapv2": "true",
+	}, attrMapFromBlock(b))
+}
+
 func TestNetworkBlocks_defaults(t *testing.T) {
 	name := "Simple"
 	networks := []unifi.Network{
@@ -723,6 +1435,42 @@ func TestNetworkBlocks_defaults(t *testing.T) {
 	assert.False(t, hasInternet)
 }
 
+func TestNetworkBlocks_dhcpRelay(t *testing.T) {
+	relayName := "Servers"
+	staleName := "Stale"
+	networks := []unifi.Network{
+		{
+			ID:                    "net1",
+			Purpose:               "corporate",
+			Name:                  &relayName,
+			DHCPRelayEnabled:      true,
+			DHCPRelayServers:      []string{"10.0.0.5", "10.0.0.6"},
+			InternetAccessEnabled: true,
+		},
+		{
+			// The controller keeps the servers after relay is switched off.
+			ID:                    "net2",
+			Purpose:               "corporate",
+			Name:                  &staleName,
+			DHCPRelayServers:      []string{"10.0.0.5"},
+			InternetAccessEnabled: true,
+		},
+	}
+
+	blocks := NetworkBlocks(networks)
+	require.Len(t, blocks, 2)
+
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, "true", attrs["dhcp_relay_enabled"])
+	assert.Equal(t, `["10.0.0.5", "10.0.0.6"]`, attrs["dhcp_relay_servers"])
+
+	attrs2 := attrMapFromBlock(blocks[1])
+	_, hasRelay := attrs2["dhcp_relay_enabled"]
+	assert.False(t, hasRelay)
+	_, hasServers := attrs2["dhcp_relay_servers"]
+	assert.False(t, hasServers)
+}
+
 func TestNetworkBlocks_vlanOnly(t *testing.T) {
 	iotName := "IoT"
 	iotVLAN := int64(100)
@@ -839,6 +1587,38 @@ func TestWLANBlocks(t *testing.T) {
 	assert.False(t, hasBand)
 }
 
+func TestWLANBlocks6GHz(t *testing.T) {
+	blocks := WLANBlocks([]unifi.WLAN{{
+		ID:        "wlan1",
+		Name:      "Fast",
+		NetworkID: "net1",
+		WLANBand:  "5g",
+		WLANBands: []string{"5g", "6g"},
+	}})
+	require.Len(t, blocks, 1)
+
+	attrs := attrMapFromBlock(blocks[0])
+	assert.Equal(t, "false", attrs["enabled_2g"])
+	assert.Equal(t, "true", attrs["enabled_6g"])
+	_, has5G := attrs["enabled_5g"]
+	assert.False(t, has5G)
+	// wifi_band conflicts with the per-band flags.
+	_, hasBand := attrs["wifi_band"]
+	assert.False(t, hasBand)
+}
+
+func TestWLANBlocksAPGroups(t *testing.T) {
+	blocks := WLANBlocks([]unifi.WLAN{
+		{ID: "wlan1", Name: "Office", NetworkID: "net1", ApGroupMode: "groups", ApGroupIDs: []string{"apg1", "apg2"}},
+		{ID: "wlan2", Name: "Everywhere", NetworkID: "net1", ApGroupMode: "all", ApGroupIDs: []string{"default"}},
+	})
+	require.Len(t, blocks, 2)
+
+	assert.Equal(t, `["apg1", "apg2"]`, attrMapFromBlock(blocks[0])["ap_group_ids"])
+	_, hasGroups := attrMapFromBlock(blocks[1])["ap_group_ids"]
+	assert.False(t, hasGroups, "the default group means every AP")
+}
+
 // ---------------------------------------------------------------------------
 // ClientGroupBlocks
 // ---------------------------------------------------------------------------
@@ -872,6 +1652,624 @@ func TestClientGroupBlocks(t *testing.T) {
 	assert.Equal(t, "iot_devices", b2.ResourceName)
 }
 
+// ---------------------------------------------------------------------------
+// NATRuleBlocks
+// ---------------------------------------------------------------------------
+
+func TestNATRuleBlocks(t *testing.T) {
+	lan := "LAN"
+	refs := NewReferences(nil, []unifi.Network{{ID: "net-001", Name: &lan}})
+	port, fwdPort := int64(8443), int64(443)
+	rules := []unifi.Nat{
+		{
+			ID:          "nat1",
+			Description: "Web Server",
+			Type:        "DNAT",
+			Enabled:     true,
+			IPVersion:   "IPV4",
+			Protocol:    "tcp",
+			InInterface: "wan-1",
+			IPAddress:   "192.168.1.10",
+			Port:        &port,
+			DestinationFilter: &unifi.NatDestinationFilter{
+				FilterType: "ADDRESS_AND_PORT",
+				Address:    "203.0.113.10",
+				Port:       &fwdPort,
+			},
+			SourceFilter: &unifi.NatSourceFilter{FilterType: "NONE"},
+		},
+		{
+			ID:           "nat2",
+			Description:  "LAN masquerade",
+			Type:         "MASQUERADE",
+			OutInterface: "wan-1",
+			Logging:      true,
+			SourceFilter: &unifi.NatSourceFilter{
+				FilterType:    "NETWORK_CONF",
+				NetworkConfID: "net-001",
+				Address:       "10.0.0.1", // kept by the controller from an earlier type
+				InvertAddress: true,
+			},
+		},
+		{
+			ID:           "nat3",
+			Description:  "Predefined",
+			Type:         "MASQUERADE",
+			IsPredefined: true,
+		},
+	}
+
+	blocks := NATRuleBlocks(rules, refs)
+	require.Len(t, blocks, 2, "predefined rules are skipped")
+
+	// Defaults are omitted.
+	b := blocks[0]
+	assert.Equal(t, "terrifi_nat_rule", b.ResourceType)
+	assert.Equal(t, "web_server", b.ResourceName)
+	assert.Equal(t, "nat1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"description":  `"Web Server"`,
+		"type":         `"DNAT"`,
+		"protocol":     `"tcp"`,
+		"in_interface": `"wan-1"`,
+		"ip_address":   `"192.168.1.10"`,
+		"port":         "8443",
+	}, attrMapFromBlock(b))
+	require.Len(t, b.Blocks, 1, "NONE filters are omitted")
+	assert.Equal(t, "destination", b.Blocks[0].Name)
+	assert.Equal(t, map[string]string{
+		"address": `"203.0.113.10"`,
+		"port":    "443",
+	}, nestedAttrMap(b.Blocks[0]))
+
+	attrs2 := attrMapFromBlock(blocks[1])
+	assert.Equal(t, "false", attrs2["enabled"])
+	assert.Equal(t, "true", attrs2["logging"])
+	require.Len(t, blocks[1].Blocks, 1)
+	assert.Equal(t, "source", blocks[1].Blocks[0].Name)
+	assert.Equal(t, map[string]string{
+		"network_id":     "data.terrifi_network.lan.id",
+		"invert_address": "true",
+	}, nestedAttrMap(blocks[1].Blocks[0]))
+}
+
+// ---------------------------------------------------------------------------
+// PortForwardBlocks
+// ---------------------------------------------------------------------------
+
+func TestPortForwardBlocks(t *testing.T) {
+	forwards := []unifi.PortForward{
+		{
+			ID:      "pf1",
+			Name:    "Web Server",
+			Enabled: true,
+			Proto:   "tcp_udp",
+			Src:     "any",
+			DstPort: "443",
+			Fwd:     "192.168.1.10",
+			FwdPort: "8443",
+		},
+		{
+			ID:            "pf2",
+			Name:          "Game",
+			Enabled:       false,
+			Proto:         "udp",
+			PfwdInterface: "both",
+			Src:           "203.0.113.0/24",
+			DstPort:       "27015-27030",
+			Fwd:           "192.168.1.20",
+			FwdPort:       "27015-27030",
+			Log:           true,
+		},
+	}
+
+	blocks := PortForwardBlocks(forwards)
+	require.Len(t, blocks, 2)
+
+	// Defaults are omitted.
+	b := blocks[0]
+	assert.Equal(t, "terrifi_port_forward", b.ResourceType)
+	assert.Equal(t, "web_server", b.ResourceName)
+	assert.Equal(t, "pf1", b.ImportID)
+	attrs := attrMapFromBlock(b)
+	assert.Equal(t, map[string]string{
+		"name":     `"Web Server"`,
+		"dst_port": `"443"`,
+		"fwd_ip":   `"192.168.1.10"`,
+		"fwd_port": `"8443"`,
+	}, attrs)
+
+	attrs2 := attrMapFromBlock(blocks[1])
+	assert.Equal(t, "false", attrs2["enabled"])
+	assert.Equal(t, `"udp"`, attrs2["protocol"])
+	assert.Equal(t, `"both"`, attrs2["wan_interface"])
+	assert.Equal(t, `"203.0.113.0/24"`, attrs2["source"])
+	assert.Equal(t, "true", attrs2["log"])
+}
+
+// ---------------------------------------------------------------------------
+// PortProfileBlocks
+// ---------------------------------------------------------------------------
+
+func TestPortProfileBlocks(t *testing.T) {
+	lan := "LAN"
+	refs := NewReferences(nil, []unifi.Network{{ID: "net-001", Name: &lan}})
+	timeout := int64(600)
+	profiles := []unifi.PortProfile{
+		{
+			ID:             "pp1",
+			Name:           "Access Points",
+			TaggedVLANMgmt: "auto",
+			PoeMode:        "auto",
+			StpPortMode:    true,
+			LldpmedEnabled: true,
+			Dot1XCtrl:      "force_authorized",
+		},
+		{
+			ID:                     "pp2",
+			Name:                   "Desk Ports",
+			NATiveNetworkID:        "net-001",
+			TaggedVLANMgmt:         "custom",
+			ExcludedNetworkIDs:     []string{"net-009"},
+			VoiceNetworkID:         "net-002",
+			PoeMode:                "off",
+			Isolation:              true,
+			Dot1XCtrl:              "mac_based",
+			Dot1XIDleTimeout:       &timeout,
+			PortSecurityEnabled:    true,
+			PortSecurityMACAddress: []string{"AA:BB:CC:DD:EE:FF"},
+		},
+	}
+
+	blocks := PortProfileBlocks(profiles, refs)
+	require.Len(t, blocks, 2)
+
+	// Defaults are omitted.
+	b := blocks[0]
+	assert.Equal(t, "terrifi_port_profile", b.ResourceType)
+	assert.Equal(t, "access_points", b.ResourceName)
+	assert.Equal(t, "pp1", b.ImportID)
+	assert.Equal(t, map[string]string{"name": `"Access Points"`}, attrMapFromBlock(b))
+
+	attrs2 := attrMapFromBlock(blocks[1])
+	assert.Equal(t, "data.terrifi_network.lan.id", attrs2["native_network_id"])
+	assert.Equal(t, `"custom"`, attrs2["tagged_vlan_mgmt"])
+	assert.Equal(t, `["net-009"]`, attrs2["excluded_network_ids"])
+	assert.Equal(t, `"net-002"`, attrs2["voice_network_id"])
+	assert.Equal(t, `"off"`, attrs2["poe_mode"])
+	assert.Equal(t, "true", attrs2["isolation"])
+	assert.Equal(t, "false", attrs2["stp_enabled"])
+	assert.Equal(t, "false", attrs2["lldpmed_enabled"])
+	assert.Equal(t, `"mac_based"`, attrs2["dot1x_ctrl"])
+	assert.Equal(t, "600", attrs2["dot1x_idle_timeout"])
+	assert.Equal(t, `["aa:bb:cc:dd:ee:ff"]`, attrs2["port_security_mac_addresses"])
+
+	// Unresolved network IDs stay literals with a TODO comment.
+	for _, a := range blocks[1].Attributes {
+		if a.Key == "excluded_network_ids" || a.Key == "voice_network_id" {
+			assert.Contains(t, a.Comment, "TODO", a.Key)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// TrafficRuleBlocks
+// ---------------------------------------------------------------------------
+
+func TestTrafficRuleBlocks(t *testing.T) {
+	rules := []TrafficRule{
+		{
+			ID:             "tr1",
+			Description:    "Block games",
+			Action:         "BLOCK",
+			Enabled:        true,
+			ClientMACs:     []string{"aa:bb:cc:dd:ee:01"},
+			MatchingTarget: "APP_CATEGORY",
+			AppCategoryIDs: []int64{4},
+			Schedule: &unifi.FirewallPolicySchedule{
+				Mode:           "EVERY_WEEK",
+				TimeRangeStart: "20:00",
+				TimeRangeEnd:   "23:59",
+				RepeatOnDays:   []string{"sun", "mon"},
+			},
+		},
+		{
+			ID:             "tr2",
+			Description:    "Allow docs",
+			Action:         "ALLOW",
+			Enabled:        false,
+			NetworkIDs:     []string{"net1"},
+			MatchingTarget: "DOMAIN",
+			Domains:        []string{"example.com"},
+			AppIDs:         []int64{589885},
+			Schedule:       &unifi.FirewallPolicySchedule{Mode: "ALWAYS"},
+		},
+		{
+			ID:             "tr3",
+			Description:    "Holiday",
+			Enabled:        true,
+			MatchingTarget: "INTERNET",
+			Schedule:       &unifi.FirewallPolicySchedule{Mode: "CUSTOM", RepeatOnDays: []string{"sat"}},
+			DateStart:      "2030-12-20",
+			DateEnd:        "2031-01-05",
+		},
+	}
+
+	blocks := TrafficRuleBlocks(rules)
+	require.Len(t, blocks, 3)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_traffic_rule", b.ResourceType)
+	assert.Equal(t, "block_games", b.ResourceName)
+	assert.Equal(t, "tr1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"description":      `"Block games"`,
+		"mac_addresses":    `["aa:bb:cc:dd:ee:01"]`,
+		"app_category_ids": "[4]",
+	}, attrMapFromBlock(b), "defaults are omitted")
+	require.Len(t, b.Blocks, 1)
+	assert.Equal(t, map[string]string{
+		"mode":             `"EVERY_WEEK"`,
+		"time_range_start": `"20:00"`,
+		"time_range_end":   `"23:59"`,
+		"repeat_on_days":   `["sun", "mon"]`,
+	}, nestedAttrMap(b.Blocks[0]))
+
+	b = blocks[1]
+	assert.Equal(t, map[string]string{
+		"description": `"Allow docs"`,
+		"action":      `"ALLOW"`,
+		"enabled":     "false",
+		"network_ids": `["net1"]`,
+		"domains":     `["example.com"]`,
+	}, attrMapFromBlock(b), "only the matching target's destinations are generated")
+	assert.Empty(t, b.Blocks, "an always-on schedule is omitted")
+
+	b = blocks[2]
+	assert.Equal(t, map[string]string{"description": `"Holiday"`}, attrMapFromBlock(b))
+	require.Len(t, b.Blocks, 1)
+	sched := nestedAttrMap(b.Blocks[0])
+	assert.Equal(t, `"2030-12-20"`, sched["date_start"])
+	assert.Equal(t, `"2031-01-05"`, sched["date_end"])
+}
+
+// ---------------------------------------------------------------------------
+// TrafficRouteBlocks
+// ---------------------------------------------------------------------------
+
+func TestTrafficRouteBlocks(t *testing.T) {
+	routes := []TrafficRoute{
+		{
+			ID:             "rt1",
+			Description:    "Streaming via VPN",
+			Enabled:        true,
+			KillSwitch:     true,
+			NetworkID:      "vpn1",
+			NetworkIDs:     []string{"net1"},
+			MatchingTarget: "DOMAIN",
+			Domains:        []string{"netflix.com"},
+			IPs:            []string{"203.0.113.0/24"},
+		},
+		{
+			ID:             "rt2",
+			Description:    "NAS backups",
+			Enabled:        false,
+			NetworkID:      "wan2",
+			NextHop:        "192.0.2.1",
+			ClientMACs:     []string{"aa:bb:cc:dd:ee:01"},
+			MatchingTarget: "IP",
+			IPs:            []string{"203.0.113.0/24", "198.51.100.10-198.51.100.20"},
+		},
+		{
+			ID:             "rt3",
+			Description:    "Everything",
+			Enabled:        true,
+			NetworkID:      "vpn1",
+			MatchingTarget: "INTERNET",
+		},
+	}
+
+	blocks := TrafficRouteBlocks(routes)
+	require.Len(t, blocks, 3)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_traffic_route", b.ResourceType)
+	assert.Equal(t, "streaming_via_vpn", b.ResourceName)
+	assert.Equal(t, "rt1", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"description":          `"Streaming via VPN"`,
+		"interface_network_id": `"vpn1"`,
+		"kill_switch":          "true",
+		"network_ids":          `["net1"]`,
+		"domains":              `["netflix.com"]`,
+	}, attrMapFromBlock(b), "only the matching target's destinations are generated")
+	assert.Contains(t, b.Attributes[1].Comment, "TODO")
+
+	b = blocks[1]
+	assert.Equal(t, map[string]string{
+		"description":          `"NAS backups"`,
+		"interface_network_id": `"wan2"`,
+		"enabled":              "false",
+		"next_hop":             `"192.0.2.1"`,
+		"mac_addresses":        `["aa:bb:cc:dd:ee:01"]`,
+		"ips":                  `["203.0.113.0/24", "198.51.100.10-198.51.100.20"]`,
+	}, attrMapFromBlock(b))
+
+	b = blocks[2]
+	assert.Equal(t, map[string]string{
+		"description":          `"Everything"`,
+		"interface_network_id": `"vpn1"`,
+	}, attrMapFromBlock(b), "defaults are omitted")
+}
+
+// ---------------------------------------------------------------------------
+// Setting blocks
+// ---------------------------------------------------------------------------
+
+func TestSettingCountryBlocks(t *testing.T) {
+	code := int64(840)
+	blocks := SettingCountryBlocks("default", &settings.Country{Code: &code})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_country", b.ResourceType)
+	assert.Equal(t, "default", b.ResourceName)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, "840", attrMapFromBlock(b)["code"])
+
+	assert.Empty(t, SettingCountryBlocks("default", &settings.Country{}))
+}
+
+func TestSettingLocaleBlocks(t *testing.T) {
+	blocks := SettingLocaleBlocks("Branch Office", &settings.Locale{Timezone: "Europe/Berlin"})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_locale", b.ResourceType)
+	assert.Equal(t, "branch_office", b.ResourceName)
+	assert.Equal(t, "Branch Office", b.ImportID)
+	assert.Equal(t, `"Europe/Berlin"`, attrMapFromBlock(b)["timezone"])
+
+	assert.Empty(t, SettingLocaleBlocks("default", nil))
+}
+
+func TestSettingRadiusBlocks(t *testing.T) {
+	authPort := int64(1812)
+	blocks := SettingRadiusBlocks("default", &settings.Radius{
+		Enabled:       true,
+		AuthPort:      &authPort,
+		TunneledReply: true,
+		XSecret:       "s3cret",
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_radius", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+
+	attrs := attrMapFromBlock(b)
+	assert.Equal(t, "true", attrs["enabled"])
+	assert.Equal(t, "1812", attrs["auth_port"])
+	assert.Equal(t, "false", attrs["accounting_enabled"])
+	assert.Equal(t, "true", attrs["tunneled_reply"])
+	assert.NotContains(t, attrs, "acct_port")
+	assert.NotContains(t, attrs, "secret")
+
+	assert.Empty(t, SettingRadiusBlocks("default", nil))
+}
+
+func TestSettingRsyslogBlocks(t *testing.T) {
+	port := int64(514)
+	blocks := SettingRsyslogBlocks("default", &settings.Rsyslogd{
+		Enabled:  true,
+		IP:       "192.168.1.50",
+		Port:     &port,
+		Contents: []string{"device", "firewall_default_policy"},
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_rsyslog", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+
+	attrs := attrMapFromBlock(b)
+	assert.Equal(t, "true", attrs["enabled"])
+	assert.Equal(t, `"192.168.1.50"`, attrs["host"])
+	assert.Equal(t, "514", attrs["port"])
+	assert.Equal(t, `["device", "firewall_default_policy"]`, attrs["contents"])
+	assert.Equal(t, "false", attrs["log_all_contents"])
+
+	assert.Empty(t, SettingRsyslogBlocks("default", nil))
+}
+
+func TestSettingTeleportBlocks(t *testing.T) {
+	blocks := SettingTeleportBlocks("default", &settings.Teleport{
+		Enabled:    true,
+		SubnetCidr: "192.168.2.0/24",
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_teleport", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+
+	attrs := attrMapFromBlock(b)
+	assert.Equal(t, "true", attrs["enabled"])
+	assert.Equal(t, `"192.168.2.0/24"`, attrs["subnet_cidr"])
+
+	// An empty subnet is left to the controller.
+	blocks = SettingTeleportBlocks("default", &settings.Teleport{})
+	require.Len(t, blocks, 1)
+	_, hasSubnet := attrMapFromBlock(blocks[0])["subnet_cidr"]
+	assert.False(t, hasSubnet)
+
+	assert.Empty(t, SettingTeleportBlocks("default", nil))
+}
+
+func TestSettingConnectivityBlocks(t *testing.T) {
+	blocks := SettingConnectivityBlocks("default", &settings.Connectivity{
+		Enabled:    true,
+		UplinkType: "custom",
+		UplinkHost: "1.1.1.1",
+		XMeshPsk:   "s3cret",
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_connectivity", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"enabled":     "true",
+		"uplink_type": `"custom"`,
+		"uplink_host": `"1.1.1.1"`,
+	}, attrMapFromBlock(b))
+
+	assert.Empty(t, SettingConnectivityBlocks("default", nil))
+}
+
+func TestSettingGlobalSwitchBlocks(t *testing.T) {
+	blocks := SettingGlobalSwitchBlocks("default", &settings.GlobalSwitch{
+		AclDeviceIsolation: []string{"net1"},
+		AclL3Isolation: []settings.SettingGlobalSwitchAclL3Isolation{
+			{SourceNetwork: "net2", DestinationNetworks: []string{"net1", "net3"}},
+		},
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_global_switch", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"device_isolation_network_ids": `["net1"]`,
+		"l3_isolation":                 `[{ source_network_id = "net2", destination_network_ids = ["net1", "net3"] }]`,
+	}, attrMapFromBlock(b))
+
+	// Empty lists are left unmanaged.
+	blocks = SettingGlobalSwitchBlocks("default", &settings.GlobalSwitch{})
+	require.Len(t, blocks, 1)
+	assert.Empty(t, blocks[0].Attributes)
+
+	assert.Empty(t, SettingGlobalSwitchBlocks("default", nil))
+}
+
+func TestSettingGuestAccessBlocks(t *testing.T) {
+	number, unit := int64(8), int64(60)
+	blocks := SettingGuestAccessBlocks("default", &settings.GuestAccess{
+		PortalEnabled:               true,
+		Auth:                        "hotspot",
+		PasswordEnabled:             true,
+		XPassword:                   "s3cret",
+		Expire:                      "custom",
+		ExpireNumber:                &number,
+		ExpireUnit:                  &unit,
+		RedirectEnabled:             true,
+		RedirectUrl:                 "https://example.com",
+		PortalCustomized:            true,
+		PortalCustomizedTitle:       "Welcome",
+		PortalCustomizedWelcomeText: "Hidden by its toggle",
+		PortalCustomizedTos:         "Be nice",
+		PortalCustomizedTosEnabled:  true,
+		PortalCustomizedBgColor:     "#1a1a1a",
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_guest_access", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"portal_enabled":          "true",
+		"auth":                    `"hotspot"`,
+		"voucher_enabled":         "false",
+		"password_enabled":        "true",
+		"password":                `"REPLACE_ME"`,
+		"expire_minutes":          "480",
+		"redirect_enabled":        "true",
+		"redirect_url":            `"https://example.com"`,
+		"portal_customized":       "true",
+		"portal_title":            `"Welcome"`,
+		"portal_terms_of_service": `"Be nice"`,
+		"portal_background_color": `"#1a1a1a"`,
+	}, attrMapFromBlock(b))
+
+	// Unsupported authentication methods are left to the controller.
+	blocks = SettingGuestAccessBlocks("default", &settings.GuestAccess{Auth: "facebook_wifi", Expire: "1440"})
+	require.Len(t, blocks, 1)
+	attrs := attrMapFromBlock(blocks[0])
+	assert.NotContains(t, attrs, "auth")
+	assert.NotContains(t, attrs, "password")
+	assert.Equal(t, "1440", attrs["expire_minutes"])
+
+	assert.Empty(t, SettingGuestAccessBlocks("default", nil))
+}
+
+func TestSettingIPSBlocks(t *testing.T) {
+	blocks := SettingIPSBlocks("default", &settings.Ips{
+		IPsMode: "ips",
+		Suppression: &settings.SettingIpsSuppression{
+			Whitelist: []settings.SettingIpsWhitelist{
+				{Direction: "src", Mode: "ip", Value: "192.168.1.10"},
+				{Direction: "dest", Mode: "subnet", Value: "10.0.0.0/8"},
+				{Direction: "both", Mode: "network", Value: "net1"},
+			},
+		},
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_ips", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"ips_mode": `"ips"`,
+		"allowlist": `[{ direction = "src", ip = "192.168.1.10" }, ` +
+			`{ direction = "dest", subnet = "10.0.0.0/8" }, ` +
+			`{ direction = "both", network_id = "net1" }]`,
+	}, attrMapFromBlock(b))
+
+	// An empty allowlist is left unmanaged.
+	blocks = SettingIPSBlocks("default", &settings.Ips{IPsMode: "disabled"})
+	require.Len(t, blocks, 1)
+	assert.NotContains(t, attrMapFromBlock(blocks[0]), "allowlist")
+
+	assert.Empty(t, SettingIPSBlocks("default", nil))
+}
+
+func TestSettingUSGBlocks(t *testing.T) {
+	blocks := SettingUSGBlocks("default", &settings.Usg{
+		MdnsEnabled:                    true,
+		UPnPEnabled:                    true,
+		UPnPWANInterface:               "WAN2",
+		DHCPRelayServer1:               "192.168.1.5",
+		DHCPRelayServer3:               "192.168.1.6",
+		OffloadSch:                     true,
+		GeoIPFilteringEnabled:          true,
+		GeoIPFilteringBlock:            "block",
+		GeoIPFilteringCountries:        "CN,RU",
+		GeoIPFilteringTrafficDirection: "both",
+	})
+	require.Len(t, blocks, 1)
+
+	b := blocks[0]
+	assert.Equal(t, "terrifi_setting_usg", b.ResourceType)
+	assert.Equal(t, "default", b.ImportID)
+	assert.Equal(t, map[string]string{
+		"mdns_enabled":               "true",
+		"upnp_enabled":               "true",
+		"upnp_nat_pmp_enabled":       "false",
+		"upnp_secure_mode":           "false",
+		"upnp_wan_interface":         `"WAN2"`,
+		"dhcp_relay_servers":         `["192.168.1.5", "192.168.1.6"]`,
+		"offload_accounting":         "false",
+		"offload_l2_blocking":        "false",
+		"offload_scheduler":          "true",
+		"geo_ip_filtering_enabled":   "true",
+		"geo_ip_filtering_action":    `"block"`,
+		"geo_ip_filtering_countries": `["CN", "RU"]`,
+		"geo_ip_filtering_direction": `"both"`,
+	}, attrMapFromBlock(b))
+
+	assert.Empty(t, SettingUSGBlocks("default", nil))
+}
+
 // ---------------------------------------------------------------------------
 // Helpers
 // ---------------------------------------------------------------------------
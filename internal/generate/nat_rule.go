@@ -0,0 +1,116 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// NATRuleBlocks generates import + resource blocks for NAT rules. The
+// controller's predefined rules are skipped. Network IDs in source and
+// destination filters are resolved through refs, which may be nil.
+func NATRuleBlocks(rules []unifi.Nat, refs *References) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(rules))
+	for _, r := range rules {
+		if r.IsPredefined {
+			continue
+		}
+		block := ResourceBlock{
+			ResourceType: "terrifi_nat_rule",
+			ResourceName: ToTerraformName(r.Description),
+			ImportID:     r.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "description", Value: HCLString(r.Description)})
+		block.Attributes = append(block.Attributes, Attr{Key: "type", Value: HCLString(r.Type)})
+
+		if !r.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if r.IPVersion != "" && r.IPVersion != "IPV4" {
+			block.Attributes = append(block.Attributes, Attr{Key: "ip_version", Value: HCLString(r.IPVersion)})
+		}
+		if r.Protocol != "" && r.Protocol != "all" {
+			block.Attributes = append(block.Attributes, Attr{Key: "protocol", Value: HCLString(r.Protocol)})
+		}
+		// Interfaces are usually WANs, which refs doesn't resolve.
+		if r.InInterface != "" {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "in_interface",
+				Value:   HCLString(r.InInterface),
+				Comment: "TODO: find and reference corresponding terrifi_network or terrifi_wan resource",
+			})
+		}
+		if r.OutInterface != "" {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "out_interface",
+				Value:   HCLString(r.OutInterface),
+				Comment: "TODO: find and reference corresponding terrifi_network or terrifi_wan resource",
+			})
+		}
+		if r.IPAddress != "" {
+			block.Attributes = append(block.Attributes, Attr{Key: "ip_address", Value: HCLString(r.IPAddress)})
+		}
+		if r.Port != nil && *r.Port != 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "port", Value: HCLInt64(*r.Port)})
+		}
+		if r.Exclude {
+			block.Attributes = append(block.Attributes, Attr{Key: "exclude", Value: HCLBool(true)})
+		}
+		if r.Logging {
+			block.Attributes = append(block.Attributes, Attr{Key: "logging", Value: HCLBool(true)})
+		}
+
+		if nb, ok := buildNATFilterBlock(refs, "source", r.SourceFilter); ok {
+			block.Blocks = append(block.Blocks, nb)
+		}
+		if nb, ok := buildNATFilterBlock(refs, "destination", (*unifi.NatSourceFilter)(r.DestinationFilter)); ok {
+			block.Blocks = append(block.Blocks, nb)
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
+
+// buildNATFilterBlock returns the source or destination block for a NAT
+// filter. Only the attributes of the filter's type are written, since the
+// controller keeps the others when the type is changed in the UI. It reports
+// false for filters that match everything.
+func buildNATFilterBlock(refs *References, name string, f *unifi.NatSourceFilter) (NestedBlock, bool) {
+	nb := NestedBlock{Name: name}
+	if f == nil {
+		return nb, false
+	}
+
+	switch f.FilterType {
+	case "NETWORK_CONF":
+		if f.NetworkConfID != "" {
+			nb.Attributes = append(nb.Attributes, networkAttr(refs, "network_id", f.NetworkConfID))
+		}
+	case "FIREWALL_GROUPS":
+		if len(f.FirewallGroupIDs) > 0 {
+			nb.Attributes = append(nb.Attributes, Attr{
+				Key:     "firewall_group_ids",
+				Value:   HCLStringList(f.FirewallGroupIDs),
+				Comment: "TODO: find and reference corresponding terrifi_firewall_group resources",
+			})
+		}
+	case "ADDRESS_AND_PORT":
+		if f.Address != "" {
+			nb.Attributes = append(nb.Attributes, Attr{Key: "address", Value: HCLString(f.Address)})
+		}
+	default:
+		return nb, false
+	}
+
+	if f.Port != nil && *f.Port != 0 {
+		nb.Attributes = append(nb.Attributes, Attr{Key: "port", Value: HCLInt64(*f.Port)})
+	}
+	if f.InvertAddress {
+		nb.Attributes = append(nb.Attributes, Attr{Key: "invert_address", Value: HCLBool(true)})
+	}
+	if f.InvertPort {
+		nb.Attributes = append(nb.Attributes, Attr{Key: "invert_port", Value: HCLBool(true)})
+	}
+	return nb, true
+}
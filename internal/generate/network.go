@@ -1,6 +1,9 @@
 package generate
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -53,26 +56,56 @@ func NetworkBlocks(networks []unifi.Network) []ResourceBlock {
 					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_lease", Value: HCLInt64(*n.DHCPDLeaseTime)})
 				}
 
+				// Servers left over from manual mode are ignored by the
+				// controller while DNS is auto, so only emit them when enabled.
 				var dnsServers []string
-				if n.DHCPDDNS1 != "" {
-					dnsServers = append(dnsServers, n.DHCPDDNS1)
+				if n.DHCPDDNSEnabled {
+					for _, dns := range []string{n.DHCPDDNS1, n.DHCPDDNS2, n.DHCPDDNS3, n.DHCPDDNS4} {
+						if dns != "" {
+							dnsServers = append(dnsServers, dns)
+						}
+					}
 				}
-				if n.DHCPDDNS2 != "" {
-					dnsServers = append(dnsServers, n.DHCPDDNS2)
+				if len(dnsServers) > 0 {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_dns", Value: HCLStringList(dnsServers)})
 				}
-				if n.DHCPDDNS3 != "" {
-					dnsServers = append(dnsServers, n.DHCPDDNS3)
+				if n.DHCPDConflictChecking {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_ping_check", Value: HCLBool(true)})
 				}
-				if n.DHCPDDNS4 != "" {
-					dnsServers = append(dnsServers, n.DHCPDDNS4)
+				if n.DHCPDGatewayEnabled && n.DHCPDGateway != nil && *n.DHCPDGateway != "" {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_gateway", Value: HCLString(*n.DHCPDGateway)})
 				}
-				if len(dnsServers) > 0 {
-					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_dns", Value: HCLStringList(dnsServers)})
+				var ntpServers []string
+				if n.DHCPDNtpEnabled {
+					for _, ntp := range []*string{n.DHCPDNtp1, n.DHCPDNtp2} {
+						if ntp != nil && *ntp != "" {
+							ntpServers = append(ntpServers, *ntp)
+						}
+					}
 				}
+				if len(ntpServers) > 0 {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_ntp", Value: HCLStringList(ntpServers)})
+				}
+				if n.DHCPDBootEnabled && n.DHCPDBootServer != "" {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_boot_server", Value: HCLString(n.DHCPDBootServer)})
+					if n.DHCPDBootFilename != nil && *n.DHCPDBootFilename != "" {
+						block.Attributes = append(block.Attributes, Attr{Key: "dhcp_boot_filename", Value: HCLString(*n.DHCPDBootFilename)})
+					}
+				}
+				if n.DHCPDUnifiController != nil && *n.DHCPDUnifiController != "" {
+					block.Attributes = append(block.Attributes, Attr{Key: "dhcp_unifi_controller", Value: HCLString(*n.DHCPDUnifiController)})
+				}
+			}
+			if n.DHCPRelayEnabled && len(n.DHCPRelayServers) > 0 {
+				block.Attributes = append(block.Attributes, Attr{Key: "dhcp_relay_enabled", Value: HCLBool(true)})
+				block.Attributes = append(block.Attributes, Attr{Key: "dhcp_relay_servers", Value: HCLStringList(n.DHCPRelayServers)})
 			}
 			if !n.InternetAccessEnabled {
 				block.Attributes = append(block.Attributes, Attr{Key: "internet_access_enabled", Value: HCLBool(false)})
 			}
+			if natOutbound := natOutboundHCL(n.NATOutboundIPAddresses); natOutbound != "" {
+				block.Attributes = append(block.Attributes, Attr{Key: "nat_outbound", Value: natOutbound})
+			}
 		}
 
 		blocks = append(blocks, block)
@@ -80,3 +113,27 @@ func NetworkBlocks(networks []unifi.Network) []ResourceBlock {
 	DeduplicateNames(blocks)
 	return blocks
 }
+
+// natOutboundHCL renders the outbound NAT entries that pin an address as a
+// nat_outbound list, or "" if there are none. Entries in "all" mode are the
+// default and are left out.
+func natOutboundHCL(entries []unifi.NetworkNATOutboundIPAddresses) string {
+	var objs []string
+	for _, e := range entries {
+		if e.WANNetworkGroup == nil || e.Mode == nil {
+			continue
+		}
+		switch *e.Mode {
+		case "ip_address":
+			objs = append(objs, fmt.Sprintf("{ wan_network_group = %s, ip_address = %s }",
+				HCLString(*e.WANNetworkGroup), HCLString(e.IPAddress)))
+		case "ip_address_pool":
+			objs = append(objs, fmt.Sprintf("{ wan_network_group = %s, ip_address_pool = %s }",
+				HCLString(*e.WANNetworkGroup), HCLStringList(e.IPAddressPool)))
+		}
+	}
+	if len(objs) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(objs, ", ") + "]"
+}
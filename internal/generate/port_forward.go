@@ -0,0 +1,43 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// PortForwardBlocks generates import + resource blocks for port forwarding
+// rules. Attributes at their default value are omitted.
+func PortForwardBlocks(forwards []unifi.PortForward) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(forwards))
+	for _, f := range forwards {
+		block := ResourceBlock{
+			ResourceType: "terrifi_port_forward",
+			ResourceName: ToTerraformName(f.Name),
+			ImportID:     f.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(f.Name)})
+		block.Attributes = append(block.Attributes, Attr{Key: "dst_port", Value: HCLString(f.DstPort)})
+		block.Attributes = append(block.Attributes, Attr{Key: "fwd_ip", Value: HCLString(f.Fwd)})
+		block.Attributes = append(block.Attributes, Attr{Key: "fwd_port", Value: HCLString(f.FwdPort)})
+
+		if !f.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if f.Proto != "" && f.Proto != "tcp_udp" {
+			block.Attributes = append(block.Attributes, Attr{Key: "protocol", Value: HCLString(f.Proto)})
+		}
+		if f.PfwdInterface != "" && f.PfwdInterface != "wan" {
+			block.Attributes = append(block.Attributes, Attr{Key: "wan_interface", Value: HCLString(f.PfwdInterface)})
+		}
+		if f.Src != "" && f.Src != "any" {
+			block.Attributes = append(block.Attributes, Attr{Key: "source", Value: HCLString(f.Src)})
+		}
+		if f.Log {
+			block.Attributes = append(block.Attributes, Attr{Key: "log", Value: HCLBool(true)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// PortProfileBlocks generates import + resource blocks for switch port
+// profiles. Attributes at their defaults are omitted. Network IDs are
+// resolved through refs, which may be nil.
+func PortProfileBlocks(profiles []unifi.PortProfile, refs *References) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(profiles))
+	for _, p := range profiles {
+		block := ResourceBlock{
+			ResourceType: "terrifi_port_profile",
+			ResourceName: ToTerraformName(p.Name),
+			ImportID:     p.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(p.Name)})
+
+		if p.NATiveNetworkID != "" {
+			block.Attributes = append(block.Attributes, networkAttr(refs, "native_network_id", p.NATiveNetworkID))
+		}
+		if p.TaggedVLANMgmt != "" && p.TaggedVLANMgmt != "auto" {
+			block.Attributes = append(block.Attributes, Attr{Key: "tagged_vlan_mgmt", Value: HCLString(p.TaggedVLANMgmt)})
+		}
+		if p.TaggedVLANMgmt == "custom" && len(p.ExcludedNetworkIDs) > 0 {
+			if ref, ok := refs.Networks(p.ExcludedNetworkIDs); ok {
+				block.Attributes = append(block.Attributes, Attr{Key: "excluded_network_ids", Value: ref})
+			} else {
+				block.Attributes = append(block.Attributes, Attr{
+					Key:     "excluded_network_ids",
+					Value:   HCLStringList(p.ExcludedNetworkIDs),
+					Comment: "TODO: find and reference corresponding terrifi_network resources",
+				})
+			}
+		}
+		if p.VoiceNetworkID != "" {
+			block.Attributes = append(block.Attributes, networkAttr(refs, "voice_network_id", p.VoiceNetworkID))
+		}
+		if p.PoeMode == "off" {
+			block.Attributes = append(block.Attributes, Attr{Key: "poe_mode", Value: HCLString("off")})
+		}
+		if p.Isolation {
+			block.Attributes = append(block.Attributes, Attr{Key: "isolation", Value: HCLBool(true)})
+		}
+		if !p.StpPortMode {
+			block.Attributes = append(block.Attributes, Attr{Key: "stp_enabled", Value: HCLBool(false)})
+		}
+		if !p.LldpmedEnabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "lldpmed_enabled", Value: HCLBool(false)})
+		}
+		if p.Dot1XCtrl != "" && p.Dot1XCtrl != "force_authorized" {
+			block.Attributes = append(block.Attributes, Attr{Key: "dot1x_ctrl", Value: HCLString(p.Dot1XCtrl)})
+		}
+		if p.Dot1XCtrl == "mac_based" && p.Dot1XIDleTimeout != nil && *p.Dot1XIDleTimeout != 300 {
+			block.Attributes = append(block.Attributes, Attr{Key: "dot1x_idle_timeout", Value: HCLInt64(*p.Dot1XIDleTimeout)})
+		}
+		if p.PortSecurityEnabled && len(p.PortSecurityMACAddress) > 0 {
+			macs := make([]string, len(p.PortSecurityMACAddress))
+			for i, mac := range p.PortSecurityMACAddress {
+				macs[i] = strings.ToLower(mac)
+			}
+			block.Attributes = append(block.Attributes, Attr{Key: "port_security_mac_addresses", Value: HCLStringList(macs)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
+
+// networkAttr returns a network ID attribute, referencing the network when
+// refs can resolve it.
+func networkAttr(refs *References, key, networkID string) Attr {
+	if ref, ok := refs.Network(networkID); ok {
+		return Attr{Key: key, Value: ref}
+	}
+	return Attr{
+		Key:     key,
+		Value:   HCLString(networkID),
+		Comment: "TODO: find and reference corresponding terrifi_network resource",
+	}
+}
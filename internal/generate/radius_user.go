@@ -0,0 +1,43 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// RADIUSUserBlocks generates import + resource blocks for users of the
+// built-in RADIUS server. Accounts the controller marks as hidden or
+// read-only are its own and are skipped.
+func RADIUSUserBlocks(accounts []unifi.Account) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(accounts))
+	for _, a := range accounts {
+		if a.Hidden || a.NoEdit {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_radius_user",
+			ResourceName: ToTerraformName(a.Name),
+			ImportID:     a.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(a.Name)})
+		block.Attributes = append(block.Attributes, Attr{
+			Key:     "password",
+			Value:   HCLString("REPLACE_ME"),
+			Comment: "SENSITIVE: not written to generated configuration",
+		})
+		if a.VLAN != nil {
+			block.Attributes = append(block.Attributes, Attr{Key: "vlan", Value: HCLInt64(*a.VLAN)})
+		}
+		if a.TunnelType != nil && *a.TunnelType != 13 {
+			block.Attributes = append(block.Attributes, Attr{Key: "tunnel_type", Value: HCLInt64(*a.TunnelType)})
+		}
+		if a.TunnelMediumType != nil && *a.TunnelMediumType != 6 {
+			block.Attributes = append(block.Attributes, Attr{Key: "tunnel_medium_type", Value: HCLInt64(*a.TunnelMediumType)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
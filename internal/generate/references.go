@@ -0,0 +1,167 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// DataSourceBlock represents one data {} block that looks up an existing
+// object by name.
+type DataSourceBlock struct {
+	DataSourceType string
+	Name           string
+	Attributes     []Attr
+}
+
+// References resolves the IDs of zones and networks that generated resources
+// point to. An object generated in the same run is referenced by its resource
+// address; any other object gets a data source block that looks it up by
+// name, so the generated configuration plans without hand-editing. IDs that
+// cannot be resolved stay literal with a TODO comment.
+//
+// A nil *References resolves nothing.
+type References struct {
+	names     map[string]map[string]string // data source type → object ID → object name
+	resources map[string]map[string]string // resource type → object ID → resource address
+	data      map[string]map[string]string // data source type → object ID → data source address
+	dataNames map[string]map[string]bool   // data source type → block names in use
+	blocks    []DataSourceBlock
+}
+
+// NewReferences returns References that can resolve the given zones and
+// networks.
+func NewReferences(zones []unifi.FirewallZone, networks []unifi.Network) *References {
+	r := &References{
+		names: map[string]map[string]string{
+			"terrifi_firewall_zone": {},
+			"terrifi_network":       {},
+		},
+		resources: map[string]map[string]string{},
+		data:      map[string]map[string]string{},
+		dataNames: map[string]map[string]bool{},
+	}
+	for _, z := range zones {
+		if z.Name != "" {
+			r.names["terrifi_firewall_zone"][z.ID] = z.Name
+		}
+	}
+	for _, n := range networks {
+		if n.Name != nil && *n.Name != "" {
+			r.names["terrifi_network"][n.ID] = *n.Name
+		}
+	}
+	return r
+}
+
+// AddResources registers generated blocks so that references to their objects
+// use the resource address instead of a data source. Call it before
+// generating the blocks that refer to them.
+func (r *References) AddResources(blocks []ResourceBlock) {
+	if r == nil {
+		return
+	}
+	for _, b := range blocks {
+		if r.resources[b.ResourceType] == nil {
+			r.resources[b.ResourceType] = map[string]string{}
+		}
+		r.resources[b.ResourceType][b.ImportID] = b.ResourceType + "." + b.ResourceName + ".id"
+	}
+}
+
+// Zone returns an HCL expression for the ID of the given firewall zone.
+func (r *References) Zone(id string) (string, bool) {
+	return r.ref("terrifi_firewall_zone", id)
+}
+
+// Network returns an HCL expression for the ID of the given network.
+func (r *References) Network(id string) (string, bool) {
+	return r.ref("terrifi_network", id)
+}
+
+// Networks returns an HCL list expression for the given network IDs. It
+// reports false unless every ID resolves.
+func (r *References) Networks(ids []string) (string, bool) {
+	// Check every ID first so a partial match does not leave behind data
+	// sources that nothing uses.
+	for _, id := range ids {
+		if !r.resolvable("terrifi_network", id) {
+			return "", false
+		}
+	}
+	refs := make([]string, len(ids))
+	for i, id := range ids {
+		refs[i], _ = r.Network(id)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(refs, ", ")), true
+}
+
+// DataSourceBlocks returns the data source blocks needed by the references
+// handed out so far, in the order they were first used.
+func (r *References) DataSourceBlocks() []DataSourceBlock {
+	if r == nil {
+		return nil
+	}
+	return r.blocks
+}
+
+func (r *References) resolvable(typ, id string) bool {
+	if r == nil {
+		return false
+	}
+	_, isResource := r.resources[typ][id]
+	_, hasName := r.names[typ][id]
+	return isResource || hasName
+}
+
+func (r *References) ref(typ, id string) (string, bool) {
+	if r == nil || id == "" {
+		return "", false
+	}
+	if addr, ok := r.resources[typ][id]; ok {
+		return addr, true
+	}
+	if addr, ok := r.data[typ][id]; ok {
+		return addr, true
+	}
+	name, ok := r.names[typ][id]
+	if !ok {
+		return "", false
+	}
+
+	if r.data[typ] == nil {
+		r.data[typ] = map[string]string{}
+		r.dataNames[typ] = map[string]bool{}
+	}
+	blockName := ToTerraformName(name)
+	for i := 2; r.dataNames[typ][blockName]; i++ {
+		blockName = fmt.Sprintf("%s_%d", ToTerraformName(name), i)
+	}
+	r.dataNames[typ][blockName] = true
+
+	addr := "data." + typ + "." + blockName + ".id"
+	r.data[typ][id] = addr
+	r.blocks = append(r.blocks, DataSourceBlock{
+		DataSourceType: typ,
+		Name:           blockName,
+		Attributes:     []Attr{{Key: "name", Value: HCLString(name)}},
+	})
+	return addr, true
+}
+
+var dataSourceTemplate = template.Must(template.New("data").Parse(`{{- range . -}}
+data "{{ .DataSourceType }}" "{{ .Name }}" {
+{{- range .Attributes }}
+  {{ .Key }} = {{ .Value }}
+{{- end }}
+}
+
+{{ end }}`))
+
+// WriteDataSourceBlocks renders the given DataSourceBlocks as HCL to the writer.
+func WriteDataSourceBlocks(w io.Writer, blocks []DataSourceBlock) error {
+	return dataSourceTemplate.Execute(w, blocks)
+}
@@ -0,0 +1,325 @@
+package generate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// SettingConnectivityBlocks generates the import + resource block for a
+// site's uplink connectivity monitor setting.
+func SettingConnectivityBlocks(site string, s *settings.Connectivity) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_connectivity",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(s.Enabled)})
+	if s.UplinkType != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "uplink_type", Value: HCLString(s.UplinkType)})
+	}
+	if s.UplinkHost != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "uplink_host", Value: HCLString(s.UplinkHost)})
+	}
+	return []ResourceBlock{block}
+}
+
+// SettingCountryBlocks generates the import + resource block for a site's
+// country setting. Settings are per-site singletons, so the import ID is the
+// site name.
+func SettingCountryBlocks(site string, s *settings.Country) []ResourceBlock {
+	if s == nil || s.Code == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_country",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "code", Value: HCLInt64(*s.Code)})
+	return []ResourceBlock{block}
+}
+
+// SettingGlobalSwitchBlocks generates the import + resource block for a
+// site's switch isolation ACLs. Empty lists are omitted, which leaves them
+// unmanaged.
+func SettingGlobalSwitchBlocks(site string, s *settings.GlobalSwitch) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_global_switch",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	if len(s.AclDeviceIsolation) > 0 {
+		block.Attributes = append(block.Attributes, Attr{
+			Key:     "device_isolation_network_ids",
+			Value:   HCLStringList(s.AclDeviceIsolation),
+			Comment: "TODO: find and reference corresponding terrifi_network resources",
+		})
+	}
+	if len(s.AclL3Isolation) > 0 {
+		objs := make([]string, 0, len(s.AclL3Isolation))
+		for _, a := range s.AclL3Isolation {
+			objs = append(objs, fmt.Sprintf("{ source_network_id = %s, destination_network_ids = %s }",
+				HCLString(a.SourceNetwork), HCLStringList(a.DestinationNetworks)))
+		}
+		block.Attributes = append(block.Attributes, Attr{
+			Key:     "l3_isolation",
+			Value:   "[" + strings.Join(objs, ", ") + "]",
+			Comment: "TODO: find and reference corresponding terrifi_network resources",
+		})
+	}
+	return []ResourceBlock{block}
+}
+
+// SettingGuestAccessBlocks generates the import + resource block for a site's
+// guest hotspot portal setting. The guest password is replaced with a
+// placeholder; it is read back into state on import.
+func SettingGuestAccessBlocks(site string, s *settings.GuestAccess) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_guest_access",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "portal_enabled", Value: HCLBool(s.PortalEnabled)})
+	// Other methods, such as Facebook Wi-Fi, are not supported by the resource.
+	switch s.Auth {
+	case "none", "hotspot", "custom":
+		block.Attributes = append(block.Attributes, Attr{Key: "auth", Value: HCLString(s.Auth)})
+	}
+	if s.CustomIP != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "custom_portal_ip", Value: HCLString(s.CustomIP)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "voucher_enabled", Value: HCLBool(s.VoucherEnabled)})
+	block.Attributes = append(block.Attributes, Attr{Key: "password_enabled", Value: HCLBool(s.PasswordEnabled)})
+	if s.PasswordEnabled {
+		block.Attributes = append(block.Attributes, Attr{
+			Key:     "password",
+			Value:   HCLString("REPLACE_ME"),
+			Comment: "SENSITIVE: not written to generated configuration",
+		})
+	}
+	if minutes, ok := guestAccessExpireMinutes(s); ok {
+		block.Attributes = append(block.Attributes, Attr{Key: "expire_minutes", Value: HCLInt64(minutes)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "redirect_enabled", Value: HCLBool(s.RedirectEnabled)})
+	if s.RedirectUrl != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "redirect_url", Value: HCLString(s.RedirectUrl)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "portal_customized", Value: HCLBool(s.PortalCustomized)})
+	if s.PortalCustomizedTitle != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "portal_title", Value: HCLString(s.PortalCustomizedTitle)})
+	}
+	// Text hidden by its toggle is left out, matching what guests see.
+	if s.PortalCustomizedWelcomeTextEnabled && s.PortalCustomizedWelcomeText != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "portal_welcome_text", Value: HCLString(s.PortalCustomizedWelcomeText)})
+	}
+	if s.PortalCustomizedTosEnabled && s.PortalCustomizedTos != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "portal_terms_of_service", Value: HCLString(s.PortalCustomizedTos)})
+	}
+	if s.PortalCustomizedBgColor != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "portal_background_color", Value: HCLString(s.PortalCustomizedBgColor)})
+	}
+	if s.PortalCustomizedButtonColor != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "portal_button_color", Value: HCLString(s.PortalCustomizedButtonColor)})
+	}
+	return []ResourceBlock{block}
+}
+
+// guestAccessExpireMinutes returns the guest authorization duration in
+// minutes. The controller stores either a preset number of minutes in expire,
+// or "custom" with a number and unit.
+func guestAccessExpireMinutes(s *settings.GuestAccess) (int64, bool) {
+	if s.Expire == "custom" {
+		if s.ExpireNumber == nil || s.ExpireUnit == nil {
+			return 0, false
+		}
+		return *s.ExpireNumber * *s.ExpireUnit, true
+	}
+	minutes, err := strconv.ParseInt(s.Expire, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return minutes, true
+}
+
+// SettingIPSBlocks generates the import + resource block for a site's
+// intrusion prevention setting. The allowlist is omitted when it is empty,
+// which leaves it unmanaged.
+func SettingIPSBlocks(site string, s *settings.Ips) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_ips",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	if s.IPsMode != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "ips_mode", Value: HCLString(s.IPsMode)})
+	}
+	if s.Suppression != nil && len(s.Suppression.Whitelist) > 0 {
+		objs := make([]string, 0, len(s.Suppression.Whitelist))
+		hasNetwork := false
+		for _, w := range s.Suppression.Whitelist {
+			key := "ip"
+			switch w.Mode {
+			case "subnet":
+				key = "subnet"
+			case "network":
+				key = "network_id"
+				hasNetwork = true
+			}
+			objs = append(objs, fmt.Sprintf("{ direction = %s, %s = %s }", HCLString(w.Direction), key, HCLString(w.Value)))
+		}
+		attr := Attr{Key: "allowlist", Value: "[" + strings.Join(objs, ", ") + "]"}
+		if hasNetwork {
+			attr.Comment = "TODO: find and reference corresponding terrifi_network resources"
+		}
+		block.Attributes = append(block.Attributes, attr)
+	}
+	return []ResourceBlock{block}
+}
+
+// SettingLocaleBlocks generates the import + resource block for a site's
+// locale setting.
+func SettingLocaleBlocks(site string, s *settings.Locale) []ResourceBlock {
+	if s == nil || s.Timezone == "" {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_locale",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "timezone", Value: HCLString(s.Timezone)})
+	return []ResourceBlock{block}
+}
+
+// SettingRadiusBlocks generates the import + resource block for a site's
+// built-in RADIUS server setting. The shared secret is omitted; it is read
+// back into state on import without being written to generated config.
+func SettingRadiusBlocks(site string, s *settings.Radius) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_radius",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(s.Enabled)})
+	if s.AuthPort != nil {
+		block.Attributes = append(block.Attributes, Attr{Key: "auth_port", Value: HCLInt64(*s.AuthPort)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "accounting_enabled", Value: HCLBool(s.AccountingEnabled)})
+	if s.AcctPort != nil {
+		block.Attributes = append(block.Attributes, Attr{Key: "acct_port", Value: HCLInt64(*s.AcctPort)})
+	}
+	if s.InterimUpdateInterval != nil {
+		block.Attributes = append(block.Attributes, Attr{Key: "interim_update_interval", Value: HCLInt64(*s.InterimUpdateInterval)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "tunneled_reply", Value: HCLBool(s.TunneledReply)})
+	return []ResourceBlock{block}
+}
+
+// SettingRsyslogBlocks generates the import + resource block for a site's
+// remote syslog setting.
+func SettingRsyslogBlocks(site string, s *settings.Rsyslogd) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_rsyslog",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(s.Enabled)})
+	if s.IP != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "host", Value: HCLString(s.IP)})
+	}
+	if s.Port != nil {
+		block.Attributes = append(block.Attributes, Attr{Key: "port", Value: HCLInt64(*s.Port)})
+	}
+	if len(s.Contents) > 0 {
+		block.Attributes = append(block.Attributes, Attr{Key: "contents", Value: HCLStringList(s.Contents)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "log_all_contents", Value: HCLBool(s.LogAllContents)})
+	return []ResourceBlock{block}
+}
+
+// SettingTeleportBlocks generates the import + resource block for a site's
+// Teleport (one-click VPN) setting.
+func SettingTeleportBlocks(site string, s *settings.Teleport) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_teleport",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(s.Enabled)})
+	if s.SubnetCidr != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "subnet_cidr", Value: HCLString(s.SubnetCidr)})
+	}
+	return []ResourceBlock{block}
+}
+
+// SettingUSGBlocks generates the import + resource block for a site's gateway
+// setting.
+func SettingUSGBlocks(site string, s *settings.Usg) []ResourceBlock {
+	if s == nil {
+		return nil
+	}
+	block := ResourceBlock{
+		ResourceType: "terrifi_setting_usg",
+		ResourceName: ToTerraformName(site),
+		ImportID:     site,
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "mdns_enabled", Value: HCLBool(s.MdnsEnabled)})
+	block.Attributes = append(block.Attributes, Attr{Key: "upnp_enabled", Value: HCLBool(s.UPnPEnabled)})
+	block.Attributes = append(block.Attributes, Attr{Key: "upnp_nat_pmp_enabled", Value: HCLBool(s.UPnPNATPmpEnabled)})
+	block.Attributes = append(block.Attributes, Attr{Key: "upnp_secure_mode", Value: HCLBool(s.UPnPSecureMode)})
+	if s.UPnPWANInterface != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "upnp_wan_interface", Value: HCLString(s.UPnPWANInterface)})
+	}
+	var servers []string
+	for _, server := range []string{
+		s.DHCPRelayServer1, s.DHCPRelayServer2, s.DHCPRelayServer3, s.DHCPRelayServer4, s.DHCPRelayServer5,
+	} {
+		if server != "" {
+			servers = append(servers, server)
+		}
+	}
+	if len(servers) > 0 {
+		block.Attributes = append(block.Attributes, Attr{Key: "dhcp_relay_servers", Value: HCLStringList(servers)})
+	}
+	block.Attributes = append(block.Attributes, Attr{Key: "offload_accounting", Value: HCLBool(s.OffloadAccounting)})
+	block.Attributes = append(block.Attributes, Attr{Key: "offload_l2_blocking", Value: HCLBool(s.OffloadL2Blocking)})
+	block.Attributes = append(block.Attributes, Attr{Key: "offload_scheduler", Value: HCLBool(s.OffloadSch)})
+	block.Attributes = append(block.Attributes, Attr{Key: "geo_ip_filtering_enabled", Value: HCLBool(s.GeoIPFilteringEnabled)})
+	if s.GeoIPFilteringBlock != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "geo_ip_filtering_action", Value: HCLString(s.GeoIPFilteringBlock)})
+	}
+	if s.GeoIPFilteringCountries != "" {
+		block.Attributes = append(block.Attributes, Attr{
+			Key:   "geo_ip_filtering_countries",
+			Value: HCLStringList(strings.Split(s.GeoIPFilteringCountries, ",")),
+		})
+	}
+	if s.GeoIPFilteringTrafficDirection != "" {
+		block.Attributes = append(block.Attributes, Attr{Key: "geo_ip_filtering_direction", Value: HCLString(s.GeoIPFilteringTrafficDirection)})
+	}
+	return []ResourceBlock{block}
+}
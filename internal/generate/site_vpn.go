@@ -0,0 +1,113 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// SiteVPNBlocks generates import + resource blocks for the site-to-site VPN
+// networks that NetworkBlocks leaves out. Only IPsec and WireGuard VPNs are
+// supported by terrifi_site_vpn. Pre-shared keys are replaced with a
+// placeholder, and the WireGuard private key is omitted; both are read back
+// into state on import. local_network_ids is left out, since exposing a
+// network applies to every tunnel at once.
+func SiteVPNBlocks(networks []unifi.Network) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(networks))
+	for _, n := range networks {
+		if n.Purpose != unifi.PurposeSiteVPN || n.VPNType == nil {
+			continue
+		}
+		var vpnType string
+		switch *n.VPNType {
+		case "ipsec-vpn":
+			vpnType = "ipsec"
+		case "wireguard-client":
+			vpnType = "wireguard"
+		default:
+			continue
+		}
+
+		name := ""
+		if n.Name != nil {
+			name = *n.Name
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_site_vpn",
+			ResourceName: ToTerraformName(name),
+			ImportID:     n.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(name)})
+		if vpnType != "ipsec" {
+			block.Attributes = append(block.Attributes, Attr{Key: "type", Value: HCLString(vpnType)})
+		}
+		if !n.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+
+		var remoteGateway, wanInterface *string
+		var preSharedKey bool
+		if vpnType == "wireguard" {
+			remoteGateway, wanInterface = n.WireguardClientPeerIP, n.WireguardInterface
+			preSharedKey = n.WireguardClientPresharedKeyEnabled
+		} else {
+			remoteGateway, wanInterface = n.IPSecPeerIP, n.IPSecInterface
+			preSharedKey = true
+		}
+		if remoteGateway != nil {
+			block.Attributes = append(block.Attributes, Attr{Key: "remote_gateway", Value: HCLString(*remoteGateway)})
+		}
+		if wanInterface != nil && *wanInterface != "" && *wanInterface != "wan" {
+			block.Attributes = append(block.Attributes, Attr{Key: "wan_interface", Value: HCLString(*wanInterface)})
+		}
+		if preSharedKey {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "pre_shared_key",
+				Value:   HCLString("REPLACE_ME"),
+				Comment: "SENSITIVE: not written to generated configuration",
+			})
+		}
+		block.Attributes = append(block.Attributes, Attr{Key: "remote_subnets", Value: HCLStringList(n.RemoteVPNSubnets)})
+		if n.RouteDistance != nil && *n.RouteDistance != 30 {
+			block.Attributes = append(block.Attributes, Attr{Key: "route_distance", Value: HCLInt64(*n.RouteDistance)})
+		}
+
+		if vpnType == "wireguard" {
+			if n.WireguardClientPeerPort != nil && *n.WireguardClientPeerPort != 51820 {
+				block.Attributes = append(block.Attributes, Attr{Key: "remote_port", Value: HCLInt64(*n.WireguardClientPeerPort)})
+			}
+			if n.WireguardClientPeerPublicKey != nil {
+				block.Attributes = append(block.Attributes, Attr{Key: "remote_public_key", Value: HCLString(*n.WireguardClientPeerPublicKey)})
+			}
+			if n.IPSubnet != nil && *n.IPSubnet != "" {
+				block.Attributes = append(block.Attributes, Attr{Key: "tunnel_ip", Value: HCLString(*n.IPSubnet)})
+			}
+		} else {
+			if !n.IPSecDynamicRouting {
+				block.Attributes = append(block.Attributes, Attr{Key: "route_based", Value: HCLBool(false)})
+			}
+			if n.IPSecTunnelIPEnabled && n.IPSecTunnelIP != nil && *n.IPSecTunnelIP != "" {
+				block.Attributes = append(block.Attributes, Attr{Key: "tunnel_ip", Value: HCLString(*n.IPSecTunnelIP)})
+			}
+			if n.IPSecKeyExchange != nil && *n.IPSecKeyExchange != "ikev2" {
+				block.Attributes = append(block.Attributes, Attr{Key: "ike_version", Value: HCLString(*n.IPSecKeyExchange)})
+			}
+			if n.IPSecIkeEncryption != nil && *n.IPSecIkeEncryption != "aes256" {
+				block.Attributes = append(block.Attributes, Attr{Key: "encryption", Value: HCLString(*n.IPSecIkeEncryption)})
+			}
+			if n.IPSecIkeHash != nil && *n.IPSecIkeHash != "sha256" {
+				block.Attributes = append(block.Attributes, Attr{Key: "hash", Value: HCLString(*n.IPSecIkeHash)})
+			}
+			if n.IPSecIkeDhGroup != nil && *n.IPSecIkeDhGroup != 14 {
+				block.Attributes = append(block.Attributes, Attr{Key: "dh_group", Value: HCLInt64(*n.IPSecIkeDhGroup)})
+			}
+			if !n.IPSecPfs {
+				block.Attributes = append(block.Attributes, Attr{Key: "pfs", Value: HCLBool(false)})
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
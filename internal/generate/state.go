@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ManagedResource is a resource that is already tracked in Terraform state,
+// as reported by `terraform show -json`.
+type ManagedResource struct {
+	Type   string
+	Values map[string]any
+}
+
+type stateModule struct {
+	Resources []struct {
+		Mode   string         `json:"mode"`
+		Type   string         `json:"type"`
+		Values map[string]any `json:"values"`
+	} `json:"resources"`
+	ChildModules []stateModule `json:"child_modules"`
+}
+
+// ParseState reads the JSON output of `terraform show -json` and returns the
+// managed resources in all modules. An empty state (no "values" key) yields
+// no resources.
+func ParseState(r io.Reader) ([]ManagedResource, error) {
+	var state struct {
+		Values *struct {
+			RootModule stateModule `json:"root_module"`
+		} `json:"values"`
+	}
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return nil, fmt.Errorf("decoding state JSON: %w", err)
+	}
+	if state.Values == nil {
+		return nil, nil
+	}
+
+	var managed []ManagedResource
+	var walk func(m stateModule)
+	walk = func(m stateModule) {
+		for _, res := range m.Resources {
+			if res.Mode != "managed" {
+				continue
+			}
+			managed = append(managed, ManagedResource{Type: res.Type, Values: res.Values})
+		}
+		for _, child := range m.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+	return managed, nil
+}
+
+// FilterUnmanaged splits blocks into those not yet tracked in state and those
+// that are. A block is managed when a resource of the same type in state has
+// the block's import ID as the value of its import attribute (see
+// importIDAttribute).
+func FilterUnmanaged(blocks []ResourceBlock, managed []ManagedResource) (unmanaged, skipped []ResourceBlock) {
+	seen := make(map[string]bool, len(managed))
+	for _, m := range managed {
+		if v, ok := m.Values[importIDAttribute(m.Type)].(string); ok {
+			seen[m.Type+"\x00"+v] = true
+		}
+	}
+
+	for _, b := range blocks {
+		if seen[b.ResourceType+"\x00"+b.ImportID] {
+			skipped = append(skipped, b)
+		} else {
+			unmanaged = append(unmanaged, b)
+		}
+	}
+	return unmanaged, skipped
+}
+
+// importIDAttribute returns the state attribute whose value equals the import
+// ID generated for the resource type. Settings are per-site singletons
+// imported by site name, and devices are imported by MAC address.
+func importIDAttribute(resourceType string) string {
+	switch {
+	case strings.HasPrefix(resourceType, "terrifi_setting_"):
+		return "site"
+	case resourceType == "terrifi_device":
+		return "mac"
+	default:
+		return "id"
+	}
+}
@@ -0,0 +1,75 @@
+package generate
+
+// TrafficRoute is a traffic route as read from the controller's v2 API. The
+// provider reads routes into its own type, so callers copy them into this one.
+type TrafficRoute struct {
+	ID          string
+	Description string
+	Enabled     bool
+	KillSwitch  bool
+
+	// NetworkID is the WAN or VPN client network matching traffic leaves
+	// through.
+	NetworkID string
+	NextHop   string
+
+	// ClientMACs and NetworkIDs are the route's CLIENT and NETWORK targets.
+	ClientMACs []string
+	NetworkIDs []string
+
+	// MatchingTarget selects which of the destination fields applies: DOMAIN,
+	// IP or REGION. Any other value matches all internet traffic. IPs holds
+	// addresses, subnets and "start-stop" ranges.
+	MatchingTarget string
+	Domains        []string
+	IPs            []string
+	Regions        []string
+}
+
+// TrafficRouteBlocks generates import + resource blocks for traffic routes.
+// Attributes at their default value are omitted.
+func TrafficRouteBlocks(routes []TrafficRoute) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(routes))
+	for _, r := range routes {
+		block := ResourceBlock{
+			ResourceType: "terrifi_traffic_route",
+			ResourceName: ToTerraformName(r.Description),
+			ImportID:     r.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "description", Value: HCLString(r.Description)})
+		block.Attributes = append(block.Attributes, Attr{
+			Key:     "interface_network_id",
+			Value:   HCLString(r.NetworkID),
+			Comment: "TODO: find and reference corresponding terrifi_network or terrifi_wan resource",
+		})
+		if !r.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if r.NextHop != "" {
+			block.Attributes = append(block.Attributes, Attr{Key: "next_hop", Value: HCLString(r.NextHop)})
+		}
+		if r.KillSwitch {
+			block.Attributes = append(block.Attributes, Attr{Key: "kill_switch", Value: HCLBool(true)})
+		}
+		if len(r.ClientMACs) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "mac_addresses", Value: HCLStringList(r.ClientMACs)})
+		}
+		if len(r.NetworkIDs) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "network_ids", Value: HCLStringList(r.NetworkIDs)})
+		}
+
+		switch {
+		case r.MatchingTarget == "DOMAIN" && len(r.Domains) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "domains", Value: HCLStringList(r.Domains)})
+		case r.MatchingTarget == "IP" && len(r.IPs) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "ips", Value: HCLStringList(r.IPs)})
+		case r.MatchingTarget == "REGION" && len(r.Regions) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "regions", Value: HCLStringList(r.Regions)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
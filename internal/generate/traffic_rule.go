@@ -0,0 +1,83 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// TrafficRule is a traffic rule as read from the controller's v2 API. The
+// SDK has no traffic rule type, so callers copy the provider's into this one.
+type TrafficRule struct {
+	ID          string
+	Description string
+	Action      string
+	Enabled     bool
+
+	// ClientMACs and NetworkIDs are the rule's CLIENT and NETWORK targets.
+	ClientMACs []string
+	NetworkIDs []string
+
+	// MatchingTarget selects which of the destination fields applies: APP,
+	// APP_CATEGORY, DOMAIN or REGION. Any other value matches all traffic.
+	MatchingTarget string
+	AppIDs         []int64
+	AppCategoryIDs []int64
+	Domains        []string
+	Regions        []string
+
+	Schedule  *unifi.FirewallPolicySchedule
+	DateStart string
+	DateEnd   string
+}
+
+// TrafficRuleBlocks generates import + resource blocks for traffic rules.
+// Attributes at their default value are omitted, as is an always-on schedule.
+func TrafficRuleBlocks(rules []TrafficRule) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(rules))
+	for _, r := range rules {
+		block := ResourceBlock{
+			ResourceType: "terrifi_traffic_rule",
+			ResourceName: ToTerraformName(r.Description),
+			ImportID:     r.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "description", Value: HCLString(r.Description)})
+		if r.Action != "" && r.Action != "BLOCK" {
+			block.Attributes = append(block.Attributes, Attr{Key: "action", Value: HCLString(r.Action)})
+		}
+		if !r.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if len(r.ClientMACs) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "mac_addresses", Value: HCLStringList(r.ClientMACs)})
+		}
+		if len(r.NetworkIDs) > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "network_ids", Value: HCLStringList(r.NetworkIDs)})
+		}
+
+		switch {
+		case r.MatchingTarget == "APP" && len(r.AppIDs) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "app_ids", Value: HCLInt64List(r.AppIDs)})
+		case r.MatchingTarget == "APP_CATEGORY" && len(r.AppCategoryIDs) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "app_category_ids", Value: HCLInt64List(r.AppCategoryIDs)})
+		case r.MatchingTarget == "DOMAIN" && len(r.Domains) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "domains", Value: HCLStringList(r.Domains)})
+		case r.MatchingTarget == "REGION" && len(r.Regions) > 0:
+			block.Attributes = append(block.Attributes, Attr{Key: "regions", Value: HCLStringList(r.Regions)})
+		}
+
+		if r.Schedule != nil && r.Schedule.Mode != "" && r.Schedule.Mode != "ALWAYS" {
+			sched := buildScheduleBlock(r.Schedule)
+			if r.DateStart != "" {
+				sched.Attributes = append(sched.Attributes, Attr{Key: "date_start", Value: HCLString(r.DateStart)})
+			}
+			if r.DateEnd != "" {
+				sched.Attributes = append(sched.Attributes, Attr{Key: "date_end", Value: HCLString(r.DateEnd)})
+			}
+			block.Blocks = append(block.Blocks, sched)
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
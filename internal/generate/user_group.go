@@ -0,0 +1,36 @@
+package generate
+
+import (
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// UserGroupBlocks generates import + resource blocks for user groups. Groups
+// the controller marks as hidden or read-only are its own and are skipped.
+// Rates are only written for enforced limits, since the controller stores a
+// disabled limit as unlimited.
+func UserGroupBlocks(groups []unifi.ClientGroup) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(groups))
+	for _, g := range groups {
+		if g.Hidden || g.NoEdit {
+			continue
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_user_group",
+			ResourceName: ToTerraformName(g.Name),
+			ImportID:     g.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(g.Name)})
+		if g.QOSRateMaxDown != nil && *g.QOSRateMaxDown > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "download_limit_kbps", Value: HCLInt64(*g.QOSRateMaxDown)})
+		}
+		if g.QOSRateMaxUp != nil && *g.QOSRateMaxUp > 0 {
+			block.Attributes = append(block.Attributes, Attr{Key: "upload_limit_kbps", Value: HCLInt64(*g.QOSRateMaxUp)})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
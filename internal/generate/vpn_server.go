@@ -0,0 +1,116 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// WireGuardPeer is a client of a WireGuard VPN server. The SDK has no type
+// for them, so callers copy the provider's into this one.
+type WireGuardPeer struct {
+	Name         string
+	InterfaceIP  string
+	PublicKey    string
+	PresharedKey string
+}
+
+// VPNServerBlocks generates import + resource blocks for the WireGuard and
+// L2TP VPN servers. peers holds the peers of each WireGuard server, by network
+// ID. The L2TP pre-shared key and the peers' pre-shared keys are replaced
+// with a placeholder, and the WireGuard private key is omitted; they are all
+// read back into state on import.
+func VPNServerBlocks(networks []unifi.Network, peers map[string][]WireGuardPeer) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(networks))
+	for _, n := range networks {
+		if n.Purpose != unifi.PurposeUserVPN || n.VPNType == nil {
+			continue
+		}
+		var serverType string
+		switch *n.VPNType {
+		case "wireguard-server":
+			serverType = "wireguard"
+		case "l2tp-server":
+			serverType = "l2tp"
+		default:
+			continue
+		}
+
+		name := ""
+		if n.Name != nil {
+			name = *n.Name
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_vpn_server",
+			ResourceName: ToTerraformName(name),
+			ImportID:     n.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(name)})
+		block.Attributes = append(block.Attributes, Attr{Key: "type", Value: HCLString(serverType)})
+		if !n.Enabled {
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
+		}
+		if n.IPSubnet != nil {
+			block.Attributes = append(block.Attributes, Attr{Key: "subnet", Value: HCLString(*n.IPSubnet)})
+		}
+
+		wanInterface := n.L2TpInterface
+		if serverType == "wireguard" {
+			wanInterface = n.WireguardInterface
+		}
+		if wanInterface != nil && *wanInterface != "" && *wanInterface != "wan" {
+			block.Attributes = append(block.Attributes, Attr{Key: "wan_interface", Value: HCLString(*wanInterface)})
+		}
+
+		switch serverType {
+		case "wireguard":
+			if n.LocalPort != nil && *n.LocalPort != 51820 {
+				block.Attributes = append(block.Attributes, Attr{Key: "listen_port", Value: HCLInt64(*n.LocalPort)})
+			}
+			if hcl := wireGuardPeersHCL(peers[n.ID]); hcl != "" {
+				attr := Attr{Key: "peers", Value: hcl}
+				if strings.Contains(hcl, "REPLACE_ME") {
+					attr.Comment = "SENSITIVE: pre-shared keys not written to generated configuration"
+				}
+				block.Attributes = append(block.Attributes, attr)
+			}
+		case "l2tp":
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "pre_shared_key",
+				Value:   HCLString("REPLACE_ME"),
+				Comment: "SENSITIVE: not written to generated configuration",
+			})
+			if n.RequireMschapv2 {
+				block.Attributes = append(block.Attributes, Attr{Key: "require_mschapv2", Value: HCLBool(true)})
+			}
+			if n.L2TpAllowWeakCiphers {
+				block.Attributes = append(block.Attributes, Attr{Key: "allow_weak_ciphers", Value: HCLBool(true)})
+			}
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
+
+// wireGuardPeersHCL renders the peers as a peers list, or "" if there are
+// none.
+func wireGuardPeersHCL(peers []WireGuardPeer) string {
+	var objs []string
+	for _, p := range peers {
+		obj := fmt.Sprintf("{ name = %s, interface_ip = %s, public_key = %s",
+			HCLString(p.Name), HCLString(p.InterfaceIP), HCLString(p.PublicKey))
+		if p.PresharedKey != "" {
+			obj += `, preshared_key = "REPLACE_ME"`
+		}
+		objs = append(objs, obj+" }")
+	}
+	if len(objs) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(objs, ", ") + "]"
+}
@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// WANBlocks generates import + resource blocks for the WAN networks that
+// NetworkBlocks leaves out. Only dhcp, static and pppoe WANs are supported by
+// terrifi_wan. The PPPoE password is omitted; it is read back into state on
+// import without being written to generated config.
+func WANBlocks(networks []unifi.Network) []ResourceBlock {
+	blocks := make([]ResourceBlock, 0, len(networks))
+	for _, n := range networks {
+		if n.Purpose != "wan" || n.WANNetworkGroup == nil || n.WANType == nil {
+			continue
+		}
+		wanType := *n.WANType
+		switch wanType {
+		case "dhcp", "static", "pppoe":
+			// supported
+		default:
+			continue
+		}
+
+		name := *n.WANNetworkGroup
+		if n.Name != nil && *n.Name != "" {
+			name = *n.Name
+		}
+
+		block := ResourceBlock{
+			ResourceType: "terrifi_wan",
+			ResourceName: ToTerraformName(name),
+			ImportID:     n.ID,
+		}
+
+		block.Attributes = append(block.Attributes, Attr{Key: "network_group", Value: HCLString(*n.WANNetworkGroup)})
+		block.Attributes = append(block.Attributes, Attr{Key: "name", Value: HCLString(name)})
+		block.Attributes = append(block.Attributes, Attr{Key: "type", Value: HCLString(wanType)})
+
+		if n.WANVLANEnabled && n.WANVLAN != nil {
+			block.Attributes = append(block.Attributes, Attr{Key: "vlan_id", Value: HCLInt64(*n.WANVLAN)})
+		}
+
+		switch wanType {
+		case "static":
+			if n.WANIP != nil && n.WANNetmask != nil {
+				ones, _ := net.IPMask(net.ParseIP(*n.WANNetmask).To4()).Size()
+				block.Attributes = append(block.Attributes, Attr{Key: "ip_address", Value: HCLString(fmt.Sprintf("%s/%d", *n.WANIP, ones))})
+			}
+			if n.WANGateway != nil {
+				block.Attributes = append(block.Attributes, Attr{Key: "gateway", Value: HCLString(*n.WANGateway)})
+			}
+		case "pppoe":
+			if n.WANUsername != "" {
+				block.Attributes = append(block.Attributes, Attr{Key: "pppoe_username", Value: HCLString(n.WANUsername)})
+			}
+		}
+
+		if n.WANDNSPreference != nil && *n.WANDNSPreference == "manual" {
+			var dnsServers []string
+			for _, dns := range []*string{n.WANDNS1, n.WANDNS2} {
+				if dns != nil && *dns != "" {
+					dnsServers = append(dnsServers, *dns)
+				}
+			}
+			if len(dnsServers) > 0 {
+				block.Attributes = append(block.Attributes, Attr{Key: "dns_servers", Value: HCLStringList(dnsServers)})
+			}
+		}
+
+		if n.WANSmartQEnabled && n.WANSmartQDownRate != nil && n.WANSmartQUpRate != nil {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:   "smart_queues",
+				Value: fmt.Sprintf("{ download_kbps = %d, upload_kbps = %d }", *n.WANSmartQDownRate, *n.WANSmartQUpRate),
+			})
+		}
+
+		blocks = append(blocks, block)
+	}
+	DeduplicateNames(blocks)
+	return blocks
+}
@@ -1,6 +1,8 @@
 package generate
 
 import (
+	"slices"
+
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -26,10 +28,28 @@ func WLANBlocks(wlans []unifi.WLAN) []ResourceBlock {
 			Comment: "TODO: find and reference corresponding terrifi_network resource",
 		})
 
+		if w.ApGroupMode == "groups" && len(w.ApGroupIDs) > 0 {
+			block.Attributes = append(block.Attributes, Attr{
+				Key:     "ap_group_ids",
+				Value:   HCLStringList(w.ApGroupIDs),
+				Comment: "TODO: find and reference corresponding terrifi_ap_group resources",
+			})
+		}
+
 		if !w.Enabled {
 			block.Attributes = append(block.Attributes, Attr{Key: "enabled", Value: HCLBool(false)})
 		}
-		if w.WLANBand != "" && w.WLANBand != "both" {
+		// wifi_band can't express 6 GHz, so a WLAN using it is written with
+		// the per-band flags instead.
+		if slices.Contains(w.WLANBands, "6g") {
+			if !slices.Contains(w.WLANBands, "2g") {
+				block.Attributes = append(block.Attributes, Attr{Key: "enabled_2g", Value: HCLBool(false)})
+			}
+			if !slices.Contains(w.WLANBands, "5g") {
+				block.Attributes = append(block.Attributes, Attr{Key: "enabled_5g", Value: HCLBool(false)})
+			}
+			block.Attributes = append(block.Attributes, Attr{Key: "enabled_6g", Value: HCLBool(true)})
+		} else if w.WLANBand != "" && w.WLANBand != "both" {
 			block.Attributes = append(block.Attributes, Attr{Key: "wifi_band", Value: HCLString(w.WLANBand)})
 		}
 		if w.Security != "" && w.Security != "wpapsk" {
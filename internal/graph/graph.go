@@ -0,0 +1,227 @@
+// Package graph renders the zone-based firewall layout of a UniFi site —
+// zones, the networks in each zone, and the policies between zones — as a
+// Graphviz DOT or Mermaid diagram. It is used by the terrifi CLI's graph
+// command.
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Network is a network shown inside its zone.
+type Network struct {
+	ID   string
+	Name string
+}
+
+// Zone is a firewall zone and the networks assigned to it.
+type Zone struct {
+	ID       string
+	Name     string
+	Networks []Network
+}
+
+// Policy is a firewall policy drawn as an edge between two zones.
+type Policy struct {
+	ID                string
+	Name              string
+	Action            string
+	Enabled           bool
+	SourceZoneID      string
+	DestinationZoneID string
+}
+
+// Graph is the diagram model: zones as nodes/groups, policies as edges.
+type Graph struct {
+	Zones    []Zone
+	Policies []Policy
+}
+
+// Options controls which controller objects are included in the graph.
+type Options struct {
+	// IncludePredefined includes the controller's built-in policies. There
+	// are dozens of them per site, so they are omitted by default.
+	IncludePredefined bool
+}
+
+// Build assembles a Graph from API data. Zones are sorted by name, networks
+// within a zone by name, and policies by index. Policies that reference a
+// zone not in zones are dropped.
+func Build(zones []unifi.FirewallZone, networks []unifi.Network, policies []*unifi.FirewallPolicy, opts Options) Graph {
+	networkNames := make(map[string]string, len(networks))
+	for _, n := range networks {
+		name := n.ID
+		if n.Name != nil && *n.Name != "" {
+			name = *n.Name
+		}
+		networkNames[n.ID] = name
+	}
+
+	var g Graph
+	zoneIDs := make(map[string]bool, len(zones))
+	for _, z := range zones {
+		zone := Zone{ID: z.ID, Name: z.Name}
+		for _, id := range z.NetworkIDs {
+			name, ok := networkNames[id]
+			if !ok {
+				name = id
+			}
+			zone.Networks = append(zone.Networks, Network{ID: id, Name: name})
+		}
+		sort.Slice(zone.Networks, func(i, j int) bool { return zone.Networks[i].Name < zone.Networks[j].Name })
+		g.Zones = append(g.Zones, zone)
+		zoneIDs[z.ID] = true
+	}
+	sort.Slice(g.Zones, func(i, j int) bool { return g.Zones[i].Name < g.Zones[j].Name })
+
+	sorted := make([]*unifi.FirewallPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.Predefined && !opts.IncludePredefined {
+			continue
+		}
+		if p.Source == nil || p.Destination == nil {
+			continue
+		}
+		if !zoneIDs[p.Source.ZoneID] || !zoneIDs[p.Destination.ZoneID] {
+			continue
+		}
+		sorted = append(sorted, p)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return policyIndex(sorted[i]) < policyIndex(sorted[j]) })
+
+	for _, p := range sorted {
+		g.Policies = append(g.Policies, Policy{
+			ID:                p.ID,
+			Name:              p.Name,
+			Action:            p.Action,
+			Enabled:           p.Enabled,
+			SourceZoneID:      p.Source.ZoneID,
+			DestinationZoneID: p.Destination.ZoneID,
+		})
+	}
+	return g
+}
+
+func policyIndex(p *unifi.FirewallPolicy) int64 {
+	if p.Index == nil {
+		return 0
+	}
+	return *p.Index
+}
+
+// edgeColor returns the edge color for a policy action: green for allow,
+// red for block/reject.
+func edgeColor(action string) string {
+	switch strings.ToUpper(action) {
+	case "ALLOW":
+		return "#2e7d32"
+	case "BLOCK", "REJECT":
+		return "#c62828"
+	default:
+		return "#555555"
+	}
+}
+
+func policyLabel(p Policy) string {
+	label := fmt.Sprintf("%s (%s)", p.Name, strings.ToLower(p.Action))
+	if !p.Enabled {
+		label += " [disabled]"
+	}
+	return label
+}
+
+// WriteDOT renders g as a Graphviz digraph. Each zone is a cluster containing
+// its networks; policy edges connect the clusters.
+func WriteDOT(w io.Writer, g Graph) error {
+	var b strings.Builder
+	b.WriteString("digraph firewall {\n")
+	b.WriteString("  compound=true;\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=rounded, fontname=\"Helvetica\"];\n")
+	b.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, z := range g.Zones {
+		fmt.Fprintf(&b, "\n  subgraph cluster_%s {\n", z.ID)
+		fmt.Fprintf(&b, "    label=%s;\n", dotQuote(z.Name))
+		// Policy edges attach to this invisible anchor and are clipped to
+		// the cluster border via ltail/lhead.
+		fmt.Fprintf(&b, "    %s [shape=point, style=invis];\n", zoneAnchor(z.ID))
+		for _, n := range z.Networks {
+			fmt.Fprintf(&b, "    %s [label=%s];\n", "n_"+n.ID, dotQuote(n.Name))
+		}
+		b.WriteString("  }\n")
+	}
+
+	if len(g.Policies) > 0 {
+		b.WriteString("\n")
+	}
+	for _, p := range g.Policies {
+		attrs := []string{
+			"label=" + dotQuote(policyLabel(p)),
+			"color=" + dotQuote(edgeColor(p.Action)),
+			"fontcolor=" + dotQuote(edgeColor(p.Action)),
+		}
+		if p.SourceZoneID != p.DestinationZoneID {
+			attrs = append(attrs, "ltail=cluster_"+p.SourceZoneID, "lhead=cluster_"+p.DestinationZoneID)
+		}
+		if !p.Enabled {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&b, "  %s -> %s [%s];\n", zoneAnchor(p.SourceZoneID), zoneAnchor(p.DestinationZoneID), strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid renders g as a Mermaid flowchart. Each zone is a subgraph
+// containing its networks; policy edges connect the subgraphs.
+func WriteMermaid(w io.Writer, g Graph) error {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+
+	for _, z := range g.Zones {
+		fmt.Fprintf(&b, "  subgraph %s[%s]\n", zoneAnchor(z.ID), mermaidQuote(z.Name))
+		if len(z.Networks) == 0 {
+			// Mermaid drops empty subgraphs, so keep a placeholder node.
+			fmt.Fprintf(&b, "    %s_empty[ ]\n", zoneAnchor(z.ID))
+			fmt.Fprintf(&b, "    style %s_empty fill:none,stroke:none\n", zoneAnchor(z.ID))
+		}
+		for _, n := range z.Networks {
+			fmt.Fprintf(&b, "    %s(%s)\n", "n_"+n.ID, mermaidQuote(n.Name))
+		}
+		b.WriteString("  end\n")
+	}
+
+	for i, p := range g.Policies {
+		arrow := "-->"
+		if !p.Enabled {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s|%s| %s\n", zoneAnchor(p.SourceZoneID), arrow, mermaidQuote(policyLabel(p)), zoneAnchor(p.DestinationZoneID))
+		fmt.Fprintf(&b, "  linkStyle %d stroke:%s\n", i, edgeColor(p.Action))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func zoneAnchor(id string) string {
+	return "z_" + id
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// mermaidQuote wraps s in double quotes, replacing characters Mermaid cannot
+// represent inside a quoted label with Mermaid entity codes.
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;").Replace(s) + `"`
+}
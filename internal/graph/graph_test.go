@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func strPtr(s string) *string { return &s }
+func int64Ptr(i int64) *int64 { return &i }
+
+func testGraph() Graph {
+	zones := []unifi.FirewallZone{
+		{ID: "zint", Name: "Internal", NetworkIDs: []string{"nlan", "nmgmt"}},
+		{ID: "ziot", Name: "IoT", NetworkIDs: []string{"niot"}},
+		{ID: "zext", Name: "External"},
+	}
+	networks := []unifi.Network{
+		{ID: "nlan", Name: strPtr("LAN")},
+		{ID: "nmgmt", Name: strPtr("Management")},
+		{ID: "niot", Name: strPtr(`IoT "things"`)},
+	}
+	policies := []*unifi.FirewallPolicy{
+		{
+			ID: "p2", Name: "Block IoT to LAN", Action: "BLOCK", Enabled: true, Index: int64Ptr(10002),
+			Source:      &unifi.FirewallPolicySource{ZoneID: "ziot"},
+			Destination: &unifi.FirewallPolicyDestination{ZoneID: "zint"},
+		},
+		{
+			ID: "p1", Name: "Allow LAN to IoT", Action: "ALLOW", Enabled: false, Index: int64Ptr(10001),
+			Source:      &unifi.FirewallPolicySource{ZoneID: "zint"},
+			Destination: &unifi.FirewallPolicyDestination{ZoneID: "ziot"},
+		},
+		{
+			ID: "p3", Name: "Built-in", Action: "ALLOW", Enabled: true, Predefined: true,
+			Source:      &unifi.FirewallPolicySource{ZoneID: "zint"},
+			Destination: &unifi.FirewallPolicyDestination{ZoneID: "zext"},
+		},
+		{
+			ID: "p4", Name: "Unknown zone", Action: "ALLOW", Enabled: true,
+			Source:      &unifi.FirewallPolicySource{ZoneID: "zgone"},
+			Destination: &unifi.FirewallPolicyDestination{ZoneID: "zext"},
+		},
+	}
+	return Build(zones, networks, policies, Options{})
+}
+
+func TestBuild(t *testing.T) {
+	g := testGraph()
+
+	require.Len(t, g.Zones, 3)
+	assert.Equal(t, "External", g.Zones[0].Name)
+	assert.Equal(t, "Internal", g.Zones[1].Name)
+	assert.Equal(t, "IoT", g.Zones[2].Name)
+	assert.Empty(t, g.Zones[0].Networks)
+	assert.Equal(t, []Network{{ID: "nlan", Name: "LAN"}, {ID: "nmgmt", Name: "Management"}}, g.Zones[1].Networks)
+
+	// Predefined and dangling policies are dropped; the rest sorted by index.
+	require.Len(t, g.Policies, 2)
+	assert.Equal(t, "p1", g.Policies[0].ID)
+	assert.Equal(t, "p2", g.Policies[1].ID)
+}
+
+func TestBuild_includePredefined(t *testing.T) {
+	zones := []unifi.FirewallZone{{ID: "a", Name: "A"}, {ID: "b", Name: "B"}}
+	policies := []*unifi.FirewallPolicy{
+		{
+			ID: "p", Name: "Built-in", Action: "ALLOW", Predefined: true,
+			Source:      &unifi.FirewallPolicySource{ZoneID: "a"},
+			Destination: &unifi.FirewallPolicyDestination{ZoneID: "b"},
+		},
+	}
+
+	assert.Empty(t, Build(zones, nil, policies, Options{}).Policies)
+	assert.Len(t, Build(zones, nil, policies, Options{IncludePredefined: true}).Policies, 1)
+}
+
+func TestBuild_unknownNetworkFallsBackToID(t *testing.T) {
+	g := Build([]unifi.FirewallZone{{ID: "a", Name: "A", NetworkIDs: []string{"missing"}}}, nil, nil, Options{})
+	assert.Equal(t, []Network{{ID: "missing", Name: "missing"}}, g.Zones[0].Networks)
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteDOT(&buf, testGraph()))
+	out := buf.String()
+
+	assert.Contains(t, out, "digraph firewall {")
+	assert.Contains(t, out, "subgraph cluster_zint {")
+	assert.Contains(t, out, `label="Internal";`)
+	assert.Contains(t, out, `n_niot [label="IoT \"things\""];`)
+	assert.Contains(t, out, `z_ziot -> z_zint [label="Block IoT to LAN (block)", color="#c62828", fontcolor="#c62828", ltail=cluster_ziot, lhead=cluster_zint];`)
+	assert.Contains(t, out, `label="Allow LAN to IoT (allow) [disabled]"`)
+	assert.Contains(t, out, "style=dashed")
+	assert.NotContains(t, out, "Built-in")
+}
+
+func TestWriteMermaid(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMermaid(&buf, testGraph()))
+	out := buf.String()
+
+	assert.Contains(t, out, "flowchart LR\n")
+	assert.Contains(t, out, `  subgraph z_zint["Internal"]`)
+	assert.Contains(t, out, `    n_niot("IoT #quot;things#quot;")`)
+	assert.Contains(t, out, "    z_zext_empty[ ]\n")
+	assert.Contains(t, out, `  z_zint -.->|"Allow LAN to IoT (allow) [disabled]"| z_ziot`)
+	assert.Contains(t, out, `  z_ziot -->|"Block IoT to LAN (block)"| z_zint`)
+	assert.Contains(t, out, "  linkStyle 1 stroke:#c62828\n")
+}
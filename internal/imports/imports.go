@@ -0,0 +1,77 @@
+// Package imports reads the import blocks of a Terraform configuration. It is
+// used by the terrifi CLI's verify-imports command to check import IDs against
+// the controller before terraform plan does.
+package imports
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// Block is an import block targeting a single managed resource.
+type Block struct {
+	Type string
+	Name string
+
+	// ID is the import ID, or empty when the id attribute is not a literal
+	// string (e.g. it refers to a variable) and can't be checked without
+	// evaluating the configuration.
+	ID string
+
+	// Range is the location of the block, for reporting.
+	Range hcl.Range
+}
+
+// Address returns the block's target, e.g. terrifi_network.iot.
+func (b Block) Address() string {
+	return b.Type + "." + b.Name
+}
+
+// Parse returns the import blocks of one configuration file. Blocks that use
+// for_each or target a module or a resource instance are skipped, since their
+// targets depend on evaluating the configuration.
+func Parse(src []byte, filename string) ([]Block, error) {
+	f, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("parsing %s: %s", filename, diags.Error())
+	}
+
+	var blocks []Block
+	for _, block := range f.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "import" {
+			continue
+		}
+		attrs := block.Body.Attributes
+		to, ok := attrs["to"]
+		if !ok || attrs["for_each"] != nil {
+			continue
+		}
+		traversal, diags := hcl.AbsTraversalForExpr(to.Expr)
+		if diags.HasErrors() || len(traversal) != 2 {
+			continue
+		}
+		name, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			continue
+		}
+
+		b := Block{
+			Type:  traversal.RootName(),
+			Name:  name.Name,
+			Range: block.DefRange(),
+		}
+		if id, ok := attrs["id"]; ok {
+			// Decoding without an evaluation context fails for anything but
+			// a constant.
+			var s string
+			if diags := gohcl.DecodeExpression(id.Expr, nil, &s); !diags.HasErrors() {
+				b.ID = s
+			}
+		}
+		blocks = append(blocks, b)
+	}
+	return blocks, nil
+}
@@ -0,0 +1,66 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	src := `import {
+  to = terrifi_network.lan
+  id = "abc123"
+}
+
+import {
+  to = terrifi_setting_locale.default
+  id = "default"
+}
+
+import {
+  to = terrifi_wlan.home
+  id = var.home_wlan_id
+}
+
+import {
+  to = terrifi_network.vlans[0]
+  id = "def456"
+}
+
+import {
+  for_each = var.ids
+  to       = terrifi_dns_record.r[each.key]
+  id       = each.value
+}
+
+import {
+  to = module.site.terrifi_network.lan
+  id = "ghi789"
+}
+
+resource "terrifi_network" "lan" {
+  name = "LAN"
+}
+`
+	blocks, err := Parse([]byte(src), "imports.tf")
+	require.NoError(t, err)
+	require.Len(t, blocks, 3)
+
+	assert.Equal(t, "terrifi_network.lan", blocks[0].Address())
+	assert.Equal(t, "abc123", blocks[0].ID)
+	assert.Equal(t, "imports.tf", blocks[0].Range.Filename)
+	assert.Equal(t, 1, blocks[0].Range.Start.Line)
+
+	assert.Equal(t, "terrifi_setting_locale", blocks[1].Type)
+	assert.Equal(t, "default", blocks[1].ID)
+
+	// A non-literal ID is returned so it can be reported, but left empty.
+	assert.Equal(t, "terrifi_wlan.home", blocks[2].Address())
+	assert.Empty(t, blocks[2].ID)
+}
+
+func TestParse_invalid(t *testing.T) {
+	_, err := Parse([]byte(`import {`), "broken.tf")
+	assert.ErrorContains(t, err, "parsing broken.tf")
+}
@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &adminResource{}
+	_ resource.ResourceWithImportState = &adminResource{}
+)
+
+// emailRegexp loosely matches an email address; the controller does its own
+// validation.
+var emailRegexp = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func NewAdminResource() resource.Resource {
+	return &adminResource{}
+}
+
+type adminResource struct {
+	client ClientAPI
+}
+
+type adminResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Site        types.String `tfsdk:"site"`
+	Name        types.String `tfsdk:"name"`
+	Email       types.String `tfsdk:"email"`
+	Role        types.String `tfsdk:"role"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	Password    types.String `tfsdk:"password"`
+	SuperAdmin  types.Bool   `tfsdk:"super_admin"`
+}
+
+func (r *adminResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_admin"
+}
+
+func (r *adminResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a controller administrator and its access to a site. An admin with a " +
+			"`password` is created as a local account; without one, the controller emails an invitation to " +
+			"`email`. Destroying the resource revokes the admin's access to the site. The provider's account " +
+			"must be a super admin.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the admin.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site the admin is given access to. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The admin's user name.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The admin's email address. Required when `password` is not set, as the " +
+					"invitation is sent to it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(emailRegexp, "must be a valid email address"),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("password")),
+				},
+			},
+
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The admin's role on the site: `admin` (full management) or `readonly` " +
+					"(view only).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("admin", "readonly"),
+				},
+			},
+
+			"permissions": schema.SetAttribute{
+				MarkdownDescription: "Additional permissions on the site, for `readonly` admins that need to " +
+					"perform specific actions, e.g. `API_DEVICE_ADOPT` or `API_DEVICE_RESTART`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password of a local admin account. The controller doesn't report it, " +
+					"so changes made outside Terraform are not detected, and removing the attribute leaves the " +
+					"password unchanged.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"super_admin": schema.BoolAttribute{
+				MarkdownDescription: "Whether the admin is a super admin, with access to every site and the " +
+					"controller's settings. Super admins are managed in the UniFi UI.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *adminResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *adminResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan adminResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateAdmin(ctx, site, r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Admin", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *adminResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state adminResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	a, err := r.client.GetAdmin(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Admin",
+			fmt.Sprintf("Could not read admin %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(a, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *adminResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan adminResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	d := r.modelToAPI(ctx, &plan)
+	d.ID = state.ID.ValueString()
+	// Only send the password when it changed, so an update doesn't reset a
+	// password the admin has changed since.
+	if plan.Password.Equal(state.Password) {
+		d.Password = ""
+	}
+
+	updated, err := r.client.UpdateAdmin(ctx, site, d)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Admin", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *adminResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state adminResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.client.RevokeAdmin(ctx, site, state.ID.ValueString()); err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Revoking Admin", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_admin.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *adminResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *adminResource) modelToAPI(ctx context.Context, m *adminResourceModel) *adminRequest {
+	permissions := setStrings(ctx, m.Permissions)
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return &adminRequest{
+		Name:        m.Name.ValueString(),
+		Email:       m.Email.ValueString(),
+		Role:        m.Role.ValueString(),
+		Permissions: permissions,
+		Password:    m.Password.ValueString(),
+	}
+}
+
+// apiToModel copies an admin into the model. The password is never reported,
+// so it is left as configured.
+func (r *adminResource) apiToModel(a *admin, m *adminResourceModel, site string) {
+	m.ID = types.StringValue(a.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(a.Name)
+	m.Email = stringValueOrNull(a.Email)
+	m.Role = types.StringValue(a.Role)
+	if len(a.Permissions) > 0 {
+		m.Permissions = stringSetValue(a.Permissions, nil)
+	} else {
+		m.Permissions = types.SetNull(types.StringType)
+	}
+	m.SuperAdmin = types.BoolValue(a.IsSuper)
+}
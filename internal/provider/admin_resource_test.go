@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+// newTestSitemgrServer serves the sitemgr endpoint, recording the commands it
+// receives and answering get-admins with admins and every other command with
+// echo.
+func newTestSitemgrServer(t *testing.T, admins, echo string) (*httptest.Server, *[]map[string]any) {
+	var got []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/sitemgr", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		got = append(got, body)
+		w.Header().Set("Content-Type", "application/json")
+		if body["cmd"] == "get-admins" {
+			fmt.Fprintf(w, `{"meta":{"rc":"ok"},"data":%s}`, admins)
+			return
+		}
+		fmt.Fprintf(w, `{"meta":{"rc":"ok"},"data":%s}`, echo)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got
+}
+
+func TestCreateAdmin(t *testing.T) {
+	t.Run("invite", func(t *testing.T) {
+		srv, got := newTestSitemgrServer(t,
+			`[{"_id":"a1","name":"alice","email":"alice@example.com","role":"admin"}]`, `[]`)
+
+		a, err := newTestClient(t, srv.URL, false).CreateAdmin(context.Background(), "default", &adminRequest{
+			Name:        "alice",
+			Email:       "alice@example.com",
+			Role:        "admin",
+			Permissions: []string{},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, *got, 2, "the admin is looked up when it isn't echoed back")
+		assert.Equal(t, map[string]any{
+			"cmd":         "invite-admin",
+			"name":        "alice",
+			"email":       "alice@example.com",
+			"role":        "admin",
+			"permissions": []any{},
+		}, (*got)[0])
+		assert.Equal(t, "a1", a.ID)
+	})
+
+	t.Run("local account", func(t *testing.T) {
+		srv, got := newTestSitemgrServer(t, `[]`, `[{"_id":"a2","name":"bob","role":"readonly"}]`)
+
+		a, err := newTestClient(t, srv.URL, false).CreateAdmin(context.Background(), "default", &adminRequest{
+			Name:        "bob",
+			Role:        "readonly",
+			Permissions: []string{"API_DEVICE_RESTART"},
+			Password:    "hunter22",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, *got, 1)
+		assert.Equal(t, map[string]any{
+			"cmd":         "create-admin",
+			"name":        "bob",
+			"role":        "readonly",
+			"permissions": []any{"API_DEVICE_RESTART"},
+			"x_password":  "hunter22",
+		}, (*got)[0])
+		assert.Equal(t, "a2", a.ID)
+	})
+}
+
+func TestGetAdmin(t *testing.T) {
+	srv, _ := newTestSitemgrServer(t, `[
+		{"_id":"a1","name":"alice","role":"admin"},
+		{"_id":"a2","name":"bob","role":"readonly"}
+	]`, `[]`)
+	c := newTestClient(t, srv.URL, false)
+
+	a, err := c.GetAdmin(context.Background(), "default", "a2")
+	require.NoError(t, err)
+	assert.Equal(t, "bob", a.Name)
+
+	_, err = c.GetAdmin(context.Background(), "default", "a3")
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
+
+func TestRevokeAdmin(t *testing.T) {
+	srv, got := newTestSitemgrServer(t, `[]`, `[]`)
+
+	require.NoError(t, newTestClient(t, srv.URL, false).RevokeAdmin(context.Background(), "default", "a1"))
+	assert.Equal(t, []map[string]any{{"cmd": "revoke-admin", "admin": "a1"}}, *got)
+}
+
+func TestAdminModelToAPI(t *testing.T) {
+	r := &adminResource{}
+	ctx := context.Background()
+
+	d := r.modelToAPI(ctx, &adminResourceModel{
+		Name:        types.StringValue("alice"),
+		Email:       types.StringValue("alice@example.com"),
+		Role:        types.StringValue("readonly"),
+		Permissions: types.SetNull(types.StringType),
+		Password:    types.StringNull(),
+	})
+	assert.Equal(t, &adminRequest{
+		Name:        "alice",
+		Email:       "alice@example.com",
+		Role:        "readonly",
+		Permissions: []string{},
+	}, d, "a null set is sent as an empty list")
+}
+
+func TestAdminAPIToModel(t *testing.T) {
+	r := &adminResource{}
+
+	m := adminResourceModel{Password: types.StringValue("hunter22")}
+	r.apiToModel(&admin{
+		ID:          "a1",
+		Name:        "alice",
+		Role:        "readonly",
+		Permissions: []string{"API_DEVICE_RESTART", "API_DEVICE_ADOPT"},
+	}, &m, "default")
+
+	assert.Equal(t, "a1", m.ID.ValueString())
+	assert.Equal(t, "default", m.Site.ValueString())
+	assert.True(t, m.Email.IsNull())
+	assert.Equal(t, "readonly", m.Role.ValueString())
+	assert.Equal(t, stringSet("API_DEVICE_ADOPT", "API_DEVICE_RESTART"), m.Permissions)
+	assert.Equal(t, "hunter22", m.Password.ValueString(), "the password is not read back")
+	assert.False(t, m.SuperAdmin.ValueBool())
+
+	r.apiToModel(&admin{ID: "a1", Name: "alice", Role: "admin"}, &m, "default")
+	assert.True(t, m.Permissions.IsNull())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccAdmin_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-admin-%s", randomSuffix())
+	config := func(role string) string {
+		return fmt.Sprintf(`
+resource "terrifi_admin" "test" {
+  name     = %q
+  email    = "%s@example.com"
+  role     = %q
+  password = "tfacc-Passw0rd!"
+}
+`, name, name, role)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("readonly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_admin.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_admin.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_admin.test", "role", "readonly"),
+					resource.TestCheckResourceAttr("terrifi_admin.test", "super_admin", "false"),
+				),
+			},
+			{
+				Config: config("admin"),
+				Check:  resource.TestCheckResourceAttr("terrifi_admin.test", "role", "admin"),
+			},
+			{
+				ResourceName:            "terrifi_admin.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &apGroupResource{}
+	_ resource.ResourceWithImportState = &apGroupResource{}
+)
+
+func NewAPGroupResource() resource.Resource {
+	return &apGroupResource{}
+}
+
+type apGroupResource struct {
+	client ClientAPI
+}
+
+type apGroupResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Site       types.String `tfsdk:"site"`
+	Name       types.String `tfsdk:"name"`
+	DeviceMACs types.Set    `tfsdk:"device_macs"`
+}
+
+func (r *apGroupResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ap_group"
+}
+
+func (r *apGroupResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an AP group on the UniFi controller. An AP group is a named set of access " +
+			"points; a WLAN with `ap_group_ids` is only broadcast by the access points in those groups.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the AP group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the AP group with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the AP group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"device_macs": schema.SetAttribute{
+				MarkdownDescription: "The MAC addresses of the access points in the group, e.g. from " +
+					"`terrifi_device.mac`. An access point can be in several groups.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(
+							macRegexp,
+							"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+						),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *apGroupResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *apGroupResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan apGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateAPGroup(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating AP Group", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *apGroupResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state apGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	group, err := r.client.GetAPGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading AP Group",
+			fmt.Sprintf("Could not read AP group %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(group, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *apGroupResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan apGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetAPGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading AP Group for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "AP group", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	state.Name = plan.Name
+	state.DeviceMACs = plan.DeviceMACs
+
+	group := r.modelToAPI(&state)
+	group.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateAPGroup(ctx, site, group)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating AP Group", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *apGroupResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state apGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteAPGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting AP Group", err.Error())
+	}
+}
+
+func (r *apGroupResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *apGroupResource) modelToAPI(m *apGroupResourceModel) *unifi.APGroup {
+	macs := sortedSetStrings(m.DeviceMACs)
+	for i, mac := range macs {
+		macs[i] = strings.ToLower(mac)
+	}
+	// Sort again: lower-casing can reorder MACs that differ only in case, and
+	// merge duplicates.
+	slices.Sort(macs)
+	macs = slices.Compact(macs)
+	return &unifi.APGroup{
+		Name:       m.Name.ValueString(),
+		DeviceMacs: macs,
+	}
+}
+
+// apiToModel keeps the spelling of MACs in m that only differ in case from
+// the controller's lower-case ones, so an upper-case MAC in config doesn't
+// produce a perpetual diff.
+func (r *apGroupResource) apiToModel(group *unifi.APGroup, m *apGroupResourceModel, site string) {
+	configured := map[string]string{}
+	for _, mac := range sortedSetStrings(m.DeviceMACs) {
+		configured[strings.ToLower(mac)] = mac
+	}
+
+	macs := stringSetValue(group.DeviceMacs, strings.ToLower)
+	vals := make([]attr.Value, 0, len(macs.Elements()))
+	for _, mac := range sortedSetStrings(macs) {
+		if orig, ok := configured[mac]; ok {
+			mac = orig
+		}
+		vals = append(vals, types.StringValue(mac))
+	}
+
+	m.ID = types.StringValue(group.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(group.Name)
+	m.DeviceMACs = types.SetValueMust(types.StringType, vals)
+}
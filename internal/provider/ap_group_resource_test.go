@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestAPGroupModelToAPI(t *testing.T) {
+	r := &apGroupResource{}
+
+	tests := []struct {
+		name string
+		macs types.Set
+		want []string
+	}{
+		{
+			name: "lower-cased and sorted",
+			macs: stringSet("AA:BB:CC:00:00:02", "aa:bb:cc:00:00:01"),
+			want: []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"},
+		},
+		{
+			name: "spellings of the same MAC are merged",
+			macs: stringSet("AA:BB:CC:00:00:01", "aa:bb:cc:00:00:01"),
+			want: []string{"aa:bb:cc:00:00:01"},
+		},
+		{
+			name: "empty",
+			macs: types.SetValueMust(types.StringType, []attr.Value{}),
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := r.modelToAPI(&apGroupResourceModel{Name: types.StringValue("Upstairs"), DeviceMACs: tt.macs})
+
+			assert.Equal(t, "Upstairs", group.Name)
+			assert.Equal(t, tt.want, group.DeviceMacs)
+		})
+	}
+}
+
+func TestAPGroupAPIToModel(t *testing.T) {
+	r := &apGroupResource{}
+
+	tests := []struct {
+		name       string
+		configured types.Set
+		apiMACs    []string
+		want       types.Set
+	}{
+		{
+			name:       "import lower-cases",
+			configured: types.SetNull(types.StringType),
+			apiMACs:    []string{"AA:BB:CC:00:00:02", "aa:bb:cc:00:00:01"},
+			want:       stringSet("aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"),
+		},
+		{
+			name:       "configured spelling is kept",
+			configured: stringSet("AA:BB:CC:00:00:01"),
+			apiMACs:    []string{"aa:bb:cc:00:00:01"},
+			want:       stringSet("AA:BB:CC:00:00:01"),
+		},
+		{
+			name:       "devices added outside Terraform",
+			configured: stringSet("AA:BB:CC:00:00:01"),
+			apiMACs:    []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:03"},
+			want:       stringSet("AA:BB:CC:00:00:01", "aa:bb:cc:00:00:03"),
+		},
+		{
+			name:       "no devices",
+			configured: stringSet("aa:bb:cc:00:00:01"),
+			apiMACs:    nil,
+			want:       types.SetValueMust(types.StringType, []attr.Value{}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := apGroupResourceModel{DeviceMACs: tt.configured}
+			r.apiToModel(&unifi.APGroup{ID: "g1", Name: "Upstairs", DeviceMacs: tt.apiMACs}, &m, "default")
+
+			assert.Equal(t, "g1", m.ID.ValueString())
+			assert.Equal(t, "default", m.Site.ValueString())
+			assert.Equal(t, "Upstairs", m.Name.ValueString())
+			assert.True(t, tt.want.Equal(m.DeviceMACs), "got %v", m.DeviceMACs)
+		})
+	}
+}
+
+func TestAPGroupCRUD(t *testing.T) {
+	macs := func(values ...string) types.Set {
+		elems := make([]attr.Value, len(values))
+		for i, v := range values {
+			elems[i] = types.StringValue(v)
+		}
+		return types.SetValueMust(types.StringType, elems)
+	}
+	plan := apGroupResourceModel{
+		ID:         types.StringUnknown(),
+		Site:       types.StringNull(),
+		Name:       types.StringValue("Office"),
+		DeviceMACs: macs("AA:BB:CC:00:00:01", "aa:bb:cc:00:00:02"),
+	}
+
+	fake := newFakeClient()
+	r := &apGroupResource{client: fake}
+
+	created, diags := testCreate(t, r, plan)
+	require.False(t, diags.HasError(), "create: %v", diags)
+	assert.Equal(t, "default", created.Site.ValueString())
+	assert.Equal(t, plan.DeviceMACs, created.DeviceMACs, "configured spelling is kept")
+	group := fake.apGroups[created.ID.ValueString()]
+	assert.Equal(t, []string{"aa:bb:cc:00:00:01", "aa:bb:cc:00:00:02"}, group.DeviceMacs)
+
+	read, diags := testRead(t, r, *created)
+	require.False(t, diags.HasError(), "read: %v", diags)
+	assert.Equal(t, created, read)
+
+	update := *created
+	update.Name = types.StringValue("Upstairs")
+	update.DeviceMACs = macs()
+	updated, diags := testUpdate(t, r, *created, update)
+	require.False(t, diags.HasError(), "update: %v", diags)
+	assert.Equal(t, "Upstairs", updated.Name.ValueString())
+	assert.Empty(t, updated.DeviceMACs.Elements())
+	assert.Equal(t, []string{}, fake.apGroups[created.ID.ValueString()].DeviceMacs)
+
+	diags = testDelete(t, r, *updated)
+	require.False(t, diags.HasError(), "delete: %v", diags)
+	assert.Empty(t, fake.apGroups)
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccAPGroup_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-apg-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_ap_group" "test" {
+  name        = %q
+  device_macs = []
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_ap_group.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_ap_group.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_ap_group.test", "device_macs.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_ap_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_ap_group" "test" {
+  name        = "%s-renamed"
+  device_macs = []
+}
+`, name),
+				Check: resource.TestCheckResourceAttr("terrifi_ap_group.test", "name", name+"-renamed"),
+			},
+		},
+	})
+}
+
+func TestAccAPGroup_wlan(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	config := func(apGroupIDs string) string {
+		return wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_ap_group" "test" {
+  name        = "tfacc-apg-%s"
+  device_macs = []
+}
+
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.wlan_test.id
+%s}
+`, suffix, wlanName, apGroupIDs)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("  ap_group_ids = [terrifi_ap_group.test.id]\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "ap_group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("terrifi_wlan.test", "ap_group_ids.*", "terrifi_ap_group.test", "id"),
+				),
+			},
+			// Removing ap_group_ids broadcasts the WLAN on every AP again.
+			{
+				Config: config(""),
+				Check:  resource.TestCheckNoResourceAttr("terrifi_wlan.test", "ap_group_ids.#"),
+			},
+		},
+	})
+}
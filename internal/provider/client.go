@@ -11,11 +11,16 @@ import (
 	"net/http"
 	"net/http/cookiejar"
 	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	ui "github.com/ubiquiti-community/go-unifi/unifi"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 // Client wraps the go-unifi API client with site information.
@@ -23,7 +28,7 @@ import (
 // and CRUD methods for every UniFi API endpoint. We wrap it to carry the default site
 // name alongside the API client so resources can fall back to it.
 type Client struct {
-	*ui.ApiClient
+	sdk     *ui.ApiClient // replaced when the session expires; call it through withSDK
 	Site    string
 	BaseURL string
 	APIPath string // API path prefix, e.g. "/proxy/network" for UniFi OS, empty for legacy
@@ -31,6 +36,28 @@ type Client struct {
 	HTTP    *retryablehttp.Client
 	csrf    string // CSRF token for custom v2/v1 API requests that bypass the SDK
 	cache   *responseCache // nil when response caching is disabled (zero overhead)
+	conditional *conditionalCache // validators of earlier GETs; nil disables conditional requests
+	reads   singleflight.Group // merges concurrent identical GETs; see doV2Request
+	writes  atomic.Uint64      // count of write requests, so GETs never merge across a write
+
+	pageSize int // records per page for paginated v1 lists; 0 means defaultPageSize
+
+	warnUnzonedNetworks bool // plan-time warning for networks outside custom firewall zones
+
+	workaroundLogged sync.Once // one-time warning that the firewall policy workarounds are in use; see logWorkaround
+
+	// Credentials for logging the custom-request session in again when the
+	// controller expires it mid-run; see relogin. Empty with API key auth.
+	username  string
+	password  string
+	loginPath string
+	loginMu   sync.RWMutex  // guards csrf, sdk and sdkSession; held exclusively while logging in
+	session   atomic.Uint64 // incremented on every relogin
+
+	sdkConfig  ui.Config // for creating a new SDK client when its session expires; see reloginSDK
+	sdkSession uint64    // incremented on every reloginSDK
+
+	limiter *rate.Limiter // shared by HTTP's transport and withSDK; nil when MaxRequestsPerSecond is unset
 }
 
 // SiteOrDefault returns the given site if non-empty, otherwise falls back to the
@@ -47,13 +74,16 @@ func (c *Client) SiteOrDefault(site types.String) string {
 // UniFi API client. It can be populated from Terraform attributes, env vars,
 // or both (via ClientConfigFromEnv).
 type ClientConfig struct {
-	APIURL           string
-	Username         string
-	Password         string
-	APIKey           string
-	Site             string
-	AllowInsecure    bool
-	ResponseCaching  bool
+	APIURL               string
+	Username             string
+	Password             string
+	APIKey               string
+	Site                 string
+	AllowInsecure        bool
+	ResponseCaching      bool
+	PageSize             int // records per page for paginated list endpoints; 0 uses the default
+	MaxRequestsPerSecond int  // cap on requests per second, SDK calls included; 0 means unlimited
+	WarnUnzonedNetworks  bool // warn at plan time about networks outside custom firewall zones
 }
 
 // ClientConfigFromEnv reads UniFi connection configuration from environment
@@ -75,6 +105,15 @@ func ClientConfigFromEnv() ClientConfig {
 	if os.Getenv("UNIFI_RESPONSE_CACHING") == "true" {
 		cfg.ResponseCaching = true
 	}
+	if v, err := strconv.Atoi(os.Getenv("UNIFI_PAGE_SIZE")); err == nil && v > 0 {
+		cfg.PageSize = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("UNIFI_MAX_REQUESTS_PER_SECOND")); err == nil && v > 0 {
+		cfg.MaxRequestsPerSecond = v
+	}
+	if os.Getenv("UNIFI_WARN_UNZONED_NETWORKS") == "true" {
+		cfg.WarnUnzonedNetworks = true
+	}
 	return cfg
 }
 
@@ -89,6 +128,18 @@ func ClientConfigFromEnv() ClientConfig {
 // perform an independent login to obtain our own session cookie + CSRF token.
 // If the SDK ever exposes a Do() method or the CSRF token, this dual-login
 // approach can be eliminated.
+//
+// TODO(go-unifi): The SDK refreshes its own session when the token expiry it
+// was given passes, but returns a LoginRequiredError for an unexpected 401,
+// and its login method isn't exported. SDK calls therefore go through
+// withSDK, which replaces the SDK client with a newly logged-in one; see
+// reloginSDK.
+//
+// TODO(go-unifi): The SDK's internal HTTP client can't be wrapped, so
+// MaxRequestsPerSecond paces SDK calls in withSDK with the limiter our custom
+// HTTP client's transport uses. That paces each call rather than each request:
+// the SDK's own retries of 429 responses (honoring Retry-After) and its
+// logins aren't paced.
 func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 	if cfg.APIURL == "" {
 		return nil, fmt.Errorf("API URL is required (set UNIFI_API or pass api_url)")
@@ -99,13 +150,14 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 
 	// Create the SDK client using the v1.33.36+ constructor.
 	// This handles HTTP setup, TLS, login, and API path discovery internally.
-	sdkClient, err := ui.New(ctx, &ui.Config{
+	sdkConfig := ui.Config{
 		BaseURL:       cfg.APIURL,
 		APIKey:        cfg.APIKey,
 		Username:      cfg.Username,
 		Password:      cfg.Password,
 		AllowInsecure: cfg.AllowInsecure,
-	})
+	}
+	sdkClient, err := ui.New(ctx, &sdkConfig)
 	if err != nil {
 		return nil, fmt.Errorf("initializing SDK client: %w", err)
 	}
@@ -115,15 +167,21 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 	// doesn't expose its internal HTTP client or CSRF token, so we maintain
 	// our own authenticated session for these requests.
 	httpClient := newRetryableHTTPClient(cfg.AllowInsecure)
+	var limiter *rate.Limiter
+	if cfg.MaxRequestsPerSecond > 0 {
+		transport := newRateLimitedTransport(httpClient.HTTPClient.Transport, cfg.MaxRequestsPerSecond)
+		httpClient.HTTPClient.Transport = transport
+		limiter = transport.limiter
+	}
 
 	apiPath, err := discoverAPIPath(ctx, httpClient, cfg.APIURL)
 	if err != nil {
 		return nil, fmt.Errorf("API path discovery failed: %w", err)
 	}
 
-	var csrf string
+	var csrf, loginPath string
 	if cfg.APIKey == "" && cfg.Username != "" && cfg.Password != "" {
-		loginPath := "/api/login"
+		loginPath = "/api/login"
 		if apiPath == "/proxy/network" {
 			loginPath = "/api/auth/login"
 		}
@@ -139,7 +197,8 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 	}
 
 	return &Client{
-		ApiClient: sdkClient,
+		sdk:       sdkClient,
+		sdkConfig: sdkConfig,
 		Site:      cfg.Site,
 		BaseURL:   cfg.APIURL,
 		APIPath:   apiPath,
@@ -147,6 +206,17 @@ func NewClient(ctx context.Context, cfg ClientConfig) (*Client, error) {
 		HTTP:      httpClient,
 		csrf:      csrf,
 		cache:     cache,
+		pageSize:  cfg.PageSize,
+
+		conditional: newConditionalCache(),
+
+		warnUnzonedNetworks: cfg.WarnUnzonedNetworks,
+
+		username:  cfg.Username,
+		password:  cfg.Password,
+		loginPath: loginPath,
+
+		limiter: limiter,
 	}, nil
 }
 
@@ -208,6 +278,71 @@ func loginForCustomRequests(ctx context.Context, httpClient *retryablehttp.Clien
 	return csrf, nil
 }
 
+// canRelogin reports whether the custom-request session can be logged in
+// again. With API key auth a 401 means the key itself was rejected, so
+// there is nothing to refresh.
+func (c *Client) canRelogin() bool {
+	return c.APIKey == "" && c.username != "" && c.password != "" && c.loginPath != ""
+}
+
+// auth returns the CSRF token to send with a custom request, and the
+// session it belongs to.
+func (c *Client) auth() (csrf string, session uint64) {
+	c.loginMu.RLock()
+	defer c.loginMu.RUnlock()
+	return c.csrf, c.session.Load()
+}
+
+// relogin logs the custom-request session in again after the controller
+// rejected a request sent with session, e.g. because the session expired
+// during a long apply. Concurrent requests that fail together only log in
+// once: if another request has already refreshed the session, relogin
+// returns without logging in and the caller retries with the new session.
+func (c *Client) relogin(ctx context.Context, session uint64) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.session.Load() != session {
+		return nil
+	}
+
+	csrf, err := loginForCustomRequests(ctx, c.HTTP, c.BaseURL, c.loginPath, c.username, c.password)
+	if err != nil {
+		return fmt.Errorf("session expired and logging in again failed: %w", err)
+	}
+	c.csrf = csrf
+	c.session.Add(1)
+	return nil
+}
+
+// sdkClient returns the SDK client and the session it belongs to.
+func (c *Client) sdkClient() (*ui.ApiClient, uint64) {
+	c.loginMu.RLock()
+	defer c.loginMu.RUnlock()
+	return c.sdk, c.sdkSession
+}
+
+// reloginSDK replaces the SDK client with a newly logged-in one after the
+// controller rejected a call made in session. As with relogin, concurrent
+// calls that fail together only log in once.
+func (c *Client) reloginSDK(ctx context.Context, session uint64) error {
+	c.loginMu.Lock()
+	defer c.loginMu.Unlock()
+
+	if c.sdkSession != session {
+		return nil
+	}
+
+	cfg := c.sdkConfig
+	sdk, err := ui.New(ctx, &cfg)
+	if err != nil {
+		return fmt.Errorf("session expired and logging in again failed: %w", err)
+	}
+	c.sdk = sdk
+	c.sdkSession++
+	return nil
+}
+
 // discoverAPIPath probes the UniFi controller to determine the API path prefix.
 // UniFi OS controllers return HTTP 200 on GET / and use "/proxy/network" as the
 // API path prefix. Legacy controllers return HTTP 302 and use no prefix.
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ClientAPI is the part of *Client that resources and data sources use. They
+// hold a ClientAPI rather than a *Client so that their CRUD logic can be unit
+// tested against an in-memory fake (see fake_client_test.go) instead of a
+// live controller.
+//
+// When a resource starts calling a new client method, add it here and to the
+// fake.
+type ClientAPI interface {
+	SiteOrDefault(site types.String) string
+	WarnUnzonedNetworks() bool
+
+	// Controller
+	GetControllerSysinfo(ctx context.Context, site string) (*controllerSysinfo, error)
+	HasZoneBasedFirewall(ctx context.Context, site string) (bool, error)
+	ListSites(ctx context.Context) ([]unifi.Site, error)
+
+	// Admins
+	CreateAdmin(ctx context.Context, site string, d *adminRequest) (*admin, error)
+	GetAdmin(ctx context.Context, site, id string) (*admin, error)
+	UpdateAdmin(ctx context.Context, site string, d *adminRequest) (*admin, error)
+	RevokeAdmin(ctx context.Context, site, id string) error
+
+	// AP groups
+	ListAPGroup(ctx context.Context, site string) ([]unifi.APGroup, error)
+	GetAPGroup(ctx context.Context, site, id string) (*unifi.APGroup, error)
+	CreateAPGroup(ctx context.Context, site string, d *unifi.APGroup) (*unifi.APGroup, error)
+	UpdateAPGroup(ctx context.Context, site string, d *unifi.APGroup) (*unifi.APGroup, error)
+	DeleteAPGroup(ctx context.Context, site, id string) error
+
+	// Client devices
+	ListClientDevices(ctx context.Context, site string) ([]unifi.Client, error)
+	GetClientDevice(ctx context.Context, site string, id string) (*unifi.Client, error)
+	GetClientDeviceByMAC(ctx context.Context, site string, mac string) (*unifi.Client, error)
+	CreateClientDevice(ctx context.Context, site string, d *unifi.Client) (*unifi.Client, error)
+	UpdateClientDevice(ctx context.Context, site string, d *unifi.Client) (*unifi.Client, error)
+	SetClientDeviceGroups(ctx context.Context, site, id string, groupIDs []string) error
+	ForgetClientDevicesByMAC(ctx context.Context, site string, macs []string) error
+	GetFingerprintOverride(ctx context.Context, site string, mac string) (int64, error)
+	SetFingerprintOverride(ctx context.Context, site string, mac string, deviceTypeID int64) error
+	GetClientFingerprint(ctx context.Context, site, mac string) (*unifi.ClientInfoFingerprint, error)
+	SetClientFingerprintOverride(ctx context.Context, site string, d *clientFingerprintOverride) error
+
+	// Client groups
+	GetNetworkMembersGroup(ctx context.Context, site string, id string) (*unifi.NetworkMembersGroup, error)
+	CreateNetworkMembersGroup(ctx context.Context, site string, d *unifi.NetworkMembersGroup) (*unifi.NetworkMembersGroup, error)
+	UpdateNetworkMembersGroup(ctx context.Context, site string, d *unifi.NetworkMembersGroup) (*unifi.NetworkMembersGroup, error)
+	DeleteNetworkMembersGroup(ctx context.Context, site string, id string) error
+
+	// Devices
+	ListDevice(ctx context.Context, site string) ([]unifi.Device, error)
+	GetDevice(ctx context.Context, site, id string) (*unifi.Device, error)
+	GetDeviceByMAC(ctx context.Context, site, mac string) (*unifi.Device, error)
+	UpdateDevice(ctx context.Context, site string, id string, m *deviceResourceModel) error
+	AdoptDevice(ctx context.Context, site, mac string) error
+	WaitForDeviceAdoption(ctx context.Context, site, mac string) (*unifi.Device, error)
+	SetDeviceInform(ctx context.Context, site, mac, informURL string) error
+	SetDeviceLocate(ctx context.Context, site, mac string, enabled bool) error
+	GetDeviceLocating(ctx context.Context, site, mac string) (bool, error)
+
+	// DHCP options
+	GetDHCPOption(ctx context.Context, site, id string) (*unifi.DHCPOption, error)
+	CreateDHCPOption(ctx context.Context, site string, d *unifi.DHCPOption) (*unifi.DHCPOption, error)
+	UpdateDHCPOption(ctx context.Context, site string, d *unifi.DHCPOption) (*unifi.DHCPOption, error)
+	DeleteDHCPOption(ctx context.Context, site, id string) error
+
+	// DNS records
+	ListDNSRecord(ctx context.Context, site string) ([]unifi.DNSRecord, error)
+	GetDNSRecord(ctx context.Context, site, id string) (*unifi.DNSRecord, error)
+	CreateDNSRecord(ctx context.Context, site string, d *unifi.DNSRecord) (*unifi.DNSRecord, error)
+	UpdateDNSRecord(ctx context.Context, site string, d *unifi.DNSRecord) (*unifi.DNSRecord, error)
+	DeleteDNSRecord(ctx context.Context, site, id string) error
+
+	// DPI restrictions
+	GetDpiApp(ctx context.Context, site, id string) (*unifi.DpiApp, error)
+	CreateDpiApp(ctx context.Context, site string, d *unifi.DpiApp) (*unifi.DpiApp, error)
+	UpdateDpiApp(ctx context.Context, site string, d *unifi.DpiApp) (*unifi.DpiApp, error)
+	DeleteDpiApp(ctx context.Context, site, id string) error
+	GetDpiGroup(ctx context.Context, site, id string) (*unifi.DpiGroup, error)
+	CreateDpiGroup(ctx context.Context, site string, d *unifi.DpiGroup) (*unifi.DpiGroup, error)
+	UpdateDpiGroup(ctx context.Context, site string, d *unifi.DpiGroup) (*unifi.DpiGroup, error)
+	DeleteDpiGroup(ctx context.Context, site, id string) error
+
+	// Firewall groups
+	ListFirewallGroup(ctx context.Context, site string) ([]unifi.FirewallGroup, error)
+	GetFirewallGroup(ctx context.Context, site, id string) (*unifi.FirewallGroup, error)
+	CreateFirewallGroup(ctx context.Context, site string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error)
+	UpdateFirewallGroup(ctx context.Context, site string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error)
+	DeleteFirewallGroup(ctx context.Context, site, id string) error
+
+	// Firewall policies
+	ListFirewallPolicies(ctx context.Context, site string) ([]*unifi.FirewallPolicy, error)
+	GetFirewallPolicy(ctx context.Context, site string, id string) (*firewallPolicyFull, error)
+	CreateFirewallPolicy(ctx context.Context, site string, d *unifi.FirewallPolicy, schedOverride *firewallPolicyScheduleRequest) (*firewallPolicyFull, error)
+	UpdateFirewallPolicy(ctx context.Context, site string, d *unifi.FirewallPolicy, schedOverride *firewallPolicyScheduleRequest) (*firewallPolicyFull, error)
+	SetFirewallPolicyEnabled(ctx context.Context, site string, id string, enabled bool) error
+	DeleteFirewallPolicy(ctx context.Context, site string, id string) error
+	GetFirewallPolicyOrdering(ctx context.Context, site, sourceZoneID, destZoneID string) ([]string, error)
+	ReorderFirewallPolicies(ctx context.Context, site, sourceZoneID, destZoneID string, policyIDs []string) ([]firewallPolicyResponse, error)
+
+	// Firewall zones
+	ListFirewallZone(ctx context.Context, site string) ([]unifi.FirewallZone, error)
+	GetFirewallZone(ctx context.Context, site string, id string) (*unifi.FirewallZone, error)
+	CreateFirewallZone(ctx context.Context, site string, d *unifi.FirewallZone) (*unifi.FirewallZone, error)
+	UpdateFirewallZone(ctx context.Context, site string, d *unifi.FirewallZone) (*unifi.FirewallZone, error)
+	DeleteFirewallZone(ctx context.Context, site string, id string) error
+
+	// Guests
+	AuthorizeGuest(ctx context.Context, site string, d *guestAuthorizationRequest) (*guestAuthorization, error)
+	GetGuestAuthorization(ctx context.Context, site, mac string) (*guestAuthorization, error)
+	UnauthorizeGuest(ctx context.Context, site, mac string) error
+
+	// Hotspot vouchers
+	CreateHotspotVouchers(ctx context.Context, site string, d *hotspotVoucherRequest) ([]hotspotVoucher, error)
+	GetHotspotVouchers(ctx context.Context, site string, createTime int64) ([]hotspotVoucher, error)
+	DeleteHotspotVoucher(ctx context.Context, site, id string) error
+
+	// NAT rules
+	GetNat(ctx context.Context, site, id string) (*unifi.Nat, error)
+	CreateNat(ctx context.Context, site string, d *unifi.Nat) (*unifi.Nat, error)
+	UpdateNat(ctx context.Context, site string, d *unifi.Nat) (*unifi.Nat, error)
+	DeleteNat(ctx context.Context, site, id string) error
+
+	// Networks
+	ListNetwork(ctx context.Context, site string) ([]unifi.Network, error)
+	GetNetwork(ctx context.Context, site, id string) (*unifi.Network, error)
+	CreateNetwork(ctx context.Context, site string, d *unifi.Network) (*unifi.Network, error)
+	UpdateNetwork(ctx context.Context, site string, d *unifi.Network) (*unifi.Network, error)
+	DeleteNetwork(ctx context.Context, site, id, name string) error
+	GetGatewayWANs(ctx context.Context, site string) (map[string]gatewayWANStatus, error)
+	UpdateWAN(ctx context.Context, site, id string, payload *wanPayload) error
+
+	// Port forwards
+	ListPortForward(ctx context.Context, site string) ([]unifi.PortForward, error)
+	GetPortForward(ctx context.Context, site, id string) (*unifi.PortForward, error)
+	CreatePortForward(ctx context.Context, site string, d *unifi.PortForward) (*unifi.PortForward, error)
+	UpdatePortForward(ctx context.Context, site string, d *unifi.PortForward) (*unifi.PortForward, error)
+	DeletePortForward(ctx context.Context, site, id string) error
+
+	// Port profiles
+	ListPortProfile(ctx context.Context, site string) ([]unifi.PortProfile, error)
+	GetPortProfile(ctx context.Context, site, id string) (*unifi.PortProfile, error)
+	CreatePortProfile(ctx context.Context, site string, d *unifi.PortProfile) (*unifi.PortProfile, error)
+	UpdatePortProfile(ctx context.Context, site string, d *unifi.PortProfile) (*unifi.PortProfile, error)
+	DeletePortProfile(ctx context.Context, site, id string) error
+
+	// RADIUS profiles
+	ListRADIUSProfile(ctx context.Context, site string) ([]unifi.RADIUSProfile, error)
+
+	// RADIUS users
+	GetAccount(ctx context.Context, site, id string) (*unifi.Account, error)
+	CreateAccount(ctx context.Context, site string, d *unifi.Account) (*unifi.Account, error)
+	UpdateAccount(ctx context.Context, site string, d *unifi.Account) (*unifi.Account, error)
+	DeleteAccount(ctx context.Context, site, id string) error
+
+	// Site VPNs
+	CreateSiteVPN(ctx context.Context, site string, payload *siteVPNPayload) (string, error)
+	UpdateSiteVPN(ctx context.Context, site, id string, payload *siteVPNPayload) error
+	SetNetworkExposedToSiteVPN(ctx context.Context, site, id string, exposed bool) error
+
+	// Settings
+	GetSettingConnectivity(ctx context.Context, site string) (*settings.Connectivity, error)
+	GetSettingCountry(ctx context.Context, site string) (*settings.Country, error)
+	GetSettingGlobalSwitch(ctx context.Context, site string) (*settings.GlobalSwitch, error)
+	GetSettingGuestAccess(ctx context.Context, site string) (*settings.GuestAccess, error)
+	GetSettingIps(ctx context.Context, site string) (*settings.Ips, error)
+	GetSettingLocale(ctx context.Context, site string) (*settings.Locale, error)
+	GetSettingRadius(ctx context.Context, site string) (*settings.Radius, error)
+	GetSettingRsyslogd(ctx context.Context, site string) (*settings.Rsyslogd, error)
+	GetSettingTeleport(ctx context.Context, site string) (*settings.Teleport, error)
+	GetSettingUsg(ctx context.Context, site string) (*settings.Usg, error)
+	GetSettingSuperCloudaccess(ctx context.Context, site string) (*settings.SuperCloudaccess, error)
+	updateSetting(ctx context.Context, site, key, id string, payload any) error
+
+	// Traffic routes
+	ListTrafficRoute(ctx context.Context, site string) ([]trafficRoute, error)
+	GetTrafficRoute(ctx context.Context, site, id string) (*trafficRoute, error)
+	CreateTrafficRoute(ctx context.Context, site string, route *trafficRoute) (*trafficRoute, error)
+	UpdateTrafficRoute(ctx context.Context, site string, route *trafficRoute) (*trafficRoute, error)
+	DeleteTrafficRoute(ctx context.Context, site, id string) error
+
+	// Traffic rules
+	ListTrafficRule(ctx context.Context, site string) ([]trafficRule, error)
+	GetTrafficRule(ctx context.Context, site, id string) (*trafficRule, error)
+	CreateTrafficRule(ctx context.Context, site string, rule *trafficRule) (*trafficRule, error)
+	UpdateTrafficRule(ctx context.Context, site string, rule *trafficRule) (*trafficRule, error)
+	DeleteTrafficRule(ctx context.Context, site, id string) error
+
+	// User groups
+	ListClientGroup(ctx context.Context, site string) ([]unifi.ClientGroup, error)
+	GetClientGroup(ctx context.Context, site, id string) (*unifi.ClientGroup, error)
+	CreateClientGroup(ctx context.Context, site string, d *unifi.ClientGroup) (*unifi.ClientGroup, error)
+	UpdateClientGroup(ctx context.Context, site string, d *unifi.ClientGroup) (*unifi.ClientGroup, error)
+	DeleteClientGroup(ctx context.Context, site, id string) error
+
+	// VPN servers
+	CreateVPNServer(ctx context.Context, site string, payload *vpnServerPayload) (string, error)
+	UpdateVPNServer(ctx context.Context, site, id string, payload *vpnServerPayload) error
+	ListWireGuardPeers(ctx context.Context, site, networkID string) ([]wireguardPeer, error)
+	CreateWireGuardPeers(ctx context.Context, site, networkID string, peers []wireguardPeer) error
+	UpdateWireGuardPeers(ctx context.Context, site, networkID string, peers []wireguardPeer) error
+	DeleteWireGuardPeers(ctx context.Context, site, networkID string, ids []string) error
+
+	// WLANs
+	ListWLAN(ctx context.Context, site string) ([]unifi.WLAN, error)
+	GetWLAN(ctx context.Context, site, id string) (*unifi.WLAN, error)
+	CreateWLAN(ctx context.Context, site string, d *unifi.WLAN) (*unifi.WLAN, error)
+	UpdateWLAN(ctx context.Context, site string, d *unifi.WLAN) (*unifi.WLAN, error)
+	DeleteWLAN(ctx context.Context, site, id string) error
+	GetWLANNetworkPool(ctx context.Context, site, id string) (*wlanNetworkPool, error)
+	SetWLANNetworkPool(ctx context.Context, site, id string, pool *wlanNetworkPool) error
+	ListWLANGroup(ctx context.Context, site string) ([]unifi.WLANGroup, error)
+}
+
+var _ ClientAPI = &Client{}
+
+// WarnUnzonedNetworks reports whether terrifi_network should warn at plan
+// time about networks outside custom firewall zones.
+func (c *Client) WarnUnzonedNetworks() bool {
+	return c.warnUnzonedNetworks
+}
@@ -124,18 +124,11 @@ func (c *Client) GetClientDevice(ctx context.Context, site string, id string) (*
 }
 
 // ListClientDevices returns all configured client devices for the given site.
+// The user store is paginated on large sites; see listV1Paged.
 func (c *Client) ListClientDevices(ctx context.Context, site string) ([]unifi.Client, error) {
-	var respBody struct {
-		Meta json.RawMessage `json:"meta"`
-		Data []unifi.Client  `json:"data"`
-	}
-	err := c.doV1Request(ctx, http.MethodGet,
+	return listV1Paged(ctx, c,
 		fmt.Sprintf("%s%s/api/s/%s/rest/user", c.BaseURL, c.APIPath, site),
-		nil, &respBody)
-	if err != nil {
-		return nil, err
-	}
-	return respBody.Data, nil
+		func(cl unifi.Client) string { return cl.ID })
 }
 
 // GetClientDeviceByMAC looks up a client device by MAC address. This is needed
@@ -161,6 +154,32 @@ func (c *Client) GetClientDeviceByMAC(ctx context.Context, site string, mac stri
 	return &respBody.Data[0], nil
 }
 
+// clientDeviceGroupsRequest is a partial PUT payload for api/s/{site}/rest/user/{id}
+// that only touches group membership. The controller merges it into the
+// existing record, so names, fixed IPs, etc. managed elsewhere are untouched.
+type clientDeviceGroupsRequest struct {
+	ID                     string   `json:"_id"`
+	NetworkMembersGroupIDs []string `json:"network_members_group_ids"`
+}
+
+// SetClientDeviceGroups replaces the client group IDs of a single client
+// device record without sending any of its other fields.
+func (c *Client) SetClientDeviceGroups(ctx context.Context, site, id string, groupIDs []string) error {
+	if groupIDs == nil {
+		groupIDs = []string{}
+	}
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/api/s/%s/rest/user/%s", c.BaseURL, c.APIPath, site, id),
+		clientDeviceGroupsRequest{ID: id, NetworkMembersGroupIDs: groupIDs}, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
 // ForgetClientDevicesByMAC removes one or more known client devices via the
 // stamgr "forget-sta" command. UniFi controllers do not honor DELETE on
 // /rest/user/{id} (they return 404), so we use the documented stamgr endpoint
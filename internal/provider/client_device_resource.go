@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -32,7 +33,7 @@ func NewClientDeviceResource() resource.Resource {
 }
 
 type clientDeviceResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type clientDeviceResourceModel struct {
@@ -45,10 +46,42 @@ type clientDeviceResourceModel struct {
 	NetworkID         types.String `tfsdk:"network_id"`
 	NetworkOverrideID types.String `tfsdk:"network_override_id"`
 	LocalDNSRecord    types.String `tfsdk:"local_dns_record"`
+	ManageDNSRecord   types.Bool   `tfsdk:"manage_dns_record"`
 	ClientGroupIDs    types.Set    `tfsdk:"client_group_ids"`
 	DeviceTypeID      types.Int64  `tfsdk:"device_type_id"`
 	FixedApMAC        types.String `tfsdk:"fixed_ap_mac"`
 	Blocked           types.Bool   `tfsdk:"blocked"`
+	ManagedFields     types.Set    `tfsdk:"managed_fields"`
+
+	BlockedSchedule *clientDeviceBlockedScheduleModel `tfsdk:"blocked_schedule"`
+}
+
+// clientDeviceBlockedScheduleModel is a recurring window during which the
+// client is blocked from the internet. It is stored on the controller as a
+// traffic rule targeting the client (see blockedScheduleRuleDescription).
+type clientDeviceBlockedScheduleModel struct {
+	TimeRangeStart types.String `tfsdk:"time_range_start"`
+	TimeRangeEnd   types.String `tfsdk:"time_range_end"`
+	RepeatOnDays   types.Set    `tfsdk:"repeat_on_days"`
+}
+
+var timeOfDayRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// clientDeviceManagedFieldNames are the attributes that managed_fields can
+// list. They are the ones stored on the controller's client record (plus the
+// fingerprint override); blocked_schedule and manage_dns_record create
+// objects of their own and are always owned by the resource.
+var clientDeviceManagedFieldNames = []string{
+	"name",
+	"note",
+	"fixed_ip",
+	"network_id",
+	"network_override_id",
+	"local_dns_record",
+	"client_group_ids",
+	"device_type_id",
+	"fixed_ap_mac",
+	"blocked",
 }
 
 func (r *clientDeviceResource) Metadata(
@@ -66,7 +99,7 @@ func (r *clientDeviceResource) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a client device on the UniFi controller. Use this resource to set " +
-			"aliases, notes, fixed IPs, VLAN overrides, local DNS records, custom device icons, AP locking, and blocked status for known clients.",
+			"aliases, notes, fixed IPs, VLAN overrides, local DNS records, custom device icons, AP locking, and blocked status (permanent or scheduled) for known clients.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -144,6 +177,15 @@ func (r *clientDeviceResource) Schema(
 				},
 			},
 
+			"manage_dns_record": schema.BoolAttribute{
+				MarkdownDescription: "Also maintain a static `A` record mapping `local_dns_record` to `fixed_ip`, as " +
+					"`terrifi_dns_record` would. The provider creates, updates, and deletes the record along with " +
+					"this resource, so the two can't get out of step. Requires `local_dns_record`. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
 			"client_group_ids": schema.SetAttribute{
 				MarkdownDescription: "Set of client group IDs to assign this device to. " +
 					"Use `terrifi_client_group` to manage groups.",
@@ -176,6 +218,60 @@ func (r *clientDeviceResource) Schema(
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+
+			"managed_fields": schema.SetAttribute{
+				MarkdownDescription: "Limit the attributes this resource manages, for sharing a client with the UniFi UI " +
+					"or another tool. Attributes not listed are neither read nor written: their values on the " +
+					"controller are kept on every update, they can't be set in the configuration, and they are " +
+					"null in state (`blocked` stays `false`). Destroying the resource then clears only the listed " +
+					"attributes instead of forgetting the client. Valid values: `name`, `note`, `fixed_ip`, " +
+					"`network_id`, `network_override_id`, `local_dns_record`, `client_group_ids`, `device_type_id`, " +
+					"`fixed_ap_mac`, `blocked`. `fixed_ip` and `network_id` must be listed together. If not set, " +
+					"all attributes are managed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(clientDeviceManagedFieldNames...)),
+				},
+			},
+
+			"blocked_schedule": schema.SingleNestedAttribute{
+				MarkdownDescription: "Block the client's internet access during a recurring time window, e.g. a " +
+					"bedtime cutoff. Implemented as a controller traffic rule targeting this client, which the " +
+					"provider creates, updates, and deletes along with this resource. Cannot be combined with " +
+					"`blocked = true`.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"time_range_start": schema.StringAttribute{
+						MarkdownDescription: "Start of the blocked window in 24-hour `HH:MM` format (e.g. `21:30`).",
+						Required:            true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(timeOfDayRegexp, "must be a time in HH:MM format"),
+						},
+					},
+					"time_range_end": schema.StringAttribute{
+						MarkdownDescription: "End of the blocked window in 24-hour `HH:MM` format (e.g. `07:00`). " +
+							"A window that ends before it starts runs past midnight.",
+						Required: true,
+						Validators: []validator.String{
+							stringvalidator.RegexMatches(timeOfDayRegexp, "must be a time in HH:MM format"),
+						},
+					},
+					"repeat_on_days": schema.SetAttribute{
+						MarkdownDescription: "Days of the week the window applies to. Valid values: `mon`, `tue`, `wed`, " +
+							"`thu`, `fri`, `sat`, `sun`. Omit to block every day.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(
+								stringvalidator.OneOf("mon", "tue", "wed", "thu", "fri", "sat", "sun"),
+							),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -183,6 +279,9 @@ func (r *clientDeviceResource) Schema(
 func (r *clientDeviceResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		clientDeviceFixedIPNetworkValidator{},
+		clientDeviceBlockedScheduleValidator{},
+		clientDeviceManagedDNSRecordValidator{},
+		clientDeviceManagedFieldsValidator{},
 	}
 }
 
@@ -229,9 +328,34 @@ func (r *clientDeviceResource) Create(
 	plannedDeviceTypeID := plan.DeviceTypeID
 
 	site := r.client.SiteOrDefault(plan.Site)
+	managed := clientDeviceManagedFields(&plan)
 	apiObj := r.modelToAPI(ctx, &plan)
 
-	created, err := r.client.CreateClientDevice(ctx, site, apiObj)
+	// With partial ownership the client usually exists already, named and
+	// noted by whoever else manages it. Take over its record instead of
+	// creating a new one, keeping the attributes this resource doesn't own.
+	var existing *unifi.Client
+	if managed != nil {
+		found, err := r.client.GetClientDeviceByMAC(ctx, site, apiObj.MAC)
+		if err != nil {
+			if _, ok := err.(*unifi.NotFoundError); !ok {
+				resp.Diagnostics.AddError("Error Reading Client Device", err.Error())
+				return
+			}
+		} else {
+			existing = found
+		}
+	}
+
+	var created *unifi.Client
+	var err error
+	if existing != nil {
+		mergeUnmanagedClientFields(apiObj, existing, managed)
+		apiObj.ID = existing.ID
+		created, err = r.client.UpdateClientDevice(ctx, site, apiObj)
+	} else {
+		created, err = r.client.CreateClientDevice(ctx, site, apiObj)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Client Device", err.Error())
 		return
@@ -246,10 +370,26 @@ func (r *clientDeviceResource) Create(
 		}
 	}
 
+	if plan.BlockedSchedule != nil {
+		mac := strings.ToLower(plan.MAC.ValueString())
+		if err := r.syncBlockedSchedule(ctx, site, mac, plan.BlockedSchedule); err != nil {
+			resp.Diagnostics.AddError("Error Setting Blocked Schedule", err.Error())
+			return
+		}
+	}
+
+	if plan.ManageDNSRecord.ValueBool() {
+		if err := r.syncManagedDNSRecord(ctx, site, "", plan.LocalDNSRecord.ValueString(), plan.FixedIP.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error Creating DNS Record", err.Error())
+			return
+		}
+	}
+
 	r.apiToModel(created, &plan, site)
 	plan.ClientGroupIDs = plannedGroupIDs
 	plan.NetworkID = plannedNetworkID
 	plan.DeviceTypeID = plannedDeviceTypeID
+	ignoreUnmanagedClientFields(&plan, managed)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -291,15 +431,48 @@ func (r *clientDeviceResource) Read(
 	// Read fingerprint override via the v2 client info API. This may fail for
 	// clients that have never connected (404) — treat as no override. Other
 	// errors are non-fatal: preserve the prior state value if we can't read.
+	managed := clientDeviceManagedFields(&state)
 	mac := strings.ToLower(state.MAC.ValueString())
-	devTypeID, err := r.client.GetFingerprintOverride(ctx, site, mac)
+	if managed.manages("device_type_id") {
+		devTypeID, err := r.client.GetFingerprintOverride(ctx, site, mac)
+		if err != nil {
+			// Non-fatal: keep prior device_type_id state rather than failing Read.
+			state.DeviceTypeID = priorDeviceTypeID
+		} else if devTypeID != 0 {
+			state.DeviceTypeID = types.Int64Value(devTypeID)
+		} else {
+			state.DeviceTypeID = types.Int64Null()
+		}
+	}
+	ignoreUnmanagedClientFields(&state, managed)
+
+	// Look the blocking rule up even when state has no schedule, so a device
+	// imported after an earlier configuration created the rule picks it up,
+	// and removing blocked_schedule from the config deletes it. The lookup
+	// only fails the read for devices that already manage a schedule; for
+	// the others (e.g. on controllers without traffic rules) it is skipped.
+	rule, err := r.findBlockedScheduleRule(ctx, site, mac)
 	if err != nil {
-		// Non-fatal: keep prior device_type_id state rather than failing Read.
-		state.DeviceTypeID = priorDeviceTypeID
-	} else if devTypeID != 0 {
-		state.DeviceTypeID = types.Int64Value(devTypeID)
+		if state.BlockedSchedule != nil {
+			resp.Diagnostics.AddError("Error Reading Blocked Schedule", err.Error())
+			return
+		}
 	} else {
-		state.DeviceTypeID = types.Int64Null()
+		state.BlockedSchedule = blockedScheduleFromRule(rule)
+	}
+
+	// Likewise for the managed DNS record. If it was deleted or edited
+	// outside Terraform, report manage_dns_record as false so the next apply
+	// puts it back.
+	if state.ManageDNSRecord.ValueBool() {
+		record, err := r.findManagedDNSRecord(ctx, site, state.LocalDNSRecord.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading DNS Record", err.Error())
+			return
+		}
+		state.ManageDNSRecord = types.BoolValue(managedDNSRecordInSync(record, state.FixedIP.ValueString()))
+	} else {
+		state.ManageDNSRecord = types.BoolValue(false)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -322,13 +495,33 @@ func (r *clientDeviceResource) Update(
 	plannedNetworkID := plan.NetworkID
 	plannedDeviceTypeID := plan.DeviceTypeID
 
+	// The managed DNS record is found by its current name, which the plan
+	// may be about to change.
+	var priorDNSName string
+	if state.ManageDNSRecord.ValueBool() {
+		priorDNSName = state.LocalDNSRecord.ValueString()
+	}
+
 	r.applyPlanToState(&plan, &state)
 
 	site := r.client.SiteOrDefault(state.Site)
 	mac := strings.ToLower(state.MAC.ValueString())
+	managed := clientDeviceManagedFields(&plan)
 	apiObj := r.modelToAPI(ctx, &state)
 	apiObj.ID = state.ID.ValueString()
 
+	if managed != nil {
+		current, err := r.client.GetClientDevice(ctx, site, apiObj.ID)
+		if err != nil {
+			if _, ok := err.(*unifi.NotFoundError); !ok {
+				resp.Diagnostics.AddError("Error Reading Client Device for Update", err.Error())
+				return
+			}
+		} else {
+			mergeUnmanagedClientFields(apiObj, current, managed)
+		}
+	}
+
 	updated, err := r.client.UpdateClientDevice(ctx, site, apiObj)
 	if err != nil {
 		if _, ok := err.(*unifi.NotFoundError); !ok {
@@ -354,15 +547,37 @@ func (r *clientDeviceResource) Update(
 		}
 	}
 
-	if err := r.syncFingerprintOverride(ctx, site, mac, plannedDeviceTypeID); err != nil {
-		resp.Diagnostics.AddError("Error Setting Fingerprint Override", err.Error())
-		return
+	if managed.manages("device_type_id") {
+		if err := r.syncFingerprintOverride(ctx, site, mac, plannedDeviceTypeID); err != nil {
+			resp.Diagnostics.AddError("Error Setting Fingerprint Override", err.Error())
+			return
+		}
+	}
+
+	if plan.BlockedSchedule != nil || state.BlockedSchedule != nil {
+		if err := r.syncBlockedSchedule(ctx, site, mac, plan.BlockedSchedule); err != nil {
+			resp.Diagnostics.AddError("Error Setting Blocked Schedule", err.Error())
+			return
+		}
+	}
+	state.BlockedSchedule = plan.BlockedSchedule
+
+	if plan.ManageDNSRecord.ValueBool() || priorDNSName != "" {
+		var name, ip string
+		if plan.ManageDNSRecord.ValueBool() {
+			name, ip = plan.LocalDNSRecord.ValueString(), plan.FixedIP.ValueString()
+		}
+		if err := r.syncManagedDNSRecord(ctx, site, priorDNSName, name, ip); err != nil {
+			resp.Diagnostics.AddError("Error Updating DNS Record", err.Error())
+			return
+		}
 	}
 
 	r.apiToModel(updated, &state, site)
 	state.ClientGroupIDs = plannedGroupIDs
 	state.NetworkID = plannedNetworkID
 	state.DeviceTypeID = plannedDeviceTypeID
+	ignoreUnmanagedClientFields(&state, managed)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -385,29 +600,63 @@ func (r *clientDeviceResource) Delete(
 		_ = r.client.SetFingerprintOverride(ctx, site, mac, 0)
 	}
 
-	// Clear all network and group bindings before deleting. The controller
-	// retains DHCP reservations, DNS records, and group references even after
-	// the user record is removed. Sending an update with all bindings cleared
-	// ensures dependent resources (networks, client groups) can be deleted.
 	mac := strings.ToLower(state.MAC.ValueString())
-	clearObj := &unifi.Client{
-		ID:  state.ID.ValueString(),
-		MAC: mac,
-	}
-	_, err := r.client.UpdateClientDevice(ctx, site, clearObj)
-	if err != nil {
-		if _, ok := err.(*unifi.NotFoundError); ok {
-			// Controller auto-cleaned the user record (common for non-connected
-			// MACs), but network references may persist. Look up by MAC and
-			// clear bindings on the current record before forgetting.
-			if found, lookupErr := r.client.GetClientDeviceByMAC(ctx, site, mac); lookupErr == nil {
-				clearObj.ID = found.ID
-				_, _ = r.client.UpdateClientDevice(ctx, site, clearObj)
+	managed := clientDeviceManagedFields(&state)
+
+	if managed == nil {
+		// Clear all network and group bindings before deleting. The controller
+		// retains DHCP reservations, DNS records, and group references even after
+		// the user record is removed. Sending an update with all bindings cleared
+		// ensures dependent resources (networks, client groups) can be deleted.
+		clearObj := &unifi.Client{
+			ID:  state.ID.ValueString(),
+			MAC: mac,
+		}
+		_, err := r.client.UpdateClientDevice(ctx, site, clearObj)
+		if err != nil {
+			if _, ok := err.(*unifi.NotFoundError); ok {
+				// Controller auto-cleaned the user record (common for non-connected
+				// MACs), but network references may persist. Look up by MAC and
+				// clear bindings on the current record before forgetting.
+				if found, lookupErr := r.client.GetClientDeviceByMAC(ctx, site, mac); lookupErr == nil {
+					clearObj.ID = found.ID
+					_, _ = r.client.UpdateClientDevice(ctx, site, clearObj)
+				}
 			}
+			// Non-404 errors clearing bindings are not fatal — proceed to delete.
+			// The delete itself may still succeed, and dependent resource deletes
+			// (e.g., client groups) have retry logic for stale references.
+		}
+	}
+
+	if state.BlockedSchedule != nil {
+		if err := r.syncBlockedSchedule(ctx, site, mac, nil); err != nil {
+			resp.Diagnostics.AddError("Error Removing Blocked Schedule", err.Error())
+			return
+		}
+	}
+
+	if state.ManageDNSRecord.ValueBool() {
+		if err := r.syncManagedDNSRecord(ctx, site, state.LocalDNSRecord.ValueString(), "", ""); err != nil {
+			resp.Diagnostics.AddError("Error Deleting DNS Record", err.Error())
+			return
 		}
-		// Non-404 errors clearing bindings are not fatal — proceed to delete.
-		// The delete itself may still succeed, and dependent resource deletes
-		// (e.g., client groups) have retry logic for stale references.
+	}
+
+	// With partial ownership the client belongs to someone else too: clear
+	// only the attributes this resource owns and keep the record instead of
+	// forgetting it.
+	if managed != nil {
+		current, err := r.client.GetClientDevice(ctx, site, state.ID.ValueString())
+		if err == nil {
+			clearObj := &unifi.Client{ID: current.ID, MAC: mac}
+			mergeUnmanagedClientFields(clearObj, current, managed)
+			_, err = r.client.UpdateClientDevice(ctx, site, clearObj)
+		}
+		if _, ok := err.(*unifi.NotFoundError); err != nil && !ok {
+			resp.Diagnostics.AddError("Error Deleting Client Device", err.Error())
+		}
+		return
 	}
 
 	if err := r.client.ForgetClientDevicesByMAC(ctx, site, []string{mac}); err != nil {
@@ -480,10 +729,385 @@ func (v clientDeviceFixedIPNetworkValidator) ValidateResource(ctx context.Contex
 	}
 }
 
+// clientDeviceBlockedScheduleValidator rejects a blocked schedule on a client
+// that is already blocked permanently.
+type clientDeviceBlockedScheduleValidator struct{}
+
+func (v clientDeviceBlockedScheduleValidator) Description(_ context.Context) string {
+	return "blocked_schedule cannot be specified when blocked is true."
+}
+
+func (v clientDeviceBlockedScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clientDeviceBlockedScheduleValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var blocked types.Bool
+	var schedule types.Object
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocked"), &blocked)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocked_schedule"), &schedule)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if blocked.ValueBool() && !schedule.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocked_schedule"),
+			"Conflicting Block Attributes",
+			"Attribute \"blocked_schedule\" cannot be specified when \"blocked\" is true.",
+		)
+	}
+}
+
+// clientDeviceManagedDNSRecordValidator requires a hostname for the managed
+// DNS record.
+type clientDeviceManagedDNSRecordValidator struct{}
+
+func (v clientDeviceManagedDNSRecordValidator) Description(_ context.Context) string {
+	return "When manage_dns_record is true, local_dns_record must also be specified."
+}
+
+func (v clientDeviceManagedDNSRecordValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clientDeviceManagedDNSRecordValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var manage types.Bool
+	var localDNSRecord types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("manage_dns_record"), &manage)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("local_dns_record"), &localDNSRecord)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if manage.ValueBool() && localDNSRecord.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("manage_dns_record"),
+			"Missing DNS Record Name",
+			"Attribute \"local_dns_record\" must be specified when \"manage_dns_record\" is true.",
+		)
+	}
+}
+
+// clientDeviceManagedFieldsValidator rejects values for attributes that
+// managed_fields leaves to someone else, which would otherwise be silently
+// ignored.
+type clientDeviceManagedFieldsValidator struct{}
+
+func (v clientDeviceManagedFieldsValidator) Description(_ context.Context) string {
+	return "When managed_fields is specified, only the attributes it lists may be specified, and fixed_ip and network_id must be listed together."
+}
+
+func (v clientDeviceManagedFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v clientDeviceManagedFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var managedFields types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("managed_fields"), &managedFields)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, e := range managedFields.Elements() {
+		if e.IsUnknown() {
+			return
+		}
+	}
+	managed := clientDeviceManagedFields(&clientDeviceResourceModel{ManagedFields: managedFields})
+	if managed == nil {
+		return
+	}
+
+	if managed.manages("fixed_ip") != managed.manages("network_id") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("managed_fields"),
+			"Incomplete Managed Fields",
+			"\"fixed_ip\" and \"network_id\" must be listed together in \"managed_fields\".",
+		)
+	}
+
+	for _, name := range clientDeviceManagedFieldNames {
+		if managed.manages(name) {
+			continue
+		}
+		var value attr.Value
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if value != nil && !value.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Unmanaged Attribute",
+				fmt.Sprintf("Attribute %q cannot be specified because it is not listed in \"managed_fields\".", name),
+			)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helper methods
 // ---------------------------------------------------------------------------
 
+// clientDeviceFieldSet is the set of attributes a client device resource
+// owns. A nil set means all of them.
+type clientDeviceFieldSet map[string]bool
+
+func (s clientDeviceFieldSet) manages(name string) bool {
+	return s == nil || s[name]
+}
+
+// clientDeviceManagedFields returns the attributes listed in managed_fields,
+// or nil when it isn't set.
+func clientDeviceManagedFields(m *clientDeviceResourceModel) clientDeviceFieldSet {
+	if m.ManagedFields.IsNull() || m.ManagedFields.IsUnknown() {
+		return nil
+	}
+	fields := clientDeviceFieldSet{}
+	for _, name := range sortedSetStrings(m.ManagedFields) {
+		fields[name] = true
+	}
+	return fields
+}
+
+// ignoreUnmanagedClientFields drops the controller's values for attributes
+// the resource doesn't own, so changes made to them elsewhere don't show up
+// as drift. blocked has a default and is reset to it rather than to null.
+func ignoreUnmanagedClientFields(m *clientDeviceResourceModel, managed clientDeviceFieldSet) {
+	if managed == nil {
+		return
+	}
+	if !managed["name"] {
+		m.Name = types.StringNull()
+	}
+	if !managed["note"] {
+		m.Note = types.StringNull()
+	}
+	if !managed["fixed_ip"] {
+		m.FixedIP = types.StringNull()
+		m.NetworkID = types.StringNull()
+	}
+	if !managed["network_override_id"] {
+		m.NetworkOverrideID = types.StringNull()
+	}
+	if !managed["local_dns_record"] {
+		m.LocalDNSRecord = types.StringNull()
+	}
+	if !managed["client_group_ids"] {
+		m.ClientGroupIDs = types.SetNull(types.StringType)
+	}
+	if !managed["device_type_id"] {
+		m.DeviceTypeID = types.Int64Null()
+	}
+	if !managed["fixed_ap_mac"] {
+		m.FixedApMAC = types.StringNull()
+	}
+	if !managed["blocked"] {
+		m.Blocked = types.BoolValue(false)
+	}
+}
+
+// mergeUnmanagedClientFields copies the attributes the resource doesn't own
+// from the controller's current record into c, so that sending c leaves them
+// as they are. Settings that are disabled on the controller stay cleared.
+func mergeUnmanagedClientFields(c, current *unifi.Client, managed clientDeviceFieldSet) {
+	if managed == nil {
+		return
+	}
+	if !managed["name"] {
+		c.Name = current.Name
+	}
+	if !managed["note"] {
+		c.Note = current.Note
+	}
+	if !managed["fixed_ip"] {
+		c.FixedIP, c.NetworkID, c.UseFixedIP = "", "", false
+		if current.UseFixedIP {
+			c.FixedIP, c.NetworkID, c.UseFixedIP = current.FixedIP, current.NetworkID, true
+		}
+	}
+	if !managed["network_override_id"] {
+		c.VirtualNetworkOverrideID, c.VirtualNetworkOverrideEnabled = "", nil
+		if current.VirtualNetworkOverrideEnabled != nil && *current.VirtualNetworkOverrideEnabled {
+			c.VirtualNetworkOverrideID, c.VirtualNetworkOverrideEnabled = current.VirtualNetworkOverrideID, boolPtr(true)
+		}
+	}
+	if !managed["local_dns_record"] {
+		c.LocalDNSRecord, c.LocalDNSRecordEnabled = "", false
+		if current.LocalDNSRecordEnabled {
+			c.LocalDNSRecord, c.LocalDNSRecordEnabled = current.LocalDNSRecord, true
+		}
+	}
+	if !managed["client_group_ids"] {
+		c.NetworkMembersGroupIDs = current.NetworkMembersGroupIDs
+	}
+	if !managed["fixed_ap_mac"] {
+		c.FixedApMAC, c.FixedApEnabled = "", false
+		if current.FixedApEnabled {
+			c.FixedApMAC, c.FixedApEnabled = current.FixedApMAC, true
+		}
+	}
+	if !managed["blocked"] {
+		c.Blocked = current.Blocked
+	}
+}
+
+// findManagedDNSRecord returns the A record named name, or nil if there is
+// none. Like the blocked schedule rule, the record is found by what it
+// contains rather than by an ID in state, so it survives import.
+func (r *clientDeviceResource) findManagedDNSRecord(ctx context.Context, site, name string) (*unifi.DNSRecord, error) {
+	records, err := r.client.ListDNSRecord(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].RecordType == "A" && strings.EqualFold(records[i].Key, name) {
+			return &records[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// syncManagedDNSRecord points the A record for the client's hostname at its
+// fixed IP. priorName is the name the record currently has, if the provider
+// already manages one; an empty name deletes the record.
+func (r *clientDeviceResource) syncManagedDNSRecord(ctx context.Context, site, priorName, name, ip string) error {
+	lookup := priorName
+	if lookup == "" {
+		lookup = name
+	}
+	existing, err := r.findManagedDNSRecord(ctx, site, lookup)
+	if err != nil {
+		return err
+	}
+
+	if name == "" {
+		if existing == nil {
+			return nil
+		}
+		err := r.client.DeleteDNSRecord(ctx, site, existing.ID)
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return nil
+		}
+		return err
+	}
+
+	record := &unifi.DNSRecord{
+		Key:        name,
+		Value:      ip,
+		RecordType: "A",
+		Enabled:    true,
+	}
+	if existing == nil {
+		_, err = r.client.CreateDNSRecord(ctx, site, record)
+		return err
+	}
+	record.ID = existing.ID
+	_, err = r.client.UpdateDNSRecord(ctx, site, record)
+	return err
+}
+
+// managedDNSRecordInSync reports whether the managed DNS record exists and
+// still resolves to the client's fixed IP.
+func managedDNSRecordInSync(record *unifi.DNSRecord, ip string) bool {
+	return record != nil && record.Enabled && record.Value == ip
+}
+
+// blockedScheduleRuleDescription is the description of the traffic rule that
+// implements a client's blocked_schedule. The provider finds the rule by this
+// description, so it needs no extra state and survives import.
+func blockedScheduleRuleDescription(mac string) string {
+	return fmt.Sprintf("Blocked schedule for %s (managed by terrifi)", strings.ToLower(mac))
+}
+
+// findBlockedScheduleRule returns the traffic rule implementing the client's
+// blocked schedule, or nil if there is none.
+func (r *clientDeviceResource) findBlockedScheduleRule(ctx context.Context, site, mac string) (*trafficRule, error) {
+	rules, err := r.client.ListTrafficRule(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	description := blockedScheduleRuleDescription(mac)
+	for i := range rules {
+		if rules[i].Description == description {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// syncBlockedSchedule creates, updates, or (when planned is nil) deletes the
+// traffic rule implementing the client's blocked schedule.
+func (r *clientDeviceResource) syncBlockedSchedule(ctx context.Context, site, mac string, planned *clientDeviceBlockedScheduleModel) error {
+	existing, err := r.findBlockedScheduleRule(ctx, site, mac)
+	if err != nil {
+		return err
+	}
+
+	if planned == nil {
+		if existing == nil {
+			return nil
+		}
+		return r.client.DeleteTrafficRule(ctx, site, existing.ID)
+	}
+
+	rule := blockedScheduleRule(ctx, mac, planned)
+	if existing == nil {
+		_, err = r.client.CreateTrafficRule(ctx, site, rule)
+		return err
+	}
+	rule.ID = existing.ID
+	_, err = r.client.UpdateTrafficRule(ctx, site, rule)
+	return err
+}
+
+// blockedScheduleRule builds the traffic rule that blocks the client's
+// internet access during the planned window.
+func blockedScheduleRule(ctx context.Context, mac string, m *clientDeviceBlockedScheduleModel) *trafficRule {
+	schedule := firewallPolicyScheduleRequest{
+		Mode:           "EVERY_DAY",
+		TimeAllDay:     boolPtr(false),
+		TimeRangeStart: m.TimeRangeStart.ValueString(),
+		TimeRangeEnd:   m.TimeRangeEnd.ValueString(),
+	}
+	if !m.RepeatOnDays.IsNull() && !m.RepeatOnDays.IsUnknown() {
+		schedule.Mode = "EVERY_WEEK"
+		schedule.RepeatOnDays = sortedSetStrings(m.RepeatOnDays)
+	}
+
+	return &trafficRule{
+		Action:         "BLOCK",
+		Description:    blockedScheduleRuleDescription(mac),
+		Enabled:        true,
+		MatchingTarget: "INTERNET",
+		TargetDevices: []trafficRuleTarget{
+			{Type: "CLIENT", ClientMAC: strings.ToLower(mac)},
+		},
+		Schedule: schedule,
+	}
+}
+
+// blockedScheduleFromRule converts a blocked schedule traffic rule back into
+// the model. A missing rule yields nil.
+func blockedScheduleFromRule(rule *trafficRule) *clientDeviceBlockedScheduleModel {
+	if rule == nil {
+		return nil
+	}
+	m := &clientDeviceBlockedScheduleModel{
+		TimeRangeStart: types.StringValue(rule.Schedule.TimeRangeStart),
+		TimeRangeEnd:   types.StringValue(rule.Schedule.TimeRangeEnd),
+		RepeatOnDays:   types.SetNull(types.StringType),
+	}
+	if rule.Schedule.Mode == "EVERY_WEEK" && len(rule.Schedule.RepeatOnDays) > 0 {
+		m.RepeatOnDays = stringSetValue(rule.Schedule.RepeatOnDays, strings.ToLower)
+	}
+	return m
+}
+
 // syncFingerprintOverride sets or clears the fingerprint override based on the
 // planned device_type_id value. If the plan value is null (user removed the
 // attribute), the override is cleared. If set, the override is applied.
@@ -529,6 +1153,9 @@ func (r *clientDeviceResource) applyPlanToState(plan, state *clientDeviceResourc
 	} else {
 		state.LocalDNSRecord = types.StringNull()
 	}
+	if !plan.ManageDNSRecord.IsNull() && !plan.ManageDNSRecord.IsUnknown() {
+		state.ManageDNSRecord = plan.ManageDNSRecord
+	}
 	if !plan.ClientGroupIDs.IsNull() && !plan.ClientGroupIDs.IsUnknown() {
 		state.ClientGroupIDs = plan.ClientGroupIDs
 	} else {
@@ -549,6 +1176,9 @@ func (r *clientDeviceResource) applyPlanToState(plan, state *clientDeviceResourc
 	} else {
 		state.Blocked = types.BoolNull()
 	}
+	// Which attributes are sent depends on managed_fields, so Update must
+	// follow the plan's list, including when it is added or removed.
+	state.ManagedFields = plan.ManagedFields
 }
 
 func (r *clientDeviceResource) modelToAPI(ctx context.Context, m *clientDeviceResourceModel) *unifi.Client {
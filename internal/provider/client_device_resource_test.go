@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/rand"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -643,6 +644,295 @@ func TestClientDeviceAPIToModel(t *testing.T) {
 	})
 }
 
+func TestBlockedScheduleRule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("every day", func(t *testing.T) {
+		m := &clientDeviceBlockedScheduleModel{
+			TimeRangeStart: types.StringValue("21:30"),
+			TimeRangeEnd:   types.StringValue("07:00"),
+			RepeatOnDays:   types.SetNull(types.StringType),
+		}
+
+		rule := blockedScheduleRule(ctx, "AA:BB:CC:DD:EE:FF", m)
+
+		assert.Equal(t, "BLOCK", rule.Action)
+		assert.Equal(t, "INTERNET", rule.MatchingTarget)
+		assert.True(t, rule.Enabled)
+		assert.Equal(t, "Blocked schedule for aa:bb:cc:dd:ee:ff (managed by terrifi)", rule.Description)
+		assert.Equal(t, []trafficRuleTarget{{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"}}, rule.TargetDevices)
+		assert.Equal(t, "EVERY_DAY", rule.Schedule.Mode)
+		assert.Equal(t, "21:30", rule.Schedule.TimeRangeStart)
+		assert.Equal(t, "07:00", rule.Schedule.TimeRangeEnd)
+		assert.Empty(t, rule.Schedule.RepeatOnDays)
+
+		assert.Equal(t, m, blockedScheduleFromRule(rule))
+	})
+
+	t.Run("selected days", func(t *testing.T) {
+		m := &clientDeviceBlockedScheduleModel{
+			TimeRangeStart: types.StringValue("22:00"),
+			TimeRangeEnd:   types.StringValue("06:00"),
+			RepeatOnDays: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("mon"), types.StringValue("tue"),
+			}),
+		}
+
+		rule := blockedScheduleRule(ctx, "aa:bb:cc:dd:ee:ff", m)
+
+		assert.Equal(t, "EVERY_WEEK", rule.Schedule.Mode)
+		assert.ElementsMatch(t, []string{"mon", "tue"}, rule.Schedule.RepeatOnDays)
+
+		got := blockedScheduleFromRule(rule)
+		assert.True(t, m.RepeatOnDays.Equal(got.RepeatOnDays))
+	})
+
+	t.Run("no rule", func(t *testing.T) {
+		assert.Nil(t, blockedScheduleFromRule(nil))
+	})
+}
+
+func TestSyncManagedDNSRecord(t *testing.T) {
+	ctx := context.Background()
+	f := newFakeClient()
+	f.dnsRecords["other"] = unifi.DNSRecord{ID: "other", Key: "nas.home", Value: "nas.example.com", RecordType: "CNAME"}
+	r := &clientDeviceResource{client: f}
+
+	records := func() []unifi.DNSRecord {
+		var out []unifi.DNSRecord
+		for id, rec := range f.dnsRecords {
+			if id != "other" {
+				out = append(out, rec)
+			}
+		}
+		return out
+	}
+
+	require.NoError(t, r.syncManagedDNSRecord(ctx, "default", "", "nas.home", "192.168.1.10"))
+	require.Len(t, records(), 1)
+	created := records()[0]
+	assert.Equal(t, "nas.home", created.Key)
+	assert.Equal(t, "192.168.1.10", created.Value)
+	assert.Equal(t, "A", created.RecordType)
+	assert.True(t, created.Enabled)
+
+	found, err := r.findManagedDNSRecord(ctx, "default", "NAS.home")
+	require.NoError(t, err)
+	require.NotNil(t, found, "lookup ignores case and skips other record types")
+	assert.Equal(t, created.ID, found.ID)
+	assert.True(t, managedDNSRecordInSync(found, "192.168.1.10"))
+	assert.False(t, managedDNSRecordInSync(found, "192.168.1.11"))
+	assert.False(t, managedDNSRecordInSync(nil, "192.168.1.10"))
+
+	// Renaming and readdressing updates the record in place.
+	require.NoError(t, r.syncManagedDNSRecord(ctx, "default", "nas.home", "storage.home", "192.168.1.11"))
+	require.Len(t, records(), 1)
+	assert.Equal(t, created.ID, records()[0].ID)
+	assert.Equal(t, "storage.home", records()[0].Key)
+	assert.Equal(t, "192.168.1.11", records()[0].Value)
+
+	require.NoError(t, r.syncManagedDNSRecord(ctx, "default", "storage.home", "", ""))
+	assert.Empty(t, records())
+	assert.Contains(t, f.dnsRecords, "other", "unrelated records are left alone")
+
+	// Deleting a record that is already gone is not an error.
+	require.NoError(t, r.syncManagedDNSRecord(ctx, "default", "storage.home", "", ""))
+}
+
+func TestClientDeviceManagedFields(t *testing.T) {
+	r := &clientDeviceResource{}
+	ctx := context.Background()
+
+	current := &unifi.Client{
+		ID:                            "abc",
+		MAC:                           "aa:bb:cc:dd:ee:ff",
+		Name:                          "Set in the UI",
+		Note:                          "Also set in the UI",
+		UseFixedIP:                    true,
+		FixedIP:                       "192.168.1.50",
+		NetworkID:                     "net-old",
+		VirtualNetworkOverrideEnabled: boolPtr(false),
+		VirtualNetworkOverrideID:      "net-stale",
+		LocalDNSRecordEnabled:         true,
+		LocalDNSRecord:                "printer.home",
+		NetworkMembersGroupIDs:        []string{"grp1"},
+		Blocked:                       boolPtr(true),
+	}
+
+	t.Run("nil set manages everything", func(t *testing.T) {
+		m := &clientDeviceResourceModel{ManagedFields: types.SetNull(types.StringType)}
+		managed := clientDeviceManagedFields(m)
+		assert.Nil(t, managed)
+		assert.True(t, managed.manages("name"))
+
+		c := &unifi.Client{MAC: "aa:bb:cc:dd:ee:ff"}
+		mergeUnmanagedClientFields(c, current, managed)
+		assert.Empty(t, c.Name)
+	})
+
+	t.Run("merge keeps unmanaged values", func(t *testing.T) {
+		m := &clientDeviceResourceModel{
+			MAC:           types.StringValue("aa:bb:cc:dd:ee:ff"),
+			FixedIP:       types.StringValue("192.168.1.60"),
+			NetworkID:     types.StringValue("net-new"),
+			ManagedFields: stringSet("fixed_ip", "network_id"),
+		}
+		managed := clientDeviceManagedFields(m)
+		assert.True(t, managed.manages("fixed_ip"))
+		assert.False(t, managed.manages("name"))
+
+		c := r.modelToAPI(ctx, m)
+		mergeUnmanagedClientFields(c, current, managed)
+
+		assert.Equal(t, "192.168.1.60", c.FixedIP)
+		assert.Equal(t, "net-new", c.NetworkID)
+		assert.Equal(t, "Set in the UI", c.Name)
+		assert.Equal(t, "Also set in the UI", c.Note)
+		assert.Empty(t, c.VirtualNetworkOverrideID, "disabled settings stay cleared")
+		assert.Equal(t, "printer.home", c.LocalDNSRecord)
+		assert.True(t, c.LocalDNSRecordEnabled)
+		assert.Equal(t, []string{"grp1"}, c.NetworkMembersGroupIDs)
+		require.NotNil(t, c.Blocked)
+		assert.True(t, *c.Blocked)
+	})
+
+	t.Run("merge clears managed values on delete", func(t *testing.T) {
+		managed := clientDeviceFieldSet{"fixed_ip": true, "network_id": true}
+		c := &unifi.Client{ID: "abc", MAC: "aa:bb:cc:dd:ee:ff"}
+		mergeUnmanagedClientFields(c, current, managed)
+
+		req := buildClientDeviceRequest(c)
+		assert.Equal(t, "Set in the UI", req.Name)
+		assert.Empty(t, req.FixedIP)
+		require.NotNil(t, req.UseFixedIP)
+		assert.False(t, *req.UseFixedIP)
+	})
+
+	t.Run("state ignores unmanaged values", func(t *testing.T) {
+		var m clientDeviceResourceModel
+		r.apiToModel(current, &m, "default")
+		ignoreUnmanagedClientFields(&m, clientDeviceFieldSet{"note": true})
+
+		assert.True(t, m.Name.IsNull())
+		assert.Equal(t, "Also set in the UI", m.Note.ValueString())
+		assert.True(t, m.FixedIP.IsNull())
+		assert.True(t, m.NetworkID.IsNull())
+		assert.True(t, m.LocalDNSRecord.IsNull())
+		assert.True(t, m.ClientGroupIDs.IsNull())
+		assert.False(t, m.Blocked.ValueBool(), "blocked is reported at its default")
+	})
+}
+
+func TestClientDeviceUpdateManagedFields(t *testing.T) {
+	const mac = "aa:bb:cc:dd:ee:ff"
+	f := newFakeClient()
+	f.clients["c1"] = unifi.Client{ID: "c1", MAC: mac, Name: "Renamed in UI", Note: "Set in the UI"}
+	f.fingerprints[mac] = unifi.ClientInfoFingerprint{}
+	r := &clientDeviceResource{client: f}
+
+	full := clientDeviceResourceModel{
+		ID:              types.StringValue("c1"),
+		Site:            types.StringValue("default"),
+		MAC:             types.StringValue(mac),
+		Name:            types.StringValue("Printer"),
+		ManageDNSRecord: types.BoolValue(false),
+		ClientGroupIDs:  types.SetNull(types.StringType),
+		Blocked:         types.BoolValue(false),
+		ManagedFields:   types.SetNull(types.StringType),
+	}
+	partial := full
+	partial.Name = types.StringNull()
+	partial.FixedIP = types.StringValue("192.168.1.50")
+	partial.NetworkID = types.StringValue("net1")
+	partial.ManagedFields = stringSet("fixed_ip", "network_id")
+
+	t.Run("adding managed_fields keeps unmanaged values", func(t *testing.T) {
+		got, diags := testUpdate(t, r, full, partial)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, partial.ManagedFields, got.ManagedFields)
+		assert.True(t, got.Name.IsNull())
+		assert.Equal(t, "192.168.1.50", got.FixedIP.ValueString())
+
+		stored := f.clients["c1"]
+		assert.Equal(t, "Renamed in UI", stored.Name)
+		assert.Equal(t, "Set in the UI", stored.Note)
+		assert.Equal(t, "192.168.1.50", stored.FixedIP)
+	})
+
+	t.Run("removing managed_fields manages everything", func(t *testing.T) {
+		got, diags := testUpdate(t, r, partial, full)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.True(t, got.ManagedFields.IsNull())
+		assert.Equal(t, "Printer", got.Name.ValueString())
+
+		stored := f.clients["c1"]
+		assert.Equal(t, "Printer", stored.Name)
+		assert.Empty(t, stored.Note, "attributes not in config are cleared")
+		assert.Empty(t, stored.FixedIP)
+	})
+}
+
+func TestClientDeviceReadBlockedSchedule(t *testing.T) {
+	const mac = "aa:bb:cc:dd:ee:ff"
+	imported := clientDeviceResourceModel{
+		ID:             types.StringValue("c1"),
+		Site:           types.StringNull(),
+		ClientGroupIDs: types.SetNull(types.StringType),
+		ManagedFields:  types.SetNull(types.StringType),
+	}
+	schedule := &clientDeviceBlockedScheduleModel{
+		TimeRangeStart: types.StringValue("21:30"),
+		TimeRangeEnd:   types.StringValue("07:00"),
+		RepeatOnDays:   types.SetNull(types.StringType),
+	}
+	newFake := func() *fakeClient {
+		f := newFakeClient()
+		f.clients["c1"] = unifi.Client{ID: "c1", MAC: mac, Name: "Tablet"}
+		f.fingerprints[mac] = unifi.ClientInfoFingerprint{}
+		return f
+	}
+
+	t.Run("import picks up the existing rule", func(t *testing.T) {
+		f := newFake()
+		rule := *blockedScheduleRule(context.Background(), mac, schedule)
+		rule.ID = "rule1"
+		f.trafficRules["rule1"] = rule
+		r := &clientDeviceResource{client: f}
+
+		got, diags := testRead(t, r, imported)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, schedule, got.BlockedSchedule)
+
+		// Applying the same schedule updates the rule rather than adding one.
+		require.NoError(t, r.syncBlockedSchedule(context.Background(), "default", mac, got.BlockedSchedule))
+		assert.Len(t, f.trafficRules, 1)
+	})
+
+	t.Run("no rule", func(t *testing.T) {
+		r := &clientDeviceResource{client: newFake()}
+
+		got, diags := testRead(t, r, imported)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Nil(t, got.BlockedSchedule)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		f := newFake()
+		r := &clientDeviceResource{client: f}
+
+		f.failNext("ListTrafficRule", fmt.Errorf("not supported"))
+		got, diags := testRead(t, r, imported)
+		require.False(t, diags.HasError(), "devices without a schedule are still read: %v", diags)
+		assert.Nil(t, got.BlockedSchedule)
+
+		withSchedule := imported
+		withSchedule.BlockedSchedule = schedule
+		f.failNext("ListTrafficRule", fmt.Errorf("not supported"))
+		_, diags = testRead(t, r, withSchedule)
+		assert.True(t, diags.HasError())
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests — require TF_ACC=1 and a UniFi controller
 // ---------------------------------------------------------------------------
@@ -1956,3 +2246,255 @@ resource "terrifi_client_device" "test" {
 		},
 	})
 }
+
+func TestAccClientDevice_blockedSchedule(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-blocked-schedule"
+
+  blocked_schedule = {
+    time_range_start = "21:30"
+    time_range_end   = "07:00"
+  }
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "blocked", "false"),
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "blocked_schedule.time_range_start", "21:30"),
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "blocked_schedule.time_range_end", "07:00"),
+					resource.TestCheckNoResourceAttr("terrifi_client_device.test", "blocked_schedule.repeat_on_days"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-blocked-schedule"
+
+  blocked_schedule = {
+    time_range_start = "22:00"
+    time_range_end   = "06:00"
+    repeat_on_days   = ["sun", "mon", "tue", "wed", "thu"]
+  }
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "blocked_schedule.time_range_start", "22:00"),
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "blocked_schedule.repeat_on_days.#", "5"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-blocked-schedule"
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_client_device.test", "blocked_schedule.time_range_start"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClientDevice_validationBlockedSchedule(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac     = %q
+  name    = "tfacc-blocked-schedule-conflict"
+  blocked = true
+
+  blocked_schedule = {
+    time_range_start = "21:30"
+    time_range_end   = "07:00"
+  }
+}
+`, mac),
+				ExpectError: regexp.MustCompile(`Conflicting Block Attributes`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-blocked-schedule-time"
+
+  blocked_schedule = {
+    time_range_start = "9pm"
+    time_range_end   = "07:00"
+  }
+}
+`, mac),
+				ExpectError: regexp.MustCompile(`HH:MM`),
+			},
+		},
+	})
+}
+
+func TestAccClientDevice_manageDNSRecord(t *testing.T) {
+	mac := randomMAC()
+	netName := fmt.Sprintf("tfacc-mdns-%s", randomSuffix())
+	dnsName := fmt.Sprintf("tfacc-mdns-%s.local", randomSuffix())
+	vlan := randomVLAN()
+	third := vlan % 256
+
+	config := func(manage bool, host int) string {
+		return fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = %d
+  subnet       = "10.%d.5.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "10.%d.5.6"
+  dhcp_stop    = "10.%d.5.254"
+}
+
+resource "terrifi_client_device" "test" {
+  mac               = %q
+  name              = "tfacc-manage-dns"
+  fixed_ip          = "10.%d.5.%d"
+  network_id        = terrifi_network.test.id
+  local_dns_record  = %q
+  manage_dns_record = %t
+}
+`, netName, vlan, third, third, third, mac, third, host, dnsName, manage)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, 100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "manage_dns_record", "true"),
+				),
+			},
+			{
+				// Changing the fixed IP moves the record with it.
+				Config: config(true, 101),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "fixed_ip", fmt.Sprintf("10.%d.5.101", third)),
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "manage_dns_record", "true"),
+				),
+			},
+			{
+				Config: config(false, 101),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "manage_dns_record", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClientDevice_validationManageDNSRecord(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac               = %q
+  name              = "tfacc-manage-dns-missing"
+  manage_dns_record = true
+}
+`, mac),
+				ExpectError: regexp.MustCompile(`Missing DNS Record Name`),
+			},
+		},
+	})
+}
+
+func TestAccClientDevice_managedFields(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-managed-fields"
+  note = "owned by another tool"
+}
+`, mac),
+			},
+			{
+				// Hand the note over to someone else: it is kept on the
+				// controller but no longer tracked.
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac            = %q
+  name           = "tfacc-managed-fields-renamed"
+  managed_fields = ["name"]
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "name", "tfacc-managed-fields-renamed"),
+					resource.TestCheckNoResourceAttr("terrifi_client_device.test", "note"),
+					resource.TestCheckResourceAttr("terrifi_client_device.test", "managed_fields.#", "1"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac            = %q
+  name           = "tfacc-managed-fields-renamed"
+  managed_fields = ["name"]
+}
+`, mac),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccClientDevice_validationManagedFields(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac            = %q
+  name           = "tfacc-managed-fields"
+  note           = "not managed"
+  managed_fields = ["name"]
+}
+`, mac),
+				ExpectError: regexp.MustCompile(`not listed in "managed_fields"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac            = %q
+  fixed_ip       = "192.168.1.50"
+  network_id     = "abc"
+  managed_fields = ["fixed_ip"]
+}
+`, mac),
+				ExpectError: regexp.MustCompile(`must be listed together`),
+			},
+		},
+	})
+}
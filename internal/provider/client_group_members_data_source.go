@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &clientGroupMembersDataSource{}
+
+func NewClientGroupMembersDataSource() datasource.DataSource {
+	return &clientGroupMembersDataSource{}
+}
+
+type clientGroupMembersDataSource struct {
+	client ClientAPI
+}
+
+type clientGroupMembersDataSourceModel struct {
+	Site    types.String             `tfsdk:"site"`
+	GroupID types.String             `tfsdk:"group_id"`
+	MACs    types.List               `tfsdk:"macs"`
+	Clients []clientGroupMemberModel `tfsdk:"clients"`
+}
+
+type clientGroupMemberModel struct {
+	ID      types.String `tfsdk:"id"`
+	MAC     types.String `tfsdk:"mac"`
+	Name    types.String `tfsdk:"name"`
+	Blocked types.Bool   `tfsdk:"blocked"`
+}
+
+func (d *clientGroupMembersDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_client_group_members"
+}
+
+func (d *clientGroupMembersDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the client devices in a client group and whether each is blocked. Combined with " +
+			"`for_each` on `terrifi_client_device`, this blocks or unblocks every member of a group with one " +
+			"variable, without a resource per device in the configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the client group, e.g. from `terrifi_client_group`.",
+				Required:            true,
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to search. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"macs": schema.ListAttribute{
+				MarkdownDescription: "The lower-cased MAC addresses of the group's members, sorted.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+
+			"clients": schema.ListNestedAttribute{
+				MarkdownDescription: "The group's members, ordered by MAC address.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the client device.",
+							Computed:            true,
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "The lower-cased MAC address of the client device.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the client device. Null if not set.",
+							Computed:            true,
+						},
+						"blocked": schema.BoolAttribute{
+							MarkdownDescription: "Whether the client device is blocked from network access.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *clientGroupMembersDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *clientGroupMembersDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config clientGroupMembersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	clients, err := d.client.ListClientDevices(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Client Devices",
+			fmt.Sprintf("Could not list client devices in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	members := clientGroupMembersToModels(clients, config.GroupID.ValueString())
+	macs := make([]string, len(members))
+	for i, m := range members {
+		macs[i] = m.MAC.ValueString()
+	}
+
+	config.Site = types.StringValue(site)
+	config.Clients = members
+	macList, diags := types.ListValueFrom(ctx, types.StringType, macs)
+	resp.Diagnostics.Append(diags...)
+	config.MACs = macList
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// clientGroupMembersToModels returns the clients in groupID as data source
+// models, ordered by MAC address. Like clientGroupMembers, MACs are
+// lower-cased.
+func clientGroupMembersToModels(clients []unifi.Client, groupID string) []clientGroupMemberModel {
+	members := []clientGroupMemberModel{}
+	for _, c := range clients {
+		if !slices.Contains(c.NetworkMembersGroupIDs, groupID) {
+			continue
+		}
+		members = append(members, clientGroupMemberModel{
+			ID:      types.StringValue(c.ID),
+			MAC:     types.StringValue(strings.ToLower(c.MAC)),
+			Name:    stringValueOrNull(c.Name),
+			Blocked: types.BoolValue(c.Blocked != nil && *c.Blocked),
+		})
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].MAC.ValueString() < members[j].MAC.ValueString()
+	})
+	return members
+}
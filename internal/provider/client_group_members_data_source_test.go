@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests — no TF_ACC, no network, no env vars needed
+// ---------------------------------------------------------------------------
+
+func TestClientGroupMembersToModels(t *testing.T) {
+	blocked := true
+	unblocked := false
+	clients := []unifi.Client{
+		{ID: "c1", MAC: "AA:00:00:00:00:02", Name: "Guest Phone", NetworkMembersGroupIDs: []string{"guests"}, Blocked: &blocked},
+		{ID: "c2", MAC: "aa:00:00:00:00:01", NetworkMembersGroupIDs: []string{"kids", "guests"}, Blocked: &unblocked},
+		{ID: "c3", MAC: "aa:00:00:00:00:03", Name: "Laptop", NetworkMembersGroupIDs: []string{"kids"}},
+		{ID: "c4", MAC: "aa:00:00:00:00:04", Name: "Unknown"},
+	}
+
+	t.Run("members sorted by MAC", func(t *testing.T) {
+		members := clientGroupMembersToModels(clients, "guests")
+		assert.Len(t, members, 2)
+
+		assert.Equal(t, "c2", members[0].ID.ValueString())
+		assert.Equal(t, "aa:00:00:00:00:01", members[0].MAC.ValueString())
+		assert.True(t, members[0].Name.IsNull())
+		assert.False(t, members[0].Blocked.ValueBool())
+
+		assert.Equal(t, "c1", members[1].ID.ValueString())
+		assert.Equal(t, "aa:00:00:00:00:02", members[1].MAC.ValueString())
+		assert.Equal(t, "Guest Phone", members[1].Name.ValueString())
+		assert.True(t, members[1].Blocked.ValueBool())
+	})
+
+	t.Run("unset blocked is false", func(t *testing.T) {
+		members := clientGroupMembersToModels(clients, "kids")
+		assert.Len(t, members, 2)
+		assert.False(t, members[1].Blocked.IsNull())
+		assert.False(t, members[1].Blocked.ValueBool())
+	})
+
+	t.Run("empty group", func(t *testing.T) {
+		assert.Empty(t, clientGroupMembersToModels(clients, "nobody"))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccClientGroupMembersDataSource_basic(t *testing.T) {
+	requireHardware(t)
+	groupName := fmt.Sprintf("tfacc-grpmembers-%s", randomSuffix())
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_group" "test" {
+  name = %q
+}
+
+resource "terrifi_client_device" "test" {
+  mac              = %q
+  name             = "tfacc-group-member"
+  client_group_ids = [terrifi_client_group.test.id]
+  blocked          = true
+}
+
+data "terrifi_client_group_members" "test" {
+  group_id   = terrifi_client_group.test.id
+  depends_on = [terrifi_client_device.test]
+}
+`, groupName, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.terrifi_client_group_members.test", "macs.#", "1"),
+					resource.TestCheckResourceAttr("data.terrifi_client_group_members.test", "macs.0", mac),
+					resource.TestCheckResourceAttr("data.terrifi_client_group_members.test", "clients.0.name", "tfacc-group-member"),
+					resource.TestCheckResourceAttr("data.terrifi_client_group_members.test", "clients.0.blocked", "true"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &clientGroupMembershipResource{}
+	_ resource.ResourceWithImportState = &clientGroupMembershipResource{}
+)
+
+func NewClientGroupMembershipResource() resource.Resource {
+	return &clientGroupMembershipResource{}
+}
+
+type clientGroupMembershipResource struct {
+	client ClientAPI
+}
+
+type clientGroupMembershipResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Site    types.String `tfsdk:"site"`
+	GroupID types.String `tfsdk:"group_id"`
+	MACs    types.Set    `tfsdk:"macs"`
+}
+
+func (r *clientGroupMembershipResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_client_group_membership"
+}
+
+func (r *clientGroupMembershipResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manages the members of a client group. Clients listed in `macs` are " +
+			"added to the group and any other client in the group is removed from it. Do not combine this resource " +
+			"with `client_group_ids` on `terrifi_client_device` for the same group, or the two will fight.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the client group (same as `group_id`).",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site the client group belongs to. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the client group. Use the `id` of a `terrifi_client_group` resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"macs": schema.SetAttribute{
+				MarkdownDescription: "The MAC addresses of the clients that make up the group. Clients the controller " +
+					"does not know yet are created as new client records.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(
+							macRegexp,
+							"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+						),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *clientGroupMembershipResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *clientGroupMembershipResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan clientGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	groupID := plan.GroupID.ValueString()
+
+	if err := r.syncMembership(ctx, site, groupID, setToMACs(plan.MACs)); err != nil {
+		resp.Diagnostics.AddError("Error Setting Client Group Membership", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(groupID)
+	plan.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *clientGroupMembershipResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state clientGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+	groupID := state.ID.ValueString()
+
+	if _, err := r.client.GetNetworkMembersGroup(ctx, site, groupID); err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Client Group",
+			fmt.Sprintf("Could not read client group %s: %s", groupID, err.Error()),
+		)
+		return
+	}
+
+	clients, err := r.client.ListClientDevices(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Client Devices",
+			fmt.Sprintf("Could not list client devices in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	members := clientGroupMembers(clients, groupID)
+
+	// Keep the configured spelling of MACs that only differ in case, so an
+	// upper-case MAC in config doesn't produce a perpetual diff.
+	configured := map[string]string{}
+	for _, mac := range setToMACs(state.MACs) {
+		configured[strings.ToLower(mac)] = mac
+	}
+	vals := make([]attr.Value, len(members))
+	for i, mac := range members {
+		if orig, ok := configured[mac]; ok {
+			mac = orig
+		}
+		vals[i] = types.StringValue(mac)
+	}
+
+	state.ID = types.StringValue(groupID)
+	state.Site = types.StringValue(site)
+	state.GroupID = types.StringValue(groupID)
+	state.MACs = types.SetValueMust(types.StringType, vals)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *clientGroupMembershipResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan clientGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.syncMembership(ctx, site, state.ID.ValueString(), setToMACs(plan.MACs)); err != nil {
+		resp.Diagnostics.AddError("Error Setting Client Group Membership", err.Error())
+		return
+	}
+
+	state.MACs = plan.MACs
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *clientGroupMembershipResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state clientGroupMembershipResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Empty the group. The group itself is managed by terrifi_client_group.
+	if err := r.syncMembership(ctx, site, state.ID.ValueString(), nil); err != nil {
+		resp.Diagnostics.AddError("Error Clearing Client Group Membership", err.Error())
+	}
+}
+
+func (r *clientGroupMembershipResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the group ID, optionally prefixed with "site:".
+	id := req.ID
+	if parts := strings.SplitN(req.ID, ":", 2); len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		id = parts[1]
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), id)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// syncMembership makes macs the exact member list of the group. The client
+// list is fetched once and only records whose membership changes are written.
+func (r *clientGroupMembershipResource) syncMembership(ctx context.Context, site, groupID string, macs []string) error {
+	clients, err := r.client.ListClientDevices(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing client devices: %w", err)
+	}
+
+	changes, missing := planClientGroupMembership(clients, groupID, macs)
+
+	for _, ch := range changes {
+		tflog.Debug(ctx, "Updating client group membership", map[string]any{
+			"mac":       ch.MAC,
+			"group_ids": ch.GroupIDs,
+		})
+		if err := r.client.SetClientDeviceGroups(ctx, site, ch.ID, ch.GroupIDs); err != nil {
+			return fmt.Errorf("updating client %s: %w", ch.MAC, err)
+		}
+	}
+
+	for _, mac := range missing {
+		tflog.Debug(ctx, "Creating client record for group member", map[string]any{"mac": mac})
+		_, err := r.client.CreateClientDevice(ctx, site, &unifi.Client{
+			MAC:                    mac,
+			NetworkMembersGroupIDs: []string{groupID},
+		})
+		if err != nil {
+			return fmt.Errorf("creating client %s: %w", mac, err)
+		}
+	}
+	return nil
+}
+
+// clientGroupChange is a client record whose group IDs must be rewritten.
+type clientGroupChange struct {
+	ID       string
+	MAC      string
+	GroupIDs []string
+}
+
+// planClientGroupMembership computes the updates needed for macs to be the
+// exact membership of groupID. It returns the client records to rewrite and
+// the MACs that have no client record yet. MACs are compared case-insensitively
+// and other group assignments are preserved.
+func planClientGroupMembership(clients []unifi.Client, groupID string, macs []string) ([]clientGroupChange, []string) {
+	want := map[string]bool{}
+	for _, mac := range macs {
+		want[strings.ToLower(mac)] = true
+	}
+
+	var changes []clientGroupChange
+	seen := map[string]bool{}
+	for _, c := range clients {
+		mac := strings.ToLower(c.MAC)
+		seen[mac] = true
+		has := slices.Contains(c.NetworkMembersGroupIDs, groupID)
+		switch {
+		case want[mac] && !has:
+			ids := append(slices.Clone(c.NetworkMembersGroupIDs), groupID)
+			changes = append(changes, clientGroupChange{ID: c.ID, MAC: mac, GroupIDs: ids})
+		case !want[mac] && has:
+			ids := slices.DeleteFunc(slices.Clone(c.NetworkMembersGroupIDs), func(id string) bool { return id == groupID })
+			changes = append(changes, clientGroupChange{ID: c.ID, MAC: mac, GroupIDs: ids})
+		}
+	}
+
+	var missing []string
+	for mac := range want {
+		if !seen[mac] {
+			missing = append(missing, mac)
+		}
+	}
+	sort.Strings(missing)
+	return changes, missing
+}
+
+// clientGroupMembers returns the sorted, lower-cased MACs of the clients in
+// groupID.
+func clientGroupMembers(clients []unifi.Client, groupID string) []string {
+	var macs []string
+	for _, c := range clients {
+		if slices.Contains(c.NetworkMembersGroupIDs, groupID) {
+			macs = append(macs, strings.ToLower(c.MAC))
+		}
+	}
+	sort.Strings(macs)
+	return macs
+}
+
+func setToMACs(s types.Set) []string {
+	if s.IsNull() || s.IsUnknown() {
+		return nil
+	}
+	var macs []string
+	for _, v := range s.Elements() {
+		if sv, ok := v.(types.String); ok {
+			macs = append(macs, sv.ValueString())
+		}
+	}
+	return macs
+}
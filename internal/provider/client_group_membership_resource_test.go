@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestPlanClientGroupMembership(t *testing.T) {
+	clients := []unifi.Client{
+		{ID: "c1", MAC: "aa:aa:aa:aa:aa:01", NetworkMembersGroupIDs: []string{"grp", "other"}},
+		{ID: "c2", MAC: "aa:aa:aa:aa:aa:02", NetworkMembersGroupIDs: []string{"other"}},
+		{ID: "c3", MAC: "aa:aa:aa:aa:aa:03", NetworkMembersGroupIDs: []string{"grp"}},
+		{ID: "c4", MAC: "aa:aa:aa:aa:aa:04"},
+	}
+
+	t.Run("adds, removes, and creates", func(t *testing.T) {
+		changes, missing := planClientGroupMembership(clients, "grp", []string{
+			"aa:aa:aa:aa:aa:01", // already a member
+			"AA:AA:AA:AA:AA:02", // add, keeping "other"
+			"aa:aa:aa:aa:aa:04", // add
+			"aa:aa:aa:aa:aa:99", // unknown to the controller
+		})
+
+		assert.Equal(t, []clientGroupChange{
+			{ID: "c2", MAC: "aa:aa:aa:aa:aa:02", GroupIDs: []string{"other", "grp"}},
+			{ID: "c3", MAC: "aa:aa:aa:aa:aa:03", GroupIDs: []string{}},
+			{ID: "c4", MAC: "aa:aa:aa:aa:aa:04", GroupIDs: []string{"grp"}},
+		}, changes)
+		assert.Equal(t, []string{"aa:aa:aa:aa:aa:99"}, missing)
+	})
+
+	t.Run("empty membership removes everyone", func(t *testing.T) {
+		changes, missing := planClientGroupMembership(clients, "grp", nil)
+
+		assert.Equal(t, []clientGroupChange{
+			{ID: "c1", MAC: "aa:aa:aa:aa:aa:01", GroupIDs: []string{"other"}},
+			{ID: "c3", MAC: "aa:aa:aa:aa:aa:03", GroupIDs: []string{}},
+		}, changes)
+		assert.Empty(t, missing)
+	})
+
+	t.Run("no-op", func(t *testing.T) {
+		changes, missing := planClientGroupMembership(clients, "grp", []string{"aa:aa:aa:aa:aa:01", "aa:aa:aa:aa:aa:03"})
+		assert.Empty(t, changes)
+		assert.Empty(t, missing)
+	})
+}
+
+func TestClientGroupMembers(t *testing.T) {
+	clients := []unifi.Client{
+		{MAC: "AA:AA:AA:AA:AA:02", NetworkMembersGroupIDs: []string{"grp"}},
+		{MAC: "aa:aa:aa:aa:aa:01", NetworkMembersGroupIDs: []string{"other", "grp"}},
+		{MAC: "aa:aa:aa:aa:aa:03", NetworkMembersGroupIDs: []string{"other"}},
+	}
+	assert.Equal(t, []string{"aa:aa:aa:aa:aa:01", "aa:aa:aa:aa:aa:02"}, clientGroupMembers(clients, "grp"))
+}
+
+func TestSetClientDeviceGroups(t *testing.T) {
+	var putPath string
+	var putBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		putPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&putBody))
+		w.Write([]byte(`{"meta": {"rc": "ok"}, "data": []}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	err := client.SetClientDeviceGroups(context.Background(), "default", "c1", nil)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasSuffix(putPath, "/api/s/default/rest/user/c1"))
+	// Only the ID and group list are sent; other client fields are untouched.
+	assert.Equal(t, map[string]any{"_id": "c1", "network_members_group_ids": []any{}}, putBody)
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccClientGroupMembership_basic(t *testing.T) {
+	requireHardware(t)
+	groupName := fmt.Sprintf("tfacc-cligrp-%s", randomSuffix())
+	mac1 := randomMAC()
+	mac2 := randomMAC()
+
+	config := func(macs ...string) string {
+		quoted := make([]string, len(macs))
+		for i, m := range macs {
+			quoted[i] = fmt.Sprintf("%q", m)
+		}
+		return fmt.Sprintf(`
+resource "terrifi_client_group" "test" {
+  name = %q
+}
+
+resource "terrifi_client_device" "one" {
+  mac  = %q
+  name = "tfacc-member-one"
+}
+
+resource "terrifi_client_group_membership" "test" {
+  group_id   = terrifi_client_group.test.id
+  macs       = [%s]
+  depends_on = [terrifi_client_device.one]
+}
+`, groupName, mac1, strings.Join(quoted, ", "))
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// mac2 has no client record yet and is created.
+				Config: config(mac1, mac2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"terrifi_client_group_membership.test", "id",
+						"terrifi_client_group.test", "id",
+					),
+					resource.TestCheckResourceAttr("terrifi_client_group_membership.test", "macs.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_client_group_membership.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: config(mac2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_group_membership.test", "macs.#", "1"),
+					resource.TestCheckTypeSetElemAttr("terrifi_client_group_membership.test", "macs.*", mac2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClientGroupMembership_validationInvalidMAC(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_client_group_membership" "test" {
+  group_id = "000000000000000000000000"
+  macs     = ["not-a-mac"]
+}
+`,
+				ExpectError: regexp.MustCompile(`valid MAC address`),
+			},
+		},
+	})
+}
@@ -33,7 +33,7 @@ func NewClientGroupResource() resource.Resource {
 }
 
 type clientGroupResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type clientGroupResourceModel struct {
@@ -176,9 +176,22 @@ func (r *clientGroupResource) Update(
 		return
 	}
 
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetNetworkMembersGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Client Group for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "client group", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
 	r.applyPlanToState(&plan, &state)
 
-	site := r.client.SiteOrDefault(state.Site)
 	group := r.modelToAPI(&state)
 	group.ID = state.ID.ValueString()
 
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -112,6 +114,41 @@ func TestClientGroupApplyPlanToState(t *testing.T) {
 	})
 }
 
+func TestClientGroupDelete(t *testing.T) {
+	state := clientGroupResourceModel{
+		ID:   types.StringValue("group123"),
+		Site: types.StringValue("default"),
+		Name: types.StringValue("IoT Devices"),
+	}
+	newFake := func() *fakeClient {
+		fake := newFakeClient()
+		fake.clientGroups["group123"] = unifi.NetworkMembersGroup{ID: "group123", Name: "IoT Devices", Type: "CLIENTS"}
+		return fake
+	}
+
+	t.Run("retries while the group is still referenced", func(t *testing.T) {
+		fake := newFake()
+		fake.failNext("DeleteNetworkMembersGroup", errors.New("api.err.ObjectReferredBy"))
+		r := &clientGroupResource{client: fake}
+
+		diags := testDelete(t, r, state)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Equal(t, 2, fake.calls["DeleteNetworkMembersGroup"])
+		assert.Empty(t, fake.clientGroups)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		fake := newFake()
+		fake.failNext("DeleteNetworkMembersGroup", errors.New("api.err.ServerError"))
+		r := &clientGroupResource{client: fake}
+
+		diags := testDelete(t, r, state)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error Deleting Client Group", diags.Errors()[0].Summary())
+		assert.Equal(t, 1, fake.calls["DeleteNetworkMembersGroup"])
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests
 // ---------------------------------------------------------------------------
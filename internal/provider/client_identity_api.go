@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// clientFingerprintOverride is the body of the v2 fingerprint_override
+// endpoint. Besides dev_id_override (the device type, which sets the icon),
+// it accepts the category, family and OS that traffic identification uses.
+// Fields left nil are not overridden.
+type clientFingerprintOverride struct {
+	MAC           string `json:"mac"`
+	DevIDOverride *int64 `json:"dev_id_override,omitempty"`
+	DevCat        *int64 `json:"dev_cat,omitempty"`
+	DevFamily     *int64 `json:"dev_family,omitempty"`
+	OSName        *int64 `json:"os_name,omitempty"`
+	SearchQuery   string `json:"search_query"`
+}
+
+// GetClientFingerprint reads the fingerprint of a client device from the v2
+// client info API: the identified category, family and OS, and any device
+// type override. Returns a *unifi.NotFoundError if the controller doesn't
+// know the client.
+func (c *Client) GetClientFingerprint(ctx context.Context, site, mac string) (*unifi.ClientInfoFingerprint, error) {
+	var respBody struct {
+		Fingerprint unifi.ClientInfoFingerprint `json:"fingerprint"`
+	}
+	err := c.doV2Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/v2/api/site/%s/clients/local/%s?includeUnifiDevices=true", c.BaseURL, c.APIPath, site, mac),
+		nil, &respBody)
+	if err != nil {
+		if strings.Contains(err.Error(), "(404)") {
+			return nil, &unifi.NotFoundError{}
+		}
+		return nil, err
+	}
+	return &respBody.Fingerprint, nil
+}
+
+// SetClientFingerprintOverride replaces the fingerprint override of a client
+// device. To clear every override, use SetFingerprintOverride with 0.
+func (c *Client) SetClientFingerprintOverride(ctx context.Context, site string, d *clientFingerprintOverride) error {
+	return c.doV2Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/v2/api/site/%s/station/%s/fingerprint_override", c.BaseURL, c.APIPath, site, d.MAC),
+		d, nil)
+}
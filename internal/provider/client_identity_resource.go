@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &clientIdentityResource{}
+	_ resource.ResourceWithImportState = &clientIdentityResource{}
+)
+
+func NewClientIdentityResource() resource.Resource {
+	return &clientIdentityResource{}
+}
+
+type clientIdentityResource struct {
+	client ClientAPI
+}
+
+type clientIdentityResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Site       types.String `tfsdk:"site"`
+	MAC        types.String `tfsdk:"mac"`
+	CategoryID types.Int64  `tfsdk:"category_id"`
+	FamilyID   types.Int64  `tfsdk:"family_id"`
+	OSID       types.Int64  `tfsdk:"os_id"`
+}
+
+func (r *clientIdentityResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_client_identity"
+}
+
+func (r *clientIdentityResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	overrideValidators := func(self string) []validator.Int64 {
+		var others []path.Expression
+		for _, name := range []string{"category_id", "family_id", "os_id"} {
+			if name != self {
+				others = append(others, path.MatchRoot(name))
+			}
+		}
+		return []validator.Int64{
+			int64validator.AtLeast(1),
+			int64validator.AtLeastOneOf(others...),
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Overrides how the controller identifies a client device: its category, family and " +
+			"operating system. Traffic identification, and the features built on it such as traffic rules " +
+			"that target device categories, use these values instead of the fingerprinted ones, so they stay " +
+			"the same after a controller restore or a fingerprint database update. The IDs are those of the " +
+			"controller's fingerprint database. To change only the icon, use `device_type_id` on " +
+			"`terrifi_client_device`; an icon set there is kept.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the client device.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site the client device belongs to. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the client device (e.g. `aa:bb:cc:dd:ee:ff`). The controller " +
+					"must already know the client.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						macRegexp,
+						"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+					),
+				},
+			},
+
+			"category_id": schema.Int64Attribute{
+				MarkdownDescription: "The device category (`dev_cat`), e.g. smartphone or smart TV. When omitted, " +
+					"the fingerprinted category is used.",
+				Optional:   true,
+				Validators: overrideValidators("category_id"),
+			},
+
+			"family_id": schema.Int64Attribute{
+				MarkdownDescription: "The device family (`dev_family`). When omitted, the fingerprinted family is used.",
+				Optional:            true,
+				Validators:          overrideValidators("family_id"),
+			},
+
+			"os_id": schema.Int64Attribute{
+				MarkdownDescription: "The operating system (`os_name`). When omitted, the fingerprinted operating " +
+					"system is used.",
+				Optional:   true,
+				Validators: overrideValidators("os_id"),
+			},
+		},
+	}
+}
+
+func (r *clientIdentityResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *clientIdentityResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan clientIdentityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	mac := strings.ToLower(plan.MAC.ValueString())
+
+	if !r.setOverride(ctx, site, mac, &plan, &resp.Diagnostics) {
+		return
+	}
+
+	plan.ID = types.StringValue(mac)
+	plan.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *clientIdentityResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state clientIdentityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	fp, err := r.client.GetClientFingerprint(ctx, site, state.ID.ValueString())
+	if err != nil {
+		// The override goes away with the client record.
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Client Identity",
+			fmt.Sprintf("Could not read the fingerprint of client %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Site = types.StringValue(site)
+	if state.MAC.IsNull() {
+		state.MAC = state.ID
+	}
+	r.apiToModel(fp, &state)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *clientIdentityResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan clientIdentityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if !r.setOverride(ctx, site, state.ID.ValueString(), &plan, &resp.Diagnostics) {
+		return
+	}
+
+	state.CategoryID = plan.CategoryID
+	state.FamilyID = plan.FamilyID
+	state.OSID = plan.OSID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *clientIdentityResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state clientIdentityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+	mac := state.ID.ValueString()
+
+	fp, err := r.client.GetClientFingerprint(ctx, site, mac)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Clearing Client Identity", err.Error())
+		return
+	}
+
+	// Clearing the override also clears the device type, so keep one set by
+	// terrifi_client_device.
+	if deviceTypeID := fingerprintDeviceTypeOverride(fp); deviceTypeID != nil {
+		err = r.client.SetClientFingerprintOverride(ctx, site, &clientFingerprintOverride{
+			MAC:           mac,
+			DevIDOverride: deviceTypeID,
+		})
+	} else {
+		err = r.client.SetFingerprintOverride(ctx, site, mac, 0)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error Clearing Client Identity", err.Error())
+	}
+}
+
+// ImportState imports by the client's MAC address, as "mac" or "site:mac".
+func (r *clientIdentityResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// MACs contain colons, so a site prefix is recognised by the part count.
+	id := req.ID
+	if parts := strings.Split(req.ID, ":"); len(parts) == 7 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		id = strings.Join(parts[1:], ":")
+	}
+	id = strings.ToLower(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac"), id)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// setOverride replaces the client's fingerprint override with the one in m,
+// keeping its device type override. It reports whether it succeeded.
+func (r *clientIdentityResource) setOverride(
+	ctx context.Context,
+	site, mac string,
+	m *clientIdentityResourceModel,
+	diags *diag.Diagnostics,
+) bool {
+	fp, err := r.client.GetClientFingerprint(ctx, site, mac)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			diags.AddError(
+				"Client Not Found",
+				fmt.Sprintf("The controller does not know a client with MAC %s in site %q. Overrides can only "+
+					"be set once the client has connected, or after creating it with terrifi_client_device.", mac, site),
+			)
+			return false
+		}
+		diags.AddError("Error Reading Client Fingerprint", err.Error())
+		return false
+	}
+
+	override := r.modelToAPI(m)
+	override.MAC = mac
+	override.DevIDOverride = fingerprintDeviceTypeOverride(fp)
+	if err := r.client.SetClientFingerprintOverride(ctx, site, override); err != nil {
+		diags.AddError("Error Setting Client Identity", err.Error())
+		return false
+	}
+	return true
+}
+
+func (r *clientIdentityResource) modelToAPI(m *clientIdentityResourceModel) *clientFingerprintOverride {
+	return &clientFingerprintOverride{
+		DevCat:    m.CategoryID.ValueInt64Pointer(),
+		DevFamily: m.FamilyID.ValueInt64Pointer(),
+		OSName:    m.OSID.ValueInt64Pointer(),
+	}
+}
+
+// apiToModel reads the identity of the client into the attributes m
+// overrides. The controller reports identified and overridden values alike,
+// so attributes left unset are not read, except right after an import, when
+// none are set yet.
+func (r *clientIdentityResource) apiToModel(fp *unifi.ClientInfoFingerprint, m *clientIdentityResourceModel) {
+	imported := m.CategoryID.IsNull() && m.FamilyID.IsNull() && m.OSID.IsNull()
+	read := func(current types.Int64, v *int64) types.Int64 {
+		if current.IsNull() && !imported {
+			return current
+		}
+		return types.Int64PointerValue(v)
+	}
+	m.CategoryID = read(m.CategoryID, fp.DevCat)
+	m.FamilyID = read(m.FamilyID, fp.DevFamily)
+	m.OSID = read(m.OSID, fp.OsName)
+}
+
+// fingerprintDeviceTypeOverride returns the device type override of a
+// fingerprint, or nil if it has none.
+func fingerprintDeviceTypeOverride(fp *unifi.ClientInfoFingerprint) *int64 {
+	if fp.DevIdOverride == nil || *fp.DevIdOverride == 0 {
+		return nil
+	}
+	return fp.DevIdOverride
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestClientIdentityCRUD(t *testing.T) {
+	const mac = "aa:bb:cc:dd:ee:01"
+	identified, icon := int64(9), int64(4321)
+	plan := clientIdentityResourceModel{
+		ID:         types.StringUnknown(),
+		Site:       types.StringNull(),
+		MAC:        types.StringValue("AA:BB:CC:DD:EE:01"),
+		CategoryID: types.Int64Value(44),
+		FamilyID:   types.Int64Null(),
+		OSID:       types.Int64Value(56),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.fingerprints[mac] = unifi.ClientInfoFingerprint{DevCat: &identified, DevFamily: &identified, OsName: &identified}
+		r := &clientIdentityResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		assert.Equal(t, mac, created.ID.ValueString())
+		assert.Equal(t, "AA:BB:CC:DD:EE:01", created.MAC.ValueString(), "configured spelling is kept")
+		fp := fake.fingerprints[mac]
+		assert.Equal(t, int64(44), *fp.DevCat)
+		assert.Nil(t, fp.DevFamily, "unset attributes are not overridden")
+		assert.Equal(t, int64(56), *fp.OsName)
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, created, read)
+
+		update := *created
+		update.OSID = types.Int64Null()
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.True(t, updated.OSID.IsNull())
+		assert.Nil(t, fake.fingerprints[mac].OsName)
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.False(t, fake.fingerprints[mac].HasOverride)
+		assert.Equal(t, 1, fake.calls["SetFingerprintOverride"])
+	})
+
+	t.Run("device type override is kept", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.fingerprints[mac] = unifi.ClientInfoFingerprint{DevIdOverride: &icon, HasOverride: true}
+		r := &clientIdentityResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		assert.Equal(t, icon, *fake.fingerprints[mac].DevIdOverride)
+
+		diags = testDelete(t, r, *created)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		fp := fake.fingerprints[mac]
+		assert.Equal(t, icon, *fp.DevIdOverride)
+		assert.Nil(t, fp.DevCat)
+		assert.Zero(t, fake.calls["SetFingerprintOverride"], "clearing would drop the icon")
+	})
+
+	t.Run("unknown client", func(t *testing.T) {
+		r := &clientIdentityResource{client: newFakeClient()}
+
+		_, diags := testCreate(t, r, plan)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Client Not Found", diags.Errors()[0].Summary())
+	})
+
+	t.Run("import reads every attribute", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.fingerprints[mac] = unifi.ClientInfoFingerprint{DevCat: &identified, OsName: &identified}
+		r := &clientIdentityResource{client: fake}
+
+		imported := clientIdentityResourceModel{
+			ID:         types.StringValue(mac),
+			Site:       types.StringNull(),
+			MAC:        types.StringValue(mac),
+			CategoryID: types.Int64Null(),
+			FamilyID:   types.Int64Null(),
+			OSID:       types.Int64Null(),
+		}
+		read, diags := testRead(t, r, imported)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, identified, read.CategoryID.ValueInt64())
+		assert.True(t, read.FamilyID.IsNull())
+		assert.Equal(t, identified, read.OSID.ValueInt64())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccClientIdentity_basic(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-identity"
+}
+
+resource "terrifi_client_identity" "test" {
+  mac         = terrifi_client_device.test.mac
+  category_id = 44
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_client_identity.test", "id", mac),
+					resource.TestCheckResourceAttr("terrifi_client_identity.test", "category_id", "44"),
+					resource.TestCheckNoResourceAttr("terrifi_client_identity.test", "os_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccClientIdentity_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_client_identity" "test" {
+  mac = "aa:bb:cc:dd:ee:ff"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+		},
+	})
+}
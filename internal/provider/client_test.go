@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// sessionServer serves the firewall zone list to requests carrying the CSRF
+// token of the latest login, and 401 to every other request. Each login
+// issues a new token.
+type sessionServer struct {
+	*httptest.Server
+	logins   atomic.Int64
+	failAuth atomic.Bool
+	// hold, when set, delays 401 responses so that concurrent requests
+	// overlap.
+	hold time.Duration
+}
+
+func newSessionServer(t *testing.T) *sessionServer {
+	t.Helper()
+	s := &sessionServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth/login" {
+			if s.failAuth.Load() {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("X-Csrf-Token", fmt.Sprintf("token-%d", s.logins.Add(1)))
+			return
+		}
+		if r.Header.Get("X-Csrf-Token") != fmt.Sprintf("token-%d", s.logins.Load()) {
+			time.Sleep(s.hold)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]unifi.FirewallZone{{ID: "zone-1", Name: "LAN"}})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// newSessionClient returns a client logged in with an expired session.
+func newSessionClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+	c := newTestClient(t, serverURL, false)
+	c.username = "admin"
+	c.password = "secret"
+	c.loginPath = "/api/auth/login"
+	c.csrf = "expired"
+	return c
+}
+
+func TestClientRelogin(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("logs in again and retries once", func(t *testing.T) {
+		srv := newSessionServer(t)
+		c := newSessionClient(t, srv.URL)
+
+		zones, err := c.ListFirewallZone(ctx, "default")
+		require.NoError(t, err)
+		require.Len(t, zones, 1)
+		assert.Equal(t, int64(1), srv.logins.Load())
+
+		// The refreshed session is reused.
+		_, err = c.ListFirewallZone(ctx, "default")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), srv.logins.Load())
+	})
+
+	t.Run("concurrent failures log in once", func(t *testing.T) {
+		srv := newSessionServer(t)
+		srv.hold = 50 * time.Millisecond
+		c := newSessionClient(t, srv.URL)
+
+		var wg sync.WaitGroup
+		for i := range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Distinct sites, so the GETs aren't merged into one.
+				_, err := c.ListFirewallZone(ctx, fmt.Sprintf("site%d", i))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int64(1), srv.logins.Load())
+	})
+
+	t.Run("failed login is reported", func(t *testing.T) {
+		srv := newSessionServer(t)
+		srv.failAuth.Store(true)
+		c := newSessionClient(t, srv.URL)
+
+		_, err := c.ListFirewallZone(ctx, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "logging in again failed")
+	})
+
+	t.Run("API key is not retried", func(t *testing.T) {
+		srv := newSessionServer(t)
+		c := newSessionClient(t, srv.URL)
+		c.APIKey = "rejected"
+
+		_, err := c.ListFirewallZone(ctx, "default")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "(401)")
+		assert.Equal(t, int64(0), srv.logins.Load())
+	})
+}
+
+// sdkSessionServer is a UniFi OS controller for the SDK client: it accepts
+// logins and serves one port forward to requests carrying the CSRF token of
+// a live session. expire ends every session, as the controller does when a
+// session times out mid-run.
+type sdkSessionServer struct {
+	*httptest.Server
+	logins   atomic.Int64
+	expired  atomic.Int64 // sessions up to and including this login are rejected
+	rejected atomic.Int64
+}
+
+func newSDKSessionServer(t *testing.T) *sdkSessionServer {
+	t.Helper()
+	s := &sdkSessionServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/":
+			return
+		case "/api/auth/login":
+			w.Header().Set("X-Csrf-Token", fmt.Sprintf("token-%d", s.logins.Add(1)))
+			return
+		}
+		var session int64
+		fmt.Sscanf(r.Header.Get("X-Csrf-Token"), "token-%d", &session)
+		if session <= s.expired.Load() {
+			s.rejected.Add(1)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/proxy/network/status":
+			w.Write([]byte(`{"meta":{"rc":"ok","server_version":"9.0.0"}}`))
+		case "/proxy/network/api/s/default/rest/portforward/pf-1":
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"pf-1","name":"Web","enabled":true,"proto":"tcp","dst_port":"443","fwd":"192.168.1.10","fwd_port":"8443"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *sdkSessionServer) expire() {
+	s.expired.Store(s.logins.Load())
+}
+
+func TestClientReloginSDK(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resource read logs in again and retries once", func(t *testing.T) {
+		srv := newSDKSessionServer(t)
+		c, err := NewClient(ctx, ClientConfig{APIURL: srv.URL, Username: "admin", Password: "secret", Site: "default"})
+		require.NoError(t, err)
+		srv.expire()
+		logins := srv.logins.Load()
+
+		r := &portForwardResource{client: c}
+		read, diags := testRead(t, r, portForwardResourceModel{
+			ID:   types.StringValue("pf-1"),
+			Site: types.StringNull(),
+		})
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, "Web", read.Name.ValueString())
+		assert.Equal(t, int64(1), srv.rejected.Load())
+		assert.Equal(t, logins+1, srv.logins.Load())
+
+		// The new SDK session is reused.
+		_, err = c.GetPortForward(ctx, "default", "pf-1")
+		require.NoError(t, err)
+		assert.Equal(t, logins+1, srv.logins.Load())
+	})
+
+	t.Run("API key is not retried", func(t *testing.T) {
+		srv := newSDKSessionServer(t)
+		c, err := NewClient(ctx, ClientConfig{APIURL: srv.URL, APIKey: "rejected", Site: "default"})
+		require.NoError(t, err)
+
+		_, err = c.GetPortForward(ctx, "default", "pf-1")
+		var loginErr *unifi.LoginRequiredError
+		require.ErrorAs(t, err, &loginErr)
+		assert.Equal(t, int64(0), srv.logins.Load())
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// The UniFi controller has no object revisions or ETags, and its PUT
+// endpoints replace the whole object, so an edit made in the UI between
+// `terraform plan` and `terraform apply` would be silently overwritten.
+// Instead, Update re-reads the object and converts it with the resource's own
+// apiToModel. If any attribute the resource manages no longer matches the
+// state the plan was computed from, the update is refused with a conflict
+// diagnostic. Fields the provider does not manage are ignored, so volatile
+// controller bookkeeping does not cause spurious conflicts.
+
+// changedAttributes compares two resource models (pointers to the same struct
+// type) and returns the tfsdk names of the attributes whose values differ.
+func changedAttributes(prior, current any) []string {
+	p := reflect.ValueOf(prior).Elem()
+	c := reflect.ValueOf(current).Elem()
+
+	var changed []string
+	for i := 0; i < p.NumField(); i++ {
+		name := p.Type().Field(i).Tag.Get("tfsdk")
+		if name == "" || name == "-" {
+			continue
+		}
+		pv, cv := p.Field(i).Interface(), c.Field(i).Interface()
+		if a, ok := pv.(attr.Value); ok {
+			if !a.Equal(cv.(attr.Value)) {
+				changed = append(changed, name)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(pv, cv) {
+			changed = append(changed, name)
+		}
+	}
+	return changed
+}
+
+// checkConcurrentChanges adds a conflict error and returns false if the
+// object re-read from the controller (current) differs from the prior state.
+// kind is a human-readable resource name such as "network".
+func checkConcurrentChanges(diags *diag.Diagnostics, kind, id string, prior, current any) bool {
+	changed := changedAttributes(prior, current)
+	if len(changed) == 0 {
+		return true
+	}
+	diags.AddError(
+		"Resource Changed Outside Terraform",
+		fmt.Sprintf("The %s %s was modified on the controller (for example in the UniFi UI) after Terraform "+
+			"last read it. Changed attributes: %s.\n\nThe update was not applied, to avoid overwriting those "+
+			"changes. Run plan again to review them against your configuration, then apply.",
+			kind, id, strings.Join(changed, ", ")),
+	)
+	return false
+}
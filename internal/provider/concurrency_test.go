@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestChangedAttributes(t *testing.T) {
+	r := &dnsRecordResource{}
+	prior := dnsRecordResourceModel{}
+	r.apiToModel(&unifi.DNSRecord{
+		ID:         "rec-1",
+		Key:        "nas.home",
+		Value:      "192.168.1.10",
+		RecordType: "A",
+		Enabled:    true,
+	}, &prior, "default")
+
+	t.Run("unchanged", func(t *testing.T) {
+		current := prior
+		r.apiToModel(&unifi.DNSRecord{
+			ID:         "rec-1",
+			Key:        "nas.home",
+			Value:      "192.168.1.10",
+			RecordType: "A",
+			Enabled:    true,
+		}, &current, "default")
+
+		assert.Empty(t, changedAttributes(&prior, &current))
+
+		var diags diag.Diagnostics
+		assert.True(t, checkConcurrentChanges(&diags, "DNS record", "rec-1", &prior, &current))
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("edited on the controller", func(t *testing.T) {
+		current := prior
+		r.apiToModel(&unifi.DNSRecord{
+			ID:         "rec-1",
+			Key:        "nas.home",
+			Value:      "192.168.1.20",
+			RecordType: "A",
+			Enabled:    false,
+		}, &current, "default")
+
+		assert.ElementsMatch(t, []string{"value", "enabled"}, changedAttributes(&prior, &current))
+
+		var diags diag.Diagnostics
+		assert.False(t, checkConcurrentChanges(&diags, "DNS record", "rec-1", &prior, &current))
+		assert.True(t, diags.HasError())
+		assert.Equal(t, "Resource Changed Outside Terraform", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "Changed attributes: ")
+	})
+
+	t.Run("null versus value", func(t *testing.T) {
+		a := clientGroupResourceModel{Name: types.StringNull()}
+		b := clientGroupResourceModel{Name: types.StringValue("IoT")}
+		assert.Equal(t, []string{"name"}, changedAttributes(&a, &b))
+	})
+}
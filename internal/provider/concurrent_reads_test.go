@@ -0,0 +1,153 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// blockingZoneServer serves a fixed firewall zone list, holding every GET
+// until the returned release func is called so that concurrent callers
+// overlap.
+func blockingZoneServer(t *testing.T, gets, writes *atomic.Int64) (*httptest.Server, func()) {
+	t.Helper()
+	ch := make(chan struct{})
+	var once sync.Once
+	release := func() { once.Do(func() { close(ch) }) }
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writes.Add(1)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		gets.Add(1)
+		<-ch
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]unifi.FirewallZone{{ID: "zone-1", Name: "LAN"}})
+	}))
+	t.Cleanup(srv.Close)
+	// Registered last so it runs first: a failed test must not leave
+	// handlers blocked while the server shuts down.
+	t.Cleanup(release)
+	return srv, release
+}
+
+func TestConcurrentReads_MergedWithoutCaching(t *testing.T) {
+	var gets, writes atomic.Int64
+	srv, release := blockingZoneServer(t, &gets, &writes)
+
+	client := newTestClient(t, srv.URL, false)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	results := make([][]unifi.FirewallZone, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zones, err := client.ListFirewallZone(ctx, "default")
+			assert.NoError(t, err)
+			results[i] = zones
+		}()
+	}
+
+	// Give every goroutine time to join the request in flight.
+	time.Sleep(100 * time.Millisecond)
+	release()
+	wg.Wait()
+
+	assert.Equal(t, int64(1), gets.Load(), "concurrent identical GETs should share one request")
+	for _, zones := range results {
+		require.Len(t, zones, 1)
+		assert.Equal(t, "LAN", zones[0].Name)
+	}
+
+	// Each caller decodes its own copy.
+	results[0][0].Name = "changed"
+	assert.Equal(t, "LAN", results[1][0].Name)
+
+	// Once the request has completed, the next GET goes to the controller.
+	_, err := client.ListFirewallZone(ctx, "default")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), gets.Load())
+}
+
+func TestConcurrentReads_CallerCancelDoesNotFailOthers(t *testing.T) {
+	var gets, writes atomic.Int64
+	srv, release := blockingZoneServer(t, &gets, &writes)
+
+	client := newTestClient(t, srv.URL, false)
+	first, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var firstErr error
+	var zones []unifi.FirewallZone
+	var err error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, firstErr = client.ListFirewallZone(first, "default")
+	}()
+	require.Eventually(t, func() bool { return gets.Load() == 1 }, time.Second, 10*time.Millisecond)
+	go func() {
+		defer wg.Done()
+		zones, err = client.ListFirewallZone(context.Background(), "default")
+	}()
+
+	// Give the second caller time to join the request in flight, then cancel
+	// the caller that started it.
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	release()
+	wg.Wait()
+
+	assert.ErrorIs(t, firstErr, context.Canceled)
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+	assert.Equal(t, "LAN", zones[0].Name)
+	assert.Equal(t, int64(1), gets.Load(), "the second caller should share the first request")
+}
+
+func TestConcurrentReads_NotMergedAcrossWrite(t *testing.T) {
+	var gets, writes atomic.Int64
+	srv, release := blockingZoneServer(t, &gets, &writes)
+
+	client := newTestClient(t, srv.URL, false)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := client.ListFirewallZone(ctx, "default")
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool { return gets.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	// A write completes while the first GET is still in flight; a GET issued
+	// afterwards must not be served the older response.
+	require.NoError(t, client.DeleteFirewallZone(ctx, "default", "zone-2"))
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := client.ListFirewallZone(ctx, "default")
+		assert.NoError(t, err)
+	}()
+	require.Eventually(t, func() bool { return gets.Load() == 2 }, time.Second, 10*time.Millisecond)
+
+	release()
+	wg.Wait()
+	assert.Equal(t, int64(1), writes.Load())
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// conditionalCache remembers the validators (ETag and Last-Modified) of GET
+// responses along with their bodies, so that the next GET of the same URL can
+// ask the controller whether anything changed. If it answers 304 Not
+// Modified, the remembered body is used and the list isn't downloaded again,
+// which matters on slow links such as a management VPN. Unlike responseCache,
+// every read still reaches the controller, so results are never stale.
+//
+// Only responses that carry a validator are remembered; endpoints that don't
+// send one behave as before. When Client.conditional is nil, conditional
+// requests are disabled.
+type conditionalCache struct {
+	mu      sync.Mutex
+	entries map[string]conditionalEntry
+}
+
+// conditionalEntry is a remembered GET response.
+type conditionalEntry struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+func newConditionalCache() *conditionalCache {
+	return &conditionalCache{entries: make(map[string]conditionalEntry)}
+}
+
+// get returns the remembered response for url.
+func (cc *conditionalCache) get(url string) (conditionalEntry, bool) {
+	if cc == nil {
+		return conditionalEntry{}, false
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	e, ok := cc.entries[url]
+	return e, ok
+}
+
+// update remembers a successful GET response for url, or forgets url if the
+// response has no validator.
+func (cc *conditionalCache) update(url string, header http.Header, body []byte) {
+	if cc == nil {
+		return
+	}
+	e := conditionalEntry{
+		etag:         header.Get("ETag"),
+		lastModified: header.Get("Last-Modified"),
+		body:         body,
+	}
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	if e.etag == "" && e.lastModified == "" {
+		delete(cc.entries, url)
+		return
+	}
+	cc.entries[url] = e
+}
+
+// setHeaders makes req conditional on the remembered response. ETag takes
+// precedence, as in RFC 9110; servers ignore If-Modified-Since when
+// If-None-Match is present.
+func (e conditionalEntry) setHeaders(req *retryablehttp.Request) {
+	if e.etag != "" {
+		req.Header.Set("If-None-Match", e.etag)
+	} else if e.lastModified != "" {
+		req.Header.Set("If-Modified-Since", e.lastModified)
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type conditionalTestItem struct {
+	ID string `json:"_id"`
+}
+
+func TestConditionalGet_ETag(t *testing.T) {
+	var full, notModified atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Write([]byte(`[{"_id":"a"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+	c.conditional = newConditionalCache()
+
+	for range 3 {
+		var items []conditionalTestItem
+		require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, &items))
+		assert.Equal(t, []conditionalTestItem{{ID: "a"}}, items)
+	}
+	assert.Equal(t, int64(1), full.Load())
+	assert.Equal(t, int64(2), notModified.Load())
+}
+
+func TestConditionalGet_LastModified(t *testing.T) {
+	const lastModified = "Wed, 21 Oct 2026 07:28:00 GMT"
+	var sawIfModifiedSince atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == lastModified {
+			sawIfModifiedSince.Store(true)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`[{"_id":"a"}]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+	c.conditional = newConditionalCache()
+
+	for range 2 {
+		var items []conditionalTestItem
+		require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, &items))
+		assert.Equal(t, []conditionalTestItem{{ID: "a"}}, items)
+	}
+	assert.True(t, sawIfModifiedSince.Load())
+}
+
+func TestConditionalGet_NoValidators(t *testing.T) {
+	var conditional atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			conditional.Add(1)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+	c.conditional = newConditionalCache()
+
+	for range 2 {
+		require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, nil))
+	}
+	assert.Zero(t, conditional.Load())
+	assert.Empty(t, c.conditional.entries, "responses without validators are not kept")
+}
+
+func TestConditionalGet_ChangedObjectIsRefetched(t *testing.T) {
+	var version atomic.Int64
+	version.Store(1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"v1"`
+		body := `[{"_id":"a"}]`
+		if version.Load() == 2 {
+			etag, body = `"v2"`, `[{"_id":"b"}]`
+		}
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+	c.conditional = newConditionalCache()
+
+	var items []conditionalTestItem
+	require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, &items))
+	assert.Equal(t, "a", items[0].ID)
+
+	version.Store(2)
+	items = nil
+	require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, &items))
+	assert.Equal(t, "b", items[0].ID)
+}
+
+func TestSendRequest_Gzip(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Write([]byte(`[{"_id":"uncompressed"}]`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`[{"_id":"compressed"}]`))
+		gz.Close()
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+
+	var items []conditionalTestItem
+	require.NoError(t, c.doV2Request(context.Background(), http.MethodGet, srv.URL+"/list", nil, &items))
+	assert.Equal(t, []conditionalTestItem{{ID: "compressed"}}, items)
+}
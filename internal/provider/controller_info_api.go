@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// controllerSysinfo is the subset of the stat/sysinfo response the provider
+// uses. The SDK parses the same endpoint but keeps the result private.
+type controllerSysinfo struct {
+	Version        string `json:"version"`
+	Name           string `json:"name"`
+	UBNTDeviceType string `json:"ubnt_device_type"`
+	UDMVersion     string `json:"udm_version"`
+}
+
+// GetControllerSysinfo returns the controller's system information for the
+// given site.
+func (c *Client) GetControllerSysinfo(ctx context.Context, site string) (*controllerSysinfo, error) {
+	var resp struct {
+		Data []controllerSysinfo `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/stat/sysinfo", c.BaseURL, c.APIPath, site),
+		nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return &controllerSysinfo{}, nil
+	}
+	return &resp.Data[0], nil
+}
+
+// HasZoneBasedFirewall reports whether the site uses the zone-based firewall
+// (UniFi Network 9.0+, after migration). Controllers without it either lack
+// the v2 zone endpoint (404) or return no zones. Other errors are returned so
+// that a transient failure isn't mistaken for a missing feature.
+func (c *Client) HasZoneBasedFirewall(ctx context.Context, site string) (bool, error) {
+	zones, err := c.ListFirewallZone(ctx, site)
+	if err != nil {
+		if strings.Contains(err.Error(), "(404)") {
+			return false, nil
+		}
+		return false, err
+	}
+	return len(zones) > 0, nil
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &controllerInfoDataSource{}
+
+func NewControllerInfoDataSource() datasource.DataSource {
+	return &controllerInfoDataSource{}
+}
+
+type controllerInfoDataSource struct {
+	client ClientAPI
+}
+
+type controllerInfoDataSourceModel struct {
+	Site              types.String          `tfsdk:"site"`
+	Version           types.String          `tfsdk:"version"`
+	Name              types.String          `tfsdk:"name"`
+	HardwareModel     types.String          `tfsdk:"hardware_model"`
+	ConsoleVersion    types.String          `tfsdk:"console_version"`
+	ZoneBasedFirewall types.Bool            `tfsdk:"zone_based_firewall"`
+	Sites             []controllerSiteModel `tfsdk:"sites"`
+}
+
+type controllerSiteModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+func (d *controllerInfoDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_controller_info"
+}
+
+func (d *controllerInfoDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the UniFi controller's version, hardware, available features, and sites. " +
+			"Useful for modules that must work across controller versions, e.g. to create zone-based firewall " +
+			"resources only where the zone-based firewall is available.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to check features on. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"version": schema.StringAttribute{
+				MarkdownDescription: "The UniFi Network application version (e.g. `9.0.114`).",
+				Computed:            true,
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The controller's name (e.g. `Dream Machine`).",
+				Computed:            true,
+			},
+
+			"hardware_model": schema.StringAttribute{
+				MarkdownDescription: "The console hardware model (e.g. `UDMPRO`, `UCGMAX`). Null for self-hosted controllers.",
+				Computed:            true,
+			},
+
+			"console_version": schema.StringAttribute{
+				MarkdownDescription: "The UniFi OS version of the console. Null for self-hosted controllers.",
+				Computed:            true,
+			},
+
+			"zone_based_firewall": schema.BoolAttribute{
+				MarkdownDescription: "Whether the site uses the zone-based firewall, which `terrifi_firewall_zone`, " +
+					"`terrifi_firewall_policy`, and `terrifi_firewall_policy_order` require.",
+				Computed: true,
+			},
+
+			"sites": schema.ListNestedAttribute{
+				MarkdownDescription: "The sites on the controller that the credentials can access.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the site.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The short name of the site, as used in the `site` attribute (e.g. `default`).",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The display name of the site.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *controllerInfoDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *controllerInfoDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config controllerInfoDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	info, err := d.client.GetControllerSysinfo(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Controller Info", err.Error())
+		return
+	}
+
+	zoneBased, err := d.client.HasZoneBasedFirewall(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Checking Zone-Based Firewall",
+			fmt.Sprintf("Could not list firewall zones in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	sites, err := d.client.ListSites(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Sites", err.Error())
+		return
+	}
+
+	config.Site = types.StringValue(site)
+	controllerInfoToModel(info, zoneBased, sites, &config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// controllerInfoToModel fills the computed attributes of m.
+func controllerInfoToModel(info *controllerSysinfo, zoneBased bool, sites []unifi.Site, m *controllerInfoDataSourceModel) {
+	m.Version = types.StringValue(info.Version)
+	m.Name = stringValueOrNull(info.Name)
+	m.HardwareModel = stringValueOrNull(info.UBNTDeviceType)
+	m.ConsoleVersion = stringValueOrNull(info.UDMVersion)
+	m.ZoneBasedFirewall = types.BoolValue(zoneBased)
+
+	m.Sites = make([]controllerSiteModel, len(sites))
+	for i, s := range sites {
+		m.Sites[i] = controllerSiteModel{
+			ID:          types.StringValue(s.ID),
+			Name:        types.StringValue(s.Name),
+			Description: types.StringValue(s.Description),
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests — no TF_ACC, no network, no env vars needed
+// ---------------------------------------------------------------------------
+
+func TestGetControllerSysinfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/stat/sysinfo", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"version":"9.0.114","name":"Dream Machine","ubnt_device_type":"UDMPRO","udm_version":"4.1.13"}]}`))
+	}))
+	defer srv.Close()
+
+	info, err := newTestClient(t, srv.URL, false).GetControllerSysinfo(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, &controllerSysinfo{
+		Version:        "9.0.114",
+		Name:           "Dream Machine",
+		UBNTDeviceType: "UDMPRO",
+		UDMVersion:     "4.1.13",
+	}, info)
+}
+
+func TestHasZoneBasedFirewall(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "zones", status: http.StatusOK, body: `[{"_id":"zone-1","name":"Internal"}]`, want: true},
+		{name: "no zones", status: http.StatusOK, body: `[]`, want: false},
+		{name: "no endpoint", status: http.StatusNotFound, body: `{}`, want: false},
+		{name: "server error", status: http.StatusInternalServerError, body: `{}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			got, err := newTestClient(t, srv.URL, false).HasZoneBasedFirewall(context.Background(), "default")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestControllerInfoToModel(t *testing.T) {
+	t.Run("unifi os console", func(t *testing.T) {
+		var m controllerInfoDataSourceModel
+		controllerInfoToModel(
+			&controllerSysinfo{Version: "9.0.114", Name: "Dream Machine", UBNTDeviceType: "UDMPRO", UDMVersion: "4.1.13"},
+			true,
+			[]unifi.Site{{ID: "site-1", Name: "default", Description: "Default"}},
+			&m,
+		)
+		assert.Equal(t, "9.0.114", m.Version.ValueString())
+		assert.Equal(t, "Dream Machine", m.Name.ValueString())
+		assert.Equal(t, "UDMPRO", m.HardwareModel.ValueString())
+		assert.Equal(t, "4.1.13", m.ConsoleVersion.ValueString())
+		assert.True(t, m.ZoneBasedFirewall.ValueBool())
+		require.Len(t, m.Sites, 1)
+		assert.Equal(t, "site-1", m.Sites[0].ID.ValueString())
+		assert.Equal(t, "default", m.Sites[0].Name.ValueString())
+		assert.Equal(t, "Default", m.Sites[0].Description.ValueString())
+	})
+
+	t.Run("self-hosted controller", func(t *testing.T) {
+		var m controllerInfoDataSourceModel
+		controllerInfoToModel(&controllerSysinfo{Version: "8.6.9"}, false, nil, &m)
+		assert.Equal(t, "8.6.9", m.Version.ValueString())
+		assert.True(t, m.HardwareModel.IsNull())
+		assert.True(t, m.ConsoleVersion.IsNull())
+		assert.False(t, m.ZoneBasedFirewall.ValueBool())
+		assert.Empty(t, m.Sites)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccControllerInfoDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "terrifi_controller_info" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.terrifi_controller_info.test", "version"),
+					resource.TestCheckResourceAttrSet("data.terrifi_controller_info.test", "zone_based_firewall"),
+					resource.TestCheckResourceAttr("data.terrifi_controller_info.test", "site", "default"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.terrifi_controller_info.test", "sites.*", map[string]string{
+						"name": "default",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -35,7 +35,9 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
@@ -56,6 +58,7 @@ type deviceUpdatePayload struct {
 	Volume                     *int64                      `json:"volume,omitempty"`
 	ConfigNetwork              *deviceConfigNetworkPayload `json:"config_network,omitempty"`
 	RadioTable                 []unifi.DeviceRadioTable    `json:"radio_table,omitempty"`
+	PortOverrides              []map[string]any            `json:"port_overrides,omitempty"`
 }
 
 type deviceConfigNetworkPayload struct {
@@ -183,6 +186,14 @@ func (c *Client) UpdateDevice(ctx context.Context, site string, id string, m *de
 		payload.RadioTable = radioTable
 	}
 
+	if m.PortMirrors != nil {
+		overrides, err := c.getDevicePortOverrides(ctx, site, id)
+		if err != nil {
+			return fmt.Errorf("reading device for port mirror merge: %w", err)
+		}
+		payload.PortOverrides = applyPortMirrors(overrides, m.PortMirrors)
+	}
+
 	// Use the v1 REST API endpoint for device updates.
 	var resp struct {
 		Meta struct {
@@ -205,6 +216,83 @@ func (c *Client) UpdateDevice(ctx context.Context, site string, id string, m *de
 	return nil
 }
 
+// getDevicePortOverrides returns the device's port_overrides as raw JSON
+// objects. We deliberately avoid unifi.DevicePortOverrides here: its bool
+// fields are all omitempty, so a decode/encode round trip would silently drop
+// explicit false values (e.g. autoneg) on ports we don't manage.
+func (c *Client) getDevicePortOverrides(ctx context.Context, site, id string) ([]map[string]any, error) {
+	var resp struct {
+		Meta struct {
+			RC  string `json:"rc"`
+			Msg string `json:"msg,omitempty"`
+		} `json:"meta"`
+		Data []struct {
+			ID            string           `json:"_id"`
+			PortOverrides []map[string]any `json:"port_overrides"`
+		} `json:"data"`
+	}
+
+	url := fmt.Sprintf("%s%s/api/s/%s/stat/device", c.BaseURL, c.APIPath, site)
+	if err := c.doV2Request(ctx, http.MethodGet, url, nil, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Meta.RC != "" && resp.Meta.RC != "ok" {
+		return nil, fmt.Errorf("controller returned rc=%s msg=%s", resp.Meta.RC, resp.Meta.Msg)
+	}
+
+	for _, d := range resp.Data {
+		if d.ID == id {
+			return d.PortOverrides, nil
+		}
+	}
+	return nil, &unifi.NotFoundError{}
+}
+
+// applyPortMirrors merges the planned mirror sessions into the device's
+// existing port overrides. Destination ports in the plan are switched to
+// op_mode "mirror"; any other port currently in mirror mode is returned to
+// "switch". All other override fields are left untouched.
+func applyPortMirrors(overrides []map[string]any, mirrors []devicePortMirrorModel) []map[string]any {
+	wanted := map[int64]int64{}
+	for _, m := range mirrors {
+		wanted[m.DestinationPortIdx.ValueInt64()] = m.SourcePortIdx.ValueInt64()
+	}
+
+	result := make([]map[string]any, 0, len(overrides)+len(wanted))
+	for _, po := range overrides {
+		idx, ok := po["port_idx"].(float64)
+		if !ok {
+			result = append(result, po)
+			continue
+		}
+		if src, ok := wanted[int64(idx)]; ok {
+			po["op_mode"] = "mirror"
+			po["mirror_port_idx"] = src
+			delete(wanted, int64(idx))
+		} else if po["op_mode"] == "mirror" {
+			po["op_mode"] = "switch"
+			delete(po, "mirror_port_idx")
+		}
+		result = append(result, po)
+	}
+
+	// Destinations without an existing override get a new entry. Sort for a
+	// deterministic payload.
+	var remaining []int64
+	for dst := range wanted {
+		remaining = append(remaining, dst)
+	}
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i] < remaining[j] })
+	for _, dst := range remaining {
+		result = append(result, map[string]any{
+			"port_idx":        dst,
+			"op_mode":         "mirror",
+			"mirror_port_idx": wanted[dst],
+		})
+	}
+	return result
+}
+
 // txPowerNumberRE matches a JSON `"tx_power": <number>` pair so we can wrap the
 // number in quotes before handing the bytes to the SDK's UnmarshalJSON, which
 // only accepts a string. The controller emits the field as a number for some
@@ -306,3 +394,131 @@ func (c *Client) GetDevice(ctx context.Context, site, id string) (*unifi.Device,
 	}
 	return nil, &unifi.NotFoundError{}
 }
+
+// Polling parameters for WaitForDeviceAdoption. Variables so tests can shorten
+// them.
+var (
+	deviceAdoptionTimeout      = 10 * time.Minute
+	deviceAdoptionPollInterval = 5 * time.Second
+)
+
+// AdoptDevice asks the controller to adopt a device that is pending adoption
+// into the given site. The SDK's AdoptDevice discards the response meta, so a
+// rejected command (e.g. an unknown MAC) would go unnoticed.
+func (c *Client) AdoptDevice(ctx context.Context, site, mac string) error {
+	payload := map[string]any{
+		"cmd": "adopt",
+		"mac": strings.ToLower(mac),
+	}
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/api/s/%s/cmd/devmgr", c.BaseURL, c.APIPath, site),
+		payload, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
+// SetDeviceInform tells an adopted device to report to a different inform
+// URL, typically another controller's http://<host>:8080/inform. The device
+// stays in this controller's list but stops checking in once it has switched.
+func (c *Client) SetDeviceInform(ctx context.Context, site, mac, informURL string) error {
+	payload := map[string]any{
+		"cmd":        "set-inform",
+		"mac":        strings.ToLower(mac),
+		"inform_url": informURL,
+	}
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/api/s/%s/cmd/devmgr", c.BaseURL, c.APIPath, site),
+		payload, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
+// SetDeviceLocate starts or stops blinking a device's LED, so it can be
+// picked out on a rack or ceiling. The controller keeps it blinking until
+// told to stop.
+func (c *Client) SetDeviceLocate(ctx context.Context, site, mac string, enabled bool) error {
+	cmd := "unset-locate"
+	if enabled {
+		cmd = "set-locate"
+	}
+	payload := map[string]any{
+		"cmd": cmd,
+		"mac": strings.ToLower(mac),
+	}
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/api/s/%s/cmd/devmgr", c.BaseURL, c.APIPath, site),
+		payload, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
+// GetDeviceLocating reports whether a device's LED is blinking because of
+// SetDeviceLocate. The SDK's Device doesn't decode the locating flag, so it
+// is read from stat/device directly.
+func (c *Client) GetDeviceLocating(ctx context.Context, site, mac string) (bool, error) {
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+		Data []struct {
+			Locating bool `json:"locating"`
+		} `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/stat/device/%s", c.BaseURL, c.APIPath, site, strings.ToLower(mac)),
+		nil, &respBody)
+	if err != nil {
+		return false, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return false, err
+	}
+	if len(respBody.Data) == 0 {
+		return false, &unifi.NotFoundError{}
+	}
+	return respBody.Data[0].Locating, nil
+}
+
+// WaitForDeviceAdoption polls the device until the controller reports it as
+// adopted and connected. Adoption includes provisioning and, for devices on
+// old firmware, an upgrade, so this can take several minutes.
+func (c *Client) WaitForDeviceAdoption(ctx context.Context, site, mac string) (*unifi.Device, error) {
+	ctx, cancel := context.WithTimeout(ctx, deviceAdoptionTimeout)
+	defer cancel()
+
+	var last *unifi.Device
+	for {
+		d, err := c.GetDeviceByMAC(ctx, site, mac)
+		if err == nil {
+			if d.Adopted && d.State == unifi.DeviceStateConnected {
+				return d, nil
+			}
+			if d.State == unifi.DeviceStateAdoptFailed {
+				return nil, fmt.Errorf("controller reported adoption of %s as failed", mac)
+			}
+			last = d
+		}
+
+		select {
+		case <-ctx.Done():
+			if last != nil {
+				return nil, fmt.Errorf("timed out waiting for %s to be adopted (last state: %s)", mac, last.State)
+			}
+			return nil, fmt.Errorf("timed out waiting for %s to be adopted: %w", mac, err)
+		case <-time.After(deviceAdoptionPollInterval):
+		}
+	}
+}
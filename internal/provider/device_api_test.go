@@ -1,9 +1,17 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/ubiquiti-community/go-unifi/unifi"
@@ -125,3 +133,222 @@ func TestFixRadioTableBytes_decodesIntoUnifiDevice(t *testing.T) {
 	assert.Equal(t, "23", d.RadioTable[1].TxPower)
 	assert.Equal(t, "high", d.RadioTable[1].TxPowerMode)
 }
+
+func TestApplyPortMirrors(t *testing.T) {
+	mirror := func(src, dst int64) devicePortMirrorModel {
+		return devicePortMirrorModel{
+			SourcePortIdx:      types.Int64Value(src),
+			DestinationPortIdx: types.Int64Value(dst),
+		}
+	}
+
+	t.Run("existing override becomes mirror and keeps other fields", func(t *testing.T) {
+		overrides := []map[string]any{
+			{"port_idx": float64(8), "name": "Sensor", "autoneg": false},
+		}
+
+		got := applyPortMirrors(overrides, []devicePortMirrorModel{mirror(1, 8)})
+
+		require.Len(t, got, 1)
+		assert.Equal(t, "mirror", got[0]["op_mode"])
+		assert.Equal(t, int64(1), got[0]["mirror_port_idx"])
+		assert.Equal(t, "Sensor", got[0]["name"])
+		assert.Equal(t, false, got[0]["autoneg"])
+	})
+
+	t.Run("missing destination gets a new override", func(t *testing.T) {
+		got := applyPortMirrors(nil, []devicePortMirrorModel{mirror(3, 24), mirror(2, 12)})
+
+		require.Len(t, got, 2)
+		assert.Equal(t, int64(12), got[0]["port_idx"])
+		assert.Equal(t, int64(2), got[0]["mirror_port_idx"])
+		assert.Equal(t, int64(24), got[1]["port_idx"])
+		assert.Equal(t, int64(3), got[1]["mirror_port_idx"])
+	})
+
+	t.Run("unlisted mirror is returned to switching", func(t *testing.T) {
+		overrides := []map[string]any{
+			{"port_idx": float64(5), "op_mode": "mirror", "mirror_port_idx": float64(2)},
+			{"port_idx": float64(6), "op_mode": "aggregate"},
+		}
+
+		got := applyPortMirrors(overrides, []devicePortMirrorModel{})
+
+		require.Len(t, got, 2)
+		assert.Equal(t, "switch", got[0]["op_mode"])
+		assert.NotContains(t, got[0], "mirror_port_idx")
+		assert.Equal(t, "aggregate", got[1]["op_mode"])
+	})
+}
+
+func TestAdoptDevice(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/devmgr", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &body))
+		if body["mac"] == "aa:bb:cc:dd:ee:00" {
+			w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.UnknownDevice"},"data":[]}`))
+			return
+		}
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	require.NoError(t, client.AdoptDevice(context.Background(), "default", "AA:BB:CC:DD:EE:FF"))
+	assert.Equal(t, "adopt", body["cmd"])
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", body["mac"])
+
+	err := client.AdoptDevice(context.Background(), "default", "aa:bb:cc:dd:ee:00")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api.err.UnknownDevice")
+}
+
+func TestSetDeviceInform(t *testing.T) {
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/devmgr", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &body))
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	err := client.SetDeviceInform(context.Background(), "default", "AA:BB:CC:DD:EE:FF", "http://10.0.0.2:8080/inform")
+	require.NoError(t, err)
+	assert.Equal(t, "set-inform", body["cmd"])
+	assert.Equal(t, "aa:bb:cc:dd:ee:ff", body["mac"])
+	assert.Equal(t, "http://10.0.0.2:8080/inform", body["inform_url"])
+}
+
+func TestSetDeviceLocate(t *testing.T) {
+	var cmds []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/devmgr", r.URL.Path)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", body["mac"])
+		cmds = append(cmds, body["cmd"].(string))
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	require.NoError(t, client.SetDeviceLocate(context.Background(), "default", "AA:BB:CC:DD:EE:FF", true))
+	require.NoError(t, client.SetDeviceLocate(context.Background(), "default", "aa:bb:cc:dd:ee:ff", false))
+	assert.Equal(t, []string{"set-locate", "unset-locate"}, cmds)
+}
+
+func TestGetDeviceLocating(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/proxy/network/api/s/default/stat/device/aa:bb:cc:dd:ee:ff":
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"mac":"aa:bb:cc:dd:ee:ff","locating":true}]}`))
+		default:
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	locating, err := client.GetDeviceLocating(context.Background(), "default", "AA:BB:CC:DD:EE:FF")
+	require.NoError(t, err)
+	assert.True(t, locating)
+
+	_, err = client.GetDeviceLocating(context.Background(), "default", "aa:bb:cc:dd:ee:00")
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
+
+func TestInformURLRegexp(t *testing.T) {
+	for _, u := range []string{
+		"http://10.0.0.2:8080/inform",
+		"https://unifi.example.com/inform",
+		"http://controller",
+	} {
+		assert.True(t, informURLRegexp.MatchString(u), u)
+	}
+	for _, u := range []string{
+		"controller.example.com:8080/inform",
+		"ftp://10.0.0.2/inform",
+		"http://",
+		"http://host name/inform",
+	} {
+		assert.False(t, informURLRegexp.MatchString(u), u)
+	}
+}
+
+func TestWaitForDeviceAdoption(t *testing.T) {
+	origTimeout, origInterval := deviceAdoptionTimeout, deviceAdoptionPollInterval
+	deviceAdoptionPollInterval = time.Millisecond
+	t.Cleanup(func() {
+		deviceAdoptionTimeout, deviceAdoptionPollInterval = origTimeout, origInterval
+	})
+
+	type status struct {
+		adopted bool
+		state   unifi.DeviceState
+	}
+
+	// serve returns a server that reports the given statuses in order,
+	// repeating the last one.
+	serve := func(states ...status) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			i := int(calls.Add(1)) - 1
+			if i >= len(states) {
+				i = len(states) - 1
+			}
+			fmt.Fprintf(w, `{"meta":{"rc":"ok"},"data":[{"_id":"dev1","mac":"aa:bb:cc:dd:ee:ff","adopted":%v,"state":%v}]}`,
+				states[i].adopted, int64(states[i].state))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &calls
+	}
+
+	t.Run("returns once adopted and connected", func(t *testing.T) {
+		deviceAdoptionTimeout = time.Second
+		srv, calls := serve(
+			status{false, unifi.DeviceStatePending},
+			status{true, unifi.DeviceStateAdopting},
+			status{true, unifi.DeviceStateProvisioning},
+			status{true, unifi.DeviceStateConnected},
+		)
+		client := newTestClient(t, srv.URL, false)
+
+		d, err := client.WaitForDeviceAdoption(context.Background(), "default", "aa:bb:cc:dd:ee:ff")
+		require.NoError(t, err)
+		assert.Equal(t, "dev1", d.ID)
+		assert.Equal(t, int32(4), calls.Load())
+	})
+
+	t.Run("fails when adoption fails", func(t *testing.T) {
+		deviceAdoptionTimeout = time.Second
+		srv, _ := serve(
+			status{false, unifi.DeviceStateAdopting},
+			status{false, unifi.DeviceStateAdoptFailed},
+		)
+		client := newTestClient(t, srv.URL, false)
+
+		_, err := client.WaitForDeviceAdoption(context.Background(), "default", "aa:bb:cc:dd:ee:ff")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed")
+	})
+
+	t.Run("times out with the last state", func(t *testing.T) {
+		deviceAdoptionTimeout = 20 * time.Millisecond
+		srv, _ := serve(status{true, unifi.DeviceStateProvisioning})
+		client := newTestClient(t, srv.URL, false)
+
+		_, err := client.WaitForDeviceAdoption(context.Background(), "default", "aa:bb:cc:dd:ee:ff")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+		assert.Contains(t, err.Error(), "Provisioning")
+	})
+}
@@ -21,7 +21,7 @@ func NewDeviceDataSource() datasource.DataSource {
 }
 
 type deviceDataSource struct {
-	client *Client
+	client ClientAPI
 }
 
 type deviceDataSourceModel struct {
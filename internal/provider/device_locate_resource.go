@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &deviceLocateResource{}
+	_ resource.ResourceWithImportState = &deviceLocateResource{}
+)
+
+func NewDeviceLocateResource() resource.Resource {
+	return &deviceLocateResource{}
+}
+
+type deviceLocateResource struct {
+	client ClientAPI
+}
+
+type deviceLocateResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Site    types.String `tfsdk:"site"`
+	MAC     types.String `tfsdk:"mac"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *deviceLocateResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_device_locate"
+}
+
+func (r *deviceLocateResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Blinks the LED of an adopted device so it can be identified physically, e.g. to " +
+			"find the switch that port overrides are being applied to. The LED blinks while the resource exists " +
+			"and `enabled` is `true`; destroying the resource stops it.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the device.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site the device belongs to. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the device to locate (e.g. `aa:bb:cc:dd:ee:ff`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						macRegexp,
+						"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+					),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the LED is blinking. Set to `false` to stop it without removing the " +
+					"resource. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *deviceLocateResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *deviceLocateResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan deviceLocateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	mac := strings.ToLower(plan.MAC.ValueString())
+
+	if err := r.client.SetDeviceLocate(ctx, site, mac, plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Error Locating Device", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(mac)
+	plan.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *deviceLocateResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state deviceLocateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	locating, err := r.client.GetDeviceLocating(ctx, site, state.ID.ValueString())
+	if err != nil {
+		// A forgotten device can't blink; drop the resource so the next
+		// apply reports the device as missing.
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Device",
+			fmt.Sprintf("Could not read device %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	state.Site = types.StringValue(site)
+	state.MAC = state.ID
+	state.Enabled = types.BoolValue(locating)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *deviceLocateResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan deviceLocateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.client.SetDeviceLocate(ctx, site, state.ID.ValueString(), plan.Enabled.ValueBool()); err != nil {
+		resp.Diagnostics.AddError("Error Locating Device", err.Error())
+		return
+	}
+
+	state.Enabled = plan.Enabled
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *deviceLocateResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state deviceLocateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.client.SetDeviceLocate(ctx, site, state.ID.ValueString(), false); err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Stopping Device Locate", err.Error())
+	}
+}
+
+// ImportState imports by the device's MAC address, as "mac" or "site:mac".
+func (r *deviceLocateResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// MACs contain colons, so a site prefix is recognised by the part count.
+	id := req.ID
+	if parts := strings.Split(req.ID, ":"); len(parts) == 7 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		id = strings.Join(parts[1:], ":")
+	}
+	id = strings.ToLower(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac"), id)...)
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+// TestDeviceLocateCRUD drives the resource against the in-memory fake client.
+func TestDeviceLocateCRUD(t *testing.T) {
+	const mac = "aa:bb:cc:dd:ee:ff"
+	plan := deviceLocateResourceModel{
+		ID:      types.StringUnknown(),
+		Site:    types.StringNull(),
+		MAC:     types.StringValue("AA:BB:CC:DD:EE:FF"),
+		Enabled: types.BoolValue(true),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.locating[mac] = false
+		r := &deviceLocateResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		assert.Equal(t, mac, created.ID.ValueString())
+		assert.Equal(t, "default", created.Site.ValueString())
+		assert.True(t, fake.locating[mac])
+
+		// Stopped outside Terraform: the drift shows up on refresh.
+		fake.locating[mac] = false
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.False(t, read.Enabled.ValueBool())
+
+		off := *created
+		off.Enabled = types.BoolValue(false)
+		fake.locating[mac] = true
+		updated, diags := testUpdate(t, r, *created, off)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.False(t, updated.Enabled.ValueBool())
+		assert.False(t, fake.locating[mac])
+
+		fake.locating[mac] = true
+		diags = testDelete(t, r, *created)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.False(t, fake.locating[mac])
+	})
+
+	t.Run("forgotten device", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &deviceLocateResource{client: fake}
+
+		_, diags := testCreate(t, r, plan)
+		assert.True(t, diags.HasError())
+
+		state := plan
+		state.ID = types.StringValue(mac)
+		read, diags := testRead(t, r, state)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read, "a missing device is removed from state")
+
+		diags = testDelete(t, r, state)
+		assert.False(t, diags.HasError(), "delete: %v", diags)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccDeviceLocate_basic(t *testing.T) {
+	if os.Getenv("TF_ACC") == "" {
+		t.Skip("TF_ACC not set")
+	}
+	preCheck(t)
+	requireAdoptedDevice(t)
+
+	dev := findFirstAdoptedDevice(t)
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_device_locate" "test" {
+  mac = %q
+}
+`, dev.MAC),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_device_locate.test", "id", dev.MAC),
+					resource.TestCheckResourceAttr("terrifi_device_locate.test", "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_device_locate.test",
+				ImportState:       true,
+				ImportStateId:     dev.MAC,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_device_locate" "test" {
+  mac     = %q
+  enabled = false
+}
+`, dev.MAC),
+				Check: resource.TestCheckResourceAttr("terrifi_device_locate.test", "enabled", "false"),
+			},
+		},
+	})
+}
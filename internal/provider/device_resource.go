@@ -76,6 +76,8 @@ func radioSettingsSchema(band string) schema.SingleNestedAttribute {
 
 var ledColorRegexp = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}){1,2}$`)
 
+var informURLRegexp = regexp.MustCompile(`^https?://[^\s/]+(/\S*)?$`)
+
 var (
 	_ resource.Resource                     = &deviceResource{}
 	_ resource.ResourceWithImportState      = &deviceResource{}
@@ -87,7 +89,7 @@ func NewDeviceResource() resource.Resource {
 }
 
 type deviceResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type deviceResourceModel struct {
@@ -95,6 +97,8 @@ type deviceResourceModel struct {
 	Site                types.String              `tfsdk:"site"`
 	MAC                 types.String              `tfsdk:"mac"`
 	Name                types.String              `tfsdk:"name"`
+	Adopt               types.Bool                `tfsdk:"adopt"`
+	InformURL           types.String              `tfsdk:"inform_url"`
 	LedEnabled          types.Bool                `tfsdk:"led_enabled"`
 	LedColor            types.String              `tfsdk:"led_color"`
 	LedBrightness       types.Int64               `tfsdk:"led_brightness"`
@@ -108,6 +112,7 @@ type deviceResourceModel struct {
 	Radio24             *deviceRadioSettingsModel `tfsdk:"radio_24"`
 	Radio5              *deviceRadioSettingsModel `tfsdk:"radio_5"`
 	Radio6              *deviceRadioSettingsModel `tfsdk:"radio_6"`
+	PortMirrors         []devicePortMirrorModel   `tfsdk:"port_mirrors"`
 	// Computed/read-only.
 	Model   types.String `tfsdk:"model"`
 	Type    types.String `tfsdk:"type"`
@@ -125,6 +130,14 @@ type deviceConfigNetworkModel struct {
 	DNS2    types.String `tfsdk:"dns2"`
 }
 
+// devicePortMirrorModel is one SPAN session on a switch. The controller models
+// mirroring as a port override on the destination port (op_mode = "mirror")
+// that points at a single source port via mirror_port_idx.
+type devicePortMirrorModel struct {
+	SourcePortIdx      types.Int64 `tfsdk:"source_port_idx"`
+	DestinationPortIdx types.Int64 `tfsdk:"destination_port_idx"`
+}
+
 func (r *deviceResource) Metadata(
 	_ context.Context,
 	req resource.MetadataRequest,
@@ -139,9 +152,10 @@ func (r *deviceResource) Schema(
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages settings on an adopted UniFi network device (access point, switch, or gateway). " +
-			"The device must already be adopted by the controller. This resource does not adopt or forget devices — " +
-			"it only manages configurable properties like name, LED behavior, and SNMP settings. " +
+		MarkdownDescription: "Manages settings on a UniFi network device (access point, switch, or gateway). " +
+			"The device must already be adopted by the controller, unless `adopt` is set to adopt a device that is " +
+			"pending adoption. This resource never forgets devices — it only manages configurable properties like " +
+			"name, LED behavior, and SNMP settings. " +
 			"Removing the resource from Terraform state does not affect the device on the controller.",
 
 		Attributes: map[string]schema.Attribute{
@@ -165,7 +179,7 @@ func (r *deviceResource) Schema(
 
 			"mac": schema.StringAttribute{
 				MarkdownDescription: "The MAC address of the device (e.g. `aa:bb:cc:dd:ee:ff`). " +
-					"The device must already be adopted by the controller.",
+					"The device must already be adopted by the controller unless `adopt` is `true`.",
 				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -183,6 +197,27 @@ func (r *deviceResource) Schema(
 				Optional:            true,
 			},
 
+			"adopt": schema.BoolAttribute{
+				MarkdownDescription: "Whether to adopt the device into `site` if it is pending adoption when the resource " +
+					"is created. Terraform waits up to 10 minutes for the device to finish adopting and provisioning " +
+					"before applying the other settings. Has no effect on a device that is already adopted. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"inform_url": schema.StringAttribute{
+				MarkdownDescription: "Inform URL to point the device at, e.g. `http://new-controller.example.com:8080/inform`. " +
+					"Setting or changing this sends a `set-inform` command after the other settings are applied, which " +
+					"moves the device to the controller at that URL. The controller does not report the current inform " +
+					"URL, so removing the attribute does not change the device.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(informURLRegexp, "must be an http(s) URL (e.g. http://controller:8080/inform)"),
+				},
+			},
+
 			"led_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether LEDs are enabled. `true` forces LEDs on, `false` forces LEDs off. " +
 					"Omit to follow the site default.",
@@ -298,6 +333,33 @@ func (r *deviceResource) Schema(
 			"radio_5":  radioSettingsSchema("5 GHz (`na`)"),
 			"radio_6":  radioSettingsSchema("6 GHz (`6e`)"),
 
+			"port_mirrors": schema.SetNestedAttribute{
+				MarkdownDescription: "Port mirroring (SPAN) sessions on a switch. Each session copies traffic from " +
+					"`source_port_idx` to `destination_port_idx`, e.g. for an IDS sensor. When set, this attribute " +
+					"owns all mirror sessions on the device: any destination port not listed is returned to normal " +
+					"switching. Use an empty set to remove all sessions. Omit to leave mirroring unchanged.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_port_idx": schema.Int64Attribute{
+							MarkdownDescription: "Index of the port whose traffic is mirrored.",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 56),
+							},
+						},
+						"destination_port_idx": schema.Int64Attribute{
+							MarkdownDescription: "Index of the port that receives the mirrored traffic. " +
+								"A port can be the destination of only one session.",
+							Required: true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 56),
+							},
+						},
+					},
+				},
+			},
+
 			// Read-only attributes.
 			"model": schema.StringAttribute{
 				MarkdownDescription: "The hardware model of the device (e.g. `U6-LR`, `US-16-XG`).",
@@ -333,7 +395,7 @@ func (r *deviceResource) Schema(
 
 			"state": schema.Int64Attribute{
 				MarkdownDescription: "The device state. 0 = unknown, 1 = connected, 2 = pending, " +
-					"4 = upgrading, 5 = provisioning, 6 = heartbeat missed.",
+					"4 = upgrading, 5 = provisioning, 6 = heartbeat missed, 7 = adopting, 10 = adoption failed.",
 				Computed: true,
 				PlanModifiers: []planmodifier.Int64{
 					int64planmodifier.UseStateForUnknown(),
@@ -346,6 +408,7 @@ func (r *deviceResource) Schema(
 func (r *deviceResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
 	return []resource.ConfigValidator{
 		deviceConfigNetworkValidator{},
+		devicePortMirrorValidator{},
 	}
 }
 
@@ -388,18 +451,40 @@ func (r *deviceResource) Create(
 	site := r.client.SiteOrDefault(plan.Site)
 	mac := strings.ToLower(plan.MAC.ValueString())
 
-	// Look up the existing device by MAC — it must already be adopted.
+	// Look up the existing device by MAC — it must already be adopted, or be
+	// pending adoption with adopt = true.
 	existing, err := r.client.GetDeviceByMAC(ctx, site, mac)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Device Not Found",
-			fmt.Sprintf("No adopted device found with MAC %q in site %q. "+
-				"The device must be adopted by the controller before it can be managed by Terraform: %s",
+			fmt.Sprintf("No device found with MAC %q in site %q. "+
+				"The device must be adopted by the controller, or pending adoption, before it can be managed by Terraform: %s",
 				mac, site, err.Error()),
 		)
 		return
 	}
 
+	if !existing.Adopted {
+		if !plan.Adopt.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Device Not Adopted",
+				fmt.Sprintf("Device %q in site %q has not been adopted by the controller. "+
+					"Adopt it in the UniFi UI, or set adopt = true to adopt it from Terraform.", mac, site),
+			)
+			return
+		}
+
+		if err := r.client.AdoptDevice(ctx, site, mac); err != nil {
+			resp.Diagnostics.AddError("Error Adopting Device", err.Error())
+			return
+		}
+		existing, err = r.client.WaitForDeviceAdoption(ctx, site, mac)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Adopting Device", err.Error())
+			return
+		}
+	}
+
 	// TODO(go-unifi): Bypass SDK's UpdateDevice — see device_api.go for details.
 	err = r.client.UpdateDevice(ctx, site, existing.ID, &plan)
 	if err != nil {
@@ -407,6 +492,13 @@ func (r *deviceResource) Create(
 		return
 	}
 
+	if !plan.InformURL.IsNull() && !plan.InformURL.IsUnknown() {
+		if err := r.client.SetDeviceInform(ctx, site, mac, plan.InformURL.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error Setting Device Inform URL", err.Error())
+			return
+		}
+	}
+
 	// Re-read to get full state including runtime fields (state, ip, etc.).
 	device, err := r.client.GetDevice(ctx, site, existing.ID)
 	if err != nil {
@@ -474,6 +566,16 @@ func (r *deviceResource) Update(
 		return
 	}
 
+	// Only a new inform URL is sent; the device already reports to an
+	// unchanged one.
+	if !plan.InformURL.IsNull() && !plan.InformURL.IsUnknown() && !plan.InformURL.Equal(state.InformURL) {
+		err := r.client.SetDeviceInform(ctx, site, state.MAC.ValueString(), plan.InformURL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Setting Device Inform URL", err.Error())
+			return
+		}
+	}
+
 	// Re-read to get full state including runtime fields.
 	device, err := r.client.GetDevice(ctx, site, state.ID.ValueString())
 	if err != nil {
@@ -483,6 +585,10 @@ func (r *deviceResource) Update(
 
 	r.apiToModel(device, &state, site)
 	r.preserveNullOptionals(&plan, &state)
+	// adopt and inform_url are not reported by the controller; track the
+	// configured values.
+	state.Adopt = plan.Adopt
+	state.InformURL = plan.InformURL
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -598,6 +704,9 @@ func (r *deviceResource) preserveNullOptionals(plan, state *deviceResourceModel)
 	preserveNullRadio(plan.Radio24, &state.Radio24)
 	preserveNullRadio(plan.Radio5, &state.Radio5)
 	preserveNullRadio(plan.Radio6, &state.Radio6)
+	if plan.PortMirrors == nil {
+		state.PortMirrors = nil
+	}
 }
 
 // preserveNullRadio handles null preservation for one radio block. If the
@@ -659,6 +768,11 @@ func (r *deviceResource) apiToModel(d *unifi.Device, m *deviceResourceModel, sit
 	m.MAC = types.StringValue(d.MAC)
 
 	m.Name = stringValueOrNull(d.Name)
+	// adopt is not reported by the controller. Keep the configured value, and
+	// fall back to the default after import.
+	if m.Adopt.IsNull() || m.Adopt.IsUnknown() {
+		m.Adopt = types.BoolValue(false)
+	}
 	switch d.LedOverride {
 	case "on":
 		m.LedEnabled = types.BoolValue(true)
@@ -712,6 +826,17 @@ func (r *deviceResource) apiToModel(d *unifi.Device, m *deviceResourceModel, sit
 		}
 	}
 
+	m.PortMirrors = []devicePortMirrorModel{}
+	for _, po := range d.PortOverrides {
+		if po.OpMode != "mirror" || po.PortIDX == nil || po.MirrorPortIDX == nil {
+			continue
+		}
+		m.PortMirrors = append(m.PortMirrors, devicePortMirrorModel{
+			SourcePortIdx:      types.Int64Value(*po.MirrorPortIDX),
+			DestinationPortIdx: types.Int64Value(*po.PortIDX),
+		})
+	}
+
 	// Read-only fields.
 	m.Model = stringValueOrNull(d.Model)
 	m.Type = stringValueOrNull(d.Type)
@@ -778,3 +903,48 @@ func (v deviceConfigNetworkValidator) ValidateResource(ctx context.Context, req
 		}
 	}
 }
+
+// devicePortMirrorValidator rejects port mirror sessions that the controller
+// cannot represent: a port mirroring itself, or two sessions sharing one
+// destination port (each destination holds a single mirror_port_idx).
+type devicePortMirrorValidator struct{}
+
+func (v devicePortMirrorValidator) Description(_ context.Context) string {
+	return "Each port_mirrors destination must be unique and differ from its source."
+}
+
+func (v devicePortMirrorValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v devicePortMirrorValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var mirrors []devicePortMirrorModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("port_mirrors"), &mirrors)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	destinations := map[int64]bool{}
+	for _, m := range mirrors {
+		if m.SourcePortIdx.IsUnknown() || m.DestinationPortIdx.IsUnknown() ||
+			m.SourcePortIdx.IsNull() || m.DestinationPortIdx.IsNull() {
+			continue
+		}
+		dst := m.DestinationPortIdx.ValueInt64()
+		if m.SourcePortIdx.ValueInt64() == dst {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("port_mirrors"),
+				"Invalid port mirror",
+				fmt.Sprintf("Port %d cannot mirror itself.", dst),
+			)
+		}
+		if destinations[dst] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("port_mirrors"),
+				"Invalid port mirror",
+				fmt.Sprintf("Port %d is the destination of more than one mirror session.", dst),
+			)
+		}
+		destinations[dst] = true
+	}
+}
@@ -999,6 +999,49 @@ resource "terrifi_device" "test" {
 	})
 }
 
+// ---------------------------------------------------------------------------
+// Port mirroring unit tests
+// ---------------------------------------------------------------------------
+
+func TestDevicePortMirrorsAPIToModel(t *testing.T) {
+	r := &deviceResource{}
+	idx := func(v int64) *int64 { return &v }
+
+	t.Run("only mirror overrides are reported", func(t *testing.T) {
+		dev := &unifi.Device{
+			ID:  "sw-1",
+			MAC: "aa:bb:cc:dd:ee:01",
+			PortOverrides: []unifi.DevicePortOverrides{
+				{PortIDX: idx(1), OpMode: "switch"},
+				{PortIDX: idx(8), OpMode: "mirror", MirrorPortIDX: idx(2)},
+			},
+		}
+
+		var m deviceResourceModel
+		r.apiToModel(dev, &m, "default")
+
+		require.Len(t, m.PortMirrors, 1)
+		assert.Equal(t, int64(2), m.PortMirrors[0].SourcePortIdx.ValueInt64())
+		assert.Equal(t, int64(8), m.PortMirrors[0].DestinationPortIdx.ValueInt64())
+	})
+
+	t.Run("unconfigured port_mirrors stays null", func(t *testing.T) {
+		dev := &unifi.Device{
+			ID:  "sw-1",
+			MAC: "aa:bb:cc:dd:ee:01",
+			PortOverrides: []unifi.DevicePortOverrides{
+				{PortIDX: idx(8), OpMode: "mirror", MirrorPortIDX: idx(2)},
+			},
+		}
+
+		var m deviceResourceModel
+		r.apiToModel(dev, &m, "default")
+		r.preserveNullOptionals(&deviceResourceModel{}, &m)
+
+		assert.Nil(t, m.PortMirrors)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Radio settings unit tests
 // ---------------------------------------------------------------------------
@@ -1475,6 +1518,24 @@ resource "terrifi_device" "test" {
 	})
 }
 
+func TestAccDeviceResource_validationInvalidInformURL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_device" "test" {
+  mac        = "aa:bb:cc:dd:ee:ff"
+  inform_url = "controller.example.com:8080/inform"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an http\(s\) URL`),
+			},
+		},
+	})
+}
+
 func TestAccDeviceResource_validationStaticRequiresAddressing(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { preCheck(t) },
@@ -1495,6 +1556,26 @@ resource "terrifi_device" "test" {
 	})
 }
 
+func TestAccDeviceResource_validationPortMirrorSelf(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_device" "test" {
+  mac = "aa:bb:cc:dd:ee:ff"
+  port_mirrors = [
+    { source_port_idx = 4, destination_port_idx = 4 },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`cannot mirror itself`),
+			},
+		},
+	})
+}
+
 func TestAccDeviceResource_validationDHCPRejectsAddressing(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { preCheck(t) },
@@ -0,0 +1,405 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                     = &dhcpOptionResource{}
+	_ resource.ResourceWithImportState      = &dhcpOptionResource{}
+	_ resource.ResourceWithConfigValidators = &dhcpOptionResource{}
+)
+
+// dhcpOptionReservedCodes are the option codes the controller refuses for
+// custom options, because it sets them itself or through dedicated network
+// fields.
+var dhcpOptionReservedCodes = []int64{15, 42, 43, 44, 51, 66, 67, 252}
+
+// NewDHCPOptionResource is the factory function registered in provider.Resources().
+func NewDHCPOptionResource() resource.Resource {
+	return &dhcpOptionResource{}
+}
+
+// dhcpOptionResource holds the API client, injected by Configure().
+type dhcpOptionResource struct {
+	client ClientAPI
+}
+
+// dhcpOptionResourceModel is the Terraform-side representation of a custom
+// DHCP option definition.
+type dhcpOptionResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Site   types.String `tfsdk:"site"`
+	Name   types.String `tfsdk:"name"`
+	Code   types.Int64  `tfsdk:"code"`
+	Type   types.String `tfsdk:"type"`
+	Width  types.Int64  `tfsdk:"width"`
+	Signed types.Bool   `tfsdk:"signed"`
+}
+
+// Metadata sets the resource type name.
+func (r *dhcpOptionResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dhcp_option"
+}
+
+// Schema defines the HCL schema for the terrifi_dhcp_option resource.
+func (r *dhcpOptionResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Defines a custom DHCP option on the UniFi controller, e.g. option 150 (TFTP server " +
+			"list) for IP phones. Once defined, the option can be given a value in each network's DHCP settings.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the DHCP option.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the DHCP option with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the option, up to 25 letters, digits, `-` and `_`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[A-Za-z0-9_-]{1,25}$`),
+						"must be 1-25 letters, digits, '-' or '_'",
+					),
+				},
+			},
+
+			"code": schema.Int64Attribute{
+				MarkdownDescription: "The option code (7-254). Codes the controller manages itself can't be used: " +
+					"15, 42 (use `dhcp_ntp` on `terrifi_network`), 43 (`dhcp_unifi_controller`), 44, 51 (`dhcp_lease`), " +
+					"66 (`dhcp_boot_server`), 67 (`dhcp_boot_filename`) and 252.",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.Between(7, 254),
+					int64validator.NoneOf(dhcpOptionReservedCodes...),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The type of the option's value. One of: `boolean`, `hexarray`, `integer`, " +
+					"`ipaddress`, `macaddress`, `text`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("boolean", "hexarray", "integer", "ipaddress", "macaddress", "text"),
+				},
+			},
+
+			"width": schema.Int64Attribute{
+				MarkdownDescription: "The size of an `integer` value in bits: `8`, `16` or `32`. Required when `type` " +
+					"is `integer`, and only valid then.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.OneOf(8, 16, 32),
+				},
+			},
+
+			"signed": schema.BoolAttribute{
+				MarkdownDescription: "Whether an `integer` value is signed. Only valid when `type` is `integer`. " +
+					"Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute. width and signed only apply to integer options.
+func (r *dhcpOptionResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		dhcpOptionIntegerValidator{},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *dhcpOptionResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new DHCP option.
+func (r *dhcpOptionResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan dhcpOptionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateDHCPOption(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating DHCP Option", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *dhcpOptionResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state dhcpOptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	option, err := r.client.GetDHCPOption(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading DHCP Option",
+			fmt.Sprintf("Could not read DHCP option %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(option, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing DHCP option. Every attribute is either required
+// or has a default, so the plan is the complete object.
+func (r *dhcpOptionResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan dhcpOptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetDHCPOption(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading DHCP Option for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "DHCP option", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	option := r.modelToAPI(&plan)
+	option.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateDHCPOption(ctx, site, option)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating DHCP Option", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the DHCP option from the UniFi controller.
+func (r *dhcpOptionResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state dhcpOptionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteDHCPOption(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting DHCP Option", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_dhcp_option.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *dhcpOptionResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI converts our Terraform model to the go-unifi DHCPOption struct.
+// The controller stores the code as a string.
+func (r *dhcpOptionResource) modelToAPI(m *dhcpOptionResourceModel) *unifi.DHCPOption {
+	option := &unifi.DHCPOption{
+		Name: m.Name.ValueString(),
+		Code: strconv.FormatInt(m.Code.ValueInt64(), 10),
+		Type: m.Type.ValueString(),
+	}
+	if option.Type == "integer" {
+		option.Width = m.Width.ValueInt64Pointer()
+		option.Signed = m.Signed.ValueBool()
+	}
+	return option
+}
+
+// apiToModel converts the go-unifi DHCPOption struct back to our Terraform model.
+func (r *dhcpOptionResource) apiToModel(option *unifi.DHCPOption, m *dhcpOptionResourceModel, site string) {
+	m.ID = types.StringValue(option.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(option.Name)
+	m.Type = types.StringValue(option.Type)
+	m.Signed = types.BoolValue(option.Signed)
+
+	if code, err := strconv.ParseInt(option.Code, 10, 64); err == nil {
+		m.Code = types.Int64Value(code)
+	} else {
+		m.Code = types.Int64Null()
+	}
+
+	if option.Type == "integer" && option.Width != nil {
+		m.Width = types.Int64Value(*option.Width)
+	} else {
+		m.Width = types.Int64Null()
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// dhcpOptionIntegerValidator requires width for integer options and rejects
+// width and signed for every other type, which the controller ignores.
+type dhcpOptionIntegerValidator struct{}
+
+func (v dhcpOptionIntegerValidator) Description(_ context.Context) string {
+	return "width is required when type is integer; width and signed are only valid then"
+}
+
+func (v dhcpOptionIntegerValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dhcpOptionIntegerValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var optionType types.String
+	var width types.Int64
+	var signed types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &optionType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("width"), &width)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("signed"), &signed)...)
+	if resp.Diagnostics.HasError() || optionType.IsUnknown() || optionType.IsNull() {
+		return
+	}
+
+	if optionType.ValueString() == "integer" {
+		if width.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("width"),
+				"Missing Integer Width",
+				`width is required when type is "integer".`,
+			)
+		}
+		return
+	}
+
+	if !width.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("width"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf(`width can only be set when type is "integer", got %q.`, optionType.ValueString()),
+		)
+	}
+	if !signed.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("signed"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf(`signed can only be set when type is "integer", got %q.`, optionType.ValueString()),
+		)
+	}
+}
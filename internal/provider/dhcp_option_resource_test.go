@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestDHCPOptionModelToAPI(t *testing.T) {
+	r := &dhcpOptionResource{}
+
+	t.Run("ip address", func(t *testing.T) {
+		option := r.modelToAPI(&dhcpOptionResourceModel{
+			Name:   types.StringValue("tftp-servers"),
+			Code:   types.Int64Value(150),
+			Type:   types.StringValue("ipaddress"),
+			Width:  types.Int64Null(),
+			Signed: types.BoolValue(false),
+		})
+
+		assert.Equal(t, "tftp-servers", option.Name)
+		assert.Equal(t, "150", option.Code)
+		assert.Equal(t, "ipaddress", option.Type)
+		assert.Nil(t, option.Width)
+		assert.False(t, option.Signed)
+	})
+
+	t.Run("integer", func(t *testing.T) {
+		option := r.modelToAPI(&dhcpOptionResourceModel{
+			Name:   types.StringValue("offset"),
+			Code:   types.Int64Value(200),
+			Type:   types.StringValue("integer"),
+			Width:  types.Int64Value(32),
+			Signed: types.BoolValue(true),
+		})
+
+		require.NotNil(t, option.Width)
+		assert.Equal(t, int64(32), *option.Width)
+		assert.True(t, option.Signed)
+	})
+}
+
+func TestDHCPOptionAPIToModel(t *testing.T) {
+	r := &dhcpOptionResource{}
+	width := int64(16)
+
+	t.Run("integer", func(t *testing.T) {
+		var m dhcpOptionResourceModel
+		r.apiToModel(&unifi.DHCPOption{
+			ID: "o1", Name: "offset", Code: "200", Type: "integer", Width: &width, Signed: true,
+		}, &m, "default")
+
+		assert.Equal(t, "o1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "offset", m.Name.ValueString())
+		assert.Equal(t, int64(200), m.Code.ValueInt64())
+		assert.Equal(t, "integer", m.Type.ValueString())
+		assert.Equal(t, int64(16), m.Width.ValueInt64())
+		assert.True(t, m.Signed.ValueBool())
+	})
+
+	t.Run("width is ignored for other types", func(t *testing.T) {
+		var m dhcpOptionResourceModel
+		r.apiToModel(&unifi.DHCPOption{
+			ID: "o1", Name: "tftp-servers", Code: "150", Type: "ipaddress", Width: &width,
+		}, &m, "default")
+
+		assert.True(t, m.Width.IsNull())
+		assert.False(t, m.Signed.ValueBool())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccDHCPOption_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dhcp_option" "test" {
+  name = %q
+  code = 150
+  type = "ipaddress"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_dhcp_option.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "code", "150"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "type", "ipaddress"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "signed", "false"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_dhcp_option.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dhcp_option" "test" {
+  name   = %q
+  code   = 200
+  type   = "integer"
+  width  = 16
+  signed = true
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "code", "200"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "type", "integer"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "width", "16"),
+					resource.TestCheckResourceAttr("terrifi_dhcp_option.test", "signed", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDHCPOption_validationReservedCode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dhcp_option" "test" {
+  name = "controller"
+  code = 43
+  type = "ipaddress"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?s)code.*43`),
+			},
+		},
+	})
+}
+
+func TestAccDHCPOption_validationIntegerWidth(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dhcp_option" "test" {
+  name = "offset"
+  code = 200
+  type = "integer"
+}
+`,
+				ExpectError: regexp.MustCompile(`width is required when type is "integer"`),
+			},
+			{
+				Config: `
+resource "terrifi_dhcp_option" "test" {
+  name  = "tftp"
+  code  = 150
+  type  = "ipaddress"
+  width = 8
+}
+`,
+				ExpectError: regexp.MustCompile(`width can only be set when type is "integer"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// dnsForwardingRecordType is the record type of the controller's static DNS
+// entries that forward a domain to another resolver.
+const dnsForwardingRecordType = "FORWARD"
+
+var (
+	_ resource.Resource                = &dnsForwardingResource{}
+	_ resource.ResourceWithImportState = &dnsForwardingResource{}
+)
+
+func NewDNSForwardingResource() resource.Resource {
+	return &dnsForwardingResource{}
+}
+
+type dnsForwardingResource struct {
+	client ClientAPI
+}
+
+// dnsForwardingResourceModel is a static DNS entry of type FORWARD. The
+// `unifi` tags map fields to the go-unifi DNSRecord struct (see mapping.go).
+type dnsForwardingResourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Site    types.String `tfsdk:"site"`
+	Domain  types.String `tfsdk:"domain"  unifi:"Key"`
+	Server  types.String `tfsdk:"server"  unifi:"Value"`
+	Enabled types.Bool   `tfsdk:"enabled" unifi:"Enabled"`
+}
+
+func (r *dnsForwardingResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dns_forwarding"
+}
+
+func (r *dnsForwardingResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a conditional DNS forwarding entry on the UniFi gateway. Lookups of " +
+			"`domain` and every name under it are forwarded to `server` instead of the gateway's upstream " +
+			"resolvers, e.g. to resolve an internal `corp.example` zone.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the forwarding entry.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the forwarding entry with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain to forward, e.g. `corp.example`. Subdomains are forwarded too, so " +
+					"no wildcard is needed.",
+				Required: true,
+				Validators: []validator.String{
+					dnsForwardingDomainValidator{},
+				},
+			},
+
+			"server": schema.StringAttribute{
+				MarkdownDescription: "The IP address of the resolver to forward lookups to.",
+				Required:            true,
+				Validators: []validator.String{
+					trafficRouteIPValidator{allowRanges: false},
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the forwarding entry is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+func (r *dnsForwardingResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *dnsForwardingResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan dnsForwardingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateDNSRecord(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating DNS Forwarding Entry", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dnsForwardingResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state dnsForwardingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	rec, err := r.client.GetDNSRecord(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading DNS Forwarding Entry",
+			fmt.Sprintf("Could not read DNS forwarding entry %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+	// Forwarding entries share an ID space with DNS records, so an import
+	// can name the wrong kind of object.
+	if rec.RecordType != dnsForwardingRecordType {
+		resp.Diagnostics.AddError(
+			"Not a DNS Forwarding Entry",
+			fmt.Sprintf("%s is a %s DNS record, not a forwarding entry. Manage it with terrifi_dns_record.",
+				state.ID.ValueString(), rec.RecordType),
+		)
+		return
+	}
+
+	r.apiToModel(rec, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dnsForwardingResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan dnsForwardingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetDNSRecord(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading DNS Forwarding Entry for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "DNS forwarding entry", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	state.Domain = plan.Domain
+	state.Server = plan.Server
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		state.Enabled = plan.Enabled
+	}
+
+	rec := r.modelToAPI(&state)
+	rec.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateDNSRecord(ctx, site, rec)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating DNS Forwarding Entry", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *dnsForwardingResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state dnsForwardingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteDNSRecord(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting DNS Forwarding Entry", err.Error())
+	}
+}
+
+func (r *dnsForwardingResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// dnsForwardingDomainValidator checks that a forwarded domain is a plain
+// domain name. dnsmasq forwards subdomains on its own, so wildcards are
+// rejected rather than silently stored.
+type dnsForwardingDomainValidator struct{}
+
+func (v dnsForwardingDomainValidator) Description(_ context.Context) string {
+	return "must be a domain name without wildcards"
+}
+
+func (v dnsForwardingDomainValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsForwardingDomainValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	domain := req.ConfigValue.ValueString()
+	if err := validateDNSRecordName(domain); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Domain", err.Error())
+		return
+	}
+	if strings.Contains(domain, "*") {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Domain",
+			fmt.Sprintf("%q contains a wildcard; write %q, which also forwards every name under it.",
+				domain, strings.TrimPrefix(domain, "*.")))
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *dnsForwardingResource) modelToAPI(m *dnsForwardingResourceModel) *unifi.DNSRecord {
+	rec := &unifi.DNSRecord{RecordType: dnsForwardingRecordType}
+	mapModelToAPI(m, rec)
+	return rec
+}
+
+func (r *dnsForwardingResource) apiToModel(rec *unifi.DNSRecord, m *dnsForwardingResourceModel, site string) {
+	mapAPIToModel(rec, m)
+	m.ID = types.StringValue(rec.ID)
+	m.Site = types.StringValue(site)
+}
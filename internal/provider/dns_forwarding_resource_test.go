@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestDNSForwardingCRUD(t *testing.T) {
+	plan := dnsForwardingResourceModel{
+		ID:      types.StringUnknown(),
+		Site:    types.StringNull(),
+		Domain:  types.StringValue("corp.example"),
+		Server:  types.StringValue("10.0.0.53"),
+		Enabled: types.BoolValue(true),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsForwardingResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		assert.Equal(t, "default", created.Site.ValueString())
+		require.Contains(t, fake.dnsRecords, created.ID.ValueString())
+		rec := fake.dnsRecords[created.ID.ValueString()]
+		assert.Equal(t, "FORWARD", rec.RecordType)
+		assert.Equal(t, "corp.example", rec.Key)
+		assert.Equal(t, "10.0.0.53", rec.Value)
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, created, read)
+
+		update := *created
+		update.Server = types.StringValue("10.0.0.54")
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, "10.0.0.54", updated.Server.ValueString())
+		assert.Equal(t, "FORWARD", fake.dnsRecords[created.ID.ValueString()].RecordType)
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dnsRecords)
+	})
+
+	t.Run("read rejects a DNS record", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.dnsRecords["rec1"] = unifi.DNSRecord{ID: "rec1", Key: "web.home", RecordType: "A", Value: "192.168.1.10"}
+		r := &dnsForwardingResource{client: fake}
+
+		state := plan
+		state.ID = types.StringValue("rec1")
+		_, diags := testRead(t, r, state)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Not a DNS Forwarding Entry", diags.Errors()[0].Summary())
+	})
+}
+
+func TestDNSForwardingDomainValidator(t *testing.T) {
+	for _, tc := range []struct {
+		domain string
+		ok     bool
+	}{
+		{"corp.example", true},
+		{"lan", true},
+		{"*.corp.example", false},
+		{"corp..example", false},
+		{".corp.example", false},
+	} {
+		t.Run(tc.domain, func(t *testing.T) {
+			var resp validator.StringResponse
+			dnsForwardingDomainValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("domain"),
+				ConfigValue: types.StringValue(tc.domain),
+			}, &resp)
+			assert.Equal(t, tc.ok, !resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccDNSForwarding_basic(t *testing.T) {
+	domain := fmt.Sprintf("tfacc-%s.example", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_forwarding" "test" {
+  domain = %q
+  server = "10.0.0.53"
+}
+`, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_dns_forwarding.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_dns_forwarding.test", "domain", domain),
+					resource.TestCheckResourceAttr("terrifi_dns_forwarding.test", "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_dns_forwarding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_forwarding" "test" {
+  domain  = %q
+  server  = "10.0.0.54"
+  enabled = false
+}
+`, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dns_forwarding.test", "server", "10.0.0.54"),
+					resource.TestCheckResourceAttr("terrifi_dns_forwarding.test", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSForwarding_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dns_forwarding" "test" {
+  domain = "corp.example"
+  server = "resolver.corp.example"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid IP Address`),
+			},
+		},
+	})
+}
@@ -3,14 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/alexklibisz/terrifi/internal/txtrecord"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -33,6 +39,9 @@ import (
 var (
 	_ resource.Resource                = &dnsRecordResource{}
 	_ resource.ResourceWithImportState = &dnsRecordResource{}
+
+	_ resource.ResourceWithConfigValidators = &dnsRecordResource{}
+	_ resource.ResourceWithModifyPlan       = &dnsRecordResource{}
 )
 
 // NewDNSRecordResource is the factory function registered in provider.Resources().
@@ -43,7 +52,7 @@ func NewDNSRecordResource() resource.Resource {
 
 // dnsRecordResource holds the API client, injected by Configure().
 type dnsRecordResource struct {
-	client *Client
+	client ClientAPI
 }
 
 // dnsRecordResourceModel is the Terraform-side representation of a DNS record.
@@ -56,17 +65,26 @@ type dnsRecordResource struct {
 //   - set     — attribute has an explicit value
 //
 // Plain Go types can't represent null, so we'd lose information.
+//
+// The `unifi` tags map fields to the go-unifi DNSRecord struct (see mapping.go).
+// Optional fields are zeronull: the API reports unset values as 0 or "".
+//
+// A round-robin record (Values set) is stored on the controller as one record
+// per value, all with the same name. RecordIDs lists them in value order (it
+// is null for a single-value record), and ID is one of them.
 type dnsRecordResourceModel struct {
 	ID         types.String `tfsdk:"id"`
 	Site       types.String `tfsdk:"site"`
-	Name       types.String `tfsdk:"name"`
-	Enabled    types.Bool   `tfsdk:"enabled"`
-	Port       types.Int64  `tfsdk:"port"`
-	Priority   types.Int64  `tfsdk:"priority"`
-	RecordType types.String `tfsdk:"record_type"`
-	TTL        types.Int64  `tfsdk:"ttl"`
+	Name       types.String `tfsdk:"name"        unifi:"Key"`
+	Enabled    types.Bool   `tfsdk:"enabled"     unifi:"Enabled"`
+	Port       types.Int64  `tfsdk:"port"        unifi:"Port,zeronull"`
+	Priority   types.Int64  `tfsdk:"priority"    unifi:"Priority,zeronull"`
+	RecordType types.String `tfsdk:"record_type" unifi:"RecordType,zeronull"`
+	TTL        types.Int64  `tfsdk:"ttl"         unifi:"Ttl,zeronull"`
 	Value      types.String `tfsdk:"value"`
-	Weight     types.Int64  `tfsdk:"weight"`
+	Values     types.Set    `tfsdk:"values"`
+	RecordIDs  types.List   `tfsdk:"record_ids"`
+	Weight     types.Int64  `tfsdk:"weight"      unifi:"Weight,zeronull"`
 }
 
 // Metadata sets the resource type name. Combined with the provider type name "terrifi",
@@ -117,11 +135,15 @@ func (r *dnsRecordResource) Schema(
 			// Required means the user must provide this. RequiresReplace because the UniFi
 			// API treats the record key (hostname) as part of its identity.
 			"name": schema.StringAttribute{
-				MarkdownDescription: "The hostname for the DNS record.",
-				Required:            true,
+				MarkdownDescription: "The hostname for the DNS record. A leading `*.` label makes a wildcard record " +
+					"that matches every name under the rest of the hostname (e.g. `*.example.lan`).",
+				Required: true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
+				Validators: []validator.String{
+					dnsRecordNameValidator{},
+				},
 			},
 
 			// Optional+Computed with a Default. The user can set it, but if they don't,
@@ -157,15 +179,39 @@ func (r *dnsRecordResource) Schema(
 				},
 			},
 			"ttl": schema.Int64Attribute{
-				MarkdownDescription: "The TTL in seconds.",
-				Optional:            true,
+				MarkdownDescription: "The TTL in seconds, between 0 and 65535. Some controller versions reject TTLs " +
+					"below 60 seconds.",
+				Optional: true,
 				Validators: []validator.Int64{
-					int64validator.AtMost(65535),
+					int64validator.Between(0, 65535),
 				},
 			},
 			"value": schema.StringAttribute{
-				MarkdownDescription: "The value of the DNS record (IP address, hostname, etc.).",
-				Required:            true,
+				MarkdownDescription: "The value of the DNS record (IP address, hostname, etc.). For TXT records, write " +
+					"the value unquoted; long values are split into 255-byte strings and quoted automatically. " +
+					"Exactly one of `value` or `values` must be set.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("values")),
+				},
+			},
+			"values": schema.SetAttribute{
+				MarkdownDescription: "The addresses of a round-robin A or AAAA record. The controller stores one " +
+					"record per address, all managed by this resource, and answers lookups with all of them.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"record_ids": schema.ListAttribute{
+				MarkdownDescription: "The IDs of the controller records of a round-robin record, ordered by value. " +
+					"Null when `values` is not set.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"weight": schema.Int64Attribute{
 				MarkdownDescription: "The weight for SRV records.",
@@ -178,6 +224,16 @@ func (r *dnsRecordResource) Schema(
 	}
 }
 
+// ConfigValidators returns validators that need to look at more than one
+// attribute. The TXT length limit only applies when record_type is TXT, and
+// round-robin values only make sense for address records.
+func (r *dnsRecordResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		dnsRecordTXTLengthValidator{},
+		dnsRecordValuesTypeValidator{},
+	}
+}
+
 // Configure is called by the framework to inject the provider's API client.
 // Every resource gets its own Configure call. The client was stored in
 // resp.ResourceData by the provider's Configure method.
@@ -222,15 +278,22 @@ func (r *dnsRecordResource) Create(
 	}
 
 	site := r.client.SiteOrDefault(plan.Site)
-	record := r.modelToAPI(&plan)
 
-	created, err := r.client.CreateDNSRecord(ctx, site, record)
-	if err != nil {
-		resp.Diagnostics.AddError("Error Creating DNS Record", err.Error())
-		return
+	var records []*unifi.DNSRecord
+	for _, value := range dnsRecordPlannedValues(&plan) {
+		created, err := r.client.CreateDNSRecord(ctx, site, r.modelToAPIWithValue(&plan, value))
+		if err != nil {
+			// Don't leave part of a round-robin record behind.
+			for _, rec := range records {
+				_ = r.client.DeleteDNSRecord(ctx, site, rec.ID)
+			}
+			resp.Diagnostics.AddError("Error Creating DNS Record", err.Error()+dnsRecordTTLHint(plan.TTL))
+			return
+		}
+		records = append(records, created)
 	}
 
-	r.apiToModel(created, &plan, site)
+	r.recordsToModel(records, &plan, site, !plan.Values.IsNull())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -250,22 +313,22 @@ func (r *dnsRecordResource) Read(
 
 	site := r.client.SiteOrDefault(state.Site)
 
-	record, err := r.client.GetDNSRecord(ctx, site, state.ID.ValueString())
+	records, err := r.getRecords(ctx, site, &state)
 	if err != nil {
-		// If the record was deleted outside Terraform, remove it from state
-		// so Terraform knows it needs to be recreated.
-		if _, ok := err.(*unifi.NotFoundError); ok {
-			resp.State.RemoveResource(ctx)
-			return
-		}
 		resp.Diagnostics.AddError(
 			"Error Reading DNS Record",
 			fmt.Sprintf("Could not read DNS record %s: %s", state.ID.ValueString(), err.Error()),
 		)
 		return
 	}
+	// If the record was deleted outside Terraform, remove it from state
+	// so Terraform knows it needs to be recreated.
+	if len(records) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	r.apiToModel(record, &state, site)
+	r.recordsToModel(records, &state, site, !state.RecordIDs.IsNull())
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -292,22 +355,94 @@ func (r *dnsRecordResource) Update(
 		return
 	}
 
-	r.applyPlanToState(&plan, &state)
-
 	site := r.client.SiteOrDefault(state.Site)
-	record := r.modelToAPI(&state)
-	record.ID = state.ID.ValueString()
 
-	updated, err := r.client.UpdateDNSRecord(ctx, site, record)
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.getRecords(ctx, site, &state)
+	if err == nil && len(current) == 0 {
+		err = &unifi.NotFoundError{}
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error Updating DNS Record", err.Error())
+		resp.Diagnostics.AddError("Error Reading DNS Record for Update", err.Error())
+		return
+	}
+	verify := state
+	r.recordsToModel(current, &verify, site, !state.RecordIDs.IsNull())
+	if !checkConcurrentChanges(&resp.Diagnostics, "DNS record", state.ID.ValueString(), &state, &verify) {
 		return
 	}
 
-	r.apiToModel(updated, &state, site)
+	roundRobin := !plan.Values.IsNull()
+	r.applyPlanToState(&plan, &state)
+	state.Value = plan.Value
+	state.Values = plan.Values
+
+	records, err := r.syncRecords(ctx, site, &state, current)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating DNS Record", err.Error()+dnsRecordTTLHint(state.TTL))
+		// Records may have been added or removed before the failure; save
+		// them so the next apply picks up where this one stopped.
+		if roundRobin || !state.RecordIDs.IsNull() {
+			r.recordsToModel(records, &state, site, true)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+		return
+	}
+
+	r.recordsToModel(records, &state, site, roundRobin)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+// ModifyPlan warns when a new record would shadow a name the controller
+// publishes for its clients. dnsmasq answers configured records before the
+// names it learns from DHCP, so such a record silently wins. It also warns
+// about TTLs that some controller versions reject.
+func (r *dnsRecordResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to check.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan dnsRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only check values that are new, so existing records don't warn on
+	// every plan.
+	var state *dnsRecordResourceModel
+	if !req.State.Raw.IsNull() {
+		state = &dnsRecordResourceModel{}
+		resp.Diagnostics.Append(req.State.Get(ctx, state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Changing a round-robin record's values adds and removes controller
+	// records, so which IDs it ends up with isn't known until apply.
+	if state != nil && !state.Values.Equal(plan.Values) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("record_ids"), types.ListUnknown(types.StringType))...)
+	}
+
+	if state == nil || !state.TTL.Equal(plan.TTL) {
+		r.checkTTL(ctx, &plan, &resp.Diagnostics)
+	}
+
+	if plan.Name.IsUnknown() || plan.RecordType.IsUnknown() {
+		return
+	}
+	if state == nil || !state.Name.Equal(plan.Name) {
+		r.checkClientShadowing(ctx, &plan, &resp.Diagnostics)
+	}
+}
+
 // Delete removes the DNS record from the UniFi controller.
 func (r *dnsRecordResource) Delete(
 	ctx context.Context,
@@ -322,9 +457,24 @@ func (r *dnsRecordResource) Delete(
 
 	site := r.client.SiteOrDefault(state.Site)
 
-	err := r.client.DeleteDNSRecord(ctx, site, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Error Deleting DNS Record", err.Error())
+	if state.RecordIDs.IsNull() {
+		err := r.client.DeleteDNSRecord(ctx, site, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Deleting DNS Record", err.Error())
+		}
+		return
+	}
+
+	// Records of a round-robin record that are already gone (for example
+	// after a partially failed delete) are skipped.
+	var ids []string
+	resp.Diagnostics.Append(state.RecordIDs.ElementsAs(ctx, &ids, false)...)
+	for _, id := range ids {
+		err := r.client.DeleteDNSRecord(ctx, site, id)
+		if _, ok := err.(*unifi.NotFoundError); err != nil && !ok {
+			resp.Diagnostics.AddError("Error Deleting DNS Record", err.Error())
+			return
+		}
 	}
 }
 
@@ -332,12 +482,25 @@ func (r *dnsRecordResource) Delete(
 // We support two formats:
 //   - "site:id" — import from a specific site
 //   - "id"      — import from the provider's default site
+//
+// The id may also be a comma-separated list of record IDs, which imports
+// them as one round-robin record.
 func (r *dnsRecordResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
 	parts := strings.SplitN(req.ID, ":", 2)
+	id := parts[len(parts)-1]
+
+	if ids := strings.Split(id, ","); len(ids) > 1 {
+		if len(parts) == 2 {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_ids"), ids)...)
+		return
+	}
 
 	if len(parts) == 2 {
 		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
@@ -350,10 +513,254 @@ func (r *dnsRecordResource) ImportState(
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// dnsRecordTXTLengthValidator rejects TXT values longer than txtrecord.MaxLength.
+type dnsRecordTXTLengthValidator struct{}
+
+func (v dnsRecordTXTLengthValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("TXT record values must be at most %d bytes.", txtrecord.MaxLength)
+}
+
+func (v dnsRecordTXTLengthValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordTXTLengthValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType, value types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("record_type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value"), &value)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if recordType.ValueString() != "TXT" || value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	if n := len(txtrecord.Decode(value.ValueString())); n > txtrecord.MaxLength {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"TXT Value Too Long",
+			fmt.Sprintf("TXT record values must be at most %d bytes, got %d.", txtrecord.MaxLength, n),
+		)
+	}
+}
+
+// dnsRecordValuesTypeValidator rejects values for record types other than A
+// and AAAA. A record without a record_type is an address record.
+type dnsRecordValuesTypeValidator struct{}
+
+func (v dnsRecordValuesTypeValidator) Description(_ context.Context) string {
+	return "values can only be set when record_type is A or AAAA."
+}
+
+func (v dnsRecordValuesTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordValuesTypeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var recordType types.String
+	var values types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("record_type"), &recordType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("values"), &values)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if values.IsNull() || recordType.IsNull() || recordType.IsUnknown() {
+		return
+	}
+
+	switch t := recordType.ValueString(); t {
+	case "A", "AAAA":
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("values"),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("values can only be set when record_type is A or AAAA, got %q. Use value instead.", t),
+		)
+	}
+}
+
+// dnsRecordNameValidator checks the record name's labels. A wildcard is only
+// accepted as the whole leading label, which is the only form dnsmasq matches.
+type dnsRecordNameValidator struct{}
+
+func (v dnsRecordNameValidator) Description(_ context.Context) string {
+	return "must be a hostname whose labels are non-empty; \"*\" is only allowed as the first label of a wildcard record"
+}
+
+func (v dnsRecordNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsRecordNameValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if err := validateDNSRecordName(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid DNS Record Name", err.Error())
+	}
+}
+
+// validateDNSRecordName returns an error describing what is wrong with name,
+// or nil if it is a usable record name.
+func validateDNSRecordName(name string) error {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		switch {
+		case label == "":
+			return fmt.Errorf("%q contains an empty label; names must not start or end with a dot or contain \"..\"", name)
+		case label == "*" && i > 0:
+			return fmt.Errorf("%q has a wildcard label that is not first; only a leading \"*.\" is supported", name)
+		case label == "*" && len(labels) == 1:
+			return fmt.Errorf("%q is a bare wildcard; write the domain it applies to, e.g. \"*.example.lan\"", name)
+		case label != "*" && strings.Contains(label, "*"):
+			return fmt.Errorf("%q has a partial wildcard label %q; \"*\" must be a whole label", name, label)
+		}
+	}
+	return nil
+}
+
 // ---------------------------------------------------------------------------
 // Helper methods
 // ---------------------------------------------------------------------------
 
+// checkClientShadowing warns when the planned record would hide a client
+// hostname the controller serves. Lookup failures are ignored; the check is
+// best-effort.
+func (r *dnsRecordResource) checkClientShadowing(ctx context.Context, plan *dnsRecordResourceModel, diags *diag.Diagnostics) {
+	switch plan.RecordType.ValueString() {
+	case "", "A", "AAAA", "CNAME":
+	default:
+		// Other types don't answer address lookups.
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	networks, err := r.client.ListNetwork(ctx, site)
+	if err != nil {
+		return
+	}
+	clients, err := r.client.ListClientDevices(ctx, site)
+	if err != nil {
+		return
+	}
+
+	var domains []string
+	for _, n := range networks {
+		if n.DomainName != nil && *n.DomainName != "" {
+			domains = append(domains, *n.DomainName)
+		}
+	}
+	if summary, detail := dnsRecordShadowWarning(plan.Name.ValueString(), domains, clients); summary != "" {
+		diags.AddAttributeWarning(path.Root("name"), summary, detail)
+	}
+}
+
+// dnsRecordMinTTL is the lowest TTL every controller version accepts. Some
+// versions reject anything shorter with a generic validation error.
+const dnsRecordMinTTL = 60
+
+// checkTTL warns when the planned TTL is below dnsRecordMinTTL. The schema
+// can't reject it outright, since newer controllers accept it. The
+// controller version is included when it can be read, to help users match
+// the warning against their release notes.
+func (r *dnsRecordResource) checkTTL(ctx context.Context, plan *dnsRecordResourceModel, diags *diag.Diagnostics) {
+	if !dnsRecordTTLBelowMin(plan.TTL) {
+		return
+	}
+
+	version := ""
+	if info, err := r.client.GetControllerSysinfo(ctx, r.client.SiteOrDefault(plan.Site)); err == nil {
+		version = info.Version
+	}
+	summary, detail := dnsRecordTTLWarning(plan.TTL.ValueInt64(), version)
+	diags.AddAttributeWarning(path.Root("ttl"), summary, detail)
+}
+
+// dnsRecordTTLBelowMin reports whether ttl is set to a value that some
+// controller versions reject. Zero means "use the default" and is always
+// accepted.
+func dnsRecordTTLBelowMin(ttl types.Int64) bool {
+	if ttl.IsNull() || ttl.IsUnknown() {
+		return false
+	}
+	v := ttl.ValueInt64()
+	return v > 0 && v < dnsRecordMinTTL
+}
+
+// dnsRecordTTLWarning returns the plan warning for a TTL below
+// dnsRecordMinTTL.
+func dnsRecordTTLWarning(ttl int64, version string) (summary, detail string) {
+	controller := "Some controller versions may"
+	if version != "" {
+		controller = fmt.Sprintf("The controller (version %s) may", version)
+	}
+	return "DNS Record TTL Below Controller Minimum",
+		fmt.Sprintf("ttl is %d seconds. %s reject TTLs below %d seconds, failing the apply. "+
+			"Use a ttl of at least %d, or omit it to use the controller's default.",
+			ttl, controller, dnsRecordMinTTL, dnsRecordMinTTL)
+}
+
+// dnsRecordTTLHint returns a suffix for create and update errors that names
+// the TTL constraint when it is the likely cause. The controller's own
+// validation error doesn't say which field it rejected.
+func dnsRecordTTLHint(ttl types.Int64) string {
+	if !dnsRecordTTLBelowMin(ttl) {
+		return ""
+	}
+	return fmt.Sprintf("\n\nttl is %d seconds; some controller versions reject TTLs below %d seconds. "+
+		"Use a ttl of at least %d, or omit it.", ttl.ValueInt64(), dnsRecordMinTTL, dnsRecordMinTTL)
+}
+
+// dnsRecordShadowWarning returns a warning for a record name that matches a
+// client's hostname (bare or under one of the network domains), or a wildcard
+// over a network domain, which matches every client on it. It returns empty
+// strings when nothing is shadowed.
+func dnsRecordShadowWarning(name string, domains []string, clients []unifi.Client) (string, string) {
+	name = strings.ToLower(name)
+
+	if base, ok := strings.CutPrefix(name, "*."); ok {
+		for _, d := range domains {
+			if strings.EqualFold(base, d) {
+				return "Wildcard DNS Record Shadows Client Hostnames",
+					fmt.Sprintf("%q covers the domain of a network, so it overrides the names the controller "+
+						"publishes for every client on it (e.g. <hostname>.%s). Use a subdomain for the wildcard "+
+						"instead.", name, d)
+			}
+		}
+		return "", ""
+	}
+
+	for _, c := range clients {
+		for _, host := range []string{c.Hostname, c.Name} {
+			if host == "" || strings.Contains(host, " ") {
+				continue
+			}
+			host = strings.ToLower(host)
+			match := name == host
+			for _, d := range domains {
+				match = match || name == host+"."+strings.ToLower(d)
+			}
+			if match {
+				return "DNS Record Shadows Client Hostname",
+					fmt.Sprintf("%q matches the name the controller publishes for client %s, so lookups will "+
+						"return this record instead of the client's address. Rename the record or the client "+
+						"if that is not intended.", name, c.MAC)
+			}
+		}
+	}
+	return "", ""
+}
+
 // applyPlanToState merges the user's planned changes into the current state.
 // For each field, if the plan has a concrete value (not null, not unknown), we
 // copy it to state. Otherwise we keep the existing state value.
@@ -389,83 +796,203 @@ func (r *dnsRecordResource) applyPlanToState(plan, state *dnsRecordResourceModel
 }
 
 // modelToAPI converts our Terraform model to the go-unifi DNSRecord struct.
-// The go-unifi SDK uses plain Go types (string, bool, int64) and pointers,
-// so we need to unwrap the Terraform wrapper types.
-//
-// Note: the API struct field names don't always match our model names:
+// The scalar fields are mapped by their `unifi` tags. Note the API field names
+// don't always match our model names:
 //   - model.Name  → API Key    (the hostname)
-//   - model.Value → API Value  (the record target)
 //   - model.TTL   → API Ttl    (different casing)
+//
+// Value is mapped by hand because TXT values are encoded.
 func (r *dnsRecordResource) modelToAPI(m *dnsRecordResourceModel) *unifi.DNSRecord {
-	rec := &unifi.DNSRecord{
-		Key:   m.Name.ValueString(),
-		Value: m.Value.ValueString(),
+	return r.modelToAPIWithValue(m, m.Value.ValueString())
+}
+
+// modelToAPIWithValue is modelToAPI for one value of a round-robin record.
+func (r *dnsRecordResource) modelToAPIWithValue(m *dnsRecordResourceModel, value string) *unifi.DNSRecord {
+	rec := &unifi.DNSRecord{}
+	mapModelToAPI(m, rec)
+	rec.Value = value
+
+	// TXT values are split into 255-byte strings and quoted so dnsmasq
+	// doesn't mangle long SPF/DKIM values or values containing commas.
+	if m.RecordType.ValueString() == "TXT" {
+		rec.Value = txtrecord.Encode(rec.Value)
 	}
 
-	if !m.Enabled.IsNull() {
-		rec.Enabled = m.Enabled.ValueBool()
+	return rec
+}
+
+// apiToModel converts the go-unifi DNSRecord struct back to our Terraform model.
+// Optional fields are zeronull, so Terraform doesn't show spurious diffs
+// (e.g., "port: 0 → null").
+func (r *dnsRecordResource) apiToModel(rec *unifi.DNSRecord, m *dnsRecordResourceModel, site string) {
+	// For TXT records, keep the configured value if it encodes to what the
+	// controller stored (the user may have quoted it by hand); otherwise
+	// report the decoded value.
+	switch {
+	case rec.RecordType != "TXT":
+		m.Value = types.StringValue(rec.Value)
+	case !m.Value.IsNull() && !m.Value.IsUnknown() && txtrecord.Encode(m.Value.ValueString()) == rec.Value:
+		// keep m.Value
+	default:
+		m.Value = types.StringValue(txtrecord.Decode(rec.Value))
 	}
 
-	// Port is a pointer in the API struct (*int64) because it's truly optional.
-	// ValueInt64Pointer returns nil when the Terraform value is null.
-	rec.Port = m.Port.ValueInt64Pointer()
+	mapAPIToModel(rec, m)
+	m.ID = types.StringValue(rec.ID)
+	m.Site = types.StringValue(site)
+	m.Values = types.SetNull(types.StringType)
+	m.RecordIDs = types.ListNull(types.StringType)
+}
+
+// ---------------------------------------------------------------------------
+// Round-robin records
+// ---------------------------------------------------------------------------
 
-	if !m.Priority.IsNull() {
-		rec.Priority = m.Priority.ValueInt64()
+// dnsRecordPlannedValues returns the values of the controller records m
+// describes: the round-robin values in sorted order, or its single value.
+func dnsRecordPlannedValues(m *dnsRecordResourceModel) []string {
+	if !m.Values.IsNull() {
+		return sortedSetStrings(m.Values)
 	}
+	return []string{m.Value.ValueString()}
+}
 
-	if !m.RecordType.IsNull() {
-		rec.RecordType = m.RecordType.ValueString()
+// getRecords reads the controller records of m. Records that no longer exist
+// are left out, so an empty result means the whole record is gone.
+func (r *dnsRecordResource) getRecords(ctx context.Context, site string, m *dnsRecordResourceModel) ([]*unifi.DNSRecord, error) {
+	ids := []string{m.ID.ValueString()}
+	if !m.RecordIDs.IsNull() {
+		ids = nil
+		m.RecordIDs.ElementsAs(ctx, &ids, false)
 	}
 
-	if !m.TTL.IsNull() {
-		rec.Ttl = m.TTL.ValueInt64()
+	var records []*unifi.DNSRecord
+	for _, id := range ids {
+		rec, err := r.client.GetDNSRecord(ctx, site, id)
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
 	}
+	return records, nil
+}
 
-	if !m.Weight.IsNull() {
-		rec.Weight = m.Weight.ValueInt64()
+// syncRecords makes the controller records current match the planned values
+// of m. Records whose value is still wanted are updated in place, as are
+// records whose value was removed, which are reused for new values, so a
+// single-value record keeps its ID when its value changes. Records are only
+// created or deleted when the number of values changes.
+//
+// It returns the records that exist afterwards. If a request fails, the
+// records returned are those known to exist at that point.
+func (r *dnsRecordResource) syncRecords(
+	ctx context.Context,
+	site string,
+	m *dnsRecordResourceModel,
+	current []*unifi.DNSRecord,
+) ([]*unifi.DNSRecord, error) {
+	values := dnsRecordPlannedValues(m)
+
+	wanted := make(map[string]bool, len(values))
+	for _, v := range values {
+		wanted[v] = true
+	}
+	existing := make(map[string]*unifi.DNSRecord, len(current))
+	var spare []*unifi.DNSRecord
+	for _, rec := range current {
+		if wanted[rec.Value] && existing[rec.Value] == nil {
+			existing[rec.Value] = rec
+		} else {
+			spare = append(spare, rec)
+		}
 	}
 
-	return rec
-}
+	// untouched returns the records not yet processed, for the error case.
+	var result []*unifi.DNSRecord
+	untouched := func(from int) []*unifi.DNSRecord {
+		records := append([]*unifi.DNSRecord{}, result...)
+		for _, v := range values[from:] {
+			if rec := existing[v]; rec != nil {
+				records = append(records, rec)
+			}
+		}
+		return append(records, spare...)
+	}
 
-// apiToModel converts the go-unifi DNSRecord struct back to our Terraform model.
-// For optional fields, we set them to types.XXXNull() when the API returns zero values,
-// so Terraform doesn't show spurious diffs (e.g., "port: 0 → null").
-func (r *dnsRecordResource) apiToModel(rec *unifi.DNSRecord, m *dnsRecordResourceModel, site string) {
-	m.ID = types.StringValue(rec.ID)
-	m.Site = types.StringValue(site)
-	m.Name = types.StringValue(rec.Key)
-	m.Value = types.StringValue(rec.Value)
-	m.Enabled = types.BoolValue(rec.Enabled)
+	for i, v := range values {
+		rec := existing[v]
+		if rec == nil && len(spare) > 0 {
+			rec, spare = spare[0], spare[1:]
+		}
 
-	if rec.Port != nil && *rec.Port != 0 {
-		m.Port = types.Int64PointerValue(rec.Port)
-	} else {
-		m.Port = types.Int64Null()
+		var err error
+		var updated *unifi.DNSRecord
+		if rec != nil {
+			desired := r.modelToAPIWithValue(m, v)
+			desired.ID = rec.ID
+			updated, err = r.client.UpdateDNSRecord(ctx, site, desired)
+			if err != nil && existing[v] == nil {
+				// The reused record wasn't processed; keep it.
+				spare = append([]*unifi.DNSRecord{rec}, spare...)
+			}
+		} else {
+			updated, err = r.client.CreateDNSRecord(ctx, site, r.modelToAPIWithValue(m, v))
+		}
+		if err != nil {
+			return untouched(i), err
+		}
+		delete(existing, v)
+		result = append(result, updated)
 	}
 
-	if rec.Priority != 0 {
-		m.Priority = types.Int64Value(rec.Priority)
-	} else {
-		m.Priority = types.Int64Null()
+	for i, rec := range spare {
+		err := r.client.DeleteDNSRecord(ctx, site, rec.ID)
+		if _, ok := err.(*unifi.NotFoundError); err != nil && !ok {
+			return append(result, spare[i:]...), err
+		}
 	}
+	return result, nil
+}
 
-	if rec.RecordType != "" {
-		m.RecordType = types.StringValue(rec.RecordType)
-	} else {
-		m.RecordType = types.StringNull()
+// recordsToModel converts the controller records of m back to the model. For
+// a single-value record it is apiToModel. For a round-robin record, the
+// shared attributes are taken from the record with the lowest value, and ID
+// is kept if that record still exists.
+func (r *dnsRecordResource) recordsToModel(records []*unifi.DNSRecord, m *dnsRecordResourceModel, site string, roundRobin bool) {
+	if !roundRobin && len(records) == 1 {
+		r.apiToModel(records[0], m, site)
+		return
 	}
 
-	if rec.Ttl != 0 {
-		m.TTL = types.Int64Value(rec.Ttl)
-	} else {
-		m.TTL = types.Int64Null()
+	sorted := append([]*unifi.DNSRecord{}, records...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Value != sorted[j].Value {
+			return sorted[i].Value < sorted[j].Value
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	id := m.ID.ValueString()
+	values := make([]string, len(sorted))
+	ids := make([]attr.Value, len(sorted))
+	keepID := false
+	for i, rec := range sorted {
+		values[i] = rec.Value
+		ids[i] = types.StringValue(rec.ID)
+		keepID = keepID || rec.ID == id
 	}
 
-	if rec.Weight != 0 {
-		m.Weight = types.Int64Value(rec.Weight)
-	} else {
-		m.Weight = types.Int64Null()
+	if len(sorted) > 0 {
+		r.apiToModel(sorted[0], m, site)
+	}
+	if keepID {
+		m.ID = types.StringValue(id)
 	}
+	m.Site = types.StringValue(site)
+	m.Value = types.StringNull()
+	m.Values = stringSetValue(values, nil)
+	m.RecordIDs = types.ListValueMust(types.StringType, ids)
 }
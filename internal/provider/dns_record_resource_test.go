@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -145,6 +148,58 @@ func TestDNSRecordAPIToModel(t *testing.T) {
 	})
 }
 
+func TestDNSRecordTXTValue(t *testing.T) {
+	r := &dnsRecordResource{}
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 300)
+
+	t.Run("modelToAPI splits and quotes long values", func(t *testing.T) {
+		rec := r.modelToAPI(&dnsRecordResourceModel{
+			Name:       types.StringValue("selector._domainkey.example.com"),
+			Value:      types.StringValue(dkim),
+			RecordType: types.StringValue("TXT"),
+		})
+		assert.True(t, strings.HasPrefix(rec.Value, `"v=DKIM1;`))
+		assert.Contains(t, rec.Value, `","`)
+	})
+
+	t.Run("modelToAPI leaves non-TXT values alone", func(t *testing.T) {
+		rec := r.modelToAPI(&dnsRecordResourceModel{
+			Name:       types.StringValue("a.example.com"),
+			Value:      types.StringValue("a,b"),
+			RecordType: types.StringValue("CNAME"),
+		})
+		assert.Equal(t, "a,b", rec.Value)
+	})
+
+	t.Run("apiToModel decodes on import", func(t *testing.T) {
+		var model dnsRecordResourceModel
+		r.apiToModel(&unifi.DNSRecord{
+			Key:        "example.com",
+			Value:      `"v=spf1 ","include:_spf.example.com ~all"`,
+			RecordType: "TXT",
+		}, &model, "default")
+		assert.Equal(t, "v=spf1 include:_spf.example.com ~all", model.Value.ValueString())
+	})
+
+	t.Run("apiToModel keeps hand-quoted config value", func(t *testing.T) {
+		quoted := `"part one","part two"`
+		model := dnsRecordResourceModel{Value: types.StringValue(quoted)}
+		r.apiToModel(&unifi.DNSRecord{Key: "example.com", Value: quoted, RecordType: "TXT"}, &model, "default")
+		assert.Equal(t, quoted, model.Value.ValueString())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		plan := dnsRecordResourceModel{
+			Name:       types.StringValue("selector._domainkey.example.com"),
+			Value:      types.StringValue(dkim),
+			RecordType: types.StringValue("TXT"),
+		}
+		rec := r.modelToAPI(&plan)
+		r.apiToModel(rec, &plan, "default")
+		assert.Equal(t, dkim, plan.Value.ValueString())
+	})
+}
+
 // TestDNSRecordApplyPlanToState verifies the merge logic that handles updates.
 // When a user changes some fields, applyPlanToState should update those fields
 // in state while leaving unchanged fields (null/unknown in the plan) alone.
@@ -202,6 +257,356 @@ func TestDNSRecordApplyPlanToState(t *testing.T) {
 	})
 }
 
+// TestValidateDNSRecordName checks where wildcards are allowed in record names.
+func TestValidateDNSRecordName(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		ok   bool
+	}{
+		{"host.example.lan", true},
+		{"_sip._tcp.example.lan", true},
+		{"*.example.lan", true},
+		{"*.lan", true},
+		{"*", false},
+		{"*.", false},
+		{"foo.*.example.lan", false},
+		{"*.*.example.lan", false},
+		{"foo*.example.lan", false},
+		{".example.lan", false},
+		{"example.lan.", false},
+		{"host..lan", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDNSRecordName(tc.name)
+			assert.Equal(t, tc.ok, err == nil, "error: %v", err)
+		})
+	}
+}
+
+// TestDNSRecordShadowWarning checks which names are reported as shadowing
+// the client hostnames the controller publishes.
+func TestDNSRecordShadowWarning(t *testing.T) {
+	domains := []string{"Example.lan"}
+	clients := []unifi.Client{
+		{MAC: "aa:bb:cc:dd:ee:01", Hostname: "printer"},
+		{MAC: "aa:bb:cc:dd:ee:02", Name: "NAS"},
+		{MAC: "aa:bb:cc:dd:ee:03", Name: "Living Room TV"},
+	}
+
+	for _, tc := range []struct {
+		name    string
+		summary string
+	}{
+		{"printer", "DNS Record Shadows Client Hostname"},
+		{"printer.example.lan", "DNS Record Shadows Client Hostname"},
+		{"nas.EXAMPLE.lan", "DNS Record Shadows Client Hostname"},
+		{"printer.other.lan", ""},
+		{"scanner.example.lan", ""},
+		{"living room tv.example.lan", ""},
+		{"*.example.lan", "Wildcard DNS Record Shadows Client Hostnames"},
+		{"*.apps.example.lan", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			summary, detail := dnsRecordShadowWarning(tc.name, domains, clients)
+			assert.Equal(t, tc.summary, summary)
+			assert.Equal(t, tc.summary == "", detail == "")
+		})
+	}
+}
+
+// TestDNSRecordTTLWarning checks which TTLs are flagged as below the
+// controller minimum and that the error hint names the constraint.
+func TestDNSRecordTTLWarning(t *testing.T) {
+	for _, tc := range []struct {
+		ttl   types.Int64
+		below bool
+	}{
+		{types.Int64Null(), false},
+		{types.Int64Unknown(), false},
+		{types.Int64Value(0), false},
+		{types.Int64Value(1), true},
+		{types.Int64Value(59), true},
+		{types.Int64Value(60), false},
+		{types.Int64Value(3600), false},
+	} {
+		t.Run(tc.ttl.String(), func(t *testing.T) {
+			assert.Equal(t, tc.below, dnsRecordTTLBelowMin(tc.ttl))
+			assert.Equal(t, tc.below, dnsRecordTTLHint(tc.ttl) != "")
+		})
+	}
+
+	assert.Contains(t, dnsRecordTTLHint(types.Int64Value(30)), "ttl is 30 seconds")
+
+	_, detail := dnsRecordTTLWarning(30, "9.0.114")
+	assert.Contains(t, detail, "version 9.0.114")
+	assert.Contains(t, detail, "below 60 seconds")
+
+	_, detail = dnsRecordTTLWarning(30, "")
+	assert.Contains(t, detail, "Some controller versions")
+}
+
+// TestDNSRecordCRUD drives the resource against the in-memory fake client.
+func TestDNSRecordCRUD(t *testing.T) {
+	plan := dnsRecordResourceModel{
+		ID:         types.StringUnknown(),
+		Site:       types.StringNull(),
+		Name:       types.StringValue("web.home"),
+		Enabled:    types.BoolValue(true),
+		Port:       types.Int64Null(),
+		Priority:   types.Int64Null(),
+		RecordType: types.StringValue("A"),
+		TTL:        types.Int64Null(),
+		Value:      types.StringValue("192.168.1.10"),
+		Values:     types.SetNull(types.StringType),
+		RecordIDs:  types.ListUnknown(types.StringType),
+		Weight:     types.Int64Null(),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		assert.Equal(t, "default", created.Site.ValueString())
+		require.Contains(t, fake.dnsRecords, created.ID.ValueString())
+		assert.Equal(t, "web.home", fake.dnsRecords[created.ID.ValueString()].Key)
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, created, read)
+
+		update := *created
+		update.Value = types.StringValue("192.168.1.11")
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, "192.168.1.11", updated.Value.ValueString())
+		assert.Equal(t, "192.168.1.11", fake.dnsRecords[created.ID.ValueString()].Value)
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dnsRecords)
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.failNext("CreateDNSRecord", errors.New("api.err.Invalid"))
+		r := &dnsRecordResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error Creating DNS Record", diags.Errors()[0].Summary())
+		assert.Nil(t, created, "nothing is saved to state")
+	})
+
+	t.Run("read removes a record deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.dnsRecords, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+
+	t.Run("read error keeps state", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		fake.failNext("GetDNSRecord", errors.New("connection reset"))
+
+		read, diags := testRead(t, r, *created)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error Reading DNS Record", diags.Errors()[0].Summary())
+		assert.Equal(t, created, read)
+	})
+
+	t.Run("update refuses to overwrite changes made on the controller", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+
+		edited := fake.dnsRecords[created.ID.ValueString()]
+		edited.Value = "192.168.1.99"
+		fake.dnsRecords[edited.ID] = edited
+
+		update := *created
+		update.Value = types.StringValue("192.168.1.11")
+		_, diags = testUpdate(t, r, *created, update)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateDNSRecord"])
+		assert.Equal(t, "192.168.1.99", fake.dnsRecords[edited.ID].Value)
+	})
+
+	t.Run("delete error", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		fake.failNext("DeleteDNSRecord", errors.New("api.err.ServerError"))
+
+		diags = testDelete(t, r, *created)
+		require.True(t, diags.HasError())
+		assert.Equal(t, "Error Deleting DNS Record", diags.Errors()[0].Summary())
+		assert.Len(t, fake.dnsRecords, 1)
+	})
+}
+
+func TestDNSRecordRoundRobin(t *testing.T) {
+	plan := dnsRecordResourceModel{
+		ID:         types.StringUnknown(),
+		Site:       types.StringNull(),
+		Name:       types.StringValue("web.home"),
+		Enabled:    types.BoolValue(true),
+		Port:       types.Int64Null(),
+		Priority:   types.Int64Null(),
+		RecordType: types.StringValue("A"),
+		TTL:        types.Int64Value(300),
+		Value:      types.StringNull(),
+		Values:     stringSet("192.168.1.12", "192.168.1.10", "192.168.1.11"),
+		RecordIDs:  types.ListUnknown(types.StringType),
+		Weight:     types.Int64Null(),
+	}
+
+	// valuesByID returns the value of each record on the fake controller.
+	valuesByID := func(fake *fakeClient) map[string]string {
+		values := map[string]string{}
+		for id, rec := range fake.dnsRecords {
+			values[id] = rec.Value
+		}
+		return values
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		require.Len(t, fake.dnsRecords, 3)
+		for _, rec := range fake.dnsRecords {
+			assert.Equal(t, "web.home", rec.Key)
+			assert.Equal(t, int64(300), rec.Ttl)
+		}
+		assert.True(t, created.Value.IsNull())
+		assert.Equal(t, []string{"192.168.1.10", "192.168.1.11", "192.168.1.12"}, sortedSetStrings(created.Values))
+		require.Len(t, created.RecordIDs.Elements(), 3)
+		assert.Equal(t, created.RecordIDs.Elements()[0], created.ID)
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, created, read)
+
+		// Replace .12 with .13 and raise the TTL: the record for .12 is
+		// reused, so no record is created or deleted.
+		before := valuesByID(fake)
+		update := *created
+		update.Values = stringSet("192.168.1.10", "192.168.1.11", "192.168.1.13")
+		update.TTL = types.Int64Value(600)
+		update.ID = types.StringUnknown()
+		update.RecordIDs = types.ListUnknown(types.StringType)
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, created.ID, updated.ID)
+		assert.Equal(t, []string{"192.168.1.10", "192.168.1.11", "192.168.1.13"}, sortedSetStrings(updated.Values))
+		after := valuesByID(fake)
+		require.Len(t, after, 3)
+		for id, v := range before {
+			if v == "192.168.1.12" {
+				assert.Equal(t, "192.168.1.13", after[id])
+			} else {
+				assert.Equal(t, v, after[id])
+			}
+		}
+		for _, rec := range fake.dnsRecords {
+			assert.Equal(t, int64(600), rec.Ttl)
+		}
+
+		// Shrinking to a single value deletes the other records.
+		single := *updated
+		single.Value = types.StringValue("192.168.1.11")
+		single.Values = types.SetNull(types.StringType)
+		single.ID = types.StringUnknown()
+		single.RecordIDs = types.ListUnknown(types.StringType)
+		final, diags := testUpdate(t, r, *updated, single)
+		require.False(t, diags.HasError(), "update to single value: %v", diags)
+		require.Len(t, fake.dnsRecords, 1)
+		assert.Equal(t, "192.168.1.11", final.Value.ValueString())
+		assert.True(t, final.Values.IsNull())
+		assert.True(t, final.RecordIDs.IsNull())
+		assert.Equal(t, "192.168.1.11", fake.dnsRecords[final.ID.ValueString()].Value)
+
+		diags = testDelete(t, r, *final)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dnsRecords)
+	})
+
+	t.Run("single value grows into round robin", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		single := plan
+		single.Value = types.StringValue("192.168.1.10")
+		single.Values = types.SetNull(types.StringType)
+		created, diags := testCreate(t, r, single)
+		require.False(t, diags.HasError(), "create: %v", diags)
+
+		update := *created
+		update.Value = types.StringNull()
+		update.Values = stringSet("192.168.1.10", "192.168.1.11")
+		update.RecordIDs = types.ListUnknown(types.StringType)
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, created.ID, updated.ID, "existing record is kept")
+		assert.Len(t, updated.RecordIDs.Elements(), 2)
+		assert.Len(t, fake.dnsRecords, 2)
+	})
+
+	t.Run("create error removes records already created", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		fake.failNext("CreateDNSRecord", nil, nil, errors.New("api.err.Invalid"))
+
+		created, diags := testCreate(t, r, plan)
+		require.True(t, diags.HasError())
+		assert.Nil(t, created)
+		assert.Empty(t, fake.dnsRecords)
+	})
+
+	t.Run("read drops records deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.dnsRecords, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Equal(t, []string{"192.168.1.11", "192.168.1.12"}, sortedSetStrings(read.Values))
+		assert.NotEqual(t, created.ID, read.ID)
+
+		fake.dnsRecords = map[string]unifi.DNSRecord{}
+		read, diags = testRead(t, r, *read)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+
+	t.Run("delete skips records already gone", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dnsRecordResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.dnsRecords, created.ID.ValueString())
+
+		diags = testDelete(t, r, *created)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dnsRecords)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests — require TF_ACC=1 and a UniFi controller (Docker or hardware)
 // ---------------------------------------------------------------------------
@@ -600,3 +1005,208 @@ resource "terrifi_dns_record" "test" {
 		},
 	})
 }
+
+func TestAccDNSRecord_txtLongValue(t *testing.T) {
+	name := fmt.Sprintf("tfacc-txt-%s.home", randomSuffix())
+	value := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8A", 12)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = %q
+  value       = %q
+  record_type = "TXT"
+}
+`, name, value),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "value", value),
+				),
+			},
+			{
+				ResourceName:      "terrifi_dns_record.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_validationTXTTooLong(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = "tfacc-txt-too-long.home"
+  value       = %q
+  record_type = "TXT"
+}
+`, strings.Repeat("a", 4001)),
+				ExpectError: regexp.MustCompile(`TXT Value Too Long`),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_validationTTL(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dns_record" "test" {
+  name  = "tfacc-negative-ttl.home"
+  value = "192.168.1.203"
+  ttl   = -1
+}
+`,
+				ExpectError: regexp.MustCompile(`must be between 0 and 65535`),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_wildcard(t *testing.T) {
+	name := fmt.Sprintf("*.tfacc-wildcard-%s.home", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = %q
+  value       = "192.168.1.201"
+  record_type = "A"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "value", "192.168.1.201"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_validationName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dns_record" "test" {
+  name  = "tfacc.*.home"
+  value = "192.168.1.202"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid DNS Record Name`),
+			},
+		},
+	})
+}
+
+// TestAccDNSRecord_roundRobin tests a record with several values: growing and
+// shrinking the set, and importing the controller records as one resource.
+func TestAccDNSRecord_roundRobin(t *testing.T) {
+	name := fmt.Sprintf("tfacc-rr-%s.home", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = %q
+  values      = ["192.168.1.221", "192.168.1.222"]
+  record_type = "A"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_dns_record.test", "value"),
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "values.#", "2"),
+					resource.TestCheckTypeSetElemAttr("terrifi_dns_record.test", "values.*", "192.168.1.221"),
+					resource.TestCheckTypeSetElemAttr("terrifi_dns_record.test", "values.*", "192.168.1.222"),
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "record_ids.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = %q
+  values      = ["192.168.1.221", "192.168.1.223", "192.168.1.224"]
+  record_type = "A"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "values.#", "3"),
+					resource.TestCheckTypeSetElemAttr("terrifi_dns_record.test", "values.*", "192.168.1.224"),
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "record_ids.#", "3"),
+				),
+			},
+			{
+				ResourceName: "terrifi_dns_record.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["terrifi_dns_record.test"]
+					ids := make([]string, 3)
+					for i := range ids {
+						ids[i] = rs.Primary.Attributes[fmt.Sprintf("record_ids.%d", i)]
+					}
+					return strings.Join(ids, ","), nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dns_record" "test" {
+  name        = %q
+  value       = "192.168.1.223"
+  record_type = "A"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dns_record.test", "value", "192.168.1.223"),
+					resource.TestCheckNoResourceAttr("terrifi_dns_record.test", "values.#"),
+					resource.TestCheckNoResourceAttr("terrifi_dns_record.test", "record_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDNSRecord_validationValues(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dns_record" "test" {
+  name        = "tfacc-rr-cname.home"
+  values      = ["a.home", "b.home"]
+  record_type = "CNAME"
+}
+`,
+				ExpectError: regexp.MustCompile(`values can only be set when record_type is A or AAAA`),
+			},
+			{
+				Config: `
+resource "terrifi_dns_record" "test" {
+  name   = "tfacc-rr-both.home"
+  value  = "192.168.1.221"
+  values = ["192.168.1.222"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package provider
+
+// TODO(go-unifi): The SDK's DpiGroup and DpiApp structs tag their list fields
+// (dpiapp_ids, apps, cats) omitempty, so an update can't empty them: the field
+// is left out and the controller keeps the stored list. When the SDK always
+// sends them, this file can be deleted.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// dpiGroupUpdateRequest is the payload for PUT api/s/{site}/rest/dpigroup/{id}.
+// DPIappIDs shadows the embedded field so that it is always sent.
+type dpiGroupUpdateRequest struct {
+	unifi.DpiGroup
+	DPIappIDs []string `json:"dpiapp_ids"`
+}
+
+// dpiAppUpdateRequest is the payload for PUT api/s/{site}/rest/dpiapp/{id}.
+// Apps and Cats shadow the embedded fields so that they are always sent.
+type dpiAppUpdateRequest struct {
+	unifi.DpiApp
+	Apps []int64 `json:"apps"`
+	Cats []int64 `json:"cats"`
+}
+
+// UpdateDpiGroup updates a DPI restriction group via the v1 REST API,
+// bypassing the SDK so that removing every restriction empties the group.
+// This method shadows the SDK's promoted UpdateDpiGroup on ApiClient.
+func (c *Client) UpdateDpiGroup(ctx context.Context, site string, d *unifi.DpiGroup) (*unifi.DpiGroup, error) {
+	payload := dpiGroupUpdateRequest{DpiGroup: *d, DPIappIDs: d.DPIappIDs}
+	if payload.DPIappIDs == nil {
+		payload.DPIappIDs = []string{}
+	}
+
+	var group unifi.DpiGroup
+	err := c.doDpiRequest(ctx,
+		fmt.Sprintf("%s%s/api/s/%s/rest/dpigroup/%s", c.BaseURL, c.APIPath, site, d.ID),
+		payload, &group)
+	if err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// UpdateDpiApp updates a DPI restriction via the v1 REST API, bypassing the
+// SDK so that removing all apps or all categories clears them.
+// This method shadows the SDK's promoted UpdateDpiApp on ApiClient.
+func (c *Client) UpdateDpiApp(ctx context.Context, site string, d *unifi.DpiApp) (*unifi.DpiApp, error) {
+	payload := dpiAppUpdateRequest{DpiApp: *d, Apps: d.Apps, Cats: d.Cats}
+	if payload.Apps == nil {
+		payload.Apps = []int64{}
+	}
+	if payload.Cats == nil {
+		payload.Cats = []int64{}
+	}
+
+	var app unifi.DpiApp
+	err := c.doDpiRequest(ctx,
+		fmt.Sprintf("%s%s/api/s/%s/rest/dpiapp/%s", c.BaseURL, c.APIPath, site, d.ID),
+		payload, &app)
+	if err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
+
+// doDpiRequest PUTs body to a DPI endpoint and decodes the single object in
+// the response into result.
+func (c *Client) doDpiRequest(ctx context.Context, url string, body, result any) error {
+	var respBody struct {
+		Meta json.RawMessage   `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := c.doV1Request(ctx, http.MethodPut, url, body, &respBody); err != nil {
+		return err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return err
+	}
+	if len(respBody.Data) != 1 {
+		return &unifi.NotFoundError{}
+	}
+	return json.Unmarshal(respBody.Data[0], result)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestUpdateDpiGroup(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"g1","name":"Kids","enabled":true}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	updated, err := client.UpdateDpiGroup(context.Background(), "default", &unifi.DpiGroup{ID: "g1", Name: "Kids", Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "g1", updated.ID)
+	assert.Equal(t, "/proxy/network/api/s/default/rest/dpigroup/g1", gotPath)
+	// An empty group is sent as [] so the controller clears the stored list.
+	assert.Equal(t, []any{}, gotBody["dpiapp_ids"])
+	assert.Equal(t, "Kids", gotBody["name"])
+}
+
+func TestUpdateDpiApp(t *testing.T) {
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"d1","name":"No games","apps":[589885]}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	updated, err := client.UpdateDpiApp(context.Background(), "default", &unifi.DpiApp{ID: "d1", Name: "No games", Apps: []int64{589885}})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{589885}, updated.Apps)
+	assert.Equal(t, []any{float64(589885)}, gotBody["apps"])
+	assert.Equal(t, []any{}, gotBody["cats"])
+}
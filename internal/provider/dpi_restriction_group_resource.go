@@ -0,0 +1,299 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                = &dpiRestrictionGroupResource{}
+	_ resource.ResourceWithImportState = &dpiRestrictionGroupResource{}
+)
+
+// NewDPIRestrictionGroupResource is the factory function registered in provider.Resources().
+func NewDPIRestrictionGroupResource() resource.Resource {
+	return &dpiRestrictionGroupResource{}
+}
+
+// dpiRestrictionGroupResource holds the API client, injected by Configure().
+type dpiRestrictionGroupResource struct {
+	client ClientAPI
+}
+
+// dpiRestrictionGroupResourceModel is the Terraform-side representation of a
+// legacy DPI restriction group (the controller's "dpigroup"), the set of
+// restrictions that a network or WLAN enforces.
+type dpiRestrictionGroupResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Site           types.String `tfsdk:"site"`
+	Name           types.String `tfsdk:"name"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	RestrictionIDs types.Set    `tfsdk:"restriction_ids"`
+}
+
+// Metadata sets the resource type name.
+func (r *dpiRestrictionGroupResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dpi_restriction_group"
+}
+
+// Schema defines the HCL schema for the terrifi_dpi_restriction_group resource.
+func (r *dpiRestrictionGroupResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a DPI restriction group, a named set of `terrifi_dpi_restriction`s. A " +
+			"group takes effect once it is applied to a network or WLAN, which is done in the UniFi UI. This is " +
+			"the legacy DPI API, for controllers that haven't migrated to traffic rules; on current controllers " +
+			"use `terrifi_traffic_rule` instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the restriction group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the restriction group with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the restriction group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the group's restrictions are enforced. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"restriction_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the `terrifi_dpi_restriction`s in the group.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *dpiRestrictionGroupResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new DPI restriction group.
+func (r *dpiRestrictionGroupResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan dpiRestrictionGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateDpiGroup(ctx, site, r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating DPI Restriction Group", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *dpiRestrictionGroupResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state dpiRestrictionGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	group, err := r.client.GetDpiGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading DPI Restriction Group",
+			fmt.Sprintf("Could not read DPI restriction group %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(group, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing DPI restriction group.
+func (r *dpiRestrictionGroupResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan dpiRestrictionGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetDpiGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading DPI Restriction Group for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "DPI restriction group", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	// Start from the current group so the controller's own attributes are
+	// sent back unchanged.
+	group := *current
+	desired := r.modelToAPI(ctx, &plan)
+	group.Name = desired.Name
+	group.Enabled = desired.Enabled
+	group.DPIappIDs = desired.DPIappIDs
+
+	updated, err := r.client.UpdateDpiGroup(ctx, site, &group)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating DPI Restriction Group", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the DPI restriction group from the UniFi controller.
+func (r *dpiRestrictionGroupResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state dpiRestrictionGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteDpiGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting DPI Restriction Group", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_dpi_restriction_group.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *dpiRestrictionGroupResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI converts our Terraform model to the go-unifi DpiGroup struct.
+func (r *dpiRestrictionGroupResource) modelToAPI(ctx context.Context, m *dpiRestrictionGroupResourceModel) *unifi.DpiGroup {
+	return &unifi.DpiGroup{
+		Name:      m.Name.ValueString(),
+		Enabled:   m.Enabled.ValueBool(),
+		DPIappIDs: setStrings(ctx, m.RestrictionIDs),
+	}
+}
+
+// apiToModel converts the go-unifi DpiGroup struct back to our Terraform
+// model.
+func (r *dpiRestrictionGroupResource) apiToModel(group *unifi.DpiGroup, m *dpiRestrictionGroupResourceModel, site string) {
+	m.ID = types.StringValue(group.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(group.Name)
+	m.Enabled = types.BoolValue(group.Enabled)
+	m.RestrictionIDs = stringSetOrNull(group.DPIappIDs)
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestDPIRestrictionGroupModelRoundTrip(t *testing.T) {
+	r := &dpiRestrictionGroupResource{}
+
+	tests := []struct {
+		name  string
+		model dpiRestrictionGroupResourceModel
+	}{
+		{
+			name: "with restrictions",
+			model: dpiRestrictionGroupResourceModel{
+				Name:           types.StringValue("Kids"),
+				Enabled:        types.BoolValue(true),
+				RestrictionIDs: stringSet("d1", "d2"),
+			},
+		},
+		{
+			name: "disabled",
+			model: dpiRestrictionGroupResourceModel{
+				Name:           types.StringValue("Guests"),
+				Enabled:        types.BoolValue(false),
+				RestrictionIDs: stringSet("d3"),
+			},
+		},
+		{
+			name: "no restrictions",
+			model: dpiRestrictionGroupResourceModel{
+				Name:           types.StringValue("Empty"),
+				Enabled:        types.BoolValue(true),
+				RestrictionIDs: types.SetNull(types.StringType),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group := r.modelToAPI(context.Background(), &tt.model)
+			group.ID = "g1"
+
+			var got dpiRestrictionGroupResourceModel
+			r.apiToModel(group, &got, "default")
+
+			want := tt.model
+			want.ID = types.StringValue("g1")
+			want.Site = types.StringValue("default")
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestDPIRestrictionGroupCRUD(t *testing.T) {
+	plan := dpiRestrictionGroupResourceModel{
+		ID:             types.StringUnknown(),
+		Site:           types.StringNull(),
+		Name:           types.StringValue("Kids"),
+		Enabled:        types.BoolValue(true),
+		RestrictionIDs: stringSet("d1", "d2"),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionGroupResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		assert.ElementsMatch(t, []string{"d1", "d2"}, fake.dpiGroups[id].DPIappIDs)
+
+		// Removing every restriction leaves the group empty.
+		update := *created
+		update.RestrictionIDs = types.SetNull(types.StringType)
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Empty(t, fake.dpiGroups[id].DPIappIDs)
+		assert.True(t, updated.RestrictionIDs.IsNull())
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dpiGroups)
+	})
+
+	t.Run("update refuses concurrent changes", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		group := fake.dpiGroups[id]
+		group.DPIappIDs = []string{"d1"}
+		fake.dpiGroups[id] = group
+
+		update := *created
+		update.Name = types.StringValue("Teens")
+		_, diags = testUpdate(t, r, *created, update)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateDpiGroup"])
+	})
+
+	t.Run("read removes a group deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.dpiGroups, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccDPIRestrictionGroup_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-dpig-%s", randomSuffix())
+
+	config := func(group string) string {
+		return fmt.Sprintf(`
+resource "terrifi_dpi_restriction" "games" {
+  name             = "%[1]s-games"
+  app_category_ids = [4]
+}
+
+resource "terrifi_dpi_restriction" "video" {
+  name             = "%[1]s-video"
+  app_category_ids = [5]
+}
+
+resource "terrifi_dpi_restriction_group" "test" {
+  name = %[1]q
+%[2]s}
+`, name, group)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  restriction_ids = [terrifi_dpi_restriction.games.id, terrifi_dpi_restriction.video.id]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_dpi_restriction_group.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "restriction_ids.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_dpi_restriction_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Disabling the group and dropping one restriction.
+			{
+				Config: config(`
+  enabled         = false
+  restriction_ids = [terrifi_dpi_restriction.games.id]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "restriction_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("terrifi_dpi_restriction_group.test", "restriction_ids.*", "terrifi_dpi_restriction.games", "id"),
+				),
+			},
+			// Removing every restriction leaves an empty group.
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "enabled", "true"),
+					resource.TestCheckNoResourceAttr("terrifi_dpi_restriction_group.test", "restriction_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDPIRestrictionGroup_importSiteID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dpi_restriction_group" "test" {
+  name = "tfacc-dpig-%s"
+}
+`, randomSuffix()),
+			},
+			{
+				ResourceName:      "terrifi_dpi_restriction_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["terrifi_dpi_restriction_group.test"]
+					if rs == nil {
+						return "", fmt.Errorf("resource not found in state")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["site"], rs.Primary.Attributes["id"]), nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,335 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                = &dpiRestrictionResource{}
+	_ resource.ResourceWithImportState = &dpiRestrictionResource{}
+)
+
+// NewDPIRestrictionResource is the factory function registered in provider.Resources().
+func NewDPIRestrictionResource() resource.Resource {
+	return &dpiRestrictionResource{}
+}
+
+// dpiRestrictionResource holds the API client, injected by Configure().
+type dpiRestrictionResource struct {
+	client ClientAPI
+}
+
+// dpiRestrictionResourceModel is the Terraform-side representation of a
+// legacy DPI restriction (the controller's "dpiapp"), which blocks a set of
+// apps and app categories for the networks its group is applied to.
+type dpiRestrictionResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Site           types.String `tfsdk:"site"`
+	Name           types.String `tfsdk:"name"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Blocked        types.Bool   `tfsdk:"blocked"`
+	Log            types.Bool   `tfsdk:"log"`
+	AppIDs         types.Set    `tfsdk:"app_ids"`
+	AppCategoryIDs types.Set    `tfsdk:"app_category_ids"`
+}
+
+// Metadata sets the resource type name.
+func (r *dpiRestrictionResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dpi_restriction"
+}
+
+// Schema defines the HCL schema for the terrifi_dpi_restriction resource.
+func (r *dpiRestrictionResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a DPI restriction, which blocks a set of apps and app categories. " +
+			"Restrictions take effect through a `terrifi_dpi_restriction_group`. This is the legacy DPI API, " +
+			"for controllers that haven't migrated to traffic rules; on current controllers use " +
+			"`terrifi_traffic_rule` instead.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the restriction.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the restriction with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the restriction.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the restriction is enforced. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"blocked": schema.BoolAttribute{
+				MarkdownDescription: "Whether matching traffic is blocked. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"log": schema.BoolAttribute{
+				MarkdownDescription: "Whether matching traffic is logged. Default: `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+
+			"app_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the DPI apps to restrict (e.g. `589885` for YouTube). At least one of " +
+					"`app_ids` and `app_category_ids` is required.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.AtLeastOneOf(path.MatchRoot("app_category_ids")),
+				},
+			},
+
+			"app_category_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the DPI app categories to restrict (e.g. `4` for games).",
+				ElementType:         types.Int64Type,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *dpiRestrictionResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new DPI restriction.
+func (r *dpiRestrictionResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan dpiRestrictionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateDpiApp(ctx, site, r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating DPI Restriction", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *dpiRestrictionResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state dpiRestrictionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	app, err := r.client.GetDpiApp(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading DPI Restriction",
+			fmt.Sprintf("Could not read DPI restriction %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(app, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing DPI restriction.
+func (r *dpiRestrictionResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan dpiRestrictionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetDpiApp(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading DPI Restriction for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "DPI restriction", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	// Start from the current restriction so attributes the provider doesn't
+	// manage, such as its bandwidth limits, are sent back unchanged.
+	app := *current
+	desired := r.modelToAPI(ctx, &plan)
+	app.Name = desired.Name
+	app.Enabled = desired.Enabled
+	app.Blocked = desired.Blocked
+	app.Log = desired.Log
+	app.Apps = desired.Apps
+	app.Cats = desired.Cats
+
+	updated, err := r.client.UpdateDpiApp(ctx, site, &app)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating DPI Restriction", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the DPI restriction from the UniFi controller.
+func (r *dpiRestrictionResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state dpiRestrictionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteDpiApp(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting DPI Restriction", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_dpi_restriction.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *dpiRestrictionResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI converts our Terraform model to the go-unifi DpiApp struct.
+func (r *dpiRestrictionResource) modelToAPI(ctx context.Context, m *dpiRestrictionResourceModel) *unifi.DpiApp {
+	return &unifi.DpiApp{
+		Name:    m.Name.ValueString(),
+		Enabled: m.Enabled.ValueBool(),
+		Blocked: m.Blocked.ValueBool(),
+		Log:     m.Log.ValueBool(),
+		Apps:    setInt64s(ctx, m.AppIDs),
+		Cats:    setInt64s(ctx, m.AppCategoryIDs),
+	}
+}
+
+// apiToModel converts the go-unifi DpiApp struct back to our Terraform model.
+func (r *dpiRestrictionResource) apiToModel(app *unifi.DpiApp, m *dpiRestrictionResourceModel, site string) {
+	m.ID = types.StringValue(app.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(app.Name)
+	m.Enabled = types.BoolValue(app.Enabled)
+	m.Blocked = types.BoolValue(app.Blocked)
+	m.Log = types.BoolValue(app.Log)
+	m.AppIDs = int64SetOrNull(app.Apps)
+	m.AppCategoryIDs = int64SetOrNull(app.Cats)
+}
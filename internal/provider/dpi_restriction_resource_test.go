@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestDPIRestrictionModelToAPI(t *testing.T) {
+	r := &dpiRestrictionResource{}
+
+	app := r.modelToAPI(context.Background(), &dpiRestrictionResourceModel{
+		Name:           types.StringValue("No games"),
+		Enabled:        types.BoolValue(true),
+		Blocked:        types.BoolValue(true),
+		Log:            types.BoolValue(false),
+		AppIDs:         types.SetNull(types.Int64Type),
+		AppCategoryIDs: int64Set(4),
+	})
+
+	assert.Equal(t, "No games", app.Name)
+	assert.True(t, app.Enabled)
+	assert.True(t, app.Blocked)
+	assert.False(t, app.Log)
+	assert.Nil(t, app.Apps)
+	assert.Equal(t, []int64{4}, app.Cats)
+}
+
+func TestDPIRestrictionAPIToModel(t *testing.T) {
+	r := &dpiRestrictionResource{}
+
+	var m dpiRestrictionResourceModel
+	r.apiToModel(&unifi.DpiApp{ID: "d1", Name: "No video", Enabled: true, Blocked: true, Log: true, Apps: []int64{589885}}, &m, "default")
+
+	assert.Equal(t, "d1", m.ID.ValueString())
+	assert.Equal(t, "default", m.Site.ValueString())
+	assert.Equal(t, "No video", m.Name.ValueString())
+	assert.True(t, m.Log.ValueBool())
+	assert.Equal(t, int64Set(589885), m.AppIDs)
+	assert.True(t, m.AppCategoryIDs.IsNull())
+}
+
+func TestDPIRestrictionCRUD(t *testing.T) {
+	plan := dpiRestrictionResourceModel{
+		ID:             types.StringUnknown(),
+		Site:           types.StringNull(),
+		Name:           types.StringValue("No games"),
+		Enabled:        types.BoolValue(true),
+		Blocked:        types.BoolValue(true),
+		Log:            types.BoolValue(false),
+		AppIDs:         types.SetNull(types.Int64Type),
+		AppCategoryIDs: int64Set(4),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		assert.Equal(t, []int64{4}, fake.dpiApps[id].Cats)
+
+		update := *created
+		update.AppIDs = int64Set(589885)
+		update.AppCategoryIDs = types.SetNull(types.Int64Type)
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, []int64{589885}, fake.dpiApps[id].Apps)
+		assert.Empty(t, fake.dpiApps[id].Cats)
+		assert.True(t, updated.AppCategoryIDs.IsNull())
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.dpiApps)
+	})
+
+	t.Run("update keeps bandwidth limits", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		app := fake.dpiApps[id]
+		rate := int64(1000)
+		app.QOSRateMaxDown = &rate
+		fake.dpiApps[id] = app
+
+		update := *created
+		update.Name = types.StringValue("No more games")
+		_, diags = testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, "No more games", fake.dpiApps[id].Name)
+		require.NotNil(t, fake.dpiApps[id].QOSRateMaxDown)
+		assert.Equal(t, int64(1000), *fake.dpiApps[id].QOSRateMaxDown)
+	})
+
+	t.Run("update refuses concurrent changes", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		app := fake.dpiApps[id]
+		app.Blocked = false
+		fake.dpiApps[id] = app
+
+		update := *created
+		update.Name = types.StringValue("No more games")
+		_, diags = testUpdate(t, r, *created, update)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateDpiApp"])
+	})
+
+	t.Run("read removes a restriction deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &dpiRestrictionResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.dpiApps, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccDPIRestriction_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-dpi-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dpi_restriction" "test" {
+  name             = %q
+  app_category_ids = [4]
+}
+
+resource "terrifi_dpi_restriction_group" "test" {
+  name            = %q
+  restriction_ids = [terrifi_dpi_restriction.test.id]
+}
+`, name, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_dpi_restriction.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "blocked", "true"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "log", "false"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "app_category_ids.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction_group.test", "restriction_ids.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_dpi_restriction.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "terrifi_dpi_restriction_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_dpi_restriction" "test" {
+  name    = %q
+  app_ids = [589885]
+  log     = true
+}
+
+resource "terrifi_dpi_restriction_group" "test" {
+  name = %q
+}
+`, name, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_dpi_restriction.test", "app_category_ids.#"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "app_ids.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_dpi_restriction.test", "log", "true"),
+					resource.TestCheckNoResourceAttr("terrifi_dpi_restriction_group.test", "restriction_ids.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDPIRestriction_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_dpi_restriction" "test" {
+  name = "tfacc-invalid"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
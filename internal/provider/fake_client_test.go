@@ -0,0 +1,634 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// fakeClient is an in-memory ClientAPI for unit testing resource CRUD logic
+// without a controller. It stores AP groups, client devices, DNS records,
+// DPI restrictions and groups, firewall groups, client groups, user groups,
+// port profiles, traffic rules, firewall zones, (read-only) firewall policies, device
+// locate states, (read-only) devices and gateway uplinks, which networks are
+// exposed to site-to-site VPNs, and client fingerprints; calling any other
+// method panics through the nil embedded ClientAPI, so a test fails loudly
+// if a resource starts using something the fake doesn't model yet.
+//
+// Errors can be queued per method with failNext to exercise error paths.
+type fakeClient struct {
+	ClientAPI
+
+	site        string
+	nextID      int
+	warnUnzoned bool
+
+	apGroups       map[string]unifi.APGroup
+	clients        map[string]unifi.Client
+	dnsRecords     map[string]unifi.DNSRecord
+	dpiApps        map[string]unifi.DpiApp
+	dpiGroups      map[string]unifi.DpiGroup
+	firewallGroups map[string]unifi.FirewallGroup
+	clientGroups   map[string]unifi.NetworkMembersGroup
+	userGroups     map[string]unifi.ClientGroup
+	portProfiles   map[string]unifi.PortProfile
+	trafficRules   map[string]trafficRule
+	zones          map[string]unifi.FirewallZone
+	policies       map[string]unifi.FirewallPolicy
+	locating       map[string]bool // device MAC → LED blinking; absent MACs are unknown devices
+	devices        []unifi.Device
+	gatewayWANs    map[string]gatewayWANStatus
+	siteVPNExposed map[string]bool                        // network ID → exposed to site-to-site VPNs; absent IDs are unknown networks
+	fingerprints   map[string]unifi.ClientInfoFingerprint // client MAC → fingerprint; absent MACs are unknown clients
+
+	errs  map[string][]error // method name → errors to return, in order
+	calls map[string]int     // method name → number of calls
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		site:           "default",
+		apGroups:       map[string]unifi.APGroup{},
+		clients:        map[string]unifi.Client{},
+		dnsRecords:     map[string]unifi.DNSRecord{},
+		dpiApps:        map[string]unifi.DpiApp{},
+		dpiGroups:      map[string]unifi.DpiGroup{},
+		firewallGroups: map[string]unifi.FirewallGroup{},
+		clientGroups:   map[string]unifi.NetworkMembersGroup{},
+		userGroups:     map[string]unifi.ClientGroup{},
+		portProfiles:   map[string]unifi.PortProfile{},
+		trafficRules:   map[string]trafficRule{},
+		zones:          map[string]unifi.FirewallZone{},
+		policies:       map[string]unifi.FirewallPolicy{},
+		locating:       map[string]bool{},
+		gatewayWANs:    map[string]gatewayWANStatus{},
+		siteVPNExposed: map[string]bool{},
+		fingerprints:   map[string]unifi.ClientInfoFingerprint{},
+		errs:           map[string][]error{},
+		calls:          map[string]int{},
+	}
+}
+
+// failNext makes the next len(errs) calls to method return errs, in order.
+func (f *fakeClient) failNext(method string, errs ...error) {
+	f.errs[method] = append(f.errs[method], errs...)
+}
+
+// call records a call to method and returns its next queued error, if any.
+func (f *fakeClient) call(method string) error {
+	f.calls[method]++
+	if len(f.errs[method]) == 0 {
+		return nil
+	}
+	err := f.errs[method][0]
+	f.errs[method] = f.errs[method][1:]
+	return err
+}
+
+func (f *fakeClient) newID() string {
+	f.nextID++
+	return fmt.Sprintf("fake-%d", f.nextID)
+}
+
+func (f *fakeClient) SiteOrDefault(site types.String) string {
+	if v := site.ValueString(); v != "" {
+		return v
+	}
+	return f.site
+}
+
+func (f *fakeClient) WarnUnzonedNetworks() bool {
+	return f.warnUnzoned
+}
+
+// fakeGet, fakeUpdate, and fakeDelete implement the common CRUD semantics on
+// one of the fake's tables: unknown IDs are a *unifi.NotFoundError, and
+// callers get copies so they can't modify the stored objects.
+
+func fakeGet[T any](f *fakeClient, method string, items map[string]T, id string) (*T, error) {
+	if err := f.call(method); err != nil {
+		return nil, err
+	}
+	item, ok := items[id]
+	if !ok {
+		return nil, &unifi.NotFoundError{}
+	}
+	return &item, nil
+}
+
+func fakeUpdate[T any](f *fakeClient, method string, items map[string]T, id string, d *T) (*T, error) {
+	if err := f.call(method); err != nil {
+		return nil, err
+	}
+	if _, ok := items[id]; !ok {
+		return nil, &unifi.NotFoundError{}
+	}
+	items[id] = *d
+	item := *d
+	return &item, nil
+}
+
+func fakeDelete[T any](f *fakeClient, method string, items map[string]T, id string) error {
+	if err := f.call(method); err != nil {
+		return err
+	}
+	if _, ok := items[id]; !ok {
+		return &unifi.NotFoundError{}
+	}
+	delete(items, id)
+	return nil
+}
+
+// AP groups
+
+func (f *fakeClient) GetAPGroup(_ context.Context, _, id string) (*unifi.APGroup, error) {
+	return fakeGet(f, "GetAPGroup", f.apGroups, id)
+}
+
+func (f *fakeClient) CreateAPGroup(_ context.Context, _ string, d *unifi.APGroup) (*unifi.APGroup, error) {
+	if err := f.call("CreateAPGroup"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.apGroups[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateAPGroup(_ context.Context, _ string, d *unifi.APGroup) (*unifi.APGroup, error) {
+	return fakeUpdate(f, "UpdateAPGroup", f.apGroups, d.ID, d)
+}
+
+func (f *fakeClient) DeleteAPGroup(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteAPGroup", f.apGroups, id)
+}
+
+// DNS records
+
+func (f *fakeClient) ListDNSRecord(_ context.Context, _ string) ([]unifi.DNSRecord, error) {
+	if err := f.call("ListDNSRecord"); err != nil {
+		return nil, err
+	}
+	records := make([]unifi.DNSRecord, 0, len(f.dnsRecords))
+	for _, r := range f.dnsRecords {
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func (f *fakeClient) GetDNSRecord(_ context.Context, _, id string) (*unifi.DNSRecord, error) {
+	return fakeGet(f, "GetDNSRecord", f.dnsRecords, id)
+}
+
+func (f *fakeClient) CreateDNSRecord(_ context.Context, _ string, d *unifi.DNSRecord) (*unifi.DNSRecord, error) {
+	if err := f.call("CreateDNSRecord"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.dnsRecords[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateDNSRecord(_ context.Context, _ string, d *unifi.DNSRecord) (*unifi.DNSRecord, error) {
+	return fakeUpdate(f, "UpdateDNSRecord", f.dnsRecords, d.ID, d)
+}
+
+func (f *fakeClient) DeleteDNSRecord(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteDNSRecord", f.dnsRecords, id)
+}
+
+// DPI restrictions
+
+func (f *fakeClient) GetDpiApp(_ context.Context, _, id string) (*unifi.DpiApp, error) {
+	return fakeGet(f, "GetDpiApp", f.dpiApps, id)
+}
+
+func (f *fakeClient) CreateDpiApp(_ context.Context, _ string, d *unifi.DpiApp) (*unifi.DpiApp, error) {
+	if err := f.call("CreateDpiApp"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.dpiApps[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateDpiApp(_ context.Context, _ string, d *unifi.DpiApp) (*unifi.DpiApp, error) {
+	return fakeUpdate(f, "UpdateDpiApp", f.dpiApps, d.ID, d)
+}
+
+func (f *fakeClient) DeleteDpiApp(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteDpiApp", f.dpiApps, id)
+}
+
+func (f *fakeClient) GetDpiGroup(_ context.Context, _, id string) (*unifi.DpiGroup, error) {
+	return fakeGet(f, "GetDpiGroup", f.dpiGroups, id)
+}
+
+func (f *fakeClient) CreateDpiGroup(_ context.Context, _ string, d *unifi.DpiGroup) (*unifi.DpiGroup, error) {
+	if err := f.call("CreateDpiGroup"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.dpiGroups[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateDpiGroup(_ context.Context, _ string, d *unifi.DpiGroup) (*unifi.DpiGroup, error) {
+	return fakeUpdate(f, "UpdateDpiGroup", f.dpiGroups, d.ID, d)
+}
+
+func (f *fakeClient) DeleteDpiGroup(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteDpiGroup", f.dpiGroups, id)
+}
+
+// Firewall groups
+
+func (f *fakeClient) ListFirewallGroup(_ context.Context, _ string) ([]unifi.FirewallGroup, error) {
+	if err := f.call("ListFirewallGroup"); err != nil {
+		return nil, err
+	}
+	groups := make([]unifi.FirewallGroup, 0, len(f.firewallGroups))
+	for _, g := range f.firewallGroups {
+		groups = append(groups, g)
+	}
+	return groups, nil
+}
+
+func (f *fakeClient) GetFirewallGroup(_ context.Context, _, id string) (*unifi.FirewallGroup, error) {
+	return fakeGet(f, "GetFirewallGroup", f.firewallGroups, id)
+}
+
+func (f *fakeClient) CreateFirewallGroup(_ context.Context, _ string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error) {
+	if err := f.call("CreateFirewallGroup"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.firewallGroups[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateFirewallGroup(_ context.Context, _ string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error) {
+	return fakeUpdate(f, "UpdateFirewallGroup", f.firewallGroups, d.ID, d)
+}
+
+func (f *fakeClient) DeleteFirewallGroup(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteFirewallGroup", f.firewallGroups, id)
+}
+
+// Client groups
+
+func (f *fakeClient) GetNetworkMembersGroup(_ context.Context, _ string, id string) (*unifi.NetworkMembersGroup, error) {
+	return fakeGet(f, "GetNetworkMembersGroup", f.clientGroups, id)
+}
+
+func (f *fakeClient) CreateNetworkMembersGroup(_ context.Context, _ string, d *unifi.NetworkMembersGroup) (*unifi.NetworkMembersGroup, error) {
+	if err := f.call("CreateNetworkMembersGroup"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.clientGroups[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateNetworkMembersGroup(_ context.Context, _ string, d *unifi.NetworkMembersGroup) (*unifi.NetworkMembersGroup, error) {
+	return fakeUpdate(f, "UpdateNetworkMembersGroup", f.clientGroups, d.ID, d)
+}
+
+func (f *fakeClient) DeleteNetworkMembersGroup(_ context.Context, _ string, id string) error {
+	return fakeDelete(f, "DeleteNetworkMembersGroup", f.clientGroups, id)
+}
+
+// User groups
+
+func (f *fakeClient) GetClientGroup(_ context.Context, _, id string) (*unifi.ClientGroup, error) {
+	return fakeGet(f, "GetClientGroup", f.userGroups, id)
+}
+
+func (f *fakeClient) CreateClientGroup(_ context.Context, _ string, d *unifi.ClientGroup) (*unifi.ClientGroup, error) {
+	if err := f.call("CreateClientGroup"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.userGroups[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateClientGroup(_ context.Context, _ string, d *unifi.ClientGroup) (*unifi.ClientGroup, error) {
+	return fakeUpdate(f, "UpdateClientGroup", f.userGroups, d.ID, d)
+}
+
+func (f *fakeClient) DeleteClientGroup(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteClientGroup", f.userGroups, id)
+}
+
+// Port profiles
+
+func (f *fakeClient) GetPortProfile(_ context.Context, _, id string) (*unifi.PortProfile, error) {
+	return fakeGet(f, "GetPortProfile", f.portProfiles, id)
+}
+
+func (f *fakeClient) CreatePortProfile(_ context.Context, _ string, d *unifi.PortProfile) (*unifi.PortProfile, error) {
+	if err := f.call("CreatePortProfile"); err != nil {
+		return nil, err
+	}
+	created := *d
+	created.ID = f.newID()
+	f.portProfiles[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdatePortProfile(_ context.Context, _ string, d *unifi.PortProfile) (*unifi.PortProfile, error) {
+	return fakeUpdate(f, "UpdatePortProfile", f.portProfiles, d.ID, d)
+}
+
+func (f *fakeClient) DeletePortProfile(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeletePortProfile", f.portProfiles, id)
+}
+
+// Traffic rules
+
+func (f *fakeClient) ListTrafficRule(_ context.Context, _ string) ([]trafficRule, error) {
+	if err := f.call("ListTrafficRule"); err != nil {
+		return nil, err
+	}
+	rules := make([]trafficRule, 0, len(f.trafficRules))
+	for _, r := range f.trafficRules {
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
+
+func (f *fakeClient) GetTrafficRule(_ context.Context, _, id string) (*trafficRule, error) {
+	return fakeGet(f, "GetTrafficRule", f.trafficRules, id)
+}
+
+func (f *fakeClient) CreateTrafficRule(_ context.Context, _ string, rule *trafficRule) (*trafficRule, error) {
+	if err := f.call("CreateTrafficRule"); err != nil {
+		return nil, err
+	}
+	created := *rule
+	created.ID = f.newID()
+	f.trafficRules[created.ID] = created
+	return &created, nil
+}
+
+func (f *fakeClient) UpdateTrafficRule(_ context.Context, _ string, rule *trafficRule) (*trafficRule, error) {
+	return fakeUpdate(f, "UpdateTrafficRule", f.trafficRules, rule.ID, rule)
+}
+
+func (f *fakeClient) DeleteTrafficRule(_ context.Context, _, id string) error {
+	return fakeDelete(f, "DeleteTrafficRule", f.trafficRules, id)
+}
+
+// Client devices
+
+func (f *fakeClient) GetClientDevice(_ context.Context, _ string, id string) (*unifi.Client, error) {
+	return fakeGet(f, "GetClientDevice", f.clients, id)
+}
+
+func (f *fakeClient) UpdateClientDevice(_ context.Context, _ string, d *unifi.Client) (*unifi.Client, error) {
+	return fakeUpdate(f, "UpdateClientDevice", f.clients, d.ID, d)
+}
+
+// Client fingerprints
+
+func (f *fakeClient) GetClientFingerprint(_ context.Context, _, mac string) (*unifi.ClientInfoFingerprint, error) {
+	return fakeGet(f, "GetClientFingerprint", f.fingerprints, mac)
+}
+
+// GetFingerprintOverride returns the client's device type override, or 0
+// when it has none.
+func (f *fakeClient) GetFingerprintOverride(_ context.Context, _, mac string) (int64, error) {
+	fp, err := fakeGet(f, "GetFingerprintOverride", f.fingerprints, mac)
+	if err != nil {
+		return 0, err
+	}
+	if fp.DevIdOverride == nil {
+		return 0, nil
+	}
+	return *fp.DevIdOverride, nil
+}
+
+// SetClientFingerprintOverride stores the override as the client's
+// fingerprint, as the controller reports overridden values in place of the
+// identified ones.
+func (f *fakeClient) SetClientFingerprintOverride(_ context.Context, _ string, d *clientFingerprintOverride) error {
+	if err := f.call("SetClientFingerprintOverride"); err != nil {
+		return err
+	}
+	fp, ok := f.fingerprints[d.MAC]
+	if !ok {
+		return &unifi.NotFoundError{}
+	}
+	fp.DevIdOverride = d.DevIDOverride
+	fp.HasOverride = true
+	fp.DevCat, fp.DevFamily, fp.OsName = d.DevCat, d.DevFamily, d.OSName
+	f.fingerprints[d.MAC] = fp
+	return nil
+}
+
+// SetFingerprintOverride only models clearing (deviceTypeID 0), which drops
+// every override.
+func (f *fakeClient) SetFingerprintOverride(_ context.Context, _ string, mac string, deviceTypeID int64) error {
+	if err := f.call("SetFingerprintOverride"); err != nil {
+		return err
+	}
+	if deviceTypeID != 0 {
+		return fmt.Errorf("fake: setting a device type override is not modeled")
+	}
+	fp, ok := f.fingerprints[mac]
+	if !ok {
+		return &unifi.NotFoundError{}
+	}
+	f.fingerprints[mac] = unifi.ClientInfoFingerprint{DevId: fp.DevId}
+	return nil
+}
+
+// Devices
+
+func (f *fakeClient) ListDevice(_ context.Context, _ string) ([]unifi.Device, error) {
+	if err := f.call("ListDevice"); err != nil {
+		return nil, err
+	}
+	return append([]unifi.Device(nil), f.devices...), nil
+}
+
+func (f *fakeClient) SetDeviceLocate(_ context.Context, _, mac string, enabled bool) error {
+	if err := f.call("SetDeviceLocate"); err != nil {
+		return err
+	}
+	if _, ok := f.locating[mac]; !ok {
+		return &unifi.NotFoundError{}
+	}
+	f.locating[mac] = enabled
+	return nil
+}
+
+func (f *fakeClient) GetDeviceLocating(_ context.Context, _, mac string) (bool, error) {
+	if err := f.call("GetDeviceLocating"); err != nil {
+		return false, err
+	}
+	locating, ok := f.locating[mac]
+	if !ok {
+		return false, &unifi.NotFoundError{}
+	}
+	return locating, nil
+}
+
+// Firewall zones
+
+func (f *fakeClient) ListFirewallZone(_ context.Context, _ string) ([]unifi.FirewallZone, error) {
+	if err := f.call("ListFirewallZone"); err != nil {
+		return nil, err
+	}
+	zones := make([]unifi.FirewallZone, 0, len(f.zones))
+	for _, z := range f.zones {
+		zones = append(zones, z)
+	}
+	return zones, nil
+}
+
+func (f *fakeClient) GetFirewallZone(_ context.Context, _ string, id string) (*unifi.FirewallZone, error) {
+	return fakeGet(f, "GetFirewallZone", f.zones, id)
+}
+
+func (f *fakeClient) UpdateFirewallZone(_ context.Context, _ string, d *unifi.FirewallZone) (*unifi.FirewallZone, error) {
+	return fakeUpdate(f, "UpdateFirewallZone", f.zones, d.ID, d)
+}
+
+// Networks
+
+func (f *fakeClient) GetGatewayWANs(_ context.Context, _ string) (map[string]gatewayWANStatus, error) {
+	if err := f.call("GetGatewayWANs"); err != nil {
+		return nil, err
+	}
+	wans := make(map[string]gatewayWANStatus, len(f.gatewayWANs))
+	for k, v := range f.gatewayWANs {
+		wans[k] = v
+	}
+	return wans, nil
+}
+
+// Site VPNs
+
+func (f *fakeClient) SetNetworkExposedToSiteVPN(_ context.Context, _, id string, exposed bool) error {
+	if err := f.call("SetNetworkExposedToSiteVPN"); err != nil {
+		return err
+	}
+	if _, ok := f.siteVPNExposed[id]; !ok {
+		return &unifi.NotFoundError{}
+	}
+	f.siteVPNExposed[id] = exposed
+	return nil
+}
+
+// Firewall policies
+
+func (f *fakeClient) ListFirewallPolicies(_ context.Context, _ string) ([]*unifi.FirewallPolicy, error) {
+	if err := f.call("ListFirewallPolicies"); err != nil {
+		return nil, err
+	}
+	policies := make([]*unifi.FirewallPolicy, 0, len(f.policies))
+	for _, p := range f.policies {
+		policies = append(policies, &p)
+	}
+	return policies, nil
+}
+
+// ---------------------------------------------------------------------------
+// CRUD harness
+//
+// These call a resource's CRUD methods the way Terraform would, with plans
+// and state built from the resource's model type M.
+// ---------------------------------------------------------------------------
+
+func testResourceSchema(t *testing.T, r fwresource.Resource) fwresource.SchemaResponse {
+	t.Helper()
+	var resp fwresource.SchemaResponse
+	r.Schema(context.Background(), fwresource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), "schema: %v", resp.Diagnostics)
+	return resp
+}
+
+func testState[M any](t *testing.T, r fwresource.Resource, m *M) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	sch := testResourceSchema(t, r).Schema
+	state := tfsdk.State{Schema: sch, Raw: tftypes.NewValue(sch.Type().TerraformType(ctx), nil)}
+	if m != nil {
+		diags := state.Set(ctx, m)
+		require.False(t, diags.HasError(), "state: %v", diags)
+	}
+	return state
+}
+
+func testPlan[M any](t *testing.T, r fwresource.Resource, m *M) tfsdk.Plan {
+	t.Helper()
+	state := testState(t, r, m)
+	return tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+}
+
+// testModel reads the model back out of state. It returns nil if the
+// resource was removed from state.
+func testModel[M any](t *testing.T, state tfsdk.State) *M {
+	t.Helper()
+	if state.Raw.IsNull() {
+		return nil
+	}
+	var m M
+	diags := state.Get(context.Background(), &m)
+	require.False(t, diags.HasError(), "reading state: %v", diags)
+	return &m
+}
+
+func testCreate[M any](t *testing.T, r fwresource.Resource, plan M) (*M, diag.Diagnostics) {
+	t.Helper()
+	p := testPlan(t, r, &plan)
+	req := fwresource.CreateRequest{Plan: p, Config: tfsdk.Config{Schema: p.Schema, Raw: p.Raw}}
+	resp := fwresource.CreateResponse{State: testState[M](t, r, nil)}
+	r.Create(context.Background(), req, &resp)
+	return testModel[M](t, resp.State), resp.Diagnostics
+}
+
+func testRead[M any](t *testing.T, r fwresource.Resource, state M) (*M, diag.Diagnostics) {
+	t.Helper()
+	s := testState(t, r, &state)
+	resp := fwresource.ReadResponse{State: s}
+	r.Read(context.Background(), fwresource.ReadRequest{State: s}, &resp)
+	return testModel[M](t, resp.State), resp.Diagnostics
+}
+
+func testUpdate[M any](t *testing.T, r fwresource.Resource, state, plan M) (*M, diag.Diagnostics) {
+	t.Helper()
+	p := testPlan(t, r, &plan)
+	req := fwresource.UpdateRequest{
+		State:  testState(t, r, &state),
+		Plan:   p,
+		Config: tfsdk.Config{Schema: p.Schema, Raw: p.Raw},
+	}
+	resp := fwresource.UpdateResponse{State: tfsdk.State{Schema: p.Schema, Raw: p.Raw}}
+	r.Update(context.Background(), req, &resp)
+	return testModel[M](t, resp.State), resp.Diagnostics
+}
+
+func testDelete[M any](t *testing.T, r fwresource.Resource, state M) diag.Diagnostics {
+	t.Helper()
+	s := testState(t, r, &state)
+	resp := fwresource.DeleteResponse{State: s}
+	r.Delete(context.Background(), fwresource.DeleteRequest{State: s}, &resp)
+	return resp.Diagnostics
+}
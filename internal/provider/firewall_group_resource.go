@@ -31,7 +31,7 @@ func NewFirewallGroupResource() resource.Resource {
 
 // firewallGroupResource holds the API client, injected by Configure().
 type firewallGroupResource struct {
-	client *Client
+	client ClientAPI
 }
 
 // firewallGroupResourceModel is the Terraform-side representation of a firewall group.
@@ -203,9 +203,22 @@ func (r *firewallGroupResource) Update(
 		return
 	}
 
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetFirewallGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Firewall Group for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "firewall group", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
 	r.applyPlanToState(&plan, &state)
 
-	site := r.client.SiteOrDefault(state.Site)
 	group, diags := r.modelToAPI(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
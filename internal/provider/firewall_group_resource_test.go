@@ -188,6 +188,62 @@ func TestFirewallGroupApplyPlanToState(t *testing.T) {
 	})
 }
 
+func TestFirewallGroupCRUD(t *testing.T) {
+	members := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("80"), types.StringValue("443")})
+	plan := firewallGroupResourceModel{
+		ID:      types.StringUnknown(),
+		Site:    types.StringNull(),
+		Name:    types.StringValue("Web Ports"),
+		Type:    types.StringValue("port-group"),
+		Members: members,
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &firewallGroupResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		assert.ElementsMatch(t, []string{"80", "443"}, fake.firewallGroups[id].GroupMembers)
+
+		update := *created
+		update.Members = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("8443")})
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.True(t, update.Members.Equal(updated.Members))
+		assert.Equal(t, []string{"8443"}, fake.firewallGroups[id].GroupMembers)
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.firewallGroups)
+	})
+
+	t.Run("read removes a group deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &firewallGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.firewallGroups, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+
+	t.Run("update of a group deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &firewallGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.firewallGroups, created.ID.ValueString())
+
+		_, diags = testUpdate(t, r, *created, *created)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateFirewallGroup"])
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests — require TF_ACC=1 and a UniFi controller (Docker or hardware)
 // ---------------------------------------------------------------------------
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &firewallPoliciesDataSource{}
+
+func NewFirewallPoliciesDataSource() datasource.DataSource {
+	return &firewallPoliciesDataSource{}
+}
+
+type firewallPoliciesDataSource struct {
+	client ClientAPI
+}
+
+type firewallPoliciesDataSourceModel struct {
+	Site     types.String                 `tfsdk:"site"`
+	Action   types.String                 `tfsdk:"action"`
+	ZoneID   types.String                 `tfsdk:"zone_id"`
+	Enabled  types.Bool                   `tfsdk:"enabled"`
+	Policies []firewallPolicySummaryModel `tfsdk:"policies"`
+}
+
+type firewallPolicySummaryModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Description       types.String `tfsdk:"description"`
+	Enabled           types.Bool   `tfsdk:"enabled"`
+	Action            types.String `tfsdk:"action"`
+	Protocol          types.String `tfsdk:"protocol"`
+	IPVersion         types.String `tfsdk:"ip_version"`
+	Index             types.Int64  `tfsdk:"index"`
+	SourceZoneID      types.String `tfsdk:"source_zone_id"`
+	DestinationZoneID types.String `tfsdk:"destination_zone_id"`
+	Logging           types.Bool   `tfsdk:"logging"`
+	Predefined        types.Bool   `tfsdk:"predefined"`
+}
+
+// firewallPolicyFilter selects policies for the data source. Empty strings
+// and a nil Enabled match everything.
+type firewallPolicyFilter struct {
+	Action  string
+	ZoneID  string
+	Enabled *bool
+}
+
+func (d *firewallPoliciesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_policies"
+}
+
+func (d *firewallPoliciesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the zone-based firewall policies on a site, including predefined ones and ones " +
+			"not managed by Terraform. Useful for auditing the firewall or finding the IDs of policies to reference " +
+			"in `terrifi_firewall_policy_order`.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to list. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"action": schema.StringAttribute{
+				MarkdownDescription: "Only include policies with this action: `ALLOW`, `BLOCK` or `REJECT`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALLOW", "BLOCK", "REJECT"),
+				},
+			},
+
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "Only include policies whose source or destination is this firewall zone.",
+				Optional:            true,
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Only include enabled (`true`) or disabled (`false`) policies.",
+				Optional:            true,
+			},
+
+			"policies": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching policies, ordered by source zone, destination zone and index, " +
+					"which is the order the gateway evaluates them in.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the policy.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the policy.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the policy. Null if not set.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is enabled.",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "The action taken on matching traffic: `ALLOW`, `BLOCK` or `REJECT`.",
+							Computed:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "The protocol the policy matches, e.g. `all` or `tcp_udp`.",
+							Computed:            true,
+						},
+						"ip_version": schema.StringAttribute{
+							MarkdownDescription: "The IP version the policy matches: `BOTH`, `IPV4` or `IPV6`.",
+							Computed:            true,
+						},
+						"index": schema.Int64Attribute{
+							MarkdownDescription: "The position of the policy within its zone pair. Null if the " +
+								"controller did not report one.",
+							Computed: true,
+						},
+						"source_zone_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the source firewall zone.",
+							Computed:            true,
+						},
+						"destination_zone_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the destination firewall zone.",
+							Computed:            true,
+						},
+						"logging": schema.BoolAttribute{
+							MarkdownDescription: "Whether matching traffic is logged.",
+							Computed:            true,
+						},
+						"predefined": schema.BoolAttribute{
+							MarkdownDescription: "Whether the policy is one of the controller's built-in policies, " +
+								"which can't be managed with `terrifi_firewall_policy`.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *firewallPoliciesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *firewallPoliciesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config firewallPoliciesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	policies, err := d.client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Firewall Policies",
+			fmt.Sprintf("Could not list firewall policies in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	filter := firewallPolicyFilter{
+		Action: config.Action.ValueString(),
+		ZoneID: config.ZoneID.ValueString(),
+	}
+	if !config.Enabled.IsNull() {
+		enabled := config.Enabled.ValueBool()
+		filter.Enabled = &enabled
+	}
+
+	config.Site = types.StringValue(site)
+	config.Policies = firewallPoliciesToModels(filterFirewallPolicies(policies, filter))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// filterFirewallPolicies returns the policies matching every set field of f.
+func filterFirewallPolicies(policies []*unifi.FirewallPolicy, f firewallPolicyFilter) []*unifi.FirewallPolicy {
+	var result []*unifi.FirewallPolicy
+	for _, p := range policies {
+		if f.Action != "" && p.Action != f.Action {
+			continue
+		}
+		if f.Enabled != nil && p.Enabled != *f.Enabled {
+			continue
+		}
+		if f.ZoneID != "" {
+			src, dst := firewallPolicyZones(p)
+			if src != f.ZoneID && dst != f.ZoneID {
+				continue
+			}
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// firewallPoliciesToModels converts policies to the data source model,
+// ordered by zone pair and then index. Policies without an index sort last
+// within their zone pair, and ties are broken by ID so the list is stable
+// across reads.
+func firewallPoliciesToModels(policies []*unifi.FirewallPolicy) []firewallPolicySummaryModel {
+	sorted := make([]*unifi.FirewallPolicy, len(policies))
+	copy(sorted, policies)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		iSrc, iDst := firewallPolicyZones(sorted[i])
+		jSrc, jDst := firewallPolicyZones(sorted[j])
+		if iSrc != jSrc {
+			return iSrc < jSrc
+		}
+		if iDst != jDst {
+			return iDst < jDst
+		}
+		iIdx, jIdx := sorted[i].Index, sorted[j].Index
+		if (iIdx == nil) != (jIdx == nil) {
+			return iIdx != nil
+		}
+		if iIdx != nil && *iIdx != *jIdx {
+			return *iIdx < *jIdx
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	models := make([]firewallPolicySummaryModel, len(sorted))
+	for i, p := range sorted {
+		src, dst := firewallPolicyZones(p)
+		models[i] = firewallPolicySummaryModel{
+			ID:                types.StringValue(p.ID),
+			Name:              types.StringValue(p.Name),
+			Description:       stringValueOrNull(p.Description),
+			Enabled:           types.BoolValue(p.Enabled),
+			Action:            types.StringValue(p.Action),
+			Protocol:          types.StringValue(p.Protocol),
+			IPVersion:         types.StringValue(p.IPVersion),
+			Index:             types.Int64PointerValue(p.Index),
+			SourceZoneID:      types.StringValue(src),
+			DestinationZoneID: types.StringValue(dst),
+			Logging:           types.BoolValue(p.Logging),
+			Predefined:        types.BoolValue(p.Predefined),
+		}
+	}
+	return models
+}
+
+// firewallPolicyZones returns a policy's source and destination zone IDs,
+// which are empty if the controller omitted the endpoint.
+func firewallPolicyZones(p *unifi.FirewallPolicy) (string, string) {
+	var src, dst string
+	if p.Source != nil {
+		src = p.Source.ZoneID
+	}
+	if p.Destination != nil {
+		dst = p.Destination.ZoneID
+	}
+	return src, dst
+}
@@ -0,0 +1,191 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func testPolicy(id, action string, enabled bool, src, dst string, index *int64) *unifi.FirewallPolicy {
+	return &unifi.FirewallPolicy{
+		ID:          id,
+		Name:        "policy " + id,
+		Action:      action,
+		Enabled:     enabled,
+		Index:       index,
+		Source:      &unifi.FirewallPolicySource{ZoneID: src},
+		Destination: &unifi.FirewallPolicyDestination{ZoneID: dst},
+	}
+}
+
+func policyIDs(policies []*unifi.FirewallPolicy) []string {
+	ids := make([]string, len(policies))
+	for i, p := range policies {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestFilterFirewallPolicies(t *testing.T) {
+	policies := []*unifi.FirewallPolicy{
+		testPolicy("a", "ALLOW", true, "internal", "external", nil),
+		testPolicy("b", "BLOCK", true, "iot", "internal", nil),
+		testPolicy("c", "BLOCK", false, "guest", "external", nil),
+		{ID: "d", Action: "REJECT", Enabled: true},
+	}
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name   string
+		filter firewallPolicyFilter
+		want   []string
+	}{
+		{"no filter", firewallPolicyFilter{}, []string{"a", "b", "c", "d"}},
+		{"action", firewallPolicyFilter{Action: "BLOCK"}, []string{"b", "c"}},
+		{"zone matches source or destination", firewallPolicyFilter{ZoneID: "internal"}, []string{"a", "b"}},
+		{"enabled", firewallPolicyFilter{Enabled: &enabled}, []string{"a", "b", "d"}},
+		{"disabled", firewallPolicyFilter{Enabled: &disabled}, []string{"c"}},
+		{"combined", firewallPolicyFilter{Action: "BLOCK", ZoneID: "external", Enabled: &disabled}, []string{"c"}},
+		{"no match", firewallPolicyFilter{Action: "REJECT", ZoneID: "internal"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterFirewallPolicies(policies, tt.filter)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, policyIDs(got))
+		})
+	}
+}
+
+func TestFirewallPoliciesToModels(t *testing.T) {
+	idx := func(i int64) *int64 { return &i }
+	models := firewallPoliciesToModels([]*unifi.FirewallPolicy{
+		testPolicy("no-index", "ALLOW", true, "internal", "external", nil),
+		testPolicy("second", "ALLOW", true, "internal", "external", idx(10001)),
+		testPolicy("other-pair", "BLOCK", true, "iot", "internal", idx(10000)),
+		testPolicy("first", "BLOCK", false, "internal", "external", idx(10000)),
+	})
+
+	ids := make([]string, len(models))
+	for i, m := range models {
+		ids[i] = m.ID.ValueString()
+	}
+	assert.Equal(t, []string{"first", "second", "no-index", "other-pair"}, ids)
+
+	first := models[0]
+	assert.Equal(t, "policy first", first.Name.ValueString())
+	assert.True(t, first.Description.IsNull())
+	assert.False(t, first.Enabled.ValueBool())
+	assert.Equal(t, "BLOCK", first.Action.ValueString())
+	assert.Equal(t, int64(10000), first.Index.ValueInt64())
+	assert.Equal(t, "internal", first.SourceZoneID.ValueString())
+	assert.Equal(t, "external", first.DestinationZoneID.ValueString())
+	assert.True(t, models[2].Index.IsNull())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccFirewallPoliciesDataSource_filters(t *testing.T) {
+	zone1Name := fmt.Sprintf("tfacc-pols-z1-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-pols-z2-%s", randomSuffix())
+	policyName := fmt.Sprintf("tfacc-pols-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFirewallPolicyZonesConfig(zone1Name, zone2Name) + fmt.Sprintf(`
+resource "terrifi_firewall_policy" "test" {
+  name   = %q
+  action = "BLOCK"
+
+  source {
+    zone_id = terrifi_firewall_zone.zone1.id
+  }
+
+  destination {
+    zone_id = terrifi_firewall_zone.zone2.id
+  }
+}
+
+data "terrifi_firewall_policies" "block" {
+  zone_id    = terrifi_firewall_zone.zone1.id
+  action     = "BLOCK"
+  depends_on = [terrifi_firewall_policy.test]
+}
+
+data "terrifi_firewall_policies" "disabled" {
+  zone_id    = terrifi_firewall_zone.zone1.id
+  enabled    = false
+  depends_on = [terrifi_firewall_policy.test]
+}
+`, policyName),
+				// Creating a zone also creates predefined policies between it and
+				// the built-in zones, so the lists aren't checked for length.
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.terrifi_firewall_policies.block", "policies.*", map[string]string{
+						"name":       policyName,
+						"action":     "BLOCK",
+						"enabled":    "true",
+						"predefined": "false",
+					}),
+					resource.TestCheckTypeSetElemAttrPair(
+						"data.terrifi_firewall_policies.block", "policies.*.id",
+						"terrifi_firewall_policy.test", "id",
+					),
+					testCheckNoPolicyNamed("data.terrifi_firewall_policies.disabled", policyName),
+				),
+			},
+		},
+	})
+}
+
+// testCheckNoPolicyNamed checks that a terrifi_firewall_policies data source
+// did not return a policy with the given name.
+func testCheckNoPolicyNamed(dataSource, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[dataSource]
+		if !ok {
+			return fmt.Errorf("%s not found in state", dataSource)
+		}
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "policies.") && strings.HasSuffix(k, ".name") && v == name {
+				return fmt.Errorf("%s unexpectedly includes policy %q", dataSource, name)
+			}
+		}
+		return nil
+	}
+}
+
+func TestAccFirewallPoliciesDataSource_invalidAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "terrifi_firewall_policies" "test" {
+  action = "DROP"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
@@ -3,7 +3,7 @@ package provider
 // TODO(go-unifi): This file works around bugs in the go-unifi SDK for firewall
 // policy CRUD operations. When the upstream SDK fixes these issues, this file
 // can be deleted and the resource can use the SDK's built-in methods directly
-// (c.ApiClient.Create/Update/DeleteFirewallPolicy). The bugs are:
+// (Create/Update/DeleteFirewallPolicy). The bugs are:
 //
 //  1. SDK's DeleteFirewallPolicy only treats HTTP 200 as success. The v2
 //     firewall policy DELETE endpoint returns 204 No Content on success, which
@@ -23,17 +23,63 @@ package provider
 //     but the v2 API returns `port` as a JSON string (e.g. "443"). The SDK
 //     fails to unmarshal this, breaking all GET/list operations.
 //     Fix needed in SDK: use json.Number or a custom unmarshaler for port.
+//
+//  5. SDK decodes the whole policy list with a single json.Unmarshal, so one
+//     field whose type changes in a controller release fails every read.
+//     We decode each policy separately and tolerate type mismatches; see
+//     decodeFirewallPolicies.
+//     Fix needed in SDK: decode leniently, or skip fields it can't decode.
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	"runtime/debug"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
+// logWorkaround logs a warning, once per provider run, that firewall policies
+// are read and written through the requests in this file rather than the SDK.
+// It includes the controller and SDK versions so behavior differences can be
+// correlated with them, and so we can tell when the workarounds can retire.
+func (c *Client) logWorkaround(ctx context.Context, operation string) {
+	c.workaroundLogged.Do(func() {
+		controllerVersion := "unknown"
+		if sdk, _ := c.sdkClient(); sdk != nil && sdk.Version() != "" {
+			controllerVersion = sdk.Version()
+		}
+		tflog.Warn(ctx, "Using the provider's own v2 requests for firewall policies instead of the go-unifi SDK", map[string]any{
+			"operation":          operation,
+			"controller_version": controllerVersion,
+			"sdk_version":        goUnifiVersion(),
+			"workarounds":        "see TODO(go-unifi) in internal/provider/firewall_policy_api.go",
+		})
+	})
+}
+
+// goUnifiVersion returns the version of the go-unifi module the provider was
+// built with, or "unknown" if the build has no module information.
+func goUnifiVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == "github.com/ubiquiti-community/go-unifi" {
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
+
 // firewallPolicyCreateRequest is the payload for POST /v2/api/site/{site}/firewall-policies.
 // Uses a bespoke struct to control omitempty on boolean and slice fields.
 type firewallPolicyCreateRequest struct {
@@ -103,6 +149,7 @@ type firewallPolicyFull struct {
 // the schedule payload instead of deriving it from d.Schedule, allowing callers
 // to include fields (e.g. date_range_start, date_range_end) not in the SDK struct.
 func (c *Client) CreateFirewallPolicy(ctx context.Context, site string, d *unifi.FirewallPolicy, schedOverride *firewallPolicyScheduleRequest) (*firewallPolicyFull, error) {
+	c.logWorkaround(ctx, "create")
 	payload := buildFirewallPolicyCreateRequest(d, schedOverride)
 
 	var result firewallPolicyResponse
@@ -119,6 +166,7 @@ func (c *Client) CreateFirewallPolicy(ctx context.Context, site string, d *unifi
 // SDK to include _id in the PUT body and control boolean serialization. schedOverride,
 // when non-nil, is used as the schedule payload instead of deriving it from d.Schedule.
 func (c *Client) UpdateFirewallPolicy(ctx context.Context, site string, d *unifi.FirewallPolicy, schedOverride *firewallPolicyScheduleRequest) (*firewallPolicyFull, error) {
+	c.logWorkaround(ctx, "update")
 	create := buildFirewallPolicyCreateRequest(d, schedOverride)
 	payload := firewallPolicyUpdateRequest{
 		ID:                          d.ID,
@@ -135,9 +183,37 @@ func (c *Client) UpdateFirewallPolicy(ctx context.Context, site string, d *unifi
 	return result.toFull(), nil
 }
 
+// SetFirewallPolicyEnabled enables or disables a firewall policy without
+// sending the provider's view of the rest of the policy. It reads the
+// controller's current JSON for the policy, changes only `enabled`, and PUTs
+// that back. Fields edited in the UI since the last refresh, and fields the
+// provider does not model, are preserved.
+func (c *Client) SetFirewallPolicyEnabled(ctx context.Context, site string, id string, enabled bool) error {
+	c.logWorkaround(ctx, "set_enabled")
+	var rawPolicies []map[string]any
+	err := c.doV2Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies", c.BaseURL, c.APIPath, site),
+		struct{}{}, &rawPolicies)
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range rawPolicies {
+		if raw["_id"] != id {
+			continue
+		}
+		raw["enabled"] = enabled
+		return c.doV2Request(ctx, http.MethodPut,
+			fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies/%s", c.BaseURL, c.APIPath, site, id),
+			raw, nil)
+	}
+	return &unifi.NotFoundError{}
+}
+
 // DeleteFirewallPolicy deletes a firewall policy via the v2 API, bypassing the
 // SDK to handle 204 No Content responses.
 func (c *Client) DeleteFirewallPolicy(ctx context.Context, site string, id string) error {
+	c.logWorkaround(ctx, "delete")
 	return c.doV2Request(ctx, http.MethodDelete,
 		fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies/%s", c.BaseURL, c.APIPath, site, id),
 		struct{}{}, nil)
@@ -147,10 +223,7 @@ func (c *Client) DeleteFirewallPolicy(ctx context.Context, site string, id strin
 // Reuses the same workaround as GetFirewallPolicy (custom response struct with
 // string port field).
 func (c *Client) ListFirewallPolicies(ctx context.Context, site string) ([]*unifi.FirewallPolicy, error) {
-	var rawPolicies []firewallPolicyResponse
-	err := c.doV2Request(ctx, http.MethodGet,
-		fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies", c.BaseURL, c.APIPath, site),
-		struct{}{}, &rawPolicies)
+	rawPolicies, err := c.listFirewallPolicyResponses(ctx, site)
 	if err != nil {
 		return nil, err
 	}
@@ -166,12 +239,9 @@ func (c *Client) ListFirewallPolicies(ctx context.Context, site string) ([]*unif
 // SDK's generated FirewallPolicySource/Destination struct defines `port` as
 // *int64, but the v2 API returns `port` as a JSON string (e.g. "443"). The SDK
 // fails to unmarshal this. When the SDK fixes the port field type (or adds a
-// custom unmarshaler), this can be replaced with c.ApiClient.GetFirewallPolicy().
+// custom unmarshaler), this can be replaced with the SDK's GetFirewallPolicy().
 func (c *Client) GetFirewallPolicy(ctx context.Context, site string, id string) (*firewallPolicyFull, error) {
-	var rawPolicies []firewallPolicyResponse
-	err := c.doV2Request(ctx, http.MethodGet,
-		fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies", c.BaseURL, c.APIPath, site),
-		struct{}{}, &rawPolicies)
+	rawPolicies, err := c.listFirewallPolicyResponses(ctx, site)
 	if err != nil {
 		return nil, err
 	}
@@ -184,6 +254,97 @@ func (c *Client) GetFirewallPolicy(ctx context.Context, site string, id string)
 	return nil, &unifi.NotFoundError{}
 }
 
+// listFirewallPolicyResponses fetches the raw policy list and decodes it with
+// decodeFirewallPolicies.
+func (c *Client) listFirewallPolicyResponses(ctx context.Context, site string) ([]firewallPolicyResponse, error) {
+	c.logWorkaround(ctx, "read")
+	var raw []json.RawMessage
+	err := c.doV2Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/v2/api/site/%s/firewall-policies", c.BaseURL, c.APIPath, site),
+		struct{}{}, &raw)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFirewallPolicies(ctx, raw)
+}
+
+// decodeFirewallPolicies decodes each policy on its own so that a controller
+// upgrade which adds fields, or changes the type of one we read, doesn't break
+// every refresh until a provider release (SDK bug #5 above). Unknown fields
+// and fields of an unexpected type are logged at debug level and skipped; the
+// rest of the policy is still decoded. Malformed JSON is still an error.
+func decodeFirewallPolicies(ctx context.Context, raw []json.RawMessage) ([]firewallPolicyResponse, error) {
+	policies := make([]firewallPolicyResponse, len(raw))
+	for i, r := range raw {
+		if err := json.Unmarshal(r, &policies[i]); err != nil {
+			// json.Unmarshal keeps going after a type mismatch and reports
+			// the first one at the end, so policies[i] is otherwise complete.
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return nil, fmt.Errorf("decoding firewall policy: %w", err)
+			}
+			tflog.Debug(ctx, "Ignoring firewall policy field with unexpected type", map[string]any{
+				"policy_id": policies[i].ID,
+				"field":     typeErr.Field,
+				"error":     err.Error(),
+			})
+		}
+		if unknown := unknownFirewallPolicyFields(r); len(unknown) > 0 {
+			tflog.Debug(ctx, "Ignoring unknown firewall policy fields", map[string]any{
+				"policy_id": policies[i].ID,
+				"fields":    unknown,
+			})
+		}
+	}
+	return policies, nil
+}
+
+var (
+	firewallPolicyKnownFields         = jsonFieldNames(reflect.TypeOf(firewallPolicyResponse{}))
+	firewallPolicyEndpointKnownFields = jsonFieldNames(reflect.TypeOf(firewallPolicyEndpointResponse{}))
+)
+
+// unknownFirewallPolicyFields returns the sorted top-level, source.* and
+// destination.* keys of a policy that firewallPolicyResponse doesn't decode.
+func unknownFirewallPolicyFields(raw json.RawMessage) []string {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &top); err != nil {
+		return nil
+	}
+
+	var unknown []string
+	for k := range top {
+		if !firewallPolicyKnownFields[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	for _, ep := range []string{"source", "destination"} {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(top[ep], &fields); err != nil {
+			continue
+		}
+		for k := range fields {
+			if !firewallPolicyEndpointKnownFields[k] {
+				unknown = append(unknown, ep+"."+k)
+			}
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// jsonFieldNames returns the set of JSON keys a struct type decodes.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
 // firewallPolicyResponse mirrors the API's JSON response shape where `port`
 // is a string instead of int64. We unmarshal into this and convert to the SDK
 // struct.
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestSetFirewallPolicyEnabled(t *testing.T) {
+	var putPath string
+	var putBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[
+				{"_id": "other", "name": "Other", "enabled": true},
+				{"_id": "pol-1", "name": "Block IoT", "description": "edited in the UI", "enabled": true,
+				 "action": "BLOCK", "source": {"zone_id": "z1", "port": "443"}, "some_new_field": {"x": 1}}
+			]`))
+		case http.MethodPut:
+			putPath = r.URL.Path
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&putBody))
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	err := client.SetFirewallPolicyEnabled(context.Background(), "default", "pol-1", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "/proxy/network/v2/api/site/default/firewall-policies/pol-1", putPath)
+	assert.Equal(t, false, putBody["enabled"])
+	// Everything else is sent back exactly as the controller returned it.
+	assert.Equal(t, "edited in the UI", putBody["description"])
+	assert.Equal(t, map[string]any{"zone_id": "z1", "port": "443"}, putBody["source"])
+	assert.Equal(t, map[string]any{"x": float64(1)}, putBody["some_new_field"])
+}
+
+func TestSetFirewallPolicyEnabled_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s request", r.Method)
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	err := client.SetFirewallPolicyEnabled(context.Background(), "default", "missing", true)
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
+
+func TestListFirewallPolicies_ToleratesSchemaChanges(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"_id": "pol-1", "name": "Allow DNS", "action": "ALLOW", "enabled": true, "index": 10000,
+			 "new_top_level": {"nested": true},
+			 "source": {"zone_id": "z1", "ips": ["10.0.0.1"], "new_endpoint_field": 1},
+			 "destination": {"zone_id": "z2", "port": "53"}},
+			{"_id": "pol-2", "name": "Block IoT", "action": "BLOCK", "enabled": true, "index": "10001",
+			 "source": {"zone_id": "z3", "match_opposite_ips": "sometimes"},
+			 "destination": {"zone_id": "z4"}}
+		]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	policies, err := client.ListFirewallPolicies(context.Background(), "default")
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+
+	// Unknown fields are ignored.
+	assert.Equal(t, "Allow DNS", policies[0].Name)
+	assert.Equal(t, []string{"10.0.0.1"}, policies[0].Source.IPs)
+	require.NotNil(t, policies[0].Destination.Port)
+	assert.Equal(t, int64(53), *policies[0].Destination.Port)
+
+	// Fields whose type changed are skipped; the rest of the policy is read.
+	assert.Equal(t, "Block IoT", policies[1].Name)
+	assert.Equal(t, "z3", policies[1].Source.ZoneID)
+	assert.False(t, policies[1].Source.MatchOppositeIPs)
+	assert.Equal(t, "z4", policies[1].Destination.ZoneID)
+}
+
+func TestListFirewallPolicies_MalformedJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"_id": "pol-1", "name": }]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	_, err := client.ListFirewallPolicies(context.Background(), "default")
+	assert.Error(t, err)
+}
+
+func TestUnknownFirewallPolicyFields(t *testing.T) {
+	raw := json.RawMessage(`{
+		"_id": "pol-1", "name": "x", "future_flag": true,
+		"source": {"zone_id": "z1", "geo": "NZ"},
+		"destination": {"zone_id": "z2"}
+	}`)
+	assert.Equal(t, []string{"future_flag", "source.geo"}, unknownFirewallPolicyFields(raw))
+}
+
+func TestLogWorkaround_Once(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := newTestClient(t, srv.URL, false)
+
+	_, err := client.ListFirewallPolicies(ctx, "default")
+	require.NoError(t, err)
+	require.NoError(t, client.DeleteFirewallPolicy(ctx, "default", "pol-1"))
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	var warnings []map[string]any
+	for _, e := range entries {
+		if e["@level"] == "warn" {
+			warnings = append(warnings, e)
+		}
+	}
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "read", warnings[0]["operation"])
+	assert.Equal(t, "unknown", warnings[0]["controller_version"])
+	assert.Contains(t, warnings[0], "sdk_version")
+}
@@ -26,7 +26,7 @@ func NewFirewallPolicyOrderResource() resource.Resource {
 }
 
 type firewallPolicyOrderResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type firewallPolicyOrderResourceModel struct {
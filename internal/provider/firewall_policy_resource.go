@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -24,6 +28,8 @@ var (
 	_ resource.Resource                = &firewallPolicyResource{}
 	_ resource.ResourceWithImportState = &firewallPolicyResource{}
 	_ resource.ResourceWithModifyPlan  = &firewallPolicyResource{}
+
+	_ resource.ResourceWithConfigValidators = &firewallPolicyResource{}
 )
 
 func NewFirewallPolicyResource() resource.Resource {
@@ -31,7 +37,7 @@ func NewFirewallPolicyResource() resource.Resource {
 }
 
 type firewallPolicyResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type firewallPolicyResourceModel struct {
@@ -52,6 +58,8 @@ type firewallPolicyResourceModel struct {
 	Source              types.Object `tfsdk:"source"`
 	Destination         types.Object `tfsdk:"destination"`
 	Schedule            types.Object `tfsdk:"schedule"`
+	ManageSchedule      types.Bool   `tfsdk:"manage_schedule"`
+	CloneFromPolicyID   types.String `tfsdk:"clone_from_policy_id"`
 }
 
 type firewallPolicyEndpointModel struct {
@@ -63,6 +71,7 @@ type firewallPolicyEndpointModel struct {
 	PortMatchingType   types.String `tfsdk:"port_matching_type"`
 	Port               types.Int64  `tfsdk:"port"`
 	PortGroupID        types.String `tfsdk:"port_group_id"`
+	PortGroupName      types.String `tfsdk:"port_group_name"`
 	MatchOppositePorts types.Bool   `tfsdk:"match_opposite_ports"`
 	MatchOppositeIPs   types.Bool   `tfsdk:"match_opposite_ips"`
 }
@@ -88,6 +97,7 @@ var endpointAttrTypes = map[string]attr.Type{
 	"port_matching_type":   types.StringType,
 	"port":                 types.Int64Type,
 	"port_group_id":        types.StringType,
+	"port_group_name":      types.StringType,
 	"match_opposite_ports": types.BoolType,
 	"match_opposite_ips":   types.BoolType,
 }
@@ -104,6 +114,58 @@ var scheduleAttrTypes = map[string]attr.Type{
 	"date_end":         types.StringType,
 }
 
+// scheduleBlock is the schedule block of resources whose schedule is a v2
+// firewall policy schedule, such as firewall policies and traffic rules.
+func scheduleBlock(description string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: description,
+		Validators:          []validator.Object{scheduleCustomRequiresDatesValidator{}},
+		Attributes: map[string]schema.Attribute{
+			"mode": schema.StringAttribute{
+				MarkdownDescription: "Schedule mode. Valid values: `ALWAYS`, `EVERY_DAY`, `EVERY_WEEK`, `ONE_TIME_ONLY`, `CUSTOM`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ALWAYS", "EVERY_DAY", "EVERY_WEEK", "ONE_TIME_ONLY", "CUSTOM"),
+				},
+			},
+			"date": schema.StringAttribute{
+				MarkdownDescription: "Date for one-time schedules.",
+				Optional:            true,
+			},
+			"time_all_day": schema.BoolAttribute{
+				MarkdownDescription: "Whether the schedule applies all day.",
+				Optional:            true,
+			},
+			"time_range_start": schema.StringAttribute{
+				MarkdownDescription: "Start time for the schedule (e.g. `08:00`).",
+				Optional:            true,
+			},
+			"time_range_end": schema.StringAttribute{
+				MarkdownDescription: "End time for the schedule (e.g. `17:00`).",
+				Optional:            true,
+			},
+			"repeat_on_days": schema.SetAttribute{
+				MarkdownDescription: "Days of the week to repeat on. Valid values: `mon`, `tue`, `wed`, `thu`, `fri`, `sat`, `sun`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf("mon", "tue", "wed", "thu", "fri", "sat", "sun"),
+					),
+				},
+			},
+			"date_start": schema.StringAttribute{
+				MarkdownDescription: "Start date of the schedule range (e.g. `2026-01-01`). Required for `CUSTOM` mode.",
+				Optional:            true,
+			},
+			"date_end": schema.StringAttribute{
+				MarkdownDescription: "End date of the schedule range (e.g. `2026-12-31`). Required for `CUSTOM` mode.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
 func (r *firewallPolicyResource) Metadata(
 	_ context.Context,
 	req resource.MetadataRequest,
@@ -123,7 +185,8 @@ func (r *firewallPolicyResource) Schema(
 			Required:            true,
 		},
 		"ips": schema.SetAttribute{
-			MarkdownDescription: "IP addresses or CIDR ranges to match.",
+			MarkdownDescription: "IP addresses or CIDR ranges to match. With `ip_version` set to `IPV4` or `IPV6`, " +
+				"every entry must be of that version.",
 			ElementType:         types.StringType,
 			Optional:            true,
 		},
@@ -143,7 +206,7 @@ func (r *firewallPolicyResource) Schema(
 			Optional:            true,
 		},
 		"port_matching_type": schema.StringAttribute{
-			MarkdownDescription: "Port matching type. Valid values: `ANY`, `SPECIFIC`, `OBJECT`. Default: `ANY`. Automatically derived when `port` or `port_group_id` is set.",
+			MarkdownDescription: "Port matching type. Valid values: `ANY`, `SPECIFIC`, `OBJECT`. Default: `ANY`. Automatically derived when `port`, `port_group_id`, or `port_group_name` is set. `OBJECT` requires `port_group_id` or `port_group_name`.",
 			Optional:            true,
 			Computed:            true,
 			Default:             stringdefault.StaticString("ANY"),
@@ -159,6 +222,14 @@ func (r *firewallPolicyResource) Schema(
 			MarkdownDescription: "Port group ID to match (when `port_matching_type` is `OBJECT`).",
 			Optional:            true,
 		},
+		"port_group_name": schema.StringAttribute{
+			MarkdownDescription: "Name of the port group to match (when `port_matching_type` is `OBJECT`). The provider resolves it to the group's ID, which is useful for groups not managed by this configuration. Conflicts with `port_group_id`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("port_group_id")),
+			},
+		},
 		"match_opposite_ports": schema.BoolAttribute{
 			MarkdownDescription: "Inverts port matching. When `true` and action is `ALLOW`, all ports except the specified ones are allowed. When `true` and action is `BLOCK`, all ports except the specified ones are blocked.",
 			Optional:            true,
@@ -247,9 +318,14 @@ func (r *firewallPolicyResource) Schema(
 			},
 
 			"connection_states": schema.SetAttribute{
-				MarkdownDescription: "Set of connection states to match (e.g. `NEW`, `ESTABLISHED`, `RELATED`, `INVALID`).",
+				MarkdownDescription: "Set of connection states to match. Valid values: `NEW`, `ESTABLISHED`, `RELATED`, `INVALID`.",
 				ElementType:         types.StringType,
 				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.OneOf("NEW", "ESTABLISHED", "RELATED", "INVALID"),
+					),
+				},
 			},
 
 			"match_ipsec": schema.BoolAttribute{
@@ -258,8 +334,10 @@ func (r *firewallPolicyResource) Schema(
 			},
 
 			"logging": schema.BoolAttribute{
-				MarkdownDescription: "Whether to enable syslog logging for matched traffic.",
-				Optional:            true,
+				MarkdownDescription: "Whether to enable syslog logging for matched traffic. Where the entries go " +
+					"is a site-wide choice (the controller has no per-policy destination): manage it with " +
+					"`terrifi_setting_rsyslog`.",
+				Optional: true,
 			},
 
 			"create_allow_respond": schema.BoolAttribute{
@@ -271,65 +349,62 @@ func (r *firewallPolicyResource) Schema(
 				MarkdownDescription: "The ordering index of the policy, assigned by the controller.",
 				Computed:            true,
 			},
+
+			"manage_schedule": schema.BoolAttribute{
+				MarkdownDescription: "Whether Terraform manages the policy's schedule. When `false`, the `schedule` " +
+					"block must be omitted, `schedule` stays null in state, and updates send the schedule the " +
+					"controller already has instead of the default `ALWAYS` schedule, so a schedule configured in " +
+					"the UI survives adopting an imported policy. New policies still get the controller's default " +
+					"`ALWAYS` schedule, or the template's schedule with `clone_from_policy_id`. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+
+			"clone_from_policy_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of an existing policy to use as a template, typically one built in the " +
+					"UniFi UI. The new policy starts as a copy of the template, and the attributes and blocks in " +
+					"the configuration override it. Optional attributes and blocks that are not configured " +
+					"(`description`, `logging`, `match_ipsec`, `create_allow_respond`, `connection_states`, " +
+					"`source`, `destination`, `schedule`) are inherited and then left unmanaged: they stay null " +
+					"in state and keep their controller values on update. `enabled`, `ip_version`, `protocol` " +
+					"and `connection_state_type` default to the template's values instead of the usual defaults. " +
+					"Removing this attribute makes Terraform manage every attribute again, clearing the ones that " +
+					"are not configured.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 
 		Blocks: map[string]schema.Block{
 			"source": schema.SingleNestedBlock{
 				MarkdownDescription: "Source endpoint configuration for the firewall policy.",
+				Validators:          []validator.Object{endpointPortGroupValidator{}},
 				Attributes:          endpointAttributes,
 			},
 
 			"destination": schema.SingleNestedBlock{
 				MarkdownDescription: "Destination endpoint configuration for the firewall policy.",
+				Validators:          []validator.Object{endpointPortGroupValidator{}},
 				Attributes:          endpointAttributes,
 			},
 
-			"schedule": schema.SingleNestedBlock{
-				MarkdownDescription: "Schedule configuration for when this policy is active.",
-				Validators:          []validator.Object{scheduleCustomRequiresDatesValidator{}},
-				Attributes: map[string]schema.Attribute{
-					"mode": schema.StringAttribute{
-						MarkdownDescription: "Schedule mode. Valid values: `ALWAYS`, `EVERY_DAY`, `EVERY_WEEK`, `ONE_TIME_ONLY`, `CUSTOM`.",
-						Optional:            true,
-						Validators: []validator.String{
-							stringvalidator.OneOf("ALWAYS", "EVERY_DAY", "EVERY_WEEK", "ONE_TIME_ONLY", "CUSTOM"),
-						},
-					},
-					"date": schema.StringAttribute{
-						MarkdownDescription: "Date for one-time schedules.",
-						Optional:            true,
-					},
-					"time_all_day": schema.BoolAttribute{
-						MarkdownDescription: "Whether the schedule applies all day.",
-						Optional:            true,
-					},
-					"time_range_start": schema.StringAttribute{
-						MarkdownDescription: "Start time for the schedule (e.g. `08:00`).",
-						Optional:            true,
-					},
-					"time_range_end": schema.StringAttribute{
-						MarkdownDescription: "End time for the schedule (e.g. `17:00`).",
-						Optional:            true,
-					},
-					"repeat_on_days": schema.SetAttribute{
-						MarkdownDescription: "Days of the week to repeat on. Valid values: `mon`, `tue`, `wed`, `thu`, `fri`, `sat`, `sun`.",
-						ElementType:         types.StringType,
-						Optional:            true,
-					},
-					"date_start": schema.StringAttribute{
-						MarkdownDescription: "Start date of the schedule range (e.g. `2026-01-01`). Required for `CUSTOM` mode.",
-						Optional:            true,
-					},
-					"date_end": schema.StringAttribute{
-						MarkdownDescription: "End date of the schedule range (e.g. `2026-12-31`). Required for `CUSTOM` mode.",
-						Optional:            true,
-					},
-				},
-			},
+			"schedule": scheduleBlock("Schedule configuration for when this policy is active."),
 		},
 	}
 }
 
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *firewallPolicyResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		firewallPolicyIPVersionValidator{},
+		firewallPolicyUnmanagedScheduleValidator{},
+	}
+}
+
 func (r *firewallPolicyResource) Configure(
 	_ context.Context,
 	req resource.ConfigureRequest,
@@ -363,6 +438,28 @@ func (r *firewallPolicyResource) Create(
 	}
 
 	site := r.client.SiteOrDefault(plan.Site)
+	configured := plan
+
+	if !plan.CloneFromPolicyID.IsNull() {
+		template, err := r.client.GetFirewallPolicy(ctx, site, plan.CloneFromPolicyID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Template Firewall Policy",
+				fmt.Sprintf("Could not read firewall policy %s to clone: %s", plan.CloneFromPolicyID.ValueString(), err.Error()),
+			)
+			return
+		}
+		var base firewallPolicyResourceModel
+		r.apiToModel(template, &base, site)
+		inheritClonedAttributes(&plan, &base)
+	}
+
+	groupNames, err := r.resolvePortGroupNames(ctx, site, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Resolving Port Group", err.Error())
+		return
+	}
+
 	policy := r.modelToAPI(ctx, &plan)
 	schedReq := scheduleModelToRequest(ctx, &plan)
 
@@ -373,6 +470,9 @@ func (r *firewallPolicyResource) Create(
 	}
 
 	r.apiToModel(created, &plan, site)
+	restorePortGroupNames(&configured, &plan, groupNames)
+	maskClonedAttributes(&configured, &plan)
+	maskUnmanagedSchedule(&plan)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -402,7 +502,24 @@ func (r *firewallPolicyResource) Read(
 		return
 	}
 
+	prior := state
 	r.apiToModel(full, &state, site)
+
+	if usesPortGroupName(&prior) {
+		groupNames, err := r.portGroupNames(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Port Groups", err.Error())
+			return
+		}
+		restorePortGroupNames(&prior, &state, groupNames)
+	}
+	maskClonedAttributes(&prior, &state)
+	// Imported policies have no manage_schedule yet; start from the default.
+	if state.ManageSchedule.IsNull() {
+		state.ManageSchedule = types.BoolValue(true)
+	}
+	maskUnmanagedSchedule(&state)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -418,12 +535,61 @@ func (r *firewallPolicyResource) Update(
 		return
 	}
 
+	// Toggling enabled is the most common change and is often done while
+	// the policy is also being edited in the UI. Send only the flag so a
+	// full PUT of our (possibly stale) view doesn't clobber those edits.
+	enabledOnly, err := onlyAttributeChanged(req.State.Raw, req.Plan.Raw, "enabled")
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Firewall Policy", err.Error())
+		return
+	}
+	if enabledOnly {
+		site := r.client.SiteOrDefault(state.Site)
+		err := r.client.SetFirewallPolicyEnabled(ctx, site, state.ID.ValueString(), plan.Enabled.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Updating Firewall Policy", err.Error())
+			return
+		}
+		// Keep the prior values for everything else: they match the plan,
+		// and any concurrent UI edits will show up as drift on refresh.
+		state.Enabled = plan.Enabled
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	r.applyPlanToState(&plan, &state)
 
 	site := r.client.SiteOrDefault(state.Site)
+	configured := state
+
+	// Attributes left to the controller must be sent with their current
+	// values, since the PUT replaces the whole policy.
+	var current *firewallPolicyFull
+	if !state.CloneFromPolicyID.IsNull() || !state.ManageSchedule.ValueBool() {
+		current, err = r.client.GetFirewallPolicy(ctx, site, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Firewall Policy for Update", err.Error())
+			return
+		}
+	}
+	if !state.CloneFromPolicyID.IsNull() {
+		var base firewallPolicyResourceModel
+		r.apiToModel(current, &base, site)
+		inheritClonedAttributes(&state, &base)
+	}
+
+	groupNames, err := r.resolvePortGroupNames(ctx, site, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Resolving Port Group", err.Error())
+		return
+	}
+
 	policy := r.modelToAPI(ctx, &state)
 	policy.ID = state.ID.ValueString()
 	schedReq := scheduleModelToRequest(ctx, &state)
+	if !state.ManageSchedule.ValueBool() {
+		schedReq = current.RawSchedule
+	}
 
 	updated, err := r.client.UpdateFirewallPolicy(ctx, site, policy, schedReq)
 	if err != nil {
@@ -432,6 +598,9 @@ func (r *firewallPolicyResource) Update(
 	}
 
 	r.apiToModel(updated, &state, site)
+	restorePortGroupNames(&configured, &state, groupNames)
+	maskClonedAttributes(&configured, &state)
+	maskUnmanagedSchedule(&state)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -448,7 +617,43 @@ func (r *firewallPolicyResource) Delete(
 
 	site := r.client.SiteOrDefault(state.Site)
 
-	err := r.client.DeleteFirewallPolicy(ctx, site, state.ID.ValueString())
+	// A policy with create_allow_respond has a respond policy the controller
+	// created alongside it. Deleting only the parent can orphan that
+	// companion, and repeated create/destroy cycles pile them up, so delete
+	// the companions first. The flag is read from the controller rather than
+	// state, since cloned policies may leave it unmanaged.
+	policies, err := r.client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Firewall Policy", err.Error())
+		return
+	}
+	companions, ambiguous := allowRespondCompanions(state.ID.ValueString(), policies)
+	if len(ambiguous) > 0 {
+		names := make([]string, len(ambiguous))
+		for i, p := range ambiguous {
+			names[i] = fmt.Sprintf("%s (%q)", p.ID, p.Name)
+		}
+		resp.Diagnostics.AddError(
+			"Ambiguous Allow-Respond Policies",
+			fmt.Sprintf("Another policy with create_allow_respond has the same name and zones as policy %s, so "+
+				"its respond policies can't be told apart: %s. Deleting the policy alone would orphan its "+
+				"respond policy. Rename one of the two policies, or delete the respond policies in the UniFi "+
+				"UI, and try again.", state.ID.ValueString(), strings.Join(names, ", ")),
+		)
+		return
+	}
+	for _, companion := range companions {
+		err := r.client.DeleteFirewallPolicy(ctx, site, companion.ID)
+		if _, ok := err.(*unifi.NotFoundError); err != nil && !ok {
+			resp.Diagnostics.AddError(
+				"Error Deleting Allow-Respond Policy",
+				fmt.Sprintf("Could not delete respond policy %s (%q) created for this policy: %s", companion.ID, companion.Name, err.Error()),
+			)
+			return
+		}
+	}
+
+	err = r.client.DeleteFirewallPolicy(ctx, site, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Firewall Policy", err.Error())
 	}
@@ -486,6 +691,14 @@ func (r *firewallPolicyResource) ModifyPlan(
 		return
 	}
 
+	if !plan.CloneFromPolicyID.IsNull() && !plan.CloneFromPolicyID.IsUnknown() {
+		r.planClonedDefaults(ctx, req, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	}
+
 	// Only validate when create_allow_respond is explicitly true.
 	if plan.CreateAllowRespond.IsNull() || plan.CreateAllowRespond.IsUnknown() || !plan.CreateAllowRespond.ValueBool() {
 		return
@@ -524,6 +737,132 @@ func (r *firewallPolicyResource) ModifyPlan(
 // Helper methods
 // ---------------------------------------------------------------------------
 
+// onlyAttributeChanged reports whether the plan differs from the prior state
+// and every difference is within one of the top-level attributes names.
+func onlyAttributeChanged(state, plan tftypes.Value, names ...string) (bool, error) {
+	diffs, err := state.Diff(plan)
+	if err != nil {
+		return false, fmt.Errorf("comparing plan to state: %w", err)
+	}
+	if len(diffs) == 0 {
+		return false, nil
+	}
+	for _, d := range diffs {
+		steps := d.Path.Steps()
+		if len(steps) == 0 {
+			return false, nil
+		}
+		attr, ok := steps[0].(tftypes.AttributeName)
+		if !ok || !slices.Contains(names, string(attr)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// planClonedDefaults plans enabled, ip_version, protocol and
+// connection_state_type from the baseline of a cloned policy where they are
+// not configured, instead of their schema defaults. The baseline is the
+// template policy on create and the prior state afterwards.
+func (r *firewallPolicyResource) planClonedDefaults(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	plan *firewallPolicyResourceModel,
+	diags *diag.Diagnostics,
+) {
+	var config, base firewallPolicyResourceModel
+	diags.Append(req.Config.Get(ctx, &config)...)
+	if diags.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		site := r.client.SiteOrDefault(plan.Site)
+		template, err := r.client.GetFirewallPolicy(ctx, site, plan.CloneFromPolicyID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("clone_from_policy_id"),
+				"Error Reading Template Firewall Policy",
+				fmt.Sprintf("Could not read firewall policy %s to clone: %s", plan.CloneFromPolicyID.ValueString(), err.Error()),
+			)
+			return
+		}
+		r.apiToModel(template, &base, site)
+	} else {
+		diags.Append(req.State.Get(ctx, &base)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	if config.Enabled.IsNull() {
+		plan.Enabled = base.Enabled
+	}
+	if config.IPVersion.IsNull() {
+		plan.IPVersion = base.IPVersion
+	}
+	if config.Protocol.IsNull() {
+		plan.Protocol = base.Protocol
+	}
+	if config.ConnectionStateType.IsNull() {
+		plan.ConnectionStateType = base.ConnectionStateType
+	}
+}
+
+// inheritClonedAttributes fills the optional attributes that m leaves null
+// with their values in base, for policies created with clone_from_policy_id.
+func inheritClonedAttributes(m, base *firewallPolicyResourceModel) {
+	m.Description = valueOr(m.Description, base.Description)
+	m.MatchIPSec = valueOr(m.MatchIPSec, base.MatchIPSec)
+	m.Logging = valueOr(m.Logging, base.Logging)
+	m.CreateAllowRespond = valueOr(m.CreateAllowRespond, base.CreateAllowRespond)
+	m.ConnectionStates = valueOr(m.ConnectionStates, base.ConnectionStates)
+	m.Source = valueOr(m.Source, base.Source)
+	m.Destination = valueOr(m.Destination, base.Destination)
+	m.Schedule = valueOr(m.Schedule, base.Schedule)
+}
+
+// maskClonedAttributes nulls the attributes of m that inheritClonedAttributes
+// fills in, wherever the configured model leaves them null, so that state
+// only tracks what the configuration manages. It does nothing for policies
+// without clone_from_policy_id.
+func maskClonedAttributes(configured, m *firewallPolicyResourceModel) {
+	if configured.CloneFromPolicyID.IsNull() {
+		return
+	}
+	m.Description = nullIfNull(configured.Description, m.Description)
+	m.MatchIPSec = nullIfNull(configured.MatchIPSec, m.MatchIPSec)
+	m.Logging = nullIfNull(configured.Logging, m.Logging)
+	m.CreateAllowRespond = nullIfNull(configured.CreateAllowRespond, m.CreateAllowRespond)
+	m.ConnectionStates = nullIfNull(configured.ConnectionStates, m.ConnectionStates)
+	m.Source = nullIfNull(configured.Source, m.Source)
+	m.Destination = nullIfNull(configured.Destination, m.Destination)
+	m.Schedule = nullIfNull(configured.Schedule, m.Schedule)
+}
+
+// maskUnmanagedSchedule nulls the schedule of policies with manage_schedule
+// set to false, so that a schedule configured in the UI doesn't show up as
+// drift.
+func maskUnmanagedSchedule(m *firewallPolicyResourceModel) {
+	if !m.ManageSchedule.IsNull() && !m.ManageSchedule.ValueBool() {
+		m.Schedule = types.ObjectNull(scheduleAttrTypes)
+	}
+}
+
+func valueOr[T attr.Value](v, fallback T) T {
+	if v.IsNull() {
+		return fallback
+	}
+	return v
+}
+
+func nullIfNull[T attr.Value](configured, v T) T {
+	if configured.IsNull() {
+		return configured
+	}
+	return v
+}
+
 func (r *firewallPolicyResource) applyPlanToState(plan, state *firewallPolicyResourceModel) {
 	if !plan.Name.IsUnknown() {
 		state.Name = plan.Name
@@ -574,6 +913,10 @@ func (r *firewallPolicyResource) applyPlanToState(plan, state *firewallPolicyRes
 	if !plan.Schedule.IsUnknown() {
 		state.Schedule = plan.Schedule
 	}
+	if !plan.ManageSchedule.IsNull() && !plan.ManageSchedule.IsUnknown() {
+		state.ManageSchedule = plan.ManageSchedule
+	}
+	state.CloneFromPolicyID = plan.CloneFromPolicyID
 }
 
 func (r *firewallPolicyResource) modelToAPI(ctx context.Context, m *firewallPolicyResourceModel) *unifi.FirewallPolicy {
@@ -596,9 +939,7 @@ func (r *firewallPolicyResource) modelToAPI(ctx context.Context, m *firewallPoli
 	}
 
 	if !m.ConnectionStates.IsNull() && !m.ConnectionStates.IsUnknown() {
-		var states []string
-		m.ConnectionStates.ElementsAs(ctx, &states, false)
-		policy.ConnectionStates = states
+		policy.ConnectionStates = sortedSetStrings(m.ConnectionStates)
 	}
 
 	if !m.Source.IsNull() && !m.Source.IsUnknown() {
@@ -683,6 +1024,121 @@ func resolveMatchingTarget(ctx context.Context, m *firewallPolicyEndpointModel)
 	return "ANY", nil
 }
 
+// portGroupNames returns the site's port groups as a map from ID to name.
+func (r *firewallPolicyResource) portGroupNames(ctx context.Context, site string) (map[string]string, error) {
+	groups, err := r.client.ListFirewallGroup(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing firewall groups: %w", err)
+	}
+	names := make(map[string]string)
+	for _, g := range groups {
+		if g.GroupType == "port-group" {
+			names[g.ID] = g.Name
+		}
+	}
+	return names, nil
+}
+
+// usesPortGroupName reports whether either endpoint of m references its port
+// group by name.
+func usesPortGroupName(m *firewallPolicyResourceModel) bool {
+	return endpointPortGroupName(m.Source) != "" || endpointPortGroupName(m.Destination) != ""
+}
+
+// resolvePortGroupNames sets port_group_id on each endpoint of m that
+// specifies port_group_name, so that modelToAPI sends the resolved ID. It
+// returns the site's port groups (ID to name) for restorePortGroupNames, or
+// nil when no endpoint uses a name.
+func (r *firewallPolicyResource) resolvePortGroupNames(ctx context.Context, site string, m *firewallPolicyResourceModel) (map[string]string, error) {
+	if !usesPortGroupName(m) {
+		return nil, nil
+	}
+	groupNames, err := r.portGroupNames(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	if m.Source, err = resolveEndpointPortGroup(m.Source, groupNames); err != nil {
+		return nil, err
+	}
+	if m.Destination, err = resolveEndpointPortGroup(m.Destination, groupNames); err != nil {
+		return nil, err
+	}
+	return groupNames, nil
+}
+
+// resolveEndpointPortGroup returns obj with port_group_id set to the ID of the
+// port group named by its port_group_name. Names must match exactly one group.
+func resolveEndpointPortGroup(obj types.Object, groupNames map[string]string) (types.Object, error) {
+	name := endpointPortGroupName(obj)
+	if name == "" {
+		return obj, nil
+	}
+	var ids []string
+	for id, n := range groupNames {
+		if n == name {
+			ids = append(ids, id)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return obj, fmt.Errorf("no port group named %q exists", name)
+	case 1:
+		return withEndpointAttr(obj, "port_group_id", types.StringValue(ids[0])), nil
+	default:
+		return obj, fmt.Errorf("%d port groups are named %q; use port_group_id instead", len(ids), name)
+	}
+}
+
+// restorePortGroupNames rewrites the endpoints of m (freshly read from the API,
+// so only port_group_id is set) to use port_group_name wherever the configured
+// model did. If the group the policy points at has been renamed, the new name
+// is recorded so Terraform reports the drift. If it no longer exists, the ID is
+// kept and the name left null.
+func restorePortGroupNames(configured, m *firewallPolicyResourceModel, groupNames map[string]string) {
+	m.Source = restoreEndpointPortGroupName(configured.Source, m.Source, groupNames)
+	m.Destination = restoreEndpointPortGroupName(configured.Destination, m.Destination, groupNames)
+}
+
+func restoreEndpointPortGroupName(configured, obj types.Object, groupNames map[string]string) types.Object {
+	if endpointPortGroupName(configured) == "" || obj.IsNull() || obj.IsUnknown() {
+		return obj
+	}
+	id, ok := obj.Attributes()["port_group_id"].(types.String)
+	if !ok || id.IsNull() {
+		return obj
+	}
+	name, ok := groupNames[id.ValueString()]
+	if !ok {
+		return obj
+	}
+	obj = withEndpointAttr(obj, "port_group_name", types.StringValue(name))
+	return withEndpointAttr(obj, "port_group_id", types.StringNull())
+}
+
+// endpointPortGroupName returns the known port_group_name of an endpoint
+// object, or "" if it is null, unknown, or the endpoint itself is.
+func endpointPortGroupName(obj types.Object) string {
+	if obj.IsNull() || obj.IsUnknown() {
+		return ""
+	}
+	name, ok := obj.Attributes()["port_group_name"].(types.String)
+	if !ok || name.IsNull() || name.IsUnknown() {
+		return ""
+	}
+	return name.ValueString()
+}
+
+// withEndpointAttr returns a copy of the endpoint object with one attribute
+// replaced.
+func withEndpointAttr(obj types.Object, name string, value attr.Value) types.Object {
+	attrs := make(map[string]attr.Value, len(obj.Attributes()))
+	for k, v := range obj.Attributes() {
+		attrs[k] = v
+	}
+	attrs[name] = value
+	return types.ObjectValueMust(endpointAttrTypes, attrs)
+}
+
 func scheduleModelToAPI(ctx context.Context, m *firewallPolicyScheduleModel) *unifi.FirewallPolicySchedule {
 	sched := &unifi.FirewallPolicySchedule{
 		Mode:           m.Mode.ValueString(),
@@ -693,9 +1149,7 @@ func scheduleModelToAPI(ctx context.Context, m *firewallPolicyScheduleModel) *un
 	}
 
 	if !m.RepeatOnDays.IsNull() && !m.RepeatOnDays.IsUnknown() {
-		var days []string
-		m.RepeatOnDays.ElementsAs(ctx, &days, false)
-		sched.RepeatOnDays = days
+		sched.RepeatOnDays = sortedSetStrings(m.RepeatOnDays)
 	}
 
 	return sched
@@ -705,11 +1159,17 @@ func scheduleModelToAPI(ctx context.Context, m *firewallPolicyScheduleModel) *un
 // resource model. Returns nil when the model has no schedule block configured.
 // This preserves fields (DateRangeStart, DateRangeEnd) not present in the SDK struct.
 func scheduleModelToRequest(ctx context.Context, m *firewallPolicyResourceModel) *firewallPolicyScheduleRequest {
-	if m.Schedule.IsNull() || m.Schedule.IsUnknown() {
+	return scheduleObjectToRequest(ctx, m.Schedule)
+}
+
+// scheduleObjectToRequest builds a firewallPolicyScheduleRequest from a
+// schedule block. Returns nil when the block is not configured.
+func scheduleObjectToRequest(ctx context.Context, schedule types.Object) *firewallPolicyScheduleRequest {
+	if schedule.IsNull() || schedule.IsUnknown() {
 		return nil
 	}
 	var sched firewallPolicyScheduleModel
-	m.Schedule.As(ctx, &sched, basetypes.ObjectAsOptions{})
+	schedule.As(ctx, &sched, basetypes.ObjectAsOptions{})
 
 	req := &firewallPolicyScheduleRequest{
 		Mode:           sched.Mode.ValueString(),
@@ -723,9 +1183,7 @@ func scheduleModelToRequest(ctx context.Context, m *firewallPolicyResourceModel)
 		req.TimeAllDay = boolPtr(true)
 	}
 	if !sched.RepeatOnDays.IsNull() && !sched.RepeatOnDays.IsUnknown() {
-		var days []string
-		sched.RepeatOnDays.ElementsAs(ctx, &days, false)
-		req.RepeatOnDays = days
+		req.RepeatOnDays = sortedSetStrings(sched.RepeatOnDays)
 	}
 	return req
 }
@@ -767,11 +1225,7 @@ func (r *firewallPolicyResource) apiToModel(full *firewallPolicyFull, m *firewal
 	// When ALL, the API may still return stale states from a prior config, so
 	// we discard them to avoid spurious diffs.
 	if m.ConnectionStateType.ValueString() != "ALL" && len(policy.ConnectionStates) > 0 {
-		vals := make([]attr.Value, len(policy.ConnectionStates))
-		for i, s := range policy.ConnectionStates {
-			vals[i] = types.StringValue(s)
-		}
-		m.ConnectionStates = types.SetValueMust(types.StringType, vals)
+		m.ConnectionStates = stringSetValue(policy.ConnectionStates, strings.ToUpper)
 	} else {
 		m.ConnectionStates = types.SetNull(types.StringType)
 	}
@@ -817,6 +1271,7 @@ func endpointAPIToModel(src *unifi.FirewallPolicySource) types.Object {
 		"zone_id":              types.StringValue(src.ZoneID),
 		"port_matching_type":   stringValueOrNull(src.PortMatchingType),
 		"port_group_id":        stringValueOrNull(src.PortGroupID),
+		"port_group_name":      types.StringNull(),
 		"match_opposite_ports": boolValueOrNull(src.MatchOppositePorts),
 		"match_opposite_ips":   boolValueOrNull(src.MatchOppositeIPs),
 	}
@@ -837,6 +1292,7 @@ func destinationAPIToModel(dst *unifi.FirewallPolicyDestination) types.Object {
 		"zone_id":              types.StringValue(dst.ZoneID),
 		"port_matching_type":   stringValueOrNull(dst.PortMatchingType),
 		"port_group_id":        stringValueOrNull(dst.PortGroupID),
+		"port_group_name":      types.StringNull(),
 		"match_opposite_ports": boolValueOrNull(dst.MatchOppositePorts),
 		"match_opposite_ips":   boolValueOrNull(dst.MatchOppositeIPs),
 	}
@@ -856,7 +1312,6 @@ func destinationAPIToModel(dst *unifi.FirewallPolicyDestination) types.Object {
 // network_ids, device_ids) based on the API's matching_target value, and sets
 // the others to null.
 func populateTypedEndpointFields(attrs map[string]attr.Value, matchingTarget string, ips []string) {
-	setType := types.SetType{ElemType: types.StringType}
 	nullSet := types.SetNull(types.StringType)
 
 	// Default: all null.
@@ -869,11 +1324,7 @@ func populateTypedEndpointFields(attrs map[string]attr.Value, matchingTarget str
 		return
 	}
 
-	vals := make([]attr.Value, len(ips))
-	for i, v := range ips {
-		vals[i] = types.StringValue(v)
-	}
-	sv := types.SetValueMust(setType.ElemType, vals)
+	sv := stringSetValue(ips, nil)
 
 	switch matchingTarget {
 	case "IP":
@@ -905,11 +1356,7 @@ func scheduleAPIToModel(sched *firewallPolicyScheduleRequest) types.Object {
 	}
 
 	if sched.RepeatOnDays != nil {
-		vals := make([]attr.Value, len(sched.RepeatOnDays))
-		for i, d := range sched.RepeatOnDays {
-			vals[i] = types.StringValue(d)
-		}
-		attrs["repeat_on_days"] = types.SetValueMust(types.StringType, vals)
+		attrs["repeat_on_days"] = stringSetValue(sched.RepeatOnDays, strings.ToLower)
 	} else {
 		attrs["repeat_on_days"] = types.SetNull(types.StringType)
 	}
@@ -917,6 +1364,55 @@ func scheduleAPIToModel(sched *firewallPolicyScheduleRequest) types.Object {
 	return types.ObjectValueMust(scheduleAttrTypes, attrs)
 }
 
+// allowRespondCompanions returns the respond policies the controller created
+// for the policy with the given ID because of create_allow_respond. The API
+// doesn't link them, so a companion is recognised by shape: an allow policy
+// for RESPOND_ONLY traffic in the opposite direction between the same zones,
+// with exactly the parent's name. Policies whose name only starts with the
+// parent's may belong to another policy ("IoT" and "IoT cameras") or be
+// user-made, so they are never matched.
+//
+// If another create_allow_respond policy has the same name and zones, the
+// companions can't be attributed to either parent, and they are returned as
+// ambiguous instead.
+func allowRespondCompanions(id string, policies []*unifi.FirewallPolicy) (companions, ambiguous []*unifi.FirewallPolicy) {
+	var parent *unifi.FirewallPolicy
+	for _, p := range policies {
+		if p.ID == id {
+			parent = p
+			break
+		}
+	}
+	if parent == nil || !parent.CreateAllowRespond || parent.Source == nil || parent.Destination == nil {
+		return nil, nil
+	}
+
+	twin := false
+	for _, p := range policies {
+		if p.ID == id || p.Predefined || p.Source == nil || p.Destination == nil {
+			continue
+		}
+		if p.Name != parent.Name {
+			continue
+		}
+		switch {
+		case p.CreateAllowRespond &&
+			p.Source.ZoneID == parent.Source.ZoneID &&
+			p.Destination.ZoneID == parent.Destination.ZoneID:
+			twin = true
+		case p.Action == "ALLOW" &&
+			p.ConnectionStateType == "RESPOND_ONLY" &&
+			p.Source.ZoneID == parent.Destination.ZoneID &&
+			p.Destination.ZoneID == parent.Source.ZoneID:
+			companions = append(companions, p)
+		}
+	}
+	if twin {
+		return nil, companions
+	}
+	return companions, nil
+}
+
 func stringValueOrNull(s string) types.String {
 	if s != "" {
 		return types.StringValue(s)
@@ -967,6 +1463,138 @@ func (v scheduleCustomRequiresDatesValidator) ValidateObject(ctx context.Context
 	}
 }
 
+// endpointPortGroupValidator enforces that an endpoint matching ports by
+// object (port_matching_type = OBJECT) names the port group to match, either by
+// ID or by name.
+type endpointPortGroupValidator struct{}
+
+func (v endpointPortGroupValidator) Description(_ context.Context) string {
+	return "When port_matching_type is OBJECT, port_group_id or port_group_name is required."
+}
+
+func (v endpointPortGroupValidator) MarkdownDescription(_ context.Context) string {
+	return "When `port_matching_type` is `OBJECT`, `port_group_id` or `port_group_name` is required."
+}
+
+func (v endpointPortGroupValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var ep firewallPolicyEndpointModel
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &ep, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ep.PortMatchingType.ValueString() != "OBJECT" {
+		return
+	}
+	if ep.PortGroupID.IsNull() && ep.PortGroupName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtName("port_matching_type"),
+			"Missing Port Group",
+			"port_group_id or port_group_name is required when port_matching_type is OBJECT.",
+		)
+	}
+}
+
+// firewallPolicyUnmanagedScheduleValidator rejects a schedule block on
+// policies with manage_schedule set to false, where it would be ignored.
+type firewallPolicyUnmanagedScheduleValidator struct{}
+
+func (v firewallPolicyUnmanagedScheduleValidator) Description(_ context.Context) string {
+	return "schedule must be omitted when manage_schedule is false."
+}
+
+func (v firewallPolicyUnmanagedScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v firewallPolicyUnmanagedScheduleValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var manage types.Bool
+	var schedule types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("manage_schedule"), &manage)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schedule"), &schedule)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if manage.IsNull() || manage.IsUnknown() || manage.ValueBool() || schedule.IsNull() {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		path.Root("schedule"),
+		"Conflicting Schedule Configuration",
+		"The schedule block cannot be set when manage_schedule is false. Remove the block to leave the "+
+			"schedule to the controller, or set manage_schedule to true.",
+	)
+}
+
+// firewallPolicyIPVersionValidator ensures the source and destination ips
+// match ip_version. The controller rejects a mismatch, such as an IPv4 CIDR
+// in an IPV6 policy, with an opaque 400.
+type firewallPolicyIPVersionValidator struct{}
+
+func (v firewallPolicyIPVersionValidator) Description(_ context.Context) string {
+	return "source and destination ips must match ip_version when it is IPV4 or IPV6."
+}
+
+func (v firewallPolicyIPVersionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v firewallPolicyIPVersionValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ipVersion types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip_version"), &ipVersion)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	want := ipVersion.ValueString()
+	if want != "IPV4" && want != "IPV6" {
+		return
+	}
+
+	for _, name := range []string{"source", "destination"} {
+		var ips types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name).AtName("ips"), &ips)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for _, ip := range setStrings(ctx, ips) {
+			if got := ipEntryVersion(ip); got != "" && got != want {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(name).AtName("ips"),
+					"IP Version Mismatch",
+					fmt.Sprintf("%s ips entry %q is %s, but ip_version is %q. Use %s addresses, or set "+
+						"ip_version to \"%s\" or \"BOTH\".", name, ip, ipVersionName(got), want,
+						ipVersionName(want), got),
+				)
+			}
+		}
+	}
+}
+
+// ipEntryVersion returns IPV4 or IPV6 for an address, CIDR subnet or range,
+// or "" if it isn't one.
+func ipEntryVersion(s string) string {
+	addr, rng, err := parseTrafficRouteIP(s)
+	switch {
+	case err != nil:
+		return ""
+	case rng != nil:
+		return rng.IPVersion
+	default:
+		return addr.IPVersion
+	}
+}
+
+// ipVersionName returns the display name of an IPV4 or IPV6 ip_version.
+func ipVersionName(v string) string {
+	if v == "IPV6" {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
 func isDefaultSchedule(s *firewallPolicyScheduleRequest) bool {
 	timeAllDay := s.TimeAllDay != nil && *s.TimeAllDay
 	return s.Mode == "ALWAYS" &&
@@ -12,9 +12,11 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -36,6 +38,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -48,6 +51,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -101,6 +105,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("SPECIFIC"),
 			"port":                 types.Int64Value(443),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -113,6 +118,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -159,6 +165,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -171,6 +178,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -226,6 +234,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -238,6 +247,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -297,6 +307,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -309,6 +320,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -347,6 +359,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -359,6 +372,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -400,6 +414,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -412,6 +427,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -452,6 +468,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -464,6 +481,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -504,6 +522,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -516,6 +535,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -559,6 +579,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -571,6 +592,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -614,6 +636,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -626,6 +649,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -664,6 +688,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("SPECIFIC"),
 			"port":                 types.Int64Value(443),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolValue(true),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -676,6 +701,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolValue(true),
 		})
@@ -716,6 +742,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringNull(),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolNull(),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -728,6 +755,7 @@ func TestFirewallPolicyModelToAPI(t *testing.T) {
 			"port_matching_type":   types.StringValue("ANY"),
 			"port":                 types.Int64Null(),
 			"port_group_id":        types.StringValue("pg-001"),
+			"port_group_name":      types.StringNull(),
 			"match_opposite_ports": types.BoolValue(true),
 			"match_opposite_ips":   types.BoolNull(),
 		})
@@ -1352,6 +1380,31 @@ func TestFirewallPolicyAPIToModel(t *testing.T) {
 		assert.True(t, dstModel.MatchOppositePorts.ValueBool())
 		assert.True(t, dstModel.Port.IsNull())
 	})
+
+	t.Run("reordered sets match the configured order", func(t *testing.T) {
+		configured := &unifi.FirewallPolicy{
+			ID:                  "pol-sets",
+			Name:                "Sets",
+			Action:              "ALLOW",
+			ConnectionStateType: "CUSTOM",
+			ConnectionStates:    []string{"NEW", "ESTABLISHED"},
+		}
+		returned := *configured
+		returned.ConnectionStates = []string{"established", "NEW", "ESTABLISHED"}
+
+		var want, got firewallPolicyResourceModel
+		r.apiToModel(&firewallPolicyFull{
+			FirewallPolicy: configured,
+			RawSchedule:    &firewallPolicyScheduleRequest{Mode: "EVERY_WEEK", RepeatOnDays: []string{"mon", "fri"}},
+		}, &want, "default")
+		r.apiToModel(&firewallPolicyFull{
+			FirewallPolicy: &returned,
+			RawSchedule:    &firewallPolicyScheduleRequest{Mode: "EVERY_WEEK", RepeatOnDays: []string{"FRI", "mon", "fri"}},
+		}, &got, "default")
+
+		assert.Empty(t, changedAttributes(&want, &got))
+		assert.Len(t, got.ConnectionStates.Elements(), 2)
+	})
 }
 
 func TestFirewallPolicyApplyPlanToState(t *testing.T) {
@@ -1448,6 +1501,48 @@ func TestFirewallPolicyApplyPlanToState(t *testing.T) {
 	})
 }
 
+func TestOnlyAttributeChanged(t *testing.T) {
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"name":    tftypes.String,
+		"enabled": tftypes.Bool,
+		"source": tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+			"zone_id": tftypes.String,
+		}},
+	}}
+	value := func(name string, enabled any, zone string) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, name),
+			"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+			"source": tftypes.NewValue(objType.AttributeTypes["source"], map[string]tftypes.Value{
+				"zone_id": tftypes.NewValue(tftypes.String, zone),
+			}),
+		})
+	}
+
+	for _, tc := range []struct {
+		name  string
+		plan  tftypes.Value
+		match bool
+	}{
+		{"only enabled changed", value("p", false, "z1"), true},
+		{"nothing changed", value("p", true, "z1"), false},
+		{"enabled and name changed", value("q", false, "z1"), false},
+		{"nested change", value("p", false, "z2"), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := onlyAttributeChanged(value("p", true, "z1"), tc.plan, "enabled")
+			require.NoError(t, err)
+			assert.Equal(t, tc.match, ok)
+		})
+	}
+
+	t.Run("several attributes", func(t *testing.T) {
+		ok, err := onlyAttributeChanged(value("p", true, "z1"), value("p", false, "z2"), "enabled", "source")
+		require.NoError(t, err)
+		assert.True(t, ok, "nested changes within a listed attribute match")
+	})
+}
+
 func TestScheduleCustomRequiresDatesValidator(t *testing.T) {
 	v := scheduleCustomRequiresDatesValidator{}
 	ctx := context.Background()
@@ -1510,6 +1605,198 @@ func TestScheduleCustomRequiresDatesValidator(t *testing.T) {
 	})
 }
 
+func TestEndpointPortGroupValidator(t *testing.T) {
+	v := endpointPortGroupValidator{}
+	ctx := context.Background()
+
+	t.Run("OBJECT with port_group_id passes", func(t *testing.T) {
+		req := validator.ObjectRequest{ConfigValue: testEndpointObj("OBJECT", types.StringValue("pg-1"), types.StringNull())}
+		var resp validator.ObjectResponse
+		v.ValidateObject(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("OBJECT with port_group_name passes", func(t *testing.T) {
+		req := validator.ObjectRequest{ConfigValue: testEndpointObj("OBJECT", types.StringNull(), types.StringValue("NTP"))}
+		var resp validator.ObjectResponse
+		v.ValidateObject(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("OBJECT with unknown port_group_id passes", func(t *testing.T) {
+		req := validator.ObjectRequest{ConfigValue: testEndpointObj("OBJECT", types.StringUnknown(), types.StringNull())}
+		var resp validator.ObjectResponse
+		v.ValidateObject(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+
+	t.Run("OBJECT without a port group fails", func(t *testing.T) {
+		req := validator.ObjectRequest{ConfigValue: testEndpointObj("OBJECT", types.StringNull(), types.StringNull())}
+		var resp validator.ObjectResponse
+		v.ValidateObject(ctx, req, &resp)
+		require.True(t, resp.Diagnostics.HasError())
+		assert.Equal(t, "Missing Port Group", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("ANY without a port group passes", func(t *testing.T) {
+		req := validator.ObjectRequest{ConfigValue: testEndpointObj("ANY", types.StringNull(), types.StringNull())}
+		var resp validator.ObjectResponse
+		v.ValidateObject(ctx, req, &resp)
+		assert.False(t, resp.Diagnostics.HasError())
+	})
+}
+
+func TestResolveEndpointPortGroup(t *testing.T) {
+	groupNames := map[string]string{"pg-1": "NTP", "pg-2": "Web", "pg-3": "Web"}
+
+	t.Run("name resolves to ID", func(t *testing.T) {
+		obj, err := resolveEndpointPortGroup(testEndpointObj("OBJECT", types.StringNull(), types.StringValue("NTP")), groupNames)
+		require.NoError(t, err)
+		assert.Equal(t, types.StringValue("pg-1"), obj.Attributes()["port_group_id"])
+		assert.Equal(t, types.StringValue("NTP"), obj.Attributes()["port_group_name"])
+	})
+
+	t.Run("no name is a no-op", func(t *testing.T) {
+		in := testEndpointObj("OBJECT", types.StringValue("pg-2"), types.StringNull())
+		obj, err := resolveEndpointPortGroup(in, groupNames)
+		require.NoError(t, err)
+		assert.Equal(t, in, obj)
+	})
+
+	t.Run("unknown name fails", func(t *testing.T) {
+		_, err := resolveEndpointPortGroup(testEndpointObj("OBJECT", types.StringNull(), types.StringValue("DNS")), groupNames)
+		assert.ErrorContains(t, err, `no port group named "DNS"`)
+	})
+
+	t.Run("ambiguous name fails", func(t *testing.T) {
+		_, err := resolveEndpointPortGroup(testEndpointObj("OBJECT", types.StringNull(), types.StringValue("Web")), groupNames)
+		assert.ErrorContains(t, err, `2 port groups are named "Web"`)
+	})
+}
+
+func TestRestorePortGroupNames(t *testing.T) {
+	groupNames := map[string]string{"pg-1": "NTP Renamed"}
+	configured := &firewallPolicyResourceModel{
+		Source:      testEndpointObj("ANY", types.StringNull(), types.StringNull()),
+		Destination: testEndpointObj("OBJECT", types.StringNull(), types.StringValue("NTP")),
+	}
+
+	t.Run("ID is replaced by the group's current name", func(t *testing.T) {
+		m := &firewallPolicyResourceModel{
+			Source:      testEndpointObj("ANY", types.StringNull(), types.StringNull()),
+			Destination: testEndpointObj("OBJECT", types.StringValue("pg-1"), types.StringNull()),
+		}
+		restorePortGroupNames(configured, m, groupNames)
+
+		assert.True(t, m.Destination.Attributes()["port_group_id"].IsNull())
+		assert.Equal(t, types.StringValue("NTP Renamed"), m.Destination.Attributes()["port_group_name"])
+		assert.True(t, m.Source.Attributes()["port_group_name"].IsNull())
+	})
+
+	t.Run("deleted group keeps the ID", func(t *testing.T) {
+		m := &firewallPolicyResourceModel{
+			Source:      testEndpointObj("ANY", types.StringNull(), types.StringNull()),
+			Destination: testEndpointObj("OBJECT", types.StringValue("pg-gone"), types.StringNull()),
+		}
+		restorePortGroupNames(configured, m, groupNames)
+
+		assert.Equal(t, types.StringValue("pg-gone"), m.Destination.Attributes()["port_group_id"])
+		assert.True(t, m.Destination.Attributes()["port_group_name"].IsNull())
+	})
+}
+
+func TestClonedAttributes(t *testing.T) {
+	base := firewallPolicyResourceModel{
+		Description: types.StringValue("Built in the UI"),
+		Logging:     types.BoolValue(true),
+		MatchIPSec:  types.BoolNull(),
+		Source:      testEndpointObj("ANY", types.StringNull(), types.StringNull()),
+		Destination: testEndpointObj("OBJECT", types.StringValue("pg-1"), types.StringNull()),
+		Schedule:    types.ObjectNull(scheduleAttrTypes),
+	}
+	configured := firewallPolicyResourceModel{
+		CloneFromPolicyID: types.StringValue("pol-template"),
+		Description:       types.StringNull(),
+		Logging:           types.BoolValue(false),
+		MatchIPSec:        types.BoolNull(),
+		Source:            types.ObjectNull(endpointAttrTypes),
+		Destination:       testEndpointObj("ANY", types.StringNull(), types.StringNull()),
+		Schedule:          types.ObjectNull(scheduleAttrTypes),
+	}
+
+	t.Run("unset attributes are inherited", func(t *testing.T) {
+		m := configured
+		inheritClonedAttributes(&m, &base)
+
+		assert.Equal(t, types.StringValue("Built in the UI"), m.Description)
+		assert.Equal(t, types.BoolValue(false), m.Logging, "configured value overrides the template")
+		assert.Equal(t, base.Source, m.Source)
+		assert.Equal(t, configured.Destination, m.Destination)
+		assert.True(t, m.Schedule.IsNull())
+	})
+
+	t.Run("inherited attributes are masked in state", func(t *testing.T) {
+		m := configured
+		inheritClonedAttributes(&m, &base)
+		maskClonedAttributes(&configured, &m)
+
+		assert.True(t, m.Description.IsNull())
+		assert.True(t, m.Source.IsNull())
+		assert.Equal(t, types.BoolValue(false), m.Logging)
+		assert.Equal(t, configured.Destination, m.Destination)
+	})
+
+	t.Run("no masking without clone_from_policy_id", func(t *testing.T) {
+		plain := configured
+		plain.CloneFromPolicyID = types.StringNull()
+		m := base
+		maskClonedAttributes(&plain, &m)
+
+		assert.Equal(t, base.Description, m.Description)
+		assert.Equal(t, base.Source, m.Source)
+	})
+}
+
+func TestMaskUnmanagedSchedule(t *testing.T) {
+	sched := scheduleAPIToModel(&firewallPolicyScheduleRequest{Mode: "EVERY_DAY", TimeRangeStart: "08:00", TimeRangeEnd: "17:00"})
+
+	t.Run("unmanaged schedule is masked", func(t *testing.T) {
+		m := firewallPolicyResourceModel{ManageSchedule: types.BoolValue(false), Schedule: sched}
+		maskUnmanagedSchedule(&m)
+		assert.True(t, m.Schedule.IsNull())
+	})
+
+	t.Run("managed schedule is kept", func(t *testing.T) {
+		m := firewallPolicyResourceModel{ManageSchedule: types.BoolValue(true), Schedule: sched}
+		maskUnmanagedSchedule(&m)
+		assert.Equal(t, sched, m.Schedule)
+	})
+
+	t.Run("imported policy is treated as managed", func(t *testing.T) {
+		m := firewallPolicyResourceModel{ManageSchedule: types.BoolNull(), Schedule: sched}
+		maskUnmanagedSchedule(&m)
+		assert.Equal(t, sched, m.Schedule)
+	})
+}
+
+// testEndpointObj builds an endpoint object with the given port matching
+// fields and everything else null.
+func testEndpointObj(portMatchingType string, portGroupID, portGroupName types.String) types.Object {
+	return types.ObjectValueMust(endpointAttrTypes, map[string]attr.Value{
+		"zone_id":              types.StringValue("zone-1"),
+		"ips":                  types.SetNull(types.StringType),
+		"mac_addresses":        types.SetNull(types.StringType),
+		"network_ids":          types.SetNull(types.StringType),
+		"device_ids":           types.SetNull(types.StringType),
+		"port_matching_type":   types.StringValue(portMatchingType),
+		"port":                 types.Int64Null(),
+		"port_group_id":        portGroupID,
+		"port_group_name":      portGroupName,
+		"match_opposite_ports": types.BoolNull(),
+		"match_opposite_ips":   types.BoolNull(),
+	})
+}
+
 func TestBuildEndpointRequest(t *testing.T) {
 	t.Run("MAC matching sends values in macs field", func(t *testing.T) {
 		ep := buildEndpointRequest("zone1", "MAC", []string{"aa:bb:cc:dd:ee:ff"}, "ANY", nil, "", false, false)
@@ -1662,6 +1949,109 @@ func TestResolveIPs(t *testing.T) {
 	})
 }
 
+func TestAllowRespondCompanions(t *testing.T) {
+	policy := func(id, name, action, stateType, src, dst string) *unifi.FirewallPolicy {
+		return &unifi.FirewallPolicy{
+			ID:                  id,
+			Name:                name,
+			Action:              action,
+			ConnectionStateType: stateType,
+			Source:              &unifi.FirewallPolicySource{ZoneID: src},
+			Destination:         &unifi.FirewallPolicyDestination{ZoneID: dst},
+		}
+	}
+	ids := func(policies []*unifi.FirewallPolicy) []string {
+		var ids []string
+		for _, p := range policies {
+			ids = append(ids, p.ID)
+		}
+		return ids
+	}
+
+	parent := policy("p1", "lan-to-iot", "ALLOW", "ALL", "lan", "iot")
+	parent.CreateAllowRespond = true
+	companion := policy("p2", "lan-to-iot", "ALLOW", "RESPOND_ONLY", "iot", "lan")
+	predefined := policy("p3", "lan-to-iot", "ALLOW", "RESPOND_ONLY", "iot", "lan")
+	predefined.Predefined = true
+	policies := []*unifi.FirewallPolicy{
+		parent,
+		companion,
+		predefined,
+		policy("p4", "lan-to-iot", "ALLOW", "RESPOND_ONLY", "lan", "iot"), // same direction
+		policy("p5", "lan-to-iot", "BLOCK", "RESPOND_ONLY", "iot", "lan"), // not an allow
+		policy("p6", "other", "ALLOW", "RESPOND_ONLY", "iot", "lan"),      // different name
+		// The name only shares a prefix with the parent's.
+		policy("p7", "lan-to-iot (Return)", "ALLOW", "RESPOND_ONLY", "iot", "lan"),
+	}
+
+	got, ambiguous := allowRespondCompanions("p1", policies)
+	assert.Equal(t, []string{"p2"}, ids(got))
+	assert.Empty(t, ambiguous)
+
+	t.Run("parents whose names share a prefix", func(t *testing.T) {
+		iot := policy("a1", "IoT", "ALLOW", "ALL", "lan", "iot")
+		iot.CreateAllowRespond = true
+		cameras := policy("b1", "IoT cameras", "ALLOW", "ALL", "lan", "iot")
+		cameras.CreateAllowRespond = true
+		policies := []*unifi.FirewallPolicy{
+			iot,
+			policy("a2", "IoT", "ALLOW", "RESPOND_ONLY", "iot", "lan"),
+			cameras,
+			policy("b2", "IoT cameras", "ALLOW", "RESPOND_ONLY", "iot", "lan"),
+		}
+
+		got, ambiguous := allowRespondCompanions("a1", policies)
+		assert.Equal(t, []string{"a2"}, ids(got))
+		assert.Empty(t, ambiguous)
+
+		got, ambiguous = allowRespondCompanions("b1", policies)
+		assert.Equal(t, []string{"b2"}, ids(got))
+		assert.Empty(t, ambiguous)
+	})
+
+	t.Run("parents with the same name and zones", func(t *testing.T) {
+		twin := policy("p8", "lan-to-iot", "ALLOW", "ALL", "lan", "iot")
+		twin.CreateAllowRespond = true
+		got, ambiguous := allowRespondCompanions("p1", append(policies, twin))
+		assert.Empty(t, got)
+		assert.Equal(t, []string{"p2"}, ids(ambiguous))
+	})
+
+	t.Run("parent without create_allow_respond", func(t *testing.T) {
+		parent.CreateAllowRespond = false
+		defer func() { parent.CreateAllowRespond = true }()
+		got, ambiguous := allowRespondCompanions("p1", policies)
+		assert.Empty(t, got)
+		assert.Empty(t, ambiguous)
+	})
+
+	t.Run("parent not found", func(t *testing.T) {
+		got, ambiguous := allowRespondCompanions("missing", policies)
+		assert.Empty(t, got)
+		assert.Empty(t, ambiguous)
+	})
+}
+
+func TestIPEntryVersion(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want string
+	}{
+		{"10.0.0.1", "IPV4"},
+		{"10.0.0.0/24", "IPV4"},
+		{"10.0.0.10-10.0.0.20", "IPV4"},
+		{"2001:db8::1", "IPV6"},
+		{"2001:db8::/32", "IPV6"},
+		{"2001:db8::1-2001:db8::ff", "IPV6"},
+		{"not-an-ip", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, ipEntryVersion(tt.ip))
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests
 // ---------------------------------------------------------------------------
@@ -2291,6 +2681,75 @@ resource "terrifi_firewall_policy" "test" {
 	})
 }
 
+func TestAccFirewallPolicy_toggleEnabledPreservesUIEdits(t *testing.T) {
+	zone1Name := fmt.Sprintf("tfacc-pol-tog-z1-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-pol-tog-z2-%s", randomSuffix())
+	policyName := fmt.Sprintf("tfacc-pol-toggle-%s", randomSuffix())
+
+	zonesConfig := testAccFirewallPolicyZonesConfig(zone1Name, zone2Name)
+	config := func(enabled bool) string {
+		return zonesConfig + fmt.Sprintf(`
+resource "terrifi_firewall_policy" "test" {
+  name        = %q
+  description = "managed by terraform"
+  action      = "BLOCK"
+  enabled     = %t
+
+  source {
+    zone_id = terrifi_firewall_zone.zone1.id
+  }
+
+  destination {
+    zone_id = terrifi_firewall_zone.zone2.id
+  }
+}
+`, policyName, enabled)
+	}
+
+	var policyID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: func(s *terraform.State) error {
+					policyID = s.RootModule().Resources["terrifi_firewall_policy.test"].Primary.ID
+					return nil
+				},
+			},
+			{
+				// Simulate someone editing the description in the UI, then
+				// disable the policy from Terraform. The toggle must not
+				// overwrite the UI edit; it shows up as drift instead.
+				PreConfig: func() {
+					client := testAccGetClient(t)
+					policy, err := client.GetFirewallPolicy(t.Context(), "default", policyID)
+					require.NoError(t, err)
+					policy.Description = "edited in the UI"
+					_, err = client.UpdateFirewallPolicy(t.Context(), "default", policy.FirewallPolicy, policy.RawSchedule)
+					require.NoError(t, err)
+				},
+				Config:             config(false),
+				ExpectNonEmptyPlan: true,
+				Check: func(s *terraform.State) error {
+					policy, err := testAccGetClient(t).GetFirewallPolicy(t.Context(), "default", policyID)
+					if err != nil {
+						return err
+					}
+					if policy.Enabled {
+						return fmt.Errorf("expected policy to be disabled")
+					}
+					if policy.Description != "edited in the UI" {
+						return fmt.Errorf("expected UI description to be preserved, got %q", policy.Description)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
 func TestAccFirewallPolicy_logging(t *testing.T) {
 	zone1Name := fmt.Sprintf("tfacc-pol-log-z1-%s", randomSuffix())
 	zone2Name := fmt.Sprintf("tfacc-pol-log-z2-%s", randomSuffix())
@@ -3103,6 +3562,164 @@ resource "terrifi_firewall_policy" "test" {
 	})
 }
 
+func TestAccFirewallPolicy_portGroupName(t *testing.T) {
+	zone1Name := fmt.Sprintf("tfacc-pol-pgn-z1-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-pol-pgn-z2-%s", randomSuffix())
+	groupName := fmt.Sprintf("tfacc-pol-pgn-grp-%s", randomSuffix())
+	policyName := fmt.Sprintf("tfacc-pol-pgn-%s", randomSuffix())
+
+	config := testAccFirewallPolicyPortGroupNameConfig(zone1Name, zone2Name, groupName, policyName)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.test", "destination.port_group_name", groupName),
+					resource.TestCheckNoResourceAttr("terrifi_firewall_policy.test", "destination.port_group_id"),
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.test", "destination.port_matching_type", "OBJECT"),
+				),
+			},
+			// No drift on re-apply.
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccFirewallPolicyPortGroupNameConfig(zone1Name, zone2Name, groupName, policyName string) string {
+	return testAccFirewallPolicyZonesConfig(zone1Name, zone2Name) + fmt.Sprintf(`
+resource "terrifi_firewall_group" "test_ports" {
+  name    = %q
+  type    = "port-group"
+  members = ["123"]
+}
+
+resource "terrifi_firewall_policy" "test" {
+  name     = %q
+  action   = "BLOCK"
+  protocol = "udp"
+
+  source {
+    zone_id = terrifi_firewall_zone.zone1.id
+  }
+
+  destination {
+    zone_id            = terrifi_firewall_zone.zone2.id
+    port_matching_type = "OBJECT"
+    port_group_name    = terrifi_firewall_group.test_ports.name
+  }
+}
+`, groupName, policyName)
+}
+
+func TestAccFirewallPolicy_validationObjectRequiresPortGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_firewall_policy" "test" {
+  name   = "tfacc-invalid"
+  action = "BLOCK"
+
+  source {
+    zone_id = "000000000000000000000000"
+  }
+
+  destination {
+    zone_id            = "000000000000000000000001"
+    port_matching_type = "OBJECT"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing Port Group`),
+			},
+		},
+	})
+}
+
+func TestAccFirewallPolicy_validationPortGroupIDAndName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_firewall_policy" "test" {
+  name   = "tfacc-invalid"
+  action = "BLOCK"
+
+  source {
+    zone_id = "000000000000000000000000"
+  }
+
+  destination {
+    zone_id            = "000000000000000000000001"
+    port_matching_type = "OBJECT"
+    port_group_id      = "000000000000000000000002"
+    port_group_name    = "NTP"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccFirewallPolicy_validationIPVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_firewall_policy" "test" {
+  name       = "tfacc-invalid"
+  action     = "BLOCK"
+  ip_version = "IPV6"
+
+  source {
+    zone_id = "000000000000000000000000"
+    ips     = ["10.0.0.0/24"]
+  }
+
+  destination {
+    zone_id = "000000000000000000000001"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`IP Version Mismatch`),
+			},
+			{
+				Config: `
+resource "terrifi_firewall_policy" "test" {
+  name       = "tfacc-invalid"
+  action     = "BLOCK"
+  ip_version = "IPV4"
+
+  source {
+    zone_id = "000000000000000000000000"
+  }
+
+  destination {
+    zone_id = "000000000000000000000001"
+    ips     = ["2001:db8::/32"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`IP Version Mismatch`),
+			},
+		},
+	})
+}
+
 func TestAccFirewallPolicy_customConnectionStateType(t *testing.T) {
 	zone1Name := fmt.Sprintf("tfacc-pol-cst-z1-%s", randomSuffix())
 	zone2Name := fmt.Sprintf("tfacc-pol-cst-z2-%s", randomSuffix())
@@ -3427,6 +4044,142 @@ resource "terrifi_firewall_policy" "test" {
 	})
 }
 
+func TestAccFirewallPolicy_cloneFromPolicy(t *testing.T) {
+	zone1Name := fmt.Sprintf("tfacc-pol-clone-z1-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-pol-clone-z2-%s", randomSuffix())
+	templateName := fmt.Sprintf("tfacc-pol-template-%s", randomSuffix())
+	cloneName := fmt.Sprintf("tfacc-pol-clone-%s", randomSuffix())
+
+	templateConfig := testAccFirewallPolicyZonesConfig(zone1Name, zone2Name) + fmt.Sprintf(`
+resource "terrifi_firewall_policy" "template" {
+  name        = %q
+  action      = "BLOCK"
+  protocol    = "tcp"
+  description = "Built in the UI"
+
+  source {
+    zone_id = terrifi_firewall_zone.zone1.id
+    ips     = ["10.0.0.0/8", "192.168.0.0/16"]
+  }
+
+  destination {
+    zone_id = terrifi_firewall_zone.zone2.id
+  }
+}
+`, templateName)
+
+	cloneConfig := func(action string) string {
+		return templateConfig + fmt.Sprintf(`
+resource "terrifi_firewall_policy" "clone" {
+  name                 = %q
+  action               = %q
+  clone_from_policy_id = terrifi_firewall_policy.template.id
+}
+`, cloneName, action)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: templateConfig,
+			},
+			{
+				Config: cloneConfig("ALLOW"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.clone", "action", "ALLOW"),
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.clone", "protocol", "tcp"),
+					resource.TestCheckNoResourceAttr("terrifi_firewall_policy.clone", "description"),
+					resource.TestCheckNoResourceAttr("terrifi_firewall_policy.clone", "source.zone_id"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   cloneConfig("ALLOW"),
+				PlanOnly: true,
+			},
+			{
+				// Updating a managed attribute keeps the inherited ones.
+				Config: cloneConfig("REJECT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.clone", "action", "REJECT"),
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.clone", "protocol", "tcp"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallPolicy_unmanagedSchedule(t *testing.T) {
+	zone1Name := fmt.Sprintf("tfacc-pol-us-z1-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-pol-us-z2-%s", randomSuffix())
+	policyName := fmt.Sprintf("tfacc-pol-unsched-%s", randomSuffix())
+
+	zonesConfig := testAccFirewallPolicyZonesConfig(zone1Name, zone2Name)
+	config := func(description, extra string) string {
+		return zonesConfig + fmt.Sprintf(`
+resource "terrifi_firewall_policy" "test" {
+  name        = %q
+  action      = "BLOCK"
+  description = %q
+
+  source {
+    zone_id = terrifi_firewall_zone.zone1.id
+  }
+
+  destination {
+    zone_id = terrifi_firewall_zone.zone2.id
+  }
+%s}
+`, policyName, description, extra)
+	}
+	schedule := `
+  schedule {
+    mode             = "EVERY_DAY"
+    time_range_start = "08:00"
+    time_range_end   = "17:00"
+  }
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Stands in for a schedule configured in the UI.
+			{
+				Config: config("first", schedule),
+				Check:  resource.TestCheckResourceAttr("terrifi_firewall_policy.test", "schedule.mode", "EVERY_DAY"),
+			},
+			// Leaving the schedule to the controller must not reset it to ALWAYS.
+			{
+				Config: config("second", "  manage_schedule = false\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.test", "description", "second"),
+					resource.TestCheckResourceAttr("terrifi_firewall_policy.test", "manage_schedule", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_firewall_policy.test", "schedule.mode"),
+				),
+			},
+			// An import reads the schedule the controller kept.
+			{
+				ResourceName: "terrifi_firewall_policy.test",
+				ImportState:  true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if mode := states[0].Attributes["schedule.mode"]; mode != "EVERY_DAY" {
+						return fmt.Errorf("expected schedule.mode EVERY_DAY, got %q", mode)
+					}
+					return nil
+				},
+			},
+			{
+				Config:      config("second", "  manage_schedule = false\n"+schedule),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting Schedule Configuration`),
+			},
+		},
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Test helpers
 // ---------------------------------------------------------------------------
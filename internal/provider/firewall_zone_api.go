@@ -3,7 +3,7 @@ package provider
 // TODO(go-unifi): This entire file is a workaround for bugs in the go-unifi
 // SDK (github.com/ubiquiti-community/go-unifi). When the upstream SDK fixes
 // these issues, this file can be deleted and the firewall zone resource can
-// use the SDK's built-in methods directly (c.ApiClient.Get/Create/Update/
+// use the SDK's built-in methods directly (Get/Create/Update/
 // DeleteFirewallZone). The upstream bugs are:
 //
 //  1. unifi.FirewallZone serializes `"default_zone": false` (no omitempty),
@@ -28,7 +28,8 @@ package provider
 //     use the v2 endpoint (which does return network_ids), this mismatch
 //     causes Terraform to see empty network_ids on refresh, producing a
 //     non-empty plan diff and flaky acceptance tests.
-//     Fix needed in SDK: use the v2 GET endpoint for firewall zones.
+//     Fix needed in SDK: use the v2 GET endpoint for firewall zones (for
+//     both Get and List).
 
 import (
 	"bytes"
@@ -57,16 +58,27 @@ type firewallZoneUpdateRequest struct {
 	NetworkIDs []string `json:"network_ids"`
 }
 
+// ListFirewallZone lists firewall zones via the v2 API, bypassing the SDK to
+// avoid bug #4 (v1 endpoint doesn't return network_ids consistently).
+// This method shadows the SDK's promoted ListFirewallZone on ApiClient.
+func (c *Client) ListFirewallZone(ctx context.Context, site string) ([]unifi.FirewallZone, error) {
+	var zones []unifi.FirewallZone
+	err := c.doV2Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/v2/api/site/%s/firewall/zone", c.BaseURL, c.APIPath, site),
+		struct{}{}, &zones)
+	if err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
 // GetFirewallZone reads a firewall zone via the v2 API, bypassing the SDK
 // to avoid bug #4 (v1 endpoint doesn't return network_ids consistently).
 // The v2 API does not support GET on individual zones, so we list all zones
 // and filter by ID (same pattern as GetFirewallPolicy).
 // This method shadows the SDK's promoted GetFirewallZone on ApiClient.
 func (c *Client) GetFirewallZone(ctx context.Context, site string, id string) (*unifi.FirewallZone, error) {
-	var zones []unifi.FirewallZone
-	err := c.doV2Request(ctx, http.MethodGet,
-		fmt.Sprintf("%s%s/v2/api/site/%s/firewall/zone", c.BaseURL, c.APIPath, site),
-		struct{}{}, &zones)
+	zones, err := c.ListFirewallZone(ctx, site)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +149,13 @@ func (c *Client) DeleteFirewallZone(ctx context.Context, site string, id string)
 // by URL and subsequent GETs return cached bytes without hitting the controller.
 // Any non-GET request (POST, PUT, DELETE) invalidates the entire cache to ensure
 // subsequent reads see fresh data.
+//
+// Independently of caching, concurrent GETs of the same URL are merged into a
+// single request whose response every caller decodes separately. Terraform
+// reads data sources and refreshes resources in parallel, so several data
+// sources listing clients or policies would otherwise each issue the same
+// list call. A GET never joins one that started before the latest write, so
+// callers still see their own changes.
 func (c *Client) doV2Request(ctx context.Context, method, url string, body any, result any) error {
 	// Cache hit path: return cached bytes for GET requests without making an HTTP call.
 	if method == http.MethodGet && c.cache != nil {
@@ -155,32 +174,33 @@ func (c *Client) doV2Request(ctx context.Context, method, url string, body any,
 		return fmt.Errorf("marshaling request body: %w", err)
 	}
 
-	req, err := retryablehttp.NewRequestWithContext(ctx, method, url, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return fmt.Errorf("creating request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	// Replicate the SDK's auth logic: API key takes precedence over CSRF token.
-	if c.APIKey != "" {
-		req.Header.Set("X-API-Key", c.APIKey)
-	} else if c.csrf != "" {
-		req.Header.Set("X-Csrf-Token", c.csrf)
-	}
-
-	resp, err := c.HTTP.Do(req)
-	if err != nil {
-		return fmt.Errorf("performing request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	respBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("reading response: %w", err)
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("(%d) for %s %s\npayload: %s\nresponse: %s", resp.StatusCode, method, url, string(bodyBytes), string(respBytes))
+	var respBytes []byte
+	if method == http.MethodGet {
+		key := fmt.Sprintf("%d %s", c.writes.Load(), url)
+		// The shared request runs detached from the caller that started it,
+		// so that caller being canceled doesn't fail the others merged into
+		// it; each caller stops waiting when its own ctx is done. The HTTP
+		// client's timeout still bounds the request.
+		ch := c.reads.DoChan(key, func() (any, error) {
+			return c.sendRequest(context.WithoutCancel(ctx), method, url, bodyBytes)
+		})
+		select {
+		case res := <-ch:
+			if res.Err != nil {
+				return res.Err
+			}
+			respBytes = res.Val.([]byte)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		respBytes, err = c.sendRequest(ctx, method, url, bodyBytes)
+		// Counted even on failure: the write may have been applied before
+		// the error, and a stale merged read is worse than an extra request.
+		c.writes.Add(1)
+		if err != nil {
+			return err
+		}
 	}
 
 	// Cache management: store GET responses, invalidate on writes.
@@ -200,3 +220,80 @@ func (c *Client) doV2Request(ctx context.Context, method, url string, body any,
 
 	return nil
 }
+
+// sendRequest performs a single HTTP request for doV2Request and returns the
+// response body, or an error for non-2xx responses. When a username and
+// password session is rejected with 401, it logs in again and retries the
+// request once, so that a session expiring during a long apply doesn't fail
+// the remaining resources.
+//
+// GETs are conditional when an earlier response for the URL carried an ETag
+// or Last-Modified header (see conditionalCache), and a 304 answer returns
+// the earlier body. Responses are gzip-compressed when the controller
+// supports it: the transport asks for gzip and decompresses transparently, as
+// long as no Accept-Encoding header is set here.
+func (c *Client) sendRequest(ctx context.Context, method, url string, bodyBytes []byte) ([]byte, error) {
+	var cond *conditionalEntry
+	if method == http.MethodGet {
+		if e, ok := c.conditional.get(url); ok {
+			cond = &e
+		}
+	}
+
+	csrf, session := c.auth()
+	status, header, respBytes, err := c.sendRequestOnce(ctx, method, url, bodyBytes, csrf, cond)
+	if err == nil && status == http.StatusUnauthorized && c.canRelogin() {
+		if err := c.relogin(ctx, session); err != nil {
+			return nil, err
+		}
+		csrf, _ = c.auth()
+		status, header, respBytes, err = c.sendRequestOnce(ctx, method, url, bodyBytes, csrf, cond)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusNotModified && cond != nil {
+		return cond.body, nil
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("(%d) for %s %s\npayload: %s\nresponse: %s", status, method, url, string(bodyBytes), string(respBytes))
+	}
+	if method == http.MethodGet {
+		c.conditional.update(url, header, respBytes)
+	}
+	return respBytes, nil
+}
+
+// sendRequestOnce performs one attempt of sendRequest and returns the
+// status code, headers and body of the response. A non-nil cond makes the
+// request conditional on that earlier response.
+func (c *Client) sendRequestOnce(ctx context.Context, method, url string, bodyBytes []byte, csrf string, cond *conditionalEntry) (int, http.Header, []byte, error) {
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if cond != nil {
+		cond.setHeaders(req)
+	}
+
+	// Replicate the SDK's auth logic: API key takes precedence over CSRF token.
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	} else if csrf != "" {
+		req.Header.Set("X-Csrf-Token", csrf)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("performing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.StatusCode, resp.Header, respBytes, nil
+}
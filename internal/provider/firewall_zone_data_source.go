@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &firewallZoneDataSource{}
+
+func NewFirewallZoneDataSource() datasource.DataSource {
+	return &firewallZoneDataSource{}
+}
+
+type firewallZoneDataSource struct {
+	client ClientAPI
+}
+
+type firewallZoneDataSourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Site       types.String `tfsdk:"site"`
+	Name       types.String `tfsdk:"name"`
+	NetworkIDs types.Set    `tfsdk:"network_ids"`
+}
+
+func (d *firewallZoneDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_zone"
+}
+
+func (d *firewallZoneDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a firewall zone by name. Useful for referencing zones that are not managed " +
+			"by Terraform, such as the built-in Internal, External, and Gateway zones.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone to look up.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to look up the zone in. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the zone.",
+				Computed:            true,
+			},
+
+			"network_ids": schema.SetAttribute{
+				MarkdownDescription: "The IDs of the networks in the zone.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *firewallZoneDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *firewallZoneDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config firewallZoneDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+	name := config.Name.ValueString()
+
+	zones, err := d.client.ListFirewallZone(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Firewall Zones",
+			fmt.Sprintf("Could not list firewall zones in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	for _, zone := range zones {
+		if zone.Name != name {
+			continue
+		}
+		networkIDs, diags := types.SetValueFrom(ctx, types.StringType, zone.NetworkIDs)
+		resp.Diagnostics.Append(diags...)
+
+		config.ID = types.StringValue(zone.ID)
+		config.Site = types.StringValue(site)
+		config.NetworkIDs = networkIDs
+		resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Zone Not Found",
+		fmt.Sprintf("No firewall zone named %q in site %q.", name, site),
+	)
+}
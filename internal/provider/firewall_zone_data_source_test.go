@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccFirewallZoneDataSource_basic(t *testing.T) {
+	zoneName := fmt.Sprintf("tfacc-zone-ds-%s", randomSuffix())
+	netName := fmt.Sprintf("tfacc-net-%s", randomSuffix())
+	vlan := randomVLAN()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+}
+
+resource "terrifi_firewall_zone" "test" {
+  name        = %q
+  network_ids = [terrifi_network.test.id]
+}
+
+data "terrifi_firewall_zone" "test" {
+  name       = %q
+  depends_on = [terrifi_firewall_zone.test]
+}
+`, netName, vlan, vlan/256, vlan%256, zoneName, zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.terrifi_firewall_zone.test", "id",
+						"terrifi_firewall_zone.test", "id",
+					),
+					resource.TestCheckResourceAttr("data.terrifi_firewall_zone.test", "network_ids.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallZoneDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "terrifi_firewall_zone" "test" {
+  name = "tfacc-missing-%s"
+}
+`, randomSuffix()),
+				ExpectError: regexp.MustCompile(`Zone Not Found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &firewallZoneNetworkResource{}
+	_ resource.ResourceWithImportState = &firewallZoneNetworkResource{}
+)
+
+func NewFirewallZoneNetworkResource() resource.Resource {
+	return &firewallZoneNetworkResource{}
+}
+
+type firewallZoneNetworkResource struct {
+	client ClientAPI
+}
+
+type firewallZoneNetworkResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Site      types.String `tfsdk:"site"`
+	ZoneID    types.String `tfsdk:"zone_id"`
+	NetworkID types.String `tfsdk:"network_id"`
+}
+
+func (r *firewallZoneNetworkResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_firewall_zone_network"
+}
+
+func (r *firewallZoneNetworkResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Adds one network to a firewall zone without managing the zone's other members. " +
+			"Leave `network_ids` unset on the `terrifi_firewall_zone`, or the two resources will undo each " +
+			"other's changes.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the association, `<zone_id>:<network_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site the zone and network belong to. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the firewall zone. Use the `id` of a `terrifi_firewall_zone` resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network. Use the `id` of a `terrifi_network` resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *firewallZoneNetworkResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *firewallZoneNetworkResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan firewallZoneNetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	zoneID, networkID := plan.ZoneID.ValueString(), plan.NetworkID.ValueString()
+
+	if err := assignNetworkZone(ctx, r.client, site, networkID, zoneID); err != nil {
+		resp.Diagnostics.AddError("Error Adding Network to Firewall Zone", err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(zoneID + ":" + networkID)
+	plan.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *firewallZoneNetworkResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state firewallZoneNetworkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	zones, err := r.client.ListFirewallZone(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Firewall Zones",
+			fmt.Sprintf("Could not list firewall zones in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	// The association is gone when the zone or network was deleted, or the
+	// network was moved to another zone.
+	zone := firewallZoneForNetwork(zones, state.NetworkID.ValueString())
+	if zone == nil || zone.ID != state.ZoneID.ValueString() {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(state.ZoneID.ValueString() + ":" + state.NetworkID.ValueString())
+	state.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes: every argument requires replacement.
+// It only carries the plan over to state.
+func (r *firewallZoneNetworkResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan firewallZoneNetworkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *firewallZoneNetworkResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state firewallZoneNetworkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := removeNetworkFromZone(ctx, r.client, site, state.NetworkID.ValueString(), state.ZoneID.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Error Removing Network from Firewall Zone", err.Error())
+	}
+}
+
+func (r *firewallZoneNetworkResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is "zone_id:network_id", optionally prefixed with "site:".
+	parts := strings.Split(req.ID, ":")
+	switch len(parts) {
+	case 3:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		parts = parts[1:]
+	case 2:
+	default:
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected <zone_id>:<network_id> or <site>:<zone_id>:<network_id>, got %q.", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[0]+":"+parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("zone_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("network_id"), parts[1])...)
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestFirewallZoneNetworkCRUD(t *testing.T) {
+	f := newFakeClient()
+	f.zones["iot"] = unifi.FirewallZone{ID: "iot", Name: "IoT", NetworkIDs: []string{"net-cameras"}}
+	f.zones["lan"] = unifi.FirewallZone{ID: "lan", Name: "LAN"}
+	r := &firewallZoneNetworkResource{client: f}
+
+	created, diags := testCreate(t, r, firewallZoneNetworkResourceModel{
+		ID:        types.StringUnknown(),
+		Site:      types.StringUnknown(),
+		ZoneID:    types.StringValue("iot"),
+		NetworkID: types.StringValue("net-plugs"),
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "iot:net-plugs", created.ID.ValueString())
+	assert.Equal(t, "default", created.Site.ValueString())
+	assert.Equal(t, []string{"net-cameras", "net-plugs"}, f.zones["iot"].NetworkIDs, "other members are kept")
+
+	t.Run("read", func(t *testing.T) {
+		got, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "%v", diags)
+		require.NotNil(t, got)
+		assert.Equal(t, "iot:net-plugs", got.ID.ValueString())
+	})
+
+	t.Run("read after the network moved", func(t *testing.T) {
+		moved := *created
+		moved.ZoneID = types.StringValue("lan")
+		got, diags := testRead(t, r, moved)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Nil(t, got, "removed from state")
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		f.failNext("ListFirewallZone", errors.New("boom"))
+		_, diags := testRead(t, r, *created)
+		assert.True(t, diags.HasError())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		diags := testDelete(t, r, *created)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, []string{"net-cameras"}, f.zones["iot"].NetworkIDs)
+
+		// Deleting again, e.g. after the zone was emptied in the UI, is a no-op.
+		diags = testDelete(t, r, *created)
+		require.False(t, diags.HasError(), "%v", diags)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccFirewallZoneNetwork_basic(t *testing.T) {
+	zoneName := fmt.Sprintf("tfacc-zone-assoc-%s", randomSuffix())
+	net1Name := fmt.Sprintf("tfacc-net-assoc1-%s", randomSuffix())
+	net2Name := fmt.Sprintf("tfacc-net-assoc2-%s", randomSuffix())
+	vlan1, vlan2 := randomVLAN(), randomVLAN()
+	for vlan2 == vlan1 {
+		vlan2 = randomVLAN()
+	}
+
+	config := func(withSecond bool) string {
+		c := fmt.Sprintf(`
+resource "terrifi_firewall_zone" "shared" {
+  name = %q
+}
+
+resource "terrifi_network" "one" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+}
+
+resource "terrifi_network" "two" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+}
+
+resource "terrifi_firewall_zone_network" "one" {
+  zone_id    = terrifi_firewall_zone.shared.id
+  network_id = terrifi_network.one.id
+}
+`, zoneName, net1Name, vlan1, vlan1/256, vlan1%256, net2Name, vlan2, vlan2/256, vlan2%256)
+		if withSecond {
+			c += `
+resource "terrifi_firewall_zone_network" "two" {
+  zone_id    = terrifi_firewall_zone.shared.id
+  network_id = terrifi_network.two.id
+}
+`
+		}
+		return c
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Both associations are created in the same apply.
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("terrifi_firewall_zone_network.one", "network_id", "terrifi_network.one", "id"),
+					resource.TestCheckResourceAttrPair("terrifi_firewall_zone_network.two", "network_id", "terrifi_network.two", "id"),
+				),
+			},
+			{
+				// The zone picks up both members on refresh.
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.shared", "network_ids.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_firewall_zone_network.one",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removing one association leaves the other network in the zone.
+				Config: config(false),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.shared", "network_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("terrifi_firewall_zone.shared", "network_ids.*", "terrifi_network.one", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallZoneNetwork_invalidImportID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_firewall_zone_network" "test" {
+  zone_id    = "000000000000000000000000"
+  network_id = "000000000000000000000001"
+}
+`,
+				ResourceName:  "terrifi_firewall_zone_network.test",
+				ImportState:   true,
+				ImportStateId: "only-one-part",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
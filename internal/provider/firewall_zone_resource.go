@@ -3,23 +3,28 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
 var (
 	_ resource.Resource                = &firewallZoneResource{}
 	_ resource.ResourceWithImportState = &firewallZoneResource{}
+	_ resource.ResourceWithModifyPlan  = &firewallZoneResource{}
 )
 
 func NewFirewallZoneResource() resource.Resource {
@@ -27,7 +32,7 @@ func NewFirewallZoneResource() resource.Resource {
 }
 
 type firewallZoneResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type firewallZoneResourceModel struct {
@@ -36,6 +41,18 @@ type firewallZoneResourceModel struct {
 	Name       types.String `tfsdk:"name"`
 	NetworkIDs types.Set    `tfsdk:"network_ids"`
 	ZoneKey    types.String `tfsdk:"zone_key"`
+
+	// PoliciesAttached counts the user-defined policies that use the zone.
+	// It comes from the policy list, not the zone object.
+	PoliciesAttached types.Int64 `tfsdk:"policies_attached"`
+
+	// Description and Tags are not sent to the controller. The v2 zone API
+	// has no metadata fields, so these are kept in Terraform state only.
+	Description types.String `tfsdk:"description"`
+	Tags        types.Set    `tfsdk:"tags"`
+
+	// DeletePolicies is a provider-side flag that controls destroy behavior.
+	DeletePolicies types.Bool `tfsdk:"delete_policies"`
 }
 
 func (r *firewallZoneResource) Metadata(
@@ -79,10 +96,12 @@ func (r *firewallZoneResource) Schema(
 			},
 
 			"network_ids": schema.SetAttribute{
-				MarkdownDescription: "Set of network IDs to associate with this firewall zone.",
-				ElementType:         types.StringType,
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "Set of network IDs to associate with this firewall zone. Leave unset when the " +
+					"networks are assigned with `zone_id` on `terrifi_network` or with `terrifi_firewall_zone_network`; " +
+					"the attribute then reports the zone's current members.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
 			},
 
 			"zone_key": schema.StringAttribute{
@@ -92,6 +111,36 @@ func (r *firewallZoneResource) Schema(
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+
+			"policies_attached": schema.Int64Attribute{
+				MarkdownDescription: "The number of user-defined firewall policies with this zone as their source or " +
+					"destination, whether or not they are managed by Terraform. Built-in policies are not counted. " +
+					"Refreshed on every read, so a zone with `0` is unused.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A free-form description of the zone. The controller has no field for zone metadata, " +
+					"so this is stored in Terraform state only and is not visible in the UniFi UI.",
+				Optional: true,
+			},
+
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Set of free-form tags for the zone. Like `description`, these are stored in Terraform state only.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+
+			"delete_policies": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the zone also deletes firewall policies that still reference it " +
+					"(for example policies created outside Terraform or not yet imported). When `false` (the default), " +
+					"destroy fails with a list of the referencing policies instead of leaving the controller to reject " +
+					"the delete. Stored in Terraform state only.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -138,6 +187,8 @@ func (r *firewallZoneResource) Create(
 	}
 
 	r.apiToModel(created, &plan, site)
+	// Policies can't refer to a zone before it exists.
+	plan.PoliciesAttached = types.Int64Value(0)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -168,6 +219,17 @@ func (r *firewallZoneResource) Read(
 	}
 
 	r.apiToModel(zone, &state, site)
+
+	policies, err := r.client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Firewall Zone",
+			fmt.Sprintf("Could not list firewall policies to count references to zone %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+	state.PoliciesAttached = types.Int64Value(int64(len(dependentFirewallPolicies(policies, state.ID.ValueString()))))
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -183,8 +245,21 @@ func (r *firewallZoneResource) Update(
 		return
 	}
 
+	// description, tags and delete_policies are not sent to the controller,
+	// so a change to only those is recorded in state without an API call.
+	stateOnly, err := onlyAttributeChanged(req.State.Raw, req.Plan.Raw, "description", "tags", "delete_policies")
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Firewall Zone", err.Error())
+		return
+	}
+
 	r.applyPlanToState(&plan, &state)
 
+	if stateOnly {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+
 	site := r.client.SiteOrDefault(state.Site)
 	zone := r.modelToAPI(ctx, &state)
 	zone.ID = state.ID.ValueString()
@@ -230,8 +305,57 @@ func (r *firewallZoneResource) Delete(
 	}
 
 	site := r.client.SiteOrDefault(state.Site)
+	zoneID := state.ID.ValueString()
 
-	err := r.client.DeleteFirewallZone(ctx, site, state.ID.ValueString())
+	// Policies managed in the same configuration reference the zone via
+	// zone_id, so Terraform destroys them first. Policies that are not in
+	// state (created in the UI, or not yet imported) have no such ordering,
+	// and the controller refuses to delete a zone that is still referenced.
+	policies, err := r.client.ListFirewallPolicies(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Firewall Zone",
+			fmt.Sprintf("Could not list firewall policies to check for references to zone %s: %s", zoneID, err.Error()),
+		)
+		return
+	}
+
+	dependents := dependentFirewallPolicies(policies, zoneID)
+	if len(dependents) > 0 && !state.DeletePolicies.ValueBool() {
+		names := make([]string, len(dependents))
+		for i, p := range dependents {
+			names[i] = fmt.Sprintf("  - %s (%s)", p.Name, p.ID)
+		}
+		resp.Diagnostics.AddError(
+			"Firewall Zone Has Dependent Policies",
+			fmt.Sprintf("Firewall zone %q is still referenced by the following firewall policies:\n%s\n\n"+
+				"Delete these policies (or import them into Terraform so they are destroyed first), "+
+				"or set delete_policies = true on the zone to delete them automatically.",
+				state.Name.ValueString(), strings.Join(names, "\n")),
+		)
+		return
+	}
+
+	for _, p := range dependents {
+		tflog.Info(ctx, "Deleting firewall policy that references zone", map[string]any{
+			"zone_id":   zoneID,
+			"policy_id": p.ID,
+			"name":      p.Name,
+		})
+		err := r.client.DeleteFirewallPolicy(ctx, site, p.ID)
+		if err != nil {
+			if _, ok := err.(*unifi.NotFoundError); ok {
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Deleting Firewall Zone",
+				fmt.Sprintf("Could not delete dependent firewall policy %q (%s): %s", p.Name, p.ID, err.Error()),
+			)
+			return
+		}
+	}
+
+	err = r.client.DeleteFirewallZone(ctx, site, zoneID)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Firewall Zone", err.Error())
 	}
@@ -253,6 +377,32 @@ func (r *firewallZoneResource) ImportState(
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// ModifyPlan warns, when warn_unzoned_networks is enabled, that networks
+// joining the zone can't be checked at plan time because network_ids is not
+// known until apply (e.g. it references networks created in the same apply).
+func (r *firewallZoneResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to modify.
+	if req.Plan.Raw.IsNull() || r.client == nil || !r.client.WarnUnzonedNetworks() {
+		return
+	}
+
+	var plan firewallZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.NetworkIDs.IsUnknown() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("network_ids"), "Zone Membership Not Checked",
+			fmt.Sprintf("The network_ids of firewall zone %q are not known until apply, so networks joining "+
+				"the zone can't be checked at plan time.", plan.Name.ValueString()))
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helper methods
 // ---------------------------------------------------------------------------
@@ -264,6 +414,111 @@ func (r *firewallZoneResource) applyPlanToState(plan, state *firewallZoneResourc
 	if !plan.NetworkIDs.IsNull() && !plan.NetworkIDs.IsUnknown() {
 		state.NetworkIDs = plan.NetworkIDs
 	}
+	// State-only attributes: the plan is the only source of truth, so a null
+	// plan value means the user removed the attribute.
+	state.Description = plan.Description
+	state.Tags = plan.Tags
+	state.DeletePolicies = plan.DeletePolicies
+}
+
+// dependentFirewallPolicies returns the user-defined policies whose source or
+// destination is the given zone. Predefined policies are owned by the
+// controller and are removed along with the zone.
+func dependentFirewallPolicies(policies []*unifi.FirewallPolicy, zoneID string) []*unifi.FirewallPolicy {
+	var result []*unifi.FirewallPolicy
+	for _, p := range policies {
+		if p.Predefined {
+			continue
+		}
+		if (p.Source != nil && p.Source.ZoneID == zoneID) ||
+			(p.Destination != nil && p.Destination.ZoneID == zoneID) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// firewallZoneForNetwork returns the zone whose network_ids include the given
+// network, or nil if it is not a member of any zone. The controller places
+// each network in at most one zone.
+func firewallZoneForNetwork(zones []unifi.FirewallZone, networkID string) *unifi.FirewallZone {
+	for i := range zones {
+		if slices.Contains(zones[i].NetworkIDs, networkID) {
+			return &zones[i]
+		}
+	}
+	return nil
+}
+
+// zoneMembershipMu serializes changes to zone membership made on behalf of
+// networks. Each change rewrites a zone's whole network list, and the
+// controller loses updates when zones are written concurrently, so several
+// networks joining the same zone in one apply must take turns.
+var zoneMembershipMu sync.Mutex
+
+// assignNetworkZone moves a network into the firewall zone zoneID. The
+// controller keeps each network in at most one zone, so the network is first
+// removed from any other custom zone that lists it; built-in zones release
+// their networks on their own when a custom zone claims them.
+//
+// Membership changes hold zoneMembershipMu for their read-modify-write of
+// the zones' network lists.
+func assignNetworkZone(ctx context.Context, client ClientAPI, site, networkID, zoneID string) error {
+	zoneMembershipMu.Lock()
+	defer zoneMembershipMu.Unlock()
+
+	zones, err := client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return fmt.Errorf("listing firewall zones: %w", err)
+	}
+	target := slices.IndexFunc(zones, func(z unifi.FirewallZone) bool { return z.ID == zoneID })
+	if target < 0 {
+		return fmt.Errorf("firewall zone %s not found", zoneID)
+	}
+
+	for _, zone := range zones {
+		if zone.ID == zoneID || zone.ZoneKey != "" || !slices.Contains(zone.NetworkIDs, networkID) {
+			continue
+		}
+		zone.NetworkIDs = slices.DeleteFunc(slices.Clone(zone.NetworkIDs), func(id string) bool { return id == networkID })
+		if _, err := client.UpdateFirewallZone(ctx, site, &zone); err != nil {
+			return fmt.Errorf("removing network from firewall zone %q: %w", zone.Name, err)
+		}
+	}
+
+	zone := zones[target]
+	if slices.Contains(zone.NetworkIDs, networkID) {
+		return nil
+	}
+	zone.NetworkIDs = append(slices.Clone(zone.NetworkIDs), networkID)
+	if _, err := client.UpdateFirewallZone(ctx, site, &zone); err != nil {
+		return fmt.Errorf("adding network to firewall zone %q: %w", zone.Name, err)
+	}
+	return nil
+}
+
+// removeNetworkFromZone removes a network from the firewall zone zoneID, if
+// it is a member. The controller returns the network to its default zone. A
+// zone that no longer exists is not an error.
+func removeNetworkFromZone(ctx context.Context, client ClientAPI, site, networkID, zoneID string) error {
+	zoneMembershipMu.Lock()
+	defer zoneMembershipMu.Unlock()
+
+	zone, err := client.GetFirewallZone(ctx, site, zoneID)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return nil
+		}
+		return fmt.Errorf("reading firewall zone %s: %w", zoneID, err)
+	}
+	if !slices.Contains(zone.NetworkIDs, networkID) {
+		return nil
+	}
+	zone.NetworkIDs = slices.DeleteFunc(slices.Clone(zone.NetworkIDs), func(id string) bool { return id == networkID })
+	if _, err := client.UpdateFirewallZone(ctx, site, zone); err != nil {
+		return fmt.Errorf("removing network from firewall zone %q: %w", zone.Name, err)
+	}
+	return nil
 }
 
 // networkIDsMatch reports whether two network ID slices contain the same elements
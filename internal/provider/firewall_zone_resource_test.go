@@ -2,15 +2,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
@@ -196,6 +200,202 @@ func TestFirewallZoneApplyPlanToState(t *testing.T) {
 		assert.Equal(t, "New Zone", state.Name.ValueString())
 		assert.Equal(t, 2, len(state.NetworkIDs.Elements()))
 	})
+
+	t.Run("state-only metadata follows the plan", func(t *testing.T) {
+		state := &firewallZoneResourceModel{
+			Name:        types.StringValue("Zone"),
+			Description: types.StringValue("old description"),
+			Tags: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("iot"),
+			}),
+		}
+
+		// Removing description from config should clear it, unlike
+		// controller-backed fields which are preserved when null.
+		plan := &firewallZoneResourceModel{
+			Name:        types.StringValue("Zone"),
+			Description: types.StringNull(),
+			Tags: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("iot"),
+				types.StringValue("untrusted"),
+			}),
+		}
+
+		r.applyPlanToState(plan, state)
+
+		assert.True(t, state.Description.IsNull())
+		assert.Equal(t, 2, len(state.Tags.Elements()))
+	})
+}
+
+func TestDependentFirewallPolicies(t *testing.T) {
+	policies := []*unifi.FirewallPolicy{
+		{ID: "p1", Name: "From IoT", Source: &unifi.FirewallPolicySource{ZoneID: "iot"}, Destination: &unifi.FirewallPolicyDestination{ZoneID: "wan"}},
+		{ID: "p2", Name: "To IoT", Source: &unifi.FirewallPolicySource{ZoneID: "lan"}, Destination: &unifi.FirewallPolicyDestination{ZoneID: "iot"}},
+		{ID: "p3", Name: "Unrelated", Source: &unifi.FirewallPolicySource{ZoneID: "lan"}, Destination: &unifi.FirewallPolicyDestination{ZoneID: "wan"}},
+		{ID: "p4", Name: "Predefined", Predefined: true, Source: &unifi.FirewallPolicySource{ZoneID: "iot"}},
+		{ID: "p5", Name: "No endpoints"},
+	}
+
+	result := dependentFirewallPolicies(policies, "iot")
+	ids := make([]string, len(result))
+	for i, p := range result {
+		ids[i] = p.ID
+	}
+	assert.Equal(t, []string{"p1", "p2"}, ids)
+
+	assert.Empty(t, dependentFirewallPolicies(policies, "dmz"))
+}
+
+func TestAssignNetworkZone(t *testing.T) {
+	ctx := context.Background()
+	newClient := func() *fakeClient {
+		f := newFakeClient()
+		f.zones["z1"] = unifi.FirewallZone{ID: "z1", Name: "Internal", ZoneKey: "internal", NetworkIDs: []string{"net-a"}}
+		f.zones["z2"] = unifi.FirewallZone{ID: "z2", Name: "IoT", NetworkIDs: []string{"net-b", "net-c"}}
+		f.zones["z3"] = unifi.FirewallZone{ID: "z3", Name: "Cameras"}
+		return f
+	}
+
+	t.Run("from built-in zone", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, assignNetworkZone(ctx, f, "default", "net-a", "z3"))
+		assert.Equal(t, []string{"net-a"}, f.zones["z3"].NetworkIDs)
+		assert.Equal(t, []string{"net-a"}, f.zones["z1"].NetworkIDs, "built-in zones are left to the controller")
+		assert.Equal(t, 1, f.calls["UpdateFirewallZone"])
+	})
+
+	t.Run("between custom zones", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, assignNetworkZone(ctx, f, "default", "net-c", "z3"))
+		assert.Equal(t, []string{"net-b"}, f.zones["z2"].NetworkIDs)
+		assert.Equal(t, []string{"net-c"}, f.zones["z3"].NetworkIDs)
+		assert.Equal(t, 2, f.calls["UpdateFirewallZone"])
+	})
+
+	t.Run("already a member", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, assignNetworkZone(ctx, f, "default", "net-b", "z2"))
+		assert.Equal(t, []string{"net-b", "net-c"}, f.zones["z2"].NetworkIDs)
+		assert.Zero(t, f.calls["UpdateFirewallZone"])
+	})
+
+	t.Run("unknown zone", func(t *testing.T) {
+		f := newClient()
+		err := assignNetworkZone(ctx, f, "default", "net-b", "z9")
+		require.ErrorContains(t, err, "firewall zone z9 not found")
+		assert.Zero(t, f.calls["UpdateFirewallZone"])
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		f := newClient()
+		f.failNext("UpdateFirewallZone", fmt.Errorf("boom"))
+		err := assignNetworkZone(ctx, f, "default", "net-a", "z2")
+		require.ErrorContains(t, err, `adding network to firewall zone "IoT": boom`)
+	})
+}
+
+func TestRemoveNetworkFromZone(t *testing.T) {
+	ctx := context.Background()
+	newClient := func() *fakeClient {
+		f := newFakeClient()
+		f.zones["z2"] = unifi.FirewallZone{ID: "z2", Name: "IoT", NetworkIDs: []string{"net-b", "net-c"}}
+		return f
+	}
+
+	t.Run("member", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, removeNetworkFromZone(ctx, f, "default", "net-b", "z2"))
+		assert.Equal(t, []string{"net-c"}, f.zones["z2"].NetworkIDs)
+	})
+
+	t.Run("not a member", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, removeNetworkFromZone(ctx, f, "default", "net-x", "z2"))
+		assert.Zero(t, f.calls["UpdateFirewallZone"])
+	})
+
+	t.Run("zone deleted", func(t *testing.T) {
+		f := newClient()
+		require.NoError(t, removeNetworkFromZone(ctx, f, "default", "net-b", "z9"))
+	})
+}
+
+func TestFirewallZoneReadPoliciesAttached(t *testing.T) {
+	f := newFakeClient()
+	f.zones["iot"] = unifi.FirewallZone{ID: "iot", Name: "IoT"}
+	f.policies["p1"] = unifi.FirewallPolicy{ID: "p1", Source: &unifi.FirewallPolicySource{ZoneID: "iot"}}
+	f.policies["p2"] = unifi.FirewallPolicy{ID: "p2", Destination: &unifi.FirewallPolicyDestination{ZoneID: "iot"}}
+	f.policies["p3"] = unifi.FirewallPolicy{ID: "p3", Predefined: true, Source: &unifi.FirewallPolicySource{ZoneID: "iot"}}
+	r := &firewallZoneResource{client: f}
+
+	state := firewallZoneResourceModel{
+		ID:               types.StringValue("iot"),
+		Site:             types.StringValue("default"),
+		NetworkIDs:       types.SetNull(types.StringType),
+		Tags:             types.SetNull(types.StringType),
+		PoliciesAttached: types.Int64Value(0),
+	}
+
+	got, diags := testRead(t, r, state)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, int64(2), got.PoliciesAttached.ValueInt64())
+
+	f.failNext("ListFirewallPolicies", errors.New("boom"))
+	_, diags = testRead(t, r, state)
+	assert.True(t, diags.HasError())
+}
+
+func TestFirewallZoneModifyPlanUnknownNetworks(t *testing.T) {
+	f := newFakeClient()
+	r := &firewallZoneResource{client: f}
+
+	modifyPlan := func(networkIDs types.Set) diag.Diagnostics {
+		plan := firewallZoneResourceModel{
+			ID:               types.StringUnknown(),
+			Name:             types.StringValue("IoT"),
+			NetworkIDs:       networkIDs,
+			Tags:             types.SetNull(types.StringType),
+			PoliciesAttached: types.Int64Unknown(),
+		}
+		req := fwresource.ModifyPlanRequest{Plan: testPlan(t, r, &plan)}
+		resp := fwresource.ModifyPlanResponse{Plan: req.Plan}
+		r.ModifyPlan(context.Background(), req, &resp)
+		return resp.Diagnostics
+	}
+
+	assert.Empty(t, modifyPlan(types.SetUnknown(types.StringType)), "no warning unless warn_unzoned_networks is set")
+
+	f.warnUnzoned = true
+	diags := modifyPlan(types.SetUnknown(types.StringType))
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Zone Membership Not Checked", diags[0].Summary())
+
+	assert.Empty(t, modifyPlan(types.SetValueMust(types.StringType, []attr.Value{types.StringValue("net-iot")})))
+}
+
+func TestFirewallZoneUpdateStateOnly(t *testing.T) {
+	f := newFakeClient()
+	f.zones["iot"] = unifi.FirewallZone{ID: "iot", Name: "IoT"}
+	r := &firewallZoneResource{client: f}
+
+	state := firewallZoneResourceModel{
+		ID:               types.StringValue("iot"),
+		Site:             types.StringValue("default"),
+		Name:             types.StringValue("IoT"),
+		NetworkIDs:       types.SetNull(types.StringType),
+		Tags:             types.SetNull(types.StringType),
+		PoliciesAttached: types.Int64Value(0),
+	}
+	plan := state
+	plan.Description = types.StringValue("Smart home devices")
+	plan.Tags = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("iot")})
+
+	got, diags := testUpdate(t, r, state, plan)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Smart home devices", got.Description.ValueString())
+	assert.Equal(t, plan.Tags, got.Tags)
+	assert.Zero(t, f.calls["UpdateFirewallZone"], "state-only changes make no API call")
 }
 
 // ---------------------------------------------------------------------------
@@ -218,6 +418,7 @@ resource "terrifi_firewall_zone" "test" {
 					resource.TestCheckResourceAttr("terrifi_firewall_zone.test", "name", name),
 					resource.TestCheckResourceAttr("terrifi_firewall_zone.test", "site", "default"),
 					resource.TestCheckResourceAttrSet("terrifi_firewall_zone.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.test", "policies_attached", "0"),
 				),
 			},
 		},
@@ -538,6 +739,83 @@ resource "terrifi_firewall_zone" "test" {
 	})
 }
 
+func TestAccFirewallZone_descriptionAndTags(t *testing.T) {
+	name := fmt.Sprintf("tfacc-zone-desc-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_firewall_zone" "test" {
+  name        = %q
+  description = "Cameras and other IoT devices"
+  tags        = ["iot", "untrusted"]
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.test", "description", "Cameras and other IoT devices"),
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.test", "tags.#", "2"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_firewall_zone" "test" {
+  name = %q
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_firewall_zone.test", "description"),
+					resource.TestCheckNoResourceAttr("terrifi_firewall_zone.test", "tags.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccFirewallZone_deletePoliciesCascade(t *testing.T) {
+	name := fmt.Sprintf("tfacc-zone-cascade-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_firewall_zone" "src" {
+  name            = "%s-src"
+  delete_policies = true
+}
+
+resource "terrifi_firewall_zone" "dst" {
+  name            = "%s-dst"
+  delete_policies = true
+}
+`, name, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_firewall_zone.src", "delete_policies", "true"),
+					// Create a policy outside Terraform, like one added in the
+					// UI. Destroying the zones must delete it first.
+					func(s *terraform.State) error {
+						client := testAccGetClient(t)
+						src := s.RootModule().Resources["terrifi_firewall_zone.src"].Primary.ID
+						dst := s.RootModule().Resources["terrifi_firewall_zone.dst"].Primary.ID
+						_, err := client.CreateFirewallPolicy(t.Context(), "default", &unifi.FirewallPolicy{
+							Name:        name + "-unmanaged",
+							Enabled:     true,
+							Action:      "BLOCK",
+							IPVersion:   "BOTH",
+							Protocol:    "all",
+							Source:      &unifi.FirewallPolicySource{ZoneID: src, MatchingTarget: "ANY"},
+							Destination: &unifi.FirewallPolicyDestination{ZoneID: dst, MatchingTarget: "ANY"},
+						}, nil)
+						return err
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccFirewallZone_importSiteID(t *testing.T) {
 	name := fmt.Sprintf("tfacc-zone-impsid-%s", randomSuffix())
 	resource.Test(t, resource.TestCase{
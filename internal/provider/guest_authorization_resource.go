@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &guestAuthorizationResource{}
+	_ resource.ResourceWithImportState = &guestAuthorizationResource{}
+)
+
+func NewGuestAuthorizationResource() resource.Resource {
+	return &guestAuthorizationResource{}
+}
+
+type guestAuthorizationResource struct {
+	client ClientAPI
+}
+
+type guestAuthorizationResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Site      types.String `tfsdk:"site"`
+	MAC       types.String `tfsdk:"mac"`
+	Minutes   types.Int64  `tfsdk:"minutes"`
+	UpKbps    types.Int64  `tfsdk:"up_kbps"`
+	DownKbps  types.Int64  `tfsdk:"down_kbps"`
+	QuotaMB   types.Int64  `tfsdk:"quota_mb"`
+	APMAC     types.String `tfsdk:"ap_mac"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+func (r *guestAuthorizationResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_guest_authorization"
+}
+
+func (r *guestAuthorizationResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	// The controller can't edit an authorization, only issue or revoke one,
+	// so every argument forces a new authorization.
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Pre-authorizes a client on the guest network, as if it had passed the hotspot " +
+			"portal, so that kiosks and other devices that can't use the portal get access. An authorization " +
+			"lasts `minutes`; once it expires the resource is removed from state and the next apply issues a " +
+			"new one.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the authorized client.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to authorize the client on. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"mac": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the client to authorize (e.g. `aa:bb:cc:dd:ee:ff`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						macRegexp,
+						"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+					),
+				},
+			},
+
+			"minutes": schema.Int64Attribute{
+				MarkdownDescription: "How long the authorization lasts, in minutes. At most one year (`525600`).",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 525600),
+				},
+			},
+
+			"up_kbps": schema.Int64Attribute{
+				MarkdownDescription: "Upload bandwidth limit in kbps. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"down_kbps": schema.Int64Attribute{
+				MarkdownDescription: "Download bandwidth limit in kbps. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"quota_mb": schema.Int64Attribute{
+				MarkdownDescription: "Data transfer limit for the whole authorization, in MB. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"ap_mac": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the access point the client connects through. Optional; " +
+					"the controller uses it to attribute the session.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						macRegexp,
+						"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+					),
+				},
+			},
+
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the authorization expires, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *guestAuthorizationResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *guestAuthorizationResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan guestAuthorizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.AuthorizeGuest(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Authorizing Guest", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *guestAuthorizationResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state guestAuthorizationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	auth, err := r.client.GetGuestAuthorization(ctx, site, state.ID.ValueString())
+	if err != nil {
+		// Expired and revoked authorizations are dropped from state so the
+		// next apply authorizes the client again.
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Guest Authorization",
+			fmt.Sprintf("Could not read guest authorization for %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(auth, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes: every argument requires replacement.
+// It only carries the plan over to state.
+func (r *guestAuthorizationResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan guestAuthorizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *guestAuthorizationResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state guestAuthorizationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.client.UnauthorizeGuest(ctx, site, state.ID.ValueString()); err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Unauthorizing Guest", err.Error())
+	}
+}
+
+// ImportState imports an active authorization by the client's MAC address,
+// as "mac" or "site:mac".
+func (r *guestAuthorizationResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// MACs contain colons, so a site prefix is recognised by the part count.
+	id := req.ID
+	if parts := strings.Split(req.ID, ":"); len(parts) == 7 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		id = strings.Join(parts[1:], ":")
+	}
+	id = strings.ToLower(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("mac"), id)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *guestAuthorizationResource) modelToAPI(m *guestAuthorizationResourceModel) *guestAuthorizationRequest {
+	return &guestAuthorizationRequest{
+		MAC:     m.MAC.ValueString(),
+		Minutes: m.Minutes.ValueInt64(),
+		Up:      m.UpKbps.ValueInt64(),
+		Down:    m.DownKbps.ValueInt64(),
+		QuotaMB: m.QuotaMB.ValueInt64(),
+		APMAC:   m.APMAC.ValueString(),
+	}
+}
+
+// apiToModel copies an authorization into the model. The controller doesn't
+// report the requested duration, so minutes is only derived from the start
+// and end times when it isn't known yet (after import). ap_mac is left as
+// configured: the controller records whichever AP the client is using, which
+// changes as it roams.
+func (r *guestAuthorizationResource) apiToModel(g *guestAuthorization, m *guestAuthorizationResourceModel, site string) {
+	m.ID = types.StringValue(strings.ToLower(g.MAC))
+	m.Site = types.StringValue(site)
+	m.MAC = types.StringValue(strings.ToLower(g.MAC))
+	if m.Minutes.IsNull() || m.Minutes.IsUnknown() {
+		m.Minutes = types.Int64Value((g.End - g.Start) / 60)
+	}
+	m.UpKbps = guestLimitValue(g.Up)
+	m.DownKbps = guestLimitValue(g.Down)
+	m.QuotaMB = guestLimitValue(g.QuotaMB)
+	m.ExpiresAt = types.StringValue(time.Unix(g.End, 0).UTC().Format(time.RFC3339))
+}
+
+// guestLimitValue converts a guest rate or quota to the model. The controller
+// reports "no limit" as 0 (or -1 on some versions), which is null in the model.
+func guestLimitValue(v int64) types.Int64 {
+	if v <= 0 {
+		return types.Int64Null()
+	}
+	return types.Int64Value(v)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestAuthorizeGuest(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/stamgr", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"g1","mac":"aa:bb:cc:dd:ee:ff","start":1700000000,"end":1700003600,"qos_rate_max_up":512}
+		]}`))
+	}))
+	defer srv.Close()
+
+	auth, err := newTestClient(t, srv.URL, false).AuthorizeGuest(context.Background(), "default", &guestAuthorizationRequest{
+		MAC:     "AA:BB:CC:DD:EE:FF",
+		Minutes: 60,
+		Up:      512,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"cmd":     "authorize-guest",
+		"mac":     "aa:bb:cc:dd:ee:ff",
+		"minutes": float64(60),
+		"up":      float64(512),
+	}, got, "unset limits are omitted")
+	assert.Equal(t, "g1", auth.ID)
+	assert.Equal(t, int64(1700003600), auth.End)
+}
+
+func TestGetGuestAuthorization(t *testing.T) {
+	future := time.Now().Add(time.Hour).Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/stat/guest", r.URL.Path)
+		assert.Equal(t, fmt.Sprint(guestAuthorizationLookback), r.URL.Query().Get("within"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"meta":{"rc":"ok"},"data":[
+			{"_id":"old","mac":"aa:bb:cc:dd:ee:ff","start":1,"end":2,"expired":true},
+			{"_id":"g1","mac":"aa:bb:cc:dd:ee:ff","start":1,"end":%d}
+		]}`, future)
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+
+	auth, err := c.GetGuestAuthorization(context.Background(), "default", "AA:BB:CC:DD:EE:FF")
+	require.NoError(t, err)
+	assert.Equal(t, "g1", auth.ID)
+
+	_, err = c.GetGuestAuthorization(context.Background(), "default", "11:22:33:44:55:66")
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
+
+func TestActiveGuestAuthorization(t *testing.T) {
+	now := time.Unix(1000, 0)
+	guests := []guestAuthorization{
+		{ID: "expired-flag", MAC: "aa:bb:cc:dd:ee:ff", End: 5000, Expired: true},
+		{ID: "ended", MAC: "aa:bb:cc:dd:ee:ff", End: 900},
+		{ID: "short", MAC: "AA:BB:CC:DD:EE:FF", End: 2000},
+		{ID: "long", MAC: "aa:bb:cc:dd:ee:ff", End: 3000},
+		{ID: "other", MAC: "11:22:33:44:55:66", End: 9000},
+	}
+
+	got := activeGuestAuthorization(guests, "aa:bb:cc:dd:ee:ff", now)
+	require.NotNil(t, got)
+	assert.Equal(t, "long", got.ID)
+
+	assert.Nil(t, activeGuestAuthorization(guests[:2], "aa:bb:cc:dd:ee:ff", now))
+}
+
+func TestGuestAuthorizationAPIToModel(t *testing.T) {
+	r := &guestAuthorizationResource{}
+	g := &guestAuthorization{
+		MAC:     "AA:BB:CC:DD:EE:FF",
+		APMAC:   "11:22:33:44:55:66",
+		Start:   1700000000,
+		End:     1700007200,
+		Up:      512,
+		Down:    -1,
+		QuotaMB: 0,
+	}
+
+	t.Run("after create", func(t *testing.T) {
+		m := guestAuthorizationResourceModel{
+			Minutes: types.Int64Value(120),
+			APMAC:   types.StringNull(),
+		}
+		r.apiToModel(g, &m, "default")
+
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", m.ID.ValueString())
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", m.MAC.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, int64(120), m.Minutes.ValueInt64())
+		assert.Equal(t, int64(512), m.UpKbps.ValueInt64())
+		assert.True(t, m.DownKbps.IsNull(), "-1 means no limit")
+		assert.True(t, m.QuotaMB.IsNull(), "0 means no limit")
+		assert.True(t, m.APMAC.IsNull(), "ap_mac is not read back")
+		assert.Equal(t, "2023-11-15T00:13:20Z", m.ExpiresAt.ValueString())
+	})
+
+	t.Run("after import", func(t *testing.T) {
+		m := guestAuthorizationResourceModel{Minutes: types.Int64Null()}
+		r.apiToModel(g, &m, "default")
+		assert.Equal(t, int64(120), m.Minutes.ValueInt64())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccGuestAuthorization_basic(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_guest_authorization" "test" {
+  mac       = %q
+  minutes   = 60
+  up_kbps   = 1024
+  down_kbps = 4096
+  quota_mb  = 500
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_guest_authorization.test", "id", mac),
+					resource.TestCheckResourceAttr("terrifi_guest_authorization.test", "minutes", "60"),
+					resource.TestCheckResourceAttr("terrifi_guest_authorization.test", "up_kbps", "1024"),
+					resource.TestCheckResourceAttr("terrifi_guest_authorization.test", "down_kbps", "4096"),
+					resource.TestCheckResourceAttr("terrifi_guest_authorization.test", "quota_mb", "500"),
+					resource.TestCheckResourceAttrSet("terrifi_guest_authorization.test", "expires_at"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_guest_authorization.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
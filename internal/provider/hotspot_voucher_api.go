@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// hotspotVoucher is a hotspot voucher, as listed by stat/voucher. Vouchers
+// created together share a create_time. Duration is in minutes, rates are in
+// kbps and the data quota in MB. Quota is the number of times the voucher can
+// be used, with 0 meaning unlimited.
+type hotspotVoucher struct {
+	ID         string `json:"_id"`
+	Code       string `json:"code"`
+	CreateTime int64  `json:"create_time"`
+	Duration   int64  `json:"duration"`
+	Quota      int64  `json:"quota"`
+	Used       int64  `json:"used"`
+	Note       string `json:"note"`
+	Up         int64  `json:"qos_rate_max_up"`
+	Down       int64  `json:"qos_rate_max_down"`
+	QuotaMB    int64  `json:"qos_usage_quota"`
+}
+
+// hotspotVoucherRequest is the hotspot create-voucher command. Zero limits are
+// omitted, which leaves the vouchers unlimited.
+type hotspotVoucherRequest struct {
+	Count   int64  `json:"n"`
+	Quota   int64  `json:"quota"`
+	Minutes int64  `json:"expire"`
+	Up      int64  `json:"up,omitempty"`
+	Down    int64  `json:"down,omitempty"`
+	QuotaMB int64  `json:"bytes,omitempty"`
+	Note    string `json:"note,omitempty"`
+}
+
+// CreateHotspotVouchers creates a batch of vouchers via the hotspot
+// "create-voucher" command and returns them. The command only responds with
+// the batch's create_time, so the vouchers are read back from stat/voucher.
+func (c *Client) CreateHotspotVouchers(ctx context.Context, site string, d *hotspotVoucherRequest) ([]hotspotVoucher, error) {
+	payload := struct {
+		Cmd string `json:"cmd"`
+		hotspotVoucherRequest
+	}{"create-voucher", *d}
+
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+		Data []struct {
+			CreateTime int64 `json:"create_time"`
+		} `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/api/s/%s/cmd/hotspot", c.BaseURL, c.APIPath, site),
+		payload, &respBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+	if len(respBody.Data) == 0 {
+		return nil, fmt.Errorf("controller did not return the vouchers' create_time")
+	}
+	return c.GetHotspotVouchers(ctx, site, respBody.Data[0].CreateTime)
+}
+
+// GetHotspotVouchers returns the vouchers created at createTime, ordered by
+// code. The controller removes vouchers once they are used up or expire; a
+// batch with no vouchers left is a *unifi.NotFoundError.
+func (c *Client) GetHotspotVouchers(ctx context.Context, site string, createTime int64) ([]hotspotVoucher, error) {
+	var respBody struct {
+		Meta json.RawMessage  `json:"meta"`
+		Data []hotspotVoucher `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/stat/voucher", c.BaseURL, c.APIPath, site),
+		nil, &respBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+	if vouchers := hotspotVoucherBatch(respBody.Data, createTime); len(vouchers) > 0 {
+		return vouchers, nil
+	}
+	return nil, &unifi.NotFoundError{}
+}
+
+// DeleteHotspotVoucher revokes a voucher via the hotspot "delete-voucher"
+// command.
+func (c *Client) DeleteHotspotVoucher(ctx context.Context, site, id string) error {
+	payload := map[string]any{
+		"cmd": "delete-voucher",
+		"_id": id,
+	}
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/api/s/%s/cmd/hotspot", c.BaseURL, c.APIPath, site),
+		payload, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
+// hotspotVoucherBatch picks the vouchers created at createTime from a
+// stat/voucher listing, ordered by code.
+func hotspotVoucherBatch(vouchers []hotspotVoucher, createTime int64) []hotspotVoucher {
+	var batch []hotspotVoucher
+	for _, v := range vouchers {
+		if v.CreateTime == createTime {
+			batch = append(batch, v)
+		}
+	}
+	sort.Slice(batch, func(i, j int) bool { return batch[i].Code < batch[j].Code })
+	return batch
+}
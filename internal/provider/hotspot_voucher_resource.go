@@ -0,0 +1,389 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &hotspotVoucherResource{}
+	_ resource.ResourceWithImportState = &hotspotVoucherResource{}
+)
+
+func NewHotspotVoucherResource() resource.Resource {
+	return &hotspotVoucherResource{}
+}
+
+type hotspotVoucherResource struct {
+	client ClientAPI
+}
+
+type hotspotVoucherResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Site     types.String `tfsdk:"site"`
+	Quantity types.Int64  `tfsdk:"quantity"`
+	Uses     types.Int64  `tfsdk:"uses"`
+	Minutes  types.Int64  `tfsdk:"minutes"`
+	UpKbps   types.Int64  `tfsdk:"up_kbps"`
+	DownKbps types.Int64  `tfsdk:"down_kbps"`
+	QuotaMB  types.Int64  `tfsdk:"quota_mb"`
+	Note     types.String `tfsdk:"note"`
+	Codes    types.List   `tfsdk:"codes"`
+}
+
+func (r *hotspotVoucherResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_hotspot_voucher"
+}
+
+func (r *hotspotVoucherResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	// The controller can't edit a voucher, only create or revoke one, so
+	// every argument forces a new batch.
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a batch of hotspot vouchers, which guests redeem on the hotspot portal " +
+			"(`auth = \"hotspot\"` with `voucher_enabled` in `terrifi_setting_guest_access`). Vouchers that are " +
+			"used up or expire are removed by the controller; once none are left the resource is removed from " +
+			"state and the next apply creates a new batch.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The create time shared by the batch's vouchers, as a Unix timestamp.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to create the vouchers on. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"quantity": schema.Int64Attribute{
+				MarkdownDescription: "The number of vouchers to create, between 1 and 1000. Default: `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
+
+			"uses": schema.Int64Attribute{
+				MarkdownDescription: "How many times each voucher can be redeemed, or `0` for no limit. Default: `1`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+
+			"minutes": schema.Int64Attribute{
+				MarkdownDescription: "How long a guest has access after redeeming a voucher, in minutes. At most " +
+					"one year (`525600`).",
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.Between(1, 525600),
+				},
+			},
+
+			"up_kbps": schema.Int64Attribute{
+				MarkdownDescription: "Upload bandwidth limit in kbps. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"down_kbps": schema.Int64Attribute{
+				MarkdownDescription: "Download bandwidth limit in kbps. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"quota_mb": schema.Int64Attribute{
+				MarkdownDescription: "Data transfer limit per voucher, in MB. Omit for no limit.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+
+			"note": schema.StringAttribute{
+				MarkdownDescription: "A note shown with the vouchers in the UniFi UI and on printed vouchers.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"codes": schema.ListAttribute{
+				MarkdownDescription: "The codes of the batch's vouchers that haven't been used up or expired, " +
+					"ordered. Codes are 10 digits; the UniFi UI shows them as `12345-67890`.",
+				ElementType: types.StringType,
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *hotspotVoucherResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *hotspotVoucherResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan hotspotVoucherResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateHotspotVouchers(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Hotspot Vouchers", err.Error())
+		return
+	}
+
+	r.apiToModel(ctx, created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *hotspotVoucherResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state hotspotVoucherResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	createTime, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Hotspot Voucher ID",
+			fmt.Sprintf("Expected a Unix timestamp, got %q.", state.ID.ValueString()),
+		)
+		return
+	}
+
+	vouchers, err := r.client.GetHotspotVouchers(ctx, site, createTime)
+	if err != nil {
+		// A batch whose vouchers are all used up or expired is dropped from
+		// state so the next apply creates a new one.
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Hotspot Vouchers",
+			fmt.Sprintf("Could not read hotspot vouchers %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(ctx, vouchers, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes: every argument requires replacement.
+// It only carries the plan over to state.
+func (r *hotspotVoucherResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var plan hotspotVoucherResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete revokes the batch's remaining vouchers.
+func (r *hotspotVoucherResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state hotspotVoucherResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	createTime, err := strconv.ParseInt(state.ID.ValueString(), 10, 64)
+	if err != nil {
+		return
+	}
+
+	vouchers, err := r.client.GetHotspotVouchers(ctx, site, createTime)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Reading Hotspot Vouchers for Delete", err.Error())
+		return
+	}
+
+	for _, v := range vouchers {
+		if err := r.client.DeleteHotspotVoucher(ctx, site, v.ID); err != nil {
+			if _, ok := err.(*unifi.NotFoundError); ok {
+				continue
+			}
+			resp.Diagnostics.AddError(
+				"Error Deleting Hotspot Voucher",
+				fmt.Sprintf("Could not delete hotspot voucher %s: %s", v.ID, err.Error()),
+			)
+			return
+		}
+	}
+}
+
+// ImportState imports a batch of vouchers by their create time, as
+// "create_time" or "site:create_time".
+func (r *hotspotVoucherResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	id := req.ID
+	if site, rest, ok := strings.Cut(req.ID, ":"); ok {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), site)...)
+		id = rest
+	}
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected create_time or site:create_time, where create_time is a Unix timestamp, got %q.", req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *hotspotVoucherResource) modelToAPI(m *hotspotVoucherResourceModel) *hotspotVoucherRequest {
+	return &hotspotVoucherRequest{
+		Count:   m.Quantity.ValueInt64(),
+		Quota:   m.Uses.ValueInt64(),
+		Minutes: m.Minutes.ValueInt64(),
+		Up:      m.UpKbps.ValueInt64(),
+		Down:    m.DownKbps.ValueInt64(),
+		QuotaMB: m.QuotaMB.ValueInt64(),
+		Note:    m.Note.ValueString(),
+	}
+}
+
+// apiToModel copies a batch of vouchers, which share their settings, into the
+// model. quantity is only derived from the batch when it isn't known yet (after
+// import): the controller removes vouchers as they are used up, which must not
+// force a new batch.
+func (r *hotspotVoucherResource) apiToModel(ctx context.Context, vouchers []hotspotVoucher, m *hotspotVoucherResourceModel, site string) {
+	v := vouchers[0]
+	m.ID = types.StringValue(strconv.FormatInt(v.CreateTime, 10))
+	m.Site = types.StringValue(site)
+	if m.Quantity.IsNull() || m.Quantity.IsUnknown() {
+		m.Quantity = types.Int64Value(int64(len(vouchers)))
+	}
+	m.Uses = types.Int64Value(v.Quota)
+	m.Minutes = types.Int64Value(v.Duration)
+	m.UpKbps = guestLimitValue(v.Up)
+	m.DownKbps = guestLimitValue(v.Down)
+	m.QuotaMB = guestLimitValue(v.QuotaMB)
+	m.Note = stringValueOrNull(v.Note)
+
+	codes := make([]string, len(vouchers))
+	for i, v := range vouchers {
+		codes[i] = v.Code
+	}
+	m.Codes, _ = types.ListValueFrom(ctx, types.StringType, codes)
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+const testVoucherListing = `{"meta":{"rc":"ok"},"data":[
+	{"_id":"v2","code":"2222222222","create_time":1700000000,"duration":60,"quota":1,"qos_rate_max_up":512},
+	{"_id":"other","code":"3333333333","create_time":1600000000,"duration":60,"quota":1},
+	{"_id":"v1","code":"1111111111","create_time":1700000000,"duration":60,"quota":1,"qos_rate_max_up":512}
+]}`
+
+func TestCreateHotspotVouchers(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/proxy/network/api/s/default/cmd/hotspot":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"create_time":1700000000}]}`))
+		case "/proxy/network/api/s/default/stat/voucher":
+			w.Write([]byte(testVoucherListing))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	vouchers, err := newTestClient(t, srv.URL, false).CreateHotspotVouchers(context.Background(), "default", &hotspotVoucherRequest{
+		Count:   2,
+		Quota:   1,
+		Minutes: 60,
+		Up:      512,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"cmd":    "create-voucher",
+		"n":      float64(2),
+		"quota":  float64(1),
+		"expire": float64(60),
+		"up":     float64(512),
+	}, got, "unset limits are omitted")
+	require.Len(t, vouchers, 2)
+	assert.Equal(t, "v1", vouchers[0].ID)
+	assert.Equal(t, "v2", vouchers[1].ID)
+}
+
+func TestGetHotspotVouchers(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/stat/voucher", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(testVoucherListing))
+	}))
+	defer srv.Close()
+
+	c := newTestClient(t, srv.URL, false)
+
+	vouchers, err := c.GetHotspotVouchers(context.Background(), "default", 1600000000)
+	require.NoError(t, err)
+	require.Len(t, vouchers, 1)
+	assert.Equal(t, "other", vouchers[0].ID)
+
+	_, err = c.GetHotspotVouchers(context.Background(), "default", 1500000000)
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
+
+func TestDeleteHotspotVoucher(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/cmd/hotspot", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	err := newTestClient(t, srv.URL, false).DeleteHotspotVoucher(context.Background(), "default", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"cmd": "delete-voucher", "_id": "v1"}, got)
+}
+
+func TestHotspotVoucherAPIToModel(t *testing.T) {
+	r := &hotspotVoucherResource{}
+	ctx := context.Background()
+	vouchers := []hotspotVoucher{
+		{ID: "v1", Code: "1111111111", CreateTime: 1700000000, Duration: 1440, Quota: 0, Down: -1, QuotaMB: 500},
+		{ID: "v2", Code: "2222222222", CreateTime: 1700000000, Duration: 1440, Quota: 0, Down: -1, QuotaMB: 500},
+	}
+
+	t.Run("after create", func(t *testing.T) {
+		m := hotspotVoucherResourceModel{Quantity: types.Int64Value(5)}
+		r.apiToModel(ctx, vouchers, &m, "default")
+
+		assert.Equal(t, "1700000000", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, int64(5), m.Quantity.ValueInt64(), "used up vouchers don't change the quantity")
+		assert.Equal(t, int64(0), m.Uses.ValueInt64())
+		assert.Equal(t, int64(1440), m.Minutes.ValueInt64())
+		assert.True(t, m.UpKbps.IsNull(), "0 means no limit")
+		assert.True(t, m.DownKbps.IsNull(), "-1 means no limit")
+		assert.Equal(t, int64(500), m.QuotaMB.ValueInt64())
+		assert.True(t, m.Note.IsNull())
+
+		var codes []string
+		m.Codes.ElementsAs(ctx, &codes, false)
+		assert.Equal(t, []string{"1111111111", "2222222222"}, codes)
+	})
+
+	t.Run("after import", func(t *testing.T) {
+		m := hotspotVoucherResourceModel{Quantity: types.Int64Null()}
+		r.apiToModel(ctx, vouchers, &m, "default")
+		assert.Equal(t, int64(2), m.Quantity.ValueInt64())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccHotspotVoucher_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_hotspot_voucher" "test" {
+  quantity  = 3
+  uses      = 2
+  minutes   = 60
+  up_kbps   = 1024
+  down_kbps = 4096
+  quota_mb  = 500
+  note      = "tfacc"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_hotspot_voucher.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "quantity", "3"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "uses", "2"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "minutes", "60"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "quota_mb", "500"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "note", "tfacc"),
+					resource.TestCheckResourceAttr("terrifi_hotspot_voucher.test", "codes.#", "3"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_hotspot_voucher.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
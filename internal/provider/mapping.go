@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Tag-driven model mapping.
+//
+// Most modelToAPI/apiToModel pairs copy scalar fields one by one, and each
+// resource used to decide for itself what a zero value from the API means.
+// Instead, a model field can name its go-unifi struct field in a `unifi` tag
+// and state its null-handling rule once:
+//
+//	Name     types.String `tfsdk:"name"     unifi:"Key"`
+//	TTL      types.Int64  `tfsdk:"ttl"      unifi:"Ttl,zeronull"`
+//	Protocol types.String `tfsdk:"protocol" unifi:"Proto,default=tcp_udp"`
+//
+// The rules are:
+//   - no option: the API value is copied as-is, so a zero value is reported
+//     as "" / false / 0.
+//   - zeronull: a zero (or nil) API value is reported as null. Use it for
+//     optional attributes without a default, so "0 → null" never shows as a diff.
+//   - default=<v>: a zero (or nil) API value is reported as <v>. Use it for
+//     attributes whose schema default the controller stores as empty.
+//
+// In the other direction, null and unknown model values leave the API field
+// at its zero value (nil for pointers), and set values are copied.
+//
+// Supported model types are types.String, types.Bool and types.Int64, mapped
+// to string, bool and int64 API fields or pointers to them. Fields without a
+// `unifi` tag are ignored, so anything that needs special handling (lists,
+// nested objects, encoded values) stays hand-written next to the helper call.
+
+// fieldMapping links one model field to one API field.
+type fieldMapping struct {
+	model    int // index of the field in the model struct
+	api      int // index of the field in the API struct
+	zeroNull bool
+	def      any // model value reported for a zero API value, or nil
+}
+
+// fieldMappings caches the mappings per (model type, API type) pair.
+var fieldMappings sync.Map
+
+type mappingKey struct{ model, api reflect.Type }
+
+// mappingFor returns the field mappings between a model struct type and an
+// API struct type. Tag mistakes are programming errors, so they panic; the
+// unit tests of each resource exercise its mapping.
+func mappingFor(modelType, apiType reflect.Type) []fieldMapping {
+	key := mappingKey{modelType, apiType}
+	if cached, ok := fieldMappings.Load(key); ok {
+		return cached.([]fieldMapping)
+	}
+
+	var mappings []fieldMapping
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tag, ok := field.Tag.Lookup("unifi")
+		if !ok {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		apiField, ok := apiType.FieldByName(name)
+		if !ok || len(apiField.Index) != 1 {
+			panic(fmt.Sprintf("%s.%s: %s has no field %q", modelType.Name(), field.Name, apiType.Name(), name))
+		}
+		if !compatibleFields(field.Type, apiField.Type) {
+			panic(fmt.Sprintf("%s.%s: cannot map %s to %s.%s (%s)",
+				modelType.Name(), field.Name, field.Type, apiType.Name(), name, apiField.Type))
+		}
+
+		m := fieldMapping{model: i, api: apiField.Index[0]}
+		for _, opt := range strings.Split(opts, ",") {
+			switch {
+			case opt == "":
+			case opt == "zeronull":
+				m.zeroNull = true
+			case strings.HasPrefix(opt, "default="):
+				m.def = defaultValue(field.Type, strings.TrimPrefix(opt, "default="))
+			default:
+				panic(fmt.Sprintf("%s.%s: unknown unifi tag option %q", modelType.Name(), field.Name, opt))
+			}
+		}
+		mappings = append(mappings, m)
+	}
+
+	fieldMappings.Store(key, mappings)
+	return mappings
+}
+
+var (
+	modelStringType = reflect.TypeOf(types.String{})
+	modelBoolType   = reflect.TypeOf(types.Bool{})
+	modelInt64Type  = reflect.TypeOf(types.Int64{})
+)
+
+// compatibleFields reports whether a model field type can be mapped to an
+// API field type.
+func compatibleFields(model, api reflect.Type) bool {
+	if api.Kind() == reflect.Pointer {
+		api = api.Elem()
+	}
+	switch model {
+	case modelStringType:
+		return api.Kind() == reflect.String
+	case modelBoolType:
+		return api.Kind() == reflect.Bool
+	case modelInt64Type:
+		return api.Kind() == reflect.Int64
+	}
+	return false
+}
+
+// mapModelToAPI copies the tagged fields of model (a pointer to a model
+// struct) to api (a pointer to a go-unifi struct).
+func mapModelToAPI(model, api any) {
+	mv := reflect.ValueOf(model).Elem()
+	av := reflect.ValueOf(api).Elem()
+
+	for _, m := range mappingFor(mv.Type(), av.Type()) {
+		var v reflect.Value
+		switch src := mv.Field(m.model).Interface().(type) {
+		case types.String:
+			if src.IsNull() || src.IsUnknown() {
+				continue
+			}
+			v = reflect.ValueOf(src.ValueString())
+		case types.Bool:
+			if src.IsNull() || src.IsUnknown() {
+				continue
+			}
+			v = reflect.ValueOf(src.ValueBool())
+		case types.Int64:
+			if src.IsNull() || src.IsUnknown() {
+				continue
+			}
+			v = reflect.ValueOf(src.ValueInt64())
+		}
+
+		dst := av.Field(m.api)
+		if dst.Kind() == reflect.Pointer {
+			p := reflect.New(dst.Type().Elem())
+			p.Elem().Set(v.Convert(dst.Type().Elem()))
+			dst.Set(p)
+		} else {
+			dst.Set(v.Convert(dst.Type()))
+		}
+	}
+}
+
+// mapAPIToModel copies the mapped fields of api (a pointer to a go-unifi
+// struct) to model (a pointer to a model struct), applying each field's
+// null-handling rule.
+func mapAPIToModel(api, model any) {
+	av := reflect.ValueOf(api).Elem()
+	mv := reflect.ValueOf(model).Elem()
+
+	for _, m := range mappingFor(mv.Type(), av.Type()) {
+		src := av.Field(m.api)
+		if src.Kind() == reflect.Pointer {
+			if src.IsNil() {
+				src = reflect.Zero(src.Type().Elem())
+			} else {
+				src = src.Elem()
+			}
+		}
+
+		dst := mv.Field(m.model)
+		switch {
+		case !src.IsZero():
+			dst.Set(reflect.ValueOf(scalarValue(dst.Type(), src)))
+		case m.zeroNull:
+			dst.Set(reflect.ValueOf(nullValue(dst.Type())))
+		case m.def != nil:
+			dst.Set(reflect.ValueOf(m.def))
+		default:
+			dst.Set(reflect.ValueOf(scalarValue(dst.Type(), src)))
+		}
+	}
+}
+
+// scalarValue wraps an API value in the model type t.
+func scalarValue(t reflect.Type, v reflect.Value) any {
+	switch t {
+	case modelStringType:
+		return types.StringValue(v.String())
+	case modelBoolType:
+		return types.BoolValue(v.Bool())
+	default:
+		return types.Int64Value(v.Int())
+	}
+}
+
+// nullValue returns the null value of the model type t.
+func nullValue(t reflect.Type) any {
+	switch t {
+	case modelStringType:
+		return types.StringNull()
+	case modelBoolType:
+		return types.BoolNull()
+	default:
+		return types.Int64Null()
+	}
+}
+
+// defaultValue parses a default=<v> tag option as the model type t.
+func defaultValue(t reflect.Type, def string) any {
+	switch t {
+	case modelStringType:
+		return types.StringValue(def)
+	case modelBoolType:
+		b, err := strconv.ParseBool(def)
+		if err != nil {
+			panic(fmt.Sprintf("invalid bool default %q", def))
+		}
+		return types.BoolValue(b)
+	default:
+		n, err := strconv.ParseInt(def, 10, 64)
+		if err != nil {
+			panic(fmt.Sprintf("invalid int64 default %q", def))
+		}
+		return types.Int64Value(n)
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mappingTestAPI struct {
+	Name    string
+	Count   int64
+	Limit   *int64
+	Mode    string
+	Enabled bool
+	Flag    *bool
+	Secret  string
+}
+
+type mappingTestModel struct {
+	Name    types.String `unifi:"Name"`
+	Count   types.Int64  `unifi:"Count,zeronull"`
+	Limit   types.Int64  `unifi:"Limit,zeronull"`
+	Mode    types.String `unifi:"Mode,default=auto"`
+	Enabled types.Bool   `unifi:"Enabled"`
+	Flag    types.Bool   `unifi:"Flag,default=true"`
+	Secret  types.String // not mapped
+}
+
+func TestMapModelToAPI(t *testing.T) {
+	t.Run("set values are copied", func(t *testing.T) {
+		var api mappingTestAPI
+		mapModelToAPI(&mappingTestModel{
+			Name:    types.StringValue("a"),
+			Count:   types.Int64Value(3),
+			Limit:   types.Int64Value(0),
+			Mode:    types.StringValue("manual"),
+			Enabled: types.BoolValue(true),
+			Flag:    types.BoolValue(false),
+			Secret:  types.StringValue("ignored"),
+		}, &api)
+
+		zero := int64(0)
+		no := false
+		assert.Equal(t, mappingTestAPI{
+			Name:    "a",
+			Count:   3,
+			Limit:   &zero,
+			Mode:    "manual",
+			Enabled: true,
+			Flag:    &no,
+		}, api)
+	})
+
+	t.Run("null and unknown leave zero values", func(t *testing.T) {
+		api := mappingTestAPI{}
+		mapModelToAPI(&mappingTestModel{
+			Name:    types.StringUnknown(),
+			Count:   types.Int64Null(),
+			Limit:   types.Int64Null(),
+			Mode:    types.StringNull(),
+			Enabled: types.BoolUnknown(),
+			Flag:    types.BoolNull(),
+		}, &api)
+		assert.Equal(t, mappingTestAPI{}, api)
+	})
+}
+
+func TestMapAPIToModel(t *testing.T) {
+	t.Run("zero values follow the tag rules", func(t *testing.T) {
+		m := mappingTestModel{Secret: types.StringValue("kept")}
+		mapAPIToModel(&mappingTestAPI{}, &m)
+
+		assert.Equal(t, mappingTestModel{
+			Name:    types.StringValue(""),
+			Count:   types.Int64Null(),
+			Limit:   types.Int64Null(),
+			Mode:    types.StringValue("auto"),
+			Enabled: types.BoolValue(false),
+			Flag:    types.BoolValue(true),
+			Secret:  types.StringValue("kept"),
+		}, m)
+	})
+
+	t.Run("set values are copied", func(t *testing.T) {
+		limit := int64(10)
+		yes := true
+		var m mappingTestModel
+		mapAPIToModel(&mappingTestAPI{
+			Name:    "a",
+			Count:   3,
+			Limit:   &limit,
+			Mode:    "manual",
+			Enabled: true,
+			Flag:    &yes,
+		}, &m)
+
+		assert.Equal(t, "a", m.Name.ValueString())
+		assert.Equal(t, int64(3), m.Count.ValueInt64())
+		assert.Equal(t, int64(10), m.Limit.ValueInt64())
+		assert.Equal(t, "manual", m.Mode.ValueString())
+		assert.True(t, m.Enabled.ValueBool())
+		assert.True(t, m.Flag.ValueBool())
+	})
+}
+
+func TestMappingForInvalidTags(t *testing.T) {
+	type missingField struct {
+		Name types.String `unifi:"Missing"`
+	}
+	type wrongType struct {
+		Name types.Int64 `unifi:"Name"`
+	}
+	type unknownOption struct {
+		Name types.String `unifi:"Name,omitempty"`
+	}
+	type badDefault struct {
+		Count types.Int64 `unifi:"Count,default=many"`
+	}
+
+	assert.Panics(t, func() { mapAPIToModel(&mappingTestAPI{}, &missingField{}) })
+	assert.Panics(t, func() { mapAPIToModel(&mappingTestAPI{}, &wrongType{}) })
+	assert.Panics(t, func() { mapAPIToModel(&mappingTestAPI{}, &unknownOption{}) })
+	assert.Panics(t, func() { mapAPIToModel(&mappingTestAPI{}, &badDefault{}) })
+}
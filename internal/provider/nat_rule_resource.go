@@ -0,0 +1,611 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                     = &natRuleResource{}
+	_ resource.ResourceWithImportState      = &natRuleResource{}
+	_ resource.ResourceWithConfigValidators = &natRuleResource{}
+)
+
+func NewNATRuleResource() resource.Resource {
+	return &natRuleResource{}
+}
+
+type natRuleResource struct {
+	client ClientAPI
+}
+
+// natRuleResourceModel is the Terraform-side representation of a v2 NAT
+// rule. The `unifi` tags map fields to the go-unifi Nat struct (see
+// mapping.go).
+type natRuleResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Site         types.String `tfsdk:"site"`
+	Description  types.String `tfsdk:"description"   unifi:"Description"`
+	Type         types.String `tfsdk:"type"          unifi:"Type"`
+	Enabled      types.Bool   `tfsdk:"enabled"       unifi:"Enabled"`
+	IPVersion    types.String `tfsdk:"ip_version"    unifi:"IPVersion,default=IPV4"`
+	Protocol     types.String `tfsdk:"protocol"      unifi:"Protocol,default=all"`
+	InInterface  types.String `tfsdk:"in_interface"  unifi:"InInterface,zeronull"`
+	OutInterface types.String `tfsdk:"out_interface" unifi:"OutInterface,zeronull"`
+	IPAddress    types.String `tfsdk:"ip_address"    unifi:"IPAddress,zeronull"`
+	Port         types.Int64  `tfsdk:"port"          unifi:"Port,zeronull"`
+	Exclude      types.Bool   `tfsdk:"exclude"       unifi:"Exclude"`
+	Logging      types.Bool   `tfsdk:"logging"       unifi:"Logging"`
+	Source       types.Object `tfsdk:"source"`
+	Destination  types.Object `tfsdk:"destination"`
+}
+
+// natRuleFilterModel is the source or destination block. The controller's
+// filter_type is derived from which attributes are set.
+type natRuleFilterModel struct {
+	Address          types.String `tfsdk:"address"            unifi:"Address,zeronull"`
+	Port             types.Int64  `tfsdk:"port"               unifi:"Port,zeronull"`
+	NetworkID        types.String `tfsdk:"network_id"         unifi:"NetworkConfID,zeronull"`
+	FirewallGroupIDs types.Set    `tfsdk:"firewall_group_ids"`
+	InvertAddress    types.Bool   `tfsdk:"invert_address"     unifi:"InvertAddress"`
+	InvertPort       types.Bool   `tfsdk:"invert_port"        unifi:"InvertPort"`
+}
+
+// natRuleFilterAttrTypes defines the attribute types of the source and
+// destination blocks.
+var natRuleFilterAttrTypes = map[string]attr.Type{
+	"address":            types.StringType,
+	"port":               types.Int64Type,
+	"network_id":         types.StringType,
+	"firewall_group_ids": types.SetType{ElemType: types.StringType},
+	"invert_address":     types.BoolType,
+	"invert_port":        types.BoolType,
+}
+
+func (r *natRuleResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_nat_rule"
+}
+
+func (r *natRuleResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	filterAttributes := func(side string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"address": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The %s address to match: an IP address, CIDR subnet or range "+
+					"(`a.b.c.d-a.b.c.e`). Conflicts with `network_id` and `firewall_group_ids`.", side),
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(
+						path.MatchRelative().AtParent().AtName("network_id"),
+						path.MatchRelative().AtParent().AtName("firewall_group_ids"),
+					),
+				},
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("The %s port to match.", side),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The ID of a network whose subnet is the %s to match.", side),
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("firewall_group_ids")),
+				},
+			},
+			"firewall_group_ids": schema.SetAttribute{
+				MarkdownDescription: fmt.Sprintf("IDs of `terrifi_firewall_group`s whose addresses or ports are "+
+					"the %s to match.", side),
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"invert_address": schema.BoolAttribute{
+				MarkdownDescription: "Whether to match every address except the configured one. Default: `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"invert_port": schema.BoolAttribute{
+				MarkdownDescription: "Whether to match every port except the configured one. Default: `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a NAT rule on the UniFi gateway. `DNAT` rules rewrite the destination " +
+			"of matching traffic, `SNAT` rules rewrite its source, and `MASQUERADE` rules rewrite its source to " +
+			"the address of the outgoing interface.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the NAT rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the NAT rule with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The name of the NAT rule, shown in the UniFi UI.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The kind of translation. Valid values: `DNAT`, `SNAT`, `MASQUERADE`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("DNAT", "SNAT", "MASQUERADE"),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the NAT rule is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"ip_version": schema.StringAttribute{
+				MarkdownDescription: "IP version to match. Valid values: `IPV4`, `IPV6`. Default: `IPV4`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("IPV4"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("IPV4", "IPV6"),
+				},
+			},
+
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "Protocol to match. Valid values: `all`, `tcp`, `udp`, `tcp_udp`. Default: `all`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("all"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("all", "tcp", "udp", "tcp_udp"),
+				},
+			},
+
+			"in_interface": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network (for example a WAN) whose inbound traffic the rule " +
+					"matches. Typically set on `DNAT` rules.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"out_interface": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network (for example a WAN) whose outbound traffic the rule " +
+					"matches. Typically set on `SNAT` and `MASQUERADE` rules.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"ip_address": schema.StringAttribute{
+				MarkdownDescription: "The address matching traffic is translated to: the new destination of a " +
+					"`DNAT` rule, or the new source of an `SNAT` rule. Required for those types unless `exclude` " +
+					"is `true`, and not supported by `MASQUERADE` rules.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "The port matching traffic is translated to. Not supported by `MASQUERADE` rules.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+
+			"exclude": schema.BoolAttribute{
+				MarkdownDescription: "Whether matching traffic is exempted from NAT instead of translated, for " +
+					"example to keep a subnet out of a broader `MASQUERADE` rule. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"logging": schema.BoolAttribute{
+				MarkdownDescription: "Whether to enable syslog logging for translated traffic. Default: `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"source": schema.SingleNestedBlock{
+				MarkdownDescription: "Matches traffic by source. Without it, traffic from any source matches.",
+				Attributes:          filterAttributes("source"),
+			},
+
+			"destination": schema.SingleNestedBlock{
+				MarkdownDescription: "Matches traffic by destination. Without it, traffic to any destination matches.",
+				Attributes:          filterAttributes("destination"),
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *natRuleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		natRuleTranslationValidator{},
+	}
+}
+
+func (r *natRuleResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *natRuleResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan natRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateNat(ctx, site, r.modelToAPI(ctx, &plan, nil))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating NAT Rule", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *natRuleResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state natRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	rule, err := r.client.GetNat(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading NAT Rule",
+			fmt.Sprintf("Could not read NAT rule %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(rule, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *natRuleResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan natRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetNat(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading NAT Rule for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "NAT rule", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	r.applyPlanToState(&plan, &state)
+
+	rule := r.modelToAPI(ctx, &state, current)
+	rule.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateNat(ctx, site, rule)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating NAT Rule", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *natRuleResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state natRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteNat(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting NAT Rule", err.Error())
+	}
+}
+
+func (r *natRuleResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *natRuleResource) applyPlanToState(plan, state *natRuleResourceModel) {
+	state.Description = plan.Description
+	state.Type = plan.Type
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		state.Enabled = plan.Enabled
+	}
+	if !plan.IPVersion.IsNull() && !plan.IPVersion.IsUnknown() {
+		state.IPVersion = plan.IPVersion
+	}
+	if !plan.Protocol.IsNull() && !plan.Protocol.IsUnknown() {
+		state.Protocol = plan.Protocol
+	}
+	if !plan.Exclude.IsNull() && !plan.Exclude.IsUnknown() {
+		state.Exclude = plan.Exclude
+	}
+	if !plan.Logging.IsNull() && !plan.Logging.IsUnknown() {
+		state.Logging = plan.Logging
+	}
+	// Optional attributes without defaults: a null plan value means the user
+	// removed the attribute.
+	state.InInterface = plan.InInterface
+	state.OutInterface = plan.OutInterface
+	state.IPAddress = plan.IPAddress
+	state.Port = plan.Port
+	state.Source = plan.Source
+	state.Destination = plan.Destination
+}
+
+// modelToAPI builds the rule to send to the controller. When updating,
+// current is the rule as it is on the controller, and the fields the
+// provider does not manage, such as the rule's position, are copied from it.
+func (r *natRuleResource) modelToAPI(ctx context.Context, m *natRuleResourceModel, current *unifi.Nat) *unifi.Nat {
+	rule := &unifi.Nat{}
+	if current != nil {
+		rule.RuleIndex = current.RuleIndex
+		rule.SettingPreference = current.SettingPreference
+		rule.PppoeUseBaseInterface = current.PppoeUseBaseInterface
+	}
+	mapModelToAPI(m, rule)
+
+	rule.SourceFilter = (*unifi.NatSourceFilter)(natRuleFilterToAPI(ctx, m.Source))
+	rule.DestinationFilter = (*unifi.NatDestinationFilter)(natRuleFilterToAPI(ctx, m.Destination))
+	return rule
+}
+
+func (r *natRuleResource) apiToModel(rule *unifi.Nat, m *natRuleResourceModel, site string) {
+	mapAPIToModel(rule, m)
+	m.ID = types.StringValue(rule.ID)
+	m.Site = types.StringValue(site)
+
+	m.Source = natRuleFilterToModel((*unifi.NatSourceFilter)(rule.SourceFilter), m.Source)
+	m.Destination = natRuleFilterToModel((*unifi.NatSourceFilter)(rule.DestinationFilter), m.Destination)
+}
+
+// natRuleFilterToAPI converts a source or destination block to the
+// controller's filter. A missing block is sent as a NONE filter, so removing
+// it clears the filter on the controller.
+func natRuleFilterToAPI(ctx context.Context, o types.Object) *unifi.NatSourceFilter {
+	f := &unifi.NatSourceFilter{FilterType: "NONE"}
+	if o.IsNull() || o.IsUnknown() {
+		return f
+	}
+	var m natRuleFilterModel
+	o.As(ctx, &m, basetypes.ObjectAsOptions{})
+	mapModelToAPI(&m, f)
+
+	switch {
+	case !m.NetworkID.IsNull():
+		f.FilterType = "NETWORK_CONF"
+	case !m.FirewallGroupIDs.IsNull() && !m.FirewallGroupIDs.IsUnknown():
+		f.FilterType = "FIREWALL_GROUPS"
+		f.FirewallGroupIDs = sortedSetStrings(m.FirewallGroupIDs)
+	case !m.Address.IsNull() || !m.Port.IsNull():
+		f.FilterType = "ADDRESS_AND_PORT"
+	}
+	return f
+}
+
+// natRuleFilterToModel converts the controller's filter to a source or
+// destination block. Only the attributes of the filter's type are read, since
+// the controller keeps the others when the type changes in the UI. A NONE
+// filter is read as a missing block, unless prior is an empty block.
+func natRuleFilterToModel(f *unifi.NatSourceFilter, prior types.Object) types.Object {
+	if f == nil {
+		f = &unifi.NatSourceFilter{}
+	}
+
+	var m natRuleFilterModel
+	mapAPIToModel(f, &m)
+	m.FirewallGroupIDs = types.SetNull(types.StringType)
+	switch f.FilterType {
+	case "NETWORK_CONF":
+		m.Address = types.StringNull()
+	case "FIREWALL_GROUPS":
+		m.Address = types.StringNull()
+		m.NetworkID = types.StringNull()
+		m.FirewallGroupIDs = stringSetOrNull(f.FirewallGroupIDs)
+	case "ADDRESS_AND_PORT":
+		m.NetworkID = types.StringNull()
+	default:
+		if prior.IsNull() || prior.IsUnknown() {
+			return types.ObjectNull(natRuleFilterAttrTypes)
+		}
+		m.Address = types.StringNull()
+		m.Port = types.Int64Null()
+		m.NetworkID = types.StringNull()
+	}
+
+	obj, _ := types.ObjectValueFrom(context.Background(), natRuleFilterAttrTypes, m)
+	return obj
+}
+
+// natRuleTranslationValidator checks that ip_address and port fit the rule's
+// type.
+type natRuleTranslationValidator struct{}
+
+func (v natRuleTranslationValidator) Description(_ context.Context) string {
+	return "ip_address is required for DNAT and SNAT rules that don't set exclude, and MASQUERADE rules " +
+		"support neither ip_address nor port."
+}
+
+func (v natRuleTranslationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v natRuleTranslationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var ruleType, ipAddress types.String
+	var port types.Int64
+	var exclude types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &ruleType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ip_address"), &ipAddress)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("port"), &port)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("exclude"), &exclude)...)
+	if resp.Diagnostics.HasError() || ruleType.IsUnknown() || exclude.IsUnknown() {
+		return
+	}
+
+	switch ruleType.ValueString() {
+	case "DNAT", "SNAT":
+		if ipAddress.IsNull() && !exclude.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ip_address"),
+				"Missing Translated Address",
+				fmt.Sprintf("%s rules translate traffic to ip_address, so it must be set unless exclude is true.",
+					ruleType.ValueString()),
+			)
+		}
+	case "MASQUERADE":
+		for _, a := range []struct {
+			name string
+			set  bool
+		}{
+			{"ip_address", !ipAddress.IsNull()},
+			{"port", !port.IsNull()},
+		} {
+			if a.set {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(a.name),
+					"Unsupported Attribute",
+					fmt.Sprintf("MASQUERADE rules translate traffic to the address of the outgoing interface, "+
+						"so %s is not supported. Use an SNAT rule instead.", a.name),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func natRuleFilterObject(t *testing.T, m natRuleFilterModel) types.Object {
+	t.Helper()
+	obj, diags := types.ObjectValueFrom(context.Background(), natRuleFilterAttrTypes, m)
+	require.False(t, diags.HasError(), diags)
+	return obj
+}
+
+func TestNATRuleModelToAPI(t *testing.T) {
+	r := &natRuleResource{}
+	ctx := context.Background()
+
+	t.Run("DNAT with destination address and port", func(t *testing.T) {
+		m := &natRuleResourceModel{
+			Description: types.StringValue("Web"),
+			Type:        types.StringValue("DNAT"),
+			Enabled:     types.BoolValue(true),
+			IPVersion:   types.StringValue("IPV4"),
+			Protocol:    types.StringValue("tcp"),
+			InInterface: types.StringValue("wan-id"),
+			IPAddress:   types.StringValue("192.168.1.10"),
+			Port:        types.Int64Value(8443),
+			Exclude:     types.BoolValue(false),
+			Logging:     types.BoolValue(true),
+			Source:      types.ObjectNull(natRuleFilterAttrTypes),
+			Destination: natRuleFilterObject(t, natRuleFilterModel{
+				Address:          types.StringValue("203.0.113.7"),
+				Port:             types.Int64Value(443),
+				NetworkID:        types.StringNull(),
+				FirewallGroupIDs: types.SetNull(types.StringType),
+				InvertAddress:    types.BoolValue(false),
+				InvertPort:       types.BoolValue(false),
+			}),
+		}
+
+		port := int64(443)
+		rule := r.modelToAPI(ctx, m, nil)
+		assert.Equal(t, "Web", rule.Description)
+		assert.Equal(t, "DNAT", rule.Type)
+		assert.True(t, rule.Enabled)
+		assert.Equal(t, "tcp", rule.Protocol)
+		assert.Equal(t, "wan-id", rule.InInterface)
+		assert.Empty(t, rule.OutInterface)
+		assert.Equal(t, "192.168.1.10", rule.IPAddress)
+		require.NotNil(t, rule.Port)
+		assert.Equal(t, int64(8443), *rule.Port)
+		assert.True(t, rule.Logging)
+		assert.Equal(t, &unifi.NatSourceFilter{FilterType: "NONE"}, rule.SourceFilter)
+		assert.Equal(t, &unifi.NatDestinationFilter{
+			FilterType: "ADDRESS_AND_PORT",
+			Address:    "203.0.113.7",
+			Port:       &port,
+		}, rule.DestinationFilter)
+	})
+
+	t.Run("filter type follows the configured attributes", func(t *testing.T) {
+		m := &natRuleResourceModel{
+			Description: types.StringValue("Masq"),
+			Type:        types.StringValue("MASQUERADE"),
+			Source: natRuleFilterObject(t, natRuleFilterModel{
+				Address:          types.StringNull(),
+				Port:             types.Int64Null(),
+				NetworkID:        types.StringValue("lan-id"),
+				FirewallGroupIDs: types.SetNull(types.StringType),
+				InvertAddress:    types.BoolValue(true),
+				InvertPort:       types.BoolValue(false),
+			}),
+			Destination: natRuleFilterObject(t, natRuleFilterModel{
+				Address:   types.StringNull(),
+				Port:      types.Int64Null(),
+				NetworkID: types.StringNull(),
+				FirewallGroupIDs: types.SetValueMust(types.StringType, []attr.Value{
+					types.StringValue("g2"), types.StringValue("g1"),
+				}),
+				InvertAddress: types.BoolValue(false),
+				InvertPort:    types.BoolValue(false),
+			}),
+		}
+
+		rule := r.modelToAPI(ctx, m, nil)
+		assert.Equal(t, &unifi.NatSourceFilter{
+			FilterType:    "NETWORK_CONF",
+			NetworkConfID: "lan-id",
+			InvertAddress: true,
+		}, rule.SourceFilter)
+		assert.Equal(t, &unifi.NatDestinationFilter{
+			FilterType:       "FIREWALL_GROUPS",
+			FirewallGroupIDs: []string{"g1", "g2"},
+		}, rule.DestinationFilter)
+	})
+
+	t.Run("unmanaged fields are kept on update", func(t *testing.T) {
+		m := &natRuleResourceModel{
+			Description: types.StringValue("Masq"),
+			Type:        types.StringValue("MASQUERADE"),
+			Source:      types.ObjectNull(natRuleFilterAttrTypes),
+			Destination: types.ObjectNull(natRuleFilterAttrTypes),
+		}
+		index := int64(3)
+		current := &unifi.Nat{
+			RuleIndex:             &index,
+			SettingPreference:     "manual",
+			PppoeUseBaseInterface: true,
+		}
+
+		rule := r.modelToAPI(ctx, m, current)
+		assert.Equal(t, &index, rule.RuleIndex)
+		assert.Equal(t, "manual", rule.SettingPreference)
+		assert.True(t, rule.PppoeUseBaseInterface)
+	})
+}
+
+func TestNATRuleAPIToModel(t *testing.T) {
+	r := &natRuleResource{}
+
+	t.Run("empty fields use controller defaults", func(t *testing.T) {
+		m := natRuleResourceModel{
+			Source:      types.ObjectNull(natRuleFilterAttrTypes),
+			Destination: types.ObjectNull(natRuleFilterAttrTypes),
+		}
+		r.apiToModel(&unifi.Nat{
+			ID:           "nat1",
+			Description:  "Masq",
+			Type:         "MASQUERADE",
+			Enabled:      true,
+			OutInterface: "wan-id",
+			SourceFilter: &unifi.NatSourceFilter{FilterType: "NONE"},
+		}, &m, "default")
+
+		assert.Equal(t, "nat1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "IPV4", m.IPVersion.ValueString())
+		assert.Equal(t, "all", m.Protocol.ValueString())
+		assert.True(t, m.InInterface.IsNull())
+		assert.Equal(t, "wan-id", m.OutInterface.ValueString())
+		assert.True(t, m.IPAddress.IsNull())
+		assert.True(t, m.Port.IsNull())
+		assert.True(t, m.Source.IsNull())
+		assert.True(t, m.Destination.IsNull())
+	})
+
+	t.Run("only the attributes of the filter type are read", func(t *testing.T) {
+		m := natRuleResourceModel{
+			Source:      types.ObjectNull(natRuleFilterAttrTypes),
+			Destination: types.ObjectNull(natRuleFilterAttrTypes),
+		}
+		r.apiToModel(&unifi.Nat{
+			ID:   "nat1",
+			Type: "SNAT",
+			SourceFilter: &unifi.NatSourceFilter{
+				FilterType:    "NETWORK_CONF",
+				NetworkConfID: "lan-id",
+				Address:       "10.0.0.0/24",
+			},
+			DestinationFilter: &unifi.NatDestinationFilter{
+				FilterType:       "FIREWALL_GROUPS",
+				FirewallGroupIDs: []string{"g1"},
+				NetworkConfID:    "stale",
+				InvertPort:       true,
+			},
+		}, &m, "default")
+
+		var src, dst natRuleFilterModel
+		require.False(t, m.Source.As(context.Background(), &src, basetypes.ObjectAsOptions{}).HasError())
+		require.False(t, m.Destination.As(context.Background(), &dst, basetypes.ObjectAsOptions{}).HasError())
+		assert.Equal(t, "lan-id", src.NetworkID.ValueString())
+		assert.True(t, src.Address.IsNull())
+		assert.True(t, src.FirewallGroupIDs.IsNull())
+		assert.True(t, dst.NetworkID.IsNull())
+		assert.Equal(t, []string{"g1"}, sortedSetStrings(dst.FirewallGroupIDs))
+		assert.True(t, dst.InvertPort.ValueBool())
+	})
+
+	t.Run("empty block is kept", func(t *testing.T) {
+		empty := natRuleFilterObject(t, natRuleFilterModel{
+			Address:          types.StringNull(),
+			Port:             types.Int64Null(),
+			NetworkID:        types.StringNull(),
+			FirewallGroupIDs: types.SetNull(types.StringType),
+			InvertAddress:    types.BoolValue(false),
+			InvertPort:       types.BoolValue(false),
+		})
+		m := natRuleResourceModel{Source: empty, Destination: types.ObjectNull(natRuleFilterAttrTypes)}
+		r.apiToModel(&unifi.Nat{ID: "nat1", Type: "MASQUERADE"}, &m, "default")
+
+		assert.True(t, m.Source.Equal(empty))
+		assert.True(t, m.Destination.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccNATRule_basic(t *testing.T) {
+	desc := fmt.Sprintf("tfacc-nat-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_nat_rule" "test" {
+  description = %q
+  type        = "SNAT"
+  ip_address  = "192.0.2.10"
+
+  source {
+    address = "192.168.77.0/24"
+  }
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_nat_rule.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "ip_version", "IPV4"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "protocol", "all"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "source.address", "192.168.77.0/24"),
+					resource.TestCheckNoResourceAttr("terrifi_nat_rule.test", "destination.address"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_nat_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_nat_rule" "test" {
+  description = %q
+  type        = "DNAT"
+  protocol    = "tcp"
+  ip_address  = "192.168.77.10"
+  port        = 8443
+
+  destination {
+    port = 18443
+  }
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "type", "DNAT"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "port", "8443"),
+					resource.TestCheckResourceAttr("terrifi_nat_rule.test", "destination.port", "18443"),
+					resource.TestCheckNoResourceAttr("terrifi_nat_rule.test", "source.address"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNATRule_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_nat_rule" "test" {
+  description = "missing address"
+  type        = "DNAT"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing Translated Address`),
+			},
+			{
+				Config: `
+resource "terrifi_nat_rule" "test" {
+  description = "masquerade"
+  type        = "MASQUERADE"
+  port        = 8080
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unsupported Attribute`),
+			},
+		},
+	})
+}
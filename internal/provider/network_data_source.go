@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &networkDataSource{}
+
+func NewNetworkDataSource() datasource.DataSource {
+	return &networkDataSource{}
+}
+
+type networkDataSource struct {
+	client ClientAPI
+}
+
+type networkDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Site    types.String `tfsdk:"site"`
+	Name    types.String `tfsdk:"name"`
+	Purpose types.String `tfsdk:"purpose"`
+	VLANID  types.Int64  `tfsdk:"vlan_id"`
+	Subnet  types.String `tfsdk:"subnet"`
+}
+
+func (d *networkDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network"
+}
+
+func (d *networkDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a network by name. Useful for referencing networks that are not managed " +
+			"by Terraform, such as the Default LAN.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the network to look up.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to look up the network in. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network.",
+				Computed:            true,
+			},
+
+			"purpose": schema.StringAttribute{
+				MarkdownDescription: "The purpose of the network, e.g. `corporate`, `guest`, `vlan-only`, or `wan`.",
+				Computed:            true,
+			},
+
+			"vlan_id": schema.Int64Attribute{
+				MarkdownDescription: "The VLAN ID of the network, or null for an untagged network.",
+				Computed:            true,
+			},
+
+			"subnet": schema.StringAttribute{
+				MarkdownDescription: "The gateway IP and subnet of the network in CIDR notation, or null if it has none.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *networkDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *networkDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config networkDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+	name := config.Name.ValueString()
+
+	networks, err := d.client.ListNetwork(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Networks",
+			fmt.Sprintf("Could not list networks in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	for _, network := range networks {
+		if network.Name == nil || *network.Name != name {
+			continue
+		}
+		config.ID = types.StringValue(network.ID)
+		config.Site = types.StringValue(site)
+		config.Purpose = types.StringValue(network.Purpose)
+		config.VLANID = types.Int64PointerValue(network.VLAN)
+		config.Subnet = types.StringPointerValue(network.IPSubnet)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Network Not Found",
+		fmt.Sprintf("No network named %q in site %q.", name, site),
+	)
+}
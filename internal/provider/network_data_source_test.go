@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccNetworkDataSource_basic(t *testing.T) {
+	netName := fmt.Sprintf("tfacc-net-ds-%s", randomSuffix())
+	vlan := randomVLAN()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+}
+
+data "terrifi_network" "test" {
+  name       = %q
+  depends_on = [terrifi_network.test]
+}
+`, netName, vlan, vlan/256, vlan%256, netName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.terrifi_network.test", "id",
+						"terrifi_network.test", "id",
+					),
+					resource.TestCheckResourceAttr("data.terrifi_network.test", "purpose", "corporate"),
+					resource.TestCheckResourceAttr("data.terrifi_network.test", "vlan_id", fmt.Sprintf("%d", vlan)),
+					resource.TestCheckResourceAttr("data.terrifi_network.test", "subnet", fmt.Sprintf("10.%d.%d.1/24", vlan/256, vlan%256)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "terrifi_network" "test" {
+  name = "tfacc-missing-%s"
+}
+`, randomSuffix()),
+				ExpectError: regexp.MustCompile(`Network Not Found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &networkGroupsDataSource{}
+
+func NewNetworkGroupsDataSource() datasource.DataSource {
+	return &networkGroupsDataSource{}
+}
+
+type networkGroupsDataSource struct {
+	client ClientAPI
+}
+
+type networkGroupsDataSourceModel struct {
+	Site      types.String `tfsdk:"site"`
+	LANGroups types.List   `tfsdk:"lan_groups"`
+	WANGroups types.List   `tfsdk:"wan_groups"`
+}
+
+// networkGroups are the network groups a gateway has ports for. Each list is
+// sorted, so the primary group ("LAN" or "WAN") comes first.
+type networkGroups struct {
+	LAN []string
+	WAN []string
+}
+
+func (d *networkGroupsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_network_groups"
+}
+
+func (d *networkGroupsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the network groups the site's gateway has ports for: the LAN groups " +
+			"`terrifi_network` can place a network in, and the WAN groups (uplinks) that `nat_outbound` and " +
+			"`terrifi_wan` can refer to. Single-WAN gateways only have `LAN` and `WAN`.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to look up. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"lan_groups": schema.ListAttribute{
+				MarkdownDescription: "The LAN network groups, e.g. `[\"LAN\", \"LAN2\"]`. Null when the site has no " +
+					"gateway.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+
+			"wan_groups": schema.ListAttribute{
+				MarkdownDescription: "The WAN network groups, e.g. `[\"WAN\", \"WAN2\"]`. Null when the site has no " +
+					"gateway.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+func (d *networkGroupsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *networkGroupsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config networkGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	groups, err := gatewayNetworkGroups(ctx, d.client, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Network Groups",
+			fmt.Sprintf("Could not read the gateway's network groups in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	config.Site = types.StringValue(site)
+	config.LANGroups = types.ListNull(types.StringType)
+	config.WANGroups = types.ListNull(types.StringType)
+	if groups != nil {
+		lan, diags := types.ListValueFrom(ctx, types.StringType, groups.LAN)
+		resp.Diagnostics.Append(diags...)
+		wan, diags := types.ListValueFrom(ctx, types.StringType, groups.WAN)
+		resp.Diagnostics.Append(diags...)
+		config.LANGroups, config.WANGroups = lan, wan
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// gatewayNetworkGroups returns the network groups of the site's gateway, or
+// nil if the site has no gateway.
+func gatewayNetworkGroups(ctx context.Context, client ClientAPI, site string) (*networkGroups, error) {
+	devices, err := client.ListDevice(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("listing devices: %w", err)
+	}
+	wans, err := client.GetGatewayWANs(ctx, site)
+	if err != nil {
+		return nil, fmt.Errorf("reading gateway uplinks: %w", err)
+	}
+	return networkGroupsFromGateway(devices, wans), nil
+}
+
+// networkGroupsFromGateway collects the network groups of the first gateway
+// among devices: the groups its ports are assigned to, the uplinks it
+// reports, and the primary LAN and WAN, which every gateway has. It returns
+// nil if there is no gateway.
+func networkGroupsFromGateway(devices []unifi.Device, wans map[string]gatewayWANStatus) *networkGroups {
+	for _, d := range devices {
+		if !gatewayDeviceTypes[d.Type] {
+			continue
+		}
+
+		lan := map[string]bool{"LAN": true}
+		wan := map[string]bool{"WAN": true}
+		for _, o := range d.EthernetOverrides {
+			switch {
+			case o.Disabled:
+			case strings.HasPrefix(o.NetworkGroup, "LAN"):
+				lan[o.NetworkGroup] = true
+			case strings.HasPrefix(o.NetworkGroup, "WAN"):
+				wan[o.NetworkGroup] = true
+			}
+		}
+		for group := range wans {
+			wan[group] = true
+		}
+		return &networkGroups{LAN: sortedKeys(lan), WAN: sortedKeys(wan)}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestNetworkGroupsFromGateway(t *testing.T) {
+	t.Run("no gateway", func(t *testing.T) {
+		groups := networkGroupsFromGateway([]unifi.Device{{Type: "usw"}, {Type: "uap"}}, nil)
+		assert.Nil(t, groups)
+	})
+
+	t.Run("single WAN", func(t *testing.T) {
+		groups := networkGroupsFromGateway([]unifi.Device{{Type: "udm"}}, map[string]gatewayWANStatus{"WAN": {}})
+		require.NotNil(t, groups)
+		assert.Equal(t, []string{"LAN"}, groups.LAN)
+		assert.Equal(t, []string{"WAN"}, groups.WAN)
+	})
+
+	t.Run("port overrides and uplinks", func(t *testing.T) {
+		devices := []unifi.Device{
+			{Type: "usw", EthernetOverrides: []unifi.DeviceEthernetOverrides{{NetworkGroup: "LAN4"}}},
+			{Type: "ugw", EthernetOverrides: []unifi.DeviceEthernetOverrides{
+				{Ifname: "eth0", NetworkGroup: "WAN"},
+				{Ifname: "eth1", NetworkGroup: "LAN"},
+				{Ifname: "eth2", NetworkGroup: "LAN2"},
+				{Ifname: "eth3", NetworkGroup: "LAN3", Disabled: true},
+			}},
+		}
+		groups := networkGroupsFromGateway(devices, map[string]gatewayWANStatus{"WAN": {}, "WAN2": {}})
+		require.NotNil(t, groups)
+		assert.Equal(t, []string{"LAN", "LAN2"}, groups.LAN)
+		assert.Equal(t, []string{"WAN", "WAN2"}, groups.WAN)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccNetworkGroupsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "terrifi_network_groups" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.terrifi_network_groups.test", "site"),
+					resource.TestCheckResourceAttr("data.terrifi_network_groups.test", "lan_groups.0", "LAN"),
+					resource.TestCheckResourceAttr("data.terrifi_network_groups.test", "wan_groups.0", "WAN"),
+				),
+			},
+		},
+	})
+}
@@ -3,16 +3,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -26,6 +31,8 @@ var (
 	_ resource.Resource                = &networkResource{}
 	_ resource.ResourceWithImportState = &networkResource{}
 	_ resource.ResourceWithModifyPlan  = &networkResource{}
+
+	_ resource.ResourceWithConfigValidators = &networkResource{}
 )
 
 func NewNetworkResource() resource.Resource {
@@ -33,7 +40,7 @@ func NewNetworkResource() resource.Resource {
 }
 
 type networkResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type networkResourceModel struct {
@@ -45,13 +52,61 @@ type networkResourceModel struct {
 	Subnet                types.String `tfsdk:"subnet"`
 	NetworkGroup          types.String `tfsdk:"network_group"`
 	DHCPEnabled           types.Bool   `tfsdk:"dhcp_enabled"`
+	DHCPRangeMode         types.String `tfsdk:"dhcp_range_mode"`
 	DHCPStart             types.String `tfsdk:"dhcp_start"`
 	DHCPStop              types.String `tfsdk:"dhcp_stop"`
 	DHCPLease             types.Int64  `tfsdk:"dhcp_lease"`
 	DHCPDns               types.List   `tfsdk:"dhcp_dns"`
+	DHCPDnsAuto           types.Bool   `tfsdk:"dhcp_dns_auto"`
+	DHCPPingCheck         types.Bool   `tfsdk:"dhcp_ping_check"`
+	DHCPGateway           types.String `tfsdk:"dhcp_gateway"`
+	DHCPNtp               types.List   `tfsdk:"dhcp_ntp"`
+	DHCPBootServer        types.String `tfsdk:"dhcp_boot_server"`
+	DHCPBootFilename      types.String `tfsdk:"dhcp_boot_filename"`
+	DHCPUnifiController   types.String `tfsdk:"dhcp_unifi_controller"`
+	DHCPRelayEnabled      types.Bool   `tfsdk:"dhcp_relay_enabled"`
+	DHCPRelayServers      types.List   `tfsdk:"dhcp_relay_servers"`
 	InternetAccessEnabled types.Bool   `tfsdk:"internet_access_enabled"`
+	UPnPEnabled           types.Bool   `tfsdk:"upnp_enabled"`
+	IPv6InterfaceType     types.String `tfsdk:"ipv6_interface_type"`
+	IPv6PrefixID          types.String `tfsdk:"ipv6_prefix_id"`
+	IPv6Subnet            types.String `tfsdk:"ipv6_subnet"`
+	GatewayMAC            types.String `tfsdk:"gateway_mac"`
+	// ZoneID is applied to the firewall zone's network_ids, not the network.
+	ZoneID types.String `tfsdk:"zone_id"`
+
+	NATOutbound []networkNATOutboundModel `tfsdk:"nat_outbound"`
+}
+
+// networkNATOutboundModel pins the source address of the network's outbound
+// traffic on one WAN.
+type networkNATOutboundModel struct {
+	WANNetworkGroup types.String `tfsdk:"wan_network_group"`
+	IPAddress       types.String `tfsdk:"ip_address"`
+	IPAddressPool   types.List   `tfsdk:"ip_address_pool"`
 }
 
+// ipv4Regexp matches a dotted-quad IPv4 address.
+var ipv4Regexp = regexp.MustCompile(`^` + ipv4Pattern + `$`)
+
+// ipv4RangeRegexp matches an IPv4 address or a range of them (a.b.c.d-a.b.c.e).
+var ipv4RangeRegexp = regexp.MustCompile(`^` + ipv4Pattern + `(-` + ipv4Pattern + `)?$`)
+
+const ipv4Pattern = `((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`
+
+// wanNetworkGroupRegexp matches the controller's WAN network groups.
+var wanNetworkGroupRegexp = regexp.MustCompile(`^WAN[2-9]?$`)
+
+// lanNetworkGroupRegexp matches the controller's LAN network groups.
+var lanNetworkGroupRegexp = regexp.MustCompile(`^LAN[2-8]?$`)
+
+// DHCP lease time bounds accepted by the controller, in seconds. Values
+// outside this range are rejected with an opaque api.err.InvalidPayload.
+const (
+	minDHCPLeaseSeconds = 60
+	maxDHCPLeaseSeconds = 31536000 // 365 days
+)
+
 func (r *networkResource) Metadata(
 	_ context.Context,
 	req resource.MetadataRequest,
@@ -117,10 +172,15 @@ func (r *networkResource) Schema(
 			},
 
 			"network_group": schema.StringAttribute{
-				MarkdownDescription: "The network group. Default: `LAN`.",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("LAN"),
+				MarkdownDescription: "The gateway LAN the network is served from: `LAN`, or `LAN2` through `LAN8` on " +
+					"gateways with more than one LAN port group. Default: `LAN`. A group the gateway doesn't have is " +
+					"rejected at apply; see `terrifi_network_groups` for the groups of a site.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("LAN"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(lanNetworkGroupRegexp, "must be LAN, or LAN2 through LAN8"),
+				},
 			},
 
 			"dhcp_enabled": schema.BoolAttribute{
@@ -130,45 +190,265 @@ func (r *networkResource) Schema(
 				Default:             booldefault.StaticBool(false),
 			},
 
+			"dhcp_range_mode": schema.StringAttribute{
+				MarkdownDescription: "How the DHCP pool is chosen. `manual` (the default) uses `dhcp_start` and " +
+					"`dhcp_stop`, or the controller's choice when they are not set. `auto` derives the pool from " +
+					"`subnet`: from the sixth address after the network address up to the last address before the " +
+					"broadcast address (e.g. `192.168.33.6`–`192.168.33.254` for `192.168.33.1/24`), leaving the " +
+					"first addresses for the gateway and static hosts. The pool follows the subnet when it changes. " +
+					"`auto` requires `subnet`, cannot be combined with `dhcp_start` or `dhcp_stop`, and fails if the " +
+					"gateway address falls inside the pool.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "manual"),
+				},
+			},
+
 			"dhcp_start": schema.StringAttribute{
-				MarkdownDescription: "The starting IP address for the DHCP pool.",
+				MarkdownDescription: "The starting IP address for the DHCP pool. Computed when `dhcp_range_mode` is `auto`.",
 				Optional:            true,
 				Computed:            true,
 			},
 
 			"dhcp_stop": schema.StringAttribute{
-				MarkdownDescription: "The ending IP address for the DHCP pool.",
+				MarkdownDescription: "The ending IP address for the DHCP pool. Computed when `dhcp_range_mode` is `auto`.",
 				Optional:            true,
 				Computed:            true,
 			},
 
 			"dhcp_lease": schema.Int64Attribute{
-				MarkdownDescription: "The DHCP lease time in seconds. Default: `86400` (24 hours).",
+				MarkdownDescription: "The DHCP lease time in seconds. Must be between `60` and `31536000` (365 days). Default: `86400` (24 hours).",
 				Optional:            true,
 				Computed:            true,
 				Default:             int64default.StaticInt64(86400),
+				Validators: []validator.Int64{
+					int64validator.Between(minDHCPLeaseSeconds, maxDHCPLeaseSeconds),
+				},
 			},
 
 			"dhcp_dns": schema.ListAttribute{
-				MarkdownDescription: "List of DNS servers for DHCP clients. Maximum 4 servers.",
-				ElementType:         types.StringType,
-				Optional:            true,
-				Computed:            true,
+				MarkdownDescription: "List of DNS servers for DHCP clients. Maximum 4 servers. Setting this switches " +
+					"the network to manual DNS; removing it reverts to automatic DNS.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
 				Validators: []validator.List{
 					listvalidator.SizeAtMost(4),
 				},
 			},
 
+			"dhcp_dns_auto": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCP clients are handed the gateway as their DNS server (the controller's " +
+					"\"Auto\" DNS setting) instead of the `dhcp_dns` list. Defaults to `true` when `dhcp_dns` is not set and " +
+					"`false` when it is. Setting it explicitly to `false` requires `dhcp_dns`.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"dhcp_ping_check": schema.BoolAttribute{
+				MarkdownDescription: "Whether the DHCP server pings an address before leasing it, to avoid handing out " +
+					"addresses already in use by statically configured hosts. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"dhcp_gateway": schema.StringAttribute{
+				MarkdownDescription: "Override the default gateway handed out to DHCP clients. By default clients use the " +
+					"network's gateway address. Remove the attribute to go back to the default.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ipv4Regexp, "must be a valid IPv4 address"),
+				},
+			},
+
+			"dhcp_ntp": schema.ListAttribute{
+				MarkdownDescription: "IPv4 addresses of NTP servers handed out to DHCP clients (DHCP option 42). " +
+					"Maximum 2 servers. Remove the attribute to stop handing out NTP servers.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 2),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(ipv4Regexp, "must be a valid IPv4 address"),
+					),
+				},
+			},
+
+			"dhcp_boot_server": schema.StringAttribute{
+				MarkdownDescription: "The TFTP server (IP address or hostname) for network booting (DHCP option 66). " +
+					"Setting this enables network boot on the network.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+			},
+
+			"dhcp_boot_filename": schema.StringAttribute{
+				MarkdownDescription: "The boot file name for network booting (DHCP option 67), e.g. `pxelinux.0`. " +
+					"Requires `dhcp_boot_server`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 256),
+					stringvalidator.AlsoRequires(path.MatchRoot("dhcp_boot_server")),
+				},
+			},
+
+			"dhcp_unifi_controller": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 address of a UniFi controller handed out to DHCP clients (DHCP option 43), " +
+					"so that access points and switches on this network can find a controller that isn't on the same " +
+					"layer 2 network. Remove the attribute to stop handing it out.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ipv4Regexp, "must be a valid IPv4 address"),
+				},
+			},
+
+			"dhcp_relay_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether DHCP requests on this network are relayed to `dhcp_relay_servers` instead of " +
+					"being answered by the gateway. Cannot be combined with `dhcp_enabled`. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"dhcp_relay_servers": schema.ListAttribute{
+				MarkdownDescription: "IPv4 addresses of the DHCP servers that requests are relayed to. Maximum 5 servers. " +
+					"Required when `dhcp_relay_enabled` is `true`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 5),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(ipv4Regexp, "must be a valid IPv4 address"),
+					),
+				},
+			},
+
 			"internet_access_enabled": schema.BoolAttribute{
 				MarkdownDescription: "Whether internet access is enabled on this network. Default: `true`.",
 				Optional:            true,
 				Computed:            true,
 				Default:             booldefault.StaticBool(true),
 			},
+
+			"upnp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether clients on this network can open ports on the gateway with UPnP and " +
+					"NAT-PMP, when they are enabled for the site with `terrifi_setting_usg`. Set to `false` to keep " +
+					"a network, such as an IoT VLAN, out of UPnP without disabling it for the whole site. When not " +
+					"set, networks created by Terraform don't take part and existing networks keep their current " +
+					"setting.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"ipv6_interface_type": schema.StringAttribute{
+				MarkdownDescription: "How the network gets its IPv6 addresses, as configured on the controller: " +
+					"`pd` (prefix delegation from a WAN), `static`, or `single_network`. Null when IPv6 is disabled.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"ipv6_prefix_id": schema.StringAttribute{
+				MarkdownDescription: "The prefix ID (a hex value such as `1`) the controller assigned to this network " +
+					"within the prefix delegated by the ISP. Combine it with the delegated prefix to derive the network's " +
+					"IPv6 subnet. Null unless `ipv6_interface_type` is `pd`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"ipv6_subnet": schema.StringAttribute{
+				MarkdownDescription: "The network's IPv6 gateway address and prefix length (e.g. `2001:db8:1::1/64`). " +
+					"Null unless `ipv6_interface_type` is `static`.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"nat_outbound": schema.ListNestedAttribute{
+				MarkdownDescription: "Which of a WAN's IP addresses the network's outbound traffic is source-NATed to, " +
+					"for WANs with more than one public IP. WANs without an entry use all of their addresses. Only " +
+					"available on corporate networks. Remove the attribute to go back to the default on every WAN.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"wan_network_group": schema.StringAttribute{
+							MarkdownDescription: "The WAN the entry applies to: `WAN`, `WAN2`, ... (see `terrifi_wan_networks`).",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(wanNetworkGroupRegexp, "must be WAN, or WAN2 through WAN9"),
+							},
+						},
+						"ip_address": schema.StringAttribute{
+							MarkdownDescription: "The WAN IPv4 address to use. Exactly one of `ip_address` and " +
+								"`ip_address_pool` is required.",
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(ipv4Regexp, "must be a valid IPv4 address"),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("ip_address_pool")),
+							},
+						},
+						"ip_address_pool": schema.ListAttribute{
+							MarkdownDescription: "WAN IPv4 addresses or ranges (`a.b.c.d-a.b.c.e`) that connections " +
+								"are spread across.",
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.UniqueValues(),
+								listvalidator.ValueStringsAre(
+									stringvalidator.RegexMatches(ipv4RangeRegexp, "must be an IPv4 address or range"),
+								),
+							},
+						},
+					},
+				},
+			},
+
+			"zone_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the firewall zone the network belongs to. After creating or updating " +
+					"the network, the provider adds it to this zone's `network_ids` and removes it from any other " +
+					"custom zone, so the zone doesn't need to list the network itself. Leave `network_ids` unset on " +
+					"a `terrifi_firewall_zone` whose members are assigned this way, or the two resources will undo " +
+					"each other's changes. When unset, zone membership is not managed by this resource, and removing " +
+					"the attribute leaves the network in its current zone.",
+				Optional: true,
+			},
+
+			"gateway_mac": schema.StringAttribute{
+				MarkdownDescription: "The MAC address of the gateway that routes this network, i.e. the address " +
+					"clients see as their default router. Null for `vlan-only` networks and sites without a UniFi gateway.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+func (r *networkResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		networkDHCPDNSValidator{},
+		networkDHCPRelayValidator{},
+		networkDHCPRangeModeValidator{},
+		networkNATOutboundValidator{},
+	}
+}
+
 func (r *networkResource) Configure(
 	_ context.Context,
 	req resource.ConfigureRequest,
@@ -202,6 +482,12 @@ func (r *networkResource) Create(
 	}
 
 	site := r.client.SiteOrDefault(plan.Site)
+
+	r.checkNetworkGroups(ctx, site, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	network := r.modelToAPI(ctx, &plan)
 
 	created, err := r.client.CreateNetwork(ctx, site, network)
@@ -211,6 +497,16 @@ func (r *networkResource) Create(
 	}
 
 	r.apiToModel(ctx, created, &plan, site)
+	r.setGatewayMAC(ctx, site, &plan)
+
+	if !plan.ZoneID.IsNull() {
+		if err := assignNetworkZone(ctx, r.client, site, created.ID, plan.ZoneID.ValueString()); err != nil {
+			// The network exists, so keep it in state; the next plan retries
+			// the zone assignment.
+			plan.ZoneID = types.StringNull()
+			resp.Diagnostics.AddError("Error Assigning Network to Firewall Zone", err.Error())
+		}
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -241,6 +537,16 @@ func (r *networkResource) Read(
 	}
 
 	r.apiToModel(ctx, network, &state, site)
+	r.setGatewayMAC(ctx, site, &state)
+
+	if !state.ZoneID.IsNull() {
+		zones, err := r.client.ListFirewallZone(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reading Firewall Zones", err.Error())
+			return
+		}
+		state.ZoneID = networkZoneID(zones, state.ID.ValueString())
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -256,11 +562,33 @@ func (r *networkResource) Update(
 		return
 	}
 
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetNetwork(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Network for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(ctx, current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "network", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	r.checkNetworkGroups(ctx, site, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	r.applyPlanToState(&plan, &state)
 
-	site := r.client.SiteOrDefault(state.Site)
 	network := r.modelToAPI(ctx, &state)
 	network.ID = state.ID.ValueString()
+	// IPv6 is configured outside Terraform. The SDK sends
+	// ipv6_interface_type = "none" when it is unset, which would disable
+	// IPv6 on every update, so carry the controller's value over.
+	network.IPV6InterfaceType = current.IPV6InterfaceType
 
 	updated, err := r.client.UpdateNetwork(ctx, site, network)
 	if err != nil {
@@ -269,6 +597,16 @@ func (r *networkResource) Update(
 	}
 
 	r.apiToModel(ctx, updated, &state, site)
+	r.setGatewayMAC(ctx, site, &state)
+
+	if !plan.ZoneID.IsNull() && !plan.ZoneID.Equal(state.ZoneID) {
+		if err := assignNetworkZone(ctx, r.client, site, state.ID.ValueString(), plan.ZoneID.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Error Assigning Network to Firewall Zone", err.Error())
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	state.ZoneID = plan.ZoneID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -284,8 +622,33 @@ func (r *networkResource) Delete(
 	}
 
 	site := r.client.SiteOrDefault(state.Site)
+	networkID := state.ID.ValueString()
+
+	// Objects managed in the same configuration reference the network by ID,
+	// so Terraform destroys them first. Objects that are not in state are
+	// found here, because the controller either refuses the delete with an
+	// unhelpful error or leaves them pointing at a network that no longer
+	// exists.
+	dependents, err := r.dependents(ctx, site, networkID, state.ZoneID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting Network",
+			fmt.Sprintf("Could not check for references to network %s: %s", networkID, err.Error()),
+		)
+		return
+	}
+	if len(dependents) > 0 {
+		resp.Diagnostics.AddError(
+			"Network Is In Use",
+			fmt.Sprintf("Network %q is still used by:\n  - %s\n\n"+
+				"Delete these or move them to another network (or import them into Terraform so they are "+
+				"destroyed first), then destroy the network again.",
+				state.Name.ValueString(), strings.Join(dependents, "\n  - ")),
+		)
+		return
+	}
 
-	err := r.client.DeleteNetwork(ctx, site, state.ID.ValueString(), state.Name.ValueString())
+	err = r.client.DeleteNetwork(ctx, site, networkID, state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Network", err.Error())
 	}
@@ -323,10 +686,57 @@ func (r *networkResource) ModifyPlan(
 		return
 	}
 
+	var config networkResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.Purpose.ValueString() != "vlan-only" {
+		// dhcp_dns_auto follows dhcp_dns unless set explicitly: a DNS list
+		// means manual DNS, and removing the list reverts to auto. Without
+		// this, dhcp_dns (optional+computed) would be planned as unknown
+		// when removed and keep its old value.
+		if config.DHCPDnsAuto.IsNull() {
+			plan.DHCPDnsAuto = types.BoolValue(config.DHCPDns.IsNull())
+		}
+		if plan.DHCPDnsAuto.ValueBool() && config.DHCPDns.IsNull() {
+			plan.DHCPDns = types.ListNull(types.StringType)
+		}
+		if plan.DHCPRangeMode.ValueString() == "auto" && !plan.Subnet.IsUnknown() {
+			start, stop, err := autoDHCPRange(plan.Subnet.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(path.Root("dhcp_range_mode"), "Invalid Automatic DHCP Range", err.Error())
+				return
+			}
+			plan.DHCPStart = types.StringValue(start)
+			plan.DHCPStop = types.StringValue(stop)
+		}
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+
+		if r.client != nil && r.client.WarnUnzonedNetworks() {
+			r.checkZoneMembership(ctx, &plan, &resp.Diagnostics)
+		}
 		return
 	}
 
+	// Switching an existing network to vlan-only replaces it, and WLANs that
+	// reference it follow the new (unknown) ID, so the WLAN's own plan-time
+	// check can't see the problem. Check hotspot WLANs on the old network here.
+	if !req.State.Raw.IsNull() && r.client != nil {
+		var state networkResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Purpose.ValueString() != "vlan-only" {
+			r.checkWLANsForVLANOnly(ctx, &state, &resp.Diagnostics)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+
 	// vlan-only networks carry no IP configuration or DHCP. Null out those
 	// fields so schema defaults (e.g. dhcp_lease=86400) don't produce a
 	// perpetual plan diff against the API response, which omits them entirely.
@@ -336,28 +746,411 @@ func (r *networkResource) ModifyPlan(
 	plan.DHCPStop = types.StringNull()
 	plan.DHCPLease = types.Int64Null()
 	plan.DHCPDns = types.ListNull(types.StringType)
-
-	// internet_access_enabled is not meaningful for vlan-only networks. Override
-	// the schema default (true) to false — but only when the user did not
-	// explicitly set the field in their config. If the user set it explicitly we
-	// must leave the plan value alone or Terraform will reject the plan with
+	plan.DHCPDnsAuto = types.BoolNull()
+	plan.DHCPPingCheck = types.BoolValue(false)
+	plan.DHCPRelayEnabled = types.BoolValue(false)
+
+	// internet_access_enabled and upnp_enabled are not meaningful for vlan-only
+	// networks. Override the schema default (true) and the unknown value to
+	// false — but only when the user did not explicitly set the field in their
+	// config. If the user set it explicitly we must leave the plan value alone or Terraform will reject the plan with
 	// "planned value does not match config value".
-	var config networkResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 	if config.InternetAccessEnabled.IsNull() {
 		plan.InternetAccessEnabled = types.BoolValue(false)
 	}
+	if config.UPnPEnabled.IsNull() {
+		plan.UPnPEnabled = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
+// checkNetworkGroups adds an error for each LAN or WAN network group the
+// plan uses that the site's gateway doesn't have, which the controller would
+// otherwise reject with an opaque error. Groups that prior (the state, on
+// update) already uses, and the primary LAN and WAN, are not checked, so the
+// gateway is only queried when a network moves to another group. The check
+// is skipped if the gateway can't be read or the site has none.
+func (r *networkResource) checkNetworkGroups(ctx context.Context, site string, plan, prior *networkResourceModel, diags *diag.Diagnostics) {
+	used := map[string]bool{"LAN": true, "WAN": true}
+	if prior != nil {
+		used[prior.NetworkGroup.ValueString()] = true
+		for _, e := range prior.NATOutbound {
+			used[e.WANNetworkGroup.ValueString()] = true
+		}
+	}
+
+	type check struct {
+		group string
+		path  path.Path
+		wan   bool
+	}
+	var checks []check
+	if plan.Purpose.ValueString() != "vlan-only" && !used[plan.NetworkGroup.ValueString()] {
+		checks = append(checks, check{plan.NetworkGroup.ValueString(), path.Root("network_group"), false})
+	}
+	for i, e := range plan.NATOutbound {
+		if !used[e.WANNetworkGroup.ValueString()] {
+			checks = append(checks, check{
+				e.WANNetworkGroup.ValueString(),
+				path.Root("nat_outbound").AtListIndex(i).AtName("wan_network_group"),
+				true,
+			})
+		}
+	}
+	if len(checks) == 0 {
+		return
+	}
+
+	groups, err := gatewayNetworkGroups(ctx, r.client, site)
+	if err != nil || groups == nil {
+		return
+	}
+	for _, c := range checks {
+		available, kind := groups.LAN, "LAN"
+		if c.wan {
+			available, kind = groups.WAN, "WAN"
+		}
+		if !slices.Contains(available, c.group) {
+			diags.AddAttributeError(
+				c.path,
+				"Network Group Not Available",
+				fmt.Sprintf("The site's gateway has no %s network group %s. Available %s groups: %s.",
+					kind, c.group, kind, strings.Join(available, ", ")),
+			)
+		}
+	}
+}
+
+// checkWLANsForVLANOnly adds an error for each WLAN on the network that
+// could not be served if the network became vlan-only. Lookup failures are
+// ignored; the check is best-effort.
+func (r *networkResource) checkWLANsForVLANOnly(ctx context.Context, state *networkResourceModel, diags *diag.Diagnostics) {
+	wlans, err := r.client.ListWLAN(ctx, r.client.SiteOrDefault(state.Site))
+	if err != nil {
+		return
+	}
+	for _, w := range wlans {
+		if w.NetworkID != state.ID.ValueString() {
+			continue
+		}
+		app := "standard"
+		if w.IsGuest {
+			app = "hotspot"
+		}
+		if msg := wlanNetworkIncompatibility(w.Name, app, state.Name.ValueString(), "vlan-only"); msg != "" {
+			diags.AddAttributeError(path.Root("purpose"), "Network Cannot Serve WLAN", msg)
+		}
+	}
+}
+
+// checkZoneMembership warns when a network is not a member of any custom
+// firewall zone. A network with zone_id set is in the zone it names; when
+// zone_id is unknown until apply, that is reported instead. A new network
+// without zone_id is reported as unzoned, since only a terrifi_firewall_zone
+// listing its (still unknown) ID could put it in a zone. For existing
+// networks membership is read from the controller. Lookup failures are
+// ignored; the check is best-effort.
+func (r *networkResource) checkZoneMembership(ctx context.Context, plan *networkResourceModel, diags *diag.Diagnostics) {
+	name := plan.Name.ValueString()
+	switch {
+	case plan.ZoneID.IsUnknown():
+		diags.AddAttributeWarning(path.Root("zone_id"), "Zone Membership Not Checked",
+			fmt.Sprintf("The zone_id of network %q is not known until apply, so whether it ends up in a custom "+
+				"firewall zone can't be checked at plan time.", name))
+		return
+	case !plan.ZoneID.IsNull():
+		return
+	case plan.ID.IsNull() || plan.ID.IsUnknown():
+		diags.AddAttributeWarning(path.Root("name"), "Network Not In A Custom Firewall Zone",
+			fmt.Sprintf("Network %q is new and has no zone_id, so the controller puts it in a built-in zone and "+
+				"policies on custom zones do not match its traffic. Set zone_id on the network. If a "+
+				"terrifi_firewall_zone in this configuration lists it in network_ids, this warning can be "+
+				"ignored.", name))
+		return
+	}
+	zones, err := r.client.ListFirewallZone(ctx, r.client.SiteOrDefault(plan.Site))
+	if err != nil {
+		return
+	}
+	if msg := networkZoneWarning(zones, plan.ID.ValueString(), name); msg != "" {
+		diags.AddAttributeWarning(path.Root("name"), "Network Not In A Custom Firewall Zone", msg)
+	}
+}
+
+// networkZoneWarning returns a warning for a network that is in no firewall
+// zone or only in a built-in one, or "" if it is in a custom zone.
+func networkZoneWarning(zones []unifi.FirewallZone, networkID, name string) string {
+	zone := firewallZoneForNetwork(zones, networkID)
+	switch {
+	case zone == nil:
+		return fmt.Sprintf("Network %q is not a member of any firewall zone, so no zone-based firewall policy "+
+			"applies to its traffic. Set zone_id on the network, or add its ID to the network_ids of a "+
+			"terrifi_firewall_zone.", name)
+	case zone.ZoneKey != "":
+		return fmt.Sprintf("Network %q is in the built-in %q zone and not in any custom firewall zone, so "+
+			"policies on custom zones do not match its traffic. If that is not intended, set zone_id on the "+
+			"network, or add its ID to the network_ids of a terrifi_firewall_zone.", name, zone.Name)
+	default:
+		return ""
+	}
+}
+
+// networkZoneID returns the ID of the zone that holds the network, or null
+// if it is not a member of any zone.
+func networkZoneID(zones []unifi.FirewallZone, networkID string) types.String {
+	if zone := firewallZoneForNetwork(zones, networkID); zone != nil {
+		return types.StringValue(zone.ID)
+	}
+	return types.StringNull()
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// networkDHCPDNSValidator ensures dhcp_dns_auto and dhcp_dns agree: auto DNS
+// cannot be combined with a server list, and manual DNS needs one.
+type networkDHCPDNSValidator struct{}
+
+func (v networkDHCPDNSValidator) Description(_ context.Context) string {
+	return "dhcp_dns must not be set when dhcp_dns_auto is true, and must be set when dhcp_dns_auto is false."
+}
+
+func (v networkDHCPDNSValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkDHCPDNSValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var auto types.Bool
+	var dns types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_dns_auto"), &auto)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_dns"), &dns)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if auto.IsNull() || auto.IsUnknown() || dns.IsUnknown() {
+		return
+	}
+
+	dnsSet := !dns.IsNull() && len(dns.Elements()) > 0
+
+	if auto.ValueBool() && dnsSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_dns"),
+			"Conflicting DHCP DNS Attributes",
+			"Attribute \"dhcp_dns\" cannot be specified when \"dhcp_dns_auto\" is true.",
+		)
+	}
+	if !auto.ValueBool() && !dnsSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_dns_auto"),
+			"Missing DHCP DNS Servers",
+			"Attribute \"dhcp_dns\" must be specified when \"dhcp_dns_auto\" is false.",
+		)
+	}
+}
+
+// networkDHCPRelayValidator ensures DHCP relay is configured consistently:
+// relaying needs servers, replaces the gateway's own DHCP server, and is only
+// available on corporate networks.
+type networkDHCPRelayValidator struct{}
+
+func (v networkDHCPRelayValidator) Description(_ context.Context) string {
+	return "dhcp_relay_enabled requires dhcp_relay_servers and a corporate network, and cannot be combined with dhcp_enabled."
+}
+
+func (v networkDHCPRelayValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkDHCPRelayValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var purpose types.String
+	var dhcpEnabled, relayEnabled types.Bool
+	var servers types.List
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("purpose"), &purpose)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_enabled"), &dhcpEnabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_relay_enabled"), &relayEnabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_relay_servers"), &servers)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if relayEnabled.IsUnknown() || servers.IsUnknown() {
+		return
+	}
+
+	serversSet := !servers.IsNull() && len(servers.Elements()) > 0
+
+	if !relayEnabled.ValueBool() {
+		if serversSet {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("dhcp_relay_servers"),
+				"DHCP Relay Not Enabled",
+				"Attribute \"dhcp_relay_servers\" requires \"dhcp_relay_enabled\" to be true.",
+			)
+		}
+		return
+	}
+
+	if !purpose.IsUnknown() && purpose.ValueString() != "corporate" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_relay_enabled"),
+			"DHCP Relay Requires Corporate Network",
+			fmt.Sprintf("DHCP relay is only available on corporate networks, not %q.", purpose.ValueString()),
+		)
+	}
+	if !dhcpEnabled.IsUnknown() && dhcpEnabled.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_relay_enabled"),
+			"Conflicting DHCP Mode Attributes",
+			"Attributes \"dhcp_enabled\" and \"dhcp_relay_enabled\" cannot both be true: a network either "+
+				"serves DHCP itself or relays it.",
+		)
+	}
+	if !serversSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_relay_enabled"),
+			"Missing DHCP Relay Servers",
+			"Attribute \"dhcp_relay_servers\" must be specified when \"dhcp_relay_enabled\" is true.",
+		)
+	}
+}
+
+// networkDHCPRangeModeValidator ensures an automatic DHCP range has a subnet
+// to be derived from and is not combined with an explicit range.
+type networkDHCPRangeModeValidator struct{}
+
+func (v networkDHCPRangeModeValidator) Description(_ context.Context) string {
+	return "dhcp_range_mode = \"auto\" requires subnet and cannot be combined with dhcp_start or dhcp_stop."
+}
+
+func (v networkDHCPRangeModeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkDHCPRangeModeValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var mode, subnet, start, stop types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_range_mode"), &mode)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("subnet"), &subnet)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_start"), &start)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dhcp_stop"), &stop)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if mode.ValueString() != "auto" {
+		return
+	}
+
+	if subnet.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_range_mode"),
+			"Missing Subnet",
+			"Attribute \"subnet\" must be specified when \"dhcp_range_mode\" is \"auto\".",
+		)
+	}
+	if !start.IsNull() || !stop.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dhcp_range_mode"),
+			"Conflicting DHCP Range Attributes",
+			"Attributes \"dhcp_start\" and \"dhcp_stop\" cannot be specified when \"dhcp_range_mode\" is \"auto\".",
+		)
+	}
+}
+
+// networkNATOutboundValidator ensures nat_outbound is only set on corporate
+// networks, with at most one entry per WAN.
+type networkNATOutboundValidator struct{}
+
+func (v networkNATOutboundValidator) Description(_ context.Context) string {
+	return "nat_outbound requires a corporate network and at most one entry per WAN."
+}
+
+func (v networkNATOutboundValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v networkNATOutboundValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var purpose types.String
+	var entries []networkNATOutboundModel
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("purpose"), &purpose)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("nat_outbound"), &entries)...)
+
+	if resp.Diagnostics.HasError() || len(entries) == 0 {
+		return
+	}
+
+	if !purpose.IsUnknown() && purpose.ValueString() != "corporate" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("nat_outbound"),
+			"Outbound NAT Requires Corporate Network",
+			fmt.Sprintf("Outbound NAT is only available on corporate networks, not %q.", purpose.ValueString()),
+		)
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, e := range entries {
+		if e.WANNetworkGroup.IsUnknown() {
+			continue
+		}
+		group := e.WANNetworkGroup.ValueString()
+		if seen[group] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("nat_outbound").AtListIndex(i).AtName("wan_network_group"),
+				"Duplicate Outbound NAT Entry",
+				fmt.Sprintf("%s has more than one nat_outbound entry; combine them into one.", group),
+			)
+		}
+		seen[group] = true
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helper methods
 // ---------------------------------------------------------------------------
 
+// autoDHCPRange returns the DHCP pool for dhcp_range_mode = "auto": the sixth
+// address after the network address through the last address before the
+// broadcast address. subnet is the gateway address in CIDR notation, as the
+// subnet attribute is written.
+func autoDHCPRange(subnet string) (start, stop string, err error) {
+	gateway, err := netip.ParsePrefix(subnet)
+	if err != nil || !gateway.Addr().Is4() {
+		return "", "", fmt.Errorf("subnet %q is not an IPv4 address in CIDR notation", subnet)
+	}
+
+	network := gateway.Masked().Addr().As4()
+	base := uint32(network[0])<<24 | uint32(network[1])<<16 | uint32(network[2])<<8 | uint32(network[3])
+	size := uint64(1) << (32 - gateway.Bits())
+	if size < 8 {
+		return "", "", fmt.Errorf("subnet %s is too small for an automatic DHCP range; use a /29 or larger, "+
+			"or set dhcp_start and dhcp_stop", subnet)
+	}
+
+	first := base + 6
+	last := base + uint32(size-2)
+	addr := func(v uint32) netip.Addr {
+		return netip.AddrFrom4([4]byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)})
+	}
+
+	if gw := gateway.Addr(); gw.Compare(addr(first)) >= 0 && gw.Compare(addr(last)) <= 0 {
+		return "", "", fmt.Errorf("the gateway address %s falls inside the automatic DHCP range %s-%s; "+
+			"use a gateway among the first addresses of the subnet, or set dhcp_start and dhcp_stop",
+			gw, addr(first), addr(last))
+	}
+	return addr(first).String(), addr(last).String(), nil
+}
+
 func (r *networkResource) applyPlanToState(plan, state *networkResourceModel) {
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		state.Name = plan.Name
@@ -383,12 +1176,40 @@ func (r *networkResource) applyPlanToState(plan, state *networkResourceModel) {
 	if !plan.DHCPLease.IsNull() && !plan.DHCPLease.IsUnknown() {
 		state.DHCPLease = plan.DHCPLease
 	}
+	if !plan.DHCPDnsAuto.IsNull() && !plan.DHCPDnsAuto.IsUnknown() {
+		state.DHCPDnsAuto = plan.DHCPDnsAuto
+		// Switching to auto clears the manual list.
+		if plan.DHCPDnsAuto.ValueBool() {
+			state.DHCPDns = plan.DHCPDns
+		}
+	}
 	if !plan.DHCPDns.IsNull() && !plan.DHCPDns.IsUnknown() {
 		state.DHCPDns = plan.DHCPDns
 	}
+	if !plan.DHCPPingCheck.IsNull() && !plan.DHCPPingCheck.IsUnknown() {
+		state.DHCPPingCheck = plan.DHCPPingCheck
+	}
+	if !plan.DHCPRelayEnabled.IsNull() && !plan.DHCPRelayEnabled.IsUnknown() {
+		state.DHCPRelayEnabled = plan.DHCPRelayEnabled
+	}
 	if !plan.InternetAccessEnabled.IsNull() && !plan.InternetAccessEnabled.IsUnknown() {
 		state.InternetAccessEnabled = plan.InternetAccessEnabled
 	}
+	if !plan.UPnPEnabled.IsNull() && !plan.UPnPEnabled.IsUnknown() {
+		state.UPnPEnabled = plan.UPnPEnabled
+	}
+	// The range mode, gateway override, NTP servers, boot options, controller
+	// address and relay servers are optional without a computed value, so a null plan value
+	// means the user removed the attribute and the controller default should
+	// be restored.
+	state.DHCPRangeMode = plan.DHCPRangeMode
+	state.DHCPGateway = plan.DHCPGateway
+	state.DHCPNtp = plan.DHCPNtp
+	state.DHCPBootServer = plan.DHCPBootServer
+	state.DHCPBootFilename = plan.DHCPBootFilename
+	state.DHCPUnifiController = plan.DHCPUnifiController
+	state.DHCPRelayServers = plan.DHCPRelayServers
+	state.NATOutbound = plan.NATOutbound
 }
 
 func (r *networkResource) modelToAPI(ctx context.Context, m *networkResourceModel) *unifi.Network {
@@ -454,7 +1275,15 @@ func (r *networkResource) modelToAPI(ctx context.Context, m *networkResourceMode
 			net.DHCPDLeaseTime = &lease
 		}
 
-		if !m.DHCPDns.IsNull() && !m.DHCPDns.IsUnknown() {
+		// "Auto" DNS (dhcpd_dns_enabled=false) hands out the gateway as the
+		// DNS server and ignores dhcpd_dns_N. The SDK omits empty DNS fields,
+		// so the stale list is left on the controller; apiToModel ignores it.
+		net.DHCPDDNSEnabled = !m.DHCPDnsAuto.ValueBool()
+		if m.DHCPDnsAuto.IsNull() || m.DHCPDnsAuto.IsUnknown() {
+			net.DHCPDDNSEnabled = !m.DHCPDns.IsNull() && len(m.DHCPDns.Elements()) > 0
+		}
+
+		if net.DHCPDDNSEnabled && !m.DHCPDns.IsNull() && !m.DHCPDns.IsUnknown() {
 			var dnsServers []types.String
 			m.DHCPDns.ElementsAs(ctx, &dnsServers, false)
 
@@ -476,9 +1305,56 @@ func (r *networkResource) modelToAPI(ctx context.Context, m *networkResourceMode
 			}
 		}
 
+		if !m.DHCPPingCheck.IsNull() && !m.DHCPPingCheck.IsUnknown() {
+			net.DHCPDConflictChecking = m.DHCPPingCheck.ValueBool()
+		}
+
+		if !m.DHCPGateway.IsNull() && !m.DHCPGateway.IsUnknown() {
+			gateway := m.DHCPGateway.ValueString()
+			net.DHCPDGatewayEnabled = true
+			net.DHCPDGateway = &gateway
+		}
+
+		if !m.DHCPNtp.IsNull() && !m.DHCPNtp.IsUnknown() {
+			var servers []string
+			m.DHCPNtp.ElementsAs(ctx, &servers, false)
+			net.DHCPDNtpEnabled = true
+			if len(servers) > 0 {
+				net.DHCPDNtp1 = &servers[0]
+			}
+			if len(servers) > 1 {
+				net.DHCPDNtp2 = &servers[1]
+			}
+		}
+
+		if !m.DHCPBootServer.IsNull() && !m.DHCPBootServer.IsUnknown() {
+			net.DHCPDBootEnabled = true
+			net.DHCPDBootServer = m.DHCPBootServer.ValueString()
+			if !m.DHCPBootFilename.IsNull() && !m.DHCPBootFilename.IsUnknown() {
+				filename := m.DHCPBootFilename.ValueString()
+				net.DHCPDBootFilename = &filename
+			}
+		}
+
+		if !m.DHCPUnifiController.IsNull() && !m.DHCPUnifiController.IsUnknown() {
+			net.DHCPDUnifiController = m.DHCPUnifiController.ValueStringPointer()
+		}
+
+		if m.DHCPRelayEnabled.ValueBool() && !m.DHCPRelayServers.IsNull() && !m.DHCPRelayServers.IsUnknown() {
+			var servers []string
+			m.DHCPRelayServers.ElementsAs(ctx, &servers, false)
+			net.DHCPRelayEnabled = true
+			net.DHCPRelayServers = servers
+			applySDKRelayServersWorkaround(net)
+		}
+
 		if !m.InternetAccessEnabled.IsNull() {
 			net.InternetAccessEnabled = m.InternetAccessEnabled.ValueBool()
 		}
+
+		net.UPnPLanEnabled = m.UPnPEnabled.ValueBool()
+
+		net.NATOutboundIPAddresses = natOutboundToAPI(ctx, m.NATOutbound)
 	}
 
 	return net
@@ -539,19 +1415,18 @@ func (r *networkResource) apiToModel(ctx context.Context, net *unifi.Network, m
 			m.DHCPLease = types.Int64Null()
 		}
 
-		// Collect non-empty DNS servers into a list.
+		m.DHCPDnsAuto = types.BoolValue(!net.DHCPDDNSEnabled)
+
+		// Collect non-empty DNS servers into a list. In auto mode the
+		// controller keeps (but ignores) the previous manual servers, so
+		// they are not reported.
 		var dnsServers []string
-		if net.DHCPDDNS1 != "" {
-			dnsServers = append(dnsServers, net.DHCPDDNS1)
-		}
-		if net.DHCPDDNS2 != "" {
-			dnsServers = append(dnsServers, net.DHCPDDNS2)
-		}
-		if net.DHCPDDNS3 != "" {
-			dnsServers = append(dnsServers, net.DHCPDDNS3)
-		}
-		if net.DHCPDDNS4 != "" {
-			dnsServers = append(dnsServers, net.DHCPDDNS4)
+		if net.DHCPDDNSEnabled {
+			for _, dns := range []string{net.DHCPDDNS1, net.DHCPDDNS2, net.DHCPDDNS3, net.DHCPDDNS4} {
+				if dns != "" {
+					dnsServers = append(dnsServers, dns)
+				}
+			}
 		}
 
 		if len(dnsServers) > 0 {
@@ -564,7 +1439,60 @@ func (r *networkResource) apiToModel(ctx context.Context, net *unifi.Network, m
 			m.DHCPDns = types.ListNull(types.StringType)
 		}
 
+		m.DHCPPingCheck = types.BoolValue(net.DHCPDConflictChecking)
+
+		// The controller keeps the gateway, NTP and boot values when the feature is
+		// disabled, so only report them while the corresponding flag is on.
+		if net.DHCPDGatewayEnabled && net.DHCPDGateway != nil && *net.DHCPDGateway != "" {
+			m.DHCPGateway = types.StringPointerValue(net.DHCPDGateway)
+		} else {
+			m.DHCPGateway = types.StringNull()
+		}
+
+		var ntpServers []string
+		if net.DHCPDNtpEnabled {
+			for _, ntp := range []*string{net.DHCPDNtp1, net.DHCPDNtp2} {
+				if ntp != nil && *ntp != "" {
+					ntpServers = append(ntpServers, *ntp)
+				}
+			}
+		}
+		if len(ntpServers) > 0 {
+			m.DHCPNtp, _ = types.ListValueFrom(ctx, types.StringType, ntpServers)
+		} else {
+			m.DHCPNtp = types.ListNull(types.StringType)
+		}
+
+		if net.DHCPDBootEnabled && net.DHCPDBootServer != "" {
+			m.DHCPBootServer = types.StringValue(net.DHCPDBootServer)
+			if net.DHCPDBootFilename != nil && *net.DHCPDBootFilename != "" {
+				m.DHCPBootFilename = types.StringPointerValue(net.DHCPDBootFilename)
+			} else {
+				m.DHCPBootFilename = types.StringNull()
+			}
+		} else {
+			m.DHCPBootServer = types.StringNull()
+			m.DHCPBootFilename = types.StringNull()
+		}
+
+		if net.DHCPDUnifiController != nil && *net.DHCPDUnifiController != "" {
+			m.DHCPUnifiController = types.StringPointerValue(net.DHCPDUnifiController)
+		} else {
+			m.DHCPUnifiController = types.StringNull()
+		}
+
+		m.DHCPRelayEnabled = types.BoolValue(net.DHCPRelayEnabled)
+		if net.DHCPRelayEnabled && len(net.DHCPRelayServers) > 0 {
+			m.DHCPRelayServers, _ = types.ListValueFrom(ctx, types.StringType, net.DHCPRelayServers)
+		} else {
+			m.DHCPRelayServers = types.ListNull(types.StringType)
+		}
+
 		m.InternetAccessEnabled = types.BoolValue(net.InternetAccessEnabled)
+		m.UPnPEnabled = types.BoolValue(net.UPnPLanEnabled)
+		m.NATOutbound = natOutboundFromAPI(ctx, net.NATOutboundIPAddresses)
+
+		ipv6InterfaceToModel(net, m)
 	} else {
 		// vlan-only: null out all IP/DHCP fields.
 		m.Subnet = types.StringNull()
@@ -573,13 +1501,215 @@ func (r *networkResource) apiToModel(ctx context.Context, net *unifi.Network, m
 		m.DHCPStop = types.StringNull()
 		m.DHCPLease = types.Int64Null()
 		m.DHCPDns = types.ListNull(types.StringType)
-		// internet_access_enabled is not sent to the API for vlan-only networks.
-		// Store false so it matches what ModifyPlan produces, avoiding a
-		// perpetual diff after import or refresh.
+		m.DHCPDnsAuto = types.BoolNull()
+		m.DHCPPingCheck = types.BoolValue(false)
+		m.DHCPGateway = types.StringNull()
+		m.DHCPNtp = types.ListNull(types.StringType)
+		m.DHCPBootServer = types.StringNull()
+		m.DHCPBootFilename = types.StringNull()
+		m.DHCPUnifiController = types.StringNull()
+		m.DHCPRelayEnabled = types.BoolValue(false)
+		m.DHCPRelayServers = types.ListNull(types.StringType)
+		// internet_access_enabled and upnp_enabled are not sent to the API for
+		// vlan-only networks. Store false so it matches what ModifyPlan
+		// produces, avoiding a perpetual diff after import or refresh.
 		m.InternetAccessEnabled = types.BoolValue(false)
+		m.UPnPEnabled = types.BoolValue(false)
+		m.NATOutbound = nil
+		m.IPv6InterfaceType = types.StringNull()
+		m.IPv6PrefixID = types.StringNull()
+		m.IPv6Subnet = types.StringNull()
 	}
 }
 
+// ipv6InterfaceToModel reports the controller-derived IPv6 configuration of a
+// corporate network. The controller keeps the values of the other interface
+// types when the type is changed, so only those of the current type are set.
+func ipv6InterfaceToModel(net *unifi.Network, m *networkResourceModel) {
+	m.IPv6InterfaceType = types.StringNull()
+	m.IPv6PrefixID = types.StringNull()
+	m.IPv6Subnet = types.StringNull()
+
+	if net.IPV6InterfaceType == nil || *net.IPV6InterfaceType == "" || *net.IPV6InterfaceType == "none" {
+		return
+	}
+	m.IPv6InterfaceType = types.StringValue(*net.IPV6InterfaceType)
+
+	switch *net.IPV6InterfaceType {
+	case "pd":
+		m.IPv6PrefixID = stringValueOrNull(net.IPV6PDPrefixid)
+	case "static":
+		if net.IPV6Subnet != nil {
+			m.IPv6Subnet = stringValueOrNull(*net.IPV6Subnet)
+		}
+	}
+}
+
+// natOutboundToAPI converts nat_outbound entries to the controller's form. The
+// mode follows from which of ip_address and ip_address_pool is set.
+func natOutboundToAPI(ctx context.Context, entries []networkNATOutboundModel) []unifi.NetworkNATOutboundIPAddresses {
+	var result []unifi.NetworkNATOutboundIPAddresses
+	for _, e := range entries {
+		group := e.WANNetworkGroup.ValueString()
+		mode := "ip_address"
+		entry := unifi.NetworkNATOutboundIPAddresses{
+			WANNetworkGroup: &group,
+			IPAddress:       e.IPAddress.ValueString(),
+		}
+		if !e.IPAddressPool.IsNull() && !e.IPAddressPool.IsUnknown() {
+			mode = "ip_address_pool"
+			e.IPAddressPool.ElementsAs(ctx, &entry.IPAddressPool, false)
+		}
+		entry.Mode = &mode
+		result = append(result, entry)
+	}
+	return result
+}
+
+// natOutboundFromAPI converts the controller's outbound NAT entries to
+// nat_outbound. Entries in "all" mode are the default and are not reported.
+func natOutboundFromAPI(ctx context.Context, entries []unifi.NetworkNATOutboundIPAddresses) []networkNATOutboundModel {
+	var result []networkNATOutboundModel
+	for _, e := range entries {
+		if e.WANNetworkGroup == nil || e.Mode == nil {
+			continue
+		}
+		m := networkNATOutboundModel{
+			WANNetworkGroup: types.StringValue(*e.WANNetworkGroup),
+			IPAddress:       types.StringNull(),
+			IPAddressPool:   types.ListNull(types.StringType),
+		}
+		switch *e.Mode {
+		case "ip_address":
+			m.IPAddress = types.StringValue(e.IPAddress)
+		case "ip_address_pool":
+			m.IPAddressPool, _ = types.ListValueFrom(ctx, types.StringType, e.IPAddressPool)
+		default:
+			continue
+		}
+		result = append(result, m)
+	}
+	return result
+}
+
+// gatewayDeviceTypes are the device types that route traffic for a site.
+var gatewayDeviceTypes = map[string]bool{
+	"ugw": true, // USG
+	"udm": true, // Dream Machines and Cloud Gateways
+	"uxg": true, // Next-Gen Gateways
+}
+
+// setGatewayMAC sets gateway_mac to the MAC address of the site's gateway.
+// vlan-only networks are not routed by the gateway, so theirs is null. The
+// lookup is best-effort: on failure a previously known value is kept, since
+// a device list error should not fail a refresh of the network itself.
+func (r *networkResource) setGatewayMAC(ctx context.Context, site string, m *networkResourceModel) {
+	if m.Purpose.ValueString() != "corporate" {
+		m.GatewayMAC = types.StringNull()
+		return
+	}
+
+	devices, err := r.client.ListDevice(ctx, site)
+	if err != nil {
+		if m.GatewayMAC.IsUnknown() {
+			m.GatewayMAC = types.StringNull()
+		}
+		return
+	}
+	m.GatewayMAC = stringValueOrNull(gatewayMAC(devices))
+}
+
+// gatewayMAC returns the MAC address of the first gateway among devices, or
+// "" if there is none.
+func gatewayMAC(devices []unifi.Device) string {
+	for _, d := range devices {
+		if gatewayDeviceTypes[d.Type] {
+			return strings.ToLower(d.MAC)
+		}
+	}
+	return ""
+}
+
+// dependents lists the WLANs, clients, traffic rules and custom firewall
+// zones that reference the network, described for an error message. ownZoneID
+// is the network's own zone_id, whose membership goes away with the network.
+func (r *networkResource) dependents(ctx context.Context, site, networkID, ownZoneID string) ([]string, error) {
+	wlans, err := r.client.ListWLAN(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	// The WLAN list doesn't include VLAN pools, so read each WLAN's pool.
+	pools := map[string]*wlanNetworkPool{}
+	for _, w := range wlans {
+		pool, err := r.client.GetWLANNetworkPool(ctx, site, w.ID)
+		if err != nil {
+			return nil, err
+		}
+		pools[w.ID] = pool
+	}
+	clients, err := r.client.ListClientDevices(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := r.client.ListTrafficRule(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := r.client.ListFirewallZone(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	return networkDependents(networkID, ownZoneID, wlans, pools, clients, rules, zones), nil
+}
+
+// networkDependents describes the WLANs that serve the network (directly or
+// in a VLAN pool), the clients with a fixed IP or network override on it, the
+// traffic rules that target it or its traffic, and the custom firewall zones
+// other than ownZoneID that list it in their network_ids. Built-in zones are
+// left out: the controller puts every network without a custom zone in one,
+// and removes it when the network is deleted.
+func networkDependents(networkID, ownZoneID string, wlans []unifi.WLAN, pools map[string]*wlanNetworkPool, clients []unifi.Client, rules []trafficRule, zones []unifi.FirewallZone) []string {
+	var result []string
+	for _, w := range wlans {
+		pool := pools[w.ID]
+		if w.NetworkID == networkID || (pool != nil && pool.Enabled && slices.Contains(pool.NetworkIDs, networkID)) {
+			result = append(result, fmt.Sprintf("WLAN %q (%s)", w.Name, w.ID))
+		}
+	}
+	for _, c := range clients {
+		fixedIP := c.UseFixedIP && c.NetworkID == networkID
+		override := c.VirtualNetworkOverrideEnabled != nil && *c.VirtualNetworkOverrideEnabled &&
+			c.VirtualNetworkOverrideID == networkID
+		if !fixedIP && !override {
+			continue
+		}
+		name := c.Name
+		if name == "" {
+			name = c.Hostname
+		}
+		if name == "" {
+			result = append(result, fmt.Sprintf("client %s", c.MAC))
+		} else {
+			result = append(result, fmt.Sprintf("client %q (%s)", name, c.MAC))
+		}
+	}
+	for _, rule := range rules {
+		uses := slices.Contains(rule.NetworkIDs, networkID)
+		for _, t := range rule.TargetDevices {
+			uses = uses || (t.Type == "NETWORK" && t.NetworkID == networkID)
+		}
+		if uses {
+			result = append(result, fmt.Sprintf("traffic rule %q (%s)", rule.Description, rule.ID))
+		}
+	}
+	for _, zone := range zones {
+		if zone.ZoneKey == "" && zone.ID != ownZoneID && slices.Contains(zone.NetworkIDs, networkID) {
+			result = append(result, fmt.Sprintf("firewall zone %q (%s)", zone.Name, zone.ID))
+		}
+	}
+	return result
+}
+
 func toAttrValues(vals []types.String) []attr.Value {
 	result := make([]attr.Value, len(vals))
 	for i, v := range vals {
@@ -601,3 +1731,15 @@ func applySDKSettingPreferenceWorkaround(net *unifi.Network) {
 	manual := "manual"
 	net.SettingPreference = &manual
 }
+
+// applySDKRelayServersWorkaround copies the relay servers into
+// RemoteVPNSubnets before a corporate Network is passed to the go-unifi SDK.
+//
+// TODO(go-unifi): Remove this function and its call in modelToAPI when the SDK's
+// marshalCorporate() (network_encode.go) is fixed. That function serializes
+// dhcp_relay_servers from n.RemoteVPNSubnets instead of n.DHCPRelayServers, so
+// without this the controller receives an empty server list. Corporate networks
+// don't otherwise send remote_vpn_subnets, so the copy has no other effect.
+func applySDKRelayServersWorkaround(net *unifi.Network) {
+	net.RemoteVPNSubnets = net.DHCPRelayServers
+}
@@ -3,9 +3,11 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -229,6 +231,7 @@ func TestNetworkAPIToModel(t *testing.T) {
 			Purpose:               "corporate",
 			Name:                  &name,
 			DHCPDEnabled:          true,
+			DHCPDDNSEnabled:       true,
 			DHCPDDNS1:             "8.8.8.8",
 			DHCPDDNS2:             "8.8.4.4",
 			InternetAccessEnabled: true,
@@ -239,6 +242,24 @@ func TestNetworkAPIToModel(t *testing.T) {
 
 		assert.False(t, model.DHCPDns.IsNull())
 		assert.Equal(t, 2, len(model.DHCPDns.Elements()))
+		assert.False(t, model.DHCPDnsAuto.ValueBool())
+	})
+
+	t.Run("auto DNS ignores stale servers", func(t *testing.T) {
+		name := "Test Network"
+		net := &unifi.Network{
+			ID:           "jkl012",
+			Purpose:      "corporate",
+			Name:         &name,
+			DHCPDEnabled: true,
+			DHCPDDNS1:    "8.8.8.8",
+		}
+
+		var model networkResourceModel
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.True(t, model.DHCPDnsAuto.ValueBool())
+		assert.True(t, model.DHCPDns.IsNull())
 	})
 }
 
@@ -274,6 +295,689 @@ func TestNetworkApplyPlanToState(t *testing.T) {
 	})
 }
 
+func TestNetworkDHCPDNSAuto(t *testing.T) {
+	r := &networkResource{}
+	ctx := context.Background()
+
+	dns := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("1.1.1.1")})
+
+	t.Run("manual when servers are set", func(t *testing.T) {
+		net := r.modelToAPI(ctx, &networkResourceModel{
+			Name:        types.StringValue("Lab"),
+			Purpose:     types.StringValue("corporate"),
+			DHCPDns:     dns,
+			DHCPDnsAuto: types.BoolValue(false),
+		})
+		assert.True(t, net.DHCPDDNSEnabled)
+		assert.Equal(t, "1.1.1.1", net.DHCPDDNS1)
+	})
+
+	t.Run("auto when servers are removed", func(t *testing.T) {
+		net := r.modelToAPI(ctx, &networkResourceModel{
+			Name:        types.StringValue("Lab"),
+			Purpose:     types.StringValue("corporate"),
+			DHCPDns:     types.ListNull(types.StringType),
+			DHCPDnsAuto: types.BoolValue(true),
+		})
+		assert.False(t, net.DHCPDDNSEnabled)
+		assert.Empty(t, net.DHCPDDNS1)
+	})
+
+	t.Run("derived from servers when auto is unknown", func(t *testing.T) {
+		net := r.modelToAPI(ctx, &networkResourceModel{
+			Name:        types.StringValue("Lab"),
+			Purpose:     types.StringValue("corporate"),
+			DHCPDns:     dns,
+			DHCPDnsAuto: types.BoolUnknown(),
+		})
+		assert.True(t, net.DHCPDDNSEnabled)
+	})
+
+	t.Run("applyPlanToState clears servers when switching to auto", func(t *testing.T) {
+		state := &networkResourceModel{
+			DHCPDns:     dns,
+			DHCPDnsAuto: types.BoolValue(false),
+		}
+		plan := &networkResourceModel{
+			DHCPDns:     types.ListNull(types.StringType),
+			DHCPDnsAuto: types.BoolValue(true),
+		}
+		r.applyPlanToState(plan, state)
+		assert.True(t, state.DHCPDnsAuto.ValueBool())
+		assert.True(t, state.DHCPDns.IsNull())
+	})
+}
+
+func TestNetworkDHCPOptions(t *testing.T) {
+	r := &networkResource{}
+	ctx := context.Background()
+
+	t.Run("modelToAPI enables gateway and boot options when set", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:             types.StringValue("Lab"),
+			Purpose:          types.StringValue("corporate"),
+			DHCPEnabled:      types.BoolValue(true),
+			DHCPPingCheck:    types.BoolValue(true),
+			DHCPGateway:      types.StringValue("192.168.40.254"),
+			DHCPBootServer:   types.StringValue("192.168.40.5"),
+			DHCPBootFilename: types.StringValue("pxelinux.0"),
+		}
+
+		net := r.modelToAPI(ctx, model)
+
+		assert.True(t, net.DHCPDConflictChecking)
+		assert.True(t, net.DHCPDGatewayEnabled)
+		require.NotNil(t, net.DHCPDGateway)
+		assert.Equal(t, "192.168.40.254", *net.DHCPDGateway)
+		assert.True(t, net.DHCPDBootEnabled)
+		assert.Equal(t, "192.168.40.5", net.DHCPDBootServer)
+		require.NotNil(t, net.DHCPDBootFilename)
+		assert.Equal(t, "pxelinux.0", *net.DHCPDBootFilename)
+	})
+
+	t.Run("modelToAPI leaves gateway and boot disabled when null", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:             types.StringValue("Lab"),
+			Purpose:          types.StringValue("corporate"),
+			DHCPGateway:      types.StringNull(),
+			DHCPBootServer:   types.StringNull(),
+			DHCPBootFilename: types.StringNull(),
+		}
+
+		net := r.modelToAPI(ctx, model)
+
+		assert.False(t, net.DHCPDGatewayEnabled)
+		assert.Nil(t, net.DHCPDGateway)
+		assert.False(t, net.DHCPDBootEnabled)
+		assert.Empty(t, net.DHCPDBootServer)
+	})
+
+	t.Run("apiToModel ignores values of disabled features", func(t *testing.T) {
+		name := "Lab"
+		gateway := "192.168.40.254"
+		filename := "pxelinux.0"
+		net := &unifi.Network{
+			ID:                    "abc",
+			Purpose:               "corporate",
+			Name:                  &name,
+			DHCPDConflictChecking: true,
+			DHCPDGatewayEnabled:   false,
+			DHCPDGateway:          &gateway,
+			DHCPDBootEnabled:      false,
+			DHCPDBootServer:       "192.168.40.5",
+			DHCPDBootFilename:     &filename,
+		}
+
+		var model networkResourceModel
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.True(t, model.DHCPPingCheck.ValueBool())
+		assert.True(t, model.DHCPGateway.IsNull())
+		assert.True(t, model.DHCPBootServer.IsNull())
+		assert.True(t, model.DHCPBootFilename.IsNull())
+
+		net.DHCPDGatewayEnabled = true
+		net.DHCPDBootEnabled = true
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.Equal(t, "192.168.40.254", model.DHCPGateway.ValueString())
+		assert.Equal(t, "192.168.40.5", model.DHCPBootServer.ValueString())
+		assert.Equal(t, "pxelinux.0", model.DHCPBootFilename.ValueString())
+	})
+
+	t.Run("NTP servers round-trip and are ignored while disabled", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:    types.StringValue("Lab"),
+			Purpose: types.StringValue("corporate"),
+			DHCPNtp: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("192.168.40.3"),
+				types.StringValue("192.168.40.4"),
+			}),
+		}
+
+		net := r.modelToAPI(ctx, model)
+		assert.True(t, net.DHCPDNtpEnabled)
+		require.NotNil(t, net.DHCPDNtp1)
+		require.NotNil(t, net.DHCPDNtp2)
+		assert.Equal(t, "192.168.40.3", *net.DHCPDNtp1)
+		assert.Equal(t, "192.168.40.4", *net.DHCPDNtp2)
+
+		net.ID = "abc"
+		var got networkResourceModel
+		r.apiToModel(ctx, net, &got, "default")
+		assert.Equal(t, model.DHCPNtp, got.DHCPNtp)
+
+		net.DHCPDNtpEnabled = false
+		r.apiToModel(ctx, net, &got, "default")
+		assert.True(t, got.DHCPNtp.IsNull())
+
+		model.DHCPNtp = types.ListNull(types.StringType)
+		assert.False(t, r.modelToAPI(ctx, model).DHCPDNtpEnabled)
+	})
+
+	t.Run("controller address round-trips", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:                types.StringValue("Lab"),
+			Purpose:             types.StringValue("corporate"),
+			DHCPUnifiController: types.StringValue("192.168.40.10"),
+		}
+
+		net := r.modelToAPI(ctx, model)
+		require.NotNil(t, net.DHCPDUnifiController)
+		assert.Equal(t, "192.168.40.10", *net.DHCPDUnifiController)
+
+		net.ID = "abc"
+		var got networkResourceModel
+		r.apiToModel(ctx, net, &got, "default")
+		assert.Equal(t, "192.168.40.10", got.DHCPUnifiController.ValueString())
+
+		empty := ""
+		net.DHCPDUnifiController = &empty
+		r.apiToModel(ctx, net, &got, "default")
+		assert.True(t, got.DHCPUnifiController.IsNull())
+
+		model.DHCPUnifiController = types.StringNull()
+		assert.Nil(t, r.modelToAPI(ctx, model).DHCPDUnifiController)
+	})
+
+	t.Run("applyPlanToState clears removed gateway and boot options", func(t *testing.T) {
+		state := &networkResourceModel{
+			DHCPGateway:      types.StringValue("192.168.40.254"),
+			DHCPBootServer:   types.StringValue("192.168.40.5"),
+			DHCPBootFilename: types.StringValue("pxelinux.0"),
+		}
+		plan := &networkResourceModel{
+			DHCPGateway:      types.StringNull(),
+			DHCPBootServer:   types.StringValue("192.168.40.6"),
+			DHCPBootFilename: types.StringNull(),
+		}
+
+		r.applyPlanToState(plan, state)
+
+		assert.True(t, state.DHCPGateway.IsNull())
+		assert.Equal(t, "192.168.40.6", state.DHCPBootServer.ValueString())
+		assert.True(t, state.DHCPBootFilename.IsNull())
+	})
+}
+
+func TestNetworkDHCPRelay(t *testing.T) {
+	r := &networkResource{}
+	ctx := context.Background()
+
+	t.Run("modelToAPI sets relay servers when enabled", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:             types.StringValue("Servers"),
+			Purpose:          types.StringValue("corporate"),
+			DHCPEnabled:      types.BoolValue(false),
+			DHCPRelayEnabled: types.BoolValue(true),
+			DHCPRelayServers: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("10.0.0.5"),
+				types.StringValue("10.0.0.6"),
+			}),
+		}
+
+		net := r.modelToAPI(ctx, model)
+
+		assert.False(t, net.DHCPDEnabled)
+		assert.True(t, net.DHCPRelayEnabled)
+		assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, net.DHCPRelayServers)
+		// The SDK encodes dhcp_relay_servers from RemoteVPNSubnets.
+		assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, net.RemoteVPNSubnets)
+	})
+
+	t.Run("modelToAPI leaves relay disabled by default", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:             types.StringValue("Servers"),
+			Purpose:          types.StringValue("corporate"),
+			DHCPRelayEnabled: types.BoolValue(false),
+			DHCPRelayServers: types.ListNull(types.StringType),
+		}
+
+		net := r.modelToAPI(ctx, model)
+
+		assert.False(t, net.DHCPRelayEnabled)
+		assert.Nil(t, net.DHCPRelayServers)
+		assert.Nil(t, net.RemoteVPNSubnets)
+	})
+
+	t.Run("apiToModel ignores servers while relay is disabled", func(t *testing.T) {
+		name := "Servers"
+		net := &unifi.Network{
+			ID:               "abc",
+			Purpose:          "corporate",
+			Name:             &name,
+			DHCPRelayEnabled: false,
+			DHCPRelayServers: []string{"10.0.0.5"},
+		}
+
+		var model networkResourceModel
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.False(t, model.DHCPRelayEnabled.ValueBool())
+		assert.True(t, model.DHCPRelayServers.IsNull())
+
+		net.DHCPRelayEnabled = true
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.True(t, model.DHCPRelayEnabled.ValueBool())
+		var servers []string
+		model.DHCPRelayServers.ElementsAs(ctx, &servers, false)
+		assert.Equal(t, []string{"10.0.0.5"}, servers)
+	})
+
+	t.Run("apiToModel disables relay for vlan-only networks", func(t *testing.T) {
+		name := "Trunk"
+		net := &unifi.Network{
+			ID:               "abc",
+			Purpose:          "vlan-only",
+			Name:             &name,
+			DHCPRelayEnabled: true,
+			DHCPRelayServers: []string{"10.0.0.5"},
+		}
+
+		var model networkResourceModel
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.False(t, model.DHCPRelayEnabled.ValueBool())
+		assert.True(t, model.DHCPRelayServers.IsNull())
+	})
+
+	t.Run("applyPlanToState clears removed relay servers", func(t *testing.T) {
+		state := &networkResourceModel{
+			DHCPRelayEnabled: types.BoolValue(true),
+			DHCPRelayServers: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.5")}),
+		}
+		plan := &networkResourceModel{
+			DHCPRelayEnabled: types.BoolValue(false),
+			DHCPRelayServers: types.ListNull(types.StringType),
+		}
+
+		r.applyPlanToState(plan, state)
+
+		assert.False(t, state.DHCPRelayEnabled.ValueBool())
+		assert.True(t, state.DHCPRelayServers.IsNull())
+	})
+}
+
+func TestNetworkNATOutbound(t *testing.T) {
+	r := &networkResource{}
+	ctx := context.Background()
+
+	entries := []networkNATOutboundModel{
+		{
+			WANNetworkGroup: types.StringValue("WAN"),
+			IPAddress:       types.StringValue("203.0.113.10"),
+			IPAddressPool:   types.ListNull(types.StringType),
+		},
+		{
+			WANNetworkGroup: types.StringValue("WAN2"),
+			IPAddress:       types.StringNull(),
+			IPAddressPool: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("198.51.100.2-198.51.100.5"),
+			}),
+		},
+	}
+
+	t.Run("modelToAPI derives the mode", func(t *testing.T) {
+		model := &networkResourceModel{
+			Name:        types.StringValue("Servers"),
+			Purpose:     types.StringValue("corporate"),
+			NATOutbound: entries,
+		}
+
+		net := r.modelToAPI(ctx, model)
+
+		require.Len(t, net.NATOutboundIPAddresses, 2)
+		assert.Equal(t, "WAN", *net.NATOutboundIPAddresses[0].WANNetworkGroup)
+		assert.Equal(t, "ip_address", *net.NATOutboundIPAddresses[0].Mode)
+		assert.Equal(t, "203.0.113.10", net.NATOutboundIPAddresses[0].IPAddress)
+		assert.Equal(t, "WAN2", *net.NATOutboundIPAddresses[1].WANNetworkGroup)
+		assert.Equal(t, "ip_address_pool", *net.NATOutboundIPAddresses[1].Mode)
+		assert.Equal(t, []string{"198.51.100.2-198.51.100.5"}, net.NATOutboundIPAddresses[1].IPAddressPool)
+	})
+
+	t.Run("round trip skips default entries", func(t *testing.T) {
+		wan3, all := "WAN3", "all"
+		net := r.modelToAPI(ctx, &networkResourceModel{
+			Purpose:     types.StringValue("corporate"),
+			NATOutbound: entries,
+		})
+		net.NATOutboundIPAddresses = append(net.NATOutboundIPAddresses, unifi.NetworkNATOutboundIPAddresses{
+			WANNetworkGroup: &wan3,
+			Mode:            &all,
+		})
+
+		var model networkResourceModel
+		r.apiToModel(ctx, net, &model, "default")
+
+		assert.Equal(t, entries, model.NATOutbound)
+	})
+
+	t.Run("apiToModel reports nothing for the default", func(t *testing.T) {
+		name := "Servers"
+		var model networkResourceModel
+		r.apiToModel(ctx, &unifi.Network{ID: "abc", Purpose: "corporate", Name: &name}, &model, "default")
+
+		assert.Nil(t, model.NATOutbound)
+	})
+}
+
+func TestNetworkUPnP(t *testing.T) {
+	r := &networkResource{}
+	ctx := context.Background()
+
+	t.Run("modelToAPI sends the opt-out", func(t *testing.T) {
+		net := r.modelToAPI(ctx, &networkResourceModel{
+			Purpose:     types.StringValue("corporate"),
+			UPnPEnabled: types.BoolValue(false),
+		})
+		assert.False(t, net.UPnPLanEnabled)
+
+		net = r.modelToAPI(ctx, &networkResourceModel{
+			Purpose:     types.StringValue("corporate"),
+			UPnPEnabled: types.BoolValue(true),
+		})
+		assert.True(t, net.UPnPLanEnabled)
+	})
+
+	t.Run("apiToModel reads the flag", func(t *testing.T) {
+		var model networkResourceModel
+		r.apiToModel(ctx, &unifi.Network{ID: "abc", Purpose: "corporate", UPnPLanEnabled: true}, &model, "default")
+		assert.True(t, model.UPnPEnabled.ValueBool())
+	})
+
+	t.Run("vlan-only networks report false", func(t *testing.T) {
+		var model networkResourceModel
+		r.apiToModel(ctx, &unifi.Network{ID: "abc", Purpose: "vlan-only", UPnPLanEnabled: true}, &model, "default")
+		assert.False(t, model.UPnPEnabled.ValueBool())
+	})
+
+	t.Run("applyPlanToState keeps the state when unknown", func(t *testing.T) {
+		state := &networkResourceModel{UPnPEnabled: types.BoolValue(true)}
+		r.applyPlanToState(&networkResourceModel{UPnPEnabled: types.BoolUnknown()}, state)
+		assert.True(t, state.UPnPEnabled.ValueBool())
+
+		r.applyPlanToState(&networkResourceModel{UPnPEnabled: types.BoolValue(false)}, state)
+		assert.False(t, state.UPnPEnabled.ValueBool())
+	})
+}
+
+func TestNetworkDependents(t *testing.T) {
+	enabled, disabled := true, false
+	wlans := []unifi.WLAN{
+		{ID: "w1", Name: "Home", NetworkID: "iot"},
+		{ID: "w2", Name: "Pooled", NetworkID: "lan"},
+		{ID: "w3", Name: "Other", NetworkID: "lan"},
+	}
+	pools := map[string]*wlanNetworkPool{
+		"w2": {Enabled: true, NetworkIDs: []string{"lan", "iot"}},
+		"w3": {Enabled: false, NetworkIDs: []string{"iot"}},
+	}
+	clients := []unifi.Client{
+		{MAC: "aa:bb:cc:00:00:01", Name: "Camera", UseFixedIP: true, NetworkID: "iot"},
+		{MAC: "aa:bb:cc:00:00:02", Hostname: "plug", VirtualNetworkOverrideEnabled: &enabled, VirtualNetworkOverrideID: "iot"},
+		{MAC: "aa:bb:cc:00:00:03", UseFixedIP: true, NetworkID: "iot"},
+		{MAC: "aa:bb:cc:00:00:04", UseFixedIP: false, NetworkID: "iot"},
+		{MAC: "aa:bb:cc:00:00:05", VirtualNetworkOverrideEnabled: &disabled, VirtualNetworkOverrideID: "iot"},
+	}
+	rules := []trafficRule{
+		{ID: "r1", Description: "Block IoT", TargetDevices: []trafficRuleTarget{{Type: "NETWORK", NetworkID: "iot"}}},
+		{ID: "r2", Description: "Block access to IoT", NetworkIDs: []string{"iot"}},
+		{ID: "r3", Description: "Unrelated", TargetDevices: []trafficRuleTarget{{Type: "ALL_CLIENTS"}}},
+	}
+	zones := []unifi.FirewallZone{
+		{ID: "z1", Name: "Internal", ZoneKey: "internal", NetworkIDs: []string{"lan", "iot"}},
+		{ID: "z2", Name: "Things", NetworkIDs: []string{"iot"}},
+		{ID: "z3", Name: "Servers", NetworkIDs: []string{"dmz"}},
+	}
+
+	assert.Equal(t, []string{
+		`WLAN "Home" (w1)`,
+		`WLAN "Pooled" (w2)`,
+		`client "Camera" (aa:bb:cc:00:00:01)`,
+		`client "plug" (aa:bb:cc:00:00:02)`,
+		`client aa:bb:cc:00:00:03`,
+		`traffic rule "Block IoT" (r1)`,
+		`traffic rule "Block access to IoT" (r2)`,
+		`firewall zone "Things" (z2)`,
+	}, networkDependents("iot", "", wlans, pools, clients, rules, zones))
+
+	// The network's own zone_id and built-in zones don't hold it back.
+	assert.Empty(t, networkDependents("dmz", "z3", wlans, pools, clients, rules, zones))
+	assert.Equal(t, []string{`firewall zone "Servers" (z3)`},
+		networkDependents("dmz", "", wlans, pools, clients, rules, zones))
+}
+
+func TestNetworkZoneWarning(t *testing.T) {
+	zones := []unifi.FirewallZone{
+		{ID: "z1", Name: "Internal", ZoneKey: "internal", NetworkIDs: []string{"net-default"}},
+		{ID: "z2", Name: "IoT", NetworkIDs: []string{"net-iot", "net-cameras"}},
+		{ID: "z3", Name: "Empty"},
+	}
+
+	t.Run("custom zone member", func(t *testing.T) {
+		assert.Empty(t, networkZoneWarning(zones, "net-cameras", "Cameras"))
+	})
+
+	t.Run("built-in zone member", func(t *testing.T) {
+		msg := networkZoneWarning(zones, "net-default", "Default")
+		assert.Contains(t, msg, `"Default"`)
+		assert.Contains(t, msg, `built-in "Internal" zone`)
+	})
+
+	t.Run("no zone", func(t *testing.T) {
+		msg := networkZoneWarning(zones, "net-orphan", "Orphan")
+		assert.Contains(t, msg, "not a member of any firewall zone")
+	})
+}
+
+func TestNetworkCheckZoneMembership(t *testing.T) {
+	f := newFakeClient()
+	f.zones["z1"] = unifi.FirewallZone{ID: "z1", Name: "Internal", ZoneKey: "internal", NetworkIDs: []string{"net-default"}}
+	f.zones["z2"] = unifi.FirewallZone{ID: "z2", Name: "IoT", NetworkIDs: []string{"net-iot"}}
+	r := &networkResource{client: f}
+
+	check := func(id, zoneID types.String) diag.Diagnostics {
+		var diags diag.Diagnostics
+		r.checkZoneMembership(context.Background(), &networkResourceModel{
+			ID:     id,
+			Name:   types.StringValue("Lab"),
+			ZoneID: zoneID,
+		}, &diags)
+		return diags
+	}
+
+	t.Run("existing custom zone member", func(t *testing.T) {
+		assert.Empty(t, check(types.StringValue("net-iot"), types.StringNull()))
+	})
+
+	t.Run("existing built-in zone member", func(t *testing.T) {
+		diags := check(types.StringValue("net-default"), types.StringNull())
+		require.Len(t, diags, 1)
+		assert.Equal(t, "Network Not In A Custom Firewall Zone", diags[0].Summary())
+	})
+
+	t.Run("new network without zone_id", func(t *testing.T) {
+		diags := check(types.StringUnknown(), types.StringNull())
+		require.Len(t, diags, 1)
+		assert.Equal(t, "Network Not In A Custom Firewall Zone", diags[0].Summary())
+		assert.Contains(t, diags[0].Detail(), "is new and has no zone_id")
+	})
+
+	t.Run("new network with zone_id", func(t *testing.T) {
+		assert.Empty(t, check(types.StringUnknown(), types.StringValue("z2")))
+	})
+
+	t.Run("unknown zone_id", func(t *testing.T) {
+		diags := check(types.StringUnknown(), types.StringUnknown())
+		require.Len(t, diags, 1)
+		assert.Equal(t, "Zone Membership Not Checked", diags[0].Summary())
+	})
+}
+
+func TestNetworkZoneID(t *testing.T) {
+	zones := []unifi.FirewallZone{
+		{ID: "z1", Name: "Internal", ZoneKey: "internal", NetworkIDs: []string{"net-default"}},
+		{ID: "z2", Name: "IoT", NetworkIDs: []string{"net-iot"}},
+	}
+	assert.Equal(t, "z2", networkZoneID(zones, "net-iot").ValueString())
+	assert.Equal(t, "z1", networkZoneID(zones, "net-default").ValueString())
+	assert.True(t, networkZoneID(zones, "net-orphan").IsNull())
+}
+
+func TestNetworkIPv6ToModel(t *testing.T) {
+	t.Run("prefix delegation", func(t *testing.T) {
+		ifType, subnet := "pd", "2001:db8:1::1/64"
+		var m networkResourceModel
+		ipv6InterfaceToModel(&unifi.Network{
+			IPV6InterfaceType: &ifType,
+			IPV6PDPrefixid:    "2",
+			IPV6Subnet:        &subnet,
+		}, &m)
+		assert.Equal(t, "pd", m.IPv6InterfaceType.ValueString())
+		assert.Equal(t, "2", m.IPv6PrefixID.ValueString())
+		assert.True(t, m.IPv6Subnet.IsNull(), "static subnet is not reported for pd")
+	})
+
+	t.Run("static", func(t *testing.T) {
+		ifType, subnet := "static", "2001:db8:1::1/64"
+		var m networkResourceModel
+		ipv6InterfaceToModel(&unifi.Network{
+			IPV6InterfaceType: &ifType,
+			IPV6PDPrefixid:    "2",
+			IPV6Subnet:        &subnet,
+		}, &m)
+		assert.Equal(t, "static", m.IPv6InterfaceType.ValueString())
+		assert.True(t, m.IPv6PrefixID.IsNull(), "prefix ID is not reported for static")
+		assert.Equal(t, "2001:db8:1::1/64", m.IPv6Subnet.ValueString())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ifType := "none"
+		var m networkResourceModel
+		ipv6InterfaceToModel(&unifi.Network{IPV6InterfaceType: &ifType, IPV6PDPrefixid: "2"}, &m)
+		assert.True(t, m.IPv6InterfaceType.IsNull())
+		assert.True(t, m.IPv6PrefixID.IsNull())
+		assert.True(t, m.IPv6Subnet.IsNull())
+	})
+}
+
+func TestGatewayMAC(t *testing.T) {
+	t.Run("gateway present", func(t *testing.T) {
+		devices := []unifi.Device{
+			{MAC: "aa:bb:cc:00:00:01", Type: "uap"},
+			{MAC: "AA:BB:CC:00:00:02", Type: "udm"},
+			{MAC: "aa:bb:cc:00:00:03", Type: "usw"},
+		}
+		assert.Equal(t, "aa:bb:cc:00:00:02", gatewayMAC(devices))
+	})
+
+	t.Run("no gateway", func(t *testing.T) {
+		assert.Empty(t, gatewayMAC([]unifi.Device{{MAC: "aa:bb:cc:00:00:01", Type: "uap"}}))
+	})
+}
+
+func TestAutoDHCPRange(t *testing.T) {
+	tests := []struct {
+		subnet, start, stop string
+	}{
+		{"192.168.33.1/24", "192.168.33.6", "192.168.33.254"},
+		{"10.20.0.1/16", "10.20.0.6", "10.20.255.254"},
+		{"10.0.0.1/29", "10.0.0.6", "10.0.0.6"},
+		{"172.16.8.1/22", "172.16.8.6", "172.16.11.254"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subnet, func(t *testing.T) {
+			start, stop, err := autoDHCPRange(tt.subnet)
+			require.NoError(t, err)
+			assert.Equal(t, tt.start, start)
+			assert.Equal(t, tt.stop, stop)
+		})
+	}
+
+	t.Run("too small", func(t *testing.T) {
+		_, _, err := autoDHCPRange("10.0.0.1/30")
+		assert.ErrorContains(t, err, "too small")
+	})
+
+	t.Run("gateway inside range", func(t *testing.T) {
+		_, _, err := autoDHCPRange("192.168.33.254/24")
+		assert.ErrorContains(t, err, "falls inside")
+	})
+
+	t.Run("not IPv4 CIDR", func(t *testing.T) {
+		_, _, err := autoDHCPRange("192.168.33.1")
+		assert.Error(t, err)
+		_, _, err = autoDHCPRange("fd00::1/64")
+		assert.Error(t, err)
+	})
+}
+
+func TestNetworkCheckNetworkGroups(t *testing.T) {
+	natOutbound := func(group string) []networkNATOutboundModel {
+		return []networkNATOutboundModel{{WANNetworkGroup: types.StringValue(group)}}
+	}
+	newResource := func() (*networkResource, *fakeClient) {
+		fake := newFakeClient()
+		fake.devices = []unifi.Device{{Type: "udm"}}
+		fake.gatewayWANs["WAN"] = gatewayWANStatus{}
+		return &networkResource{client: fake}, fake
+	}
+
+	t.Run("primary groups are not checked", func(t *testing.T) {
+		r, fake := newResource()
+		plan := networkResourceModel{Purpose: types.StringValue("corporate"), NetworkGroup: types.StringValue("LAN"), NATOutbound: natOutbound("WAN")}
+
+		var diags diag.Diagnostics
+		r.checkNetworkGroups(context.Background(), "default", &plan, nil, &diags)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["ListDevice"])
+	})
+
+	t.Run("missing groups", func(t *testing.T) {
+		r, _ := newResource()
+		plan := networkResourceModel{Purpose: types.StringValue("corporate"), NetworkGroup: types.StringValue("LAN2"), NATOutbound: natOutbound("WAN2")}
+
+		var diags diag.Diagnostics
+		r.checkNetworkGroups(context.Background(), "default", &plan, nil, &diags)
+		require.Equal(t, 2, diags.ErrorsCount())
+		assert.Contains(t, diags.Errors()[0].Detail(), "no LAN network group LAN2. Available LAN groups: LAN.")
+		assert.Contains(t, diags.Errors()[1].Detail(), "no WAN network group WAN2. Available WAN groups: WAN.")
+	})
+
+	t.Run("available groups", func(t *testing.T) {
+		r, fake := newResource()
+		fake.devices[0].EthernetOverrides = []unifi.DeviceEthernetOverrides{{NetworkGroup: "LAN2"}}
+		fake.gatewayWANs["WAN2"] = gatewayWANStatus{}
+		plan := networkResourceModel{Purpose: types.StringValue("corporate"), NetworkGroup: types.StringValue("LAN2"), NATOutbound: natOutbound("WAN2")}
+
+		var diags diag.Diagnostics
+		r.checkNetworkGroups(context.Background(), "default", &plan, nil, &diags)
+		assert.False(t, diags.HasError())
+	})
+
+	t.Run("groups already in state are not checked", func(t *testing.T) {
+		r, fake := newResource()
+		state := networkResourceModel{Purpose: types.StringValue("corporate"), NetworkGroup: types.StringValue("LAN2")}
+		plan := state
+
+		var diags diag.Diagnostics
+		r.checkNetworkGroups(context.Background(), "default", &plan, &state, &diags)
+		assert.False(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["ListDevice"])
+	})
+
+	t.Run("unreadable gateway skips the check", func(t *testing.T) {
+		r, fake := newResource()
+		fake.failNext("ListDevice", fmt.Errorf("boom"))
+		plan := networkResourceModel{Purpose: types.StringValue("corporate"), NetworkGroup: types.StringValue("LAN2")}
+
+		var diags diag.Diagnostics
+		r.checkNetworkGroups(context.Background(), "default", &plan, nil, &diags)
+		assert.False(t, diags.HasError())
+	})
+}
+
 // ---------------------------------------------------------------------------
 // Acceptance tests
 // ---------------------------------------------------------------------------
@@ -316,6 +1020,7 @@ resource "terrifi_network" "test" {
 					resource.TestCheckResourceAttr("terrifi_network.test", "internet_access_enabled", "true"),
 					resource.TestCheckResourceAttr("terrifi_network.test", "site", "default"),
 					resource.TestCheckResourceAttrSet("terrifi_network.test", "id"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "ipv6_prefix_id"),
 				),
 			},
 		},
@@ -494,6 +1199,7 @@ resource "terrifi_network" "test" {
 					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_start"),
 					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_stop"),
 					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_lease"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "gateway_mac"),
 					resource.TestCheckResourceAttrSet("terrifi_network.test", "id"),
 					resource.TestCheckResourceAttr("terrifi_network.test", "site", "default"),
 				),
@@ -525,6 +1231,498 @@ resource "terrifi_network" "test" {
 	})
 }
 
+func TestAccNetwork_dhcpOptions(t *testing.T) {
+	name := fmt.Sprintf("tfacc-dhcpopt-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name                  = %q
+  purpose               = "corporate"
+  vlan_id               = 41
+  subnet                = "192.168.41.1/24"
+  dhcp_enabled          = true
+  dhcp_start            = "192.168.41.6"
+  dhcp_stop             = "192.168.41.254"
+  dhcp_ping_check       = true
+  dhcp_gateway          = "192.168.41.2"
+  dhcp_ntp              = ["192.168.41.3", "192.168.41.4"]
+  dhcp_boot_server      = "192.168.41.5"
+  dhcp_boot_filename    = "pxelinux.0"
+  dhcp_unifi_controller = "192.168.41.7"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_ping_check", "true"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_gateway", "192.168.41.2"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_ntp.#", "2"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_ntp.1", "192.168.41.4"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_boot_server", "192.168.41.5"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_boot_filename", "pxelinux.0"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_unifi_controller", "192.168.41.7"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = 41
+  subnet       = "192.168.41.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "192.168.41.6"
+  dhcp_stop    = "192.168.41.254"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_ping_check", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_gateway"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_ntp"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_boot_server"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_boot_filename"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_unifi_controller"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_dhcpDNSAuto(t *testing.T) {
+	name := fmt.Sprintf("tfacc-dnsauto-%s", randomSuffix())
+	base := `
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = 44
+  subnet       = "192.168.44.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "192.168.44.6"
+  dhcp_stop    = "192.168.44.254"
+%s
+}
+`
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(base, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_dns_auto", "true"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_dns.#"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(base, name, `  dhcp_dns = ["1.1.1.1", "9.9.9.9"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_dns_auto", "false"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_dns.#", "2"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_dns.0", "1.1.1.1"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Removing the list reverts to auto DNS.
+				Config: fmt.Sprintf(base, name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_dns_auto", "true"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_dns.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationDHCPDNSAuto(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name          = "tfacc-dns-auto"
+  purpose       = "corporate"
+  subnet        = "192.168.45.1/24"
+  dhcp_dns_auto = true
+  dhcp_dns      = ["1.1.1.1"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting DHCP DNS Attributes`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name          = "tfacc-dns-auto"
+  purpose       = "corporate"
+  subnet        = "192.168.45.1/24"
+  dhcp_dns_auto = false
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing DHCP DNS Servers`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationLeaseBounds(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name       = "tfacc-lease-bounds"
+  purpose    = "corporate"
+  subnet     = "192.168.42.1/24"
+  dhcp_lease = 30
+}
+`,
+				ExpectError: regexp.MustCompile(`(?i)between 60 and 31536000`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationBootFilenameRequiresServer(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-boot-filename"
+  purpose            = "corporate"
+  subnet             = "192.168.43.1/24"
+  dhcp_boot_filename = "pxelinux.0"
+}
+`,
+				ExpectError: regexp.MustCompile(`dhcp_boot_server`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_dhcpRelay(t *testing.T) {
+	name := fmt.Sprintf("tfacc-relay-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name               = %q
+  purpose            = "corporate"
+  vlan_id            = 46
+  subnet             = "192.168.46.1/24"
+  dhcp_relay_enabled = true
+  dhcp_relay_servers = ["10.0.0.5", "10.0.0.6"]
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_relay_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_relay_servers.#", "2"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_relay_servers.0", "10.0.0.5"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_relay_servers.1", "10.0.0.6"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Switching from relay to the gateway's own DHCP server.
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = 46
+  subnet       = "192.168.46.1/24"
+  dhcp_enabled = true
+  dhcp_start   = "192.168.46.6"
+  dhcp_stop    = "192.168.46.254"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_relay_enabled", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_network.test", "dhcp_relay_servers.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationDHCPRelay(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-relay"
+  purpose            = "corporate"
+  subnet             = "192.168.47.1/24"
+  dhcp_enabled       = true
+  dhcp_relay_enabled = true
+  dhcp_relay_servers = ["10.0.0.5"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting DHCP Mode Attributes`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-relay"
+  purpose            = "corporate"
+  subnet             = "192.168.47.1/24"
+  dhcp_relay_enabled = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing DHCP Relay Servers`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-relay"
+  purpose            = "corporate"
+  subnet             = "192.168.47.1/24"
+  dhcp_relay_servers = ["10.0.0.5"]
+}
+`,
+				ExpectError: regexp.MustCompile(`DHCP Relay Not Enabled`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-relay"
+  purpose            = "vlan-only"
+  vlan_id            = 47
+  dhcp_relay_enabled = true
+  dhcp_relay_servers = ["10.0.0.5"]
+}
+`,
+				ExpectError: regexp.MustCompile(`DHCP Relay Requires Corporate Network`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name               = "tfacc-relay"
+  purpose            = "corporate"
+  subnet             = "192.168.47.1/24"
+  dhcp_relay_enabled = true
+  dhcp_relay_servers = ["relay.example.com"]
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a valid IPv4 address`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationNATOutbound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name         = "tfacc-nat"
+  purpose      = "vlan-only"
+  vlan_id      = 48
+  nat_outbound = [{ wan_network_group = "WAN", ip_address = "203.0.113.10" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Outbound NAT Requires Corporate Network`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name    = "tfacc-nat"
+  purpose = "corporate"
+  subnet  = "192.168.48.1/24"
+  nat_outbound = [
+    { wan_network_group = "WAN", ip_address = "203.0.113.10" },
+    { wan_network_group = "WAN", ip_address = "203.0.113.11" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`Duplicate Outbound NAT Entry`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name         = "tfacc-nat"
+  purpose      = "corporate"
+  subnet       = "192.168.48.1/24"
+  nat_outbound = [{ wan_network_group = "WAN" }]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_dhcpRangeModeAuto(t *testing.T) {
+	name := fmt.Sprintf("tfacc-autorange-%s", randomSuffix())
+	config := func(third int) string {
+		return fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name            = %q
+  purpose         = "corporate"
+  vlan_id         = %d
+  subnet          = "192.168.%d.1/24"
+  dhcp_enabled    = true
+  dhcp_range_mode = "auto"
+}
+`, name, third, third)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(48),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_range_mode", "auto"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_start", "192.168.48.6"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_stop", "192.168.48.254"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(48),
+				PlanOnly: true,
+			},
+			{
+				// The range follows the subnet.
+				Config: config(49),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_start", "192.168.49.6"),
+					resource.TestCheckResourceAttr("terrifi_network.test", "dhcp_stop", "192.168.49.254"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_validationDHCPRangeMode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name            = "tfacc-autorange"
+  purpose         = "corporate"
+  subnet          = "192.168.48.1/24"
+  dhcp_range_mode = "auto"
+  dhcp_start      = "192.168.48.100"
+}
+`,
+				ExpectError: regexp.MustCompile(`Conflicting DHCP Range Attributes`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name            = "tfacc-autorange"
+  purpose         = "corporate"
+  dhcp_range_mode = "auto"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing Subnet`),
+			},
+			{
+				Config: `
+resource "terrifi_network" "test" {
+  name            = "tfacc-autorange"
+  purpose         = "corporate"
+  subnet          = "192.168.48.254/24"
+  dhcp_range_mode = "auto"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Automatic DHCP Range`),
+			},
+		},
+	})
+}
+
+func TestAccNetwork_zoneID(t *testing.T) {
+	netName := fmt.Sprintf("tfacc-net-zone-%s", randomSuffix())
+	zone1Name := fmt.Sprintf("tfacc-zone-a-%s", randomSuffix())
+	zone2Name := fmt.Sprintf("tfacc-zone-b-%s", randomSuffix())
+	vlan := randomVLAN()
+
+	config := func(zone string) string {
+		return fmt.Sprintf(`
+resource "terrifi_firewall_zone" "a" {
+  name = %q
+}
+
+resource "terrifi_firewall_zone" "b" {
+  name = %q
+}
+
+resource "terrifi_network" "test" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+  zone_id = terrifi_firewall_zone.%s.id
+}
+`, zone1Name, zone2Name, netName, vlan, vlan/256, vlan%256, zone)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("a"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("terrifi_network.test", "zone_id", "terrifi_firewall_zone.a", "id"),
+				),
+			},
+			// The zones pick up the membership on refresh without a diff.
+			{
+				Config:   config("a"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("b"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("terrifi_network.test", "zone_id", "terrifi_firewall_zone.b", "id"),
+				),
+			},
+			{
+				Config:   config("b"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 func TestAccNetwork_importSiteID(t *testing.T) {
 	name := fmt.Sprintf("tfacc-impsid-%s", randomSuffix())
 	resource.Test(t, resource.TestCase{
@@ -559,3 +1757,37 @@ resource "terrifi_network" "test" {
 		},
 	})
 }
+
+func TestAccNetwork_upnp(t *testing.T) {
+	name := fmt.Sprintf("tfacc-upnp-%s", randomSuffix())
+	config := func(upnp bool) string {
+		return fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = 56
+  subnet       = "192.168.56.1/24"
+  upnp_enabled = %t
+}
+`, name, upnp)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  resource.TestCheckResourceAttr("terrifi_network.test", "upnp_enabled", "true"),
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("terrifi_network.test", "upnp_enabled", "false"),
+			},
+			{
+				ResourceName:      "terrifi_network.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &offlineClientsDataSource{}
+
+func NewOfflineClientsDataSource() datasource.DataSource {
+	return &offlineClientsDataSource{}
+}
+
+type offlineClientsDataSource struct {
+	client ClientAPI
+}
+
+type offlineClientsDataSourceModel struct {
+	Site           types.String         `tfsdk:"site"`
+	Since          types.String         `tfsdk:"since"`
+	IncludeUnnamed types.Bool           `tfsdk:"include_unnamed"`
+	Clients        []offlineClientModel `tfsdk:"clients"`
+}
+
+type offlineClientModel struct {
+	ID       types.String `tfsdk:"id"`
+	MAC      types.String `tfsdk:"mac"`
+	Name     types.String `tfsdk:"name"`
+	Hostname types.String `tfsdk:"hostname"`
+	FixedIP  types.String `tfsdk:"fixed_ip"`
+	LastSeen types.String `tfsdk:"last_seen"`
+}
+
+func (d *offlineClientsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_offline_clients"
+}
+
+func (d *offlineClientsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists known client devices that have not been seen by the controller within a given " +
+			"duration. Useful for finding dead IoT devices or stale `terrifi_client_device` entries to clean up.",
+
+		Attributes: map[string]schema.Attribute{
+			"since": schema.StringAttribute{
+				MarkdownDescription: "How long a client must have been offline to be included, as a Go duration " +
+					"string (e.g. `72h`, `168h`, `30m`).",
+				Required: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+
+			"include_unnamed": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include clients without a name. By default only named clients " +
+					"(the ones someone has configured) are returned, since the controller remembers every MAC it has ever seen.",
+				Optional: true,
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to search. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"clients": schema.ListNestedAttribute{
+				MarkdownDescription: "Offline clients, ordered from longest offline to most recently seen. " +
+					"Clients the controller has never seen online come first.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the client device.",
+							Computed:            true,
+						},
+						"mac": schema.StringAttribute{
+							MarkdownDescription: "The MAC address of the client device.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The display name of the client device.",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							MarkdownDescription: "The hostname the client last reported.",
+							Computed:            true,
+						},
+						"fixed_ip": schema.StringAttribute{
+							MarkdownDescription: "The fixed IP address assigned to the client, if any.",
+							Computed:            true,
+						},
+						"last_seen": schema.StringAttribute{
+							MarkdownDescription: "When the client was last seen, in RFC 3339 format. Null if never seen.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *offlineClientsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *offlineClientsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config offlineClientsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	since, err := time.ParseDuration(config.Since.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("since"), "Invalid Duration", err.Error())
+		return
+	}
+
+	clients, err := d.client.ListClientDevices(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Client Devices",
+			fmt.Sprintf("Could not list client devices in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	offline := filterOfflineClients(clients, time.Now().Add(-since), config.IncludeUnnamed.ValueBool())
+
+	config.Site = types.StringValue(site)
+	config.Clients = make([]offlineClientModel, len(offline))
+	for i, c := range offline {
+		config.Clients[i] = offlineClientToModel(c)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// filterOfflineClients returns the clients last seen before cutoff, sorted
+// from longest offline to most recent. Clients with no last_seen timestamp
+// have never been online and sort first.
+func filterOfflineClients(clients []unifi.Client, cutoff time.Time, includeUnnamed bool) []unifi.Client {
+	var result []unifi.Client
+	for _, c := range clients {
+		if c.Name == "" && !includeUnnamed {
+			continue
+		}
+		if c.LastSeen != nil && !time.Unix(*c.LastSeen, 0).Before(cutoff) {
+			continue
+		}
+		result = append(result, c)
+	}
+
+	lastSeen := func(c unifi.Client) int64 {
+		if c.LastSeen == nil {
+			return 0
+		}
+		return *c.LastSeen
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if a, b := lastSeen(result[i]), lastSeen(result[j]); a != b {
+			return a < b
+		}
+		return result[i].MAC < result[j].MAC
+	})
+	return result
+}
+
+func offlineClientToModel(c unifi.Client) offlineClientModel {
+	m := offlineClientModel{
+		ID:       types.StringValue(c.ID),
+		MAC:      types.StringValue(c.MAC),
+		Name:     stringValueOrNull(c.Name),
+		Hostname: stringValueOrNull(c.Hostname),
+		FixedIP:  stringValueOrNull(c.FixedIP),
+		LastSeen: types.StringNull(),
+	}
+	if c.LastSeen != nil {
+		m.LastSeen = types.StringValue(time.Unix(*c.LastSeen, 0).UTC().Format(time.RFC3339))
+	}
+	return m
+}
+
+// durationValidator checks that a string attribute parses as a Go duration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "must be a duration string such as \"72h\" or \"30m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q is not a valid duration (e.g. \"72h\", \"30m\"): %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+		return
+	}
+	if d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q must be a positive duration.", req.ConfigValue.ValueString()),
+		)
+	}
+}
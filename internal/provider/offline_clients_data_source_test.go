@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests — no TF_ACC, no network, no env vars needed
+// ---------------------------------------------------------------------------
+
+func TestFilterOfflineClients(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	ago := func(d time.Duration) *int64 {
+		v := now.Add(-d).Unix()
+		return &v
+	}
+
+	clients := []unifi.Client{
+		{ID: "online", MAC: "00:00:00:00:00:01", Name: "Laptop", LastSeen: ago(time.Minute)},
+		{ID: "stale", MAC: "00:00:00:00:00:02", Name: "Sensor", LastSeen: ago(100 * time.Hour)},
+		{ID: "older", MAC: "00:00:00:00:00:03", Name: "Plug", LastSeen: ago(500 * time.Hour)},
+		{ID: "never", MAC: "00:00:00:00:00:04", Name: "Reserved"},
+		{ID: "unnamed", MAC: "00:00:00:00:00:05", LastSeen: ago(200 * time.Hour)},
+	}
+	cutoff := now.Add(-72 * time.Hour)
+
+	t.Run("named only", func(t *testing.T) {
+		result := filterOfflineClients(clients, cutoff, false)
+		ids := make([]string, len(result))
+		for i, c := range result {
+			ids[i] = c.ID
+		}
+		assert.Equal(t, []string{"never", "older", "stale"}, ids)
+	})
+
+	t.Run("include unnamed", func(t *testing.T) {
+		result := filterOfflineClients(clients, cutoff, true)
+		ids := make([]string, len(result))
+		for i, c := range result {
+			ids[i] = c.ID
+		}
+		assert.Equal(t, []string{"never", "older", "unnamed", "stale"}, ids)
+	})
+
+	t.Run("seen exactly at cutoff is online", func(t *testing.T) {
+		v := cutoff.Unix()
+		result := filterOfflineClients([]unifi.Client{{ID: "edge", Name: "Edge", LastSeen: &v}}, cutoff, false)
+		assert.Empty(t, result)
+	})
+}
+
+func TestOfflineClientToModel(t *testing.T) {
+	t.Run("seen client", func(t *testing.T) {
+		ts := int64(1_700_000_000)
+		m := offlineClientToModel(unifi.Client{
+			ID:       "c1",
+			MAC:      "aa:bb:cc:dd:ee:ff",
+			Name:     "Sensor",
+			Hostname: "esp-123",
+			FixedIP:  "192.168.1.50",
+			LastSeen: &ts,
+		})
+		assert.Equal(t, "c1", m.ID.ValueString())
+		assert.Equal(t, "aa:bb:cc:dd:ee:ff", m.MAC.ValueString())
+		assert.Equal(t, "Sensor", m.Name.ValueString())
+		assert.Equal(t, "esp-123", m.Hostname.ValueString())
+		assert.Equal(t, "192.168.1.50", m.FixedIP.ValueString())
+		assert.Equal(t, "2023-11-14T22:13:20Z", m.LastSeen.ValueString())
+	})
+
+	t.Run("never seen client", func(t *testing.T) {
+		m := offlineClientToModel(unifi.Client{ID: "c2", MAC: "11:22:33:44:55:66"})
+		assert.True(t, m.Name.IsNull())
+		assert.True(t, m.Hostname.IsNull())
+		assert.True(t, m.FixedIP.IsNull())
+		assert.True(t, m.LastSeen.IsNull())
+	})
+}
+
+func TestDurationValidator(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		ok    bool
+	}{
+		{"72h", true},
+		{"30m", true},
+		{"1h30m", true},
+		{"3d", false},
+		{"0s", false},
+		{"-1h", false},
+		{"", false},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			durationValidator{}.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("since"),
+				ConfigValue: types.StringValue(tc.value),
+			}, resp)
+			assert.Equal(t, !tc.ok, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccOfflineClientsDataSource_basic(t *testing.T) {
+	mac := randomMAC()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// A client that has never connected is always offline.
+				Config: fmt.Sprintf(`
+resource "terrifi_client_device" "test" {
+  mac  = %q
+  name = "tfacc-offline-client"
+}
+
+data "terrifi_offline_clients" "test" {
+  since      = "1h"
+  depends_on = [terrifi_client_device.test]
+}
+`, mac),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.terrifi_offline_clients.test", "clients.*",
+						map[string]string{
+							"mac":  mac,
+							"name": "tfacc-offline-client",
+						},
+					),
+				),
+			},
+		},
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Validation tests (no controller needed)
+// ---------------------------------------------------------------------------
+
+func TestAccOfflineClientsDataSource_validationInvalidSince(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "terrifi_offline_clients" "test" {
+  since = "3 days"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?i)Invalid Duration`),
+			},
+		},
+	})
+}
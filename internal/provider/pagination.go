@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// defaultPageSize is the number of records requested per page from paginated
+// v1 list endpoints when ClientConfig.PageSize is not set.
+const defaultPageSize = 1000
+
+// pageSizeOrDefault returns the configured page size, falling back to
+// defaultPageSize for zero or negative values.
+func (c *Client) pageSizeOrDefault() int {
+	if c.pageSize > 0 {
+		return c.pageSize
+	}
+	return defaultPageSize
+}
+
+// listV1Paged fetches every record from a v1 list endpoint, following the
+// controller's `_start`/`_limit` pagination. Large sites (thousands of clients
+// in the user store) return one page per request, so callers that issued a
+// single GET would silently see a truncated list.
+//
+// The controller may cap pages below the requested limit, so a short page
+// doesn't mean the list is complete: the next page starts after the records
+// received so far, and only an empty page ends the loop.
+//
+// Older controllers ignore the pagination parameters and return everything
+// in one response. We detect that two ways: a page larger than the requested
+// limit, or a page whose first record matches the previous page's first
+// record (i.e. `_start` was ignored). Either case ends the loop.
+func listV1Paged[T any](ctx context.Context, c *Client, baseURL string, idOf func(T) string) ([]T, error) {
+	limit := c.pageSizeOrDefault()
+
+	var all []T
+	var prevFirst string
+	for start := 0; ; start = len(all) {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing list URL: %w", err)
+		}
+		q := u.Query()
+		q.Set("_start", strconv.Itoa(start))
+		q.Set("_limit", strconv.Itoa(limit))
+		u.RawQuery = q.Encode()
+
+		var respBody struct {
+			Meta json.RawMessage `json:"meta"`
+			Data []T             `json:"data"`
+		}
+		if err := c.doV1Request(ctx, http.MethodGet, u.String(), nil, &respBody); err != nil {
+			return nil, err
+		}
+		if err := checkV1Meta(respBody.Meta); err != nil {
+			return nil, err
+		}
+
+		page := respBody.Data
+		if len(page) == 0 {
+			break
+		}
+		first := idOf(page[0])
+		if start > 0 && first == prevFirst {
+			break
+		}
+		prevFirst = first
+
+		all = append(all, page...)
+		if len(page) > limit {
+			break
+		}
+	}
+	return all, nil
+}
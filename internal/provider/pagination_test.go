@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// userStoreServer serves n fake client records from /rest/user. When paginate
+// is false it ignores _start/_limit and returns everything, like older
+// controllers do.
+func userStoreServer(t *testing.T, n int, paginate bool, hits *atomic.Int64) *httptest.Server {
+	t.Helper()
+	return cappedUserStoreServer(t, n, paginate, 0, hits)
+}
+
+// cappedUserStoreServer is userStoreServer for a controller that returns at
+// most maxPage records per page, whatever _limit asks for. A maxPage of 0
+// means no cap.
+func cappedUserStoreServer(t *testing.T, n int, paginate bool, maxPage int, hits *atomic.Int64) *httptest.Server {
+	t.Helper()
+	all := make([]unifi.Client, n)
+	for i := range all {
+		all[i] = unifi.Client{ID: fmt.Sprintf("user-%04d", i), MAC: fmt.Sprintf("00:00:00:00:%02x:%02x", i/256, i%256)}
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		page := all
+		if paginate {
+			start, _ := strconv.Atoi(r.URL.Query().Get("_start"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("_limit"))
+			if maxPage > 0 {
+				limit = min(limit, maxPage)
+			}
+			start = min(start, len(all))
+			page = all[start:min(start+limit, len(all))]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"meta": map[string]string{"rc": "ok"},
+			"data": page,
+		})
+	}))
+}
+
+func TestListClientDevices_FollowsPages(t *testing.T) {
+	var hits atomic.Int64
+	srv := userStoreServer(t, 25, true, &hits)
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+	client.pageSize = 10
+
+	clients, err := client.ListClientDevices(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Len(t, clients, 25)
+	assert.Equal(t, "user-0024", clients[24].ID)
+	// Three pages, then an empty page ends the loop.
+	assert.Equal(t, int64(4), hits.Load())
+}
+
+func TestListClientDevices_ControllerCapsPageSize(t *testing.T) {
+	var hits atomic.Int64
+	srv := cappedUserStoreServer(t, 25, true, 4, &hits)
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+	client.pageSize = 10
+
+	// Every page is shorter than the requested limit; the later pages start
+	// after the records already received.
+	clients, err := client.ListClientDevices(context.Background(), "default")
+	require.NoError(t, err)
+	require.Len(t, clients, 25)
+	for i, c := range clients {
+		assert.Equal(t, fmt.Sprintf("user-%04d", i), c.ID)
+	}
+	assert.Equal(t, int64(8), hits.Load())
+}
+
+func TestListClientDevices_ExactMultipleOfPageSize(t *testing.T) {
+	var hits atomic.Int64
+	srv := userStoreServer(t, 20, true, &hits)
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+	client.pageSize = 10
+
+	clients, err := client.ListClientDevices(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Len(t, clients, 20)
+	// Two full pages, then an empty page ends the loop.
+	assert.Equal(t, int64(3), hits.Load())
+}
+
+func TestListClientDevices_ControllerIgnoresPagination(t *testing.T) {
+	t.Run("response larger than limit", func(t *testing.T) {
+		var hits atomic.Int64
+		srv := userStoreServer(t, 25, false, &hits)
+		defer srv.Close()
+
+		client := newTestClient(t, srv.URL, false)
+		client.pageSize = 10
+
+		clients, err := client.ListClientDevices(context.Background(), "default")
+		require.NoError(t, err)
+		assert.Len(t, clients, 25)
+		assert.Equal(t, int64(1), hits.Load())
+	})
+
+	t.Run("response exactly the limit", func(t *testing.T) {
+		var hits atomic.Int64
+		srv := userStoreServer(t, 10, false, &hits)
+		defer srv.Close()
+
+		client := newTestClient(t, srv.URL, false)
+		client.pageSize = 10
+
+		// The second request returns the same first record, so we stop
+		// instead of looping forever or duplicating records.
+		clients, err := client.ListClientDevices(context.Background(), "default")
+		require.NoError(t, err)
+		assert.Len(t, clients, 10)
+		assert.Equal(t, int64(2), hits.Load())
+	})
+}
+
+func TestPageSizeOrDefault(t *testing.T) {
+	assert.Equal(t, defaultPageSize, (&Client{}).pageSizeOrDefault())
+	assert.Equal(t, 50, (&Client{pageSize: 50}).pageSizeOrDefault())
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                = &portForwardResource{}
+	_ resource.ResourceWithImportState = &portForwardResource{}
+)
+
+// NewPortForwardResource is the factory function registered in provider.Resources().
+func NewPortForwardResource() resource.Resource {
+	return &portForwardResource{}
+}
+
+// portForwardResource holds the API client, injected by Configure().
+type portForwardResource struct {
+	client ClientAPI
+}
+
+// portForwardResourceModel is the Terraform-side representation of a port
+// forwarding rule. The `unifi` tags map fields to the go-unifi PortForward
+// struct (see mapping.go); the controller stores schema defaults as "".
+type portForwardResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Site         types.String `tfsdk:"site"`
+	Name         types.String `tfsdk:"name"          unifi:"Name"`
+	Enabled      types.Bool   `tfsdk:"enabled"       unifi:"Enabled"`
+	Protocol     types.String `tfsdk:"protocol"      unifi:"Proto,default=tcp_udp"`
+	WANInterface types.String `tfsdk:"wan_interface" unifi:"PfwdInterface,default=wan"`
+	Source       types.String `tfsdk:"source"        unifi:"Src,default=any"`
+	DstPort      types.String `tfsdk:"dst_port"      unifi:"DstPort"`
+	FwdIP        types.String `tfsdk:"fwd_ip"        unifi:"Fwd"`
+	FwdPort      types.String `tfsdk:"fwd_port"      unifi:"FwdPort"`
+	Log          types.Bool   `tfsdk:"log"           unifi:"Log"`
+}
+
+// portForwardPortsRegexp matches a comma-separated list of ports and port
+// ranges, e.g. "80", "8080-8090" or "80,443".
+var portForwardPortsRegexp = regexp.MustCompile(`^[0-9]+(-[0-9]+)?(,[0-9]+(-[0-9]+)?)*$`)
+
+// Metadata sets the resource type name.
+func (r *portForwardResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port_forward"
+}
+
+// Schema defines the HCL schema for the terrifi_port_forward resource.
+func (r *portForwardResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a port forwarding rule on the UniFi gateway. Traffic arriving on a WAN " +
+			"interface at `dst_port` is forwarded to `fwd_ip`:`fwd_port` on the LAN.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the port forwarding rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the port forwarding rule with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the port forwarding rule.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the rule is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"protocol": schema.StringAttribute{
+				MarkdownDescription: "The protocol to forward. One of `tcp_udp`, `tcp`, `udp`. Default: `tcp_udp`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("tcp_udp"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("tcp_udp", "tcp", "udp"),
+				},
+			},
+
+			"wan_interface": schema.StringAttribute{
+				MarkdownDescription: "The WAN interface the rule listens on: `wan`, `wan2`, or `both`. Default: `wan`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("wan"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("wan", "wan2", "both"),
+				},
+			},
+
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The source addresses allowed to use the rule: an IPv4 address, range " +
+					"(`a.b.c.d-a.b.c.e`) or CIDR, optionally prefixed with `!` to negate it, or `any`. Default: `any`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("any"),
+			},
+
+			"dst_port": schema.StringAttribute{
+				MarkdownDescription: "The port or ports to listen on: a port, a range (`8080-8090`), or a comma-separated list of both.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(portForwardPortsRegexp, "must be a port, a port range, or a comma-separated list of them"),
+				},
+			},
+
+			"fwd_ip": schema.StringAttribute{
+				MarkdownDescription: "The LAN IPv4 address to forward traffic to.",
+				Required:            true,
+			},
+
+			"fwd_port": schema.StringAttribute{
+				MarkdownDescription: "The port or ports on `fwd_ip` to forward traffic to, in the same format as `dst_port`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(portForwardPortsRegexp, "must be a port, a port range, or a comma-separated list of them"),
+				},
+			},
+
+			"log": schema.BoolAttribute{
+				MarkdownDescription: "Whether to log forwarded traffic. Default: `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *portForwardResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new port forwarding rule.
+func (r *portForwardResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan portForwardResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreatePortForward(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Port Forward", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *portForwardResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state portForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	forward, err := r.client.GetPortForward(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Port Forward",
+			fmt.Sprintf("Could not read port forward %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(forward, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing port forwarding rule.
+func (r *portForwardResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan portForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetPortForward(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Port Forward for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "port forward", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	r.applyPlanToState(&plan, &state)
+
+	forward := r.modelToAPI(&state)
+	forward.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdatePortForward(ctx, site, forward)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Port Forward", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes the port forwarding rule from the UniFi controller.
+func (r *portForwardResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state portForwardResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeletePortForward(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Port Forward", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_port_forward.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *portForwardResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// applyPlanToState merges the user's planned changes into the current state.
+func (r *portForwardResource) applyPlanToState(plan, state *portForwardResourceModel) {
+	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
+		state.Name = plan.Name
+	}
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		state.Enabled = plan.Enabled
+	}
+	if !plan.Protocol.IsNull() && !plan.Protocol.IsUnknown() {
+		state.Protocol = plan.Protocol
+	}
+	if !plan.WANInterface.IsNull() && !plan.WANInterface.IsUnknown() {
+		state.WANInterface = plan.WANInterface
+	}
+	if !plan.Source.IsNull() && !plan.Source.IsUnknown() {
+		state.Source = plan.Source
+	}
+	if !plan.DstPort.IsNull() && !plan.DstPort.IsUnknown() {
+		state.DstPort = plan.DstPort
+	}
+	if !plan.FwdIP.IsNull() && !plan.FwdIP.IsUnknown() {
+		state.FwdIP = plan.FwdIP
+	}
+	if !plan.FwdPort.IsNull() && !plan.FwdPort.IsUnknown() {
+		state.FwdPort = plan.FwdPort
+	}
+	if !plan.Log.IsNull() && !plan.Log.IsUnknown() {
+		state.Log = plan.Log
+	}
+}
+
+// modelToAPI converts our Terraform model to the go-unifi PortForward struct.
+func (r *portForwardResource) modelToAPI(m *portForwardResourceModel) *unifi.PortForward {
+	forward := &unifi.PortForward{}
+	mapModelToAPI(m, forward)
+	return forward
+}
+
+// apiToModel converts the go-unifi PortForward struct back to our Terraform
+// model.
+func (r *portForwardResource) apiToModel(f *unifi.PortForward, m *portForwardResourceModel, site string) {
+	mapAPIToModel(f, m)
+	m.ID = types.StringValue(f.ID)
+	m.Site = types.StringValue(site)
+}
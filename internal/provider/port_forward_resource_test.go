@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestPortForwardModelToAPI(t *testing.T) {
+	r := &portForwardResource{}
+	m := &portForwardResourceModel{
+		Name:         types.StringValue("Web Server"),
+		Enabled:      types.BoolValue(true),
+		Protocol:     types.StringValue("tcp"),
+		WANInterface: types.StringValue("wan2"),
+		Source:       types.StringValue("!203.0.113.7"),
+		DstPort:      types.StringValue("443"),
+		FwdIP:        types.StringValue("192.168.1.10"),
+		FwdPort:      types.StringValue("8443"),
+		Log:          types.BoolValue(true),
+	}
+
+	assert.Equal(t, &unifi.PortForward{
+		Name:          "Web Server",
+		Enabled:       true,
+		Proto:         "tcp",
+		PfwdInterface: "wan2",
+		Src:           "!203.0.113.7",
+		DstPort:       "443",
+		Fwd:           "192.168.1.10",
+		FwdPort:       "8443",
+		Log:           true,
+	}, r.modelToAPI(m))
+}
+
+func TestPortForwardAPIToModel(t *testing.T) {
+	r := &portForwardResource{}
+
+	t.Run("empty fields use controller defaults", func(t *testing.T) {
+		var m portForwardResourceModel
+		r.apiToModel(&unifi.PortForward{
+			ID:      "pf1",
+			Name:    "SSH",
+			DstPort: "2222",
+			Fwd:     "192.168.1.5",
+			FwdPort: "22",
+		}, &m, "default")
+
+		assert.Equal(t, "pf1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.False(t, m.Enabled.ValueBool())
+		assert.Equal(t, "tcp_udp", m.Protocol.ValueString())
+		assert.Equal(t, "wan", m.WANInterface.ValueString())
+		assert.Equal(t, "any", m.Source.ValueString())
+		assert.Equal(t, "22", m.FwdPort.ValueString())
+	})
+}
+
+func TestPortForwardPortsRegexp(t *testing.T) {
+	for _, tc := range []struct {
+		value string
+		ok    bool
+	}{
+		{"80", true},
+		{"8080-8090", true},
+		{"80,443,8000-8100", true},
+		{"", false},
+		{"80,", false},
+		{"http", false},
+		{"80 443", false},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.ok, portForwardPortsRegexp.MatchString(tc.value))
+		})
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccPortForward_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-pf-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_port_forward" "test" {
+  name     = %q
+  dst_port = "18443"
+  fwd_ip   = "192.168.1.210"
+  fwd_port = "443"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "protocol", "tcp_udp"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "wan_interface", "wan"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "source", "any"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "log", "false"),
+					resource.TestCheckResourceAttrSet("terrifi_port_forward.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_port_forward.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_port_forward" "test" {
+  name     = %q
+  enabled  = false
+  protocol = "tcp"
+  source   = "203.0.113.0/24"
+  dst_port = "18443,18444"
+  fwd_ip   = "192.168.1.211"
+  fwd_port = "443,444"
+  log      = true
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "protocol", "tcp"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "source", "203.0.113.0/24"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "fwd_ip", "192.168.1.211"),
+					resource.TestCheckResourceAttr("terrifi_port_forward.test", "log", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPortForward_validationPorts(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_port_forward" "test" {
+  name     = "tfacc-pf-invalid"
+  dst_port = "https"
+  fwd_ip   = "192.168.1.210"
+  fwd_port = "443"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a port, a port range`),
+			},
+		},
+	})
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &portForwardsDataSource{}
+
+func NewPortForwardsDataSource() datasource.DataSource {
+	return &portForwardsDataSource{}
+}
+
+type portForwardsDataSource struct {
+	client ClientAPI
+}
+
+type portForwardsDataSourceModel struct {
+	Site     types.String       `tfsdk:"site"`
+	Forwards []portForwardModel `tfsdk:"forwards"`
+}
+
+// portForwardModel uses the same mapping as portForwardResourceModel.
+type portForwardModel struct {
+	ID           types.String `tfsdk:"id"            unifi:"ID"`
+	Name         types.String `tfsdk:"name"          unifi:"Name"`
+	Enabled      types.Bool   `tfsdk:"enabled"       unifi:"Enabled"`
+	Protocol     types.String `tfsdk:"protocol"      unifi:"Proto,default=tcp_udp"`
+	WANInterface types.String `tfsdk:"wan_interface" unifi:"PfwdInterface,default=wan"`
+	Source       types.String `tfsdk:"source"        unifi:"Src,default=any"`
+	DstPort      types.String `tfsdk:"dst_port"      unifi:"DstPort"`
+	FwdIP        types.String `tfsdk:"fwd_ip"        unifi:"Fwd"`
+	FwdPort      types.String `tfsdk:"fwd_port"      unifi:"FwdPort"`
+	Log          types.Bool   `tfsdk:"log"           unifi:"Log"`
+}
+
+func (d *portForwardsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port_forwards"
+}
+
+func (d *portForwardsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the port forwarding rules on a site, including ones not managed by Terraform. " +
+			"Useful for auditing what is exposed to the internet or finding rules to adopt into `terrifi_port_forward`.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to list. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"forwards": schema.ListNestedAttribute{
+				MarkdownDescription: "The port forwarding rules, ordered by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the rule.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the rule.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the rule is enabled.",
+							Computed:            true,
+						},
+						"protocol": schema.StringAttribute{
+							MarkdownDescription: "The forwarded protocol: `tcp_udp`, `tcp` or `udp`.",
+							Computed:            true,
+						},
+						"wan_interface": schema.StringAttribute{
+							MarkdownDescription: "The WAN interface the rule listens on.",
+							Computed:            true,
+						},
+						"source": schema.StringAttribute{
+							MarkdownDescription: "The source addresses allowed to use the rule, or `any`.",
+							Computed:            true,
+						},
+						"dst_port": schema.StringAttribute{
+							MarkdownDescription: "The port or ports the rule listens on.",
+							Computed:            true,
+						},
+						"fwd_ip": schema.StringAttribute{
+							MarkdownDescription: "The LAN address traffic is forwarded to.",
+							Computed:            true,
+						},
+						"fwd_port": schema.StringAttribute{
+							MarkdownDescription: "The port or ports traffic is forwarded to.",
+							Computed:            true,
+						},
+						"log": schema.BoolAttribute{
+							MarkdownDescription: "Whether forwarded traffic is logged.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *portForwardsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *portForwardsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config portForwardsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	forwards, err := d.client.ListPortForward(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Port Forwards",
+			fmt.Sprintf("Could not list port forwards in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	config.Site = types.StringValue(site)
+	config.Forwards = portForwardsToModels(forwards)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// portForwardsToModels converts the controller's rules to the data source
+// model, ordered by name and then ID so the list is stable across reads.
+func portForwardsToModels(forwards []unifi.PortForward) []portForwardModel {
+	sorted := make([]unifi.PortForward, len(forwards))
+	copy(sorted, forwards)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	models := make([]portForwardModel, len(sorted))
+	for i := range sorted {
+		mapAPIToModel(&sorted[i], &models[i])
+	}
+	return models
+}
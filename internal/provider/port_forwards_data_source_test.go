@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestPortForwardsToModels(t *testing.T) {
+	models := portForwardsToModels([]unifi.PortForward{
+		{ID: "b", Name: "Web"},
+		{ID: "c", Name: "Game", Proto: "udp"},
+		{ID: "a", Name: "Web"},
+	})
+
+	ids := make([]string, len(models))
+	for i, m := range models {
+		ids[i] = m.ID.ValueString()
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+	assert.Equal(t, "udp", models[0].Protocol.ValueString())
+	assert.Equal(t, "tcp_udp", models[1].Protocol.ValueString())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccPortForwardsDataSource_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-pf-ds-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_port_forward" "test" {
+  name     = %q
+  dst_port = "18445"
+  fwd_ip   = "192.168.1.212"
+  fwd_port = "445"
+}
+
+data "terrifi_port_forwards" "all" {
+  depends_on = [terrifi_port_forward.test]
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.terrifi_port_forwards.all", "forwards.*", map[string]string{
+						"name":     name,
+						"dst_port": "18445",
+						"fwd_ip":   "192.168.1.212",
+						"fwd_port": "445",
+						"enabled":  "true",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package provider
+
+// TODO(go-unifi): The SDK's PortProfile omits empty native_networkconf_id,
+// voice_networkconf_id, excluded_networkconf_ids and
+// port_security_mac_address from request bodies, and the controller merges
+// PUT bodies into the stored profile, so UpdatePortProfile can't clear a
+// profile's native or voice network, its excluded networks or its allowed
+// MACs. Fix needed in SDK: drop omitempty from those fields. When it is,
+// this file can be deleted.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// portProfilePayload is a port profile with the fields the SDK leaves out
+// when empty always sent. The outer fields take precedence over the
+// embedded profile's fields with the same JSON name.
+type portProfilePayload struct {
+	*unifi.PortProfile
+	NativeNetworkID        string   `json:"native_networkconf_id"`
+	VoiceNetworkID         string   `json:"voice_networkconf_id"`
+	ExcludedNetworkIDs     []string `json:"excluded_networkconf_ids"`
+	PortSecurityMACAddress []string `json:"port_security_mac_address"`
+}
+
+// UpdatePortProfile updates a port profile. It shadows the SDK method so
+// that cleared fields are sent as empty values instead of being left out.
+func (c *Client) UpdatePortProfile(ctx context.Context, site string, d *unifi.PortProfile) (*unifi.PortProfile, error) {
+	payload := portProfilePayload{
+		PortProfile:            d,
+		NativeNetworkID:        d.NATiveNetworkID,
+		VoiceNetworkID:         d.VoiceNetworkID,
+		ExcludedNetworkIDs:     d.ExcludedNetworkIDs,
+		PortSecurityMACAddress: d.PortSecurityMACAddress,
+	}
+	if payload.ExcludedNetworkIDs == nil {
+		payload.ExcludedNetworkIDs = []string{}
+	}
+	if payload.PortSecurityMACAddress == nil {
+		payload.PortSecurityMACAddress = []string{}
+	}
+
+	var respBody struct {
+		Meta json.RawMessage     `json:"meta"`
+		Data []unifi.PortProfile `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/api/s/%s/rest/portconf/%s", c.BaseURL, c.APIPath, site, d.ID),
+		payload, &respBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+	if len(respBody.Data) != 1 {
+		return nil, &unifi.NotFoundError{}
+	}
+	return &respBody.Data[0], nil
+}
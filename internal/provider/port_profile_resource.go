@@ -0,0 +1,492 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                     = &portProfileResource{}
+	_ resource.ResourceWithImportState      = &portProfileResource{}
+	_ resource.ResourceWithConfigValidators = &portProfileResource{}
+)
+
+// NewPortProfileResource is the factory function registered in provider.Resources().
+func NewPortProfileResource() resource.Resource {
+	return &portProfileResource{}
+}
+
+// portProfileResource holds the API client, injected by Configure().
+type portProfileResource struct {
+	client ClientAPI
+}
+
+// portProfileResourceModel is the Terraform-side representation of a switch
+// port profile. The `unifi` tags map fields to the go-unifi PortProfile
+// struct (see mapping.go); the controller stores schema defaults as "".
+type portProfileResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	Site                     types.String `tfsdk:"site"`
+	Name                     types.String `tfsdk:"name"                 unifi:"Name"`
+	NativeNetworkID          types.String `tfsdk:"native_network_id"    unifi:"NATiveNetworkID,zeronull"`
+	TaggedVLANMgmt           types.String `tfsdk:"tagged_vlan_mgmt"     unifi:"TaggedVLANMgmt,default=auto"`
+	ExcludedNetworkIDs       types.Set    `tfsdk:"excluded_network_ids"`
+	VoiceNetworkID           types.String `tfsdk:"voice_network_id"     unifi:"VoiceNetworkID,zeronull"`
+	PoEMode                  types.String `tfsdk:"poe_mode"             unifi:"PoeMode,default=auto"`
+	Isolation                types.Bool   `tfsdk:"isolation"            unifi:"Isolation"`
+	STPEnabled               types.Bool   `tfsdk:"stp_enabled"          unifi:"StpPortMode"`
+	LLDPMEDEnabled           types.Bool   `tfsdk:"lldpmed_enabled"      unifi:"LldpmedEnabled"`
+	Dot1XCtrl                types.String `tfsdk:"dot1x_ctrl"           unifi:"Dot1XCtrl,default=force_authorized"`
+	Dot1XIdleTimeout         types.Int64  `tfsdk:"dot1x_idle_timeout"   unifi:"Dot1XIDleTimeout"`
+	PortSecurityMACAddresses types.Set    `tfsdk:"port_security_mac_addresses"`
+}
+
+// Metadata sets the resource type name.
+func (r *portProfileResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_port_profile"
+}
+
+// Schema defines the HCL schema for the terrifi_port_profile resource.
+func (r *portProfileResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a switch port profile: the VLANs, PoE, 802.1X and port security " +
+			"settings that switch ports assigned the profile use.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the port profile.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the port profile with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the port profile.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"native_network_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network for untagged traffic. If not set, untagged traffic " +
+					"is dropped.",
+				Optional: true,
+			},
+
+			"tagged_vlan_mgmt": schema.StringAttribute{
+				MarkdownDescription: "Which networks are carried tagged: `auto` for all of them, `block_all` for " +
+					"none, or `custom` for all but `excluded_network_ids`. Default: `auto`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("auto"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "block_all", "custom"),
+				},
+			},
+
+			"excluded_network_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the networks not carried tagged. Only valid when `tagged_vlan_mgmt` " +
+					"is `custom`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"voice_network_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network advertised to VoIP phones over LLDP-MED.",
+				Optional:            true,
+			},
+
+			"poe_mode": schema.StringAttribute{
+				MarkdownDescription: "Whether the ports supply PoE: `auto` or `off`. Default: `auto`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("auto"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "off"),
+				},
+			},
+
+			"isolation": schema.BoolAttribute{
+				MarkdownDescription: "Whether devices on the ports are isolated from other isolated ports of " +
+					"the same switch. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"stp_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the ports take part in spanning tree. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"lldpmed_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the ports send LLDP-MED, which VoIP phones use to find " +
+					"`voice_network_id`. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+
+			"dot1x_ctrl": schema.StringAttribute{
+				MarkdownDescription: "The 802.1X port control mode: `force_authorized` to let every device " +
+					"through, `force_unauthorized` to block every device, `auto` to require each port's " +
+					"device to authenticate, `multi_host` to open the port to every device once one has " +
+					"authenticated, or `mac_based` to authenticate every device by its MAC address. " +
+					"Modes other than the forced ones need 802.1X control and a RADIUS profile enabled " +
+					"in the site's switch settings. Default: `force_authorized`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("force_authorized"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "force_authorized", "force_unauthorized", "mac_based", "multi_host"),
+				},
+			},
+
+			"dot1x_idle_timeout": schema.Int64Attribute{
+				MarkdownDescription: "How long, in seconds, a device authenticated by `mac_based` 802.1X control " +
+					"stays authorized without sending traffic (0-65535). Can only be set when `dot1x_ctrl` " +
+					"is `mac_based`. Default: `300`.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(300),
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+			},
+
+			"port_security_mac_addresses": schema.SetAttribute{
+				MarkdownDescription: "The MAC addresses of the only devices allowed on the ports. If not set, " +
+					"port security is off and any device is allowed.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(macRegexp, "must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)"),
+					),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *portProfileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		portProfileFieldsValidator{},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *portProfileResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new port profile.
+func (r *portProfileResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan portProfileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The attributes the resource doesn't manage get the UI's defaults.
+	profile := &unifi.PortProfile{
+		Autoneg: true,
+		Forward: "customize",
+		OpMode:  "switch",
+	}
+	r.modelToAPI(&plan, profile)
+
+	created, err := r.client.CreatePortProfile(ctx, site, profile)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Port Profile", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *portProfileResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state portProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	profile, err := r.client.GetPortProfile(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Port Profile",
+			fmt.Sprintf("Could not read port profile %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(profile, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing port profile. The plan is applied on top of the
+// profile read from the controller, so the settings the resource doesn't
+// manage (link speed, storm control, QoS) are kept.
+func (r *portProfileResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan portProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetPortProfile(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Port Profile for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "port profile", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	r.modelToAPI(&plan, current)
+
+	updated, err := r.client.UpdatePortProfile(ctx, site, current)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Port Profile", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the port profile from the UniFi controller.
+func (r *portProfileResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state portProfileResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeletePortProfile(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting Port Profile", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_port_profile.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *portProfileResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI writes the attributes the resource manages to p. Optional
+// attributes that are null clear the corresponding fields.
+func (r *portProfileResource) modelToAPI(m *portProfileResourceModel, p *unifi.PortProfile) {
+	p.NATiveNetworkID = ""
+	p.VoiceNetworkID = ""
+	mapModelToAPI(m, p)
+
+	p.ExcludedNetworkIDs = nil
+	if m.TaggedVLANMgmt.ValueString() == "custom" {
+		p.ExcludedNetworkIDs = sortedSetStrings(m.ExcludedNetworkIDs)
+	}
+
+	macs := sortedSetStrings(m.PortSecurityMACAddresses)
+	for i, mac := range macs {
+		macs[i] = strings.ToLower(mac)
+	}
+	p.PortSecurityEnabled = len(macs) > 0
+	p.PortSecurityMACAddress = macs
+	if len(macs) == 0 {
+		p.PortSecurityMACAddress = nil
+	}
+}
+
+// apiToModel converts the port profile back to our Terraform model. The
+// excluded networks and allowed MACs are only reported while they are in
+// effect, and allowed MACs that only differ in case from the configured ones
+// keep the configured spelling.
+func (r *portProfileResource) apiToModel(p *unifi.PortProfile, m *portProfileResourceModel, site string) {
+	mapAPIToModel(p, m)
+	m.ID = types.StringValue(p.ID)
+	m.Site = types.StringValue(site)
+
+	m.ExcludedNetworkIDs = types.SetNull(types.StringType)
+	if p.TaggedVLANMgmt == "custom" && len(p.ExcludedNetworkIDs) > 0 {
+		m.ExcludedNetworkIDs = stringSetValue(p.ExcludedNetworkIDs, nil)
+	}
+
+	configured := map[string]string{}
+	for _, mac := range sortedSetStrings(m.PortSecurityMACAddresses) {
+		configured[strings.ToLower(mac)] = mac
+	}
+	m.PortSecurityMACAddresses = types.SetNull(types.StringType)
+	if p.PortSecurityEnabled && len(p.PortSecurityMACAddress) > 0 {
+		macs := stringSetValue(p.PortSecurityMACAddress, strings.ToLower)
+		vals := make([]attr.Value, 0, len(macs.Elements()))
+		for _, mac := range sortedSetStrings(macs) {
+			if orig, ok := configured[mac]; ok {
+				mac = orig
+			}
+			vals = append(vals, types.StringValue(mac))
+		}
+		m.PortSecurityMACAddresses = types.SetValueMust(types.StringType, vals)
+	}
+}
+
+// portProfileFieldsValidator ensures the attributes that only apply to one
+// mode of another attribute are only set for that mode.
+type portProfileFieldsValidator struct{}
+
+func (v portProfileFieldsValidator) Description(_ context.Context) string {
+	return "excluded_network_ids can only be set when tagged_vlan_mgmt is custom, and dot1x_idle_timeout " +
+		"only when dot1x_ctrl is mac_based."
+}
+
+func (v portProfileFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portProfileFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config portProfileResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Null modes are the schema defaults.
+	fields := []struct {
+		name  string
+		set   bool
+		mode  types.String
+		attr  string
+		value string
+	}{
+		{"excluded_network_ids", !config.ExcludedNetworkIDs.IsNull(), config.TaggedVLANMgmt, "tagged_vlan_mgmt", "custom"},
+		{"dot1x_idle_timeout", !config.Dot1XIdleTimeout.IsNull(), config.Dot1XCtrl, "dot1x_ctrl", "mac_based"},
+	}
+	for _, f := range fields {
+		if !f.set || f.mode.IsUnknown() || f.mode.ValueString() == f.value {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(f.name),
+			"Invalid Attribute Combination",
+			fmt.Sprintf("%s can only be set when %s is %q.", f.name, f.attr, f.value),
+		)
+	}
+}
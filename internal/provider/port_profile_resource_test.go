@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestPortProfileCRUD(t *testing.T) {
+	set := func(values ...string) types.Set {
+		elems := make([]attr.Value, len(values))
+		for i, v := range values {
+			elems[i] = types.StringValue(v)
+		}
+		return types.SetValueMust(types.StringType, elems)
+	}
+	plan := portProfileResourceModel{
+		ID:                       types.StringUnknown(),
+		Site:                     types.StringNull(),
+		Name:                     types.StringValue("Desk"),
+		NativeNetworkID:          types.StringValue("net-1"),
+		TaggedVLANMgmt:           types.StringValue("custom"),
+		ExcludedNetworkIDs:       set("net-3", "net-2"),
+		VoiceNetworkID:           types.StringValue("net-4"),
+		PoEMode:                  types.StringValue("off"),
+		Isolation:                types.BoolValue(false),
+		STPEnabled:               types.BoolValue(true),
+		LLDPMEDEnabled:           types.BoolValue(true),
+		Dot1XCtrl:                types.StringValue("mac_based"),
+		Dot1XIdleTimeout:         types.Int64Value(600),
+		PortSecurityMACAddresses: set("AA:BB:CC:00:00:01"),
+	}
+
+	fake := newFakeClient()
+	r := &portProfileResource{client: fake}
+
+	created, diags := testCreate(t, r, plan)
+	require.False(t, diags.HasError(), "create: %v", diags)
+	assert.Equal(t, "default", created.Site.ValueString())
+	assert.Equal(t, plan.PortSecurityMACAddresses, created.PortSecurityMACAddresses, "configured spelling is kept")
+	profile := fake.portProfiles[created.ID.ValueString()]
+	assert.Equal(t, "net-1", profile.NATiveNetworkID)
+	assert.Equal(t, []string{"net-2", "net-3"}, profile.ExcludedNetworkIDs)
+	assert.Equal(t, "mac_based", profile.Dot1XCtrl)
+	require.NotNil(t, profile.Dot1XIDleTimeout)
+	assert.Equal(t, int64(600), *profile.Dot1XIDleTimeout)
+	assert.True(t, profile.PortSecurityEnabled)
+	assert.Equal(t, []string{"aa:bb:cc:00:00:01"}, profile.PortSecurityMACAddress)
+	assert.True(t, profile.Autoneg, "unmanaged attributes get the UI's defaults")
+	assert.Equal(t, "switch", profile.OpMode)
+
+	read, diags := testRead(t, r, *created)
+	require.False(t, diags.HasError(), "read: %v", diags)
+	assert.Equal(t, created, read)
+
+	// Settings made in the UniFi UI that the resource doesn't manage survive
+	// updates.
+	speed := int64(1000)
+	profile.Speed = &speed
+	fake.portProfiles[profile.ID] = profile
+
+	update := *created
+	update.NativeNetworkID = types.StringNull()
+	update.TaggedVLANMgmt = types.StringValue("block_all")
+	update.ExcludedNetworkIDs = types.SetNull(types.StringType)
+	update.VoiceNetworkID = types.StringNull()
+	update.Dot1XCtrl = types.StringValue("force_authorized")
+	update.PortSecurityMACAddresses = types.SetNull(types.StringType)
+	updated, diags := testUpdate(t, r, *created, update)
+	require.False(t, diags.HasError(), "update: %v", diags)
+	assert.True(t, updated.NativeNetworkID.IsNull())
+	assert.True(t, updated.ExcludedNetworkIDs.IsNull())
+	assert.True(t, updated.VoiceNetworkID.IsNull())
+	assert.True(t, updated.PortSecurityMACAddresses.IsNull())
+	profile = fake.portProfiles[created.ID.ValueString()]
+	assert.Equal(t, "", profile.NATiveNetworkID)
+	assert.Nil(t, profile.ExcludedNetworkIDs)
+	assert.False(t, profile.PortSecurityEnabled)
+	assert.Equal(t, &speed, profile.Speed)
+
+	diags = testDelete(t, r, *updated)
+	require.False(t, diags.HasError(), "delete: %v", diags)
+	assert.Empty(t, fake.portProfiles)
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccPortProfile_basic(t *testing.T) {
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	name := fmt.Sprintf("tfacc-pp-%s", suffix)
+
+	config := func(profile string) string {
+		return wlanTestNetwork(fmt.Sprintf("tfacc-pp-net-%s", suffix), vlan) + fmt.Sprintf(`
+resource "terrifi_port_profile" "test" {
+  name = %q
+%s}
+`, name, profile)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+  native_network_id = terrifi_network.wlan_test.id
+  tagged_vlan_mgmt  = "block_all"
+  poe_mode          = "off"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_port_profile.test", "id"),
+					resource.TestCheckResourceAttrPair("terrifi_port_profile.test", "native_network_id", "terrifi_network.wlan_test", "id"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "tagged_vlan_mgmt", "block_all"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "poe_mode", "off"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "dot1x_ctrl", "force_authorized"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_port_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Removing the native network and switching to MAC-based 802.1X
+			// control with port security.
+			{
+				Config: config(`
+  tagged_vlan_mgmt     = "custom"
+  excluded_network_ids = [terrifi_network.wlan_test.id]
+  dot1x_ctrl           = "mac_based"
+  dot1x_idle_timeout   = 600
+
+  port_security_mac_addresses = ["aa:bb:cc:dd:ee:ff"]
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_port_profile.test", "native_network_id"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "excluded_network_ids.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "dot1x_ctrl", "mac_based"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "dot1x_idle_timeout", "600"),
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "port_security_mac_addresses.#", "1"),
+				),
+			},
+			// Back to the defaults clears the excluded networks and allowed MACs.
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_port_profile.test", "tagged_vlan_mgmt", "auto"),
+					resource.TestCheckNoResourceAttr("terrifi_port_profile.test", "excluded_network_ids.#"),
+					resource.TestCheckNoResourceAttr("terrifi_port_profile.test", "port_security_mac_addresses.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccPortProfile_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_port_profile" "test" {
+  name                 = "tfacc-invalid"
+  excluded_network_ids = ["000000000000000000000000"]
+}
+`,
+				ExpectError: regexp.MustCompile(`excluded_network_ids can only be set when tagged_vlan_mgmt is "custom"`),
+			},
+			{
+				Config: `
+resource "terrifi_port_profile" "test" {
+  name               = "tfacc-invalid"
+  dot1x_ctrl         = "auto"
+  dot1x_idle_timeout = 600
+}
+`,
+				ExpectError: regexp.MustCompile(`dot1x_idle_timeout can only be set when dot1x_ctrl is "mac_based"`),
+			},
+			{
+				Config: `
+resource "terrifi_port_profile" "test" {
+  name       = "tfacc-invalid"
+  dot1x_ctrl = "mac"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
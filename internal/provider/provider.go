@@ -18,12 +18,15 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -49,13 +52,16 @@ type terrifiProvider struct{}
 // The framework automatically deserializes this HCL into a terrifiProviderModel struct.
 // types.String/types.Bool are Terraform's wrapper types that track null vs empty vs set.
 type terrifiProviderModel struct {
-	ApiKey          types.String `tfsdk:"api_key"`
-	Username        types.String `tfsdk:"username"`
-	Password        types.String `tfsdk:"password"`
-	ApiUrl          types.String `tfsdk:"api_url"`
-	Site            types.String `tfsdk:"site"`
-	AllowInsecure   types.Bool   `tfsdk:"allow_insecure"`
-	ResponseCaching types.Bool   `tfsdk:"response_caching"`
+	ApiKey               types.String `tfsdk:"api_key"`
+	Username             types.String `tfsdk:"username"`
+	Password             types.String `tfsdk:"password"`
+	ApiUrl               types.String `tfsdk:"api_url"`
+	Site                 types.String `tfsdk:"site"`
+	AllowInsecure        types.Bool   `tfsdk:"allow_insecure"`
+	ResponseCaching      types.Bool   `tfsdk:"response_caching"`
+	PageSize             types.Int64  `tfsdk:"page_size"`
+	MaxRequestsPerSecond types.Int64  `tfsdk:"max_requests_per_second"`
+	WarnUnzonedNetworks  types.Bool   `tfsdk:"warn_unzoned_networks"`
 }
 
 // New creates a new provider instance. The framework calls this factory function
@@ -126,6 +132,40 @@ func (p *terrifiProvider) Schema(
 					"cache. Can be specified with the `UNIFI_RESPONSE_CACHING` environment variable.",
 				Optional: true,
 			},
+			"page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of records requested per page from paginated list endpoints such as " +
+					"the client device (user) store. The provider follows pages until the list is complete; lower " +
+					"this if large responses time out on slow controllers. Can be specified with the " +
+					"`UNIFI_PAGE_SIZE` environment variable. Default: `1000`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_requests_per_second": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of requests per second the provider sends to the controller. " +
+					"Requests beyond the cap wait for their turn instead of being rejected by consoles that " +
+					"throttle aggressively (e.g., the UDM Pro). Calls made through the go-unifi SDK, which most " +
+					"resources use, are paced per call; the SDK's own retries and logins are not. Rate-limited " +
+					"(HTTP 429) responses are retried with backoff either way. Can be specified with the " +
+					"`UNIFI_MAX_REQUESTS_PER_SECOND` environment variable. Default: unlimited.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"warn_unzoned_networks": schema.BoolAttribute{
+				MarkdownDescription: "Emit a plan warning for each `terrifi_network` that is not a member of any " +
+					"custom firewall zone, i.e. still sits in a built-in zone such as Internal. A network left out " +
+					"of its intended zone is the most common reason a zone-based firewall policy matches no " +
+					"traffic. A network with `zone_id` set is in that zone, and a new network without `zone_id` " +
+					"is reported as unzoned. For existing networks membership is read from the controller, so a " +
+					"network being added to a zone's `network_ids` in the same apply is still reported until that " +
+					"apply completes. When `zone_id` or a zone's `network_ids` is not known until apply, a " +
+					"warning says membership could not be checked. Can be specified with the " +
+					"`UNIFI_WARN_UNZONED_NETWORKS` environment variable. Default: `false`.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -153,13 +193,16 @@ func (p *terrifiProvider) Configure(
 	// Resolve each setting: prefer the HCL attribute, fall back to the env var.
 	// This lets users configure the provider either way (or mix both).
 	cfg := ClientConfig{
-		APIURL:          stringValueOrEnv(config.ApiUrl, "UNIFI_API"),
-		Username:        stringValueOrEnv(config.Username, "UNIFI_USERNAME"),
-		Password:        stringValueOrEnv(config.Password, "UNIFI_PASSWORD"),
-		APIKey:          stringValueOrEnv(config.ApiKey, "UNIFI_API_KEY"),
-		Site:            stringValueOrEnv(config.Site, "UNIFI_SITE"),
-		AllowInsecure:   config.AllowInsecure.ValueBool(),
-		ResponseCaching: config.ResponseCaching.ValueBool(),
+		APIURL:               stringValueOrEnv(config.ApiUrl, "UNIFI_API"),
+		Username:             stringValueOrEnv(config.Username, "UNIFI_USERNAME"),
+		Password:             stringValueOrEnv(config.Password, "UNIFI_PASSWORD"),
+		APIKey:               stringValueOrEnv(config.ApiKey, "UNIFI_API_KEY"),
+		Site:                 stringValueOrEnv(config.Site, "UNIFI_SITE"),
+		AllowInsecure:        config.AllowInsecure.ValueBool(),
+		ResponseCaching:      config.ResponseCaching.ValueBool(),
+		PageSize:             int(config.PageSize.ValueInt64()),
+		MaxRequestsPerSecond: int(config.MaxRequestsPerSecond.ValueInt64()),
+		WarnUnzonedNetworks:  config.WarnUnzonedNetworks.ValueBool(),
 	}
 
 	if !cfg.AllowInsecure {
@@ -174,6 +217,24 @@ func (p *terrifiProvider) Configure(
 		}
 	}
 
+	if cfg.PageSize == 0 {
+		if v, err := strconv.Atoi(os.Getenv("UNIFI_PAGE_SIZE")); err == nil && v > 0 {
+			cfg.PageSize = v
+		}
+	}
+
+	if cfg.MaxRequestsPerSecond == 0 {
+		if v, err := strconv.Atoi(os.Getenv("UNIFI_MAX_REQUESTS_PER_SECOND")); err == nil && v > 0 {
+			cfg.MaxRequestsPerSecond = v
+		}
+	}
+
+	if !cfg.WarnUnzonedNetworks {
+		if v := os.Getenv("UNIFI_WARN_UNZONED_NETWORKS"); v == "true" {
+			cfg.WarnUnzonedNetworks = true
+		}
+	}
+
 	if cfg.Site == "" {
 		cfg.Site = "default"
 	}
@@ -231,24 +292,67 @@ func (p *terrifiProvider) Configure(
 // Each entry is a factory function that creates a new resource instance.
 func (p *terrifiProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewAdminResource,
+		NewAPGroupResource,
 		NewClientDeviceResource,
 		NewClientGroupResource,
+		NewClientGroupMembershipResource,
+		NewClientIdentityResource,
 		NewDeviceResource,
+		NewDeviceLocateResource,
+		NewDHCPOptionResource,
+		NewDNSForwardingResource,
 		NewDNSRecordResource,
+		NewDPIRestrictionResource,
+		NewDPIRestrictionGroupResource,
 		NewFirewallGroupResource,
 		NewFirewallPolicyResource,
 		NewFirewallPolicyOrderResource,
 		NewFirewallZoneResource,
+		NewFirewallZoneNetworkResource,
+		NewGuestAuthorizationResource,
+		NewHotspotVoucherResource,
+		NewNATRuleResource,
 		NewNetworkResource,
+		NewPortForwardResource,
+		NewPortProfileResource,
+		NewRADIUSUserResource,
+		NewSiteVPNResource,
+		NewSettingConnectivityResource,
+		NewSettingCountryResource,
+		NewSettingGlobalSwitchResource,
+		NewSettingGuestAccessResource,
+		NewSettingIPSResource,
+		NewSettingLocaleResource,
+		NewSettingRadiusResource,
+		NewSettingRsyslogResource,
+		NewSettingTeleportResource,
+		NewSettingUSGResource,
+		NewTrafficRouteResource,
+		NewTrafficRuleResource,
+		NewUserGroupResource,
+		NewVPNServerResource,
+		NewWANResource,
 		NewWLANResource,
 	}
 }
 
-// DataSources returns the list of data source types. Empty for now — we'll add
-// data sources (read-only lookups) as needed.
+// DataSources returns the list of data source types (read-only lookups).
 func (p *terrifiProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		NewControllerInfoDataSource,
+		NewClientGroupMembersDataSource,
 		NewDeviceDataSource,
+		NewOfflineClientsDataSource,
+		NewFirewallPoliciesDataSource,
+		NewFirewallZoneDataSource,
+		NewNetworkDataSource,
+		NewNetworkGroupsDataSource,
+		NewPortForwardsDataSource,
+		NewRADIUSProfileDataSource,
+		NewTrafficRulesDataSource,
+		NewZoneForNetworkDataSource,
+		NewWANNetworksDataSource,
 	}
 }
 
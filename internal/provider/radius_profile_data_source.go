@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &radiusProfileDataSource{}
+
+func NewRADIUSProfileDataSource() datasource.DataSource {
+	return &radiusProfileDataSource{}
+}
+
+type radiusProfileDataSource struct {
+	client ClientAPI
+}
+
+type radiusProfileDataSourceModel struct {
+	ID                types.String               `tfsdk:"id"`
+	Site              types.String               `tfsdk:"site"`
+	Name              types.String               `tfsdk:"name"`
+	AccountingEnabled types.Bool                 `tfsdk:"accounting_enabled"`
+	VLANEnabled       types.Bool                 `tfsdk:"vlan_enabled"`
+	VLANWLANMode      types.String               `tfsdk:"vlan_wlan_mode"`
+	AuthServers       []radiusProfileServerModel `tfsdk:"auth_servers"`
+	AcctServers       []radiusProfileServerModel `tfsdk:"acct_servers"`
+}
+
+// radiusProfileServerModel is one RADIUS server of a profile. Shared secrets
+// are deliberately left out so they don't end up in state.
+type radiusProfileServerModel struct {
+	IP   types.String `tfsdk:"ip"`
+	Port types.Int64  `tfsdk:"port"`
+}
+
+func (d *radiusProfileDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_radius_profile"
+}
+
+func (d *radiusProfileDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	serverAttributes := map[string]schema.Attribute{
+		"ip": schema.StringAttribute{
+			MarkdownDescription: "The IP address of the server.",
+			Computed:            true,
+		},
+		"port": schema.Int64Attribute{
+			MarkdownDescription: "The port of the server.",
+			Computed:            true,
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a RADIUS profile by name. Useful for referencing profiles that are " +
+			"provisioned outside Terraform, such as the built-in Default profile.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the profile to look up.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to look up the profile in. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the profile.",
+				Computed:            true,
+			},
+
+			"accounting_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether RADIUS accounting is enabled.",
+				Computed:            true,
+			},
+
+			"vlan_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the RADIUS server can assign clients to a VLAN.",
+				Computed:            true,
+			},
+
+			"vlan_wlan_mode": schema.StringAttribute{
+				MarkdownDescription: "How WLANs using the profile apply RADIUS-assigned VLANs " +
+					"(`disabled`, `optional` or `required`).",
+				Computed: true,
+			},
+
+			"auth_servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The authentication servers.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: serverAttributes},
+			},
+
+			"acct_servers": schema.ListNestedAttribute{
+				MarkdownDescription: "The accounting servers.",
+				Computed:            true,
+				NestedObject:        schema.NestedAttributeObject{Attributes: serverAttributes},
+			},
+		},
+	}
+}
+
+func (d *radiusProfileDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *radiusProfileDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config radiusProfileDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+	name := config.Name.ValueString()
+
+	profiles, err := d.client.ListRADIUSProfile(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing RADIUS Profiles",
+			fmt.Sprintf("Could not list RADIUS profiles in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	profile := findRADIUSProfile(profiles, name)
+	if profile == nil {
+		resp.Diagnostics.AddError(
+			"RADIUS Profile Not Found",
+			fmt.Sprintf("No RADIUS profile named %q in site %q.", name, site),
+		)
+		return
+	}
+
+	state := radiusProfileToModel(profile)
+	state.Site = types.StringValue(site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// findRADIUSProfile returns the profile with the given name, or nil.
+func findRADIUSProfile(profiles []unifi.RADIUSProfile, name string) *unifi.RADIUSProfile {
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+func radiusProfileToModel(p *unifi.RADIUSProfile) radiusProfileDataSourceModel {
+	m := radiusProfileDataSourceModel{
+		ID:                types.StringValue(p.ID),
+		Name:              types.StringValue(p.Name),
+		AccountingEnabled: types.BoolValue(p.AccountingEnabled),
+		VLANEnabled:       types.BoolValue(p.VLANEnabled),
+		VLANWLANMode:      stringValueOrNull(p.VLANWLANMode),
+		AuthServers:       []radiusProfileServerModel{},
+		AcctServers:       []radiusProfileServerModel{},
+	}
+	for _, s := range p.AuthServers {
+		m.AuthServers = append(m.AuthServers, radiusProfileServerModel{
+			IP:   types.StringValue(s.IP),
+			Port: types.Int64PointerValue(s.Port),
+		})
+	}
+	for _, s := range p.AcctServers {
+		m.AcctServers = append(m.AcctServers, radiusProfileServerModel{
+			IP:   types.StringValue(s.IP),
+			Port: types.Int64PointerValue(s.Port),
+		})
+	}
+	return m
+}
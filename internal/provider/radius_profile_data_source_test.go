@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests — no TF_ACC, no network, no env vars needed
+// ---------------------------------------------------------------------------
+
+func TestFindRADIUSProfile(t *testing.T) {
+	profiles := []unifi.RADIUSProfile{
+		{ID: "rp-default", Name: "Default"},
+		{ID: "rp-corp", Name: "Corp"},
+	}
+
+	t.Run("found", func(t *testing.T) {
+		p := findRADIUSProfile(profiles, "Corp")
+		require.NotNil(t, p)
+		assert.Equal(t, "rp-corp", p.ID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, findRADIUSProfile(profiles, "corp"))
+	})
+}
+
+func TestRADIUSProfileToModel(t *testing.T) {
+	port := int64(1812)
+	m := radiusProfileToModel(&unifi.RADIUSProfile{
+		ID:           "rp-corp",
+		Name:         "Corp",
+		VLANEnabled:  true,
+		VLANWLANMode: "required",
+		AuthServers: []unifi.RADIUSProfileAuthServers{
+			{IP: "10.0.0.5", Port: &port, XSecret: "s3cret"},
+		},
+	})
+
+	assert.Equal(t, "rp-corp", m.ID.ValueString())
+	assert.False(t, m.AccountingEnabled.ValueBool())
+	assert.True(t, m.VLANEnabled.ValueBool())
+	assert.Equal(t, "required", m.VLANWLANMode.ValueString())
+	require.Len(t, m.AuthServers, 1)
+	assert.Equal(t, "10.0.0.5", m.AuthServers[0].IP.ValueString())
+	assert.Equal(t, int64(1812), m.AuthServers[0].Port.ValueInt64())
+	assert.Empty(t, m.AcctServers)
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccRADIUSProfileDataSource_default(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "terrifi_radius_profile" "test" {
+  name = "Default"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.terrifi_radius_profile.test", "id"),
+					resource.TestCheckResourceAttr("data.terrifi_radius_profile.test", "name", "Default"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRADIUSProfileDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "terrifi_radius_profile" "test" {
+  name = "tfacc-missing-%s"
+}
+`, randomSuffix()),
+				ExpectError: regexp.MustCompile(`RADIUS Profile Not Found`),
+			},
+		},
+	})
+}
@@ -0,0 +1,117 @@
+package provider
+
+// TODO(go-unifi): The SDK's Account struct can't represent a RADIUS user
+// without a VLAN the way the controller does. vlan is tagged omitempty, so an
+// update can't remove it: the field is left out and the controller keeps the
+// stored value. The controller clears it when sent an empty string, and then
+// returns the empty string, which the SDK fails to decode into *int64. When
+// the SDK handles both, this file can be deleted.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// accountUpdateRequest is the payload for PUT api/s/{site}/rest/account/{id}.
+// VLAN shadows the embedded field so that it is always sent.
+type accountUpdateRequest struct {
+	unifi.Account
+	VLAN any `json:"vlan"`
+}
+
+// accountIntFields are the numeric account fields the controller reports as
+// "" when they are unset.
+var accountIntFields = []string{"vlan", "tunnel_type", "tunnel_medium_type"}
+
+// ListAccount lists RADIUS users via the v1 REST API, bypassing the SDK so
+// that unset numeric fields decode as nil.
+// This method shadows the SDK's promoted ListAccount on ApiClient.
+func (c *Client) ListAccount(ctx context.Context, site string) ([]unifi.Account, error) {
+	var respBody struct {
+		Meta json.RawMessage   `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/rest/account", c.BaseURL, c.APIPath, site),
+		nil, &respBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+	accounts := make([]unifi.Account, 0, len(respBody.Data))
+	for _, raw := range respBody.Data {
+		account, err := decodeAccount(raw)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *account)
+	}
+	return accounts, nil
+}
+
+// GetAccount reads a RADIUS user via the v1 REST API, bypassing the SDK so
+// that unset numeric fields decode as nil.
+// This method shadows the SDK's promoted GetAccount on ApiClient.
+func (c *Client) GetAccount(ctx context.Context, site, id string) (*unifi.Account, error) {
+	return c.doAccountRequest(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/rest/account/%s", c.BaseURL, c.APIPath, site, id), nil)
+}
+
+// UpdateAccount updates a RADIUS user via the v1 REST API, bypassing the SDK
+// so that a removed VLAN is cleared.
+// This method shadows the SDK's promoted UpdateAccount on ApiClient.
+func (c *Client) UpdateAccount(ctx context.Context, site string, d *unifi.Account) (*unifi.Account, error) {
+	payload := accountUpdateRequest{Account: *d, VLAN: ""}
+	if d.VLAN != nil {
+		payload.VLAN = *d.VLAN
+	}
+	return c.doAccountRequest(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/api/s/%s/rest/account/%s", c.BaseURL, c.APIPath, site, d.ID), payload)
+}
+
+// doAccountRequest sends a request to an account endpoint and decodes the
+// single account in the response.
+func (c *Client) doAccountRequest(ctx context.Context, method, url string, body any) (*unifi.Account, error) {
+	var respBody struct {
+		Meta json.RawMessage   `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := c.doV1Request(ctx, method, url, body, &respBody); err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+	if len(respBody.Data) != 1 {
+		return nil, &unifi.NotFoundError{}
+	}
+	return decodeAccount(respBody.Data[0])
+}
+
+// decodeAccount decodes an account, treating "" in numeric fields as unset.
+func decodeAccount(raw json.RawMessage) (*unifi.Account, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decoding account: %w", err)
+	}
+	for _, key := range accountIntFields {
+		if string(fields[key]) == `""` {
+			delete(fields, key)
+		}
+	}
+	cleaned, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("decoding account: %w", err)
+	}
+	var account unifi.Account
+	if err := json.Unmarshal(cleaned, &account); err != nil {
+		return nil, fmt.Errorf("decoding account: %w", err)
+	}
+	return &account, nil
+}
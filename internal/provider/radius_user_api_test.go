@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestUpdateAccount(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"a1","name":"alice"}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	t.Run("VLAN is sent", func(t *testing.T) {
+		vlan := int64(30)
+		updated, err := client.UpdateAccount(context.Background(), "default", &unifi.Account{ID: "a1", Name: "alice", VLAN: &vlan})
+		require.NoError(t, err)
+		assert.Equal(t, "a1", updated.ID)
+		assert.Contains(t, gotPath, "/api/s/default/rest/account/a1")
+		assert.Equal(t, float64(30), gotBody["vlan"])
+		assert.Equal(t, "alice", gotBody["name"])
+	})
+
+	t.Run("removed VLAN is cleared", func(t *testing.T) {
+		_, err := client.UpdateAccount(context.Background(), "default", &unifi.Account{ID: "a1", Name: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "", gotBody["vlan"])
+	})
+}
+
+func TestDecodeAccount(t *testing.T) {
+	t.Run("empty numeric fields are unset", func(t *testing.T) {
+		account, err := decodeAccount([]byte(`{"_id":"a1","name":"alice","vlan":"","tunnel_type":13,"tunnel_medium_type":""}`))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", account.Name)
+		assert.Nil(t, account.VLAN)
+		require.NotNil(t, account.TunnelType)
+		assert.Equal(t, int64(13), *account.TunnelType)
+		assert.Nil(t, account.TunnelMediumType)
+	})
+
+	t.Run("VLAN", func(t *testing.T) {
+		account, err := decodeAccount([]byte(`{"_id":"a1","vlan":30}`))
+		require.NoError(t, err)
+		require.NotNil(t, account.VLAN)
+		assert.Equal(t, int64(30), *account.VLAN)
+	})
+}
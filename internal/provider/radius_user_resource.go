@@ -0,0 +1,343 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                = &radiusUserResource{}
+	_ resource.ResourceWithImportState = &radiusUserResource{}
+)
+
+// RFC 2868 tunnel attribute values the UniFi UI sends for every user: a VLAN
+// tunnel (Tunnel-Type 13) over IEEE 802 media (Tunnel-Medium-Type 6).
+const (
+	radiusTunnelTypeVLAN       = 13
+	radiusTunnelMediumType8021 = 6
+)
+
+// NewRADIUSUserResource is the factory function registered in provider.Resources().
+func NewRADIUSUserResource() resource.Resource {
+	return &radiusUserResource{}
+}
+
+// radiusUserResource holds the API client, injected by Configure().
+type radiusUserResource struct {
+	client ClientAPI
+}
+
+// radiusUserResourceModel is the Terraform-side representation of a user of
+// the built-in RADIUS server. The controller calls these accounts.
+type radiusUserResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Site             types.String `tfsdk:"site"`
+	Name             types.String `tfsdk:"name"`
+	Password         types.String `tfsdk:"password"`
+	VLAN             types.Int64  `tfsdk:"vlan"`
+	TunnelType       types.Int64  `tfsdk:"tunnel_type"`
+	TunnelMediumType types.Int64  `tfsdk:"tunnel_medium_type"`
+}
+
+// Metadata sets the resource type name.
+func (r *radiusUserResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_radius_user"
+}
+
+// Schema defines the HCL schema for the terrifi_radius_user resource.
+func (r *radiusUserResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a user of the UniFi controller's built-in RADIUS server, which authenticates " +
+			"clients of WPA-Enterprise WLANs and 802.1X-protected switch ports. Enable the server with " +
+			"`terrifi_setting_radius`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the RADIUS user.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the RADIUS user with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The user name clients authenticate with. Spaces and quotes are not allowed.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^"' ]+$`),
+						"must not be empty or contain spaces or quotes",
+					),
+				},
+			},
+
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The user's password.",
+				Required:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"vlan": schema.Int64Attribute{
+				MarkdownDescription: "The VLAN the server assigns to the user's connections (dynamic VLAN " +
+					"assignment), between 2 and 4009. When unset, clients stay on the network of the WLAN or port " +
+					"they connect to. Assigning a VLAN to tunneled EAP methods such as PEAP requires " +
+					"`tunneled_reply` on `terrifi_setting_radius`.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(2, 4009),
+				},
+			},
+
+			"tunnel_type": schema.Int64Attribute{
+				MarkdownDescription: "The RFC 2868 Tunnel-Type attribute returned with the VLAN, between 1 and 13. " +
+					"Default: `13` (VLAN).",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(radiusTunnelTypeVLAN),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 13),
+				},
+			},
+
+			"tunnel_medium_type": schema.Int64Attribute{
+				MarkdownDescription: "The RFC 2868 Tunnel-Medium-Type attribute returned with the VLAN, between 1 " +
+					"and 15. Default: `6` (IEEE 802).",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(radiusTunnelMediumType8021),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 15),
+				},
+			},
+		},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *radiusUserResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Create creates a new RADIUS user.
+func (r *radiusUserResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan radiusUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateAccount(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating RADIUS User", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *radiusUserResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state radiusUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	account, err := r.client.GetAccount(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading RADIUS User",
+			fmt.Sprintf("Could not read RADIUS user %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(account, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing RADIUS user. Every attribute is either required,
+// has a default, or is cleared when removed, so the plan is the complete object.
+func (r *radiusUserResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan radiusUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetAccount(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading RADIUS User for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "RADIUS user", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	account := r.modelToAPI(&plan)
+	account.ID = state.ID.ValueString()
+	// Keep the fields this resource doesn't manage, such as the network of
+	// VPN users, instead of clearing them.
+	account.NetworkID = current.NetworkID
+	account.IP = current.IP
+	account.TunnelConfigType = current.TunnelConfigType
+
+	updated, err := r.client.UpdateAccount(ctx, site, account)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating RADIUS User", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the RADIUS user from the UniFi controller.
+func (r *radiusUserResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state radiusUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteAccount(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting RADIUS User", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_radius_user.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *radiusUserResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI converts our Terraform model to the go-unifi Account struct.
+func (r *radiusUserResource) modelToAPI(m *radiusUserResourceModel) *unifi.Account {
+	return &unifi.Account{
+		Name:             m.Name.ValueString(),
+		XPassword:        m.Password.ValueString(),
+		VLAN:             m.VLAN.ValueInt64Pointer(),
+		TunnelType:       m.TunnelType.ValueInt64Pointer(),
+		TunnelMediumType: m.TunnelMediumType.ValueInt64Pointer(),
+	}
+}
+
+// apiToModel converts the go-unifi Account struct back to our Terraform model.
+// The password is kept from the model when the controller doesn't return it.
+func (r *radiusUserResource) apiToModel(account *unifi.Account, m *radiusUserResourceModel, site string) {
+	m.ID = types.StringValue(account.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(account.Name)
+	if account.XPassword != "" {
+		m.Password = types.StringValue(account.XPassword)
+	}
+	m.VLAN = types.Int64PointerValue(account.VLAN)
+	m.TunnelType = types.Int64PointerValue(account.TunnelType)
+	m.TunnelMediumType = types.Int64PointerValue(account.TunnelMediumType)
+}
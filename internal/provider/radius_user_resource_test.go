@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestRADIUSUserModelToAPI(t *testing.T) {
+	r := &radiusUserResource{}
+
+	t.Run("with VLAN", func(t *testing.T) {
+		account := r.modelToAPI(&radiusUserResourceModel{
+			Name:             types.StringValue("alice"),
+			Password:         types.StringValue("s3cret"),
+			VLAN:             types.Int64Value(30),
+			TunnelType:       types.Int64Value(13),
+			TunnelMediumType: types.Int64Value(6),
+		})
+
+		assert.Equal(t, "alice", account.Name)
+		assert.Equal(t, "s3cret", account.XPassword)
+		require.NotNil(t, account.VLAN)
+		assert.Equal(t, int64(30), *account.VLAN)
+		require.NotNil(t, account.TunnelType)
+		assert.Equal(t, int64(13), *account.TunnelType)
+		require.NotNil(t, account.TunnelMediumType)
+		assert.Equal(t, int64(6), *account.TunnelMediumType)
+	})
+
+	t.Run("without VLAN", func(t *testing.T) {
+		account := r.modelToAPI(&radiusUserResourceModel{
+			Name:             types.StringValue("bob"),
+			Password:         types.StringValue("s3cret"),
+			VLAN:             types.Int64Null(),
+			TunnelType:       types.Int64Value(13),
+			TunnelMediumType: types.Int64Value(6),
+		})
+
+		assert.Nil(t, account.VLAN)
+	})
+}
+
+func TestRADIUSUserAPIToModel(t *testing.T) {
+	r := &radiusUserResource{}
+	vlan, tunnelType, mediumType := int64(30), int64(13), int64(6)
+
+	t.Run("all fields", func(t *testing.T) {
+		var m radiusUserResourceModel
+		r.apiToModel(&unifi.Account{
+			ID: "a1", Name: "alice", XPassword: "s3cret", VLAN: &vlan, TunnelType: &tunnelType, TunnelMediumType: &mediumType,
+		}, &m, "default")
+
+		assert.Equal(t, "a1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "alice", m.Name.ValueString())
+		assert.Equal(t, "s3cret", m.Password.ValueString())
+		assert.Equal(t, int64(30), m.VLAN.ValueInt64())
+		assert.Equal(t, int64(13), m.TunnelType.ValueInt64())
+		assert.Equal(t, int64(6), m.TunnelMediumType.ValueInt64())
+	})
+
+	t.Run("password not returned", func(t *testing.T) {
+		m := radiusUserResourceModel{Password: types.StringValue("from-state")}
+		r.apiToModel(&unifi.Account{ID: "a1", Name: "alice"}, &m, "default")
+
+		assert.Equal(t, "from-state", m.Password.ValueString())
+		assert.True(t, m.VLAN.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccRADIUSUser_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_radius_user" "test" {
+  name     = %q
+  password = "initial-password"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_radius_user.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_radius_user.test", "name", name),
+					resource.TestCheckNoResourceAttr("terrifi_radius_user.test", "vlan"),
+					resource.TestCheckResourceAttr("terrifi_radius_user.test", "tunnel_type", "13"),
+					resource.TestCheckResourceAttr("terrifi_radius_user.test", "tunnel_medium_type", "6"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_radius_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_radius_user" "test" {
+  name     = %q
+  password = "changed-password"
+  vlan     = 30
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_radius_user.test", "password", "changed-password"),
+					resource.TestCheckResourceAttr("terrifi_radius_user.test", "vlan", "30"),
+				),
+			},
+			{
+				// Removing the VLAN clears it on the controller.
+				Config: fmt.Sprintf(`
+resource "terrifi_radius_user" "test" {
+  name     = %q
+  password = "changed-password"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_radius_user.test", "vlan"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRADIUSUser_validationName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_radius_user" "test" {
+  name     = "two words"
+  password = "password"
+}
+`,
+				ExpectError: regexp.MustCompile(`must not be empty or contain spaces or quotes`),
+			},
+		},
+	})
+}
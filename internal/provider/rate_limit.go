@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport caps the rate of outgoing HTTP requests with a token
+// bucket. UniFi OS consoles (notably the UDM Pro) throttle aggressively and
+// answer bursts with 429s; pacing requests client-side keeps a large apply
+// under the controller's limit instead of relying on retries alone.
+//
+// It wraps the transport rather than the retryablehttp.Client so that every
+// attempt, including retries, waits for a token.
+type rateLimitedTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// newRateLimitedTransport returns a transport allowing at most
+// requestsPerSecond requests per second through next. A burst of one keeps
+// the cap strict: concurrent Terraform operations queue instead of firing
+// together.
+func newRateLimitedTransport(next http.RoundTripper, requestsPerSecond int) *rateLimitedTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitedTransport{
+		next:    next,
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+	}
+}
+
+// RoundTrip waits for a token (or the request's context to be cancelled)
+// before delegating to the wrapped transport.
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+// waitSDK waits for a token before an SDK call. The SDK's HTTP client can't
+// be wrapped, so SDK calls share the limiter of HTTP's transport instead; see
+// withSDK.
+func (c *Client) waitSDK(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.Wait(ctx)
+}
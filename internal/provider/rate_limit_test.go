@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedTransport(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	t.Run("paces requests", func(t *testing.T) {
+		client := newTestClient(t, srv.URL, false)
+		client.HTTP.HTTPClient.Transport = newRateLimitedTransport(client.HTTP.HTTPClient.Transport, 20)
+
+		start := time.Now()
+		for range 5 {
+			_, err := client.ListFirewallZone(context.Background(), "default")
+			require.NoError(t, err)
+		}
+		// The first request uses the initial token; the other four wait 50ms each.
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+	})
+
+	t.Run("cancelled context stops waiting", func(t *testing.T) {
+		client := newTestClient(t, srv.URL, false)
+		client.HTTP.HTTPClient.Transport = newRateLimitedTransport(client.HTTP.HTTPClient.Transport, 1)
+
+		_, err := client.ListFirewallZone(context.Background(), "default")
+		require.NoError(t, err)
+
+		before := calls.Load()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = client.ListFirewallZone(ctx, "default")
+		assert.Error(t, err)
+		assert.Equal(t, before, calls.Load())
+	})
+
+	t.Run("paces SDK calls", func(t *testing.T) {
+		srv := newSDKSessionServer(t)
+		client, err := NewClient(context.Background(), ClientConfig{
+			APIURL:               srv.URL,
+			Username:             "admin",
+			Password:             "secret",
+			Site:                 "default",
+			MaxRequestsPerSecond: 20,
+		})
+		require.NoError(t, err)
+
+		start := time.Now()
+		for range 5 {
+			_, err := client.GetPortForward(context.Background(), "default", "pf-1")
+			require.NoError(t, err)
+		}
+		// The SDK calls share the custom client's limiter, whose token the
+		// custom login already spent, so each call waits 50ms.
+		assert.GreaterOrEqual(t, time.Since(start), 240*time.Millisecond)
+	})
+}
+
+func TestRetriesRateLimitedResponses(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+	client.HTTP.RetryMax = 1
+
+	_, err := client.ListFirewallZone(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
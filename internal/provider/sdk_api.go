@@ -0,0 +1,412 @@
+package provider
+
+// TODO(go-unifi): The SDK answers a 401 for an expired session with a
+// LoginRequiredError instead of logging in again, and its login method isn't
+// exported. Every SDK method the provider uses is therefore wrapped here and
+// called through withSDK, which logs in again and retries once. When the SDK
+// refreshes its own session, this file can be deleted and the SDK client
+// embedded in Client again.
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// withSDK calls call with the current SDK client. When a username and
+// password session is rejected with a LoginRequiredError, e.g. because it
+// expired during a long apply, it logs in again (see reloginSDK) and retries
+// the call once. Each attempt waits for the rate limiter, if any.
+func withSDK[T any](ctx context.Context, c *Client, call func(*unifi.ApiClient) (T, error)) (T, error) {
+	var zero T
+	if err := c.waitSDK(ctx); err != nil {
+		return zero, err
+	}
+	sdk, session := c.sdkClient()
+	v, err := call(sdk)
+	var loginErr *unifi.LoginRequiredError
+	if !errors.As(err, &loginErr) || !c.canRelogin() {
+		return v, err
+	}
+	if err := c.reloginSDK(ctx, session); err != nil {
+		return zero, err
+	}
+	if err := c.waitSDK(ctx); err != nil {
+		return zero, err
+	}
+	sdk, _ = c.sdkClient()
+	return call(sdk)
+}
+
+// withSDKErr is withSDK for SDK methods that only return an error.
+func withSDKErr(ctx context.Context, c *Client, call func(*unifi.ApiClient) error) error {
+	_, err := withSDK(ctx, c, func(sdk *unifi.ApiClient) (struct{}, error) {
+		return struct{}{}, call(sdk)
+	})
+	return err
+}
+
+func (c *Client) ListAPGroup(ctx context.Context, site string) ([]unifi.APGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.APGroup, error) {
+		return sdk.ListAPGroup(ctx, site)
+	})
+}
+
+func (c *Client) CreateAccount(ctx context.Context, site string, d *unifi.Account) (*unifi.Account, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Account, error) {
+		return sdk.CreateAccount(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteAccount(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteAccount(ctx, site, id)
+	})
+}
+
+func (c *Client) ListClientGroup(ctx context.Context, site string) ([]unifi.ClientGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.ClientGroup, error) {
+		return sdk.ListClientGroup(ctx, site)
+	})
+}
+
+func (c *Client) GetClientGroup(ctx context.Context, site, id string) (*unifi.ClientGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.ClientGroup, error) {
+		return sdk.GetClientGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateClientGroup(ctx context.Context, site string, d *unifi.ClientGroup) (*unifi.ClientGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.ClientGroup, error) {
+		return sdk.CreateClientGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateClientGroup(ctx context.Context, site string, d *unifi.ClientGroup) (*unifi.ClientGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.ClientGroup, error) {
+		return sdk.UpdateClientGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteClientGroup(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteClientGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) ListDHCPOption(ctx context.Context, site string) ([]unifi.DHCPOption, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.DHCPOption, error) {
+		return sdk.ListDHCPOption(ctx, site)
+	})
+}
+
+func (c *Client) GetDHCPOption(ctx context.Context, site, id string) (*unifi.DHCPOption, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DHCPOption, error) {
+		return sdk.GetDHCPOption(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateDHCPOption(ctx context.Context, site string, d *unifi.DHCPOption) (*unifi.DHCPOption, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DHCPOption, error) {
+		return sdk.CreateDHCPOption(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateDHCPOption(ctx context.Context, site string, d *unifi.DHCPOption) (*unifi.DHCPOption, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DHCPOption, error) {
+		return sdk.UpdateDHCPOption(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteDHCPOption(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteDHCPOption(ctx, site, id)
+	})
+}
+
+func (c *Client) ListDNSRecord(ctx context.Context, site string) ([]unifi.DNSRecord, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.DNSRecord, error) {
+		return sdk.ListDNSRecord(ctx, site)
+	})
+}
+
+func (c *Client) GetDNSRecord(ctx context.Context, site, id string) (*unifi.DNSRecord, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DNSRecord, error) {
+		return sdk.GetDNSRecord(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateDNSRecord(ctx context.Context, site string, d *unifi.DNSRecord) (*unifi.DNSRecord, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DNSRecord, error) {
+		return sdk.CreateDNSRecord(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateDNSRecord(ctx context.Context, site string, d *unifi.DNSRecord) (*unifi.DNSRecord, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DNSRecord, error) {
+		return sdk.UpdateDNSRecord(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteDNSRecord(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteDNSRecord(ctx, site, id)
+	})
+}
+
+func (c *Client) ListDpiApp(ctx context.Context, site string) ([]unifi.DpiApp, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.DpiApp, error) {
+		return sdk.ListDpiApp(ctx, site)
+	})
+}
+
+func (c *Client) GetDpiApp(ctx context.Context, site, id string) (*unifi.DpiApp, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DpiApp, error) {
+		return sdk.GetDpiApp(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateDpiApp(ctx context.Context, site string, d *unifi.DpiApp) (*unifi.DpiApp, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DpiApp, error) {
+		return sdk.CreateDpiApp(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteDpiApp(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteDpiApp(ctx, site, id)
+	})
+}
+
+func (c *Client) GetDpiGroup(ctx context.Context, site, id string) (*unifi.DpiGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DpiGroup, error) {
+		return sdk.GetDpiGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateDpiGroup(ctx context.Context, site string, d *unifi.DpiGroup) (*unifi.DpiGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.DpiGroup, error) {
+		return sdk.CreateDpiGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteDpiGroup(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteDpiGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) ListFirewallGroup(ctx context.Context, site string) ([]unifi.FirewallGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.FirewallGroup, error) {
+		return sdk.ListFirewallGroup(ctx, site)
+	})
+}
+
+func (c *Client) GetFirewallGroup(ctx context.Context, site, id string) (*unifi.FirewallGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.FirewallGroup, error) {
+		return sdk.GetFirewallGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateFirewallGroup(ctx context.Context, site string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.FirewallGroup, error) {
+		return sdk.CreateFirewallGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateFirewallGroup(ctx context.Context, site string, d *unifi.FirewallGroup) (*unifi.FirewallGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.FirewallGroup, error) {
+		return sdk.UpdateFirewallGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteFirewallGroup(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteFirewallGroup(ctx, site, id)
+	})
+}
+
+// ListNat leaves out the SDK method's query parameters, which the provider
+// doesn't use.
+func (c *Client) ListNat(ctx context.Context, site string) ([]unifi.Nat, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.Nat, error) {
+		return sdk.ListNat(ctx, site)
+	})
+}
+
+func (c *Client) GetNat(ctx context.Context, site, id string) (*unifi.Nat, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Nat, error) {
+		return sdk.GetNat(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateNat(ctx context.Context, site string, d *unifi.Nat) (*unifi.Nat, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Nat, error) {
+		return sdk.CreateNat(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateNat(ctx context.Context, site string, d *unifi.Nat) (*unifi.Nat, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Nat, error) {
+		return sdk.UpdateNat(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteNat(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteNat(ctx, site, id)
+	})
+}
+
+// ListNetwork leaves out the SDK method's unused filter parameter, whose
+// unexported type ClientAPI can't name.
+func (c *Client) ListNetwork(ctx context.Context, site string) ([]unifi.Network, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.Network, error) {
+		return sdk.ListNetwork(ctx, site)
+	})
+}
+
+func (c *Client) GetNetwork(ctx context.Context, site, id string) (*unifi.Network, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Network, error) {
+		return sdk.GetNetwork(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateNetwork(ctx context.Context, site string, d *unifi.Network) (*unifi.Network, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Network, error) {
+		return sdk.CreateNetwork(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateNetwork(ctx context.Context, site string, d *unifi.Network) (*unifi.Network, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.Network, error) {
+		return sdk.UpdateNetwork(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteNetwork(ctx context.Context, site, id, name string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteNetwork(ctx, site, id, name)
+	})
+}
+
+func (c *Client) ListNetworkMembersGroups(ctx context.Context, site string) ([]unifi.NetworkMembersGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.NetworkMembersGroup, error) {
+		return sdk.ListNetworkMembersGroups(ctx, site)
+	})
+}
+
+func (c *Client) GetNetworkMembersGroup(ctx context.Context, site string, id string) (*unifi.NetworkMembersGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.NetworkMembersGroup, error) {
+		return sdk.GetNetworkMembersGroup(ctx, site, id)
+	})
+}
+
+func (c *Client) UpdateNetworkMembersGroup(ctx context.Context, site string, d *unifi.NetworkMembersGroup) (*unifi.NetworkMembersGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.NetworkMembersGroup, error) {
+		return sdk.UpdateNetworkMembersGroup(ctx, site, d)
+	})
+}
+
+func (c *Client) ListPortForward(ctx context.Context, site string) ([]unifi.PortForward, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.PortForward, error) {
+		return sdk.ListPortForward(ctx, site)
+	})
+}
+
+func (c *Client) GetPortForward(ctx context.Context, site, id string) (*unifi.PortForward, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.PortForward, error) {
+		return sdk.GetPortForward(ctx, site, id)
+	})
+}
+
+func (c *Client) CreatePortForward(ctx context.Context, site string, d *unifi.PortForward) (*unifi.PortForward, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.PortForward, error) {
+		return sdk.CreatePortForward(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdatePortForward(ctx context.Context, site string, d *unifi.PortForward) (*unifi.PortForward, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.PortForward, error) {
+		return sdk.UpdatePortForward(ctx, site, d)
+	})
+}
+
+func (c *Client) DeletePortForward(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeletePortForward(ctx, site, id)
+	})
+}
+
+func (c *Client) ListPortProfile(ctx context.Context, site string) ([]unifi.PortProfile, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.PortProfile, error) {
+		return sdk.ListPortProfile(ctx, site)
+	})
+}
+
+func (c *Client) GetPortProfile(ctx context.Context, site, id string) (*unifi.PortProfile, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.PortProfile, error) {
+		return sdk.GetPortProfile(ctx, site, id)
+	})
+}
+
+func (c *Client) CreatePortProfile(ctx context.Context, site string, d *unifi.PortProfile) (*unifi.PortProfile, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.PortProfile, error) {
+		return sdk.CreatePortProfile(ctx, site, d)
+	})
+}
+
+func (c *Client) DeletePortProfile(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeletePortProfile(ctx, site, id)
+	})
+}
+
+func (c *Client) ListRADIUSProfile(ctx context.Context, site string) ([]unifi.RADIUSProfile, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.RADIUSProfile, error) {
+		return sdk.ListRADIUSProfile(ctx, site)
+	})
+}
+
+func (c *Client) ListSites(ctx context.Context) ([]unifi.Site, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.Site, error) {
+		return sdk.ListSites(ctx)
+	})
+}
+
+func (c *Client) ListWLAN(ctx context.Context, site string) ([]unifi.WLAN, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.WLAN, error) {
+		return sdk.ListWLAN(ctx, site)
+	})
+}
+
+func (c *Client) GetWLAN(ctx context.Context, site, id string) (*unifi.WLAN, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.WLAN, error) {
+		return sdk.GetWLAN(ctx, site, id)
+	})
+}
+
+func (c *Client) CreateWLAN(ctx context.Context, site string, d *unifi.WLAN) (*unifi.WLAN, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.WLAN, error) {
+		return sdk.CreateWLAN(ctx, site, d)
+	})
+}
+
+func (c *Client) UpdateWLAN(ctx context.Context, site string, d *unifi.WLAN) (*unifi.WLAN, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) (*unifi.WLAN, error) {
+		return sdk.UpdateWLAN(ctx, site, d)
+	})
+}
+
+func (c *Client) DeleteWLAN(ctx context.Context, site, id string) error {
+	return withSDKErr(ctx, c, func(sdk *unifi.ApiClient) error {
+		return sdk.DeleteWLAN(ctx, site, id)
+	})
+}
+
+func (c *Client) ListWLANGroup(ctx context.Context, site string) ([]unifi.WLANGroup, error) {
+	return withSDK(ctx, c, func(sdk *unifi.ApiClient) ([]unifi.WLANGroup, error) {
+		return sdk.ListWLANGroup(ctx, site)
+	})
+}
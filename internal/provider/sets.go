@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringSetValue builds a set attribute from a string slice returned by the
+// controller. The controller does not preserve the order of these slices
+// (and has been seen to repeat entries), so values are normalized with
+// normalize, if non-nil, then de-duplicated and sorted. A set with duplicate
+// elements is invalid, and a stable order keeps state files and the values
+// sent back on update from churning between refreshes.
+func stringSetValue(values []string, normalize func(string) string) types.Set {
+	normalized := make([]string, len(values))
+	for i, v := range values {
+		if normalize != nil {
+			v = normalize(v)
+		}
+		normalized[i] = v
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	elems := make([]attr.Value, len(normalized))
+	for i, v := range normalized {
+		elems[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+// sortedSetStrings returns the elements of a known string set in sorted
+// order, so the request sent to the controller does not depend on the
+// order Terraform happened to store them in.
+func sortedSetStrings(s types.Set) []string {
+	values := make([]string, 0, len(s.Elements()))
+	for _, e := range s.Elements() {
+		if v, ok := e.(types.String); ok {
+			values = append(values, v.ValueString())
+		}
+	}
+	slices.Sort(values)
+	return values
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringSetValue(t *testing.T) {
+	t.Run("sorts and removes duplicates", func(t *testing.T) {
+		got := stringSetValue([]string{"wed", "mon", "wed"}, nil)
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("mon"), types.StringValue("wed"),
+		}), got)
+	})
+
+	t.Run("normalizes before removing duplicates", func(t *testing.T) {
+		got := stringSetValue([]string{"NEW", "established", "ESTABLISHED"}, strings.ToUpper)
+		assert.Equal(t, types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("ESTABLISHED"), types.StringValue("NEW"),
+		}), got)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		got := stringSetValue(nil, nil)
+		assert.False(t, got.IsNull())
+		assert.Empty(t, got.Elements())
+	})
+}
+
+func TestSortedSetStrings(t *testing.T) {
+	s := types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("sat"), types.StringValue("fri"), types.StringValue("mon"),
+	})
+	assert.Equal(t, []string{"fri", "mon", "sat"}, sortedSetStrings(s))
+}
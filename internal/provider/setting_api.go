@@ -0,0 +1,153 @@
+package provider
+
+// TODO(go-unifi): This file replaces the SDK's GetSetting/UpdateSetting for
+// the site-wide settings resources (terrifi_setting_*). The upstream issues are:
+//
+//  1. UpdateSetting marshals the typed structs in the unifi/settings package,
+//     whose bool fields are all tagged `omitempty`. An explicit `false` (e.g.
+//     turning a feature off) is dropped from the PUT body and the controller
+//     keeps its previous value.
+//     Fix needed in SDK: drop `omitempty` from bool setting fields, or use
+//     pointer bools.
+//
+//  2. GetSetting unmarshals a nil json.RawMessage when the response contains
+//     settings but none with the requested key, producing an opaque
+//     "unexpected end of JSON input" error instead of NotFoundError.
+//     Fix needed in SDK: return NotFoundError when no entry matches the key.
+//
+// Resources send bespoke payload structs through updateSetting (mirroring the
+// firewall zone workaround) and decode reads into the SDK types via getSetting.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// getSetting reads the site setting identified by key and decodes it into T.
+// Returns *unifi.NotFoundError when the controller has no such setting.
+func getSetting[T any](ctx context.Context, c *Client, site, key string) (*T, error) {
+	var respBody struct {
+		Meta json.RawMessage   `json:"meta"`
+		Data []json.RawMessage `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/get/setting/%s", c.BaseURL, c.APIPath, site, key),
+		nil, &respBody)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkV1Meta(respBody.Meta); err != nil {
+		return nil, err
+	}
+
+	for _, raw := range respBody.Data {
+		var base struct {
+			Key string `json:"key"`
+		}
+		if err := json.Unmarshal(raw, &base); err != nil {
+			return nil, fmt.Errorf("decoding setting: %w", err)
+		}
+		if base.Key != key {
+			continue
+		}
+		var result T
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, fmt.Errorf("decoding setting %q: %w", key, err)
+		}
+		return &result, nil
+	}
+	return nil, &unifi.NotFoundError{}
+}
+
+// updateSetting sends a partial update for the site setting identified by key.
+// The controller merges the payload into the existing setting, so payload only
+// needs the fields a resource manages. The key and _id are added here.
+func (c *Client) updateSetting(ctx context.Context, site, key, id string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshaling setting payload: %w", err)
+	}
+	body := map[string]any{}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return fmt.Errorf("marshaling setting payload: %w", err)
+	}
+	body["key"] = key
+	if id != "" {
+		body["_id"] = id
+	}
+
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err = c.doV1Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/api/s/%s/set/setting/%s", c.BaseURL, c.APIPath, site, key),
+		body, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
+
+// GetSettingConnectivity returns the site's uplink connectivity monitor
+// setting.
+func (c *Client) GetSettingConnectivity(ctx context.Context, site string) (*settings.Connectivity, error) {
+	return getSetting[settings.Connectivity](ctx, c, site, "connectivity")
+}
+
+// GetSettingCountry returns the site's country (regulatory domain) setting.
+func (c *Client) GetSettingCountry(ctx context.Context, site string) (*settings.Country, error) {
+	return getSetting[settings.Country](ctx, c, site, "country")
+}
+
+// GetSettingGlobalSwitch returns the site's global switch setting, which holds
+// the switch isolation ACLs.
+func (c *Client) GetSettingGlobalSwitch(ctx context.Context, site string) (*settings.GlobalSwitch, error) {
+	return getSetting[settings.GlobalSwitch](ctx, c, site, "global_switch")
+}
+
+// GetSettingRadius returns the site's built-in RADIUS server setting.
+func (c *Client) GetSettingRadius(ctx context.Context, site string) (*settings.Radius, error) {
+	return getSetting[settings.Radius](ctx, c, site, "radius")
+}
+
+// GetSettingGuestAccess returns the site's guest hotspot portal setting.
+func (c *Client) GetSettingGuestAccess(ctx context.Context, site string) (*settings.GuestAccess, error) {
+	return getSetting[settings.GuestAccess](ctx, c, site, "guest_access")
+}
+
+// GetSettingLocale returns the site's locale (timezone) setting.
+func (c *Client) GetSettingLocale(ctx context.Context, site string) (*settings.Locale, error) {
+	return getSetting[settings.Locale](ctx, c, site, "locale")
+}
+
+// GetSettingRsyslogd returns the site's remote syslog (SIEM server) setting.
+func (c *Client) GetSettingRsyslogd(ctx context.Context, site string) (*settings.Rsyslogd, error) {
+	return getSetting[settings.Rsyslogd](ctx, c, site, "rsyslogd")
+}
+
+// GetSettingTeleport returns the site's Teleport (one-click VPN) setting.
+func (c *Client) GetSettingTeleport(ctx context.Context, site string) (*settings.Teleport, error) {
+	return getSetting[settings.Teleport](ctx, c, site, "teleport")
+}
+
+// GetSettingUsg returns the site's gateway setting, which holds the UPnP,
+// mDNS, DHCP relay, offloading and GeoIP filtering configuration.
+func (c *Client) GetSettingUsg(ctx context.Context, site string) (*settings.Usg, error) {
+	return getSetting[settings.Usg](ctx, c, site, "usg")
+}
+
+// GetSettingSuperCloudaccess returns the controller's remote access (UniFi
+// cloud) setting.
+func (c *Client) GetSettingSuperCloudaccess(ctx context.Context, site string) (*settings.SuperCloudaccess, error) {
+	return getSetting[settings.SuperCloudaccess](ctx, c, site, "super_cloudaccess")
+}
+
+// GetSettingIps returns the site's intrusion prevention (IPS/IDS) setting.
+func (c *Client) GetSettingIps(ctx context.Context, site string) (*settings.Ips, error) {
+	return getSetting[settings.Ips](ctx, c, site, "ips")
+}
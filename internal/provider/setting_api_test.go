@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+func TestGetSetting(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"_id":"s1","key":"locale","timezone":"Europe/Berlin"},
+			{"_id":"s2","key":"country","code":276}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	t.Run("matching key is decoded", func(t *testing.T) {
+		country, err := getSetting[settings.Country](context.Background(), client, "default", "country")
+		require.NoError(t, err)
+		assert.Equal(t, "s2", country.ID)
+		require.NotNil(t, country.Code)
+		assert.Equal(t, int64(276), *country.Code)
+	})
+
+	t.Run("missing key is NotFoundError", func(t *testing.T) {
+		_, err := getSetting[settings.Ntp](context.Background(), client, "default", "ntp")
+		require.Error(t, err)
+		assert.IsType(t, &unifi.NotFoundError{}, err)
+	})
+}
+
+func TestUpdateSetting(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&gotBody)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	// Explicit false values must survive serialization; this is the reason
+	// we don't use the SDK's omitempty-tagged settings structs.
+	payload := struct {
+		Enabled bool `json:"enabled"`
+	}{Enabled: false}
+
+	err := client.updateSetting(context.Background(), "default", "ips", "abc123", payload)
+	require.NoError(t, err)
+	assert.Equal(t, "/proxy/network/api/s/default/set/setting/ips", gotPath)
+	assert.Equal(t, "ips", gotBody["key"])
+	assert.Equal(t, "abc123", gotBody["_id"])
+	assert.Equal(t, false, gotBody["enabled"])
+}
+
+func TestUpdateSetting_ControllerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"error","msg":"api.err.InvalidPayload"},"data":[]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	err := client.updateSetting(context.Background(), "default", "country", "", settingCountryPayload{Code: 840})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "api.err.InvalidPayload")
+}
@@ -0,0 +1,320 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                     = &settingConnectivityResource{}
+	_ resource.ResourceWithImportState      = &settingConnectivityResource{}
+	_ resource.ResourceWithConfigValidators = &settingConnectivityResource{}
+)
+
+func NewSettingConnectivityResource() resource.Resource {
+	return &settingConnectivityResource{}
+}
+
+type settingConnectivityResource struct {
+	client ClientAPI
+}
+
+type settingConnectivityResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Site       types.String `tfsdk:"site"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	UplinkType types.String `tfsdk:"uplink_type"`
+	UplinkHost types.String `tfsdk:"uplink_host"`
+}
+
+// settingConnectivityPayload is the body for PUT set/setting/connectivity.
+// Empty strings are omitted so that attributes left out of the config keep
+// the controller's current value. The mesh fields of the setting are never
+// sent.
+type settingConnectivityPayload struct {
+	Enabled    bool   `json:"enabled"`
+	UplinkType string `json:"uplink_type,omitempty"`
+	UplinkHost string `json:"uplink_host,omitempty"`
+}
+
+func (r *settingConnectivityResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_connectivity"
+}
+
+func (r *settingConnectivityResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the uplink connectivity monitor of a UniFi site. The monitor probes a host " +
+			"to decide whether the internet is reachable; gateways use the result to detect a dead WAN and fail " +
+			"over, and access points use it to detect a lost uplink. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the connectivity setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the uplink connectivity monitor is enabled.",
+				Required:            true,
+			},
+
+			"uplink_type": schema.StringAttribute{
+				MarkdownDescription: "Which host the monitor probes. `gateway` probes the default gateway; " +
+					"`custom` probes `uplink_host`. If not set, the controller's current value is kept.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("gateway", "custom"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"uplink_host": schema.StringAttribute{
+				MarkdownDescription: "Hostname or IP address the monitor probes when `uplink_type` is `custom` " +
+					"(e.g. `1.1.1.1`). Required when `uplink_type` is `custom`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires a host for the custom uplink type.
+func (r *settingConnectivityResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		settingConnectivityHostValidator{},
+	}
+}
+
+func (r *settingConnectivityResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingConnectivityResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingConnectivityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingConnectivity(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Connectivity Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "connectivity", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Connectivity Setting", err.Error())
+		return
+	}
+
+	connectivity, err := r.client.GetSettingConnectivity(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Connectivity Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(connectivity, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingConnectivityResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingConnectivityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	connectivity, err := r.client.GetSettingConnectivity(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Connectivity Setting",
+			fmt.Sprintf("Could not read connectivity setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(connectivity, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingConnectivityResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingConnectivityResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "connectivity", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Connectivity Setting", err.Error())
+		return
+	}
+
+	connectivity, err := r.client.GetSettingConnectivity(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Connectivity Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(connectivity, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingConnectivityResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing connectivity setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingConnectivityResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingConnectivityResource) modelToAPI(m *settingConnectivityResourceModel) settingConnectivityPayload {
+	return settingConnectivityPayload{
+		Enabled:    m.Enabled.ValueBool(),
+		UplinkType: m.UplinkType.ValueString(),
+		UplinkHost: m.UplinkHost.ValueString(),
+	}
+}
+
+func (r *settingConnectivityResource) apiToModel(s *settings.Connectivity, m *settingConnectivityResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.Enabled = types.BoolValue(s.Enabled)
+	m.UplinkType = stringValueOrNull(s.UplinkType)
+	m.UplinkHost = stringValueOrNull(s.UplinkHost)
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// settingConnectivityHostValidator rejects the custom uplink type without a
+// host, which the controller would accept and then have nothing to probe.
+type settingConnectivityHostValidator struct{}
+
+func (v settingConnectivityHostValidator) Description(_ context.Context) string {
+	return "uplink_host is required when uplink_type is custom"
+}
+
+func (v settingConnectivityHostValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v settingConnectivityHostValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var uplinkType, uplinkHost types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("uplink_type"), &uplinkType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("uplink_host"), &uplinkHost)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if uplinkType.ValueString() == "custom" && uplinkHost.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("uplink_host"),
+			"Missing Uplink Host",
+			"uplink_host is required when uplink_type is \"custom\".",
+		)
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingConnectivityModelToAPI(t *testing.T) {
+	r := &settingConnectivityResource{}
+
+	t.Run("custom host", func(t *testing.T) {
+		payload := r.modelToAPI(&settingConnectivityResourceModel{
+			Enabled:    types.BoolValue(true),
+			UplinkType: types.StringValue("custom"),
+			UplinkHost: types.StringValue("1.1.1.1"),
+		})
+
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "custom", payload.UplinkType)
+		assert.Equal(t, "1.1.1.1", payload.UplinkHost)
+	})
+
+	t.Run("unknown attributes keep controller value", func(t *testing.T) {
+		payload := r.modelToAPI(&settingConnectivityResourceModel{
+			Enabled:    types.BoolValue(false),
+			UplinkType: types.StringUnknown(),
+			UplinkHost: types.StringUnknown(),
+		})
+
+		assert.False(t, payload.Enabled)
+		assert.Empty(t, payload.UplinkType)
+		assert.Empty(t, payload.UplinkHost)
+	})
+}
+
+func TestSettingConnectivityAPIToModel(t *testing.T) {
+	r := &settingConnectivityResource{}
+
+	s := &settings.Connectivity{
+		BaseSetting: settings.BaseSetting{ID: "set-7", Key: "connectivity"},
+		Enabled:     true,
+		UplinkType:  "custom",
+		UplinkHost:  "1.1.1.1",
+		XMeshEssid:  "vwire-mesh",
+	}
+
+	var m settingConnectivityResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-7", m.ID.ValueString())
+	assert.True(t, m.Enabled.ValueBool())
+	assert.Equal(t, "custom", m.UplinkType.ValueString())
+	assert.Equal(t, "1.1.1.1", m.UplinkHost.ValueString())
+
+	r.apiToModel(&settings.Connectivity{}, &m, "default")
+	assert.True(t, m.UplinkType.IsNull())
+	assert.True(t, m.UplinkHost.IsNull())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingConnectivity_update(t *testing.T) {
+	config := func(host string) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_connectivity" "test" {
+  enabled     = true
+  uplink_type = "custom"
+  uplink_host = %q
+}
+`, host)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("1.1.1.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_connectivity.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_setting_connectivity.test", "uplink_type", "custom"),
+					resource.TestCheckResourceAttr("terrifi_setting_connectivity.test", "uplink_host", "1.1.1.1"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_connectivity.test", "id"),
+				),
+			},
+			{
+				Config: config("8.8.8.8"),
+				Check:  resource.TestCheckResourceAttr("terrifi_setting_connectivity.test", "uplink_host", "8.8.8.8"),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config("8.8.8.8"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "terrifi_setting_connectivity.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingConnectivity_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_connectivity" "test" {
+  enabled     = true
+  uplink_type = "custom"
+}
+`,
+				ExpectError: regexp.MustCompile(`uplink_host is required`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_connectivity" "test" {
+  enabled     = true
+  uplink_type = "wan2"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?i)value must be one of`),
+			},
+		},
+	})
+}
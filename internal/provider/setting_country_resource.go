@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingCountryResource{}
+	_ resource.ResourceWithImportState = &settingCountryResource{}
+)
+
+func NewSettingCountryResource() resource.Resource {
+	return &settingCountryResource{}
+}
+
+type settingCountryResource struct {
+	client ClientAPI
+}
+
+type settingCountryResourceModel struct {
+	ID   types.String `tfsdk:"id"`
+	Site types.String `tfsdk:"site"`
+	Code types.Int64  `tfsdk:"code"`
+}
+
+// settingCountryPayload is the body for PUT set/setting/country.
+type settingCountryPayload struct {
+	Code int64 `json:"code"`
+}
+
+func (r *settingCountryResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_country"
+}
+
+func (r *settingCountryResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the country (regulatory domain) of a UniFi site. The country determines which " +
+			"radio channels and transmit powers access points may use, so it should be managed alongside radio settings. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the country setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"code": schema.Int64Attribute{
+				MarkdownDescription: "The [ISO 3166-1 numeric](https://en.wikipedia.org/wiki/ISO_3166-1_numeric) country code " +
+					"(e.g. `840` for the United States, `276` for Germany, `826` for the United Kingdom).",
+				Required: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 999),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingCountryResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingCountryResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingCountryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingCountry(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Country Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "country", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Country Setting", err.Error())
+		return
+	}
+
+	country, err := r.client.GetSettingCountry(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Country Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(country, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingCountryResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingCountryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	country, err := r.client.GetSettingCountry(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Country Setting",
+			fmt.Sprintf("Could not read country setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(country, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingCountryResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingCountryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "country", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Country Setting", err.Error())
+		return
+	}
+
+	country, err := r.client.GetSettingCountry(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Country Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(country, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingCountryResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing country setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingCountryResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingCountryResource) modelToAPI(m *settingCountryResourceModel) settingCountryPayload {
+	return settingCountryPayload{
+		Code: m.Code.ValueInt64(),
+	}
+}
+
+func (r *settingCountryResource) apiToModel(s *settings.Country, m *settingCountryResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	if s.Code != nil {
+		m.Code = types.Int64Value(*s.Code)
+	} else {
+		m.Code = types.Int64Null()
+	}
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingCountryModelToAPI(t *testing.T) {
+	r := &settingCountryResource{}
+
+	payload := r.modelToAPI(&settingCountryResourceModel{
+		Code: types.Int64Value(840),
+	})
+
+	assert.Equal(t, int64(840), payload.Code)
+}
+
+func TestSettingCountryAPIToModel(t *testing.T) {
+	r := &settingCountryResource{}
+
+	t.Run("code set", func(t *testing.T) {
+		code := int64(276)
+		s := &settings.Country{
+			BaseSetting: settings.BaseSetting{ID: "set-1", Key: "country"},
+			Code:        &code,
+		}
+
+		var m settingCountryResourceModel
+		r.apiToModel(s, &m, "default")
+
+		assert.Equal(t, "set-1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, int64(276), m.Code.ValueInt64())
+	})
+
+	t.Run("code missing", func(t *testing.T) {
+		s := &settings.Country{BaseSetting: settings.BaseSetting{ID: "set-1"}}
+
+		var m settingCountryResourceModel
+		r.apiToModel(s, &m, "mysite")
+
+		assert.Equal(t, "mysite", m.Site.ValueString())
+		assert.True(t, m.Code.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingCountry_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_country" "test" {
+  code = 840
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_country.test", "code", "840"),
+					resource.TestCheckResourceAttr("terrifi_setting_country.test", "site", "default"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_country.test", "id"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config: `
+resource "terrifi_setting_country" "test" {
+  code = 840
+}
+`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingCountry_update(t *testing.T) {
+	config := func(code int) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_country" "test" {
+  code = %d
+}
+`, code)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(124),
+				Check:  resource.TestCheckResourceAttr("terrifi_setting_country.test", "code", "124"),
+			},
+			{
+				Config: config(840),
+				Check:  resource.TestCheckResourceAttr("terrifi_setting_country.test", "code", "840"),
+			},
+		},
+	})
+}
+
+func TestAccSettingCountry_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_country" "test" {
+  code = 840
+}
+`,
+			},
+			{
+				ResourceName:      "terrifi_setting_country.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingCountry_validationInvalidCode(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_country" "test" {
+  code = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`must be between 1 and 999`),
+			},
+		},
+	})
+}
@@ -0,0 +1,308 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingGlobalSwitchResource{}
+	_ resource.ResourceWithImportState = &settingGlobalSwitchResource{}
+)
+
+func NewSettingGlobalSwitchResource() resource.Resource {
+	return &settingGlobalSwitchResource{}
+}
+
+type settingGlobalSwitchResource struct {
+	client ClientAPI
+}
+
+type settingGlobalSwitchResourceModel struct {
+	ID                        types.String                          `tfsdk:"id"`
+	Site                      types.String                          `tfsdk:"site"`
+	DeviceIsolationNetworkIDs types.Set                             `tfsdk:"device_isolation_network_ids"`
+	L3Isolation               []settingGlobalSwitchL3IsolationModel `tfsdk:"l3_isolation"`
+}
+
+// settingGlobalSwitchL3IsolationModel blocks traffic from the clients of one
+// network to a set of other networks.
+type settingGlobalSwitchL3IsolationModel struct {
+	SourceNetworkID       types.String `tfsdk:"source_network_id"`
+	DestinationNetworkIDs types.Set    `tfsdk:"destination_network_ids"`
+}
+
+// settingGlobalSwitchPayload is the body for PUT set/setting/global_switch.
+// The isolation lists are pointers so that an unmanaged list is omitted while
+// a managed empty one is sent as [] to clear it.
+type settingGlobalSwitchPayload struct {
+	AclDeviceIsolation *[]string                                     `json:"acl_device_isolation,omitempty"`
+	AclL3Isolation     *[]settings.SettingGlobalSwitchAclL3Isolation `json:"acl_l3_isolation,omitempty"`
+}
+
+func (r *settingGlobalSwitchResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_global_switch"
+}
+
+func (r *settingGlobalSwitchResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the site-wide switch isolation ACLs of a UniFi site: device isolation, " +
+			"which stops clients of a network from reaching each other, and layer 3 isolation between networks. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the global switch setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"device_isolation_network_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the networks whose clients are isolated from each other. When set, " +
+					"it replaces the whole list on the controller; an empty set clears it.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+
+			"l3_isolation": schema.SetNestedAttribute{
+				MarkdownDescription: "Networks whose clients may not reach other networks. When set, it replaces " +
+					"the whole list on the controller; an empty set clears it.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_network_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the network whose clients are isolated.",
+							Required:            true,
+						},
+						"destination_network_ids": schema.SetAttribute{
+							MarkdownDescription: "IDs of the networks the source network's clients may not reach.",
+							Required:            true,
+							ElementType:         types.StringType,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *settingGlobalSwitchResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingGlobalSwitchResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingGlobalSwitchResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingGlobalSwitch(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Global Switch Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "global_switch", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Global Switch Setting", err.Error())
+		return
+	}
+
+	globalSwitch, err := r.client.GetSettingGlobalSwitch(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Global Switch Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(globalSwitch, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingGlobalSwitchResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingGlobalSwitchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	globalSwitch, err := r.client.GetSettingGlobalSwitch(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Global Switch Setting",
+			fmt.Sprintf("Could not read global switch setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(globalSwitch, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingGlobalSwitchResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingGlobalSwitchResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "global_switch", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Global Switch Setting", err.Error())
+		return
+	}
+
+	globalSwitch, err := r.client.GetSettingGlobalSwitch(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Global Switch Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(globalSwitch, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingGlobalSwitchResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing global switch setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingGlobalSwitchResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingGlobalSwitchResource) modelToAPI(m *settingGlobalSwitchResourceModel) settingGlobalSwitchPayload {
+	var payload settingGlobalSwitchPayload
+
+	if !m.DeviceIsolationNetworkIDs.IsNull() && !m.DeviceIsolationNetworkIDs.IsUnknown() {
+		ids := sortedSetStrings(m.DeviceIsolationNetworkIDs)
+		payload.AclDeviceIsolation = &ids
+	}
+
+	if m.L3Isolation != nil {
+		acls := make([]settings.SettingGlobalSwitchAclL3Isolation, 0, len(m.L3Isolation))
+		for _, e := range m.L3Isolation {
+			acls = append(acls, settings.SettingGlobalSwitchAclL3Isolation{
+				SourceNetwork:       e.SourceNetworkID.ValueString(),
+				DestinationNetworks: sortedSetStrings(e.DestinationNetworkIDs),
+			})
+		}
+		payload.AclL3Isolation = &acls
+	}
+
+	return payload
+}
+
+// apiToModel copies the controller's setting into m. Lists that m doesn't
+// manage (null) are left null.
+func (r *settingGlobalSwitchResource) apiToModel(s *settings.GlobalSwitch, m *settingGlobalSwitchResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+
+	if !m.DeviceIsolationNetworkIDs.IsNull() {
+		m.DeviceIsolationNetworkIDs = stringSetValue(s.AclDeviceIsolation, nil)
+	}
+
+	if m.L3Isolation != nil {
+		acls := make([]settingGlobalSwitchL3IsolationModel, 0, len(s.AclL3Isolation))
+		for _, a := range s.AclL3Isolation {
+			acls = append(acls, settingGlobalSwitchL3IsolationModel{
+				SourceNetworkID:       types.StringValue(a.SourceNetwork),
+				DestinationNetworkIDs: stringSetValue(a.DestinationNetworks, nil),
+			})
+		}
+		m.L3Isolation = acls
+	}
+}
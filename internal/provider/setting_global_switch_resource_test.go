@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingGlobalSwitchModelToAPI(t *testing.T) {
+	r := &settingGlobalSwitchResource{}
+
+	t.Run("isolation lists", func(t *testing.T) {
+		payload := r.modelToAPI(&settingGlobalSwitchResourceModel{
+			DeviceIsolationNetworkIDs: stringSet("net-guest", "net-iot"),
+			L3Isolation: []settingGlobalSwitchL3IsolationModel{{
+				SourceNetworkID:       types.StringValue("net-guest"),
+				DestinationNetworkIDs: stringSet("net-lan", "net-iot"),
+			}},
+		})
+
+		require.NotNil(t, payload.AclDeviceIsolation)
+		assert.Equal(t, []string{"net-guest", "net-iot"}, *payload.AclDeviceIsolation)
+		require.NotNil(t, payload.AclL3Isolation)
+		assert.Equal(t, []settings.SettingGlobalSwitchAclL3Isolation{{
+			SourceNetwork:       "net-guest",
+			DestinationNetworks: []string{"net-iot", "net-lan"},
+		}}, *payload.AclL3Isolation)
+	})
+
+	t.Run("unmanaged lists are not sent", func(t *testing.T) {
+		payload := r.modelToAPI(&settingGlobalSwitchResourceModel{
+			DeviceIsolationNetworkIDs: types.SetNull(types.StringType),
+		})
+
+		b, err := json.Marshal(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(b))
+	})
+
+	t.Run("empty lists clear them", func(t *testing.T) {
+		payload := r.modelToAPI(&settingGlobalSwitchResourceModel{
+			DeviceIsolationNetworkIDs: stringSet(),
+			L3Isolation:               []settingGlobalSwitchL3IsolationModel{},
+		})
+
+		b, err := json.Marshal(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"acl_device_isolation": [], "acl_l3_isolation": []}`, string(b))
+	})
+}
+
+func TestSettingGlobalSwitchAPIToModel(t *testing.T) {
+	r := &settingGlobalSwitchResource{}
+	s := &settings.GlobalSwitch{
+		BaseSetting:        settings.BaseSetting{ID: "set-sw", Key: "global_switch"},
+		AclDeviceIsolation: []string{"net-iot", "net-guest"},
+		AclL3Isolation: []settings.SettingGlobalSwitchAclL3Isolation{{
+			SourceNetwork:       "net-guest",
+			DestinationNetworks: []string{"net-lan"},
+		}},
+	}
+
+	t.Run("managed lists", func(t *testing.T) {
+		m := settingGlobalSwitchResourceModel{
+			DeviceIsolationNetworkIDs: stringSet(),
+			L3Isolation:               []settingGlobalSwitchL3IsolationModel{},
+		}
+		r.apiToModel(s, &m, "default")
+
+		assert.Equal(t, "set-sw", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, stringSet("net-guest", "net-iot"), m.DeviceIsolationNetworkIDs)
+		assert.Equal(t, []settingGlobalSwitchL3IsolationModel{{
+			SourceNetworkID:       types.StringValue("net-guest"),
+			DestinationNetworkIDs: stringSet("net-lan"),
+		}}, m.L3Isolation)
+	})
+
+	t.Run("empty controller lists stay empty", func(t *testing.T) {
+		m := settingGlobalSwitchResourceModel{
+			DeviceIsolationNetworkIDs: stringSet("net-guest"),
+			L3Isolation:               []settingGlobalSwitchL3IsolationModel{},
+		}
+		r.apiToModel(&settings.GlobalSwitch{}, &m, "default")
+
+		assert.Equal(t, stringSet(), m.DeviceIsolationNetworkIDs)
+		assert.NotNil(t, m.L3Isolation)
+		assert.Empty(t, m.L3Isolation)
+	})
+
+	t.Run("unmanaged lists stay null", func(t *testing.T) {
+		m := settingGlobalSwitchResourceModel{DeviceIsolationNetworkIDs: types.SetNull(types.StringType)}
+		r.apiToModel(s, &m, "default")
+
+		assert.True(t, m.DeviceIsolationNetworkIDs.IsNull())
+		assert.Nil(t, m.L3Isolation)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingGlobalSwitch_isolation(t *testing.T) {
+	guestName := fmt.Sprintf("tfacc-guest-%s", randomSuffix())
+	lanName := fmt.Sprintf("tfacc-lan-%s", randomSuffix())
+	guestVLAN := randomVLAN()
+	lanVLAN := guestVLAN%4000 + 1
+	networks := fmt.Sprintf(`
+resource "terrifi_network" "guest" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.0.1/24"
+}
+
+resource "terrifi_network" "lan" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.0.1/24"
+}
+`, guestName, guestVLAN, guestVLAN%256, lanName, lanVLAN, lanVLAN%256)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: networks + `
+resource "terrifi_setting_global_switch" "test" {
+  device_isolation_network_ids = [terrifi_network.guest.id]
+
+  l3_isolation = [
+    {
+      source_network_id       = terrifi_network.guest.id
+      destination_network_ids = [terrifi_network.lan.id]
+    },
+  ]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_setting_global_switch.test", "id"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"terrifi_setting_global_switch.test", "device_isolation_network_ids.*",
+						"terrifi_network.guest", "id",
+					),
+					resource.TestCheckResourceAttr("terrifi_setting_global_switch.test", "l3_isolation.#", "1"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config: networks + `
+resource "terrifi_setting_global_switch" "test" {
+  device_isolation_network_ids = [terrifi_network.guest.id]
+
+  l3_isolation = [
+    {
+      source_network_id       = terrifi_network.guest.id
+      destination_network_ids = [terrifi_network.lan.id]
+    },
+  ]
+}
+`,
+				PlanOnly: true,
+			},
+			// Clear the lists before the networks are destroyed.
+			{
+				Config: networks + `
+resource "terrifi_setting_global_switch" "test" {
+  device_isolation_network_ids = []
+  l3_isolation                 = []
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_global_switch.test", "device_isolation_network_ids.#", "0"),
+					resource.TestCheckResourceAttr("terrifi_setting_global_switch.test", "l3_isolation.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSettingGlobalSwitch_validationEmptyDestinations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_global_switch" "test" {
+  l3_isolation = [
+    { source_network_id = "000000000000000000000000", destination_network_ids = [] },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+		},
+	})
+}
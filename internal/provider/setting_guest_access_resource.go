@@ -0,0 +1,516 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                     = &settingGuestAccessResource{}
+	_ resource.ResourceWithImportState      = &settingGuestAccessResource{}
+	_ resource.ResourceWithConfigValidators = &settingGuestAccessResource{}
+)
+
+// guestAccessExpireUnits are the units the controller accepts for a custom
+// guest authorization duration, in minutes, largest first.
+var guestAccessExpireUnits = []int64{1440, 60, 1}
+
+func NewSettingGuestAccessResource() resource.Resource {
+	return &settingGuestAccessResource{}
+}
+
+type settingGuestAccessResource struct {
+	client ClientAPI
+}
+
+type settingGuestAccessResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Site                  types.String `tfsdk:"site"`
+	PortalEnabled         types.Bool   `tfsdk:"portal_enabled"`
+	Auth                  types.String `tfsdk:"auth"`
+	CustomPortalIP        types.String `tfsdk:"custom_portal_ip"`
+	VoucherEnabled        types.Bool   `tfsdk:"voucher_enabled"`
+	PasswordEnabled       types.Bool   `tfsdk:"password_enabled"`
+	Password              types.String `tfsdk:"password"`
+	ExpireMinutes         types.Int64  `tfsdk:"expire_minutes"`
+	RedirectEnabled       types.Bool   `tfsdk:"redirect_enabled"`
+	RedirectURL           types.String `tfsdk:"redirect_url"`
+	PortalCustomized      types.Bool   `tfsdk:"portal_customized"`
+	PortalTitle           types.String `tfsdk:"portal_title"`
+	PortalWelcomeText     types.String `tfsdk:"portal_welcome_text"`
+	PortalTermsOfService  types.String `tfsdk:"portal_terms_of_service"`
+	PortalBackgroundColor types.String `tfsdk:"portal_background_color"`
+	PortalButtonColor     types.String `tfsdk:"portal_button_color"`
+}
+
+// settingGuestAccessPayload is the body for PUT set/setting/guest_access.
+// Every field is a pointer so that attributes left out of the config keep the
+// controller's current value, while an explicit false or empty string is
+// still sent. Payment gateways, social logins and the other portal settings
+// the resource doesn't manage are never sent.
+type settingGuestAccessPayload struct {
+	PortalEnabled                      *bool   `json:"portal_enabled,omitempty"`
+	Auth                               *string `json:"auth,omitempty"`
+	CustomIP                           *string `json:"custom_ip,omitempty"`
+	VoucherEnabled                     *bool   `json:"voucher_enabled,omitempty"`
+	PasswordEnabled                    *bool   `json:"password_enabled,omitempty"`
+	XPassword                          *string `json:"x_password,omitempty"`
+	Expire                             *string `json:"expire,omitempty"`
+	ExpireNumber                       *int64  `json:"expire_number,omitempty"`
+	ExpireUnit                         *int64  `json:"expire_unit,omitempty"`
+	RedirectEnabled                    *bool   `json:"redirect_enabled,omitempty"`
+	RedirectURL                        *string `json:"redirect_url,omitempty"`
+	PortalCustomized                   *bool   `json:"portal_customized,omitempty"`
+	PortalCustomizedTitle              *string `json:"portal_customized_title,omitempty"`
+	PortalCustomizedWelcomeText        *string `json:"portal_customized_welcome_text,omitempty"`
+	PortalCustomizedWelcomeTextEnabled *bool   `json:"portal_customized_welcome_text_enabled,omitempty"`
+	PortalCustomizedTos                *string `json:"portal_customized_tos,omitempty"`
+	PortalCustomizedTosEnabled         *bool   `json:"portal_customized_tos_enabled,omitempty"`
+	PortalCustomizedBgColor            *string `json:"portal_customized_bg_color,omitempty"`
+	PortalCustomizedButtonColor        *string `json:"portal_customized_button_color,omitempty"`
+}
+
+func (r *settingGuestAccessResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_guest_access"
+}
+
+// settingStringAttribute is the string counterpart of settingBoolAttribute.
+func settingStringAttribute(description string, validators ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		Validators:          validators,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *settingGuestAccessResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the guest hotspot portal of a UniFi site: how guests authenticate, how long " +
+			"they stay authorized, where they are redirected, and the portal's text and colors. The portal " +
+			"applies to WLANs with `application = \"hotspot\"`. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the guest access setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"portal_enabled": settingBoolAttribute("Whether guests are sent to the hotspot portal before they get " +
+				"network access."),
+
+			"auth": settingStringAttribute("How guests authenticate: `none` (click through), `hotspot` "+
+				"(vouchers or a password, see `voucher_enabled` and `password_enabled`) or `custom` (an external "+
+				"portal at `custom_portal_ip`).",
+				stringvalidator.OneOf("none", "hotspot", "custom"),
+			),
+
+			"custom_portal_ip": settingStringAttribute("IPv4 address of the external portal server. "+
+				"Required when `auth` is `custom`.",
+				stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+			),
+
+			"voucher_enabled": settingBoolAttribute("Whether guests can authenticate with a voucher code. Used when " +
+				"`auth` is `hotspot`."),
+
+			"password_enabled": settingBoolAttribute("Whether guests can authenticate with `password`. Used when " +
+				"`auth` is `hotspot`."),
+
+			"password": schema.StringAttribute{
+				MarkdownDescription: "The password guests enter on the portal. Required when `password_enabled` " +
+					"is `true`.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"expire_minutes": schema.Int64Attribute{
+				MarkdownDescription: "How long, in minutes, a guest stays authorized (e.g. `480` for 8 hours, " +
+					"`1440` for a day).",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000000),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"redirect_enabled": settingBoolAttribute("Whether authorized guests are redirected to `redirect_url` " +
+				"instead of the page they originally requested."),
+
+			"redirect_url": settingStringAttribute("The URL authorized guests are redirected to. Required "+
+				"when `redirect_enabled` is `true`.",
+				stringvalidator.RegexMatches(informURLRegexp, "must be an http:// or https:// URL"),
+			),
+
+			"portal_customized": settingBoolAttribute("Whether the portal uses the `portal_*` text and colors " +
+				"below instead of the default page."),
+
+			"portal_title": settingStringAttribute("The title shown on the portal.",
+				stringvalidator.LengthAtLeast(1),
+			),
+
+			"portal_welcome_text": settingStringAttribute("Welcome text shown on the portal. Set to `\"\"` " +
+				"to hide it.",
+			),
+
+			"portal_terms_of_service": settingStringAttribute("Terms of service guests must accept. Set " +
+				"to `\"\"` to remove them.",
+			),
+
+			"portal_background_color": settingStringAttribute("Background color of the portal, as a hex "+
+				"color (e.g. `#1a1a1a`).",
+				stringvalidator.RegexMatches(ledColorRegexp, "must be a hex color like #1a1a1a"),
+			),
+
+			"portal_button_color": settingStringAttribute("Color of the portal's buttons, as a hex color.",
+				stringvalidator.RegexMatches(ledColorRegexp, "must be a hex color like #1a1a1a"),
+			),
+		},
+	}
+}
+
+// ConfigValidators requires the values that the chosen authentication and
+// redirect options depend on.
+func (r *settingGuestAccessResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		settingGuestAccessDependencyValidator{},
+	}
+}
+
+func (r *settingGuestAccessResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingGuestAccessResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingGuestAccessResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingGuestAccess(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Guest Access Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "guest_access", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Guest Access Setting", err.Error())
+		return
+	}
+
+	guestAccess, err := r.client.GetSettingGuestAccess(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Guest Access Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(guestAccess, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingGuestAccessResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingGuestAccessResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	guestAccess, err := r.client.GetSettingGuestAccess(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Guest Access Setting",
+			fmt.Sprintf("Could not read guest access setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(guestAccess, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingGuestAccessResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingGuestAccessResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "guest_access", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Guest Access Setting", err.Error())
+		return
+	}
+
+	guestAccess, err := r.client.GetSettingGuestAccess(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Guest Access Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(guestAccess, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingGuestAccessResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing guest access setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingGuestAccessResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingGuestAccessResource) modelToAPI(m *settingGuestAccessResourceModel) settingGuestAccessPayload {
+	payload := settingGuestAccessPayload{
+		PortalEnabled:               optionalBool(m.PortalEnabled),
+		Auth:                        optionalString(m.Auth),
+		CustomIP:                    optionalString(m.CustomPortalIP),
+		VoucherEnabled:              optionalBool(m.VoucherEnabled),
+		PasswordEnabled:             optionalBool(m.PasswordEnabled),
+		XPassword:                   optionalString(m.Password),
+		RedirectEnabled:             optionalBool(m.RedirectEnabled),
+		RedirectURL:                 optionalString(m.RedirectURL),
+		PortalCustomized:            optionalBool(m.PortalCustomized),
+		PortalCustomizedTitle:       optionalString(m.PortalTitle),
+		PortalCustomizedWelcomeText: optionalString(m.PortalWelcomeText),
+		PortalCustomizedTos:         optionalString(m.PortalTermsOfService),
+		PortalCustomizedBgColor:     optionalString(m.PortalBackgroundColor),
+		PortalCustomizedButtonColor: optionalString(m.PortalButtonColor),
+	}
+
+	// The portal shows the welcome text and terms of service when their
+	// toggles are on, so the toggles follow whether the text is set.
+	if payload.PortalCustomizedWelcomeText != nil {
+		v := *payload.PortalCustomizedWelcomeText != ""
+		payload.PortalCustomizedWelcomeTextEnabled = &v
+	}
+	if payload.PortalCustomizedTos != nil {
+		v := *payload.PortalCustomizedTos != ""
+		payload.PortalCustomizedTosEnabled = &v
+	}
+
+	if !m.ExpireMinutes.IsNull() && !m.ExpireMinutes.IsUnknown() {
+		// Sent as a custom duration in the largest unit that divides it, which
+		// is how the UniFi UI displays it.
+		minutes := m.ExpireMinutes.ValueInt64()
+		expire := "custom"
+		for _, unit := range guestAccessExpireUnits {
+			if minutes%unit == 0 {
+				number := minutes / unit
+				payload.Expire = &expire
+				payload.ExpireNumber = &number
+				payload.ExpireUnit = &unit
+				break
+			}
+		}
+	}
+
+	return payload
+}
+
+func (r *settingGuestAccessResource) apiToModel(s *settings.GuestAccess, m *settingGuestAccessResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.PortalEnabled = types.BoolValue(s.PortalEnabled)
+	m.Auth = stringValueOrNull(s.Auth)
+	m.CustomPortalIP = stringValueOrNull(s.CustomIP)
+	m.VoucherEnabled = types.BoolValue(s.VoucherEnabled)
+	m.PasswordEnabled = types.BoolValue(s.PasswordEnabled)
+	m.Password = stringValueOrNull(s.XPassword)
+	m.ExpireMinutes = guestAccessExpireMinutes(s)
+	m.RedirectEnabled = types.BoolValue(s.RedirectEnabled)
+	m.RedirectURL = stringValueOrNull(s.RedirectUrl)
+	m.PortalCustomized = types.BoolValue(s.PortalCustomized)
+	m.PortalTitle = stringValueOrNull(s.PortalCustomizedTitle)
+	m.PortalBackgroundColor = stringValueOrNull(s.PortalCustomizedBgColor)
+	m.PortalButtonColor = stringValueOrNull(s.PortalCustomizedButtonColor)
+
+	// Text hidden by its toggle is reported as empty, matching what guests see.
+	m.PortalWelcomeText = types.StringValue("")
+	if s.PortalCustomizedWelcomeTextEnabled {
+		m.PortalWelcomeText = types.StringValue(s.PortalCustomizedWelcomeText)
+	}
+	m.PortalTermsOfService = types.StringValue("")
+	if s.PortalCustomizedTosEnabled {
+		m.PortalTermsOfService = types.StringValue(s.PortalCustomizedTos)
+	}
+}
+
+// guestAccessExpireMinutes returns the guest authorization duration in
+// minutes. The controller stores either a preset number of minutes in
+// expire, or "custom" with a number and unit.
+func guestAccessExpireMinutes(s *settings.GuestAccess) types.Int64 {
+	if s.Expire == "custom" {
+		if s.ExpireNumber == nil || s.ExpireUnit == nil {
+			return types.Int64Null()
+		}
+		return types.Int64Value(*s.ExpireNumber * *s.ExpireUnit)
+	}
+	minutes, err := strconv.ParseInt(s.Expire, 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(minutes)
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// settingGuestAccessDependencyValidator rejects options that would leave the
+// portal without the value they depend on: a custom portal without its
+// address, password authentication without a password, and a redirect
+// without a URL.
+type settingGuestAccessDependencyValidator struct{}
+
+func (v settingGuestAccessDependencyValidator) Description(_ context.Context) string {
+	return "custom_portal_ip, password and redirect_url are required by the options that use them"
+}
+
+func (v settingGuestAccessDependencyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v settingGuestAccessDependencyValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var auth, customIP, password, redirectURL types.String
+	var passwordEnabled, redirectEnabled types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth"), &auth)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("custom_portal_ip"), &customIP)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password_enabled"), &passwordEnabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("redirect_enabled"), &redirectEnabled)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("redirect_url"), &redirectURL)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if auth.ValueString() == "custom" && customIP.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("custom_portal_ip"),
+			"Missing Custom Portal IP",
+			"custom_portal_ip is required when auth is \"custom\".",
+		)
+	}
+	if passwordEnabled.ValueBool() && password.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Guest Password",
+			"password is required when password_enabled is true.",
+		)
+	}
+	if redirectEnabled.ValueBool() && redirectURL.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("redirect_url"),
+			"Missing Redirect URL",
+			"redirect_url is required when redirect_enabled is true.",
+		)
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingGuestAccessModelToAPI(t *testing.T) {
+	r := &settingGuestAccessResource{}
+
+	t.Run("hotspot with password", func(t *testing.T) {
+		payload := r.modelToAPI(&settingGuestAccessResourceModel{
+			PortalEnabled:        types.BoolValue(true),
+			Auth:                 types.StringValue("hotspot"),
+			VoucherEnabled:       types.BoolValue(false),
+			PasswordEnabled:      types.BoolValue(true),
+			Password:             types.StringValue("welcome"),
+			RedirectURL:          types.StringValue("https://example.com"),
+			PortalWelcomeText:    types.StringValue("Hello"),
+			PortalTermsOfService: types.StringValue(""),
+		})
+
+		assert.True(t, *payload.PortalEnabled)
+		assert.Equal(t, "hotspot", *payload.Auth)
+		require.NotNil(t, payload.VoucherEnabled)
+		assert.False(t, *payload.VoucherEnabled)
+		assert.Equal(t, "welcome", *payload.XPassword)
+		assert.Equal(t, "https://example.com", *payload.RedirectURL)
+		assert.True(t, *payload.PortalCustomizedWelcomeTextEnabled)
+		// An empty text turns its toggle off.
+		assert.False(t, *payload.PortalCustomizedTosEnabled)
+		assert.Nil(t, payload.PortalCustomized)
+		assert.Nil(t, payload.Expire)
+	})
+
+	for _, tc := range []struct {
+		minutes, number, unit int64
+	}{
+		{480, 8, 60},
+		{2880, 2, 1440},
+		{90, 90, 1},
+	} {
+		t.Run(fmt.Sprintf("expire %d minutes", tc.minutes), func(t *testing.T) {
+			payload := r.modelToAPI(&settingGuestAccessResourceModel{
+				ExpireMinutes: types.Int64Value(tc.minutes),
+			})
+
+			assert.Equal(t, "custom", *payload.Expire)
+			assert.Equal(t, tc.number, *payload.ExpireNumber)
+			assert.Equal(t, tc.unit, *payload.ExpireUnit)
+		})
+	}
+}
+
+func TestSettingGuestAccessAPIToModel(t *testing.T) {
+	r := &settingGuestAccessResource{}
+	number, unit := int64(3), int64(1440)
+
+	s := &settings.GuestAccess{
+		BaseSetting:                        settings.BaseSetting{ID: "set-3", Key: "guest_access"},
+		PortalEnabled:                      true,
+		Auth:                               "hotspot",
+		VoucherEnabled:                     true,
+		XPassword:                          "welcome",
+		Expire:                             "custom",
+		ExpireNumber:                       &number,
+		ExpireUnit:                         &unit,
+		PortalCustomizedTitle:              "Guest WiFi",
+		PortalCustomizedWelcomeText:        "Hello",
+		PortalCustomizedWelcomeTextEnabled: true,
+		PortalCustomizedTos:                "Be nice",
+		PortalCustomizedBgColor:            "#1a1a1a",
+	}
+
+	var m settingGuestAccessResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-3", m.ID.ValueString())
+	assert.True(t, m.PortalEnabled.ValueBool())
+	assert.Equal(t, "hotspot", m.Auth.ValueString())
+	assert.True(t, m.VoucherEnabled.ValueBool())
+	assert.Equal(t, "welcome", m.Password.ValueString())
+	assert.Equal(t, int64(4320), m.ExpireMinutes.ValueInt64())
+	assert.Equal(t, "Guest WiFi", m.PortalTitle.ValueString())
+	assert.Equal(t, "Hello", m.PortalWelcomeText.ValueString())
+	// Terms of service with their toggle off are not shown to guests.
+	assert.Equal(t, "", m.PortalTermsOfService.ValueString())
+	assert.Equal(t, "#1a1a1a", m.PortalBackgroundColor.ValueString())
+	assert.True(t, m.RedirectURL.IsNull())
+	assert.True(t, m.CustomPortalIP.IsNull())
+}
+
+func TestGuestAccessExpireMinutes(t *testing.T) {
+	assert.Equal(t, int64(480), guestAccessExpireMinutes(&settings.GuestAccess{Expire: "480"}).ValueInt64())
+	assert.True(t, guestAccessExpireMinutes(&settings.GuestAccess{}).IsNull())
+	assert.True(t, guestAccessExpireMinutes(&settings.GuestAccess{Expire: "custom"}).IsNull())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingGuestAccess_update(t *testing.T) {
+	config := func(minutes int, title string) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_guest_access" "test" {
+  portal_enabled    = true
+  auth              = "hotspot"
+  voucher_enabled   = true
+  password_enabled  = false
+  expire_minutes    = %d
+  portal_customized = true
+  portal_title      = %q
+}
+`, minutes, title)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(480, "tfacc guest portal"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_guest_access.test", "auth", "hotspot"),
+					resource.TestCheckResourceAttr("terrifi_setting_guest_access.test", "expire_minutes", "480"),
+					resource.TestCheckResourceAttr("terrifi_setting_guest_access.test", "portal_title", "tfacc guest portal"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_guest_access.test", "id"),
+				),
+			},
+			{
+				Config: config(1440, "tfacc guest portal 2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_guest_access.test", "expire_minutes", "1440"),
+					resource.TestCheckResourceAttr("terrifi_setting_guest_access.test", "portal_title", "tfacc guest portal 2"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(1440, "tfacc guest portal 2"),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:            "terrifi_setting_guest_access.test",
+				ImportState:             true,
+				ImportStateId:           "default",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+	})
+}
+
+func TestAccSettingGuestAccess_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_guest_access" "test" {
+  auth             = "hotspot"
+  password_enabled = true
+}
+`,
+				ExpectError: regexp.MustCompile(`password is required`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_guest_access" "test" {
+  auth = "custom"
+}
+`,
+				ExpectError: regexp.MustCompile(`custom_portal_ip is required`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_guest_access" "test" {
+  redirect_enabled = true
+}
+`,
+				ExpectError: regexp.MustCompile(`redirect_url is required`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_guest_access" "test" {
+  portal_background_color = "black"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be a hex color`),
+			},
+		},
+	})
+}
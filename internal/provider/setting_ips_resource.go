@@ -0,0 +1,489 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingIPSResource{}
+	_ resource.ResourceWithImportState = &settingIPSResource{}
+)
+
+func NewSettingIPSResource() resource.Resource {
+	return &settingIPSResource{}
+}
+
+type settingIPSResource struct {
+	client ClientAPI
+}
+
+type settingIPSResourceModel struct {
+	ID        types.String               `tfsdk:"id"`
+	Site      types.String               `tfsdk:"site"`
+	IPSMode   types.String               `tfsdk:"ips_mode"`
+	Allowlist []settingIPSAllowlistModel `tfsdk:"allowlist"`
+}
+
+// settingIPSAllowlistModel is one allowlist (suppression whitelist) entry.
+// Exactly one of IP, Subnet, NetworkID and ClientDeviceID is set.
+type settingIPSAllowlistModel struct {
+	Direction      types.String `tfsdk:"direction"`
+	IP             types.String `tfsdk:"ip"`
+	Subnet         types.String `tfsdk:"subnet"`
+	NetworkID      types.String `tfsdk:"network_id"`
+	ClientDeviceID types.String `tfsdk:"client_device_id"`
+}
+
+// settingIPSPayload is the body for PUT set/setting/ips. The controller
+// merges top-level fields but replaces the suppression object as a whole, so
+// the alert suppressions read from the controller are sent back unchanged.
+type settingIPSPayload struct {
+	IPSMode     string                 `json:"ips_mode,omitempty"`
+	Suppression *settingIPSSuppression `json:"suppression,omitempty"`
+}
+
+type settingIPSSuppression struct {
+	Alerts    []settings.SettingIpsAlerts    `json:"alerts"`
+	Whitelist []settings.SettingIpsWhitelist `json:"whitelist"`
+}
+
+func (r *settingIPSResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_ips"
+}
+
+func (r *settingIPSResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the intrusion prevention (IPS/IDS) mode and allowlist of a UniFi site. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the IPS setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"ips_mode": schema.StringAttribute{
+				MarkdownDescription: "The detection mode: `ids` (detect and alert), `ips` (detect and block), " +
+					"`ipsInline` (inline blocking on gateways that support it) or `disabled`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ids", "ips", "ipsInline", "disabled"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"allowlist": schema.SetNestedAttribute{
+				MarkdownDescription: "Traffic that is never flagged or blocked. When set, it replaces the whole " +
+					"allowlist on the controller; an empty set clears it.",
+				Optional: true,
+				NestedObject: schema.NestedAttributeObject{
+					Validators: []validator.Object{settingIPSAllowlistEntryValidator{}},
+					Attributes: map[string]schema.Attribute{
+						"direction": schema.StringAttribute{
+							MarkdownDescription: "Which side of a connection the entry matches: `src`, `dest` or `both`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("src", "dest", "both"),
+							},
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "An IPv4 address.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+							},
+						},
+						"subnet": schema.StringAttribute{
+							MarkdownDescription: "An IPv4 subnet in CIDR notation (e.g. `192.168.10.0/24`).",
+							Optional:            true,
+						},
+						"network_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of a network (e.g. `terrifi_network.iot.id`). Matches the " +
+								"network's current subnet.",
+							Optional: true,
+						},
+						"client_device_id": schema.StringAttribute{
+							MarkdownDescription: "The ID of a client device (e.g. `terrifi_client_device.nas.id`). " +
+								"The client must have a fixed IP; the provider sends that address, looked up at " +
+								"apply time. If the client's fixed IP changes, the next plan shows the entry as " +
+								"changed so that applying updates the allowlist.",
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *settingIPSResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingIPSResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingIPSResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingIps(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading IPS Setting", err.Error())
+		return
+	}
+
+	r.apply(ctx, site, existing, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingIPSResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingIPSResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	ips, err := r.client.GetSettingIps(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading IPS Setting",
+			fmt.Sprintf("Could not read IPS setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	// Client devices that can't be resolved are left out, so their entries
+	// show up as changed and the next apply reports why.
+	clientIPs, _ := r.resolveClientIPs(ctx, site, state.Allowlist)
+	r.apiToModel(ips, &state, site, clientIPs)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingIPSResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingIPSResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	existing, err := r.client.GetSettingIps(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading IPS Setting", err.Error())
+		return
+	}
+
+	plan.Site = state.Site
+	r.apply(ctx, site, existing, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingIPSResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing IPS setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingIPSResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// settingIPSAllowlistEntryValidator ensures each allowlist entry names
+// exactly one target and that a subnet is valid CIDR.
+type settingIPSAllowlistEntryValidator struct{}
+
+func (v settingIPSAllowlistEntryValidator) Description(_ context.Context) string {
+	return "Exactly one of ip, subnet, network_id and client_device_id must be set."
+}
+
+func (v settingIPSAllowlistEntryValidator) MarkdownDescription(_ context.Context) string {
+	return "Exactly one of `ip`, `subnet`, `network_id` and `client_device_id` must be set."
+}
+
+func (v settingIPSAllowlistEntryValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var entry settingIPSAllowlistModel
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &entry, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := 0
+	for _, v := range []types.String{entry.IP, entry.Subnet, entry.NetworkID, entry.ClientDeviceID} {
+		// An unknown value (e.g. the ID of a resource not created yet) counts
+		// as set.
+		if !v.IsNull() {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Allowlist Entry",
+			"Each allowlist entry needs exactly one of ip, subnet, network_id and client_device_id.",
+		)
+		return
+	}
+
+	if !entry.Subnet.IsNull() && !entry.Subnet.IsUnknown() {
+		if _, _, err := net.ParseCIDR(entry.Subnet.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtName("subnet"),
+				"Invalid Allowlist Subnet",
+				fmt.Sprintf("%q is not a subnet in CIDR notation.", entry.Subnet.ValueString()),
+			)
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// apply sends the planned values, re-reads the setting and stores the result
+// in plan.
+func (r *settingIPSResource) apply(ctx context.Context, site string, existing *settings.Ips, plan *settingIPSResourceModel, diags *diag.Diagnostics) {
+	clientIPs, err := r.resolveClientIPs(ctx, site, plan.Allowlist)
+	if err != nil {
+		diags.AddError("Error Resolving IPS Allowlist", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "ips", existing.ID, r.modelToAPI(plan, existing, clientIPs))
+	if err != nil {
+		diags.AddError("Error Updating IPS Setting", err.Error())
+		return
+	}
+
+	ips, err := r.client.GetSettingIps(ctx, site)
+	if err != nil {
+		diags.AddError("Error Reading IPS Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(ips, plan, site, clientIPs)
+}
+
+// resolveClientIPs looks up the fixed IP of every client device referenced by
+// the allowlist, keyed by client device ID. The first failure is returned
+// along with the addresses resolved so far.
+func (r *settingIPSResource) resolveClientIPs(ctx context.Context, site string, allowlist []settingIPSAllowlistModel) (map[string]string, error) {
+	clientIPs := map[string]string{}
+	for _, e := range allowlist {
+		if e.ClientDeviceID.IsNull() || e.ClientDeviceID.IsUnknown() {
+			continue
+		}
+		id := e.ClientDeviceID.ValueString()
+		if _, ok := clientIPs[id]; ok {
+			continue
+		}
+		client, err := r.client.GetClientDevice(ctx, site, id)
+		if err != nil {
+			return clientIPs, fmt.Errorf("reading client device %s: %w", id, err)
+		}
+		if !client.UseFixedIP || client.FixedIP == "" {
+			return clientIPs, fmt.Errorf("client device %s (%s) has no fixed IP, so it can't be allowlisted "+
+				"by reference: set fixed_ip on it, or use ip instead", id, client.MAC)
+		}
+		clientIPs[id] = client.FixedIP
+	}
+	return clientIPs, nil
+}
+
+func (r *settingIPSResource) modelToAPI(m *settingIPSResourceModel, existing *settings.Ips, clientIPs map[string]string) settingIPSPayload {
+	var payload settingIPSPayload
+	if !m.IPSMode.IsNull() && !m.IPSMode.IsUnknown() {
+		payload.IPSMode = m.IPSMode.ValueString()
+	}
+	if m.Allowlist == nil {
+		return payload
+	}
+
+	suppression := &settingIPSSuppression{
+		Alerts:    []settings.SettingIpsAlerts{},
+		Whitelist: []settings.SettingIpsWhitelist{},
+	}
+	if existing.Suppression != nil && existing.Suppression.Alerts != nil {
+		suppression.Alerts = existing.Suppression.Alerts
+	}
+	for _, e := range m.Allowlist {
+		entry := settings.SettingIpsWhitelist{Direction: e.Direction.ValueString()}
+		switch {
+		case !e.IP.IsNull():
+			entry.Mode, entry.Value = "ip", e.IP.ValueString()
+		case !e.Subnet.IsNull():
+			entry.Mode, entry.Value = "subnet", e.Subnet.ValueString()
+		case !e.NetworkID.IsNull():
+			entry.Mode, entry.Value = "network", e.NetworkID.ValueString()
+		case !e.ClientDeviceID.IsNull():
+			entry.Mode, entry.Value = "ip", clientIPs[e.ClientDeviceID.ValueString()]
+		}
+		suppression.Whitelist = append(suppression.Whitelist, entry)
+	}
+	payload.Suppression = suppression
+	return payload
+}
+
+// apiToModel stores the controller's setting in m. The allowlist is only
+// tracked when m already manages it (m.Allowlist is non-nil). An address
+// entry is reported as the client device entry of m that resolved to it, so
+// that references survive the round trip; clientIPs maps client device IDs
+// to their current fixed IPs.
+func (r *settingIPSResource) apiToModel(s *settings.Ips, m *settingIPSResourceModel, site string, clientIPs map[string]string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.IPSMode = stringValueOrNull(s.IPsMode)
+
+	if m.Allowlist == nil {
+		return
+	}
+
+	var whitelist []settings.SettingIpsWhitelist
+	if s.Suppression != nil {
+		whitelist = s.Suppression.Whitelist
+	}
+
+	// Client device entries of m that are still waiting for a matching
+	// address entry from the controller.
+	pending := map[string]settingIPSAllowlistModel{}
+	for _, e := range m.Allowlist {
+		if !e.ClientDeviceID.IsNull() && !e.ClientDeviceID.IsUnknown() {
+			if ip, ok := clientIPs[e.ClientDeviceID.ValueString()]; ok {
+				pending[e.Direction.ValueString()+"/"+ip] = e
+			}
+		}
+	}
+
+	allowlist := make([]settingIPSAllowlistModel, 0, len(whitelist))
+	for _, w := range whitelist {
+		if w.Mode == "ip" {
+			if e, ok := pending[w.Direction+"/"+w.Value]; ok {
+				delete(pending, w.Direction+"/"+w.Value)
+				allowlist = append(allowlist, e)
+				continue
+			}
+		}
+
+		entry := settingIPSAllowlistModel{
+			Direction:      types.StringValue(w.Direction),
+			IP:             types.StringNull(),
+			Subnet:         types.StringNull(),
+			NetworkID:      types.StringNull(),
+			ClientDeviceID: types.StringNull(),
+		}
+		switch w.Mode {
+		case "subnet":
+			entry.Subnet = types.StringValue(w.Value)
+		case "network":
+			entry.NetworkID = types.StringValue(w.Value)
+		default:
+			entry.IP = types.StringValue(w.Value)
+		}
+		allowlist = append(allowlist, entry)
+	}
+	m.Allowlist = allowlist
+}
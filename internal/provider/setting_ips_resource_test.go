@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func allowlistEntry(direction, field, value string) settingIPSAllowlistModel {
+	e := settingIPSAllowlistModel{
+		Direction:      types.StringValue(direction),
+		IP:             types.StringNull(),
+		Subnet:         types.StringNull(),
+		NetworkID:      types.StringNull(),
+		ClientDeviceID: types.StringNull(),
+	}
+	switch field {
+	case "ip":
+		e.IP = types.StringValue(value)
+	case "subnet":
+		e.Subnet = types.StringValue(value)
+	case "network_id":
+		e.NetworkID = types.StringValue(value)
+	case "client_device_id":
+		e.ClientDeviceID = types.StringValue(value)
+	}
+	return e
+}
+
+func TestSettingIPSModelToAPI(t *testing.T) {
+	r := &settingIPSResource{}
+	existing := &settings.Ips{
+		Suppression: &settings.SettingIpsSuppression{
+			Alerts: []settings.SettingIpsAlerts{{Category: "emerging-scan", Type: "all"}},
+		},
+	}
+
+	t.Run("allowlist entries and references", func(t *testing.T) {
+		payload := r.modelToAPI(&settingIPSResourceModel{
+			IPSMode: types.StringValue("ips"),
+			Allowlist: []settingIPSAllowlistModel{
+				allowlistEntry("both", "ip", "192.168.1.10"),
+				allowlistEntry("src", "subnet", "192.168.20.0/24"),
+				allowlistEntry("dest", "network_id", "net-iot"),
+				allowlistEntry("both", "client_device_id", "client-nas"),
+			},
+		}, existing, map[string]string{"client-nas": "192.168.1.20"})
+
+		assert.Equal(t, "ips", payload.IPSMode)
+		require.NotNil(t, payload.Suppression)
+		assert.Equal(t, existing.Suppression.Alerts, payload.Suppression.Alerts, "alert suppressions are kept")
+		assert.Equal(t, []settings.SettingIpsWhitelist{
+			{Direction: "both", Mode: "ip", Value: "192.168.1.10"},
+			{Direction: "src", Mode: "subnet", Value: "192.168.20.0/24"},
+			{Direction: "dest", Mode: "network", Value: "net-iot"},
+			{Direction: "both", Mode: "ip", Value: "192.168.1.20"},
+		}, payload.Suppression.Whitelist)
+	})
+
+	t.Run("unmanaged allowlist is not sent", func(t *testing.T) {
+		payload := r.modelToAPI(&settingIPSResourceModel{IPSMode: types.StringUnknown()}, existing, nil)
+		assert.Empty(t, payload.IPSMode)
+		assert.Nil(t, payload.Suppression)
+	})
+
+	t.Run("empty allowlist clears it", func(t *testing.T) {
+		payload := r.modelToAPI(&settingIPSResourceModel{Allowlist: []settingIPSAllowlistModel{}}, &settings.Ips{}, nil)
+		require.NotNil(t, payload.Suppression)
+		assert.NotNil(t, payload.Suppression.Whitelist)
+		assert.Empty(t, payload.Suppression.Whitelist)
+		assert.NotNil(t, payload.Suppression.Alerts)
+	})
+}
+
+func TestSettingIPSAPIToModel(t *testing.T) {
+	r := &settingIPSResource{}
+	s := &settings.Ips{
+		BaseSetting: settings.BaseSetting{ID: "set-ips", Key: "ips"},
+		IPsMode:     "ids",
+		Suppression: &settings.SettingIpsSuppression{
+			Whitelist: []settings.SettingIpsWhitelist{
+				{Direction: "both", Mode: "ip", Value: "192.168.1.20"},
+				{Direction: "src", Mode: "subnet", Value: "192.168.20.0/24"},
+				{Direction: "dest", Mode: "network", Value: "net-iot"},
+			},
+		},
+	}
+
+	t.Run("client device references survive the round trip", func(t *testing.T) {
+		m := settingIPSResourceModel{Allowlist: []settingIPSAllowlistModel{
+			allowlistEntry("both", "client_device_id", "client-nas"),
+		}}
+		r.apiToModel(s, &m, "default", map[string]string{"client-nas": "192.168.1.20"})
+
+		assert.Equal(t, "set-ips", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "ids", m.IPSMode.ValueString())
+		assert.ElementsMatch(t, []settingIPSAllowlistModel{
+			allowlistEntry("both", "client_device_id", "client-nas"),
+			allowlistEntry("src", "subnet", "192.168.20.0/24"),
+			allowlistEntry("dest", "network_id", "net-iot"),
+		}, m.Allowlist)
+	})
+
+	t.Run("changed client IP shows as a plain address", func(t *testing.T) {
+		m := settingIPSResourceModel{Allowlist: []settingIPSAllowlistModel{
+			allowlistEntry("both", "client_device_id", "client-nas"),
+		}}
+		r.apiToModel(s, &m, "default", map[string]string{"client-nas": "192.168.1.99"})
+
+		assert.Contains(t, m.Allowlist, allowlistEntry("both", "ip", "192.168.1.20"))
+		assert.NotContains(t, m.Allowlist, allowlistEntry("both", "client_device_id", "client-nas"))
+	})
+
+	t.Run("unmanaged allowlist stays null", func(t *testing.T) {
+		var m settingIPSResourceModel
+		r.apiToModel(s, &m, "default", nil)
+		assert.Nil(t, m.Allowlist)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingIPS_allowlist(t *testing.T) {
+	mac := randomMAC()
+	netName := fmt.Sprintf("tfacc-ips-%s", randomSuffix())
+	vlan := randomVLAN()
+	third := vlan % 256
+	config := func(host int) string {
+		return fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name         = %q
+  purpose      = "corporate"
+  vlan_id      = %d
+  subnet       = "10.%d.0.1/24"
+  dhcp_enabled = true
+}
+
+resource "terrifi_client_device" "test" {
+  mac        = %q
+  name       = "tfacc-ips"
+  fixed_ip   = "10.%d.0.%d"
+  network_id = terrifi_network.test.id
+}
+
+resource "terrifi_setting_ips" "test" {
+  allowlist = [
+    { direction = "both", network_id = terrifi_network.test.id },
+    { direction = "src", client_device_id = terrifi_client_device.test.id },
+  ]
+}
+`, netName, vlan, third, mac, third, host)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(100),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_ips.test", "allowlist.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"terrifi_setting_ips.test", "allowlist.*.network_id",
+						"terrifi_network.test", "id",
+					),
+					resource.TestCheckTypeSetElemAttrPair(
+						"terrifi_setting_ips.test", "allowlist.*.client_device_id",
+						"terrifi_client_device.test", "id",
+					),
+					resource.TestCheckResourceAttrSet("terrifi_setting_ips.test", "ips_mode"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(100),
+				PlanOnly: true,
+			},
+			// The allowlist is refreshed before the client's fixed IP changes,
+			// so the new address is only picked up by the following apply.
+			{
+				Config:             config(101),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(101),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_ips.test", "allowlist.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair(
+						"terrifi_setting_ips.test", "allowlist.*.client_device_id",
+						"terrifi_client_device.test", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSettingIPS_validationAllowlistEntry(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_ips" "test" {
+  allowlist = [
+    { direction = "both", ip = "192.168.1.10", subnet = "192.168.1.0/24" },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`exactly one of ip, subnet, network_id and\s+client_device_id`),
+			},
+		},
+	})
+}
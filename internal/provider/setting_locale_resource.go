@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingLocaleResource{}
+	_ resource.ResourceWithImportState = &settingLocaleResource{}
+)
+
+func NewSettingLocaleResource() resource.Resource {
+	return &settingLocaleResource{}
+}
+
+type settingLocaleResource struct {
+	client ClientAPI
+}
+
+type settingLocaleResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	Site     types.String `tfsdk:"site"`
+	Timezone types.String `tfsdk:"timezone"`
+}
+
+// settingLocalePayload is the body for PUT set/setting/locale.
+type settingLocalePayload struct {
+	Timezone string `json:"timezone"`
+}
+
+func (r *settingLocaleResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_locale"
+}
+
+func (r *settingLocaleResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the locale of a UniFi site. The timezone is used for schedules (firewall " +
+			"policies, WLAN schedules) and log timestamps, so it should be managed alongside anything time-based. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the locale setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "The [IANA timezone](https://en.wikipedia.org/wiki/List_of_tz_database_time_zones) " +
+					"name (e.g. `America/New_York`, `Europe/Berlin`, `UTC`).",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingLocaleResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingLocaleResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingLocaleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingLocale(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Locale Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "locale", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Locale Setting", err.Error())
+		return
+	}
+
+	locale, err := r.client.GetSettingLocale(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Locale Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(locale, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingLocaleResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingLocaleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	locale, err := r.client.GetSettingLocale(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Locale Setting",
+			fmt.Sprintf("Could not read locale setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(locale, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingLocaleResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingLocaleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "locale", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Locale Setting", err.Error())
+		return
+	}
+
+	locale, err := r.client.GetSettingLocale(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Locale Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(locale, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingLocaleResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing locale setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingLocaleResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingLocaleResource) modelToAPI(m *settingLocaleResourceModel) settingLocalePayload {
+	return settingLocalePayload{
+		Timezone: m.Timezone.ValueString(),
+	}
+}
+
+func (r *settingLocaleResource) apiToModel(s *settings.Locale, m *settingLocaleResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.Timezone = stringValueOrNull(s.Timezone)
+}
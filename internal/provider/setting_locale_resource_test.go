@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingLocaleModelToAPI(t *testing.T) {
+	r := &settingLocaleResource{}
+
+	payload := r.modelToAPI(&settingLocaleResourceModel{
+		Timezone: types.StringValue("America/New_York"),
+	})
+
+	assert.Equal(t, "America/New_York", payload.Timezone)
+}
+
+func TestSettingLocaleAPIToModel(t *testing.T) {
+	r := &settingLocaleResource{}
+
+	t.Run("timezone set", func(t *testing.T) {
+		s := &settings.Locale{
+			BaseSetting: settings.BaseSetting{ID: "set-2", Key: "locale"},
+			Timezone:    "Europe/Berlin",
+		}
+
+		var m settingLocaleResourceModel
+		r.apiToModel(s, &m, "default")
+
+		assert.Equal(t, "set-2", m.ID.ValueString())
+		assert.Equal(t, "Europe/Berlin", m.Timezone.ValueString())
+	})
+
+	t.Run("empty timezone is null", func(t *testing.T) {
+		var m settingLocaleResourceModel
+		r.apiToModel(&settings.Locale{}, &m, "default")
+
+		assert.True(t, m.Timezone.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingLocale_update(t *testing.T) {
+	config := func(tz string) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_locale" "test" {
+  timezone = %q
+}
+`, tz)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Europe/Berlin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_locale.test", "timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_locale.test", "id"),
+				),
+			},
+			{
+				Config: config("UTC"),
+				Check:  resource.TestCheckResourceAttr("terrifi_setting_locale.test", "timezone", "UTC"),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config("UTC"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingLocale_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_locale" "test" {
+  timezone = "UTC"
+}
+`,
+			},
+			{
+				ResourceName:      "terrifi_setting_locale.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingRadiusResource{}
+	_ resource.ResourceWithImportState = &settingRadiusResource{}
+)
+
+func NewSettingRadiusResource() resource.Resource {
+	return &settingRadiusResource{}
+}
+
+type settingRadiusResource struct {
+	client ClientAPI
+}
+
+type settingRadiusResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	Site                  types.String `tfsdk:"site"`
+	Enabled               types.Bool   `tfsdk:"enabled"`
+	Secret                types.String `tfsdk:"secret"`
+	AuthPort              types.Int64  `tfsdk:"auth_port"`
+	AccountingEnabled     types.Bool   `tfsdk:"accounting_enabled"`
+	AcctPort              types.Int64  `tfsdk:"acct_port"`
+	InterimUpdateInterval types.Int64  `tfsdk:"interim_update_interval"`
+	TunneledReply         types.Bool   `tfsdk:"tunneled_reply"`
+}
+
+// settingRadiusPayload is the body for PUT set/setting/radius. Optional
+// fields are pointers so that attributes left out of the config keep the
+// controller's current value, while an explicit false is still sent.
+type settingRadiusPayload struct {
+	Enabled               bool   `json:"enabled"`
+	XSecret               string `json:"x_secret,omitempty"`
+	AuthPort              *int64 `json:"auth_port,omitempty"`
+	AccountingEnabled     *bool  `json:"accounting_enabled,omitempty"`
+	AcctPort              *int64 `json:"acct_port,omitempty"`
+	InterimUpdateInterval *int64 `json:"interim_update_interval,omitempty"`
+	TunneledReply         *bool  `json:"tunneled_reply,omitempty"`
+}
+
+func (r *settingRadiusResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_radius"
+}
+
+func (r *settingRadiusResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the built-in RADIUS server of a UniFi site, used for WPA-Enterprise WLANs, " +
+			"802.1X port authentication, and VPN users. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the RADIUS setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the built-in RADIUS server is enabled.",
+				Required:            true,
+			},
+
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The shared secret RADIUS clients use to authenticate with the server. " +
+					"1–48 characters; spaces, quotes, and backslashes are not allowed.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[^\\"' ]{1,48}$`),
+						"must be 1-48 characters without spaces, quotes, or backslashes",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"auth_port": schema.Int64Attribute{
+				MarkdownDescription: "UDP port the server listens on for authentication requests. " +
+					"The controller default is `1812`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"accounting_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether RADIUS accounting is enabled.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"acct_port": schema.Int64Attribute{
+				MarkdownDescription: "UDP port the server listens on for accounting requests. " +
+					"The controller default is `1813`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"interim_update_interval": schema.Int64Attribute{
+				MarkdownDescription: "Interval in seconds at which clients send interim accounting updates " +
+					"(60–86400).",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(60, 86400),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"tunneled_reply": schema.BoolAttribute{
+				MarkdownDescription: "Whether the server returns tunnel attributes (e.g. the VLAN for dynamic " +
+					"VLAN assignment) in replies to tunneled EAP methods such as PEAP and TTLS.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingRadiusResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingRadiusResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingRadiusResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingRadius(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading RADIUS Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "radius", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating RADIUS Setting", err.Error())
+		return
+	}
+
+	radius, err := r.client.GetSettingRadius(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading RADIUS Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(radius, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingRadiusResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingRadiusResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	radius, err := r.client.GetSettingRadius(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading RADIUS Setting",
+			fmt.Sprintf("Could not read RADIUS setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(radius, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingRadiusResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingRadiusResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "radius", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating RADIUS Setting", err.Error())
+		return
+	}
+
+	radius, err := r.client.GetSettingRadius(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading RADIUS Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(radius, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingRadiusResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing RADIUS setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingRadiusResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingRadiusResource) modelToAPI(m *settingRadiusResourceModel) settingRadiusPayload {
+	payload := settingRadiusPayload{
+		Enabled: m.Enabled.ValueBool(),
+	}
+	if !m.Secret.IsNull() && !m.Secret.IsUnknown() {
+		payload.XSecret = m.Secret.ValueString()
+	}
+	if !m.AuthPort.IsNull() && !m.AuthPort.IsUnknown() {
+		v := m.AuthPort.ValueInt64()
+		payload.AuthPort = &v
+	}
+	if !m.AccountingEnabled.IsNull() && !m.AccountingEnabled.IsUnknown() {
+		v := m.AccountingEnabled.ValueBool()
+		payload.AccountingEnabled = &v
+	}
+	if !m.AcctPort.IsNull() && !m.AcctPort.IsUnknown() {
+		v := m.AcctPort.ValueInt64()
+		payload.AcctPort = &v
+	}
+	if !m.InterimUpdateInterval.IsNull() && !m.InterimUpdateInterval.IsUnknown() {
+		v := m.InterimUpdateInterval.ValueInt64()
+		payload.InterimUpdateInterval = &v
+	}
+	if !m.TunneledReply.IsNull() && !m.TunneledReply.IsUnknown() {
+		v := m.TunneledReply.ValueBool()
+		payload.TunneledReply = &v
+	}
+	return payload
+}
+
+func (r *settingRadiusResource) apiToModel(s *settings.Radius, m *settingRadiusResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.Enabled = types.BoolValue(s.Enabled)
+	m.AccountingEnabled = types.BoolValue(s.AccountingEnabled)
+	m.TunneledReply = types.BoolValue(s.TunneledReply)
+
+	if s.XSecret != "" {
+		m.Secret = types.StringValue(s.XSecret)
+	} else {
+		m.Secret = types.StringNull()
+	}
+	if s.AuthPort != nil {
+		m.AuthPort = types.Int64Value(*s.AuthPort)
+	} else {
+		m.AuthPort = types.Int64Null()
+	}
+	if s.AcctPort != nil {
+		m.AcctPort = types.Int64Value(*s.AcctPort)
+	} else {
+		m.AcctPort = types.Int64Null()
+	}
+	if s.InterimUpdateInterval != nil {
+		m.InterimUpdateInterval = types.Int64Value(*s.InterimUpdateInterval)
+	} else {
+		m.InterimUpdateInterval = types.Int64Null()
+	}
+}
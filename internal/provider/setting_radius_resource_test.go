@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingRadiusModelToAPI(t *testing.T) {
+	r := &settingRadiusResource{}
+
+	t.Run("configured values are sent", func(t *testing.T) {
+		payload := r.modelToAPI(&settingRadiusResourceModel{
+			Enabled:               types.BoolValue(true),
+			Secret:                types.StringValue("s3cret"),
+			AuthPort:              types.Int64Value(1812),
+			AccountingEnabled:     types.BoolValue(false),
+			AcctPort:              types.Int64Value(1813),
+			InterimUpdateInterval: types.Int64Value(3600),
+			TunneledReply:         types.BoolValue(true),
+		})
+
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "s3cret", payload.XSecret)
+		require.NotNil(t, payload.AuthPort)
+		assert.Equal(t, int64(1812), *payload.AuthPort)
+		// An explicit false must still be sent.
+		require.NotNil(t, payload.AccountingEnabled)
+		assert.False(t, *payload.AccountingEnabled)
+		require.NotNil(t, payload.AcctPort)
+		assert.Equal(t, int64(1813), *payload.AcctPort)
+		require.NotNil(t, payload.InterimUpdateInterval)
+		assert.Equal(t, int64(3600), *payload.InterimUpdateInterval)
+		require.NotNil(t, payload.TunneledReply)
+		assert.True(t, *payload.TunneledReply)
+	})
+
+	t.Run("unset values are omitted", func(t *testing.T) {
+		payload := r.modelToAPI(&settingRadiusResourceModel{
+			Enabled:               types.BoolValue(false),
+			Secret:                types.StringUnknown(),
+			AuthPort:              types.Int64Unknown(),
+			AccountingEnabled:     types.BoolUnknown(),
+			AcctPort:              types.Int64Null(),
+			InterimUpdateInterval: types.Int64Null(),
+			TunneledReply:         types.BoolNull(),
+		})
+
+		assert.False(t, payload.Enabled)
+		assert.Empty(t, payload.XSecret)
+		assert.Nil(t, payload.AuthPort)
+		assert.Nil(t, payload.AccountingEnabled)
+		assert.Nil(t, payload.AcctPort)
+		assert.Nil(t, payload.InterimUpdateInterval)
+		assert.Nil(t, payload.TunneledReply)
+	})
+}
+
+func TestSettingRadiusAPIToModel(t *testing.T) {
+	r := &settingRadiusResource{}
+
+	authPort := int64(1812)
+	s := &settings.Radius{
+		BaseSetting:       settings.BaseSetting{ID: "set-3", Key: "radius"},
+		Enabled:           true,
+		AuthPort:          &authPort,
+		AccountingEnabled: true,
+		XSecret:           "s3cret",
+	}
+
+	var m settingRadiusResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-3", m.ID.ValueString())
+	assert.Equal(t, "default", m.Site.ValueString())
+	assert.True(t, m.Enabled.ValueBool())
+	assert.Equal(t, "s3cret", m.Secret.ValueString())
+	assert.Equal(t, int64(1812), m.AuthPort.ValueInt64())
+	assert.True(t, m.AccountingEnabled.ValueBool())
+	assert.True(t, m.AcctPort.IsNull())
+	assert.True(t, m.InterimUpdateInterval.IsNull())
+	assert.False(t, m.TunneledReply.ValueBool())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingRadius_basic(t *testing.T) {
+	config := func(enabled, tunneled bool) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_radius" "test" {
+  enabled        = %t
+  secret         = "tfaccsecret"
+  auth_port      = 1812
+  tunneled_reply = %t
+}
+`, enabled, tunneled)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_radius.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_setting_radius.test", "auth_port", "1812"),
+					resource.TestCheckResourceAttr("terrifi_setting_radius.test", "tunneled_reply", "true"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_radius.test", "id"),
+				),
+			},
+			{
+				Config: config(false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_radius.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_setting_radius.test", "tunneled_reply", "false"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(false, false),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "terrifi_setting_radius.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingRadius_validationInvalidSecret(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_radius" "test" {
+  enabled = true
+  secret  = "has a space"
+}
+`,
+				ExpectError: regexp.MustCompile(`without spaces, quotes, or backslashes`),
+			},
+		},
+	})
+}
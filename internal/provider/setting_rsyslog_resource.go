@@ -0,0 +1,363 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingRsyslogResource{}
+	_ resource.ResourceWithImportState = &settingRsyslogResource{}
+)
+
+// rsyslogContents are the categories of log entries the controller can
+// forward to a remote syslog server.
+var rsyslogContents = []string{
+	"device", "client", "firewall_default_policy", "triggers", "updates",
+	"admin_activity", "critical", "security_detections", "vpn",
+}
+
+func NewSettingRsyslogResource() resource.Resource {
+	return &settingRsyslogResource{}
+}
+
+type settingRsyslogResource struct {
+	client ClientAPI
+}
+
+type settingRsyslogResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Site           types.String `tfsdk:"site"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Host           types.String `tfsdk:"host"`
+	Port           types.Int64  `tfsdk:"port"`
+	Contents       types.Set    `tfsdk:"contents"`
+	LogAllContents types.Bool   `tfsdk:"log_all_contents"`
+	Debug          types.Bool   `tfsdk:"debug"`
+}
+
+// settingRsyslogPayload is the body for PUT set/setting/rsyslogd. Optional
+// fields are pointers (or nil slices) so that attributes left out of the
+// config keep the controller's current value, while an explicit false is
+// still sent.
+type settingRsyslogPayload struct {
+	Enabled        bool     `json:"enabled"`
+	IP             string   `json:"ip,omitempty"`
+	Port           *int64   `json:"port,omitempty"`
+	Contents       []string `json:"contents,omitempty"`
+	LogAllContents *bool    `json:"log_all_contents,omitempty"`
+	Debug          *bool    `json:"debug,omitempty"`
+}
+
+func (r *settingRsyslogResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_rsyslog"
+}
+
+func (r *settingRsyslogResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages remote syslog forwarding (the SIEM server setting) of a UniFi site. " +
+			"This is where the entries of `terrifi_firewall_policy` resources with `logging = true` are sent. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the remote syslog setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether log entries are forwarded to the remote syslog server.",
+				Required:            true,
+			},
+
+			"host": schema.StringAttribute{
+				MarkdownDescription: "IP address of the remote syslog server.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "UDP port of the remote syslog server. The controller default is `514`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"contents": schema.SetAttribute{
+				MarkdownDescription: "Categories of log entries to forward. Valid values: `device`, `client`, " +
+					"`firewall_default_policy`, `triggers`, `updates`, `admin_activity`, `critical`, " +
+					"`security_detections`, `vpn`. Ignored when `log_all_contents` is `true`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(rsyslogContents...)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"log_all_contents": schema.BoolAttribute{
+				MarkdownDescription: "Whether to forward every category of log entry, regardless of `contents`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"debug": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include debug-level entries.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingRsyslogResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingRsyslogResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingRsyslogResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingRsyslogd(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Remote Syslog Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "rsyslogd", existing.ID, r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Remote Syslog Setting", err.Error())
+		return
+	}
+
+	rsyslog, err := r.client.GetSettingRsyslogd(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Remote Syslog Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(rsyslog, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingRsyslogResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingRsyslogResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	rsyslog, err := r.client.GetSettingRsyslogd(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Remote Syslog Setting",
+			fmt.Sprintf("Could not read remote syslog setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(rsyslog, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingRsyslogResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingRsyslogResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "rsyslogd", state.ID.ValueString(), r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Remote Syslog Setting", err.Error())
+		return
+	}
+
+	rsyslog, err := r.client.GetSettingRsyslogd(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Remote Syslog Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(rsyslog, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingRsyslogResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing remote syslog setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingRsyslogResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingRsyslogResource) modelToAPI(ctx context.Context, m *settingRsyslogResourceModel) settingRsyslogPayload {
+	payload := settingRsyslogPayload{
+		Enabled: m.Enabled.ValueBool(),
+	}
+	if !m.Host.IsNull() && !m.Host.IsUnknown() {
+		payload.IP = m.Host.ValueString()
+	}
+	if !m.Port.IsNull() && !m.Port.IsUnknown() {
+		v := m.Port.ValueInt64()
+		payload.Port = &v
+	}
+	if !m.Contents.IsNull() && !m.Contents.IsUnknown() {
+		m.Contents.ElementsAs(ctx, &payload.Contents, false)
+	}
+	if !m.LogAllContents.IsNull() && !m.LogAllContents.IsUnknown() {
+		v := m.LogAllContents.ValueBool()
+		payload.LogAllContents = &v
+	}
+	if !m.Debug.IsNull() && !m.Debug.IsUnknown() {
+		v := m.Debug.ValueBool()
+		payload.Debug = &v
+	}
+	return payload
+}
+
+func (r *settingRsyslogResource) apiToModel(s *settings.Rsyslogd, m *settingRsyslogResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.Enabled = types.BoolValue(s.Enabled)
+	m.LogAllContents = types.BoolValue(s.LogAllContents)
+	m.Debug = types.BoolValue(s.Debug)
+
+	if s.IP != "" {
+		m.Host = types.StringValue(s.IP)
+	} else {
+		m.Host = types.StringNull()
+	}
+	if s.Port != nil {
+		m.Port = types.Int64Value(*s.Port)
+	} else {
+		m.Port = types.Int64Null()
+	}
+
+	contents := make([]attr.Value, len(s.Contents))
+	for i, c := range s.Contents {
+		contents[i] = types.StringValue(c)
+	}
+	m.Contents = types.SetValueMust(types.StringType, contents)
+}
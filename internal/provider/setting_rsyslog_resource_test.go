@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingRsyslogModelToAPI(t *testing.T) {
+	r := &settingRsyslogResource{}
+	ctx := context.Background()
+
+	t.Run("configured values are sent", func(t *testing.T) {
+		payload := r.modelToAPI(ctx, &settingRsyslogResourceModel{
+			Enabled: types.BoolValue(true),
+			Host:    types.StringValue("192.168.1.50"),
+			Port:    types.Int64Value(5514),
+			Contents: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("firewall_default_policy"),
+			}),
+			LogAllContents: types.BoolValue(false),
+			Debug:          types.BoolValue(false),
+		})
+
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "192.168.1.50", payload.IP)
+		require.NotNil(t, payload.Port)
+		assert.Equal(t, int64(5514), *payload.Port)
+		assert.Equal(t, []string{"firewall_default_policy"}, payload.Contents)
+		// An explicit false must still be sent.
+		require.NotNil(t, payload.LogAllContents)
+		assert.False(t, *payload.LogAllContents)
+		require.NotNil(t, payload.Debug)
+		assert.False(t, *payload.Debug)
+	})
+
+	t.Run("unset values are omitted", func(t *testing.T) {
+		payload := r.modelToAPI(ctx, &settingRsyslogResourceModel{
+			Enabled:        types.BoolValue(false),
+			Host:           types.StringUnknown(),
+			Port:           types.Int64Unknown(),
+			Contents:       types.SetUnknown(types.StringType),
+			LogAllContents: types.BoolNull(),
+			Debug:          types.BoolUnknown(),
+		})
+
+		assert.False(t, payload.Enabled)
+		assert.Empty(t, payload.IP)
+		assert.Nil(t, payload.Port)
+		assert.Nil(t, payload.Contents)
+		assert.Nil(t, payload.LogAllContents)
+		assert.Nil(t, payload.Debug)
+	})
+}
+
+func TestSettingRsyslogAPIToModel(t *testing.T) {
+	r := &settingRsyslogResource{}
+
+	port := int64(514)
+	s := &settings.Rsyslogd{
+		BaseSetting: settings.BaseSetting{ID: "set-4", Key: "rsyslogd"},
+		Enabled:     true,
+		IP:          "192.168.1.50",
+		Port:        &port,
+		Contents:    []string{"device", "vpn"},
+	}
+
+	var m settingRsyslogResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-4", m.ID.ValueString())
+	assert.Equal(t, "default", m.Site.ValueString())
+	assert.True(t, m.Enabled.ValueBool())
+	assert.Equal(t, "192.168.1.50", m.Host.ValueString())
+	assert.Equal(t, int64(514), m.Port.ValueInt64())
+	assert.Len(t, m.Contents.Elements(), 2)
+	assert.False(t, m.LogAllContents.ValueBool())
+	assert.False(t, m.Debug.ValueBool())
+
+	r.apiToModel(&settings.Rsyslogd{}, &m, "default")
+	assert.True(t, m.Host.IsNull())
+	assert.True(t, m.Port.IsNull())
+	assert.Empty(t, m.Contents.Elements())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingRsyslog_basic(t *testing.T) {
+	config := func(enabled bool, contents string) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_rsyslog" "test" {
+  enabled  = %t
+  host     = "192.168.1.50"
+  port     = 5514
+  contents = %s
+}
+`, enabled, contents)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, `["firewall_default_policy", "security_detections"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "host", "192.168.1.50"),
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "port", "5514"),
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "contents.#", "2"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_rsyslog.test", "id"),
+				),
+			},
+			{
+				Config: config(false, `["device"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_setting_rsyslog.test", "contents.#", "1"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(false, `["device"]`),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "terrifi_setting_rsyslog.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingRsyslog_validationInvalidContents(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_rsyslog" "test" {
+  enabled  = true
+  host     = "192.168.1.50"
+  contents = ["firewall"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingTeleportResource{}
+	_ resource.ResourceWithImportState = &settingTeleportResource{}
+)
+
+func NewSettingTeleportResource() resource.Resource {
+	return &settingTeleportResource{}
+}
+
+type settingTeleportResource struct {
+	client ClientAPI
+}
+
+type settingTeleportResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Site       types.String `tfsdk:"site"`
+	Enabled    types.Bool   `tfsdk:"enabled"`
+	SubnetCIDR types.String `tfsdk:"subnet_cidr"`
+}
+
+// settingTeleportPayload is the body for PUT set/setting/teleport. An empty
+// subnet is omitted so the controller keeps its current (or default) subnet.
+type settingTeleportPayload struct {
+	Enabled    bool   `json:"enabled"`
+	SubnetCIDR string `json:"subnet_cidr,omitempty"`
+}
+
+// teleportSubnetRegexp matches an IPv4 CIDR with a prefix length of 8 to 32,
+// the range accepted by the controller.
+var teleportSubnetRegexp = regexp.MustCompile(
+	`^((25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])/([89]|[12][0-9]|3[0-2])$`)
+
+func (r *settingTeleportResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_teleport"
+}
+
+func (r *settingTeleportResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages Teleport, UniFi's one-click VPN, on a UniFi site. With Teleport enabled, " +
+			"invitation links generated in the UniFi UI or the WiFiman app give remote devices access to the " +
+			"network without configuring a VPN server. Invitations themselves are not managed by this resource. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the Teleport setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether Teleport VPN connections are accepted.",
+				Required:            true,
+			},
+
+			"subnet_cidr": schema.StringAttribute{
+				MarkdownDescription: "The IPv4 subnet, in CIDR notation, that Teleport clients are assigned " +
+					"addresses from (e.g. `192.168.2.0/24`). Must not overlap any other network. If not set, the " +
+					"controller's current value is kept.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(teleportSubnetRegexp, "must be an IPv4 CIDR with a prefix length of 8 to 32"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingTeleportResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingTeleportResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingTeleportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingTeleport(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Teleport Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "teleport", existing.ID, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Teleport Setting", err.Error())
+		return
+	}
+
+	teleport, err := r.client.GetSettingTeleport(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Teleport Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(teleport, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingTeleportResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingTeleportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	teleport, err := r.client.GetSettingTeleport(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Teleport Setting",
+			fmt.Sprintf("Could not read Teleport setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(teleport, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingTeleportResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingTeleportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "teleport", state.ID.ValueString(), r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Teleport Setting", err.Error())
+		return
+	}
+
+	teleport, err := r.client.GetSettingTeleport(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Teleport Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(teleport, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingTeleportResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing Teleport setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingTeleportResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingTeleportResource) modelToAPI(m *settingTeleportResourceModel) settingTeleportPayload {
+	return settingTeleportPayload{
+		Enabled:    m.Enabled.ValueBool(),
+		SubnetCIDR: m.SubnetCIDR.ValueString(),
+	}
+}
+
+func (r *settingTeleportResource) apiToModel(s *settings.Teleport, m *settingTeleportResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.Enabled = types.BoolValue(s.Enabled)
+	m.SubnetCIDR = stringValueOrNull(s.SubnetCidr)
+}
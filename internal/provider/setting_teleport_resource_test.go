@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingTeleportModelToAPI(t *testing.T) {
+	r := &settingTeleportResource{}
+
+	t.Run("subnet set", func(t *testing.T) {
+		payload := r.modelToAPI(&settingTeleportResourceModel{
+			Enabled:    types.BoolValue(true),
+			SubnetCIDR: types.StringValue("192.168.2.0/24"),
+		})
+
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "192.168.2.0/24", payload.SubnetCIDR)
+	})
+
+	t.Run("subnet unknown keeps controller value", func(t *testing.T) {
+		payload := r.modelToAPI(&settingTeleportResourceModel{
+			Enabled:    types.BoolValue(false),
+			SubnetCIDR: types.StringUnknown(),
+		})
+
+		assert.False(t, payload.Enabled)
+		assert.Empty(t, payload.SubnetCIDR)
+	})
+}
+
+func TestSettingTeleportAPIToModel(t *testing.T) {
+	r := &settingTeleportResource{}
+
+	s := &settings.Teleport{
+		BaseSetting: settings.BaseSetting{ID: "set-5", Key: "teleport"},
+		Enabled:     true,
+		SubnetCidr:  "192.168.2.0/24",
+	}
+
+	var m settingTeleportResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-5", m.ID.ValueString())
+	assert.True(t, m.Enabled.ValueBool())
+	assert.Equal(t, "192.168.2.0/24", m.SubnetCIDR.ValueString())
+
+	r.apiToModel(&settings.Teleport{}, &m, "default")
+	assert.True(t, m.SubnetCIDR.IsNull())
+}
+
+func TestTeleportSubnetRegexp(t *testing.T) {
+	for _, s := range []string{"192.168.2.0/24", "10.0.0.0/8", "172.16.5.1/32"} {
+		assert.True(t, teleportSubnetRegexp.MatchString(s), s)
+	}
+	for _, s := range []string{"192.168.2.0", "10.0.0.0/7", "256.0.0.0/24", "fd00::/64", ""} {
+		assert.False(t, teleportSubnetRegexp.MatchString(s), s)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingTeleport_update(t *testing.T) {
+	config := func(enabled bool) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_teleport" "test" {
+  enabled     = %t
+  subnet_cidr = "192.168.201.0/24"
+}
+`, enabled)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_teleport.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_setting_teleport.test", "subnet_cidr", "192.168.201.0/24"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_teleport.test", "id"),
+				),
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckResourceAttr("terrifi_setting_teleport.test", "enabled", "false"),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(false),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "terrifi_setting_teleport.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingTeleport_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_teleport" "test" {
+  enabled     = true
+  subnet_cidr = "192.168.201.0"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an IPv4 CIDR`),
+			},
+		},
+	})
+}
@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+var (
+	_ resource.Resource                = &settingUSGResource{}
+	_ resource.ResourceWithImportState = &settingUSGResource{}
+)
+
+// usgDHCPRelayServerSlots is the number of DHCP relay servers the gateway
+// setting has room for (dhcp_relay_server_1 to dhcp_relay_server_5).
+const usgDHCPRelayServerSlots = 5
+
+func NewSettingUSGResource() resource.Resource {
+	return &settingUSGResource{}
+}
+
+type settingUSGResource struct {
+	client ClientAPI
+}
+
+type settingUSGResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Site                    types.String `tfsdk:"site"`
+	MDNSEnabled             types.Bool   `tfsdk:"mdns_enabled"`
+	UPnPEnabled             types.Bool   `tfsdk:"upnp_enabled"`
+	UPnPNATPMPEnabled       types.Bool   `tfsdk:"upnp_nat_pmp_enabled"`
+	UPnPSecureMode          types.Bool   `tfsdk:"upnp_secure_mode"`
+	UPnPWANInterface        types.String `tfsdk:"upnp_wan_interface"`
+	DHCPRelayServers        types.List   `tfsdk:"dhcp_relay_servers"`
+	OffloadAccounting       types.Bool   `tfsdk:"offload_accounting"`
+	OffloadL2Blocking       types.Bool   `tfsdk:"offload_l2_blocking"`
+	OffloadScheduler        types.Bool   `tfsdk:"offload_scheduler"`
+	GeoIPFilteringEnabled   types.Bool   `tfsdk:"geo_ip_filtering_enabled"`
+	GeoIPFilteringAction    types.String `tfsdk:"geo_ip_filtering_action"`
+	GeoIPFilteringCountries types.Set    `tfsdk:"geo_ip_filtering_countries"`
+	GeoIPFilteringDirection types.String `tfsdk:"geo_ip_filtering_direction"`
+}
+
+// settingUSGPayload is the body for PUT set/setting/usg. Every field is a
+// pointer so that attributes left out of the config keep the controller's
+// current value, while an explicit false or empty string is still sent. The
+// many gateway settings the resource doesn't manage are never sent.
+type settingUSGPayload struct {
+	MDNSEnabled             *bool   `json:"mdns_enabled,omitempty"`
+	UPnPEnabled             *bool   `json:"upnp_enabled,omitempty"`
+	UPnPNATPMPEnabled       *bool   `json:"upnp_nat_pmp_enabled,omitempty"`
+	UPnPSecureMode          *bool   `json:"upnp_secure_mode,omitempty"`
+	UPnPWANInterface        *string `json:"upnp_wan_interface,omitempty"`
+	DHCPRelayServer1        *string `json:"dhcp_relay_server_1,omitempty"`
+	DHCPRelayServer2        *string `json:"dhcp_relay_server_2,omitempty"`
+	DHCPRelayServer3        *string `json:"dhcp_relay_server_3,omitempty"`
+	DHCPRelayServer4        *string `json:"dhcp_relay_server_4,omitempty"`
+	DHCPRelayServer5        *string `json:"dhcp_relay_server_5,omitempty"`
+	OffloadAccounting       *bool   `json:"offload_accounting,omitempty"`
+	OffloadL2Blocking       *bool   `json:"offload_l2_blocking,omitempty"`
+	OffloadSch              *bool   `json:"offload_sch,omitempty"`
+	GeoIPFilteringEnabled   *bool   `json:"geo_ip_filtering_enabled,omitempty"`
+	GeoIPFilteringBlock     *string `json:"geo_ip_filtering_block,omitempty"`
+	GeoIPFilteringCountries *string `json:"geo_ip_filtering_countries,omitempty"`
+	GeoIPFilteringDirection *string `json:"geo_ip_filtering_traffic_direction,omitempty"`
+}
+
+func (r *settingUSGResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_setting_usg"
+}
+
+// settingBoolAttribute returns an optional settings toggle that keeps the
+// controller's current value when not configured.
+func settingBoolAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Computed:            true,
+		PlanModifiers: []planmodifier.Bool{
+			boolplanmodifier.UseStateForUnknown(),
+		},
+	}
+}
+
+func (r *settingUSGResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the gateway settings of a UniFi site: the mDNS reflector, UPnP, DHCP relay, " +
+			"hardware offloading and GeoIP filtering. " +
+			"This is a site-wide singleton: creating the resource adopts the existing setting, and destroying it " +
+			"leaves the controller's value unchanged. Attributes that are not configured keep the controller's " +
+			"current value.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the gateway setting.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to manage. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"mdns_enabled": settingBoolAttribute("Whether the gateway reflects mDNS (Bonjour) announcements between " +
+				"networks, so that devices such as printers and speakers can be discovered across VLANs."),
+
+			"upnp_enabled": settingBoolAttribute("Whether UPnP is enabled, letting clients open ports on the gateway " +
+				"without a port forward."),
+
+			"upnp_nat_pmp_enabled": settingBoolAttribute("Whether NAT-PMP is accepted alongside UPnP."),
+
+			"upnp_secure_mode": settingBoolAttribute("Whether UPnP only lets a client open ports that forward to its " +
+				"own IP address."),
+
+			"upnp_wan_interface": schema.StringAttribute{
+				MarkdownDescription: "The WAN that UPnP opens ports on: `WAN`, or `WAN2` to `WAN9`.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wanNetworkGroupRegexp, "must be WAN or WAN2 to WAN9"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"dhcp_relay_servers": schema.ListAttribute{
+				MarkdownDescription: "IPv4 addresses of up to five DHCP servers that DHCP requests are relayed " +
+					"to, for networks whose DHCP mode is relay. Set to `[]` to remove every server.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(usgDHCPRelayServerSlots),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+					),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"offload_accounting": settingBoolAttribute("Whether hardware offloading is used for traffic accounting. " +
+				"Offloading increases throughput but disables per-client statistics."),
+
+			"offload_l2_blocking": settingBoolAttribute("Whether layer 2 blocking is offloaded to hardware."),
+
+			"offload_scheduler": settingBoolAttribute("Whether the packet scheduler (smart queues) is offloaded to " +
+				"hardware."),
+
+			"geo_ip_filtering_enabled": settingBoolAttribute("Whether GeoIP filtering is enabled. The filter " +
+				"applies to the countries in `geo_ip_filtering_countries`."),
+
+			"geo_ip_filtering_action": schema.StringAttribute{
+				MarkdownDescription: "What GeoIP filtering does with the countries in " +
+					"`geo_ip_filtering_countries`: `block` blocks them, `allow` blocks every other country.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("block", "allow"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"geo_ip_filtering_countries": schema.SetAttribute{
+				MarkdownDescription: "Two-letter country codes (e.g. `CN`) that GeoIP filtering applies to.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtMost(150),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(countryCodeRegexp, "must be a two-letter uppercase country code"),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"geo_ip_filtering_direction": schema.StringAttribute{
+				MarkdownDescription: "Which traffic GeoIP filtering applies to: `both`, `ingress` (from the " +
+					"internet) or `egress` (to the internet).",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("both", "ingress", "egress"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *settingUSGResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *settingUSGResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan settingUSGResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	// The setting always exists on the controller; "create" adopts it.
+	existing, err := r.client.GetSettingUsg(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Gateway Setting", err.Error())
+		return
+	}
+
+	err = r.client.updateSetting(ctx, site, "usg", existing.ID, r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Gateway Setting", err.Error())
+		return
+	}
+
+	usg, err := r.client.GetSettingUsg(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Gateway Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(usg, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *settingUSGResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state settingUSGResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	usg, err := r.client.GetSettingUsg(ctx, site)
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Gateway Setting",
+			fmt.Sprintf("Could not read gateway setting for site %s: %s", site, err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(usg, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingUSGResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan settingUSGResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.updateSetting(ctx, site, "usg", state.ID.ValueString(), r.modelToAPI(ctx, &plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Gateway Setting", err.Error())
+		return
+	}
+
+	usg, err := r.client.GetSettingUsg(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Gateway Setting After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(usg, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *settingUSGResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — the setting is a site-wide singleton that cannot be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing gateway setting from state (setting continues to exist on controller)")
+}
+
+func (r *settingUSGResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The import ID is the site name, since there is one setting per site.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), req.ID)...)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *settingUSGResource) modelToAPI(ctx context.Context, m *settingUSGResourceModel) settingUSGPayload {
+	var payload settingUSGPayload
+	payload.MDNSEnabled = optionalBool(m.MDNSEnabled)
+	payload.UPnPEnabled = optionalBool(m.UPnPEnabled)
+	payload.UPnPNATPMPEnabled = optionalBool(m.UPnPNATPMPEnabled)
+	payload.UPnPSecureMode = optionalBool(m.UPnPSecureMode)
+	payload.UPnPWANInterface = optionalString(m.UPnPWANInterface)
+	payload.OffloadAccounting = optionalBool(m.OffloadAccounting)
+	payload.OffloadL2Blocking = optionalBool(m.OffloadL2Blocking)
+	payload.OffloadSch = optionalBool(m.OffloadScheduler)
+	payload.GeoIPFilteringEnabled = optionalBool(m.GeoIPFilteringEnabled)
+	payload.GeoIPFilteringBlock = optionalString(m.GeoIPFilteringAction)
+	payload.GeoIPFilteringDirection = optionalString(m.GeoIPFilteringDirection)
+
+	if !m.DHCPRelayServers.IsNull() && !m.DHCPRelayServers.IsUnknown() {
+		var servers []string
+		m.DHCPRelayServers.ElementsAs(ctx, &servers, false)
+		// Every slot is sent so that servers removed from the list are cleared.
+		slots := make([]string, usgDHCPRelayServerSlots)
+		copy(slots, servers)
+		payload.DHCPRelayServer1 = &slots[0]
+		payload.DHCPRelayServer2 = &slots[1]
+		payload.DHCPRelayServer3 = &slots[2]
+		payload.DHCPRelayServer4 = &slots[3]
+		payload.DHCPRelayServer5 = &slots[4]
+	}
+
+	if !m.GeoIPFilteringCountries.IsNull() && !m.GeoIPFilteringCountries.IsUnknown() {
+		countries := setStrings(ctx, m.GeoIPFilteringCountries)
+		sort.Strings(countries)
+		joined := strings.Join(countries, ",")
+		payload.GeoIPFilteringCountries = &joined
+	}
+
+	return payload
+}
+
+func (r *settingUSGResource) apiToModel(s *settings.Usg, m *settingUSGResourceModel, site string) {
+	m.ID = types.StringValue(s.ID)
+	m.Site = types.StringValue(site)
+	m.MDNSEnabled = types.BoolValue(s.MdnsEnabled)
+	m.UPnPEnabled = types.BoolValue(s.UPnPEnabled)
+	m.UPnPNATPMPEnabled = types.BoolValue(s.UPnPNATPmpEnabled)
+	m.UPnPSecureMode = types.BoolValue(s.UPnPSecureMode)
+	m.UPnPWANInterface = stringValueOrNull(s.UPnPWANInterface)
+	m.OffloadAccounting = types.BoolValue(s.OffloadAccounting)
+	m.OffloadL2Blocking = types.BoolValue(s.OffloadL2Blocking)
+	m.OffloadScheduler = types.BoolValue(s.OffloadSch)
+	m.GeoIPFilteringEnabled = types.BoolValue(s.GeoIPFilteringEnabled)
+	m.GeoIPFilteringAction = stringValueOrNull(s.GeoIPFilteringBlock)
+	m.GeoIPFilteringDirection = stringValueOrNull(s.GeoIPFilteringTrafficDirection)
+
+	servers := []attr.Value{}
+	for _, server := range []string{
+		s.DHCPRelayServer1, s.DHCPRelayServer2, s.DHCPRelayServer3, s.DHCPRelayServer4, s.DHCPRelayServer5,
+	} {
+		if server != "" {
+			servers = append(servers, types.StringValue(server))
+		}
+	}
+	m.DHCPRelayServers = types.ListValueMust(types.StringType, servers)
+
+	countries := []attr.Value{}
+	for _, c := range strings.Split(s.GeoIPFilteringCountries, ",") {
+		if c != "" {
+			countries = append(countries, types.StringValue(c))
+		}
+	}
+	m.GeoIPFilteringCountries = types.SetValueMust(types.StringType, countries)
+}
+
+// optionalBool returns a pointer to the value of v, or nil when v is null or
+// unknown so that the attribute is left out of a partial settings update.
+func optionalBool(v types.Bool) *bool {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	b := v.ValueBool()
+	return &b
+}
+
+// optionalString is the types.String counterpart of optionalBool.
+func optionalString(v types.String) *string {
+	if v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+	s := v.ValueString()
+	return &s
+}
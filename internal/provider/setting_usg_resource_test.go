@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi/settings"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestSettingUSGModelToAPI(t *testing.T) {
+	ctx := context.Background()
+	r := &settingUSGResource{}
+
+	t.Run("all attributes set", func(t *testing.T) {
+		relayServers, _ := types.ListValueFrom(ctx, types.StringType, []string{"10.0.0.5", "10.0.0.6"})
+		payload := r.modelToAPI(ctx, &settingUSGResourceModel{
+			MDNSEnabled:             types.BoolValue(true),
+			UPnPEnabled:             types.BoolValue(false),
+			UPnPNATPMPEnabled:       types.BoolValue(false),
+			UPnPSecureMode:          types.BoolValue(true),
+			UPnPWANInterface:        types.StringValue("WAN2"),
+			DHCPRelayServers:        relayServers,
+			OffloadAccounting:       types.BoolValue(true),
+			OffloadL2Blocking:       types.BoolValue(false),
+			OffloadScheduler:        types.BoolValue(true),
+			GeoIPFilteringEnabled:   types.BoolValue(true),
+			GeoIPFilteringAction:    types.StringValue("block"),
+			GeoIPFilteringCountries: stringSet("RU", "CN"),
+			GeoIPFilteringDirection: types.StringValue("both"),
+		})
+
+		require.NotNil(t, payload.MDNSEnabled)
+		assert.True(t, *payload.MDNSEnabled)
+		// An explicit false must be sent, not omitted.
+		require.NotNil(t, payload.UPnPEnabled)
+		assert.False(t, *payload.UPnPEnabled)
+		assert.Equal(t, "WAN2", *payload.UPnPWANInterface)
+		assert.Equal(t, "10.0.0.5", *payload.DHCPRelayServer1)
+		assert.Equal(t, "10.0.0.6", *payload.DHCPRelayServer2)
+		// Unused slots are cleared.
+		require.NotNil(t, payload.DHCPRelayServer3)
+		assert.Empty(t, *payload.DHCPRelayServer3)
+		assert.Empty(t, *payload.DHCPRelayServer5)
+		assert.True(t, *payload.OffloadSch)
+		assert.Equal(t, "block", *payload.GeoIPFilteringBlock)
+		assert.Equal(t, "CN,RU", *payload.GeoIPFilteringCountries)
+		assert.Equal(t, "both", *payload.GeoIPFilteringDirection)
+	})
+
+	t.Run("unknown attributes keep controller value", func(t *testing.T) {
+		payload := r.modelToAPI(ctx, &settingUSGResourceModel{
+			MDNSEnabled:             types.BoolValue(true),
+			UPnPEnabled:             types.BoolUnknown(),
+			UPnPWANInterface:        types.StringUnknown(),
+			DHCPRelayServers:        types.ListUnknown(types.StringType),
+			GeoIPFilteringCountries: types.SetUnknown(types.StringType),
+		})
+
+		assert.NotNil(t, payload.MDNSEnabled)
+		assert.Nil(t, payload.UPnPEnabled)
+		assert.Nil(t, payload.UPnPWANInterface)
+		assert.Nil(t, payload.DHCPRelayServer1)
+		assert.Nil(t, payload.GeoIPFilteringCountries)
+		assert.Nil(t, payload.OffloadAccounting)
+	})
+
+	t.Run("empty relay list clears every server", func(t *testing.T) {
+		payload := r.modelToAPI(ctx, &settingUSGResourceModel{
+			DHCPRelayServers: types.ListValueMust(types.StringType, nil),
+		})
+
+		require.NotNil(t, payload.DHCPRelayServer1)
+		assert.Empty(t, *payload.DHCPRelayServer1)
+	})
+}
+
+func TestSettingUSGAPIToModel(t *testing.T) {
+	r := &settingUSGResource{}
+
+	s := &settings.Usg{
+		BaseSetting:                    settings.BaseSetting{ID: "set-9", Key: "usg"},
+		MdnsEnabled:                    true,
+		UPnPEnabled:                    true,
+		UPnPWANInterface:               "WAN",
+		DHCPRelayServer1:               "10.0.0.5",
+		DHCPRelayServer3:               "10.0.0.7",
+		OffloadSch:                     true,
+		GeoIPFilteringEnabled:          true,
+		GeoIPFilteringBlock:            "allow",
+		GeoIPFilteringCountries:        "US,CA",
+		GeoIPFilteringTrafficDirection: "ingress",
+		// Not managed by the resource.
+		EchoServer: "ping.ui.com",
+	}
+
+	var m settingUSGResourceModel
+	r.apiToModel(s, &m, "default")
+
+	assert.Equal(t, "set-9", m.ID.ValueString())
+	assert.True(t, m.MDNSEnabled.ValueBool())
+	assert.True(t, m.UPnPEnabled.ValueBool())
+	assert.False(t, m.UPnPSecureMode.ValueBool())
+	assert.Equal(t, "WAN", m.UPnPWANInterface.ValueString())
+	assert.True(t, m.OffloadScheduler.ValueBool())
+	assert.Equal(t, "allow", m.GeoIPFilteringAction.ValueString())
+	assert.Equal(t, "ingress", m.GeoIPFilteringDirection.ValueString())
+
+	var servers []string
+	m.DHCPRelayServers.ElementsAs(context.Background(), &servers, false)
+	assert.Equal(t, []string{"10.0.0.5", "10.0.0.7"}, servers)
+	assert.ElementsMatch(t, []string{"US", "CA"}, setStrings(context.Background(), m.GeoIPFilteringCountries))
+
+	r.apiToModel(&settings.Usg{}, &m, "default")
+	assert.True(t, m.UPnPWANInterface.IsNull())
+	assert.True(t, m.GeoIPFilteringAction.IsNull())
+	assert.Empty(t, m.DHCPRelayServers.Elements())
+	assert.Empty(t, m.GeoIPFilteringCountries.Elements())
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSettingUSG_update(t *testing.T) {
+	config := func(mdns bool, relay string) string {
+		return fmt.Sprintf(`
+resource "terrifi_setting_usg" "test" {
+  mdns_enabled       = %t
+  upnp_enabled       = false
+  dhcp_relay_servers = [%s]
+}
+`, mdns, relay)
+	}
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true, `"10.0.0.5"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "mdns_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "upnp_enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "dhcp_relay_servers.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "dhcp_relay_servers.0", "10.0.0.5"),
+					resource.TestCheckResourceAttrSet("terrifi_setting_usg.test", "id"),
+				),
+			},
+			{
+				Config: config(false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "mdns_enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_setting_usg.test", "dhcp_relay_servers.#", "0"),
+				),
+			},
+			// Idempotent — second apply must produce no diff.
+			{
+				Config:   config(false, ""),
+				PlanOnly: true,
+			},
+			{
+				ResourceName:      "terrifi_setting_usg.test",
+				ImportState:       true,
+				ImportStateId:     "default",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSettingUSG_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_setting_usg" "test" {
+  geo_ip_filtering_countries = ["cn"]
+}
+`,
+				ExpectError: regexp.MustCompile(`two-letter uppercase country code`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_usg" "test" {
+  dhcp_relay_servers = ["dhcp.example.com"]
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an IPv4 address`),
+			},
+			{
+				Config: `
+resource "terrifi_setting_usg" "test" {
+  upnp_wan_interface = "LAN"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be WAN or WAN2 to WAN9`),
+			},
+		},
+	})
+}
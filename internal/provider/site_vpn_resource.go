@@ -0,0 +1,829 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                     = &siteVPNResource{}
+	_ resource.ResourceWithImportState      = &siteVPNResource{}
+	_ resource.ResourceWithConfigValidators = &siteVPNResource{}
+	_ resource.ResourceWithModifyPlan       = &siteVPNResource{}
+)
+
+// siteVPNTypes maps the type attribute to the network's vpn_type. The
+// gateway is the WireGuard client of the tunnel; the remote gateway listens.
+var siteVPNTypes = map[string]string{
+	"ipsec":     "ipsec-vpn",
+	"wireguard": "wireguard-client",
+}
+
+// NewSiteVPNResource is the factory function registered in provider.Resources().
+func NewSiteVPNResource() resource.Resource {
+	return &siteVPNResource{}
+}
+
+// siteVPNResource holds the API client, injected by Configure().
+type siteVPNResource struct {
+	client ClientAPI
+}
+
+// siteVPNResourceModel is the Terraform-side representation of an IPsec or
+// WireGuard site-to-site VPN. The controller stores these as networks with
+// purpose site-vpn.
+type siteVPNResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Site            types.String `tfsdk:"site"`
+	Name            types.String `tfsdk:"name"`
+	Type            types.String `tfsdk:"type"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	RemoteGateway   types.String `tfsdk:"remote_gateway"`
+	RemotePort      types.Int64  `tfsdk:"remote_port"`
+	RemotePublicKey types.String `tfsdk:"remote_public_key"`
+	WANInterface    types.String `tfsdk:"wan_interface"`
+	PreSharedKey    types.String `tfsdk:"pre_shared_key"`
+	PrivateKey      types.String `tfsdk:"private_key"`
+	PublicKey       types.String `tfsdk:"public_key"`
+	RemoteSubnets   types.Set    `tfsdk:"remote_subnets"`
+	LocalNetworkIDs types.Set    `tfsdk:"local_network_ids"`
+	RouteBased      types.Bool   `tfsdk:"route_based"`
+	RouteDistance   types.Int64  `tfsdk:"route_distance"`
+	TunnelIP        types.String `tfsdk:"tunnel_ip"`
+	IKEVersion      types.String `tfsdk:"ike_version"`
+	Encryption      types.String `tfsdk:"encryption"`
+	Hash            types.String `tfsdk:"hash"`
+	DHGroup         types.Int64  `tfsdk:"dh_group"`
+	PFS             types.Bool   `tfsdk:"pfs"`
+}
+
+// Metadata sets the resource type name.
+func (r *siteVPNResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_site_vpn"
+}
+
+// Schema defines the HCL schema for the terrifi_site_vpn resource.
+func (r *siteVPNResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an IPsec or WireGuard site-to-site VPN between the UniFi gateway and a " +
+			"remote gateway, such as another site's router or a cloud VPN gateway.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the site-to-site VPN network.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the VPN with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the VPN.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The VPN protocol: `ipsec` or `wireguard`. For `wireguard`, the UniFi gateway " +
+					"connects to a WireGuard server on the remote gateway. Default: `ipsec`. Changing this " +
+					"forces a new resource.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("ipsec"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("ipsec", "wireguard"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the tunnel is established. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"remote_gateway": schema.StringAttribute{
+				MarkdownDescription: "The public IPv4 address of the remote gateway.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+				},
+			},
+
+			"remote_port": schema.Int64Attribute{
+				MarkdownDescription: "The UDP port the remote WireGuard server listens on. Only valid when `type` " +
+					"is `wireguard`. Default: `51820`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+
+			"remote_public_key": schema.StringAttribute{
+				MarkdownDescription: "The public key of the remote WireGuard server. Required when `type` is " +
+					"`wireguard`, and only valid then.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wireguardKeyRegexp, "must be a base64-encoded WireGuard key"),
+				},
+			},
+
+			"wan_interface": schema.StringAttribute{
+				MarkdownDescription: "The WAN the tunnel is established over: `wan` for the primary, `wan2` for the " +
+					"second, and so on. Default: `wan`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("wan"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^wan[2-9]?$`), "must be wan, wan2, ..., wan9"),
+				},
+			},
+
+			"pre_shared_key": schema.StringAttribute{
+				MarkdownDescription: "The pre-shared key both gateways authenticate with. Required when `type` is " +
+					"`ipsec`. Optional for `wireguard`, where it must be a base64-encoded WireGuard key and " +
+					"adds a symmetric key to the handshake.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^"' ]+$`), "must not be empty or contain spaces or quotes"),
+				},
+			},
+
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The gateway's base64-encoded WireGuard private key. Only valid when `type` is " +
+					"`wireguard`. If not set, a key is generated when the VPN is created.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wireguardKeyRegexp, "must be a base64-encoded WireGuard key"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The gateway's WireGuard public key, for the remote server's peer " +
+					"configuration. Null for IPsec VPNs.",
+				Computed: true,
+			},
+
+			"remote_subnets": schema.SetAttribute{
+				MarkdownDescription: "The subnets behind the remote gateway, in CIDR notation. The gateway routes " +
+					"traffic for them through the tunnel, and for WireGuard they are the peer's allowed IPs.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(wanIPv4CIDRRegexp, "must be an IPv4 subnet in CIDR notation")),
+				},
+			},
+
+			"local_network_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the local networks reachable from the remote side. The controller " +
+					"exposes a network to all of the site's site-to-site VPNs at once, so listing it here exposes " +
+					"it to the other tunnels too, and removing it from the list (or destroying this resource) " +
+					"unexposes it for all of them. If not set, which networks are exposed is left unchanged.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"route_based": schema.BoolAttribute{
+				MarkdownDescription: "Whether the VPN is route-based, with a tunnel interface and routes to " +
+					"`remote_subnets`, rather than policy-based, where traffic is matched by IPsec policies. " +
+					"Only valid when `type` is `ipsec`. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+
+			"route_distance": schema.Int64Attribute{
+				MarkdownDescription: "The administrative distance of the routes injected for `remote_subnets` " +
+					"(1-255). Raise it to prefer other routes to the same subnets. Default: `30`.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 255),
+				},
+			},
+
+			"tunnel_ip": schema.StringAttribute{
+				MarkdownDescription: "The address and prefix length of the local tunnel interface, e.g. " +
+					"`10.255.0.1/30`. Required when `type` is `wireguard`. For IPsec, set it for route-based " +
+					"VPNs that run a routing protocol over the tunnel; if not set, the tunnel interface is " +
+					"unnumbered.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wanIPv4CIDRRegexp, "must be an IPv4 address with a prefix length, e.g. 10.255.0.1/30"),
+				},
+			},
+
+			"ike_version": schema.StringAttribute{
+				MarkdownDescription: "The key exchange version: `ikev1` or `ikev2`. Only valid when `type` is " +
+					"`ipsec`. Default: `ikev2`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("ikev2"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("ikev1", "ikev2"),
+				},
+			},
+
+			"encryption": schema.StringAttribute{
+				MarkdownDescription: "The cipher of both IPsec phases: `aes128`, `aes192`, `aes256` or `3des`. " +
+					"Only valid when `type` is `ipsec`. Default: `aes256`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("aes256"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("aes128", "aes192", "aes256", "3des"),
+				},
+			},
+
+			"hash": schema.StringAttribute{
+				MarkdownDescription: "The integrity algorithm of both IPsec phases: `sha1`, `md5`, `sha256`, " +
+					"`sha384` or `sha512`. Only valid when `type` is `ipsec`. Default: `sha256`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("sha256"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("sha1", "md5", "sha256", "sha384", "sha512"),
+				},
+			},
+
+			"dh_group": schema.Int64Attribute{
+				MarkdownDescription: "The Diffie-Hellman group of both IPsec phases. Only valid when `type` is " +
+					"`ipsec`. Default: `14`.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(14),
+				Validators: []validator.Int64{
+					int64validator.OneOf(2, 5, 14, 15, 16, 19, 20, 21, 25, 26),
+				},
+			},
+
+			"pfs": schema.BoolAttribute{
+				MarkdownDescription: "Whether phase 2 uses perfect forward secrecy. Only valid when `type` is " +
+					"`ipsec`. Default: `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *siteVPNResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		siteVPNTypeFieldsValidator{},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *siteVPNResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan nulls the attributes of the other VPN type, fills in the
+// WireGuard port default, and derives the WireGuard public key from a known
+// private key so that it is shown in the plan.
+func (r *siteVPNResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to fill in.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan siteVPNResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Type.IsUnknown() {
+		return
+	}
+
+	if plan.Type.ValueString() == "wireguard" {
+		if plan.RemotePort.IsUnknown() {
+			plan.RemotePort = types.Int64Value(defaultWireGuardPort)
+		}
+		plan.RouteBased = types.BoolNull()
+		plan.IKEVersion = types.StringNull()
+		plan.Encryption = types.StringNull()
+		plan.Hash = types.StringNull()
+		plan.DHGroup = types.Int64Null()
+		plan.PFS = types.BoolNull()
+		if !plan.PrivateKey.IsUnknown() && !plan.PrivateKey.IsNull() {
+			if publicKey, err := wireguardPublicKey(plan.PrivateKey.ValueString()); err == nil {
+				plan.PublicKey = types.StringValue(publicKey)
+			}
+		}
+	} else {
+		plan.RemotePort = types.Int64Null()
+		plan.PrivateKey = types.StringNull()
+		plan.PublicKey = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates a new site-to-site VPN and exposes its local networks.
+func (r *siteVPNResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan siteVPNResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	if plan.Type.ValueString() == "wireguard" && (plan.PrivateKey.IsUnknown() || plan.PrivateKey.IsNull()) {
+		key, err := generateWireGuardKey()
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating Site VPN", err.Error())
+			return
+		}
+		plan.PrivateKey = types.StringValue(key)
+	}
+
+	id, err := r.client.CreateSiteVPN(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Site VPN", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	// The tunnel exists from here on, so it is saved to state even if
+	// exposing the local networks fails; the next apply retries them.
+	if err := r.setExposure(ctx, site, types.SetNull(types.StringType), plan.LocalNetworkIDs); err != nil {
+		resp.Diagnostics.AddError("Error Exposing Local Networks", err.Error())
+	}
+
+	network, exposed, err := r.read(ctx, site, id, !plan.LocalNetworkIDs.IsNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Site VPN After Create", err.Error())
+	} else {
+		r.apiToModel(network, exposed, &plan, site)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *siteVPNResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state siteVPNResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	network, exposed, err := r.read(ctx, site, state.ID.ValueString(), !state.LocalNetworkIDs.IsNull())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Site VPN",
+			fmt.Sprintf("Could not read site VPN %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(network, exposed, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing site-to-site VPN and the exposure of its local
+// networks.
+func (r *siteVPNResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan siteVPNResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+	id := state.ID.ValueString()
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, exposed, err := r.read(ctx, site, id, !state.LocalNetworkIDs.IsNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Site VPN for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, exposed, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "site VPN", id, &state, &verify) {
+		return
+	}
+
+	if err := r.client.UpdateSiteVPN(ctx, site, id, r.modelToAPI(&plan)); err != nil {
+		resp.Diagnostics.AddError("Error Updating Site VPN", err.Error())
+		return
+	}
+
+	if err := r.setExposure(ctx, site, state.LocalNetworkIDs, plan.LocalNetworkIDs); err != nil {
+		resp.Diagnostics.AddError("Error Exposing Local Networks", err.Error())
+		return
+	}
+
+	network, exposed, err := r.read(ctx, site, id, !plan.LocalNetworkIDs.IsNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Site VPN After Update", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	r.apiToModel(network, exposed, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete unexposes the VPN's local networks and removes the VPN from the
+// controller.
+func (r *siteVPNResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state siteVPNResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	if err := r.setExposure(ctx, site, state.LocalNetworkIDs, types.SetNull(types.StringType)); err != nil {
+		resp.Diagnostics.AddError("Error Unexposing Local Networks", err.Error())
+		return
+	}
+
+	err := r.client.DeleteNetwork(ctx, site, state.ID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting Site VPN", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_site_vpn.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *siteVPNResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// read reads the VPN network and, when withExposure is set, the IDs of the
+// site's networks that are exposed to site-to-site VPNs. A network that isn't
+// a site-to-site VPN is reported as not found, so that an ID reused by
+// another network isn't adopted.
+func (r *siteVPNResource) read(ctx context.Context, site, id string, withExposure bool) (*unifi.Network, map[string]bool, error) {
+	network, err := r.client.GetNetwork(ctx, site, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if network.Purpose != unifi.PurposeSiteVPN {
+		return nil, nil, &unifi.NotFoundError{}
+	}
+	if !withExposure {
+		return network, nil, nil
+	}
+
+	networks, err := r.client.ListNetwork(ctx, site)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing networks: %w", err)
+	}
+	exposed := map[string]bool{}
+	for _, n := range networks {
+		if n.ExposedToSiteVPN {
+			exposed[n.ID] = true
+		}
+	}
+	return network, exposed, nil
+}
+
+// setExposure exposes the networks in desired that aren't in prior, and
+// unexposes the ones in prior that aren't in desired.
+func (r *siteVPNResource) setExposure(ctx context.Context, site string, prior, desired types.Set) error {
+	priorIDs := sortedSetStrings(prior)
+	desiredIDs := sortedSetStrings(desired)
+	for _, id := range priorIDs {
+		if slices.Contains(desiredIDs, id) {
+			continue
+		}
+		if err := r.client.SetNetworkExposedToSiteVPN(ctx, site, id, false); err != nil {
+			if _, ok := err.(*unifi.NotFoundError); ok {
+				continue
+			}
+			return fmt.Errorf("unexposing network %s: %w", id, err)
+		}
+	}
+	for _, id := range desiredIDs {
+		if err := r.client.SetNetworkExposedToSiteVPN(ctx, site, id, true); err != nil {
+			return fmt.Errorf("exposing network %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// modelToAPI converts our Terraform model to the site VPN payload.
+func (r *siteVPNResource) modelToAPI(m *siteVPNResourceModel) *siteVPNPayload {
+	payload := &siteVPNPayload{
+		Name:             m.Name.ValueString(),
+		Purpose:          unifi.PurposeSiteVPN,
+		VPNType:          siteVPNTypes[m.Type.ValueString()],
+		Enabled:          m.Enabled.ValueBool(),
+		RemoteVPNSubnets: sortedSetStrings(m.RemoteSubnets),
+		RouteDistance:    m.RouteDistance.ValueInt64Pointer(),
+	}
+
+	switch m.Type.ValueString() {
+	case "wireguard":
+		payload.IPSubnet = m.TunnelIP.ValueString()
+		payload.WireguardClientMode = "manual"
+		payload.WireguardClientPeerIP = m.RemoteGateway.ValueString()
+		payload.WireguardClientPeerPort = m.RemotePort.ValueInt64Pointer()
+		payload.WireguardClientPeerPublicKey = m.RemotePublicKey.ValueString()
+		payload.WireguardClientPresharedKey = m.PreSharedKey.ValueString()
+		payload.WireguardClientPresharedKeyEnabled = boolPtr(payload.WireguardClientPresharedKey != "")
+		payload.WireguardInterface = m.WANInterface.ValueString()
+		payload.WireguardLocalWANIP = "any"
+		payload.WireguardPrivateKey = m.PrivateKey.ValueString()
+		if publicKey, err := wireguardPublicKey(payload.WireguardPrivateKey); err == nil {
+			payload.WireguardPublicKey = publicKey
+		}
+	case "ipsec":
+		payload.IPSecPeerIP = m.RemoteGateway.ValueString()
+		payload.IPSecInterface = m.WANInterface.ValueString()
+		payload.IPSecLocalIP = "any"
+		payload.IPSecPreSharedKey = m.PreSharedKey.ValueString()
+		payload.IPSecDynamicRouting = m.RouteBased.ValueBool()
+		payload.IPSecProfile = "customized"
+		payload.IPSecKeyExchange = m.IKEVersion.ValueString()
+		payload.IPSecIkeEncryption = m.Encryption.ValueString()
+		payload.IPSecEspEncryption = m.Encryption.ValueString()
+		payload.IPSecEncryption = m.Encryption.ValueString()
+		payload.IPSecIkeHash = m.Hash.ValueString()
+		payload.IPSecEspHash = m.Hash.ValueString()
+		payload.IPSecHash = m.Hash.ValueString()
+		payload.IPSecIkeDhGroup = m.DHGroup.ValueInt64()
+		payload.IPSecEspDhGroup = m.DHGroup.ValueInt64()
+		payload.IPSecDhGroup = m.DHGroup.ValueInt64()
+		payload.IPSecPfs = m.PFS.ValueBool()
+		if !m.TunnelIP.IsNull() && !m.TunnelIP.IsUnknown() {
+			payload.IPSecTunnelIPEnabled = true
+			payload.IPSecTunnelIP = m.TunnelIP.ValueString()
+		}
+	}
+
+	return payload
+}
+
+// apiToModel converts the VPN network back to our Terraform model. Keys the
+// controller doesn't return are kept from the model. local_network_ids is only read when the model manages it, and then
+// only reports its own networks that are still exposed, so that networks
+// exposed for other tunnels don't show up as a diff.
+func (r *siteVPNResource) apiToModel(n *unifi.Network, exposed map[string]bool, m *siteVPNResourceModel, site string) {
+	m.ID = types.StringValue(n.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringPointerValue(n.Name)
+	m.Enabled = types.BoolValue(n.Enabled)
+	m.RemoteSubnets = stringSetValue(n.RemoteVPNSubnets, nil)
+	m.RouteDistance = types.Int64PointerValue(n.RouteDistance)
+
+	m.Type = types.StringValue("ipsec")
+	if n.VPNType != nil && *n.VPNType == siteVPNTypes["wireguard"] {
+		m.Type = types.StringValue("wireguard")
+	}
+
+	m.TunnelIP = types.StringNull()
+	m.RemotePort = types.Int64Null()
+	m.RemotePublicKey = types.StringNull()
+	m.PublicKey = types.StringNull()
+	m.RouteBased = types.BoolNull()
+	m.IKEVersion = types.StringNull()
+	m.Encryption = types.StringNull()
+	m.Hash = types.StringNull()
+	m.DHGroup = types.Int64Null()
+	m.PFS = types.BoolNull()
+
+	switch m.Type.ValueString() {
+	case "wireguard":
+		m.RemoteGateway = types.StringPointerValue(n.WireguardClientPeerIP)
+		m.RemotePort = types.Int64PointerValue(n.WireguardClientPeerPort)
+		m.RemotePublicKey = types.StringPointerValue(n.WireguardClientPeerPublicKey)
+		m.WANInterface = types.StringPointerValue(n.WireguardInterface)
+		if !n.WireguardClientPresharedKeyEnabled {
+			m.PreSharedKey = types.StringNull()
+		} else if n.WireguardClientPresharedKey != nil && *n.WireguardClientPresharedKey != "" {
+			m.PreSharedKey = types.StringValue(*n.WireguardClientPresharedKey)
+		}
+		if n.WireguardPrivateKey != nil && *n.WireguardPrivateKey != "" {
+			m.PrivateKey = types.StringValue(*n.WireguardPrivateKey)
+		} else if m.PrivateKey.IsUnknown() {
+			m.PrivateKey = types.StringNull()
+		}
+		m.PublicKey = types.StringPointerValue(n.WireguardPublicKey)
+		if n.IPSubnet != nil {
+			m.TunnelIP = stringValueOrNull(*n.IPSubnet)
+		}
+	case "ipsec":
+		m.RemoteGateway = types.StringPointerValue(n.IPSecPeerIP)
+		m.WANInterface = types.StringPointerValue(n.IPSecInterface)
+		if n.XIPSecPreSharedKey != nil && *n.XIPSecPreSharedKey != "" {
+			m.PreSharedKey = types.StringValue(*n.XIPSecPreSharedKey)
+		}
+		m.PrivateKey = types.StringNull()
+		m.RouteBased = types.BoolValue(n.IPSecDynamicRouting)
+		if n.IPSecTunnelIPEnabled && n.IPSecTunnelIP != nil {
+			m.TunnelIP = stringValueOrNull(*n.IPSecTunnelIP)
+		}
+		m.IKEVersion = types.StringPointerValue(n.IPSecKeyExchange)
+		m.Encryption = types.StringPointerValue(n.IPSecIkeEncryption)
+		m.Hash = types.StringPointerValue(n.IPSecIkeHash)
+		m.DHGroup = types.Int64PointerValue(n.IPSecIkeDhGroup)
+		m.PFS = types.BoolValue(n.IPSecPfs)
+	}
+
+	if !m.LocalNetworkIDs.IsNull() && !m.LocalNetworkIDs.IsUnknown() && exposed != nil {
+		ids := []attr.Value{}
+		for _, id := range sortedSetStrings(m.LocalNetworkIDs) {
+			if exposed[id] {
+				ids = append(ids, types.StringValue(id))
+			}
+		}
+		m.LocalNetworkIDs = types.SetValueMust(types.StringType, ids)
+	}
+}
+
+// siteVPNTypeFieldsValidator ensures the IPsec and WireGuard attributes are
+// only set for their type, and that each type has what it needs to connect.
+type siteVPNTypeFieldsValidator struct{}
+
+func (v siteVPNTypeFieldsValidator) Description(_ context.Context) string {
+	return "pre_shared_key is required for IPsec VPNs, and route_based, ike_version, encryption, hash, dh_group " +
+		"and pfs can only be set for them; remote_public_key and tunnel_ip are required for WireGuard VPNs, and " +
+		"remote_port and private_key can only be set for them."
+}
+
+func (v siteVPNTypeFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v siteVPNTypeFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config siteVPNResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() {
+		return
+	}
+	vpnType := "ipsec"
+	if !config.Type.IsNull() {
+		vpnType = config.Type.ValueString()
+	}
+
+	// Shared attributes may also be set for the other type.
+	fields := []struct {
+		name     string
+		set      bool
+		forType  string
+		required bool
+		shared   bool
+	}{
+		{"pre_shared_key", !config.PreSharedKey.IsNull(), "ipsec", true, true},
+		{"route_based", !config.RouteBased.IsNull(), "ipsec", false, false},
+		{"ike_version", !config.IKEVersion.IsNull(), "ipsec", false, false},
+		{"encryption", !config.Encryption.IsNull(), "ipsec", false, false},
+		{"hash", !config.Hash.IsNull(), "ipsec", false, false},
+		{"dh_group", !config.DHGroup.IsNull(), "ipsec", false, false},
+		{"pfs", !config.PFS.IsNull(), "ipsec", false, false},
+		{"remote_public_key", !config.RemotePublicKey.IsNull(), "wireguard", true, false},
+		{"tunnel_ip", !config.TunnelIP.IsNull(), "wireguard", true, true},
+		{"remote_port", !config.RemotePort.IsNull(), "wireguard", false, false},
+		{"private_key", !config.PrivateKey.IsNull(), "wireguard", false, false},
+	}
+	for _, f := range fields {
+		switch {
+		case vpnType == f.forType && f.required && !f.set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Missing Required Attribute",
+				fmt.Sprintf("%s is required when type is %q.", f.name, f.forType),
+			)
+		case vpnType != f.forType && f.set && !f.shared:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s can only be set when type is %q.", f.name, f.forType),
+			)
+		}
+	}
+
+	psk := config.PreSharedKey
+	if vpnType == "wireguard" && !psk.IsNull() && !psk.IsUnknown() && !wireguardKeyRegexp.MatchString(psk.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pre_shared_key"),
+			"Invalid Attribute Value",
+			"pre_shared_key must be a base64-encoded WireGuard key when type is \"wireguard\".",
+		)
+	}
+}
@@ -0,0 +1,539 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func baseSiteVPNModel() siteVPNResourceModel {
+	return siteVPNResourceModel{
+		Name:            types.StringValue("Branch"),
+		Type:            types.StringValue("ipsec"),
+		Enabled:         types.BoolValue(true),
+		RemoteGateway:   types.StringValue("203.0.113.20"),
+		WANInterface:    types.StringValue("wan"),
+		PreSharedKey:    types.StringValue("shared-secret"),
+		RemoteSubnets:   stringSet("10.20.0.0/16", "10.10.0.0/16"),
+		LocalNetworkIDs: types.SetNull(types.StringType),
+		RouteBased:      types.BoolValue(true),
+		RouteDistance:   types.Int64Value(30),
+		TunnelIP:        types.StringNull(),
+		IKEVersion:      types.StringValue("ikev2"),
+		Encryption:      types.StringValue("aes256"),
+		Hash:            types.StringValue("sha256"),
+		DHGroup:         types.Int64Value(14),
+		PFS:             types.BoolValue(true),
+	}
+}
+
+func baseWireGuardSiteVPNModel() siteVPNResourceModel {
+	return siteVPNResourceModel{
+		Name:            types.StringValue("Branch"),
+		Type:            types.StringValue("wireguard"),
+		Enabled:         types.BoolValue(true),
+		RemoteGateway:   types.StringValue("203.0.113.20"),
+		RemotePort:      types.Int64Value(51820),
+		RemotePublicKey: types.StringValue(testWireGuardPublicKey),
+		WANInterface:    types.StringValue("wan"),
+		PreSharedKey:    types.StringNull(),
+		PrivateKey:      types.StringValue(testWireGuardPrivateKey),
+		RemoteSubnets:   stringSet("10.20.0.0/16"),
+		LocalNetworkIDs: types.SetNull(types.StringType),
+		RouteDistance:   types.Int64Value(30),
+		TunnelIP:        types.StringValue("10.255.0.2/30"),
+	}
+}
+
+func TestSiteVPNModelToAPI(t *testing.T) {
+	r := &siteVPNResource{}
+
+	t.Run("defaults", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, "Branch", payload.Name)
+		assert.Equal(t, unifi.PurposeSiteVPN, payload.Purpose)
+		assert.Equal(t, "ipsec-vpn", payload.VPNType)
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "203.0.113.20", payload.IPSecPeerIP)
+		assert.Equal(t, "wan", payload.IPSecInterface)
+		assert.Equal(t, "any", payload.IPSecLocalIP)
+		assert.Equal(t, "shared-secret", payload.IPSecPreSharedKey)
+		assert.Equal(t, []string{"10.10.0.0/16", "10.20.0.0/16"}, payload.RemoteVPNSubnets)
+		assert.True(t, payload.IPSecDynamicRouting)
+		require.NotNil(t, payload.RouteDistance)
+		assert.Equal(t, int64(30), *payload.RouteDistance)
+		assert.False(t, payload.IPSecTunnelIPEnabled)
+		assert.Empty(t, payload.IPSecTunnelIP)
+		assert.Equal(t, "ikev2", payload.IPSecKeyExchange)
+		assert.Equal(t, "customized", payload.IPSecProfile)
+	})
+
+	t.Run("proposal applies to both phases", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		m.Encryption = types.StringValue("aes128")
+		m.Hash = types.StringValue("sha512")
+		m.DHGroup = types.Int64Value(19)
+		m.PFS = types.BoolValue(false)
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, "aes128", payload.IPSecIkeEncryption)
+		assert.Equal(t, "aes128", payload.IPSecEspEncryption)
+		assert.Equal(t, "aes128", payload.IPSecEncryption)
+		assert.Equal(t, "sha512", payload.IPSecIkeHash)
+		assert.Equal(t, "sha512", payload.IPSecEspHash)
+		assert.Equal(t, "sha512", payload.IPSecHash)
+		assert.Equal(t, int64(19), payload.IPSecIkeDhGroup)
+		assert.Equal(t, int64(19), payload.IPSecEspDhGroup)
+		assert.Equal(t, int64(19), payload.IPSecDhGroup)
+		assert.False(t, payload.IPSecPfs)
+	})
+
+	t.Run("tunnel IP", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		m.TunnelIP = types.StringValue("10.255.0.1/30")
+		payload := r.modelToAPI(&m)
+
+		assert.True(t, payload.IPSecTunnelIPEnabled)
+		assert.Equal(t, "10.255.0.1/30", payload.IPSecTunnelIP)
+	})
+
+	t.Run("wireguard", func(t *testing.T) {
+		m := baseWireGuardSiteVPNModel()
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, unifi.PurposeSiteVPN, payload.Purpose)
+		assert.Equal(t, "wireguard-client", payload.VPNType)
+		assert.Equal(t, "manual", payload.WireguardClientMode)
+		assert.Equal(t, "203.0.113.20", payload.WireguardClientPeerIP)
+		require.NotNil(t, payload.WireguardClientPeerPort)
+		assert.Equal(t, int64(51820), *payload.WireguardClientPeerPort)
+		assert.Equal(t, testWireGuardPublicKey, payload.WireguardClientPeerPublicKey)
+		assert.Equal(t, "wan", payload.WireguardInterface)
+		assert.Equal(t, "any", payload.WireguardLocalWANIP)
+		assert.Equal(t, testWireGuardPrivateKey, payload.WireguardPrivateKey)
+		assert.Equal(t, testWireGuardPublicKey, payload.WireguardPublicKey)
+		assert.Equal(t, "10.255.0.2/30", payload.IPSubnet)
+		assert.Equal(t, []string{"10.20.0.0/16"}, payload.RemoteVPNSubnets)
+		require.NotNil(t, payload.WireguardClientPresharedKeyEnabled)
+		assert.False(t, *payload.WireguardClientPresharedKeyEnabled)
+		assert.Empty(t, payload.IPSecPeerIP)
+		assert.Empty(t, payload.IPSecPreSharedKey)
+		assert.Empty(t, payload.IPSecKeyExchange)
+		assert.Zero(t, payload.IPSecIkeDhGroup)
+	})
+
+	t.Run("wireguard pre-shared key", func(t *testing.T) {
+		m := baseWireGuardSiteVPNModel()
+		m.PreSharedKey = types.StringValue(testWireGuardPublicKey)
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, testWireGuardPublicKey, payload.WireguardClientPresharedKey)
+		require.NotNil(t, payload.WireguardClientPresharedKeyEnabled)
+		assert.True(t, *payload.WireguardClientPresharedKeyEnabled)
+	})
+
+	t.Run("ipsec omits wireguard fields", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		payload := r.modelToAPI(&m)
+
+		assert.Empty(t, payload.WireguardClientMode)
+		assert.Empty(t, payload.IPSubnet)
+		assert.Nil(t, payload.WireguardClientPeerPort)
+		assert.Nil(t, payload.WireguardClientPresharedKeyEnabled)
+	})
+}
+
+func TestSiteVPNAPIToModel(t *testing.T) {
+	r := &siteVPNResource{}
+	str := func(s string) *string { return &s }
+	distance, dhGroup := int64(40), int64(14)
+	network := &unifi.Network{
+		ID:                   "vpn-1",
+		Name:                 str("Branch"),
+		Purpose:              unifi.PurposeSiteVPN,
+		Enabled:              true,
+		IPSecPeerIP:          str("203.0.113.20"),
+		IPSecInterface:       str("wan2"),
+		RemoteVPNSubnets:     []string{"10.20.0.0/16", "10.10.0.0/16"},
+		IPSecDynamicRouting:  true,
+		RouteDistance:        &distance,
+		IPSecTunnelIPEnabled: true,
+		IPSecTunnelIP:        str("10.255.0.1/30"),
+		IPSecKeyExchange:     str("ikev1"),
+		IPSecIkeEncryption:   str("aes256"),
+		IPSecIkeHash:         str("sha1"),
+		IPSecIkeDhGroup:      &dhGroup,
+		IPSecPfs:             true,
+	}
+
+	t.Run("all fields", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		r.apiToModel(network, nil, &m, "default")
+
+		assert.Equal(t, "vpn-1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "wan2", m.WANInterface.ValueString())
+		assert.Equal(t, "shared-secret", m.PreSharedKey.ValueString(), "unreturned key kept from model")
+		assert.Equal(t, []string{"10.10.0.0/16", "10.20.0.0/16"}, sortedSetStrings(m.RemoteSubnets))
+		assert.Equal(t, int64(40), m.RouteDistance.ValueInt64())
+		assert.Equal(t, "10.255.0.1/30", m.TunnelIP.ValueString())
+		assert.Equal(t, "ikev1", m.IKEVersion.ValueString())
+		assert.Equal(t, "sha1", m.Hash.ValueString())
+		assert.Equal(t, int64(14), m.DHGroup.ValueInt64())
+		assert.True(t, m.LocalNetworkIDs.IsNull())
+	})
+
+	t.Run("local networks only report their own", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		m.LocalNetworkIDs = stringSet("lan", "iot")
+		r.apiToModel(network, map[string]bool{"lan": true, "servers": true}, &m, "default")
+
+		assert.Equal(t, []string{"lan"}, sortedSetStrings(m.LocalNetworkIDs))
+	})
+
+	t.Run("no local networks exposed", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		m.LocalNetworkIDs = stringSet("lan")
+		r.apiToModel(network, map[string]bool{}, &m, "default")
+
+		assert.False(t, m.LocalNetworkIDs.IsNull())
+		assert.Empty(t, m.LocalNetworkIDs.Elements())
+	})
+
+	t.Run("ipsec nulls wireguard attributes", func(t *testing.T) {
+		m := baseSiteVPNModel()
+		r.apiToModel(network, nil, &m, "default")
+
+		assert.Equal(t, "ipsec", m.Type.ValueString())
+		assert.True(t, m.RemotePort.IsNull())
+		assert.True(t, m.RemotePublicKey.IsNull())
+		assert.True(t, m.PrivateKey.IsNull())
+		assert.True(t, m.PublicKey.IsNull())
+	})
+
+	t.Run("wireguard", func(t *testing.T) {
+		port := int64(51821)
+		m := baseWireGuardSiteVPNModel()
+		m.PrivateKey = types.StringUnknown()
+		m.PreSharedKey = types.StringValue(testWireGuardPublicKey)
+		r.apiToModel(&unifi.Network{
+			ID:                                 "vpn-2",
+			Name:                               str("Branch"),
+			Purpose:                            unifi.PurposeSiteVPN,
+			VPNType:                            str("wireguard-client"),
+			Enabled:                            true,
+			IPSubnet:                           str("10.255.0.2/30"),
+			RemoteVPNSubnets:                   []string{"10.20.0.0/16"},
+			RouteDistance:                      &distance,
+			WireguardClientMode:                str("manual"),
+			WireguardClientPeerIP:              str("203.0.113.21"),
+			WireguardClientPeerPort:            &port,
+			WireguardClientPeerPublicKey:       str(testPeerPublicKey),
+			WireguardClientPresharedKeyEnabled: true,
+			WireguardInterface:                 str("wan2"),
+			WireguardPrivateKey:                str(testWireGuardPrivateKey),
+			WireguardPublicKey:                 str(testWireGuardPublicKey),
+		}, nil, &m, "default")
+
+		assert.Equal(t, "wireguard", m.Type.ValueString())
+		assert.Equal(t, "203.0.113.21", m.RemoteGateway.ValueString())
+		assert.Equal(t, int64(51821), m.RemotePort.ValueInt64())
+		assert.Equal(t, testPeerPublicKey, m.RemotePublicKey.ValueString())
+		assert.Equal(t, "wan2", m.WANInterface.ValueString())
+		assert.Equal(t, testWireGuardPublicKey, m.PreSharedKey.ValueString(), "unreturned key kept from model")
+		assert.Equal(t, testWireGuardPrivateKey, m.PrivateKey.ValueString())
+		assert.Equal(t, testWireGuardPublicKey, m.PublicKey.ValueString())
+		assert.Equal(t, "10.255.0.2/30", m.TunnelIP.ValueString())
+		assert.True(t, m.RouteBased.IsNull())
+		assert.True(t, m.IKEVersion.IsNull())
+		assert.True(t, m.DHGroup.IsNull())
+		assert.True(t, m.PFS.IsNull())
+	})
+
+	t.Run("wireguard pre-shared key disabled", func(t *testing.T) {
+		m := baseWireGuardSiteVPNModel()
+		m.PreSharedKey = types.StringValue(testWireGuardPublicKey)
+		r.apiToModel(&unifi.Network{
+			ID:      "vpn-2",
+			Purpose: unifi.PurposeSiteVPN,
+			VPNType: str("wireguard-client"),
+		}, nil, &m, "default")
+
+		assert.True(t, m.PreSharedKey.IsNull())
+		assert.Equal(t, testWireGuardPrivateKey, m.PrivateKey.ValueString(), "unreturned key kept from model")
+	})
+}
+
+func TestSiteVPNSetExposure(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("exposes added and unexposes removed networks", func(t *testing.T) {
+		fake := newFakeClient()
+		fake.siteVPNExposed = map[string]bool{"lan": true, "iot": true, "servers": false}
+		r := &siteVPNResource{client: fake}
+
+		err := r.setExposure(ctx, "default", stringSet("lan", "iot"), stringSet("lan", "servers"))
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"lan": true, "iot": false, "servers": true}, fake.siteVPNExposed)
+	})
+
+	t.Run("deleted network is skipped when unexposing", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &siteVPNResource{client: fake}
+
+		err := r.setExposure(ctx, "default", stringSet("gone"), types.SetNull(types.StringType))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.calls["SetNetworkExposedToSiteVPN"])
+	})
+
+	t.Run("unknown network is an error when exposing", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &siteVPNResource{client: fake}
+
+		err := r.setExposure(ctx, "default", types.SetNull(types.StringType), stringSet("missing"))
+
+		assert.ErrorContains(t, err, "exposing network missing")
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccSiteVPN_basic(t *testing.T) {
+	requireHardware(t)
+	name := fmt.Sprintf("tfacc-s2s-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name           = %q
+  remote_gateway = "203.0.113.20"
+  pre_shared_key = "initial-secret"
+  remote_subnets = ["10.220.0.0/16"]
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_site_vpn.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "route_based", "true"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "route_distance", "30"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "ike_version", "ikev2"),
+				),
+			},
+			{
+				ResourceName:            "terrifi_site_vpn.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"pre_shared_key"},
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name           = %q
+  remote_gateway = "203.0.113.21"
+  pre_shared_key = "changed-secret"
+  remote_subnets = ["10.220.0.0/16", "10.221.0.0/16"]
+  route_distance = 50
+  tunnel_ip      = "10.255.0.1/30"
+  encryption     = "aes128"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_gateway", "203.0.113.21"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_subnets.#", "2"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "route_distance", "50"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "tunnel_ip", "10.255.0.1/30"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "encryption", "aes128"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSiteVPN_wireguard(t *testing.T) {
+	requireHardware(t)
+	name := fmt.Sprintf("tfacc-s2s-wg-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name              = %q
+  type              = "wireguard"
+  remote_gateway    = "203.0.113.20"
+  remote_public_key = %q
+  remote_subnets    = ["10.223.0.0/16"]
+  tunnel_ip         = "10.255.1.2/30"
+}
+`, name, testPeerPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_site_vpn.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_port", "51820"),
+					resource.TestCheckResourceAttrSet("terrifi_site_vpn.test", "private_key"),
+					resource.TestCheckResourceAttrSet("terrifi_site_vpn.test", "public_key"),
+					resource.TestCheckNoResourceAttr("terrifi_site_vpn.test", "ike_version"),
+				),
+			},
+			{
+				ResourceName:            "terrifi_site_vpn.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key", "pre_shared_key"},
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name              = %q
+  type              = "wireguard"
+  remote_gateway    = "203.0.113.21"
+  remote_port       = 51821
+  remote_public_key = %q
+  remote_subnets    = ["10.223.0.0/16", "10.224.0.0/16"]
+  tunnel_ip         = "10.255.1.2/30"
+  private_key       = %q
+  pre_shared_key    = %q
+}
+`, name, testPeerPublicKey, testWireGuardPrivateKey, testPeerPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_gateway", "203.0.113.21"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_port", "51821"),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "public_key", testWireGuardPublicKey),
+					resource.TestCheckResourceAttr("terrifi_site_vpn.test", "remote_subnets.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSiteVPN_localNetworks(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name    = "tfacc-s2s-net-%[1]s"
+  purpose = "corporate"
+  vlan_id = %[2]d
+  subnet  = "10.%[2]d.0.1/24"
+}
+
+resource "terrifi_site_vpn" "test" {
+  name              = "tfacc-s2s-%[1]s"
+  remote_gateway    = "203.0.113.20"
+  pre_shared_key    = "secret"
+  remote_subnets    = ["10.222.0.0/16"]
+  local_network_ids = [terrifi_network.test.id]
+}
+`, suffix, vlan%256),
+				Check: resource.TestCheckResourceAttr("terrifi_site_vpn.test", "local_network_ids.#", "1"),
+			},
+		},
+	})
+}
+
+func TestAccSiteVPN_validationRemoteSubnets(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_site_vpn" "test" {
+  name           = "tfacc-invalid"
+  remote_gateway = "203.0.113.20"
+  pre_shared_key = "secret"
+  remote_subnets = ["10.220.0.0"]
+}
+`,
+				ExpectError: regexp.MustCompile(`must be an IPv4 subnet in CIDR notation`),
+			},
+		},
+	})
+}
+
+func TestAccSiteVPN_validationTypeFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_site_vpn" "test" {
+  name           = "tfacc-invalid"
+  remote_gateway = "203.0.113.20"
+  remote_subnets = ["10.220.0.0/16"]
+}
+`,
+				ExpectError: regexp.MustCompile(`pre_shared_key is required when type is "ipsec"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name              = "tfacc-invalid"
+  type              = "wireguard"
+  remote_gateway    = "203.0.113.20"
+  remote_public_key = %q
+  remote_subnets    = ["10.220.0.0/16"]
+}
+`, testPeerPublicKey),
+				ExpectError: regexp.MustCompile(`tunnel_ip is required when type is "wireguard"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name              = "tfacc-invalid"
+  type              = "wireguard"
+  remote_gateway    = "203.0.113.20"
+  remote_public_key = %q
+  remote_subnets    = ["10.220.0.0/16"]
+  tunnel_ip         = "10.255.1.2/30"
+  encryption        = "aes128"
+}
+`, testPeerPublicKey),
+				ExpectError: regexp.MustCompile(`encryption can only be set when type is "ipsec"`),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_site_vpn" "test" {
+  name              = "tfacc-invalid"
+  type              = "wireguard"
+  remote_gateway    = "203.0.113.20"
+  remote_public_key = %q
+  remote_subnets    = ["10.220.0.0/16"]
+  tunnel_ip         = "10.255.1.2/30"
+  pre_shared_key    = "not-a-key"
+}
+`, testPeerPublicKey),
+				ExpectError: regexp.MustCompile(`must be a base64-encoded WireGuard key`),
+			},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestCreateTrafficRoute(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		gotPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Write([]byte(`{"_id": "route-1", "matching_target": "DOMAIN"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	created, err := client.CreateTrafficRoute(context.Background(), "default", &trafficRoute{
+		ID:             "ignored",
+		MatchingTarget: "DOMAIN",
+		Domains:        []trafficRouteDomain{{Domain: "example.com"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "route-1", created.ID)
+	assert.Equal(t, "/proxy/network/v2/api/site/default/trafficroutes", gotPath)
+	assert.NotContains(t, gotBody, "_id")
+	// The controller rejects null lists, so unset ones are sent as [].
+	for _, key := range []string{"target_devices", "ip_addresses", "ip_ranges", "regions"} {
+		assert.Equal(t, []any{}, gotBody[key], key)
+	}
+	assert.Equal(t, []any{map[string]any{
+		"domain":      "example.com",
+		"port_ranges": []any{},
+		"ports":       []any{},
+	}}, gotBody["domains"])
+}
+
+func TestUpdateTrafficRoute(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		gotPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Write([]byte(`{"_id": "route-1"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	_, err := client.UpdateTrafficRoute(context.Background(), "default", &trafficRoute{ID: "route-1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/proxy/network/v2/api/site/default/trafficroutes/route-1", gotPath)
+	assert.Equal(t, "route-1", gotBody["_id"])
+}
+
+func TestGetTrafficRoute(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"_id": "route-1", "description": "one", "matching_target": "DOMAIN",
+			 "domains": [{"domain": "example.com", "port_ranges": [], "ports": [443]}],
+			 "target_devices": [{"type": "NETWORK", "network_id": "net-1"}]},
+			{"_id": "route-2", "description": "two"}
+		]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	route, err := client.GetTrafficRoute(context.Background(), "default", "route-1")
+	require.NoError(t, err)
+	assert.Equal(t, "one", route.Description)
+	assert.Equal(t, []trafficRouteDomain{{Domain: "example.com", PortRanges: []string{}, Ports: []int64{443}}}, route.Domains)
+	assert.Equal(t, []trafficRuleTarget{{Type: "NETWORK", NetworkID: "net-1"}}, route.TargetDevices)
+
+	_, err = client.GetTrafficRoute(context.Background(), "default", "missing")
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
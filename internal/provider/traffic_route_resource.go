@@ -0,0 +1,591 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &trafficRouteResource{}
+	_ resource.ResourceWithImportState = &trafficRouteResource{}
+)
+
+func NewTrafficRouteResource() resource.Resource {
+	return &trafficRouteResource{}
+}
+
+type trafficRouteResource struct {
+	client ClientAPI
+}
+
+type trafficRouteResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Site               types.String `tfsdk:"site"`
+	Description        types.String `tfsdk:"description"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	InterfaceNetworkID types.String `tfsdk:"interface_network_id"`
+	NextHop            types.String `tfsdk:"next_hop"`
+	KillSwitch         types.Bool   `tfsdk:"kill_switch"`
+
+	// Which clients are routed. Neither set means all clients.
+	MACAddresses types.Set `tfsdk:"mac_addresses"`
+	NetworkIDs   types.Set `tfsdk:"network_ids"`
+
+	// Which destinations are routed. At most one is set; none means all
+	// internet traffic.
+	Domains types.Set `tfsdk:"domains"`
+	IPs     types.Set `tfsdk:"ips"`
+	Regions types.Set `tfsdk:"regions"`
+}
+
+func (r *trafficRouteResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_traffic_route"
+}
+
+func (r *trafficRouteResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	destinations := path.Expressions{
+		path.MatchRoot("domains"),
+		path.MatchRoot("ips"),
+		path.MatchRoot("regions"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a traffic route (policy-based route) on the UniFi controller. A traffic route " +
+			"sends traffic from the selected clients to the selected destinations out of a specific WAN or VPN " +
+			"client network instead of the default route.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the traffic route.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the traffic route with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The name of the route, shown in the UniFi UI.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the route is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"interface_network_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the WAN or VPN client network that matching traffic is sent out of, " +
+					"e.g. from `terrifi_wan_networks`.",
+				Required: true,
+			},
+
+			"next_hop": schema.StringAttribute{
+				MarkdownDescription: "The IP address of the gateway to send matching traffic to. Only needed when " +
+					"the interface has more than one possible gateway.",
+				Optional: true,
+				Validators: []validator.String{
+					trafficRouteIPValidator{allowRanges: false},
+				},
+			},
+
+			"kill_switch": schema.BoolAttribute{
+				MarkdownDescription: "Whether to block matching traffic while the interface is down, instead of " +
+					"letting it fall back to the default route. Default: `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+
+			"mac_addresses": schema.SetAttribute{
+				MarkdownDescription: "MAC addresses of the clients whose traffic is routed. Can be combined with " +
+					"`network_ids`. When neither is set, the route applies to all clients.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(
+							macRegexp,
+							"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+						),
+					),
+				},
+			},
+
+			"network_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the networks whose clients' traffic is routed. Can be combined with " +
+					"`mac_addresses`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "Domains to route (e.g. `example.com`). Conflicts with `ips` and `regions`. " +
+					"When none of the three is set, all internet traffic is routed.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+				},
+			},
+
+			"ips": schema.SetAttribute{
+				MarkdownDescription: "IP addresses, CIDR subnets, or ranges (`192.168.1.10-192.168.1.20`) to " +
+					"route. IPv4 and IPv6 are both accepted. Conflicts with `domains` and `regions`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+					setvalidator.ValueStringsAre(trafficRouteIPValidator{allowRanges: true}),
+				},
+			},
+
+			"regions": schema.SetAttribute{
+				MarkdownDescription: "Two-letter country codes (e.g. `US`) whose traffic is routed. Conflicts with " +
+					"`domains` and `ips`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(countryCodeRegexp, "must be an uppercase two-letter country code"),
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *trafficRouteResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *trafficRouteResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan trafficRouteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	route, err := r.modelToAPI(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Traffic Route", err.Error())
+		return
+	}
+
+	created, err := r.client.CreateTrafficRoute(ctx, site, route)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Traffic Route", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *trafficRouteResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state trafficRouteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	route, err := r.client.GetTrafficRoute(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Traffic Route",
+			fmt.Sprintf("Could not read traffic route %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(route, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *trafficRouteResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan trafficRouteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.applyPlanToState(&plan, &state)
+
+	site := r.client.SiteOrDefault(state.Site)
+	route, err := r.modelToAPI(ctx, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Traffic Route", err.Error())
+		return
+	}
+	route.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateTrafficRoute(ctx, site, route)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Traffic Route", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *trafficRouteResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state trafficRouteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteTrafficRoute(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting Traffic Route", err.Error())
+	}
+}
+
+func (r *trafficRouteResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// countryCodeRegexp matches the ISO 3166-1 alpha-2 codes the controller uses
+// for region matching.
+var countryCodeRegexp = regexp.MustCompile(`^[A-Z]{2}$`)
+
+func (r *trafficRouteResource) applyPlanToState(plan, state *trafficRouteResourceModel) {
+	state.Description = plan.Description
+	state.InterfaceNetworkID = plan.InterfaceNetworkID
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		state.Enabled = plan.Enabled
+	}
+	if !plan.KillSwitch.IsNull() && !plan.KillSwitch.IsUnknown() {
+		state.KillSwitch = plan.KillSwitch
+	}
+	// Optional attributes without defaults: a null plan value means the user
+	// removed the attribute.
+	state.NextHop = plan.NextHop
+	state.MACAddresses = plan.MACAddresses
+	state.NetworkIDs = plan.NetworkIDs
+	state.Domains = plan.Domains
+	state.IPs = plan.IPs
+	state.Regions = plan.Regions
+}
+
+func (r *trafficRouteResource) modelToAPI(ctx context.Context, m *trafficRouteResourceModel) (*trafficRoute, error) {
+	route := &trafficRoute{
+		Description:       m.Description.ValueString(),
+		Enabled:           m.Enabled.ValueBool(),
+		KillSwitchEnabled: m.KillSwitch.ValueBool(),
+		NetworkID:         m.InterfaceNetworkID.ValueString(),
+		NextHop:           m.NextHop.ValueString(),
+		MatchingTarget:    "INTERNET",
+	}
+
+	for _, mac := range setStrings(ctx, m.MACAddresses) {
+		route.TargetDevices = append(route.TargetDevices, trafficRuleTarget{
+			Type:      "CLIENT",
+			ClientMAC: strings.ToLower(mac),
+		})
+	}
+	for _, id := range setStrings(ctx, m.NetworkIDs) {
+		route.TargetDevices = append(route.TargetDevices, trafficRuleTarget{
+			Type:      "NETWORK",
+			NetworkID: id,
+		})
+	}
+	if len(route.TargetDevices) == 0 {
+		route.TargetDevices = []trafficRuleTarget{{Type: "ALL_CLIENTS"}}
+	}
+
+	switch {
+	case !m.Domains.IsNull() && !m.Domains.IsUnknown():
+		route.MatchingTarget = "DOMAIN"
+		for _, d := range setStrings(ctx, m.Domains) {
+			route.Domains = append(route.Domains, trafficRouteDomain{Domain: d})
+		}
+	case !m.IPs.IsNull() && !m.IPs.IsUnknown():
+		route.MatchingTarget = "IP"
+		for _, s := range setStrings(ctx, m.IPs) {
+			addr, rng, err := parseTrafficRouteIP(s)
+			if err != nil {
+				return nil, err
+			}
+			if rng != nil {
+				route.IPRanges = append(route.IPRanges, *rng)
+			} else {
+				route.IPAddresses = append(route.IPAddresses, *addr)
+			}
+		}
+	case !m.Regions.IsNull() && !m.Regions.IsUnknown():
+		route.MatchingTarget = "REGION"
+		route.Regions = setStrings(ctx, m.Regions)
+	}
+
+	return route, nil
+}
+
+func (r *trafficRouteResource) apiToModel(route *trafficRoute, m *trafficRouteResourceModel, site string) {
+	m.ID = types.StringValue(route.ID)
+	m.Site = types.StringValue(site)
+	m.Description = types.StringValue(route.Description)
+	m.Enabled = types.BoolValue(route.Enabled)
+	m.KillSwitch = types.BoolValue(route.KillSwitchEnabled)
+	m.InterfaceNetworkID = types.StringValue(route.NetworkID)
+	m.NextHop = stringValueOrNull(route.NextHop)
+
+	var macs, networkIDs []string
+	for _, t := range route.TargetDevices {
+		switch t.Type {
+		case "CLIENT":
+			macs = append(macs, t.ClientMAC)
+		case "NETWORK":
+			networkIDs = append(networkIDs, t.NetworkID)
+		}
+	}
+	// The controller stores MACs in lowercase; keep the configured spelling
+	// when it only differs in case.
+	if !sameMACs(setStrings(context.Background(), m.MACAddresses), macs) {
+		m.MACAddresses = stringSetOrNull(macs)
+	}
+	m.NetworkIDs = stringSetOrNull(networkIDs)
+
+	var domains, ips []string
+	for _, d := range route.Domains {
+		domains = append(domains, d.Domain)
+	}
+	for _, a := range route.IPAddresses {
+		ips = append(ips, a.IPOrSubnet)
+	}
+	for _, rng := range route.IPRanges {
+		ips = append(ips, rng.IPStart+"-"+rng.IPStop)
+	}
+
+	m.Domains = types.SetNull(types.StringType)
+	m.IPs = types.SetNull(types.StringType)
+	m.Regions = types.SetNull(types.StringType)
+	switch route.MatchingTarget {
+	case "DOMAIN":
+		m.Domains = stringSetOrNull(domains)
+	case "IP":
+		m.IPs = stringSetOrNull(ips)
+	case "REGION":
+		m.Regions = stringSetOrNull(route.Regions)
+	}
+}
+
+// parseTrafficRouteIP parses an ips entry: an address, a CIDR subnet, or a
+// "start-stop" range. Exactly one of the results is non-nil on success.
+func parseTrafficRouteIP(s string) (*trafficRouteIPAddress, *trafficRouteIPRange, error) {
+	if start, stop, ok := strings.Cut(s, "-"); ok {
+		a, errA := netip.ParseAddr(strings.TrimSpace(start))
+		b, errB := netip.ParseAddr(strings.TrimSpace(stop))
+		if errA != nil || errB != nil {
+			return nil, nil, fmt.Errorf("%q is not a valid IP range (e.g. 192.168.1.10-192.168.1.20)", s)
+		}
+		if a.Is4() != b.Is4() {
+			return nil, nil, fmt.Errorf("IP range %q mixes IPv4 and IPv6", s)
+		}
+		if b.Less(a) {
+			return nil, nil, fmt.Errorf("IP range %q ends before it starts", s)
+		}
+		return nil, &trafficRouteIPRange{IPStart: a.String(), IPStop: b.String(), IPVersion: ipVersion(a)}, nil
+	}
+
+	if prefix, err := netip.ParsePrefix(s); err == nil {
+		return &trafficRouteIPAddress{IPOrSubnet: s, IPVersion: ipVersion(prefix.Addr())}, nil, nil
+	}
+	if addr, err := netip.ParseAddr(s); err == nil {
+		return &trafficRouteIPAddress{IPOrSubnet: s, IPVersion: ipVersion(addr)}, nil, nil
+	}
+	return nil, nil, fmt.Errorf("%q is not a valid IP address, CIDR subnet, or range", s)
+}
+
+func ipVersion(a netip.Addr) string {
+	if a.Is4() {
+		return "IPV4"
+	}
+	return "IPV6"
+}
+
+// setStrings returns the elements of a string set, or nil if it is null or
+// unknown.
+func setStrings(ctx context.Context, s types.Set) []string {
+	if s.IsNull() || s.IsUnknown() {
+		return nil
+	}
+	var vals []string
+	s.ElementsAs(ctx, &vals, false)
+	return vals
+}
+
+// stringSetOrNull returns a set of the given strings, or a null set if there
+// are none.
+func stringSetOrNull(vals []string) types.Set {
+	if len(vals) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	elems := make([]attr.Value, len(vals))
+	for i, v := range vals {
+		elems[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+// sameMACs reports whether two MAC lists hold the same addresses, ignoring
+// case and order.
+func sameMACs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	norm := func(macs []string) []string {
+		out := make([]string, len(macs))
+		for i, mac := range macs {
+			out[i] = strings.ToLower(mac)
+		}
+		slices.Sort(out)
+		return out
+	}
+	return slices.Equal(norm(a), norm(b))
+}
+
+// trafficRouteIPValidator checks that a string is an IP address or CIDR
+// subnet, and with allowRanges also a "start-stop" range.
+type trafficRouteIPValidator struct {
+	allowRanges bool
+}
+
+func (v trafficRouteIPValidator) Description(_ context.Context) string {
+	if v.allowRanges {
+		return "must be an IP address, CIDR subnet, or range"
+	}
+	return "must be an IP address"
+}
+
+func (v trafficRouteIPValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v trafficRouteIPValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	s := req.ConfigValue.ValueString()
+
+	if !v.allowRanges {
+		if _, err := netip.ParseAddr(s); err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address",
+				fmt.Sprintf("%q %s.", s, v.Description(ctx)))
+		}
+		return
+	}
+	if _, _, err := parseTrafficRouteIP(s); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid IP Address", err.Error())
+	}
+}
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func stringSet(vals ...string) types.Set {
+	elems := make([]attr.Value, len(vals))
+	for i, v := range vals {
+		elems[i] = types.StringValue(v)
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func baseTrafficRouteModel() trafficRouteResourceModel {
+	return trafficRouteResourceModel{
+		Description:        types.StringValue("Streaming via VPN"),
+		Enabled:            types.BoolValue(true),
+		InterfaceNetworkID: types.StringValue("vpn-1"),
+		NextHop:            types.StringNull(),
+		KillSwitch:         types.BoolValue(false),
+		MACAddresses:       types.SetNull(types.StringType),
+		NetworkIDs:         types.SetNull(types.StringType),
+		Domains:            types.SetNull(types.StringType),
+		IPs:                types.SetNull(types.StringType),
+		Regions:            types.SetNull(types.StringType),
+	}
+}
+
+func TestTrafficRouteModelToAPI(t *testing.T) {
+	r := &trafficRouteResource{}
+	ctx := context.Background()
+
+	t.Run("all clients, all internet traffic", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		route, err := r.modelToAPI(ctx, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, "INTERNET", route.MatchingTarget)
+		assert.Equal(t, "vpn-1", route.NetworkID)
+		assert.Equal(t, []trafficRuleTarget{{Type: "ALL_CLIENTS"}}, route.TargetDevices)
+	})
+
+	t.Run("clients and networks", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		m.MACAddresses = stringSet("AA:BB:CC:DD:EE:FF")
+		m.NetworkIDs = stringSet("net-1")
+		route, err := r.modelToAPI(ctx, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, []trafficRuleTarget{
+			{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"},
+			{Type: "NETWORK", NetworkID: "net-1"},
+		}, route.TargetDevices)
+	})
+
+	t.Run("domains", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		m.Domains = stringSet("example.com")
+		route, err := r.modelToAPI(ctx, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, "DOMAIN", route.MatchingTarget)
+		assert.Equal(t, []trafficRouteDomain{{Domain: "example.com"}}, route.Domains)
+	})
+
+	t.Run("ips", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		m.IPs = stringSet("1.1.1.1", "10.0.0.0/8", "2001:db8::/32", "192.168.1.10-192.168.1.20")
+		route, err := r.modelToAPI(ctx, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, "IP", route.MatchingTarget)
+		assert.ElementsMatch(t, []trafficRouteIPAddress{
+			{IPOrSubnet: "1.1.1.1", IPVersion: "IPV4"},
+			{IPOrSubnet: "10.0.0.0/8", IPVersion: "IPV4"},
+			{IPOrSubnet: "2001:db8::/32", IPVersion: "IPV6"},
+		}, route.IPAddresses)
+		assert.Equal(t, []trafficRouteIPRange{
+			{IPStart: "192.168.1.10", IPStop: "192.168.1.20", IPVersion: "IPV4"},
+		}, route.IPRanges)
+	})
+
+	t.Run("regions", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		m.Regions = stringSet("US")
+		route, err := r.modelToAPI(ctx, &m)
+		require.NoError(t, err)
+
+		assert.Equal(t, "REGION", route.MatchingTarget)
+		assert.Equal(t, []string{"US"}, route.Regions)
+	})
+}
+
+func TestTrafficRouteAPIToModel(t *testing.T) {
+	r := &trafficRouteResource{}
+
+	t.Run("round trip", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		r.apiToModel(&trafficRoute{
+			ID:             "route-1",
+			Description:    "Office",
+			Enabled:        true,
+			NetworkID:      "wan2",
+			MatchingTarget: "IP",
+			TargetDevices: []trafficRuleTarget{
+				{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"},
+				{Type: "NETWORK", NetworkID: "net-1"},
+			},
+			IPAddresses: []trafficRouteIPAddress{{IPOrSubnet: "10.0.0.0/8", IPVersion: "IPV4"}},
+			IPRanges:    []trafficRouteIPRange{{IPStart: "10.1.0.1", IPStop: "10.1.0.9", IPVersion: "IPV4"}},
+		}, &m, "default")
+
+		assert.Equal(t, "route-1", m.ID.ValueString())
+		assert.Equal(t, "wan2", m.InterfaceNetworkID.ValueString())
+		assert.True(t, m.NextHop.IsNull())
+		assert.Equal(t, stringSet("aa:bb:cc:dd:ee:ff"), m.MACAddresses)
+		assert.Equal(t, stringSet("net-1"), m.NetworkIDs)
+		assert.Equal(t, stringSet("10.0.0.0/8", "10.1.0.1-10.1.0.9"), m.IPs)
+		assert.True(t, m.Domains.IsNull())
+		assert.True(t, m.Regions.IsNull())
+	})
+
+	t.Run("all clients", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		r.apiToModel(&trafficRoute{
+			MatchingTarget: "INTERNET",
+			TargetDevices:  []trafficRuleTarget{{Type: "ALL_CLIENTS"}},
+		}, &m, "default")
+
+		assert.True(t, m.MACAddresses.IsNull())
+		assert.True(t, m.NetworkIDs.IsNull())
+	})
+
+	t.Run("keeps configured MAC case", func(t *testing.T) {
+		m := baseTrafficRouteModel()
+		m.MACAddresses = stringSet("AA:BB:CC:DD:EE:FF")
+		r.apiToModel(&trafficRoute{
+			MatchingTarget: "INTERNET",
+			TargetDevices:  []trafficRuleTarget{{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"}},
+		}, &m, "default")
+
+		assert.Equal(t, stringSet("AA:BB:CC:DD:EE:FF"), m.MACAddresses)
+	})
+}
+
+func TestParseTrafficRouteIP(t *testing.T) {
+	for _, s := range []string{"1.1.1.1", "10.0.0.0/8", "2001:db8::1", "2001:db8::/32", "10.0.0.1-10.0.0.9"} {
+		_, _, err := parseTrafficRouteIP(s)
+		assert.NoError(t, err, s)
+	}
+	for _, s := range []string{"", "example.com", "10.0.0.0/33", "10.0.0.9-10.0.0.1", "10.0.0.1-2001:db8::1", "10.0.0.1-"} {
+		_, _, err := parseTrafficRouteIP(s)
+		assert.Error(t, err, s)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccTrafficRoute_basic(t *testing.T) {
+	desc := fmt.Sprintf("tfacc-route-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "terrifi_wan_networks" "all" {}
+
+resource "terrifi_traffic_route" "test" {
+  description          = %q
+  interface_network_id = data.terrifi_wan_networks.all.networks[0].id
+  domains              = ["example.com"]
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_traffic_route.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "kill_switch", "false"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "domains.#", "1"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_traffic_route.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+data "terrifi_wan_networks" "all" {}
+
+resource "terrifi_traffic_route" "test" {
+  description          = %q
+  interface_network_id = data.terrifi_wan_networks.all.networks[0].id
+  ips                  = ["1.1.1.1", "10.0.0.0/8", "192.168.50.10-192.168.50.20"]
+  mac_addresses        = ["aa:bb:cc:dd:ee:01"]
+  kill_switch          = true
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_traffic_route.test", "domains.#"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "ips.#", "3"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "mac_addresses.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_traffic_route.test", "kill_switch", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTrafficRoute_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_traffic_route" "test" {
+  description          = "conflict"
+  interface_network_id = "wan"
+  domains              = ["example.com"]
+  regions              = ["US"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+resource "terrifi_traffic_route" "test" {
+  description          = "bad ip"
+  interface_network_id = "wan"
+  ips                  = ["10.0.0.9-10.0.0.1"]
+}
+`,
+				ExpectError: regexp.MustCompile(`ends before it starts`),
+			},
+		},
+	})
+}
@@ -0,0 +1,143 @@
+package provider
+
+// Traffic rules ("Traffic & Firewall Rules" / "Traffic Management" in the
+// UniFi UI) live on the v2 API and are not covered by the go-unifi SDK, so this
+// file talks to the endpoints directly.
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// trafficRule is a v2 traffic rule. List fields the provider does not manage
+// yet are kept as raw JSON so an update round-trips them unchanged; the
+// controller rejects null for any of them, so they are always sent as arrays.
+type trafficRule struct {
+	ID             string                        `json:"_id,omitempty"`
+	Action         string                        `json:"action"`
+	Description    string                        `json:"description"`
+	Enabled        bool                          `json:"enabled"`
+	MatchingTarget string                        `json:"matching_target"`
+	TargetDevices  []trafficRuleTarget           `json:"target_devices"`
+	Schedule       firewallPolicyScheduleRequest `json:"schedule"`
+	AppCategoryIDs []int64                       `json:"app_category_ids"`
+	AppIDs         []int64                       `json:"app_ids"`
+	NetworkIDs     []string                      `json:"network_ids"`
+	Regions        []string                      `json:"regions"`
+	Domains        []trafficRouteDomain          `json:"domains"`
+	IPAddresses    []json.RawMessage             `json:"ip_addresses"`
+	IPRanges       []json.RawMessage             `json:"ip_ranges"`
+}
+
+// trafficRuleTarget selects the clients a traffic rule applies to. Type is
+// CLIENT (with ClientMAC), NETWORK (with NetworkID), or ALL_CLIENTS.
+type trafficRuleTarget struct {
+	Type      string `json:"type"`
+	ClientMAC string `json:"client_mac,omitempty"`
+	NetworkID string `json:"network_id,omitempty"`
+}
+
+// withEmptyLists returns a copy of the rule with nil list fields, including
+// the port lists of its domains, replaced by empty ones.
+func (t trafficRule) withEmptyLists() trafficRule {
+	if t.TargetDevices == nil {
+		t.TargetDevices = []trafficRuleTarget{}
+	}
+	for _, l := range []*[]int64{&t.AppCategoryIDs, &t.AppIDs} {
+		if *l == nil {
+			*l = []int64{}
+		}
+	}
+	for _, l := range []*[]string{&t.NetworkIDs, &t.Regions} {
+		if *l == nil {
+			*l = []string{}
+		}
+	}
+
+	domains := make([]trafficRouteDomain, len(t.Domains))
+	for i, d := range t.Domains {
+		if d.PortRanges == nil {
+			d.PortRanges = []string{}
+		}
+		if d.Ports == nil {
+			d.Ports = []int64{}
+		}
+		domains[i] = d
+	}
+	t.Domains = domains
+
+	for _, l := range []*[]json.RawMessage{&t.IPAddresses, &t.IPRanges} {
+		if *l == nil {
+			*l = []json.RawMessage{}
+		}
+	}
+	return t
+}
+
+// ListTrafficRule lists all traffic rules in the site.
+func (c *Client) ListTrafficRule(ctx context.Context, site string) ([]trafficRule, error) {
+	var rules []trafficRule
+	err := c.doV2Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/v2/api/site/%s/trafficrules", c.BaseURL, c.APIPath, site),
+		struct{}{}, &rules)
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetTrafficRule reads a traffic rule by ID. The v2 API does not support GET
+// on individual rules, so we list all rules and filter.
+func (c *Client) GetTrafficRule(ctx context.Context, site, id string) (*trafficRule, error) {
+	rules, err := c.ListTrafficRule(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rules {
+		if rules[i].ID == id {
+			return &rules[i], nil
+		}
+	}
+	return nil, &unifi.NotFoundError{}
+}
+
+// CreateTrafficRule creates a traffic rule.
+func (c *Client) CreateTrafficRule(ctx context.Context, site string, rule *trafficRule) (*trafficRule, error) {
+	payload := rule.withEmptyLists()
+	payload.ID = ""
+
+	var result trafficRule
+	err := c.doV2Request(ctx, http.MethodPost,
+		fmt.Sprintf("%s%s/v2/api/site/%s/trafficrules", c.BaseURL, c.APIPath, site),
+		payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateTrafficRule replaces a traffic rule. Like other v2 endpoints, the ID
+// is required in both the URL and the body.
+func (c *Client) UpdateTrafficRule(ctx context.Context, site string, rule *trafficRule) (*trafficRule, error) {
+	payload := rule.withEmptyLists()
+
+	var result trafficRule
+	err := c.doV2Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/v2/api/site/%s/trafficrules/%s", c.BaseURL, c.APIPath, site, rule.ID),
+		payload, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteTrafficRule deletes a traffic rule.
+func (c *Client) DeleteTrafficRule(ctx context.Context, site, id string) error {
+	return c.doV2Request(ctx, http.MethodDelete,
+		fmt.Sprintf("%s%s/v2/api/site/%s/trafficrules/%s", c.BaseURL, c.APIPath, site, id),
+		struct{}{}, nil)
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+func TestCreateTrafficRule(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		gotPath = r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		w.Write([]byte(`{"_id": "rule-1", "action": "BLOCK"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	created, err := client.CreateTrafficRule(context.Background(), "default", &trafficRule{
+		ID:     "ignored",
+		Action: "BLOCK",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "rule-1", created.ID)
+	assert.Equal(t, "/proxy/network/v2/api/site/default/trafficrules", gotPath)
+	assert.NotContains(t, gotBody, "_id")
+	// The controller rejects null lists, so unset ones are sent as [].
+	for _, key := range []string{"target_devices", "app_category_ids", "app_ids", "network_ids", "regions", "domains", "ip_addresses", "ip_ranges"} {
+		assert.Equal(t, []any{}, gotBody[key], key)
+	}
+}
+
+func TestGetTrafficRule(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"_id": "rule-1", "description": "one", "target_devices": [{"type": "CLIENT", "client_mac": "aa:bb:cc:dd:ee:ff"}]},
+			{"_id": "rule-2", "description": "two"}
+		]`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL, false)
+
+	rule, err := client.GetTrafficRule(context.Background(), "default", "rule-1")
+	require.NoError(t, err)
+	assert.Equal(t, "one", rule.Description)
+	assert.Equal(t, []trafficRuleTarget{{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"}}, rule.TargetDevices)
+
+	_, err = client.GetTrafficRule(context.Background(), "default", "missing")
+	assert.IsType(t, &unifi.NotFoundError{}, err)
+}
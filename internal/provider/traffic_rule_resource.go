@@ -0,0 +1,525 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &trafficRuleResource{}
+	_ resource.ResourceWithImportState = &trafficRuleResource{}
+)
+
+func NewTrafficRuleResource() resource.Resource {
+	return &trafficRuleResource{}
+}
+
+type trafficRuleResource struct {
+	client ClientAPI
+}
+
+type trafficRuleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Site        types.String `tfsdk:"site"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Action      types.String `tfsdk:"action"`
+
+	// Which clients the rule applies to. Neither set means all clients.
+	MACAddresses types.Set `tfsdk:"mac_addresses"`
+	NetworkIDs   types.Set `tfsdk:"network_ids"`
+
+	// Which destinations the rule matches. At most one is set; none means
+	// all internet traffic.
+	AppIDs         types.Set `tfsdk:"app_ids"`
+	AppCategoryIDs types.Set `tfsdk:"app_category_ids"`
+	Domains        types.Set `tfsdk:"domains"`
+	Regions        types.Set `tfsdk:"regions"`
+
+	Schedule types.Object `tfsdk:"schedule"`
+}
+
+func (r *trafficRuleResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_traffic_rule"
+}
+
+func (r *trafficRuleResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	destinations := path.Expressions{
+		path.MatchRoot("app_ids"),
+		path.MatchRoot("app_category_ids"),
+		path.MatchRoot("domains"),
+		path.MatchRoot("regions"),
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a traffic rule on the UniFi controller. A traffic rule blocks (or allows) " +
+			"the selected clients' traffic to apps, app categories, domains or regions, optionally on a schedule. " +
+			"This is the \"Traffic Management\" / \"Traffic & Firewall Rules\" feature of the UniFi UI, commonly " +
+			"used for parental controls.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the traffic rule.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the traffic rule with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The name of the rule, shown in the UniFi UI.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the rule is enabled. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"action": schema.StringAttribute{
+				MarkdownDescription: "What to do with matching traffic: `BLOCK` or `ALLOW`. Default: `BLOCK`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("BLOCK"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("BLOCK", "ALLOW"),
+				},
+			},
+
+			"mac_addresses": schema.SetAttribute{
+				MarkdownDescription: "MAC addresses of the clients the rule applies to. Can be combined with " +
+					"`network_ids`. When neither is set, the rule applies to all clients.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(
+							macRegexp,
+							"must be a valid MAC address (e.g. aa:bb:cc:dd:ee:ff)",
+						),
+					),
+				},
+			},
+
+			"network_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the networks whose clients the rule applies to. Can be combined with " +
+					"`mac_addresses`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"app_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the DPI apps to match (e.g. `589885` for YouTube). Conflicts with " +
+					"`app_category_ids`, `domains` and `regions`. When none of the four is set, all internet " +
+					"traffic is matched.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+				},
+			},
+
+			"app_category_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the DPI app categories to match (e.g. `4` for games). Conflicts with " +
+					"`app_ids`, `domains` and `regions`.",
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+				},
+			},
+
+			"domains": schema.SetAttribute{
+				MarkdownDescription: "Domains to match (e.g. `example.com`). Conflicts with `app_ids`, " +
+					"`app_category_ids` and `regions`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+				},
+			},
+
+			"regions": schema.SetAttribute{
+				MarkdownDescription: "Two-letter country codes (e.g. `US`) to match. Conflicts with `app_ids`, " +
+					"`app_category_ids` and `domains`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ConflictsWith(destinations...),
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(countryCodeRegexp, "must be an uppercase two-letter country code"),
+					),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"schedule": scheduleBlock("When the rule is active. When omitted, the rule is always active."),
+		},
+	}
+}
+
+func (r *trafficRuleResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *trafficRuleResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan trafficRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateTrafficRule(ctx, site, r.modelToAPI(ctx, &plan, nil))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Traffic Rule", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *trafficRuleResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state trafficRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	rule, err := r.client.GetTrafficRule(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Traffic Rule",
+			fmt.Sprintf("Could not read traffic rule %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(rule, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *trafficRuleResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan trafficRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetTrafficRule(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Traffic Rule for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "traffic rule", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	r.applyPlanToState(&plan, &state)
+
+	rule := r.modelToAPI(ctx, &state, current)
+	rule.ID = state.ID.ValueString()
+
+	updated, err := r.client.UpdateTrafficRule(ctx, site, rule)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Traffic Rule", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *trafficRuleResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state trafficRuleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteTrafficRule(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting Traffic Rule", err.Error())
+	}
+}
+
+func (r *trafficRuleResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *trafficRuleResource) applyPlanToState(plan, state *trafficRuleResourceModel) {
+	state.Description = plan.Description
+	if !plan.Enabled.IsNull() && !plan.Enabled.IsUnknown() {
+		state.Enabled = plan.Enabled
+	}
+	if !plan.Action.IsNull() && !plan.Action.IsUnknown() {
+		state.Action = plan.Action
+	}
+	// Optional attributes without defaults: a null plan value means the user
+	// removed the attribute.
+	state.MACAddresses = plan.MACAddresses
+	state.NetworkIDs = plan.NetworkIDs
+	state.AppIDs = plan.AppIDs
+	state.AppCategoryIDs = plan.AppCategoryIDs
+	state.Domains = plan.Domains
+	state.Regions = plan.Regions
+	state.Schedule = plan.Schedule
+}
+
+// modelToAPI builds the rule to send to the controller. When updating,
+// current is the rule as it is on the controller: the lists the provider
+// does not manage are copied from it, and so are the ports of domains that
+// are kept, which can only be set in the UniFi UI.
+func (r *trafficRuleResource) modelToAPI(ctx context.Context, m *trafficRuleResourceModel, current *trafficRule) *trafficRule {
+	rule := &trafficRule{
+		Description:    m.Description.ValueString(),
+		Enabled:        m.Enabled.ValueBool(),
+		Action:         m.Action.ValueString(),
+		MatchingTarget: "INTERNET",
+	}
+	if current != nil {
+		rule.NetworkIDs = current.NetworkIDs
+		rule.IPAddresses = current.IPAddresses
+		rule.IPRanges = current.IPRanges
+	}
+
+	for _, mac := range setStrings(ctx, m.MACAddresses) {
+		rule.TargetDevices = append(rule.TargetDevices, trafficRuleTarget{
+			Type:      "CLIENT",
+			ClientMAC: strings.ToLower(mac),
+		})
+	}
+	for _, id := range setStrings(ctx, m.NetworkIDs) {
+		rule.TargetDevices = append(rule.TargetDevices, trafficRuleTarget{
+			Type:      "NETWORK",
+			NetworkID: id,
+		})
+	}
+	if len(rule.TargetDevices) == 0 {
+		rule.TargetDevices = []trafficRuleTarget{{Type: "ALL_CLIENTS"}}
+	}
+
+	switch {
+	case !m.AppIDs.IsNull() && !m.AppIDs.IsUnknown():
+		rule.MatchingTarget = "APP"
+		rule.AppIDs = setInt64s(ctx, m.AppIDs)
+	case !m.AppCategoryIDs.IsNull() && !m.AppCategoryIDs.IsUnknown():
+		rule.MatchingTarget = "APP_CATEGORY"
+		rule.AppCategoryIDs = setInt64s(ctx, m.AppCategoryIDs)
+	case !m.Domains.IsNull() && !m.Domains.IsUnknown():
+		rule.MatchingTarget = "DOMAIN"
+		for _, d := range setStrings(ctx, m.Domains) {
+			domain := trafficRouteDomain{Domain: d}
+			if current != nil {
+				if i := slices.IndexFunc(current.Domains, func(cd trafficRouteDomain) bool {
+					return cd.Domain == d
+				}); i >= 0 {
+					domain = current.Domains[i]
+				}
+			}
+			rule.Domains = append(rule.Domains, domain)
+		}
+	case !m.Regions.IsNull() && !m.Regions.IsUnknown():
+		rule.MatchingTarget = "REGION"
+		rule.Regions = setStrings(ctx, m.Regions)
+	}
+
+	// The controller requires a schedule; no schedule block means always.
+	if sched := scheduleObjectToRequest(ctx, m.Schedule); sched != nil {
+		rule.Schedule = *sched
+	} else {
+		rule.Schedule = firewallPolicyScheduleRequest{Mode: "ALWAYS"}
+	}
+
+	return rule
+}
+
+func (r *trafficRuleResource) apiToModel(rule *trafficRule, m *trafficRuleResourceModel, site string) {
+	m.ID = types.StringValue(rule.ID)
+	m.Site = types.StringValue(site)
+	m.Description = types.StringValue(rule.Description)
+	m.Enabled = types.BoolValue(rule.Enabled)
+	m.Action = types.StringValue(rule.Action)
+
+	var macs, networkIDs []string
+	for _, t := range rule.TargetDevices {
+		switch t.Type {
+		case "CLIENT":
+			macs = append(macs, t.ClientMAC)
+		case "NETWORK":
+			networkIDs = append(networkIDs, t.NetworkID)
+		}
+	}
+	// The controller stores MACs in lowercase; keep the configured spelling
+	// when it only differs in case.
+	if !sameMACs(setStrings(context.Background(), m.MACAddresses), macs) {
+		m.MACAddresses = stringSetOrNull(macs)
+	}
+	m.NetworkIDs = stringSetOrNull(networkIDs)
+
+	var domains []string
+	for _, d := range rule.Domains {
+		domains = append(domains, d.Domain)
+	}
+
+	m.AppIDs = types.SetNull(types.Int64Type)
+	m.AppCategoryIDs = types.SetNull(types.Int64Type)
+	m.Domains = types.SetNull(types.StringType)
+	m.Regions = types.SetNull(types.StringType)
+	switch rule.MatchingTarget {
+	case "APP":
+		m.AppIDs = int64SetOrNull(rule.AppIDs)
+	case "APP_CATEGORY":
+		m.AppCategoryIDs = int64SetOrNull(rule.AppCategoryIDs)
+	case "DOMAIN":
+		m.Domains = stringSetOrNull(domains)
+	case "REGION":
+		m.Regions = stringSetOrNull(rule.Regions)
+	}
+
+	if !isDefaultSchedule(&rule.Schedule) {
+		m.Schedule = scheduleAPIToModel(&rule.Schedule)
+	} else {
+		m.Schedule = types.ObjectNull(scheduleAttrTypes)
+	}
+}
+
+// setInt64s returns the elements of an int64 set, or nil if it is null or
+// unknown.
+func setInt64s(ctx context.Context, s types.Set) []int64 {
+	if s.IsNull() || s.IsUnknown() {
+		return nil
+	}
+	var vals []int64
+	s.ElementsAs(ctx, &vals, false)
+	return vals
+}
+
+// int64SetOrNull returns a set of the given numbers, or a null set if there
+// are none.
+func int64SetOrNull(vals []int64) types.Set {
+	if len(vals) == 0 {
+		return types.SetNull(types.Int64Type)
+	}
+	elems := make([]attr.Value, len(vals))
+	for i, v := range vals {
+		elems[i] = types.Int64Value(v)
+	}
+	return types.SetValueMust(types.Int64Type, elems)
+}
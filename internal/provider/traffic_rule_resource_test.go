@@ -0,0 +1,317 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func int64Set(vals ...int64) types.Set {
+	elems := make([]attr.Value, len(vals))
+	for i, v := range vals {
+		elems[i] = types.Int64Value(v)
+	}
+	return types.SetValueMust(types.Int64Type, elems)
+}
+
+func baseTrafficRuleModel() trafficRuleResourceModel {
+	return trafficRuleResourceModel{
+		ID:             types.StringUnknown(),
+		Site:           types.StringNull(),
+		Description:    types.StringValue("No games on school nights"),
+		Enabled:        types.BoolValue(true),
+		Action:         types.StringValue("BLOCK"),
+		MACAddresses:   types.SetNull(types.StringType),
+		NetworkIDs:     types.SetNull(types.StringType),
+		AppIDs:         types.SetNull(types.Int64Type),
+		AppCategoryIDs: types.SetNull(types.Int64Type),
+		Domains:        types.SetNull(types.StringType),
+		Regions:        types.SetNull(types.StringType),
+		Schedule:       types.ObjectNull(scheduleAttrTypes),
+	}
+}
+
+func TestTrafficRuleModelToAPI(t *testing.T) {
+	r := &trafficRuleResource{}
+	ctx := context.Background()
+
+	t.Run("all clients, all internet traffic, always", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, "BLOCK", rule.Action)
+		assert.Equal(t, "INTERNET", rule.MatchingTarget)
+		assert.Equal(t, []trafficRuleTarget{{Type: "ALL_CLIENTS"}}, rule.TargetDevices)
+		assert.Equal(t, firewallPolicyScheduleRequest{Mode: "ALWAYS"}, rule.Schedule)
+	})
+
+	t.Run("clients and networks", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.MACAddresses = stringSet("AA:BB:CC:DD:EE:FF")
+		m.NetworkIDs = stringSet("net-1")
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, []trafficRuleTarget{
+			{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"},
+			{Type: "NETWORK", NetworkID: "net-1"},
+		}, rule.TargetDevices)
+	})
+
+	t.Run("apps", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.AppIDs = int64Set(589885)
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, "APP", rule.MatchingTarget)
+		assert.Equal(t, []int64{589885}, rule.AppIDs)
+	})
+
+	t.Run("app categories", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.AppCategoryIDs = int64Set(4)
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, "APP_CATEGORY", rule.MatchingTarget)
+		assert.Equal(t, []int64{4}, rule.AppCategoryIDs)
+	})
+
+	t.Run("regions", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.Regions = stringSet("US")
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, "REGION", rule.MatchingTarget)
+		assert.Equal(t, []string{"US"}, rule.Regions)
+	})
+
+	t.Run("schedule", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.Schedule = scheduleAPIToModel(&firewallPolicyScheduleRequest{
+			Mode:           "EVERY_WEEK",
+			TimeRangeStart: "20:00",
+			TimeRangeEnd:   "23:59",
+			RepeatOnDays:   []string{"mon"},
+		})
+		rule := r.modelToAPI(ctx, &m, nil)
+
+		assert.Equal(t, "EVERY_WEEK", rule.Schedule.Mode)
+		assert.Equal(t, "20:00", rule.Schedule.TimeRangeStart)
+		assert.Equal(t, []string{"mon"}, rule.Schedule.RepeatOnDays)
+	})
+
+	t.Run("update keeps unmanaged fields and domain ports", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		m.Domains = stringSet("example.com", "example.org")
+		current := &trafficRule{
+			MatchingTarget: "DOMAIN",
+			NetworkIDs:     []string{"net-2"},
+			Domains:        []trafficRouteDomain{{Domain: "example.com", Ports: []int64{443}}},
+		}
+		rule := r.modelToAPI(ctx, &m, current)
+
+		assert.Equal(t, []string{"net-2"}, rule.NetworkIDs)
+		assert.ElementsMatch(t, []trafficRouteDomain{
+			{Domain: "example.com", Ports: []int64{443}},
+			{Domain: "example.org"},
+		}, rule.Domains)
+	})
+}
+
+func TestTrafficRuleAPIToModel(t *testing.T) {
+	r := &trafficRuleResource{}
+
+	t.Run("round trip", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		r.apiToModel(&trafficRule{
+			ID:             "rule-1",
+			Description:    "Kids",
+			Enabled:        true,
+			Action:         "BLOCK",
+			MatchingTarget: "APP_CATEGORY",
+			TargetDevices:  []trafficRuleTarget{{Type: "CLIENT", ClientMAC: "aa:bb:cc:dd:ee:ff"}},
+			AppCategoryIDs: []int64{4, 13},
+			Schedule: firewallPolicyScheduleRequest{
+				Mode:           "EVERY_DAY",
+				TimeRangeStart: "21:00",
+				TimeRangeEnd:   "07:00",
+			},
+		}, &m, "default")
+
+		assert.Equal(t, "rule-1", m.ID.ValueString())
+		assert.Equal(t, "BLOCK", m.Action.ValueString())
+		assert.Equal(t, stringSet("aa:bb:cc:dd:ee:ff"), m.MACAddresses)
+		assert.True(t, m.NetworkIDs.IsNull())
+		assert.Equal(t, int64Set(4, 13), m.AppCategoryIDs)
+		assert.True(t, m.AppIDs.IsNull())
+		assert.True(t, m.Domains.IsNull())
+		assert.False(t, m.Schedule.IsNull())
+		assert.Equal(t, types.StringValue("EVERY_DAY"), m.Schedule.Attributes()["mode"])
+	})
+
+	t.Run("always schedule is null", func(t *testing.T) {
+		m := baseTrafficRuleModel()
+		r.apiToModel(&trafficRule{
+			MatchingTarget: "DOMAIN",
+			TargetDevices:  []trafficRuleTarget{{Type: "ALL_CLIENTS"}},
+			Domains:        []trafficRouteDomain{{Domain: "example.com"}},
+			Schedule:       firewallPolicyScheduleRequest{Mode: "ALWAYS"},
+		}, &m, "default")
+
+		assert.True(t, m.MACAddresses.IsNull())
+		assert.Equal(t, stringSet("example.com"), m.Domains)
+		assert.True(t, m.Schedule.IsNull())
+	})
+}
+
+func TestTrafficRuleCRUD(t *testing.T) {
+	plan := baseTrafficRuleModel()
+	plan.Domains = stringSet("example.com")
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &trafficRuleResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		assert.Equal(t, "DOMAIN", fake.trafficRules[id].MatchingTarget)
+
+		update := *created
+		update.Domains = types.SetNull(types.StringType)
+		update.Regions = stringSet("CN")
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, "REGION", fake.trafficRules[id].MatchingTarget)
+		assert.Equal(t, stringSet("CN"), updated.Regions)
+		assert.True(t, updated.Domains.IsNull())
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.trafficRules)
+	})
+
+	t.Run("update refuses concurrent changes", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &trafficRuleResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		rule := fake.trafficRules[id]
+		rule.Enabled = false
+		fake.trafficRules[id] = rule
+
+		update := *created
+		update.Description = types.StringValue("Renamed")
+		_, diags = testUpdate(t, r, *created, update)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateTrafficRule"])
+	})
+
+	t.Run("read removes a rule deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &trafficRuleResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.trafficRules, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccTrafficRule_basic(t *testing.T) {
+	desc := fmt.Sprintf("tfacc-rule-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_traffic_rule" "test" {
+  description   = %q
+  mac_addresses = ["aa:bb:cc:dd:ee:01"]
+  domains       = ["example.com"]
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_traffic_rule.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "action", "BLOCK"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "domains.#", "1"),
+					resource.TestCheckNoResourceAttr("terrifi_traffic_rule.test", "schedule.mode"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_traffic_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_traffic_rule" "test" {
+  description      = %q
+  mac_addresses    = ["aa:bb:cc:dd:ee:01"]
+  app_category_ids = [4]
+
+  schedule {
+    mode             = "EVERY_WEEK"
+    time_range_start = "20:00"
+    time_range_end   = "23:59"
+    repeat_on_days   = ["mon", "tue", "wed", "thu"]
+  }
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_traffic_rule.test", "domains.#"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "app_category_ids.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "schedule.mode", "EVERY_WEEK"),
+					resource.TestCheckResourceAttr("terrifi_traffic_rule.test", "schedule.repeat_on_days.#", "4"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTrafficRule_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_traffic_rule" "test" {
+  description = "conflict"
+  app_ids     = [589885]
+  domains     = ["example.com"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+resource "terrifi_traffic_rule" "test" {
+  description = "bad action"
+  action      = "REJECT"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+		},
+	})
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &trafficRulesDataSource{}
+
+func NewTrafficRulesDataSource() datasource.DataSource {
+	return &trafficRulesDataSource{}
+}
+
+type trafficRulesDataSource struct {
+	client ClientAPI
+}
+
+type trafficRulesDataSourceModel struct {
+	Site  types.String       `tfsdk:"site"`
+	Rules []trafficRuleModel `tfsdk:"rules"`
+}
+
+// trafficRuleModel is trafficRuleResourceModel without the site.
+type trafficRuleModel struct {
+	ID             types.String `tfsdk:"id"`
+	Description    types.String `tfsdk:"description"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Action         types.String `tfsdk:"action"`
+	MACAddresses   types.Set    `tfsdk:"mac_addresses"`
+	NetworkIDs     types.Set    `tfsdk:"network_ids"`
+	AppIDs         types.Set    `tfsdk:"app_ids"`
+	AppCategoryIDs types.Set    `tfsdk:"app_category_ids"`
+	Domains        types.Set    `tfsdk:"domains"`
+	Regions        types.Set    `tfsdk:"regions"`
+	Schedule       types.Object `tfsdk:"schedule"`
+}
+
+func (d *trafficRulesDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_traffic_rules"
+}
+
+func (d *trafficRulesDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the traffic rules on a site, including ones created in the UniFi UI. " +
+			"Useful for auditing parental controls or finding rules to adopt into `terrifi_traffic_rule`.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to list. Defaults to the provider site.",
+				Optional:            true,
+			},
+
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The traffic rules, ordered by name. Each has the attributes of " +
+					"`terrifi_traffic_rule`.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the rule.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The name of the rule, shown in the UniFi UI.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the rule is enabled.",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "What happens to matching traffic: `BLOCK` or `ALLOW`.",
+							Computed:            true,
+						},
+						"mac_addresses": schema.SetAttribute{
+							MarkdownDescription: "MAC addresses of the clients the rule applies to.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"network_ids": schema.SetAttribute{
+							MarkdownDescription: "IDs of the networks whose clients the rule applies to. When " +
+								"neither this nor `mac_addresses` is set, the rule applies to all clients.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"app_ids": schema.SetAttribute{
+							MarkdownDescription: "IDs of the DPI apps the rule matches.",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"app_category_ids": schema.SetAttribute{
+							MarkdownDescription: "IDs of the DPI app categories the rule matches.",
+							ElementType:         types.Int64Type,
+							Computed:            true,
+						},
+						"domains": schema.SetAttribute{
+							MarkdownDescription: "Domains the rule matches.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"regions": schema.SetAttribute{
+							MarkdownDescription: "Two-letter country codes the rule matches. When none of " +
+								"`app_ids`, `app_category_ids`, `domains` and `regions` is set, the rule matches all " +
+								"internet traffic.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"schedule": schema.SingleNestedAttribute{
+							MarkdownDescription: "When the rule is active, or null if it is always active.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"mode": schema.StringAttribute{
+									MarkdownDescription: "Schedule mode: `EVERY_DAY`, `EVERY_WEEK`, `ONE_TIME_ONLY` or `CUSTOM`.",
+									Computed:            true,
+								},
+								"date": schema.StringAttribute{
+									MarkdownDescription: "Date of a one-time schedule.",
+									Computed:            true,
+								},
+								"time_all_day": schema.BoolAttribute{
+									MarkdownDescription: "Whether the schedule applies all day.",
+									Computed:            true,
+								},
+								"time_range_start": schema.StringAttribute{
+									MarkdownDescription: "Start time (e.g. `08:00`).",
+									Computed:            true,
+								},
+								"time_range_end": schema.StringAttribute{
+									MarkdownDescription: "End time (e.g. `17:00`).",
+									Computed:            true,
+								},
+								"repeat_on_days": schema.SetAttribute{
+									MarkdownDescription: "Days of the week the schedule applies.",
+									ElementType:         types.StringType,
+									Computed:            true,
+								},
+								"date_start": schema.StringAttribute{
+									MarkdownDescription: "Start date of a `CUSTOM` schedule.",
+									Computed:            true,
+								},
+								"date_end": schema.StringAttribute{
+									MarkdownDescription: "End date of a `CUSTOM` schedule.",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *trafficRulesDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *trafficRulesDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config trafficRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	rules, err := d.client.ListTrafficRule(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Traffic Rules",
+			fmt.Sprintf("Could not list traffic rules in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	config.Site = types.StringValue(site)
+	config.Rules = trafficRulesToModels(rules, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// trafficRulesToModels converts the controller's rules to the data source
+// model, ordered by name and then ID so the list is stable across reads. The
+// rules are read the same way as by terrifi_traffic_rule.
+func trafficRulesToModels(rules []trafficRule, site string) []trafficRuleModel {
+	sorted := make([]trafficRule, len(rules))
+	copy(sorted, rules)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Description != sorted[j].Description {
+			return sorted[i].Description < sorted[j].Description
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	r := &trafficRuleResource{}
+	models := make([]trafficRuleModel, len(sorted))
+	for i := range sorted {
+		m := trafficRuleResourceModel{MACAddresses: types.SetNull(types.StringType)}
+		r.apiToModel(&sorted[i], &m, site)
+		models[i] = trafficRuleModel{
+			ID:             m.ID,
+			Description:    m.Description,
+			Enabled:        m.Enabled,
+			Action:         m.Action,
+			MACAddresses:   m.MACAddresses,
+			NetworkIDs:     m.NetworkIDs,
+			AppIDs:         m.AppIDs,
+			AppCategoryIDs: m.AppCategoryIDs,
+			Domains:        m.Domains,
+			Regions:        m.Regions,
+			Schedule:       m.Schedule,
+		}
+	}
+	return models
+}
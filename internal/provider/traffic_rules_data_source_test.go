@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestTrafficRulesToModels(t *testing.T) {
+	models := trafficRulesToModels([]trafficRule{
+		{ID: "b", Description: "Block games", Action: "BLOCK", Schedule: firewallPolicyScheduleRequest{Mode: "ALWAYS"}},
+		{
+			ID:             "c",
+			Description:    "Allow docs",
+			Action:         "ALLOW",
+			MatchingTarget: "DOMAIN",
+			TargetDevices:  []trafficRuleTarget{{Type: "NETWORK", NetworkID: "net1"}},
+			Domains:        []trafficRouteDomain{{Domain: "example.com"}},
+			Schedule:       firewallPolicyScheduleRequest{Mode: "EVERY_DAY", TimeRangeStart: "08:00", TimeRangeEnd: "17:00"},
+		},
+		{ID: "a", Description: "Block games", Action: "BLOCK", Schedule: firewallPolicyScheduleRequest{Mode: "ALWAYS"}},
+	}, "default")
+
+	ids := make([]string, len(models))
+	for i, m := range models {
+		ids[i] = m.ID.ValueString()
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, ids)
+
+	m := models[0]
+	assert.Equal(t, "ALLOW", m.Action.ValueString())
+	assert.True(t, m.MACAddresses.IsNull())
+	assert.Equal(t, stringSet("net1"), m.NetworkIDs)
+	assert.Equal(t, stringSet("example.com"), m.Domains)
+	assert.True(t, m.AppIDs.IsNull())
+	require.False(t, m.Schedule.IsNull())
+	assert.Equal(t, `"EVERY_DAY"`, m.Schedule.Attributes()["mode"].String())
+
+	assert.True(t, models[1].Schedule.IsNull(), "an always-on schedule is null")
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccTrafficRulesDataSource_basic(t *testing.T) {
+	desc := fmt.Sprintf("tfacc-rules-ds-%s", randomSuffix())
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_traffic_rule" "test" {
+  description   = %q
+  mac_addresses = ["aa:bb:cc:dd:ee:02"]
+  domains       = ["example.com"]
+}
+
+data "terrifi_traffic_rules" "all" {
+  depends_on = [terrifi_traffic_rule.test]
+}
+`, desc),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.terrifi_traffic_rules.all", "rules.*", map[string]string{
+						"description":     desc,
+						"action":          "BLOCK",
+						"enabled":         "true",
+						"mac_addresses.#": "1",
+						"domains.#":       "1",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,420 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                     = &userGroupResource{}
+	_ resource.ResourceWithImportState      = &userGroupResource{}
+	_ resource.ResourceWithModifyPlan       = &userGroupResource{}
+	_ resource.ResourceWithConfigValidators = &userGroupResource{}
+)
+
+// userGroupRateUnlimited is the qos_rate_max_down/up value the controller
+// uses for a direction without a bandwidth limit.
+const userGroupRateUnlimited = -1
+
+// NewUserGroupResource is the factory function registered in provider.Resources().
+func NewUserGroupResource() resource.Resource {
+	return &userGroupResource{}
+}
+
+// userGroupResource holds the API client, injected by Configure().
+type userGroupResource struct {
+	client ClientAPI
+}
+
+// userGroupResourceModel is the Terraform-side representation of a user
+// group, the controller's legacy client group that carries bandwidth limits.
+type userGroupResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Site                 types.String `tfsdk:"site"`
+	Name                 types.String `tfsdk:"name"`
+	DownloadLimitEnabled types.Bool   `tfsdk:"download_limit_enabled"`
+	DownloadLimitKbps    types.Int64  `tfsdk:"download_limit_kbps"`
+	UploadLimitEnabled   types.Bool   `tfsdk:"upload_limit_enabled"`
+	UploadLimitKbps      types.Int64  `tfsdk:"upload_limit_kbps"`
+}
+
+// Metadata sets the resource type name.
+func (r *userGroupResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_user_group"
+}
+
+// Schema defines the HCL schema for the terrifi_user_group resource.
+func (r *userGroupResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a user group, which caps the download and upload bandwidth of the clients " +
+			"assigned to it. Clients join a user group through the WLAN they connect to (`user_group_id` on " +
+			"`terrifi_wlan`). Unlike `terrifi_client_group`, which groups clients for firewall and traffic rules, " +
+			"user groups are the controller's only per-client bandwidth limit.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user group.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the user group with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the user group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"download_limit_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the download limit is enforced. Defaults to `true` when " +
+					"`download_limit_kbps` is set and `false` otherwise. Set it to `false` to lift the limit " +
+					"without forgetting the configured rate.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"download_limit_kbps": schema.Int64Attribute{
+				MarkdownDescription: "The maximum download rate of each client in the group, in kbps, between 2 " +
+					"and 100000.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(2, 100000),
+				},
+			},
+
+			"upload_limit_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the upload limit is enforced. Defaults to `true` when " +
+					"`upload_limit_kbps` is set and `false` otherwise. Set it to `false` to lift the limit " +
+					"without forgetting the configured rate.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"upload_limit_kbps": schema.Int64Attribute{
+				MarkdownDescription: "The maximum upload rate of each client in the group, in kbps, between 2 " +
+					"and 100000.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(2, 100000),
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators requires a rate for every limit that is enabled.
+func (r *userGroupResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		userGroupLimitValidator{},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *userGroupResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan derives unset enabled flags from whether the matching rate is
+// configured. This is done on every plan rather than only when the flag is
+// unknown, so that removing an explicit flag from the config re-enables a
+// limit whose rate is still set.
+func (r *userGroupResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to fill in.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config userGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.DownloadLimitEnabled.IsNull() && !plan.DownloadLimitKbps.IsUnknown() {
+		plan.DownloadLimitEnabled = types.BoolValue(!plan.DownloadLimitKbps.IsNull())
+	}
+	if config.UploadLimitEnabled.IsNull() && !plan.UploadLimitKbps.IsUnknown() {
+		plan.UploadLimitEnabled = types.BoolValue(!plan.UploadLimitKbps.IsNull())
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates a new user group.
+func (r *userGroupResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan userGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	created, err := r.client.CreateClientGroup(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating User Group", err.Error())
+		return
+	}
+
+	r.apiToModel(created, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *userGroupResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state userGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	group, err := r.client.GetClientGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading User Group",
+			fmt.Sprintf("Could not read user group %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(group, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing user group.
+func (r *userGroupResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan userGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, err := r.client.GetClientGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading User Group for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "user group", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	// Start from the current group so the controller's own attributes, such
+	// as the no-delete flag of the default group, are sent back unchanged.
+	group := *current
+	desired := r.modelToAPI(&plan)
+	group.Name = desired.Name
+	group.QOSRateMaxDown = desired.QOSRateMaxDown
+	group.QOSRateMaxUp = desired.QOSRateMaxUp
+
+	updated, err := r.client.UpdateClientGroup(ctx, site, &group)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating User Group", err.Error())
+		return
+	}
+
+	r.apiToModel(updated, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the user group from the UniFi controller.
+func (r *userGroupResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state userGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteClientGroup(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting User Group", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_user_group.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *userGroupResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// modelToAPI converts our Terraform model to the go-unifi ClientGroup struct.
+// Disabled limits are sent as unlimited.
+func (r *userGroupResource) modelToAPI(m *userGroupResourceModel) *unifi.ClientGroup {
+	return &unifi.ClientGroup{
+		Name:           m.Name.ValueString(),
+		QOSRateMaxDown: userGroupRate(m.DownloadLimitEnabled, m.DownloadLimitKbps),
+		QOSRateMaxUp:   userGroupRate(m.UploadLimitEnabled, m.UploadLimitKbps),
+	}
+}
+
+// apiToModel converts the go-unifi ClientGroup struct back to our Terraform
+// model. The controller doesn't remember the rate of a disabled limit, so the
+// model's rate is kept in that case.
+func (r *userGroupResource) apiToModel(group *unifi.ClientGroup, m *userGroupResourceModel, site string) {
+	m.ID = types.StringValue(group.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringValue(group.Name)
+
+	if rate := group.QOSRateMaxDown; rate != nil && *rate > 0 {
+		m.DownloadLimitEnabled = types.BoolValue(true)
+		m.DownloadLimitKbps = types.Int64Value(*rate)
+	} else {
+		m.DownloadLimitEnabled = types.BoolValue(false)
+	}
+
+	if rate := group.QOSRateMaxUp; rate != nil && *rate > 0 {
+		m.UploadLimitEnabled = types.BoolValue(true)
+		m.UploadLimitKbps = types.Int64Value(*rate)
+	} else {
+		m.UploadLimitEnabled = types.BoolValue(false)
+	}
+}
+
+// userGroupRate returns the API rate for one direction of a user group.
+func userGroupRate(enabled types.Bool, kbps types.Int64) *int64 {
+	rate := int64(userGroupRateUnlimited)
+	if enabled.ValueBool() && !kbps.IsNull() {
+		rate = kbps.ValueInt64()
+	}
+	return &rate
+}
+
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// userGroupLimitValidator rejects an enabled limit without a rate, which
+// would otherwise be silently sent as unlimited.
+type userGroupLimitValidator struct{}
+
+func (v userGroupLimitValidator) Description(_ context.Context) string {
+	return "download_limit_kbps and upload_limit_kbps are required when their limit is enabled"
+}
+
+func (v userGroupLimitValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v userGroupLimitValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	for _, direction := range []string{"download", "upload"} {
+		var enabled types.Bool
+		var kbps types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(direction+"_limit_enabled"), &enabled)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(direction+"_limit_kbps"), &kbps)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if enabled.ValueBool() && kbps.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(direction+"_limit_kbps"),
+				"Missing Bandwidth Limit",
+				fmt.Sprintf("%s_limit_kbps is required when %s_limit_enabled is true.", direction, direction),
+			)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestUserGroupModelToAPI(t *testing.T) {
+	r := &userGroupResource{}
+
+	t.Run("limits enabled", func(t *testing.T) {
+		group := r.modelToAPI(&userGroupResourceModel{
+			Name:                 types.StringValue("Kids"),
+			DownloadLimitEnabled: types.BoolValue(true),
+			DownloadLimitKbps:    types.Int64Value(20000),
+			UploadLimitEnabled:   types.BoolValue(true),
+			UploadLimitKbps:      types.Int64Value(5000),
+		})
+
+		assert.Equal(t, "Kids", group.Name)
+		require.NotNil(t, group.QOSRateMaxDown)
+		assert.Equal(t, int64(20000), *group.QOSRateMaxDown)
+		require.NotNil(t, group.QOSRateMaxUp)
+		assert.Equal(t, int64(5000), *group.QOSRateMaxUp)
+	})
+
+	t.Run("disabled limit is sent as unlimited", func(t *testing.T) {
+		group := r.modelToAPI(&userGroupResourceModel{
+			Name:                 types.StringValue("Kids"),
+			DownloadLimitEnabled: types.BoolValue(false),
+			DownloadLimitKbps:    types.Int64Value(20000),
+			UploadLimitEnabled:   types.BoolValue(false),
+			UploadLimitKbps:      types.Int64Null(),
+		})
+
+		require.NotNil(t, group.QOSRateMaxDown)
+		assert.Equal(t, int64(-1), *group.QOSRateMaxDown)
+		require.NotNil(t, group.QOSRateMaxUp)
+		assert.Equal(t, int64(-1), *group.QOSRateMaxUp)
+	})
+}
+
+func TestUserGroupAPIToModel(t *testing.T) {
+	r := &userGroupResource{}
+	down, up, unlimited := int64(20000), int64(5000), int64(-1)
+
+	t.Run("limited", func(t *testing.T) {
+		var m userGroupResourceModel
+		r.apiToModel(&unifi.ClientGroup{ID: "g1", Name: "Kids", QOSRateMaxDown: &down, QOSRateMaxUp: &up}, &m, "default")
+
+		assert.Equal(t, "g1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "Kids", m.Name.ValueString())
+		assert.True(t, m.DownloadLimitEnabled.ValueBool())
+		assert.Equal(t, int64(20000), m.DownloadLimitKbps.ValueInt64())
+		assert.True(t, m.UploadLimitEnabled.ValueBool())
+		assert.Equal(t, int64(5000), m.UploadLimitKbps.ValueInt64())
+	})
+
+	t.Run("unlimited keeps the configured rate", func(t *testing.T) {
+		m := userGroupResourceModel{
+			DownloadLimitKbps: types.Int64Value(20000),
+			UploadLimitKbps:   types.Int64Null(),
+		}
+		r.apiToModel(&unifi.ClientGroup{ID: "g1", Name: "Kids", QOSRateMaxDown: &unlimited}, &m, "default")
+
+		assert.False(t, m.DownloadLimitEnabled.ValueBool())
+		assert.Equal(t, int64(20000), m.DownloadLimitKbps.ValueInt64())
+		assert.False(t, m.UploadLimitEnabled.ValueBool())
+		assert.True(t, m.UploadLimitKbps.IsNull())
+	})
+}
+
+func TestUserGroupCRUD(t *testing.T) {
+	plan := userGroupResourceModel{
+		ID:                   types.StringUnknown(),
+		Site:                 types.StringNull(),
+		Name:                 types.StringValue("Kids"),
+		DownloadLimitEnabled: types.BoolValue(true),
+		DownloadLimitKbps:    types.Int64Value(20000),
+		UploadLimitEnabled:   types.BoolValue(false),
+		UploadLimitKbps:      types.Int64Null(),
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &userGroupResource{client: fake}
+
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		assert.Equal(t, int64(20000), *fake.userGroups[id].QOSRateMaxDown)
+		assert.Equal(t, int64(-1), *fake.userGroups[id].QOSRateMaxUp)
+
+		// Disabling the limit keeps its rate in state.
+		update := *created
+		update.DownloadLimitEnabled = types.BoolValue(false)
+		updated, diags := testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, int64(-1), *fake.userGroups[id].QOSRateMaxDown)
+		assert.False(t, updated.DownloadLimitEnabled.ValueBool())
+		assert.Equal(t, int64(20000), updated.DownloadLimitKbps.ValueInt64())
+
+		diags = testDelete(t, r, *updated)
+		require.False(t, diags.HasError(), "delete: %v", diags)
+		assert.Empty(t, fake.userGroups)
+	})
+
+	t.Run("update keeps controller attributes", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &userGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		group := fake.userGroups[id]
+		group.NoDelete = true
+		fake.userGroups[id] = group
+
+		update := *created
+		update.Name = types.StringValue("Teens")
+		_, diags = testUpdate(t, r, *created, update)
+		require.False(t, diags.HasError(), "update: %v", diags)
+		assert.Equal(t, "Teens", fake.userGroups[id].Name)
+		assert.True(t, fake.userGroups[id].NoDelete)
+	})
+
+	t.Run("update refuses concurrent changes", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &userGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		id := created.ID.ValueString()
+		group := fake.userGroups[id]
+		rate := int64(1000)
+		group.QOSRateMaxDown = &rate
+		fake.userGroups[id] = group
+
+		update := *created
+		update.Name = types.StringValue("Teens")
+		_, diags = testUpdate(t, r, *created, update)
+		require.True(t, diags.HasError())
+		assert.Equal(t, 0, fake.calls["UpdateClientGroup"])
+	})
+
+	t.Run("read removes a group deleted outside Terraform", func(t *testing.T) {
+		fake := newFakeClient()
+		r := &userGroupResource{client: fake}
+		created, diags := testCreate(t, r, plan)
+		require.False(t, diags.HasError(), "create: %v", diags)
+		delete(fake.userGroups, created.ID.ValueString())
+
+		read, diags := testRead(t, r, *created)
+		require.False(t, diags.HasError(), "read: %v", diags)
+		assert.Nil(t, read)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccUserGroup_basic(t *testing.T) {
+	name := fmt.Sprintf("tfacc-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_user_group" "test" {
+  name                = %q
+  download_limit_kbps = 20000
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_user_group.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "name", name),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "download_limit_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "download_limit_kbps", "20000"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "upload_limit_enabled", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_user_group.test", "upload_limit_kbps"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_user_group.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_user_group" "test" {
+  name                   = %q
+  download_limit_enabled = false
+  download_limit_kbps    = 20000
+  upload_limit_kbps      = 5000
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "download_limit_enabled", "false"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "download_limit_kbps", "20000"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "upload_limit_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "upload_limit_kbps", "5000"),
+				),
+			},
+			{
+				// Removing the explicit flag re-enables the download limit.
+				Config: fmt.Sprintf(`
+resource "terrifi_user_group" "test" {
+  name                = %q
+  download_limit_kbps = 20000
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "download_limit_enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_user_group.test", "upload_limit_enabled", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_user_group.test", "upload_limit_kbps"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserGroup_validationEnabledWithoutRate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_user_group" "test" {
+  name                 = "tfacc-invalid"
+  upload_limit_enabled = true
+}
+`,
+				ExpectError: regexp.MustCompile(`upload_limit_kbps is required`),
+			},
+		},
+	})
+}
+
+func TestAccUserGroup_validationRate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_user_group" "test" {
+  name                = "tfacc-invalid"
+  download_limit_kbps = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`must be between 2 and 100000`),
+			},
+		},
+	})
+}
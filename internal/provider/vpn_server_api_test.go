@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 7748 section 6.1 test vectors, base64-encoded as WireGuard keys.
+const (
+	testWireGuardPrivateKey = "dwdtCnMYpX08FsFyUbJmRd9ML4frwJkqsXf7pR25LCo="
+	testWireGuardPublicKey  = "hSDwCYkwp1R0i33ctD73Wg2/Og0mOBr066SpjqqbTmo="
+	testPeerPublicKey       = "3p7bfXt9wbTTW2HC7OQ1Nz+DQ8hbeGdNrfx+FG+IK08="
+)
+
+func TestWireGuardPublicKey(t *testing.T) {
+	publicKey, err := wireguardPublicKey(testWireGuardPrivateKey)
+	require.NoError(t, err)
+	assert.Equal(t, testWireGuardPublicKey, publicKey)
+
+	_, err = wireguardPublicKey("not base64!")
+	assert.Error(t, err)
+
+	_, err = wireguardPublicKey("c2hvcnQ=")
+	assert.Error(t, err)
+}
+
+func TestGenerateWireGuardKey(t *testing.T) {
+	key, err := generateWireGuardKey()
+	require.NoError(t, err)
+	assert.Regexp(t, wireguardKeyRegexp, key)
+
+	publicKey, err := wireguardPublicKey(key)
+	require.NoError(t, err)
+	assert.Regexp(t, wireguardKeyRegexp, publicKey)
+
+	other, err := generateWireGuardKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
@@ -0,0 +1,789 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// Compile-time interface checks.
+var (
+	_ resource.Resource                     = &vpnServerResource{}
+	_ resource.ResourceWithImportState      = &vpnServerResource{}
+	_ resource.ResourceWithConfigValidators = &vpnServerResource{}
+	_ resource.ResourceWithModifyPlan       = &vpnServerResource{}
+)
+
+// defaultWireGuardPort is the listen port the UniFi UI suggests for new
+// WireGuard servers.
+const defaultWireGuardPort = 51820
+
+// vpnServerTypes maps the type attribute to the network's vpn_type.
+var vpnServerTypes = map[string]string{
+	"wireguard": "wireguard-server",
+	"l2tp":      "l2tp-server",
+}
+
+// wireguardKeyRegexp matches a base64-encoded 32-byte WireGuard key.
+var wireguardKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9+/]{42}[AEIMQUYcgkosw048]=$`)
+
+// NewVPNServerResource is the factory function registered in provider.Resources().
+func NewVPNServerResource() resource.Resource {
+	return &vpnServerResource{}
+}
+
+// vpnServerResource holds the API client, injected by Configure().
+type vpnServerResource struct {
+	client ClientAPI
+}
+
+// vpnServerResourceModel is the Terraform-side representation of a
+// remote-access VPN server. The controller stores VPN servers as networks
+// with purpose remote-user-vpn.
+type vpnServerResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Site         types.String `tfsdk:"site"`
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	Subnet       types.String `tfsdk:"subnet"`
+	WANInterface types.String `tfsdk:"wan_interface"`
+
+	// WireGuard
+	ListenPort types.Int64          `tfsdk:"listen_port"`
+	PrivateKey types.String         `tfsdk:"private_key"`
+	PublicKey  types.String         `tfsdk:"public_key"`
+	Peers      []vpnServerPeerModel `tfsdk:"peers"`
+
+	// L2TP
+	PreSharedKey     types.String `tfsdk:"pre_shared_key"`
+	RADIUSProfileID  types.String `tfsdk:"radius_profile_id"`
+	RequireMSCHAPv2  types.Bool   `tfsdk:"require_mschapv2"`
+	AllowWeakCiphers types.Bool   `tfsdk:"allow_weak_ciphers"`
+}
+
+// vpnServerPeerModel is a client of a WireGuard server.
+type vpnServerPeerModel struct {
+	Name         types.String `tfsdk:"name"`
+	InterfaceIP  types.String `tfsdk:"interface_ip"`
+	PublicKey    types.String `tfsdk:"public_key"`
+	PresharedKey types.String `tfsdk:"preshared_key"`
+}
+
+// Metadata sets the resource type name.
+func (r *vpnServerResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_vpn_server"
+}
+
+// Schema defines the HCL schema for the terrifi_vpn_server resource.
+func (r *vpnServerResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a remote-access VPN server on the UniFi gateway: a WireGuard server with its " +
+			"client peers, or an L2TP over IPsec server that authenticates users against a RADIUS profile.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the VPN server network.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to associate the VPN server with. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the VPN server.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The VPN protocol: `wireguard` or `l2tp`. Changing this forces a new resource.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("wireguard", "l2tp"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the VPN server accepts connections. Default: `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+
+			"subnet": schema.StringAttribute{
+				MarkdownDescription: "The gateway address and prefix length of the network VPN clients are " +
+					"assigned addresses from, e.g. `192.168.3.1/24`.",
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wanIPv4CIDRRegexp, "must be an IPv4 address with a prefix length, e.g. 192.168.3.1/24"),
+				},
+			},
+
+			"wan_interface": schema.StringAttribute{
+				MarkdownDescription: "The WAN the server listens on: `wan` for the primary, `wan2` for the second, " +
+					"and so on. Default: `wan`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("wan"),
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^wan[2-9]?$`), "must be wan, wan2, ..., wan9"),
+				},
+			},
+
+			"listen_port": schema.Int64Attribute{
+				MarkdownDescription: "The UDP port the WireGuard server listens on. Only valid when `type` is " +
+					"`wireguard`. Default: `51820`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
+
+			"private_key": schema.StringAttribute{
+				MarkdownDescription: "The WireGuard server's base64-encoded private key. Only valid when `type` is " +
+					"`wireguard`. If not set, a key is generated when the server is created.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wireguardKeyRegexp, "must be a base64-encoded WireGuard key"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "The WireGuard server's public key, for the client configurations. Null for " +
+					"L2TP servers.",
+				Computed: true,
+			},
+
+			"peers": schema.ListNestedAttribute{
+				MarkdownDescription: "The clients allowed to connect to the WireGuard server. Only valid when " +
+					"`type` is `wireguard`. Peers are matched by name, and peers added outside Terraform are " +
+					"removed.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the peer. Must be unique within the server.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 128),
+							},
+						},
+						"interface_ip": schema.StringAttribute{
+							MarkdownDescription: "The address assigned to the peer, within `subnet`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+							},
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "The peer's base64-encoded public key.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(wireguardKeyRegexp, "must be a base64-encoded WireGuard key"),
+							},
+						},
+						"preshared_key": schema.StringAttribute{
+							MarkdownDescription: "An optional base64-encoded pre-shared key for the peer, which adds " +
+								"a layer of symmetric encryption.",
+							Optional:  true,
+							Sensitive: true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(wireguardKeyRegexp, "must be a base64-encoded WireGuard key"),
+							},
+						},
+					},
+				},
+			},
+
+			"pre_shared_key": schema.StringAttribute{
+				MarkdownDescription: "The IPsec pre-shared key clients authenticate the tunnel with. Required when " +
+					"`type` is `l2tp`.",
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^"' ]+$`), "must not be empty or contain spaces or quotes"),
+				},
+			},
+
+			"radius_profile_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the RADIUS profile that authenticates L2TP users. Only valid when " +
+					"`type` is `l2tp`. If not set, the controller uses the default profile, which is served by " +
+					"its built-in RADIUS server (see `terrifi_radius_user`).",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"require_mschapv2": schema.BoolAttribute{
+				MarkdownDescription: "Whether L2TP users must authenticate with MS-CHAPv2. Only valid when `type` " +
+					"is `l2tp`. Default: `false`.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"allow_weak_ciphers": schema.BoolAttribute{
+				MarkdownDescription: "Whether the L2TP server accepts legacy ciphers such as 3DES and SHA1, for " +
+					"older clients. Only valid when `type` is `l2tp`. Default: `false`.",
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *vpnServerResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		vpnServerTypeFieldsValidator{},
+	}
+}
+
+// Configure is called by the framework to inject the provider's API client.
+func (r *vpnServerResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// ModifyPlan fills in the attributes whose default depends on type, and
+// derives the WireGuard public key from a known private key so that it is
+// shown in the plan.
+func (r *vpnServerResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to fill in.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan vpnServerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Type.IsUnknown() {
+		return
+	}
+
+	if plan.Type.ValueString() == "wireguard" {
+		if plan.ListenPort.IsUnknown() {
+			plan.ListenPort = types.Int64Value(defaultWireGuardPort)
+		}
+		plan.RADIUSProfileID = types.StringNull()
+		plan.RequireMSCHAPv2 = types.BoolNull()
+		plan.AllowWeakCiphers = types.BoolNull()
+		if !plan.PrivateKey.IsUnknown() && !plan.PrivateKey.IsNull() {
+			if publicKey, err := wireguardPublicKey(plan.PrivateKey.ValueString()); err == nil {
+				plan.PublicKey = types.StringValue(publicKey)
+			}
+		}
+	} else {
+		plan.ListenPort = types.Int64Null()
+		plan.PrivateKey = types.StringNull()
+		plan.PublicKey = types.StringNull()
+		if plan.RequireMSCHAPv2.IsUnknown() {
+			plan.RequireMSCHAPv2 = types.BoolValue(false)
+		}
+		if plan.AllowWeakCiphers.IsUnknown() {
+			plan.AllowWeakCiphers = types.BoolValue(false)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates a new VPN server and its peers.
+func (r *vpnServerResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan vpnServerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+
+	if plan.Type.ValueString() == "wireguard" && (plan.PrivateKey.IsUnknown() || plan.PrivateKey.IsNull()) {
+		key, err := generateWireGuardKey()
+		if err != nil {
+			resp.Diagnostics.AddError("Error Creating VPN Server", err.Error())
+			return
+		}
+		plan.PrivateKey = types.StringValue(key)
+	}
+
+	id, err := r.client.CreateVPNServer(ctx, site, r.modelToAPI(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating VPN Server", err.Error())
+		return
+	}
+	plan.ID = types.StringValue(id)
+
+	// The server exists from here on, so it is saved to state even if adding
+	// its peers fails; the next apply retries them.
+	if err := r.syncPeers(ctx, site, id, nil, plan.Peers); err != nil {
+		resp.Diagnostics.AddError("Error Adding VPN Server Peers", err.Error())
+	}
+
+	network, peers, err := r.read(ctx, site, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading VPN Server After Create", err.Error())
+	} else {
+		r.apiToModel(network, peers, &plan, site)
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state from the actual API state.
+func (r *vpnServerResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state vpnServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	network, peers, err := r.read(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading VPN Server",
+			fmt.Sprintf("Could not read VPN server %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(network, peers, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates an existing VPN server and syncs its peers.
+func (r *vpnServerResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan vpnServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+	id := state.ID.ValueString()
+
+	// Refuse to overwrite edits made on the controller since the plan was made.
+	current, currentPeers, err := r.read(ctx, site, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading VPN Server for Update", err.Error())
+		return
+	}
+	verify := state
+	r.apiToModel(current, currentPeers, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "VPN server", id, &state, &verify) {
+		return
+	}
+
+	if err := r.client.UpdateVPNServer(ctx, site, id, r.modelToAPI(&plan)); err != nil {
+		resp.Diagnostics.AddError("Error Updating VPN Server", err.Error())
+		return
+	}
+
+	if err := r.syncPeers(ctx, site, id, currentPeers, plan.Peers); err != nil {
+		resp.Diagnostics.AddError("Error Updating VPN Server Peers", err.Error())
+		return
+	}
+
+	network, peers, err := r.read(ctx, site, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading VPN Server After Update", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	r.apiToModel(network, peers, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the VPN server, and with it its peers, from the controller.
+func (r *vpnServerResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var state vpnServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	err := r.client.DeleteNetwork(ctx, site, state.ID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			return
+		}
+		resp.Diagnostics.AddError("Error Deleting VPN Server", err.Error())
+	}
+}
+
+// ImportState handles `terraform import terrifi_vpn_server.name <id>`.
+// Supports both "id" and "site:id" formats.
+func (r *vpnServerResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+// read reads the server network and, for WireGuard servers, its peers. A
+// network that isn't a VPN server is reported as not found, so that an ID
+// reused by another network isn't adopted.
+func (r *vpnServerResource) read(ctx context.Context, site, id string) (*unifi.Network, []wireguardPeer, error) {
+	network, err := r.client.GetNetwork(ctx, site, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if network.Purpose != unifi.PurposeUserVPN {
+		return nil, nil, &unifi.NotFoundError{}
+	}
+	if network.VPNType == nil || *network.VPNType != vpnServerTypes["wireguard"] {
+		return network, nil, nil
+	}
+	peers, err := r.client.ListWireGuardPeers(ctx, site, id)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing peers: %w", err)
+	}
+	return network, peers, nil
+}
+
+// syncPeers makes the server's peers match desired, matching them by name.
+func (r *vpnServerResource) syncPeers(ctx context.Context, site, id string, current []wireguardPeer, desired []vpnServerPeerModel) error {
+	add, update, remove := planWireGuardPeers(current, desired)
+	if len(remove) > 0 {
+		if err := r.client.DeleteWireGuardPeers(ctx, site, id, remove); err != nil {
+			return fmt.Errorf("removing peers: %w", err)
+		}
+	}
+	if len(update) > 0 {
+		if err := r.client.UpdateWireGuardPeers(ctx, site, id, update); err != nil {
+			return fmt.Errorf("updating peers: %w", err)
+		}
+	}
+	if len(add) > 0 {
+		for i := range add {
+			add[i].NetworkID = id
+		}
+		if err := r.client.CreateWireGuardPeers(ctx, site, id, add); err != nil {
+			return fmt.Errorf("adding peers: %w", err)
+		}
+	}
+	return nil
+}
+
+// planWireGuardPeers compares the current peers with the desired ones by
+// name and returns the peers to add, the changed peers to update (with their
+// IDs), and the IDs of the peers to remove. Removals come first when
+// applied, so an address can move from a removed peer to a new one.
+func planWireGuardPeers(current []wireguardPeer, desired []vpnServerPeerModel) (add, update []wireguardPeer, remove []string) {
+	byName := make(map[string]wireguardPeer, len(current))
+	for _, p := range current {
+		byName[p.Name] = p
+	}
+
+	for _, d := range desired {
+		want := wireguardPeer{
+			Name:         d.Name.ValueString(),
+			InterfaceIP:  d.InterfaceIP.ValueString(),
+			PublicKey:    d.PublicKey.ValueString(),
+			PresharedKey: d.PresharedKey.ValueString(),
+		}
+		have, ok := byName[want.Name]
+		if !ok {
+			add = append(add, want)
+			continue
+		}
+		delete(byName, want.Name)
+		// The controller may not return pre-shared keys, so an empty one
+		// isn't a difference.
+		if have.InterfaceIP != want.InterfaceIP || have.PublicKey != want.PublicKey ||
+			(have.PresharedKey != "" && have.PresharedKey != want.PresharedKey) {
+			want.ID = have.ID
+			want.NetworkID = have.NetworkID
+			update = append(update, want)
+		}
+	}
+
+	for _, p := range current {
+		if _, ok := byName[p.Name]; ok {
+			remove = append(remove, p.ID)
+		}
+	}
+	return add, update, remove
+}
+
+// modelToAPI converts our Terraform model to the VPN server payload.
+func (r *vpnServerResource) modelToAPI(m *vpnServerResourceModel) *vpnServerPayload {
+	payload := &vpnServerPayload{
+		Name:     m.Name.ValueString(),
+		Purpose:  unifi.PurposeUserVPN,
+		VPNType:  vpnServerTypes[m.Type.ValueString()],
+		Enabled:  m.Enabled.ValueBool(),
+		IPSubnet: m.Subnet.ValueString(),
+	}
+
+	switch m.Type.ValueString() {
+	case "wireguard":
+		payload.LocalPort = m.ListenPort.ValueInt64Pointer()
+		payload.WireguardInterface = m.WANInterface.ValueString()
+		payload.WireguardLocalWANIP = "any"
+		payload.WireguardPrivateKey = m.PrivateKey.ValueString()
+		if publicKey, err := wireguardPublicKey(payload.WireguardPrivateKey); err == nil {
+			payload.WireguardPublicKey = publicKey
+		}
+	case "l2tp":
+		payload.L2TPInterface = m.WANInterface.ValueString()
+		payload.L2TPLocalWANIP = "any"
+		payload.IPSecPreSharedKey = m.PreSharedKey.ValueString()
+		if !m.RADIUSProfileID.IsUnknown() {
+			payload.RADIUSProfileID = m.RADIUSProfileID.ValueString()
+		}
+		payload.RequireMschapv2 = m.RequireMSCHAPv2.ValueBoolPointer()
+		payload.L2TPAllowWeakCiphers = m.AllowWeakCiphers.ValueBoolPointer()
+	}
+
+	return payload
+}
+
+// apiToModel converts the VPN server network and its peers back to our
+// Terraform model. Keys the controller doesn't return are kept from the
+// model.
+func (r *vpnServerResource) apiToModel(n *unifi.Network, peers []wireguardPeer, m *vpnServerResourceModel, site string) {
+	m.ID = types.StringValue(n.ID)
+	m.Site = types.StringValue(site)
+	m.Name = types.StringPointerValue(n.Name)
+	m.Enabled = types.BoolValue(n.Enabled)
+	m.Subnet = types.StringPointerValue(n.IPSubnet)
+
+	m.Type = types.StringNull()
+	for t, vpnType := range vpnServerTypes {
+		if n.VPNType != nil && *n.VPNType == vpnType {
+			m.Type = types.StringValue(t)
+		}
+	}
+
+	m.ListenPort = types.Int64Null()
+	m.PublicKey = types.StringNull()
+	m.RADIUSProfileID = types.StringNull()
+	m.RequireMSCHAPv2 = types.BoolNull()
+	m.AllowWeakCiphers = types.BoolNull()
+
+	switch m.Type.ValueString() {
+	case "wireguard":
+		m.WANInterface = types.StringPointerValue(n.WireguardInterface)
+		m.ListenPort = types.Int64PointerValue(n.LocalPort)
+		if n.WireguardPrivateKey != nil && *n.WireguardPrivateKey != "" {
+			m.PrivateKey = types.StringValue(*n.WireguardPrivateKey)
+		} else if m.PrivateKey.IsUnknown() {
+			m.PrivateKey = types.StringNull()
+		}
+		m.PublicKey = types.StringPointerValue(n.WireguardPublicKey)
+		m.PreSharedKey = types.StringNull()
+		m.Peers = peersToModel(peers, m.Peers)
+	case "l2tp":
+		m.WANInterface = types.StringPointerValue(n.L2TpInterface)
+		m.PrivateKey = types.StringNull()
+		m.Peers = nil
+		if n.XIPSecPreSharedKey != nil && *n.XIPSecPreSharedKey != "" {
+			m.PreSharedKey = types.StringValue(*n.XIPSecPreSharedKey)
+		}
+		m.RADIUSProfileID = types.StringPointerValue(n.RADIUSProfileID)
+		m.RequireMSCHAPv2 = types.BoolValue(n.RequireMschapv2)
+		m.AllowWeakCiphers = types.BoolValue(n.L2TpAllowWeakCiphers)
+	}
+}
+
+// peersToModel converts the server's peers, in the order of the prior model
+// where they appear in it. Pre-shared keys the controller doesn't return are
+// kept from the prior model.
+func peersToModel(peers []wireguardPeer, prior []vpnServerPeerModel) []vpnServerPeerModel {
+	if len(peers) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]wireguardPeer, len(peers))
+	for _, p := range peers {
+		byName[p.Name] = p
+	}
+	toModel := func(p wireguardPeer, prior types.String) vpnServerPeerModel {
+		m := vpnServerPeerModel{
+			Name:         types.StringValue(p.Name),
+			InterfaceIP:  types.StringValue(p.InterfaceIP),
+			PublicKey:    types.StringValue(p.PublicKey),
+			PresharedKey: stringValueOrNull(p.PresharedKey),
+		}
+		if p.PresharedKey == "" {
+			m.PresharedKey = prior
+		}
+		return m
+	}
+
+	result := make([]vpnServerPeerModel, 0, len(peers))
+	for _, pm := range prior {
+		if p, ok := byName[pm.Name.ValueString()]; ok {
+			result = append(result, toModel(p, pm.PresharedKey))
+			delete(byName, p.Name)
+		}
+	}
+	for _, p := range peers {
+		if _, ok := byName[p.Name]; ok {
+			result = append(result, toModel(p, types.StringNull()))
+		}
+	}
+	return result
+}
+
+// vpnServerTypeFieldsValidator ensures the WireGuard and L2TP attributes are
+// only set for their type, and that L2TP servers have a pre-shared key.
+type vpnServerTypeFieldsValidator struct{}
+
+func (v vpnServerTypeFieldsValidator) Description(_ context.Context) string {
+	return "listen_port, private_key and peers can only be set for WireGuard servers; pre_shared_key is " +
+		"required for L2TP servers, and it, radius_profile_id, require_mschapv2 and allow_weak_ciphers can only " +
+		"be set for them."
+}
+
+func (v vpnServerTypeFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v vpnServerTypeFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config vpnServerResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() || config.Type.IsNull() {
+		return
+	}
+	serverType := config.Type.ValueString()
+
+	fields := []struct {
+		name     string
+		set      bool
+		forType  string
+		required bool
+	}{
+		{"listen_port", !config.ListenPort.IsNull(), "wireguard", false},
+		{"private_key", !config.PrivateKey.IsNull(), "wireguard", false},
+		{"peers", config.Peers != nil, "wireguard", false},
+		{"pre_shared_key", !config.PreSharedKey.IsNull(), "l2tp", true},
+		{"radius_profile_id", !config.RADIUSProfileID.IsNull(), "l2tp", false},
+		{"require_mschapv2", !config.RequireMSCHAPv2.IsNull(), "l2tp", false},
+		{"allow_weak_ciphers", !config.AllowWeakCiphers.IsNull(), "l2tp", false},
+	}
+	for _, f := range fields {
+		switch {
+		case serverType == f.forType && f.required && !f.set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Missing Required Attribute",
+				fmt.Sprintf("%s is required when type is %q.", f.name, f.forType),
+			)
+		case serverType != f.forType && f.set:
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s can only be set when type is %q.", f.name, f.forType),
+			)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func baseVPNServerModel(serverType string) vpnServerResourceModel {
+	return vpnServerResourceModel{
+		Name:             types.StringValue("Remote Access"),
+		Type:             types.StringValue(serverType),
+		Enabled:          types.BoolValue(true),
+		Subnet:           types.StringValue("192.168.3.1/24"),
+		WANInterface:     types.StringValue("wan"),
+		ListenPort:       types.Int64Null(),
+		PrivateKey:       types.StringNull(),
+		PublicKey:        types.StringNull(),
+		PreSharedKey:     types.StringNull(),
+		RADIUSProfileID:  types.StringNull(),
+		RequireMSCHAPv2:  types.BoolNull(),
+		AllowWeakCiphers: types.BoolNull(),
+	}
+}
+
+func TestVPNServerModelToAPI(t *testing.T) {
+	r := &vpnServerResource{}
+
+	t.Run("wireguard", func(t *testing.T) {
+		m := baseVPNServerModel("wireguard")
+		m.ListenPort = types.Int64Value(51821)
+		m.PrivateKey = types.StringValue(testWireGuardPrivateKey)
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, "Remote Access", payload.Name)
+		assert.Equal(t, unifi.PurposeUserVPN, payload.Purpose)
+		assert.Equal(t, "wireguard-server", payload.VPNType)
+		assert.True(t, payload.Enabled)
+		assert.Equal(t, "192.168.3.1/24", payload.IPSubnet)
+		require.NotNil(t, payload.LocalPort)
+		assert.Equal(t, int64(51821), *payload.LocalPort)
+		assert.Equal(t, "wan", payload.WireguardInterface)
+		assert.Equal(t, "any", payload.WireguardLocalWANIP)
+		assert.Equal(t, testWireGuardPrivateKey, payload.WireguardPrivateKey)
+		assert.Equal(t, testWireGuardPublicKey, payload.WireguardPublicKey)
+		assert.Empty(t, payload.L2TPInterface)
+		assert.Empty(t, payload.IPSecPreSharedKey)
+		assert.Nil(t, payload.RequireMschapv2)
+		assert.Nil(t, payload.L2TPAllowWeakCiphers)
+	})
+
+	t.Run("l2tp", func(t *testing.T) {
+		m := baseVPNServerModel("l2tp")
+		m.WANInterface = types.StringValue("wan2")
+		m.PreSharedKey = types.StringValue("shared-secret")
+		m.RADIUSProfileID = types.StringUnknown()
+		m.RequireMSCHAPv2 = types.BoolValue(true)
+		m.AllowWeakCiphers = types.BoolValue(false)
+		payload := r.modelToAPI(&m)
+
+		assert.Equal(t, "l2tp-server", payload.VPNType)
+		assert.Equal(t, "wan2", payload.L2TPInterface)
+		assert.Equal(t, "any", payload.L2TPLocalWANIP)
+		assert.Equal(t, "shared-secret", payload.IPSecPreSharedKey)
+		assert.Empty(t, payload.RADIUSProfileID, "unknown profile is left to the controller")
+		require.NotNil(t, payload.RequireMschapv2)
+		assert.True(t, *payload.RequireMschapv2)
+		require.NotNil(t, payload.L2TPAllowWeakCiphers)
+		assert.False(t, *payload.L2TPAllowWeakCiphers)
+		assert.Nil(t, payload.LocalPort)
+		assert.Empty(t, payload.WireguardInterface)
+		assert.Empty(t, payload.WireguardPrivateKey)
+	})
+}
+
+func TestVPNServerAPIToModel(t *testing.T) {
+	r := &vpnServerResource{}
+	str := func(s string) *string { return &s }
+	port := int64(51820)
+
+	t.Run("wireguard", func(t *testing.T) {
+		m := baseVPNServerModel("wireguard")
+		m.PrivateKey = types.StringUnknown()
+		m.Peers = []vpnServerPeerModel{{
+			Name:         types.StringValue("laptop"),
+			PresharedKey: types.StringValue(testWireGuardPublicKey),
+		}}
+		r.apiToModel(&unifi.Network{
+			ID:                  "net-1",
+			Name:                str("Remote Access"),
+			Purpose:             unifi.PurposeUserVPN,
+			VPNType:             str("wireguard-server"),
+			Enabled:             true,
+			IPSubnet:            str("192.168.3.1/24"),
+			LocalPort:           &port,
+			WireguardInterface:  str("wan"),
+			WireguardPrivateKey: str(testWireGuardPrivateKey),
+			WireguardPublicKey:  str(testWireGuardPublicKey),
+		}, []wireguardPeer{
+			{ID: "peer-1", Name: "laptop", InterfaceIP: "192.168.3.2", PublicKey: testPeerPublicKey},
+		}, &m, "default")
+
+		assert.Equal(t, "net-1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "wireguard", m.Type.ValueString())
+		assert.Equal(t, int64(51820), m.ListenPort.ValueInt64())
+		assert.Equal(t, testWireGuardPrivateKey, m.PrivateKey.ValueString())
+		assert.Equal(t, testWireGuardPublicKey, m.PublicKey.ValueString())
+		assert.True(t, m.RADIUSProfileID.IsNull())
+		assert.True(t, m.RequireMSCHAPv2.IsNull())
+		require.Len(t, m.Peers, 1)
+		assert.Equal(t, "192.168.3.2", m.Peers[0].InterfaceIP.ValueString())
+		assert.Equal(t, testPeerPublicKey, m.Peers[0].PublicKey.ValueString())
+		assert.Equal(t, testWireGuardPublicKey, m.Peers[0].PresharedKey.ValueString(), "unreturned key kept from model")
+	})
+
+	t.Run("l2tp keeps unreturned pre-shared key", func(t *testing.T) {
+		m := baseVPNServerModel("l2tp")
+		m.PreSharedKey = types.StringValue("shared-secret")
+		r.apiToModel(&unifi.Network{
+			ID:                   "net-2",
+			Name:                 str("L2TP"),
+			Purpose:              unifi.PurposeUserVPN,
+			VPNType:              str("l2tp-server"),
+			IPSubnet:             str("192.168.4.1/24"),
+			L2TpInterface:        str("wan2"),
+			RADIUSProfileID:      str("profile-1"),
+			RequireMschapv2:      true,
+			L2TpAllowWeakCiphers: false,
+		}, nil, &m, "default")
+
+		assert.Equal(t, "l2tp", m.Type.ValueString())
+		assert.False(t, m.Enabled.ValueBool())
+		assert.Equal(t, "wan2", m.WANInterface.ValueString())
+		assert.Equal(t, "shared-secret", m.PreSharedKey.ValueString())
+		assert.Equal(t, "profile-1", m.RADIUSProfileID.ValueString())
+		assert.True(t, m.RequireMSCHAPv2.ValueBool())
+		assert.False(t, m.AllowWeakCiphers.ValueBool())
+		assert.True(t, m.ListenPort.IsNull())
+		assert.True(t, m.PrivateKey.IsNull())
+		assert.True(t, m.PublicKey.IsNull())
+		assert.Nil(t, m.Peers)
+	})
+}
+
+func TestPlanWireGuardPeers(t *testing.T) {
+	current := []wireguardPeer{
+		{ID: "p1", Name: "laptop", InterfaceIP: "192.168.3.2", PublicKey: testPeerPublicKey, NetworkID: "net-1"},
+		{ID: "p2", Name: "phone", InterfaceIP: "192.168.3.3", PublicKey: testPeerPublicKey, NetworkID: "net-1"},
+		{ID: "p3", Name: "tablet", InterfaceIP: "192.168.3.4", PublicKey: testPeerPublicKey, NetworkID: "net-1"},
+	}
+	peer := func(name, ip string) vpnServerPeerModel {
+		return vpnServerPeerModel{
+			Name:         types.StringValue(name),
+			InterfaceIP:  types.StringValue(ip),
+			PublicKey:    types.StringValue(testPeerPublicKey),
+			PresharedKey: types.StringNull(),
+		}
+	}
+
+	add, update, remove := planWireGuardPeers(current, []vpnServerPeerModel{
+		peer("laptop", "192.168.3.2"),  // unchanged
+		peer("phone", "192.168.3.10"),  // moved
+		peer("desktop", "192.168.3.4"), // new, reuses the removed tablet's address
+	})
+
+	require.Len(t, add, 1)
+	assert.Equal(t, "desktop", add[0].Name)
+	assert.Empty(t, add[0].ID)
+	require.Len(t, update, 1)
+	assert.Equal(t, "p2", update[0].ID)
+	assert.Equal(t, "net-1", update[0].NetworkID)
+	assert.Equal(t, "192.168.3.10", update[0].InterfaceIP)
+	assert.Equal(t, []string{"p3"}, remove)
+
+	t.Run("no desired peers removes all", func(t *testing.T) {
+		add, update, remove := planWireGuardPeers(current, nil)
+		assert.Empty(t, add)
+		assert.Empty(t, update)
+		assert.Equal(t, []string{"p1", "p2", "p3"}, remove)
+	})
+}
+
+func TestPeersToModel(t *testing.T) {
+	peers := []wireguardPeer{
+		{Name: "a", InterfaceIP: "192.168.3.2", PublicKey: testPeerPublicKey},
+		{Name: "b", InterfaceIP: "192.168.3.3", PublicKey: testPeerPublicKey, PresharedKey: testWireGuardPublicKey},
+		{Name: "c", InterfaceIP: "192.168.3.4", PublicKey: testPeerPublicKey},
+	}
+	prior := []vpnServerPeerModel{
+		{Name: types.StringValue("b")},
+		{Name: types.StringValue("a"), PresharedKey: types.StringValue(testWireGuardPrivateKey)},
+	}
+
+	result := peersToModel(peers, prior)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "b", result[0].Name.ValueString(), "prior order is kept")
+	assert.Equal(t, testWireGuardPublicKey, result[0].PresharedKey.ValueString())
+	assert.Equal(t, "a", result[1].Name.ValueString())
+	assert.Equal(t, testWireGuardPrivateKey, result[1].PresharedKey.ValueString())
+	assert.Equal(t, "c", result[2].Name.ValueString(), "new peers are appended")
+	assert.True(t, result[2].PresharedKey.IsNull())
+
+	assert.Nil(t, peersToModel(nil, prior))
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccVPNServer_wireguard(t *testing.T) {
+	requireHardware(t)
+	name := fmt.Sprintf("tfacc-wg-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_vpn_server" "test" {
+  name   = %q
+  type   = "wireguard"
+  subnet = "192.168.213.1/24"
+
+  peers = [
+    {
+      name         = "laptop"
+      interface_ip = "192.168.213.2"
+      public_key   = %q
+    },
+  ]
+}
+`, name, testPeerPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_vpn_server.test", "id"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "listen_port", "51820"),
+					resource.TestCheckResourceAttrSet("terrifi_vpn_server.test", "private_key"),
+					resource.TestCheckResourceAttrSet("terrifi_vpn_server.test", "public_key"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "peers.#", "1"),
+				),
+			},
+			{
+				ResourceName:            "terrifi_vpn_server.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"private_key"},
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_vpn_server" "test" {
+  name        = %q
+  type        = "wireguard"
+  subnet      = "192.168.213.1/24"
+  listen_port = 51821
+  private_key = %q
+
+  peers = [
+    {
+      name         = "laptop"
+      interface_ip = "192.168.213.5"
+      public_key   = %q
+    },
+    {
+      name         = "phone"
+      interface_ip = "192.168.213.6"
+      public_key   = %q
+    },
+  ]
+}
+`, name, testWireGuardPrivateKey, testPeerPublicKey, testWireGuardPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "listen_port", "51821"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "public_key", testWireGuardPublicKey),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "peers.#", "2"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "peers.0.interface_ip", "192.168.213.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVPNServer_l2tp(t *testing.T) {
+	requireHardware(t)
+	name := fmt.Sprintf("tfacc-l2tp-%s", randomSuffix())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_vpn_server" "test" {
+  name           = %q
+  type           = "l2tp"
+  subnet         = "192.168.214.1/24"
+  pre_shared_key = "initial-secret"
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("terrifi_vpn_server.test", "radius_profile_id"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "require_mschapv2", "false"),
+					resource.TestCheckNoResourceAttr("terrifi_vpn_server.test", "public_key"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_vpn_server" "test" {
+  name             = %q
+  type             = "l2tp"
+  subnet           = "192.168.214.1/24"
+  pre_shared_key   = "changed-secret"
+  require_mschapv2 = true
+  enabled          = false
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "pre_shared_key", "changed-secret"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "require_mschapv2", "true"),
+					resource.TestCheckResourceAttr("terrifi_vpn_server.test", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccVPNServer_validationTypeFields(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_vpn_server" "test" {
+  name   = "tfacc-invalid"
+  type   = "l2tp"
+  subnet = "192.168.215.1/24"
+}
+`,
+				ExpectError: regexp.MustCompile(`pre_shared_key is required when type is "l2tp"`),
+			},
+			{
+				Config: `
+resource "terrifi_vpn_server" "test" {
+  name           = "tfacc-invalid"
+  type           = "wireguard"
+  subnet         = "192.168.215.1/24"
+  pre_shared_key = "secret"
+}
+`,
+				ExpectError: regexp.MustCompile(`pre_shared_key can only be set when type is "l2tp"`),
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// gatewayWANStatus is the live state of one gateway uplink, from the wanN
+// objects of the gateway's stat/device entry.
+type gatewayWANStatus struct {
+	IfName string `json:"ifname"`
+	IP     string `json:"ip"`
+	Up     bool   `json:"up"`
+}
+
+// GetGatewayWANs returns the live uplink state of the site's gateway, keyed
+// by WAN network group ("WAN", "WAN2", ...). The SDK's Device type drops the
+// wanN objects, so this reads stat/device itself. A site without a gateway
+// returns an empty map.
+func (c *Client) GetGatewayWANs(ctx context.Context, site string) (map[string]gatewayWANStatus, error) {
+	var resp struct {
+		Data []map[string]json.RawMessage `json:"data"`
+	}
+	err := c.doV1Request(ctx, http.MethodGet,
+		fmt.Sprintf("%s%s/api/s/%s/stat/device", c.BaseURL, c.APIPath, site),
+		nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return gatewayWANsFromDevices(resp.Data)
+}
+
+// gatewayWANsFromDevices extracts the uplinks of the first gateway among the
+// raw stat/device entries. wan1 is the "WAN" network group, wan2 "WAN2", and
+// so on.
+func gatewayWANsFromDevices(devices []map[string]json.RawMessage) (map[string]gatewayWANStatus, error) {
+	wans := map[string]gatewayWANStatus{}
+	for _, d := range devices {
+		var deviceType string
+		if err := json.Unmarshal(d["type"], &deviceType); err != nil || !gatewayDeviceTypes[deviceType] {
+			continue
+		}
+		for key, raw := range d {
+			n, ok := strings.CutPrefix(key, "wan")
+			if !ok || n == "" || strings.Trim(n, "0123456789") != "" {
+				continue
+			}
+			var status gatewayWANStatus
+			if err := json.Unmarshal(raw, &status); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", key, err)
+			}
+			group := "WAN"
+			if n != "1" {
+				group += n
+			}
+			wans[group] = status
+		}
+		break
+	}
+	return wans, nil
+}
+
+// wanPayload is the body for updating a WAN network. Type-specific fields
+// that don't apply are omitted, and the controller keeps their stored values.
+type wanPayload struct {
+	Name              string `json:"name,omitempty"`
+	WANType           string `json:"wan_type"`
+	WANVLANEnabled    bool   `json:"wan_vlan_enabled"`
+	WANVLAN           *int64 `json:"wan_vlan,omitempty"`
+	WANIP             string `json:"wan_ip,omitempty"`
+	WANNetmask        string `json:"wan_netmask,omitempty"`
+	WANGateway        string `json:"wan_gateway,omitempty"`
+	WANUsername       string `json:"wan_username,omitempty"`
+	WANPassword       string `json:"x_wan_password,omitempty"`
+	WANDNSPreference  string `json:"wan_dns_preference"`
+	WANDNS1           string `json:"wan_dns1"`
+	WANDNS2           string `json:"wan_dns2"`
+	WANSmartQEnabled  bool   `json:"wan_smartq_enabled"`
+	WANSmartQDownRate *int64 `json:"wan_smartq_down_rate,omitempty"`
+	WANSmartQUpRate   *int64 `json:"wan_smartq_up_rate,omitempty"`
+}
+
+// UpdateWAN updates the settings of a WAN network.
+//
+// TODO(go-unifi): The SDK marshals WAN networks with a fixed subset of
+// fields that leaves out the static address, PPPoE credentials, DNS servers
+// and smart queue rates, so UpdateNetwork can't change them. The v1 REST API
+// merges PUT bodies into the stored network, so this sends only the fields
+// terrifi_wan manages. Fix needed in SDK: include these fields in
+// marshalWAN.
+func (c *Client) UpdateWAN(ctx context.Context, site, id string, payload *wanPayload) error {
+	var respBody struct {
+		Meta json.RawMessage `json:"meta"`
+	}
+	err := c.doV1Request(ctx, http.MethodPut,
+		fmt.Sprintf("%s%s/api/s/%s/rest/networkconf/%s", c.BaseURL, c.APIPath, site, id),
+		payload, &respBody)
+	if err != nil {
+		return err
+	}
+	return checkV1Meta(respBody.Meta)
+}
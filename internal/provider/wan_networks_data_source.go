@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &wanNetworksDataSource{}
+
+func NewWANNetworksDataSource() datasource.DataSource {
+	return &wanNetworksDataSource{}
+}
+
+type wanNetworksDataSource struct {
+	client ClientAPI
+}
+
+type wanNetworksDataSourceModel struct {
+	Site     types.String      `tfsdk:"site"`
+	Networks []wanNetworkModel `tfsdk:"networks"`
+}
+
+type wanNetworkModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	NetworkGroup types.String `tfsdk:"network_group"`
+	WANType      types.String `tfsdk:"wan_type"`
+	Enabled      types.Bool   `tfsdk:"enabled"`
+	IP           types.String `tfsdk:"ip"`
+	Up           types.Bool   `tfsdk:"up"`
+}
+
+func (d *wanNetworksDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_wan_networks"
+}
+
+func (d *wanNetworksDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the WAN networks (internet uplinks) on a site, with each uplink's current " +
+			"address. WAN networks can't be managed by `terrifi_network`; use this to refer to them from " +
+			"policies and routes, and `terrifi_wan` to configure them.",
+
+		Attributes: map[string]schema.Attribute{
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to list WAN networks in. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"networks": schema.ListNestedAttribute{
+				MarkdownDescription: "The WAN networks, ordered by network group (`WAN`, `WAN2`, ...).",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The ID of the network.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the network, e.g. `Internet 1`.",
+							Computed:            true,
+						},
+						"network_group": schema.StringAttribute{
+							MarkdownDescription: "Which uplink the network is: `WAN` for the primary, `WAN2` for the " +
+								"second, and so on.",
+							Computed: true,
+						},
+						"wan_type": schema.StringAttribute{
+							MarkdownDescription: "How the uplink gets its address, e.g. `dhcp`, `static`, or `pppoe`.",
+							Computed:            true,
+						},
+						"enabled": schema.BoolAttribute{
+							MarkdownDescription: "Whether the network is enabled.",
+							Computed:            true,
+						},
+						"ip": schema.StringAttribute{
+							MarkdownDescription: "The uplink's current IPv4 address, as reported by the gateway. For a " +
+								"`static` uplink the configured address is used if the gateway doesn't report one. " +
+								"Null when the address is unknown, e.g. the uplink is down or there is no gateway.",
+							Computed: true,
+						},
+						"up": schema.BoolAttribute{
+							MarkdownDescription: "Whether the gateway reports the uplink as connected. Null when " +
+								"the site has no gateway.",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *wanNetworksDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *wanNetworksDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config wanNetworksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+
+	networks, err := d.client.ListNetwork(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Networks",
+			fmt.Sprintf("Could not list networks in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	wans, err := d.client.GetGatewayWANs(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Gateway Uplinks",
+			fmt.Sprintf("Could not read the gateway's uplink status in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	config.Site = types.StringValue(site)
+	config.Networks = wanNetworksToModels(networks, wans)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// wanNetworksToModels converts the site's WAN networks to the data source
+// model, filling in the live state of the matching gateway uplink. Networks
+// are ordered by network group and then ID so the list is stable across
+// reads.
+func wanNetworksToModels(networks []unifi.Network, wans map[string]gatewayWANStatus) []wanNetworkModel {
+	var wanNetworks []unifi.Network
+	for _, n := range networks {
+		if n.Purpose == "wan" {
+			wanNetworks = append(wanNetworks, n)
+		}
+	}
+	group := func(n unifi.Network) string {
+		if n.WANNetworkGroup != nil {
+			return *n.WANNetworkGroup
+		}
+		return ""
+	}
+	sort.SliceStable(wanNetworks, func(i, j int) bool {
+		if group(wanNetworks[i]) != group(wanNetworks[j]) {
+			return group(wanNetworks[i]) < group(wanNetworks[j])
+		}
+		return wanNetworks[i].ID < wanNetworks[j].ID
+	})
+
+	models := make([]wanNetworkModel, len(wanNetworks))
+	for i, n := range wanNetworks {
+		m := wanNetworkModel{
+			ID:           types.StringValue(n.ID),
+			Name:         types.StringPointerValue(n.Name),
+			NetworkGroup: stringValueOrNull(group(n)),
+			WANType:      types.StringPointerValue(n.WANType),
+			Enabled:      types.BoolValue(n.Enabled),
+			IP:           types.StringNull(),
+			Up:           types.BoolNull(),
+		}
+		if status, ok := wans[group(n)]; ok {
+			m.IP = stringValueOrNull(status.IP)
+			m.Up = types.BoolValue(status.Up)
+		}
+		if m.IP.IsNull() && n.WANType != nil && *n.WANType == "static" && n.WANIP != nil {
+			m.IP = stringValueOrNull(*n.WANIP)
+		}
+		models[i] = m
+	}
+	return models
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func TestGetGatewayWANs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/proxy/network/api/s/default/stat/device", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"meta":{"rc":"ok"},"data":[
+			{"type":"usw","mac":"aa:bb:cc:00:00:01","wan1":{"ip":"10.9.9.9","up":true}},
+			{"type":"udm","mac":"aa:bb:cc:00:00:02",
+			 "wan1":{"ifname":"eth8","ip":"203.0.113.7","up":true},
+			 "wan2":{"ifname":"eth9","ip":"","up":false},
+			 "wan_magic_enabled":true}
+		]}`))
+	}))
+	defer srv.Close()
+
+	wans, err := newTestClient(t, srv.URL, false).GetGatewayWANs(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]gatewayWANStatus{
+		"WAN":  {IfName: "eth8", IP: "203.0.113.7", Up: true},
+		"WAN2": {IfName: "eth9", IP: "", Up: false},
+	}, wans)
+}
+
+func TestWANNetworksToModels(t *testing.T) {
+	str := func(s string) *string { return &s }
+	networks := []unifi.Network{
+		{ID: "lan", Name: str("Default"), Purpose: "corporate"},
+		{ID: "wan2", Name: str("Internet 2"), Purpose: "wan", WANNetworkGroup: str("WAN2"), WANType: str("static"), WANIP: str("198.51.100.2"), Enabled: true},
+		{ID: "wan1", Name: str("Internet 1"), Purpose: "wan", WANNetworkGroup: str("WAN"), WANType: str("dhcp"), Enabled: true},
+	}
+
+	t.Run("with gateway status", func(t *testing.T) {
+		models := wanNetworksToModels(networks, map[string]gatewayWANStatus{
+			"WAN":  {IP: "203.0.113.7", Up: true},
+			"WAN2": {Up: false},
+		})
+		require.Len(t, models, 2)
+
+		assert.Equal(t, "wan1", models[0].ID.ValueString())
+		assert.Equal(t, "Internet 1", models[0].Name.ValueString())
+		assert.Equal(t, "WAN", models[0].NetworkGroup.ValueString())
+		assert.Equal(t, "dhcp", models[0].WANType.ValueString())
+		assert.True(t, models[0].Enabled.ValueBool())
+		assert.Equal(t, "203.0.113.7", models[0].IP.ValueString())
+		assert.True(t, models[0].Up.ValueBool())
+
+		assert.Equal(t, "wan2", models[1].ID.ValueString())
+		assert.Equal(t, "198.51.100.2", models[1].IP.ValueString(), "static address is the fallback")
+		assert.False(t, models[1].Up.ValueBool())
+	})
+
+	t.Run("without a gateway", func(t *testing.T) {
+		models := wanNetworksToModels(networks, map[string]gatewayWANStatus{})
+		require.Len(t, models, 2)
+		assert.True(t, models[0].IP.IsNull())
+		assert.True(t, models[0].Up.IsNull())
+		assert.Equal(t, "198.51.100.2", models[1].IP.ValueString())
+		assert.True(t, models[1].Up.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccWANNetworksDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "terrifi_wan_networks" "all" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.terrifi_wan_networks.all", "site", "default"),
+					resource.TestCheckResourceAttrSet("data.terrifi_wan_networks.all", "networks.#"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,548 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var (
+	_ resource.Resource                = &wanResource{}
+	_ resource.ResourceWithImportState = &wanResource{}
+
+	_ resource.ResourceWithConfigValidators = &wanResource{}
+)
+
+func NewWANResource() resource.Resource {
+	return &wanResource{}
+}
+
+type wanResource struct {
+	client ClientAPI
+}
+
+type wanResourceModel struct {
+	ID            types.String         `tfsdk:"id"`
+	Site          types.String         `tfsdk:"site"`
+	NetworkGroup  types.String         `tfsdk:"network_group"`
+	Name          types.String         `tfsdk:"name"`
+	Type          types.String         `tfsdk:"type"`
+	VLANId        types.Int64          `tfsdk:"vlan_id"`
+	IPAddress     types.String         `tfsdk:"ip_address"`
+	Gateway       types.String         `tfsdk:"gateway"`
+	PPPoEUsername types.String         `tfsdk:"pppoe_username"`
+	PPPoEPassword types.String         `tfsdk:"pppoe_password"`
+	DNSServers    types.List           `tfsdk:"dns_servers"`
+	SmartQueues   *wanSmartQueuesModel `tfsdk:"smart_queues"`
+}
+
+// wanSmartQueuesModel enables smart queues (SQM) with the uplink's rates.
+type wanSmartQueuesModel struct {
+	DownloadKbps types.Int64 `tfsdk:"download_kbps"`
+	UploadKbps   types.Int64 `tfsdk:"upload_kbps"`
+}
+
+// wanIPv4CIDRRegexp matches an IPv4 address with a prefix length, e.g.
+// 203.0.113.10/29.
+var wanIPv4CIDRRegexp = regexp.MustCompile(`^` + ipv4Pattern + `/([1-9]|[12][0-9]|3[0-2])$`)
+
+// pppoeUsernameRegexp matches the PPPoE usernames accepted by the controller.
+var pppoeUsernameRegexp = regexp.MustCompile(`^[^"' ]+$`)
+
+func (r *wanResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_wan"
+}
+
+func (r *wanResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the configuration of a WAN network (internet uplink) on the UniFi controller. " +
+			"WAN networks are created by the gateway, one per uplink port, so creating this resource adopts the " +
+			"existing WAN network of `network_group`, and destroying it leaves the network unchanged.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the WAN network.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site of the WAN network. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"network_group": schema.StringAttribute{
+				MarkdownDescription: "Which uplink to manage: `WAN` for the primary, `WAN2` for the second, and so on.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wanNetworkGroupRegexp, "must be WAN, WAN2, ..., WAN9"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the WAN network, e.g. `Internet 1`. If not set, the controller's " +
+					"current name is kept.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 128),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"type": schema.StringAttribute{
+				MarkdownDescription: "How the uplink gets its address: `dhcp`, `static` or `pppoe`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("dhcp", "static", "pppoe"),
+				},
+			},
+
+			"vlan_id": schema.Int64Attribute{
+				MarkdownDescription: "The VLAN to tag uplink traffic with, for ISPs that require one (1-4094). " +
+					"If not set, traffic is untagged.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 4094),
+				},
+			},
+
+			"ip_address": schema.StringAttribute{
+				MarkdownDescription: "The static IPv4 address and prefix length, e.g. `203.0.113.10/29`. Required " +
+					"when `type` is `static`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(wanIPv4CIDRRegexp, "must be an IPv4 address with a prefix length, e.g. 203.0.113.10/29"),
+				},
+			},
+
+			"gateway": schema.StringAttribute{
+				MarkdownDescription: "The static IPv4 gateway. Required when `type` is `static`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address"),
+				},
+			},
+
+			"pppoe_username": schema.StringAttribute{
+				MarkdownDescription: "The PPPoE username. Required when `type` is `pppoe`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pppoeUsernameRegexp, "must not contain spaces or quotes"),
+				},
+			},
+
+			"pppoe_password": schema.StringAttribute{
+				MarkdownDescription: "The PPPoE password. Only valid when `type` is `pppoe`. If not set, the " +
+					"controller's current password is kept.",
+				Optional:  true,
+				Computed:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(pppoeUsernameRegexp, "must not contain spaces or quotes"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"dns_servers": schema.ListAttribute{
+				MarkdownDescription: "Up to two IPv4 DNS servers for the gateway to use. If not set, the servers " +
+					"provided by the ISP are used.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeBetween(1, 2),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(ipv4Regexp, "must be an IPv4 address")),
+				},
+			},
+
+			"smart_queues": schema.SingleNestedAttribute{
+				MarkdownDescription: "Enables smart queues, which reduce latency under load by shaping traffic " +
+					"slightly below the uplink's rates. If not set, smart queues are disabled.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"download_kbps": schema.Int64Attribute{
+						MarkdownDescription: "The uplink's download rate in kbps (1-1000000).",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 1000000),
+						},
+					},
+					"upload_kbps": schema.Int64Attribute{
+						MarkdownDescription: "The uplink's upload rate in kbps (1-1000000).",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.Between(1, 1000000),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *wanResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		wanTypeFieldsValidator{},
+	}
+}
+
+func (r *wanResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *wanResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var plan wanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	group := plan.NetworkGroup.ValueString()
+
+	// WAN networks belong to the gateway's uplinks; "create" adopts one.
+	networks, err := r.client.ListNetwork(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Listing Networks", err.Error())
+		return
+	}
+	var id string
+	for _, n := range networks {
+		if n.Purpose == unifi.PurposeWAN && n.WANNetworkGroup != nil && *n.WANNetworkGroup == group {
+			id = n.ID
+			break
+		}
+	}
+	if id == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("network_group"),
+			"WAN Network Not Found",
+			fmt.Sprintf("Site %s has no WAN network in group %s. The gateway creates one WAN network per uplink "+
+				"port; check that the port is configured as a WAN in the UniFi UI.", site, group),
+		)
+		return
+	}
+
+	if err := r.client.UpdateWAN(ctx, site, id, r.modelToAPI(ctx, &plan)); err != nil {
+		resp.Diagnostics.AddError("Error Updating WAN", err.Error())
+		return
+	}
+
+	network, err := r.client.GetNetwork(ctx, site, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading WAN After Update", err.Error())
+		return
+	}
+
+	r.apiToModel(ctx, network, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *wanResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var state wanResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+
+	network, err := r.client.GetNetwork(ctx, site, state.ID.ValueString())
+	if err != nil {
+		if _, ok := err.(*unifi.NotFoundError); ok {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading WAN",
+			fmt.Sprintf("Could not read WAN network %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	r.apiToModel(ctx, network, &state, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *wanResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var state, plan wanResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := r.client.SiteOrDefault(state.Site)
+	id := state.ID.ValueString()
+
+	if err := r.client.UpdateWAN(ctx, site, id, r.modelToAPI(ctx, &plan)); err != nil {
+		resp.Diagnostics.AddError("Error Updating WAN", err.Error())
+		return
+	}
+
+	network, err := r.client.GetNetwork(ctx, site, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading WAN After Update", err.Error())
+		return
+	}
+
+	plan.ID = state.ID
+	r.apiToModel(ctx, network, &plan, site)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *wanResource) Delete(
+	ctx context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// No API call — WAN networks belong to the gateway's uplinks and can't be
+	// deleted. Removing the resource only stops Terraform from managing it.
+	tflog.Info(ctx, "Removing WAN from state (network continues to exist on controller)")
+}
+
+func (r *wanResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, ":", 2)
+
+	if len(parts) == 2 {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("site"), parts[0])...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+		return
+	}
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ---------------------------------------------------------------------------
+// Helper methods
+// ---------------------------------------------------------------------------
+
+func (r *wanResource) modelToAPI(ctx context.Context, m *wanResourceModel) *wanPayload {
+	payload := &wanPayload{
+		Name:             m.Name.ValueString(),
+		WANType:          m.Type.ValueString(),
+		WANDNSPreference: "auto",
+	}
+
+	if !m.VLANId.IsNull() && !m.VLANId.IsUnknown() {
+		payload.WANVLANEnabled = true
+		payload.WANVLAN = m.VLANId.ValueInt64Pointer()
+	}
+
+	switch payload.WANType {
+	case "static":
+		if prefix, err := netip.ParsePrefix(m.IPAddress.ValueString()); err == nil {
+			payload.WANIP = prefix.Addr().String()
+			payload.WANNetmask = net.IP(net.CIDRMask(prefix.Bits(), 32)).String()
+		}
+		payload.WANGateway = m.Gateway.ValueString()
+	case "pppoe":
+		payload.WANUsername = m.PPPoEUsername.ValueString()
+		payload.WANPassword = m.PPPoEPassword.ValueString()
+	}
+
+	if !m.DNSServers.IsNull() && !m.DNSServers.IsUnknown() {
+		var servers []string
+		m.DNSServers.ElementsAs(ctx, &servers, false)
+		payload.WANDNSPreference = "manual"
+		if len(servers) > 0 {
+			payload.WANDNS1 = servers[0]
+		}
+		if len(servers) > 1 {
+			payload.WANDNS2 = servers[1]
+		}
+	}
+
+	if m.SmartQueues != nil {
+		payload.WANSmartQEnabled = true
+		payload.WANSmartQDownRate = m.SmartQueues.DownloadKbps.ValueInt64Pointer()
+		payload.WANSmartQUpRate = m.SmartQueues.UploadKbps.ValueInt64Pointer()
+	}
+
+	return payload
+}
+
+func (r *wanResource) apiToModel(ctx context.Context, n *unifi.Network, m *wanResourceModel, site string) {
+	m.ID = types.StringValue(n.ID)
+	m.Site = types.StringValue(site)
+	m.NetworkGroup = types.StringPointerValue(n.WANNetworkGroup)
+	m.Name = types.StringPointerValue(n.Name)
+	m.Type = types.StringPointerValue(n.WANType)
+
+	if n.WANVLANEnabled && n.WANVLAN != nil {
+		m.VLANId = types.Int64Value(*n.WANVLAN)
+	} else {
+		m.VLANId = types.Int64Null()
+	}
+
+	// Static and PPPoE fields are kept by the controller after switching to
+	// another type, so they are only read for the current one.
+	m.IPAddress = types.StringNull()
+	m.Gateway = types.StringNull()
+	m.PPPoEUsername = types.StringNull()
+	switch m.Type.ValueString() {
+	case "static":
+		if n.WANIP != nil && *n.WANIP != "" && n.WANNetmask != nil {
+			ones, _ := net.IPMask(net.ParseIP(*n.WANNetmask).To4()).Size()
+			m.IPAddress = types.StringValue(fmt.Sprintf("%s/%d", *n.WANIP, ones))
+		}
+		if n.WANGateway != nil {
+			m.Gateway = stringValueOrNull(*n.WANGateway)
+		}
+	case "pppoe":
+		m.PPPoEUsername = stringValueOrNull(n.WANUsername)
+	}
+
+	// The password is only returned to admins with permission to see it;
+	// otherwise keep the value from the plan or state.
+	if n.XWANPassword != "" {
+		m.PPPoEPassword = types.StringValue(n.XWANPassword)
+	} else if m.PPPoEPassword.IsUnknown() {
+		m.PPPoEPassword = types.StringNull()
+	}
+
+	m.DNSServers = types.ListNull(types.StringType)
+	if n.WANDNSPreference != nil && *n.WANDNSPreference == "manual" {
+		var servers []string
+		for _, dns := range []*string{n.WANDNS1, n.WANDNS2} {
+			if dns != nil && *dns != "" {
+				servers = append(servers, *dns)
+			}
+		}
+		if len(servers) > 0 {
+			m.DNSServers, _ = types.ListValueFrom(ctx, types.StringType, servers)
+		}
+	}
+
+	if n.WANSmartQEnabled {
+		m.SmartQueues = &wanSmartQueuesModel{
+			DownloadKbps: types.Int64PointerValue(n.WANSmartQDownRate),
+			UploadKbps:   types.Int64PointerValue(n.WANSmartQUpRate),
+		}
+	} else {
+		m.SmartQueues = nil
+	}
+}
+
+// wanTypeFieldsValidator ensures the static and PPPoE attributes are set
+// exactly when type calls for them.
+type wanTypeFieldsValidator struct{}
+
+func (v wanTypeFieldsValidator) Description(_ context.Context) string {
+	return "ip_address and gateway are required for static WANs and pppoe_username for PPPoE WANs; " +
+		"none of them may be set for other types."
+}
+
+func (v wanTypeFieldsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v wanTypeFieldsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config wanResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.Type.IsUnknown() || config.Type.IsNull() {
+		return
+	}
+	wanType := config.Type.ValueString()
+
+	fields := []struct {
+		name     string
+		value    types.String
+		forType  string
+		required bool
+	}{
+		{"ip_address", config.IPAddress, "static", true},
+		{"gateway", config.Gateway, "static", true},
+		{"pppoe_username", config.PPPoEUsername, "pppoe", true},
+		{"pppoe_password", config.PPPoEPassword, "pppoe", false},
+	}
+	for _, f := range fields {
+		switch {
+		case wanType == f.forType && f.required && f.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Missing Required Attribute",
+				fmt.Sprintf("%s is required when type is %q.", f.name, f.forType),
+			)
+		case wanType != f.forType && !f.value.IsNull():
+			resp.Diagnostics.AddAttributeError(
+				path.Root(f.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("%s can only be set when type is %q.", f.name, f.forType),
+			)
+		}
+	}
+}
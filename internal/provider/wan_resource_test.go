@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests
+// ---------------------------------------------------------------------------
+
+func baseWANModel(wanType string) wanResourceModel {
+	return wanResourceModel{
+		NetworkGroup:  types.StringValue("WAN"),
+		Name:          types.StringValue("Internet 1"),
+		Type:          types.StringValue(wanType),
+		VLANId:        types.Int64Null(),
+		IPAddress:     types.StringNull(),
+		Gateway:       types.StringNull(),
+		PPPoEUsername: types.StringNull(),
+		PPPoEPassword: types.StringNull(),
+		DNSServers:    types.ListNull(types.StringType),
+	}
+}
+
+func TestWANModelToAPI(t *testing.T) {
+	r := &wanResource{}
+	ctx := context.Background()
+
+	t.Run("dhcp defaults", func(t *testing.T) {
+		m := baseWANModel("dhcp")
+		payload := r.modelToAPI(ctx, &m)
+
+		assert.Equal(t, "Internet 1", payload.Name)
+		assert.Equal(t, "dhcp", payload.WANType)
+		assert.False(t, payload.WANVLANEnabled)
+		assert.Nil(t, payload.WANVLAN)
+		assert.Equal(t, "auto", payload.WANDNSPreference)
+		assert.Empty(t, payload.WANDNS1)
+		assert.False(t, payload.WANSmartQEnabled)
+		assert.Nil(t, payload.WANSmartQDownRate)
+	})
+
+	t.Run("static", func(t *testing.T) {
+		m := baseWANModel("static")
+		m.IPAddress = types.StringValue("203.0.113.10/29")
+		m.Gateway = types.StringValue("203.0.113.9")
+		payload := r.modelToAPI(ctx, &m)
+
+		assert.Equal(t, "203.0.113.10", payload.WANIP)
+		assert.Equal(t, "255.255.255.248", payload.WANNetmask)
+		assert.Equal(t, "203.0.113.9", payload.WANGateway)
+		assert.Empty(t, payload.WANUsername)
+	})
+
+	t.Run("pppoe with VLAN, DNS and smart queues", func(t *testing.T) {
+		m := baseWANModel("pppoe")
+		m.VLANId = types.Int64Value(35)
+		m.PPPoEUsername = types.StringValue("user@isp")
+		m.PPPoEPassword = types.StringValue("secret")
+		m.DNSServers = types.ListValueMust(types.StringType, toAttrValues([]types.String{
+			types.StringValue("1.1.1.1"), types.StringValue("9.9.9.9"),
+		}))
+		m.SmartQueues = &wanSmartQueuesModel{
+			DownloadKbps: types.Int64Value(500000),
+			UploadKbps:   types.Int64Value(50000),
+		}
+		payload := r.modelToAPI(ctx, &m)
+
+		assert.True(t, payload.WANVLANEnabled)
+		require.NotNil(t, payload.WANVLAN)
+		assert.Equal(t, int64(35), *payload.WANVLAN)
+		assert.Equal(t, "user@isp", payload.WANUsername)
+		assert.Equal(t, "secret", payload.WANPassword)
+		assert.Empty(t, payload.WANIP)
+		assert.Equal(t, "manual", payload.WANDNSPreference)
+		assert.Equal(t, "1.1.1.1", payload.WANDNS1)
+		assert.Equal(t, "9.9.9.9", payload.WANDNS2)
+		assert.True(t, payload.WANSmartQEnabled)
+		assert.Equal(t, int64(500000), *payload.WANSmartQDownRate)
+		assert.Equal(t, int64(50000), *payload.WANSmartQUpRate)
+	})
+
+	t.Run("unknown password is not sent", func(t *testing.T) {
+		m := baseWANModel("pppoe")
+		m.PPPoEUsername = types.StringValue("user@isp")
+		m.PPPoEPassword = types.StringUnknown()
+		payload := r.modelToAPI(ctx, &m)
+
+		assert.Empty(t, payload.WANPassword)
+	})
+}
+
+func TestWANAPIToModel(t *testing.T) {
+	r := &wanResource{}
+	ctx := context.Background()
+
+	wan, name := "WAN", "Internet 1"
+	static, pppoe := "static", "pppoe"
+	ip, netmask, gateway := "203.0.113.10", "255.255.255.248", "203.0.113.9"
+	manual, auto, dns1 := "manual", "auto", "1.1.1.1"
+	vlan, down, up := int64(35), int64(500000), int64(50000)
+
+	t.Run("static with DNS and smart queues", func(t *testing.T) {
+		n := &unifi.Network{
+			ID:                "wan-1",
+			Purpose:           "wan",
+			Name:              &name,
+			WANNetworkGroup:   &wan,
+			WANType:           &static,
+			WANIP:             &ip,
+			WANNetmask:        &netmask,
+			WANGateway:        &gateway,
+			WANUsername:       "leftover",
+			WANDNSPreference:  &manual,
+			WANDNS1:           &dns1,
+			WANSmartQEnabled:  true,
+			WANSmartQDownRate: &down,
+			WANSmartQUpRate:   &up,
+		}
+		var m wanResourceModel
+		r.apiToModel(ctx, n, &m, "default")
+
+		assert.Equal(t, "wan-1", m.ID.ValueString())
+		assert.Equal(t, "default", m.Site.ValueString())
+		assert.Equal(t, "WAN", m.NetworkGroup.ValueString())
+		assert.Equal(t, "Internet 1", m.Name.ValueString())
+		assert.Equal(t, "static", m.Type.ValueString())
+		assert.True(t, m.VLANId.IsNull())
+		assert.Equal(t, "203.0.113.10/29", m.IPAddress.ValueString())
+		assert.Equal(t, "203.0.113.9", m.Gateway.ValueString())
+		assert.True(t, m.PPPoEUsername.IsNull(), "fields of other types are ignored")
+		var servers []string
+		m.DNSServers.ElementsAs(ctx, &servers, false)
+		assert.Equal(t, []string{"1.1.1.1"}, servers)
+		require.NotNil(t, m.SmartQueues)
+		assert.Equal(t, int64(500000), m.SmartQueues.DownloadKbps.ValueInt64())
+		assert.Equal(t, int64(50000), m.SmartQueues.UploadKbps.ValueInt64())
+	})
+
+	t.Run("pppoe with VLAN", func(t *testing.T) {
+		n := &unifi.Network{
+			ID:               "wan-1",
+			WANNetworkGroup:  &wan,
+			WANType:          &pppoe,
+			WANVLANEnabled:   true,
+			WANVLAN:          &vlan,
+			WANIP:            &ip,
+			WANUsername:      "user@isp",
+			WANDNSPreference: &auto,
+			WANDNS1:          &dns1,
+		}
+		m := wanResourceModel{PPPoEPassword: types.StringValue("secret")}
+		r.apiToModel(ctx, n, &m, "default")
+
+		assert.Equal(t, int64(35), m.VLANId.ValueInt64())
+		assert.True(t, m.IPAddress.IsNull())
+		assert.Equal(t, "user@isp", m.PPPoEUsername.ValueString())
+		assert.Equal(t, "secret", m.PPPoEPassword.ValueString(), "password not returned by the controller is kept")
+		assert.True(t, m.DNSServers.IsNull(), "servers are ignored with auto DNS")
+		assert.Nil(t, m.SmartQueues)
+	})
+
+	t.Run("password returned by the controller", func(t *testing.T) {
+		n := &unifi.Network{WANType: &pppoe, XWANPassword: "changed"}
+		m := wanResourceModel{PPPoEPassword: types.StringUnknown()}
+		r.apiToModel(ctx, n, &m, "default")
+		assert.Equal(t, "changed", m.PPPoEPassword.ValueString())
+
+		m = wanResourceModel{PPPoEPassword: types.StringUnknown()}
+		r.apiToModel(ctx, &unifi.Network{WANType: &pppoe}, &m, "default")
+		assert.True(t, m.PPPoEPassword.IsNull())
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+// WAN acceptance tests only cover plan-time validation: reconfiguring a real
+// uplink would take the test controller offline.
+
+func TestAccWAN_validationStaticRequiresAddress(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wan" "test" {
+  network_group = "WAN2"
+  type          = "static"
+  gateway       = "203.0.113.9"
+}
+`,
+				ExpectError: regexp.MustCompile(`ip_address is required when type is "static"`),
+			},
+		},
+	})
+}
+
+func TestAccWAN_validationPPPoEFieldsOnDHCP(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wan" "test" {
+  network_group  = "WAN2"
+  type           = "dhcp"
+  pppoe_username = "user@isp"
+}
+`,
+				ExpectError: regexp.MustCompile(`pppoe_username can only be set when type is "pppoe"`),
+			},
+		},
+	})
+}
+
+func TestAccWAN_validationNetworkGroup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wan" "test" {
+  network_group = "LAN"
+  type          = "dhcp"
+}
+`,
+				ExpectError: regexp.MustCompile(`must be WAN, WAN2, ..., WAN9`),
+			},
+		},
+	})
+}
@@ -3,9 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,13 +21,16 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
 
 var (
-	_ resource.Resource                = &wlanResource{}
-	_ resource.ResourceWithImportState = &wlanResource{}
+	_ resource.Resource                     = &wlanResource{}
+	_ resource.ResourceWithImportState      = &wlanResource{}
+	_ resource.ResourceWithModifyPlan       = &wlanResource{}
+	_ resource.ResourceWithConfigValidators = &wlanResource{}
 )
 
 func NewWLANResource() resource.Resource {
@@ -28,7 +38,7 @@ func NewWLANResource() resource.Resource {
 }
 
 type wlanResource struct {
-	client *Client
+	client ClientAPI
 }
 
 type wlanResourceModel struct {
@@ -37,8 +47,17 @@ type wlanResourceModel struct {
 	Name           types.String `tfsdk:"name"`
 	Enabled        types.Bool   `tfsdk:"enabled"`
 	Passphrase     types.String `tfsdk:"passphrase"`
+	// PassphraseWO is write-only: it is read from config and always null in
+	// plan and state. PassphraseWOVersion is stored and triggers rotation.
+	PassphraseWO        types.String `tfsdk:"passphrase_wo"`
+	PassphraseWOVersion types.Int64  `tfsdk:"passphrase_wo_version"`
 	NetworkID      types.String `tfsdk:"network_id"`
+	UserGroupID    types.String `tfsdk:"user_group_id"`
+	APGroupIDs     types.Set    `tfsdk:"ap_group_ids"`
 	WifiBand       types.String `tfsdk:"wifi_band"`
+	Enabled2G      types.Bool   `tfsdk:"enabled_2g"`
+	Enabled5G      types.Bool   `tfsdk:"enabled_5g"`
+	Enabled6G      types.Bool   `tfsdk:"enabled_6g"`
 	Security       types.String `tfsdk:"security"`
 	HideSSID       types.Bool   `tfsdk:"hide_ssid"`
 	WPAMode        types.String `tfsdk:"wpa_mode"`
@@ -46,6 +65,20 @@ type wlanResourceModel struct {
 	WPA3Transition          types.Bool   `tfsdk:"wpa3_transition"`
 	Application             types.String `tfsdk:"application"`
 	OptimizeIoTConnectivity types.Bool   `tfsdk:"optimize_iot_connectivity"`
+
+	NetworkPool *wlanNetworkPoolModel `tfsdk:"network_pool"`
+	Schedule    []wlanScheduleModel   `tfsdk:"schedule"`
+}
+
+// wlanScheduleModel is one window during which the WLAN broadcasts.
+type wlanScheduleModel struct {
+	DaysOfWeek      types.Set    `tfsdk:"days_of_week"`
+	StartTime       types.String `tfsdk:"start_time"`
+	DurationMinutes types.Int64  `tfsdk:"duration_minutes"`
+}
+
+type wlanNetworkPoolModel struct {
+	NetworkIDs types.List `tfsdk:"network_ids"`
 }
 
 func (r *wlanResource) Metadata(
@@ -99,36 +132,176 @@ func (r *wlanResource) Schema(
 			},
 
 			"passphrase": schema.StringAttribute{
-				MarkdownDescription: "The WPA passphrase for the WLAN. Must be 8-255 characters. Required when security is `wpapsk`.",
+				MarkdownDescription: "The WPA passphrase for the WLAN. Must be 8-255 characters. This or `passphrase_wo` is required when `security` is `wpapsk` or `wpa3`.",
 				Optional:            true,
 				Sensitive:           true,
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(8, 255),
+					stringvalidator.ConflictsWith(path.MatchRoot("passphrase_wo")),
+				},
+			},
+
+			"passphrase_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only alternative to `passphrase` (requires Terraform 1.11 or later). The value is " +
+					"sent to the controller but never stored in plan or state, so it can come from an ephemeral source such as " +
+					"Vault. Because Terraform cannot detect changes to it, the passphrase is only sent on create and when " +
+					"`passphrase_wo_version` changes. Must be 8-255 characters.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(8, 255),
+					stringvalidator.AlsoRequires(path.MatchRoot("passphrase_wo_version")),
+				},
+			},
+
+			"passphrase_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `passphrase_wo`. Increment it to rotate the passphrase; any change causes " +
+					"the current `passphrase_wo` value to be sent to the controller.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("passphrase_wo")),
 				},
 			},
 
 			"network_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the network to associate with this WLAN.",
-				Required:            true,
+				MarkdownDescription: "The ID of the network to associate with this WLAN. A `hotspot` WLAN needs a network " +
+					"the gateway routes (not `vlan-only`) to serve its captive portal. Exactly one of `network_id` or " +
+					"`network_pool` must be set; with a pool, this is the pool's first network.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("network_pool")),
+				},
+			},
+
+			"user_group_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the user group (`terrifi_user_group`) whose bandwidth limits apply to " +
+					"this WLAN's clients. Defaults to the site's default user group. Removing it leaves the WLAN in its " +
+					"current group.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			"ap_group_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the AP groups (`terrifi_ap_group`) whose access points broadcast this " +
+					"WLAN. When omitted, every access point on the site broadcasts it.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+
+			"network_pool": schema.SingleNestedAttribute{
+				MarkdownDescription: "Spread this WLAN's clients across several networks (a VLAN pool) instead of a " +
+					"single `network_id`. Each client is assigned a network by hashing its MAC address, so it lands " +
+					"on the same VLAN every time it connects. Useful for high-density deployments where one subnet " +
+					"would run out of addresses or carry too much broadcast traffic.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"network_ids": schema.ListAttribute{
+						MarkdownDescription: "IDs of the networks in the pool. At least two are required.",
+						ElementType:         types.StringType,
+						Required:            true,
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(2),
+							listvalidator.UniqueValues(),
+						},
+					},
+				},
+			},
+
+			"schedule": schema.ListNestedAttribute{
+				MarkdownDescription: "Windows during which the WLAN broadcasts. Outside every window the SSID is off, " +
+					"while `enabled` stays `true`. Omit to broadcast at all times. Controllers that don't support " +
+					"WLAN schedules fail the apply with an error rather than ignoring the schedule.",
+				Optional: true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"days_of_week": schema.SetAttribute{
+							MarkdownDescription: "Days the window starts on: `sun`, `mon`, `tue`, `wed`, `thu`, `fri`, `sat`.",
+							ElementType:         types.StringType,
+							Required:            true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(
+									stringvalidator.OneOf("sun", "mon", "tue", "wed", "thu", "fri", "sat"),
+								),
+							},
+						},
+						"start_time": schema.StringAttribute{
+							MarkdownDescription: "Time of day the window starts, as `HH:MM` in the controller's time zone.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(wlanScheduleTimeRegexp, "must be a time of day in HH:MM format"),
+							},
+						},
+						"duration_minutes": schema.Int64Attribute{
+							MarkdownDescription: "Length of the window in minutes. At most one week (`10080`).",
+							Required:            true,
+							Validators: []validator.Int64{
+								int64validator.Between(1, 10080),
+							},
+						},
+					},
+				},
 			},
 
 			"wifi_band": schema.StringAttribute{
-				MarkdownDescription: "The WiFi band for this WLAN. Must be `2g`, `5g`, or `both`. Default: `both`.",
-				Optional:            true,
-				Computed:            true,
-				Default:             stringdefault.StaticString("both"),
+				MarkdownDescription: "The WiFi band for this WLAN. Must be `2g`, `5g`, or `both`. Default: `both`. " +
+					"Conflicts with `enabled_2g`, `enabled_5g` and `enabled_6g`; when those are used, this reports " +
+					"the closest value (`5g` for a WLAN without 2.4 GHz).",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("both"),
 				Validators: []validator.String{
 					stringvalidator.OneOf("2g", "5g", "both"),
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("enabled_2g"),
+						path.MatchRoot("enabled_5g"),
+						path.MatchRoot("enabled_6g"),
+					),
 				},
 			},
 
-			"security": schema.StringAttribute{
-				MarkdownDescription: "The security protocol for this WLAN. Must be `open` or `wpapsk`. Default: `wpapsk`.",
+			"enabled_2g": schema.BoolAttribute{
+				MarkdownDescription: "Whether the WLAN broadcasts on 2.4 GHz. Setting any of `enabled_2g`, " +
+					"`enabled_5g` or `enabled_6g` selects the bands individually instead of through `wifi_band`; " +
+					"unset flags then default to `true` for 2.4 and 5 GHz and `false` for 6 GHz. Otherwise this " +
+					"follows `wifi_band`.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"enabled_5g": schema.BoolAttribute{
+				MarkdownDescription: "Whether the WLAN broadcasts on 5 GHz. See `enabled_2g`.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("wpapsk"),
+			},
+
+			"enabled_6g": schema.BoolAttribute{
+				MarkdownDescription: "Whether the WLAN broadcasts on 6 GHz. Requires access points with 6 GHz radios " +
+					"and WPA3. When no band flag is set, this keeps the controller's current value. See `enabled_2g`.",
+				Optional: true,
+				Computed: true,
+			},
+
+			"security": schema.StringAttribute{
+				MarkdownDescription: "The security protocol for this WLAN. Must be `open`, `owe` (Enhanced Open, " +
+					"encrypted without a passphrase), `wpapsk`, or `wpa3` (WPA3 only). `owe` and `wpa3` require " +
+					"`wpa3_support`. Default: `wpapsk`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("wpapsk"),
 				Validators: []validator.String{
-					stringvalidator.OneOf("open", "wpapsk"),
+					stringvalidator.OneOf("open", "owe", "wpapsk", "wpa3"),
 				},
 			},
 
@@ -186,6 +359,15 @@ func (r *wlanResource) Schema(
 	}
 }
 
+// ConfigValidators returns validators that need to look at more than one
+// attribute.
+func (r *wlanResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		wlanPassphraseRequiredValidator{},
+		wlanWPA3SecurityValidator{},
+	}
+}
+
 func (r *wlanResource) Configure(
 	_ context.Context,
 	req resource.ConfigureRequest,
@@ -227,27 +409,38 @@ func (r *wlanResource) Create(
 		return
 	}
 
-	userGroupID, err := r.lookupDefaultUserGroup(ctx, site)
-	if err != nil {
-		resp.Diagnostics.AddError("Error Looking Up User Group", err.Error())
-		return
-	}
-
-	apGroupID, err := r.lookupDefaultAPGroup(ctx, site)
-	if err != nil {
-		resp.Diagnostics.AddError("Error Looking Up AP Group", err.Error())
-		return
+	userGroupID := plan.UserGroupID.ValueString()
+	if userGroupID == "" {
+		var err error
+		userGroupID, err = r.lookupDefaultUserGroup(ctx, site)
+		if err != nil {
+			resp.Diagnostics.AddError("Error Looking Up User Group", err.Error())
+			return
+		}
 	}
 
 	// Save passphrase before API call — the API never returns x_passphrase,
 	// so we must restore it from the plan after apiToModel.
 	plannedPassphrase := plan.Passphrase
 
+	passphraseWO, diags := r.writeOnlyPassphrase(ctx, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	wlan := r.modelToAPI(&plan)
+	if passphraseWO != "" {
+		wlan.XPassphrase = passphraseWO
+	}
 	wlan.WLANGroupID = wlanGroupID
 	wlan.UserGroupID = userGroupID
-	wlan.ApGroupIDs = []string{apGroupID}
-	wlan.ApGroupMode = "all"
+	if plan.APGroupIDs.IsNull() {
+		if err := r.broadcastOnAllAPs(ctx, site, wlan); err != nil {
+			resp.Diagnostics.AddError("Error Looking Up AP Group", err.Error())
+			return
+		}
+	}
 
 	created, err := r.client.CreateWLAN(ctx, site, wlan)
 	if err != nil {
@@ -255,9 +448,22 @@ func (r *wlanResource) Create(
 		return
 	}
 
+	if plan.NetworkPool != nil {
+		if err := r.client.SetWLANNetworkPool(ctx, site, created.ID, r.networkPoolToAPI(ctx, plan.NetworkPool)); err != nil {
+			resp.Diagnostics.AddError("Error Setting WLAN Network Pool", err.Error())
+			return
+		}
+	}
+
+	// Save the WLAN to state before checking the schedule so a failed check
+	// doesn't orphan it on the controller.
+	scheduled := len(plan.Schedule) > 0
 	r.apiToModel(created, &plan, site)
 	plan.Passphrase = plannedPassphrase
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if scheduled {
+		checkWLANScheduleSupported(&resp.Diagnostics, created)
+	}
 }
 
 func (r *wlanResource) Read(
@@ -286,7 +492,17 @@ func (r *wlanResource) Read(
 		return
 	}
 
+	pool, err := r.client.GetWLANNetworkPool(ctx, site, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading WLAN Network Pool",
+			fmt.Sprintf("Could not read network pool of WLAN %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
 	r.apiToModel(wlan, &state, site)
+	state.NetworkPool = networkPoolFromAPI(pool)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
@@ -306,21 +522,54 @@ func (r *wlanResource) Update(
 	// so we must restore it from the plan after apiToModel.
 	plannedPassphrase := plan.Passphrase
 
-	r.applyPlanToState(&plan, &state)
+	// The write-only passphrase is only sent when its version changes. When
+	// it is omitted the controller keeps the current passphrase.
+	var passphraseWO string
+	if !plan.PassphraseWOVersion.Equal(state.PassphraseWOVersion) {
+		var diags diag.Diagnostics
+		passphraseWO, diags = r.writeOnlyPassphrase(ctx, req.Config)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	site := r.client.SiteOrDefault(state.Site)
 
-	// Read the existing WLAN to preserve fields we don't manage (like wlangroup_id).
+	// Read the existing WLAN to preserve fields we don't manage (like
+	// wlangroup_id), and to refuse to overwrite edits made on the controller
+	// since the plan was made.
 	existing, err := r.client.GetWLAN(ctx, site, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Reading WLAN for Update", err.Error())
 		return
 	}
+	verify := state
+	r.apiToModel(existing, &verify, site)
+	if !checkConcurrentChanges(&resp.Diagnostics, "WLAN", state.ID.ValueString(), &state, &verify) {
+		return
+	}
+
+	scoped := !state.APGroupIDs.IsNull()
+	r.applyPlanToState(&plan, &state)
 
 	wlan := r.modelToAPI(&state)
+	if passphraseWO != "" {
+		wlan.XPassphrase = passphraseWO
+	}
 	wlan.ID = state.ID.ValueString()
 	wlan.WLANGroupID = existing.WLANGroupID
-	wlan.UserGroupID = existing.UserGroupID
+	// Removing ap_group_ids goes back to broadcasting on every AP. If it was
+	// never set, the AP fields are omitted and the controller keeps them.
+	if scoped && state.APGroupIDs.IsNull() {
+		if err := r.broadcastOnAllAPs(ctx, site, wlan); err != nil {
+			resp.Diagnostics.AddError("Error Looking Up AP Group", err.Error())
+			return
+		}
+	}
+	if wlan.UserGroupID == "" {
+		wlan.UserGroupID = existing.UserGroupID
+	}
 
 	updated, err := r.client.UpdateWLAN(ctx, site, wlan)
 	if err != nil {
@@ -328,9 +577,20 @@ func (r *wlanResource) Update(
 		return
 	}
 
+	if plan.NetworkPool != nil || state.NetworkPool != nil {
+		if err := r.client.SetWLANNetworkPool(ctx, site, state.ID.ValueString(), r.networkPoolToAPI(ctx, plan.NetworkPool)); err != nil {
+			resp.Diagnostics.AddError("Error Setting WLAN Network Pool", err.Error())
+			return
+		}
+	}
+	state.NetworkPool = plan.NetworkPool
+
 	r.apiToModel(updated, &state, site)
 	state.Passphrase = plannedPassphrase
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if len(plan.Schedule) > 0 {
+		checkWLANScheduleSupported(&resp.Diagnostics, updated)
+	}
 }
 
 func (r *wlanResource) Delete(
@@ -352,6 +612,127 @@ func (r *wlanResource) Delete(
 	}
 }
 
+// ModifyPlan keeps wifi_band and the per-band flags in step, and checks that
+// the WLAN's network can support it. A hotspot WLAN served on a vlan-only
+// network plans fine but fails on apply (or silently never shows its portal),
+// so the network is looked up at plan time.
+func (r *wlanResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// During destroy the plan is null — nothing to check.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan wlanResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.planBands(ctx, req, resp, &plan)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client == nil || plan.Application.ValueString() != "hotspot" {
+		return
+	}
+
+	// A pooled WLAN is checked against every network in the pool. Networks
+	// that may not exist yet (unknown IDs) are checked on a later plan.
+	attrPath := path.Root("network_id")
+	var networkIDs []types.String
+	if plan.NetworkPool != nil {
+		attrPath = path.Root("network_pool").AtName("network_ids")
+		for _, v := range plan.NetworkPool.NetworkIDs.Elements() {
+			if id, ok := v.(types.String); ok {
+				networkIDs = append(networkIDs, id)
+			}
+		}
+	} else {
+		networkIDs = append(networkIDs, plan.NetworkID)
+	}
+
+	site := r.client.SiteOrDefault(plan.Site)
+	for _, id := range networkIDs {
+		if id.IsNull() || id.IsUnknown() {
+			continue
+		}
+		network, err := r.client.GetNetwork(ctx, site, id.ValueString())
+		if err != nil {
+			// Leave missing networks and API errors to Create/Update.
+			continue
+		}
+
+		networkName := network.ID
+		if network.Name != nil {
+			networkName = *network.Name
+		}
+		if msg := wlanNetworkIncompatibility(plan.Name.ValueString(), plan.Application.ValueString(), networkName, network.Purpose); msg != "" {
+			resp.Diagnostics.AddAttributeError(attrPath, "Network Cannot Serve WLAN", msg)
+		}
+	}
+}
+
+// planBands fills in whichever of wifi_band and the per-band flags the
+// configuration leaves to the provider.
+func (r *wlanResource) planBands(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+	plan *wlanResourceModel,
+) {
+	var config wlanResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Enabled2G.IsNull() && config.Enabled5G.IsNull() && config.Enabled6G.IsNull() {
+		if plan.WifiBand.IsUnknown() {
+			return
+		}
+		band := plan.WifiBand.ValueString()
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_2g"), band != "5g")...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_5g"), band != "2g")...)
+		// wifi_band says nothing about 6 GHz, so keep what the controller has.
+		if !req.State.Raw.IsNull() {
+			var state wlanResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if !state.Enabled6G.IsNull() {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_6g"), state.Enabled6G)...)
+			}
+		}
+		return
+	}
+
+	enabled2G := valueOr(config.Enabled2G, types.BoolValue(true))
+	enabled5G := valueOr(config.Enabled5G, types.BoolValue(true))
+	enabled6G := valueOr(config.Enabled6G, types.BoolValue(false))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_2g"), enabled2G)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_5g"), enabled5G)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("enabled_6g"), enabled6G)...)
+
+	if enabled2G.IsUnknown() || enabled5G.IsUnknown() || enabled6G.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("wifi_band"), types.StringUnknown())...)
+		return
+	}
+	if !enabled2G.ValueBool() && !enabled5G.ValueBool() && !enabled6G.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enabled_2g"),
+			"No WiFi Band Enabled",
+			"At least one of enabled_2g, enabled_5g and enabled_6g must be true. To stop broadcasting the "+
+				"SSID, set enabled = false instead.",
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("wifi_band"),
+		wlanLegacyBand(enabled2G.ValueBool(), enabled5G.ValueBool()))...)
+}
+
 func (r *wlanResource) ImportState(
 	ctx context.Context,
 	req resource.ImportStateRequest,
@@ -368,10 +749,183 @@ func (r *wlanResource) ImportState(
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
+// ---------------------------------------------------------------------------
+// Config validators
+// ---------------------------------------------------------------------------
+
+// wlanPassphraseRequiredValidator requires passphrase or passphrase_wo when
+// security is wpapsk, the default, or wpa3. Without one the controller
+// rejects the WLAN with an error that doesn't mention the passphrase.
+type wlanPassphraseRequiredValidator struct{}
+
+func (v wlanPassphraseRequiredValidator) Description(_ context.Context) string {
+	return "passphrase or passphrase_wo must be set when security is wpapsk or wpa3."
+}
+
+func (v wlanPassphraseRequiredValidator) MarkdownDescription(_ context.Context) string {
+	return "`passphrase` or `passphrase_wo` must be set when `security` is `wpapsk` or `wpa3`."
+}
+
+func (v wlanPassphraseRequiredValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var security, passphrase, passphraseWO types.String
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("security"), &security)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passphrase"), &passphrase)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("passphrase_wo"), &passphraseWO)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Null security means the wpapsk default.
+	if security.IsUnknown() || (!security.IsNull() && security.ValueString() != "wpapsk" && security.ValueString() != "wpa3") {
+		return
+	}
+	if !passphrase.IsNull() || !passphraseWO.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("passphrase"),
+		"Missing WLAN Passphrase",
+		"A WLAN with security \"wpapsk\" (the default) or \"wpa3\" needs a passphrase. Set passphrase or "+
+			"passphrase_wo, or set security = \"open\" or \"owe\" for a WLAN without one.",
+	)
+}
+
+// wlanWPA3SecurityValidator requires wpa3_support for the owe and wpa3
+// security modes, and rejects wpa3_transition with wpa3, which would let
+// WPA2 clients join a WLAN meant to be WPA3 only.
+type wlanWPA3SecurityValidator struct{}
+
+func (v wlanWPA3SecurityValidator) Description(_ context.Context) string {
+	return "wpa3_support must be true when security is owe or wpa3, and wpa3_transition must not be true when " +
+		"security is wpa3."
+}
+
+func (v wlanWPA3SecurityValidator) MarkdownDescription(_ context.Context) string {
+	return "`wpa3_support` must be `true` when `security` is `owe` or `wpa3`, and `wpa3_transition` must not be " +
+		"`true` when `security` is `wpa3`."
+}
+
+func (v wlanWPA3SecurityValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var security types.String
+	var wpa3Support, wpa3Transition types.Bool
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("security"), &security)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wpa3_support"), &wpa3Support)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wpa3_transition"), &wpa3Transition)...)
+
+	if resp.Diagnostics.HasError() || security.IsUnknown() {
+		return
+	}
+	mode := security.ValueString()
+	if mode != "owe" && mode != "wpa3" {
+		return
+	}
+
+	// Null wpa3_support means the false default.
+	if !wpa3Support.IsUnknown() && !wpa3Support.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wpa3_support"),
+			"WPA3 Support Required",
+			fmt.Sprintf("A WLAN with security %q uses WPA3, so wpa3_support must be true.", mode),
+		)
+	}
+	if mode == "wpa3" && wpa3Transition.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wpa3_transition"),
+			"Invalid Attribute Combination",
+			"wpa3_transition lets WPA2 clients join, so it can't be true when security is \"wpa3\". For WPA2/WPA3 "+
+				"mixed mode, set security = \"wpapsk\" with wpa3_support and wpa3_transition.",
+		)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Helper methods
 // ---------------------------------------------------------------------------
 
+// wlanNetworkIncompatibility returns an actionable error message when a WLAN
+// with the given application cannot be served on a network with the given
+// purpose, or "" when the combination is fine. Hotspot WLANs need the gateway
+// to host the captive portal, which vlan-only networks (no gateway interface)
+// don't have.
+func wlanNetworkIncompatibility(wlanName, application, networkName, purpose string) string {
+	if application != "hotspot" || purpose != "vlan-only" {
+		return ""
+	}
+	return fmt.Sprintf(
+		"WLAN %q uses application \"hotspot\", whose captive portal is served by the gateway, but network %q is "+
+			"vlan-only and has no gateway. Use a corporate or guest network with a subnet, or set the WLAN's "+
+			"application to \"standard\".",
+		wlanName, networkName,
+	)
+}
+
+// wlanScheduleTimeRegexp matches a 24-hour HH:MM time of day.
+var wlanScheduleTimeRegexp = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// checkWLANScheduleSupported reports an error when the controller accepted a
+// WLAN with a schedule but did not store it. Older controllers silently drop
+// schedule_with_duration, which would leave the SSID broadcasting around the
+// clock while the plan says otherwise.
+func checkWLANScheduleSupported(diags *diag.Diagnostics, wlan *unifi.WLAN) {
+	if wlan.ScheduleEnabled && len(wlan.ScheduleWithDuration) > 0 {
+		return
+	}
+	diags.AddAttributeError(
+		path.Root("schedule"),
+		"WLAN Schedules Not Supported",
+		fmt.Sprintf("The controller did not store the schedule of WLAN %q, so it broadcasts at all times. This "+
+			"controller version does not support WLAN schedules; upgrade the UniFi Network application or remove "+
+			"the schedule attribute.", wlan.Name),
+	)
+}
+
+// scheduleToAPI converts the planned schedule windows to the controller's
+// schedule_with_duration entries.
+func (r *wlanResource) scheduleToAPI(schedule []wlanScheduleModel) []unifi.WLANScheduleWithDuration {
+	entries := []unifi.WLANScheduleWithDuration{}
+	for _, s := range schedule {
+		entry := unifi.WLANScheduleWithDuration{
+			DurationMinutes: s.DurationMinutes.ValueInt64Pointer(),
+		}
+		entry.StartDaysOfWeek = sortedSetStrings(s.DaysOfWeek)
+		// The validator guarantees HH:MM, so the parse can't fail.
+		var hour, minute int64
+		fmt.Sscanf(s.StartTime.ValueString(), "%d:%d", &hour, &minute)
+		entry.StartHour = &hour
+		entry.StartMinute = &minute
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// scheduleFromAPI converts the controller's schedule entries to the model,
+// returning nil when the WLAN has no schedule.
+func scheduleFromAPI(wlan *unifi.WLAN) []wlanScheduleModel {
+	if !wlan.ScheduleEnabled || len(wlan.ScheduleWithDuration) == 0 {
+		return nil
+	}
+	schedule := make([]wlanScheduleModel, 0, len(wlan.ScheduleWithDuration))
+	for _, e := range wlan.ScheduleWithDuration {
+		var hour, minute int64
+		if e.StartHour != nil {
+			hour = *e.StartHour
+		}
+		if e.StartMinute != nil {
+			minute = *e.StartMinute
+		}
+		schedule = append(schedule, wlanScheduleModel{
+			DaysOfWeek:      stringSetValue(e.StartDaysOfWeek, strings.ToLower),
+			StartTime:       types.StringValue(fmt.Sprintf("%02d:%02d", hour, minute)),
+			DurationMinutes: types.Int64PointerValue(e.DurationMinutes),
+		})
+	}
+	return schedule
+}
+
 func (r *wlanResource) lookupDefaultWLANGroup(ctx context.Context, site string) (string, error) {
 	groups, err := r.client.ListWLANGroup(ctx, site)
 	if err != nil {
@@ -405,6 +959,30 @@ func (r *wlanResource) lookupDefaultAPGroup(ctx context.Context, site string) (s
 	return groups[0].ID, nil
 }
 
+// broadcastOnAllAPs assigns the WLAN to the site's default AP group, which
+// contains every access point.
+func (r *wlanResource) broadcastOnAllAPs(ctx context.Context, site string, wlan *unifi.WLAN) error {
+	apGroupID, err := r.lookupDefaultAPGroup(ctx, site)
+	if err != nil {
+		return err
+	}
+	wlan.ApGroupIDs = []string{apGroupID}
+	wlan.ApGroupMode = "all"
+	return nil
+}
+
+// writeOnlyPassphrase returns the passphrase_wo value from config, or "" when
+// it is not set. Write-only values are only available in config, never in the
+// plan or state.
+func (r *wlanResource) writeOnlyPassphrase(ctx context.Context, config tfsdk.Config) (string, diag.Diagnostics) {
+	var passphrase types.String
+	diags := config.GetAttribute(ctx, path.Root("passphrase_wo"), &passphrase)
+	if diags.HasError() || passphrase.IsNull() || passphrase.IsUnknown() {
+		return "", diags
+	}
+	return passphrase.ValueString(), diags
+}
+
 func (r *wlanResource) applyPlanToState(plan, state *wlanResourceModel) {
 	if !plan.Name.IsNull() && !plan.Name.IsUnknown() {
 		state.Name = plan.Name
@@ -417,12 +995,29 @@ func (r *wlanResource) applyPlanToState(plan, state *wlanResourceModel) {
 	if !plan.Passphrase.IsUnknown() {
 		state.Passphrase = plan.Passphrase
 	}
+	// Like passphrase, the version has no computed value, so a null plan
+	// value means the user stopped using passphrase_wo.
+	state.PassphraseWOVersion = plan.PassphraseWOVersion
 	if !plan.NetworkID.IsNull() && !plan.NetworkID.IsUnknown() {
 		state.NetworkID = plan.NetworkID
 	}
+	if !plan.UserGroupID.IsNull() && !plan.UserGroupID.IsUnknown() {
+		state.UserGroupID = plan.UserGroupID
+	}
+	// A null ap_group_ids means every AP broadcasts the WLAN, so always apply it.
+	state.APGroupIDs = plan.APGroupIDs
 	if !plan.WifiBand.IsNull() && !plan.WifiBand.IsUnknown() {
 		state.WifiBand = plan.WifiBand
 	}
+	if !plan.Enabled2G.IsNull() && !plan.Enabled2G.IsUnknown() {
+		state.Enabled2G = plan.Enabled2G
+	}
+	if !plan.Enabled5G.IsNull() && !plan.Enabled5G.IsUnknown() {
+		state.Enabled5G = plan.Enabled5G
+	}
+	if !plan.Enabled6G.IsNull() && !plan.Enabled6G.IsUnknown() {
+		state.Enabled6G = plan.Enabled6G
+	}
 	if !plan.Security.IsNull() && !plan.Security.IsUnknown() {
 		state.Security = plan.Security
 	}
@@ -444,19 +1039,38 @@ func (r *wlanResource) applyPlanToState(plan, state *wlanResourceModel) {
 	if !plan.OptimizeIoTConnectivity.IsNull() && !plan.OptimizeIoTConnectivity.IsUnknown() {
 		state.OptimizeIoTConnectivity = plan.OptimizeIoTConnectivity
 	}
+	// A null schedule means broadcasting at all times, so always apply it.
+	state.Schedule = plan.Schedule
 }
 
 func (r *wlanResource) modelToAPI(m *wlanResourceModel) *unifi.WLAN {
 	wlan := &unifi.WLAN{
 		Name:                 m.Name.ValueString(),
 		NetworkID:            m.NetworkID.ValueString(),
-		ScheduleWithDuration: []unifi.WLANScheduleWithDuration{},
+		UserGroupID:          m.UserGroupID.ValueString(),
+		ScheduleEnabled:      len(m.Schedule) > 0,
+		ScheduleWithDuration: r.scheduleToAPI(m.Schedule),
+	}
+
+	// The controller still requires networkconf_id on a pooled WLAN; use the
+	// pool's first network.
+	if m.NetworkPool != nil {
+		if ids := m.NetworkPool.NetworkIDs.Elements(); len(ids) > 0 {
+			if id, ok := ids[0].(types.String); ok {
+				wlan.NetworkID = id.ValueString()
+			}
+		}
 	}
 
 	if !m.Enabled.IsNull() {
 		wlan.Enabled = m.Enabled.ValueBool()
 	}
 
+	if !m.APGroupIDs.IsNull() && !m.APGroupIDs.IsUnknown() {
+		wlan.ApGroupIDs = sortedSetStrings(m.APGroupIDs)
+		wlan.ApGroupMode = "groups"
+	}
+
 	if !m.Passphrase.IsNull() && !m.Passphrase.IsUnknown() {
 		wlan.XPassphrase = m.Passphrase.ValueString()
 	}
@@ -464,9 +1078,10 @@ func (r *wlanResource) modelToAPI(m *wlanResourceModel) *unifi.WLAN {
 	if !m.WifiBand.IsNull() {
 		wlan.WLANBand = m.WifiBand.ValueString()
 	}
+	wlan.WLANBands = wlanBandsToAPI(m)
 
 	if !m.Security.IsNull() {
-		wlan.Security = m.Security.ValueString()
+		wlan.Security = wlanSecurityToAPI(m.Security.ValueString())
 	}
 
 	if !m.HideSSID.IsNull() {
@@ -508,6 +1123,14 @@ func (r *wlanResource) apiToModel(wlan *unifi.WLAN, m *wlanResourceModel, site s
 	m.Name = types.StringValue(wlan.Name)
 	m.Enabled = types.BoolValue(wlan.Enabled)
 	m.NetworkID = types.StringValue(wlan.NetworkID)
+	m.UserGroupID = types.StringValue(wlan.UserGroupID)
+	// Other modes broadcast on every AP ("all") or on APs picked one by one
+	// in the UI ("devices"), which ap_group_ids doesn't model.
+	if wlan.ApGroupMode == "groups" {
+		m.APGroupIDs = stringSetValue(wlan.ApGroupIDs, nil)
+	} else {
+		m.APGroupIDs = types.SetNull(types.StringType)
+	}
 
 	// Never set passphrase from the API response. The passphrase is managed
 	// exclusively from the Terraform config/plan. Some controller versions return
@@ -520,12 +1143,21 @@ func (r *wlanResource) apiToModel(wlan *unifi.WLAN, m *wlanResourceModel, site s
 		m.WifiBand = types.StringValue("both")
 	}
 
-	if wlan.Security != "" {
-		m.Security = types.StringValue(wlan.Security)
+	// Newer controllers list the bands individually; older ones only have
+	// wlan_band, which can't express 6 GHz.
+	if len(wlan.WLANBands) > 0 {
+		m.Enabled2G = types.BoolValue(slices.Contains(wlan.WLANBands, "2g"))
+		m.Enabled5G = types.BoolValue(slices.Contains(wlan.WLANBands, "5g"))
+		m.Enabled6G = types.BoolValue(slices.Contains(wlan.WLANBands, "6g"))
+		m.WifiBand = types.StringValue(wlanLegacyBand(m.Enabled2G.ValueBool(), m.Enabled5G.ValueBool()))
 	} else {
-		m.Security = types.StringValue("wpapsk")
+		m.Enabled2G = types.BoolValue(m.WifiBand.ValueString() != "5g")
+		m.Enabled5G = types.BoolValue(m.WifiBand.ValueString() != "2g")
+		m.Enabled6G = types.BoolValue(false)
 	}
 
+	m.Security = types.StringValue(wlanSecurityFromAPI(wlan, m.Security.ValueString()))
+
 	m.HideSSID = types.BoolValue(wlan.HideSSID)
 
 	if wlan.WPAMode != "" {
@@ -547,4 +1179,98 @@ func (r *wlanResource) apiToModel(wlan *unifi.WLAN, m *wlanResourceModel, site s
 	}
 
 	m.OptimizeIoTConnectivity = types.BoolValue(wlan.OptimizeIotWifiConnectivity)
+	m.Schedule = scheduleFromAPI(wlan)
+}
+
+// The controller has no security values for Enhanced Open and WPA3 only: it
+// stores them as open and wpapsk with wpa3_support set (and, for WPA3 only,
+// wpa3_transition unset). The validators make wpa3_support follow the mode,
+// so converting to the API only needs the base protocol.
+func wlanSecurityToAPI(security string) string {
+	switch security {
+	case "owe":
+		return "open"
+	case "wpa3":
+		return "wpapsk"
+	}
+	return security
+}
+
+// wlanSecurityFromAPI returns the security mode of a WLAN. A WLAN that is
+// both open (or wpapsk) and uses WPA3 stays open (or wpapsk) when that is the
+// prior value, so that configurations written before owe and wpa3 existed
+// don't show a diff. An empty prior value, as on import, picks the WPA3 mode.
+func wlanSecurityFromAPI(wlan *unifi.WLAN, prior string) string {
+	security := wlan.Security
+	if security == "" {
+		security = "wpapsk"
+	}
+	switch {
+	case security == "open" && wlan.WPA3Support && prior != "open":
+		return "owe"
+	case security == "wpapsk" && wlan.WPA3Support && !wlan.WPA3Transition && prior != "wpapsk":
+		return "wpa3"
+	}
+	return security
+}
+
+// wlanBandsToAPI lists the enabled bands. Flags that are not set (as in
+// plans built without ModifyPlan) follow wifi_band.
+func wlanBandsToAPI(m *wlanResourceModel) []string {
+	band := m.WifiBand.ValueString()
+	enabled := func(flag types.Bool, fallback bool) bool {
+		if flag.IsNull() || flag.IsUnknown() {
+			return fallback
+		}
+		return flag.ValueBool()
+	}
+
+	var bands []string
+	if enabled(m.Enabled2G, band != "5g") {
+		bands = append(bands, "2g")
+	}
+	if enabled(m.Enabled5G, band != "2g") {
+		bands = append(bands, "5g")
+	}
+	if enabled(m.Enabled6G, false) {
+		bands = append(bands, "6g")
+	}
+	return bands
+}
+
+// wlanLegacyBand returns the wlan_band value closest to a set of enabled
+// bands. wlan_band predates 6 GHz, so a WLAN without 2.4 GHz reports 5g.
+func wlanLegacyBand(enabled2G, enabled5G bool) string {
+	switch {
+	case enabled2G && enabled5G:
+		return "both"
+	case enabled2G:
+		return "2g"
+	default:
+		return "5g"
+	}
+}
+
+// networkPoolToAPI converts the planned network pool to its API form. A nil
+// model disables pooling.
+func (r *wlanResource) networkPoolToAPI(ctx context.Context, m *wlanNetworkPoolModel) *wlanNetworkPool {
+	if m == nil {
+		return nil
+	}
+	pool := &wlanNetworkPool{Enabled: true}
+	m.NetworkIDs.ElementsAs(ctx, &pool.NetworkIDs, false)
+	return pool
+}
+
+// networkPoolFromAPI converts the controller's VLAN pool settings to the
+// model, returning nil when pooling is disabled.
+func networkPoolFromAPI(pool *wlanNetworkPool) *wlanNetworkPoolModel {
+	if pool == nil || !pool.Enabled || len(pool.NetworkIDs) == 0 {
+		return nil
+	}
+	ids := make([]attr.Value, len(pool.NetworkIDs))
+	for i, id := range pool.NetworkIDs {
+		ids[i] = types.StringValue(id)
+	}
+	return &wlanNetworkPoolModel{NetworkIDs: types.ListValueMust(types.StringType, ids)}
 }
@@ -1,12 +1,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/stretchr/testify/assert"
 	"github.com/ubiquiti-community/go-unifi/unifi"
 )
@@ -24,6 +30,7 @@ func TestWLANModelToAPI(t *testing.T) {
 			Enabled:        types.BoolValue(true),
 			Passphrase:     types.StringValue("supersecret"),
 			NetworkID:      types.StringValue("net123"),
+			UserGroupID:    types.StringValue("ug123"),
 			WifiBand:       types.StringValue("both"),
 			Security:       types.StringValue("wpapsk"),
 			HideSSID:       types.BoolValue(false),
@@ -37,6 +44,7 @@ func TestWLANModelToAPI(t *testing.T) {
 		assert.Equal(t, "My WiFi", wlan.Name)
 		assert.Equal(t, "supersecret", wlan.XPassphrase)
 		assert.Equal(t, "net123", wlan.NetworkID)
+		assert.Equal(t, "ug123", wlan.UserGroupID)
 		assert.Equal(t, "both", wlan.WLANBand)
 		assert.Equal(t, "wpapsk", wlan.Security)
 		assert.False(t, wlan.HideSSID)
@@ -371,6 +379,245 @@ func TestWLANAPIToModel(t *testing.T) {
 	})
 }
 
+func TestWLANBands(t *testing.T) {
+	r := &wlanResource{}
+
+	t.Run("flags unset follow wifi_band", func(t *testing.T) {
+		for band, want := range map[string][]string{
+			"both": {"2g", "5g"},
+			"2g":   {"2g"},
+			"5g":   {"5g"},
+		} {
+			m := &wlanResourceModel{WifiBand: types.StringValue(band)}
+			assert.Equal(t, want, wlanBandsToAPI(m), band)
+		}
+	})
+
+	t.Run("flags override wifi_band", func(t *testing.T) {
+		m := &wlanResourceModel{
+			WifiBand:  types.StringValue("5g"),
+			Enabled2G: types.BoolValue(false),
+			Enabled5G: types.BoolValue(true),
+			Enabled6G: types.BoolValue(true),
+		}
+		wlan := r.modelToAPI(m)
+		assert.Equal(t, []string{"5g", "6g"}, wlan.WLANBands)
+		assert.Equal(t, "5g", wlan.WLANBand)
+	})
+
+	t.Run("wlan_bands from API", func(t *testing.T) {
+		var model wlanResourceModel
+		r.apiToModel(&unifi.WLAN{WLANBand: "both", WLANBands: []string{"2g", "6g"}}, &model, "default")
+
+		assert.True(t, model.Enabled2G.ValueBool())
+		assert.False(t, model.Enabled5G.ValueBool())
+		assert.True(t, model.Enabled6G.ValueBool())
+		assert.Equal(t, "2g", model.WifiBand.ValueString())
+	})
+
+	t.Run("older controller without wlan_bands", func(t *testing.T) {
+		var model wlanResourceModel
+		r.apiToModel(&unifi.WLAN{WLANBand: "5g"}, &model, "default")
+
+		assert.False(t, model.Enabled2G.ValueBool())
+		assert.True(t, model.Enabled5G.ValueBool())
+		assert.False(t, model.Enabled6G.ValueBool())
+		assert.Equal(t, "5g", model.WifiBand.ValueString())
+	})
+
+	t.Run("legacy band", func(t *testing.T) {
+		assert.Equal(t, "both", wlanLegacyBand(true, true))
+		assert.Equal(t, "2g", wlanLegacyBand(true, false))
+		assert.Equal(t, "5g", wlanLegacyBand(false, true))
+		assert.Equal(t, "5g", wlanLegacyBand(false, false))
+	})
+}
+
+func TestWLANSecurity(t *testing.T) {
+	r := &wlanResource{}
+
+	t.Run("to API", func(t *testing.T) {
+		for security, want := range map[string]string{
+			"open":   "open",
+			"owe":    "open",
+			"wpapsk": "wpapsk",
+			"wpa3":   "wpapsk",
+		} {
+			wlan := r.modelToAPI(&wlanResourceModel{
+				Security:    types.StringValue(security),
+				WPA3Support: types.BoolValue(true),
+			})
+			assert.Equal(t, want, wlan.Security, security)
+			assert.True(t, wlan.WPA3Support, security)
+		}
+	})
+
+	t.Run("from API", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			wlan  unifi.WLAN
+			prior string
+			want  string
+		}{
+			{"open", unifi.WLAN{Security: "open"}, "", "open"},
+			{"enhanced open on import", unifi.WLAN{Security: "open", WPA3Support: true}, "", "owe"},
+			{"enhanced open", unifi.WLAN{Security: "open", WPA3Support: true}, "owe", "owe"},
+			{"open with wpa3_support kept", unifi.WLAN{Security: "open", WPA3Support: true}, "open", "open"},
+			{"wpapsk", unifi.WLAN{Security: "wpapsk"}, "", "wpapsk"},
+			{"empty is wpapsk", unifi.WLAN{}, "", "wpapsk"},
+			{"transition is wpapsk", unifi.WLAN{Security: "wpapsk", WPA3Support: true, WPA3Transition: true}, "", "wpapsk"},
+			{"wpa3 only on import", unifi.WLAN{Security: "wpapsk", WPA3Support: true}, "", "wpa3"},
+			{"wpa3 only", unifi.WLAN{Security: "wpapsk", WPA3Support: true}, "wpa3", "wpa3"},
+			{"wpapsk with wpa3_support kept", unifi.WLAN{Security: "wpapsk", WPA3Support: true}, "wpapsk", "wpapsk"},
+			{"wpa3 switched off in UI", unifi.WLAN{Security: "wpapsk"}, "wpa3", "wpapsk"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				model := wlanResourceModel{Security: stringValueOrNull(tt.prior)}
+				r.apiToModel(&tt.wlan, &model, "default")
+				assert.Equal(t, tt.want, model.Security.ValueString())
+			})
+		}
+	})
+}
+
+func TestWLANNetworkIncompatibility(t *testing.T) {
+	assert.Empty(t, wlanNetworkIncompatibility("Guest", "hotspot", "Guest LAN", "corporate"))
+	assert.Empty(t, wlanNetworkIncompatibility("Cameras", "standard", "Cameras", "vlan-only"))
+	assert.Empty(t, wlanNetworkIncompatibility("Things", "iot", "IoT", "vlan-only"))
+
+	msg := wlanNetworkIncompatibility("Guest", "hotspot", "Transit", "vlan-only")
+	assert.Contains(t, msg, `"Guest"`)
+	assert.Contains(t, msg, `"Transit"`)
+	assert.Contains(t, msg, "vlan-only")
+}
+
+func TestWLANNetworkPool(t *testing.T) {
+	r := &wlanResource{}
+	ctx := context.Background()
+
+	pool := &wlanNetworkPoolModel{
+		NetworkIDs: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("net-a"), types.StringValue("net-b"),
+		}),
+	}
+
+	t.Run("modelToAPI uses the first pooled network", func(t *testing.T) {
+		wlan := r.modelToAPI(&wlanResourceModel{
+			Name:        types.StringValue("Stadium"),
+			NetworkID:   types.StringUnknown(),
+			NetworkPool: pool,
+		})
+		assert.Equal(t, "net-a", wlan.NetworkID)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		api := r.networkPoolToAPI(ctx, pool)
+		assert.Equal(t, &wlanNetworkPool{Enabled: true, NetworkIDs: []string{"net-a", "net-b"}}, api)
+		assert.Equal(t, pool, networkPoolFromAPI(api))
+	})
+
+	t.Run("disabled pool", func(t *testing.T) {
+		assert.Nil(t, r.networkPoolToAPI(ctx, nil))
+		assert.Nil(t, networkPoolFromAPI(&wlanNetworkPool{Enabled: false, NetworkIDs: []string{"net-a"}}))
+		assert.Nil(t, networkPoolFromAPI(&wlanNetworkPool{Enabled: true}))
+	})
+}
+
+func TestWLANSchedule(t *testing.T) {
+	r := &wlanResource{}
+
+	schedule := []wlanScheduleModel{{
+		DaysOfWeek: types.SetValueMust(types.StringType, []attr.Value{
+			types.StringValue("mon"), types.StringValue("fri"),
+		}),
+		StartTime:       types.StringValue("07:30"),
+		DurationMinutes: types.Int64Value(600),
+	}}
+
+	t.Run("round trip", func(t *testing.T) {
+		wlan := r.modelToAPI(&wlanResourceModel{Name: types.StringValue("Office"), Schedule: schedule})
+		assert.True(t, wlan.ScheduleEnabled)
+		if assert.Len(t, wlan.ScheduleWithDuration, 1) {
+			e := wlan.ScheduleWithDuration[0]
+			assert.Equal(t, []string{"fri", "mon"}, e.StartDaysOfWeek)
+			assert.Equal(t, int64(7), *e.StartHour)
+			assert.Equal(t, int64(30), *e.StartMinute)
+			assert.Equal(t, int64(600), *e.DurationMinutes)
+		}
+		got := scheduleFromAPI(wlan)
+		if assert.Len(t, got, 1) {
+			assert.True(t, schedule[0].DaysOfWeek.Equal(got[0].DaysOfWeek))
+			assert.Equal(t, schedule[0].StartTime, got[0].StartTime)
+			assert.Equal(t, schedule[0].DurationMinutes, got[0].DurationMinutes)
+		}
+	})
+
+	t.Run("controller reorders and repeats days", func(t *testing.T) {
+		wlan := &unifi.WLAN{
+			ScheduleEnabled: true,
+			ScheduleWithDuration: []unifi.WLANScheduleWithDuration{{
+				StartDaysOfWeek: []string{"fri", "MON", "mon"},
+				DurationMinutes: schedule[0].DurationMinutes.ValueInt64Pointer(),
+			}},
+		}
+		got := scheduleFromAPI(wlan)
+		if assert.Len(t, got, 1) {
+			assert.True(t, schedule[0].DaysOfWeek.Equal(got[0].DaysOfWeek))
+		}
+	})
+
+	t.Run("no schedule", func(t *testing.T) {
+		wlan := r.modelToAPI(&wlanResourceModel{Name: types.StringValue("Office")})
+		assert.False(t, wlan.ScheduleEnabled)
+		assert.NotNil(t, wlan.ScheduleWithDuration)
+		assert.Empty(t, wlan.ScheduleWithDuration)
+		assert.Nil(t, scheduleFromAPI(wlan))
+	})
+
+	t.Run("unsupported controller", func(t *testing.T) {
+		var diags diag.Diagnostics
+		checkWLANScheduleSupported(&diags, r.modelToAPI(&wlanResourceModel{Name: types.StringValue("Office"), Schedule: schedule}))
+		assert.False(t, diags.HasError())
+
+		checkWLANScheduleSupported(&diags, &unifi.WLAN{Name: "Office"})
+		if assert.True(t, diags.HasError()) {
+			assert.Equal(t, "WLAN Schedules Not Supported", diags.Errors()[0].Summary())
+		}
+	})
+}
+
+func TestWLANAPGroups(t *testing.T) {
+	r := &wlanResource{}
+
+	t.Run("ap_group_ids scopes the WLAN to the groups", func(t *testing.T) {
+		wlan := r.modelToAPI(&wlanResourceModel{
+			APGroupIDs: types.SetValueMust(types.StringType, []attr.Value{
+				types.StringValue("apg2"), types.StringValue("apg1"),
+			}),
+		})
+		assert.Equal(t, []string{"apg1", "apg2"}, wlan.ApGroupIDs)
+		assert.Equal(t, "groups", wlan.ApGroupMode)
+	})
+
+	t.Run("AP fields are omitted without ap_group_ids", func(t *testing.T) {
+		wlan := r.modelToAPI(&wlanResourceModel{APGroupIDs: types.SetNull(types.StringType)})
+		assert.Nil(t, wlan.ApGroupIDs)
+		assert.Empty(t, wlan.ApGroupMode)
+	})
+
+	t.Run("only group mode is read back", func(t *testing.T) {
+		var m wlanResourceModel
+		r.apiToModel(&unifi.WLAN{ApGroupMode: "groups", ApGroupIDs: []string{"apg1"}}, &m, "default")
+		assert.Equal(t, []string{"apg1"}, sortedSetStrings(m.APGroupIDs))
+
+		for _, mode := range []string{"all", "devices"} {
+			r.apiToModel(&unifi.WLAN{ApGroupMode: mode, ApGroupIDs: []string{"default-group"}}, &m, "default")
+			assert.True(t, m.APGroupIDs.IsNull(), mode)
+		}
+	})
+}
+
 func TestWLANApplyPlanToState(t *testing.T) {
 	r := &wlanResource{}
 
@@ -436,6 +683,18 @@ func TestWLANApplyPlanToState(t *testing.T) {
 
 		assert.True(t, state.OptimizeIoTConnectivity.ValueBool())
 	})
+
+	t.Run("passphrase_wo_version follows the plan", func(t *testing.T) {
+		state := &wlanResourceModel{
+			PassphraseWOVersion: types.Int64Value(1),
+		}
+
+		r.applyPlanToState(&wlanResourceModel{PassphraseWOVersion: types.Int64Value(2)}, state)
+		assert.Equal(t, int64(2), state.PassphraseWOVersion.ValueInt64())
+
+		r.applyPlanToState(&wlanResourceModel{PassphraseWOVersion: types.Int64Null()}, state)
+		assert.True(t, state.PassphraseWOVersion.IsNull())
+	})
 }
 
 // ---------------------------------------------------------------------------
@@ -640,6 +899,107 @@ resource "terrifi_wlan" "test" {
 	})
 }
 
+func TestAccWLAN_passphraseWriteOnly(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-wo-%s", suffix)
+
+	config := func(passphrase string, version int) string {
+		return wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name                  = %q
+  passphrase_wo         = %q
+  passphrase_wo_version = %d
+  network_id            = terrifi_network.wlan_test.id
+}
+`, wlanName, passphrase, version)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("firstpassword1", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_wlan.test", "passphrase_wo"),
+					resource.TestCheckNoResourceAttr("terrifi_wlan.test", "passphrase"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "passphrase_wo_version", "1"),
+				),
+			},
+			// Changing only the write-only value is invisible to Terraform.
+			{
+				Config:   config("secondpassword2", 1),
+				PlanOnly: true,
+			},
+			// Bumping the version rotates the passphrase.
+			{
+				Config: config("secondpassword2", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "passphrase_wo_version", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationPassphraseAndPassphraseWO(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name                  = "tfacc-wlan-conflict"
+  passphrase            = "firstpassword1"
+  passphrase_wo         = "firstpassword1"
+  passphrase_wo_version = 1
+  network_id            = "000000000000000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile(`(?i)Invalid Attribute Combination`),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationPassphraseRequired(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-no-passphrase"
+  network_id = "000000000000000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing WLAN Passphrase`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-no-passphrase"
+  security   = "wpapsk"
+  network_id = "000000000000000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing WLAN Passphrase`),
+			},
+		},
+	})
+}
+
 func TestAccWLAN_updateBand(t *testing.T) {
 	requireHardware(t)
 	suffix := randomSuffix()
@@ -688,6 +1048,90 @@ resource "terrifi_wlan" "test" {
 	})
 }
 
+func TestAccWLAN_bandFlags(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.wlan_test.id
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "wifi_band", "both"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled_2g", "true"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled_5g", "true"),
+				),
+			},
+			{
+				// Switching to per-band flags updates the WLAN in place.
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.wlan_test.id
+  enabled_2g = false
+}
+`, wlanName),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("terrifi_wlan.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "wifi_band", "5g"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled_2g", "false"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled_5g", "true"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled_6g", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationBandFlags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-bands"
+  passphrase = "testpassword123"
+  network_id = "unused"
+  wifi_band  = "2g"
+  enabled_6g = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-bands"
+  passphrase = "testpassword123"
+  network_id = "unused"
+  enabled_2g = false
+  enabled_5g = false
+}
+`,
+				ExpectError: regexp.MustCompile(`No WiFi Band Enabled`),
+			},
+		},
+	})
+}
+
 func TestAccWLAN_hiddenSSID(t *testing.T) {
 	requireHardware(t)
 	suffix := randomSuffix()
@@ -753,6 +1197,130 @@ resource "terrifi_wlan" "test" {
 	})
 }
 
+func TestAccWLAN_enhancedOpen(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name         = %q
+  network_id   = terrifi_network.wlan_test.id
+  security     = "owe"
+  wpa3_support = true
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "security", "owe"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "wpa3_support", "true"),
+				),
+			},
+			{
+				ResourceName:      "terrifi_wlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccWLAN_wpa3Only(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name         = %q
+  passphrase   = "testpassword123"
+  network_id   = terrifi_network.wlan_test.id
+  security     = "wpa3"
+  wpa3_support = true
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "security", "wpa3"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "wpa3_transition", "false"),
+				),
+			},
+			{
+				// Back to WPA2/WPA3 mixed mode.
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name            = %q
+  passphrase      = "testpassword123"
+  network_id      = terrifi_network.wlan_test.id
+  security        = "wpapsk"
+  wpa3_support    = true
+  wpa3_transition = true
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "security", "wpapsk"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "wpa3_transition", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationWPA3Security(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-owe"
+  network_id = "000000000000000000000000"
+  security   = "owe"
+}
+`,
+				ExpectError: regexp.MustCompile(`WPA3 Support Required`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name            = "tfacc-wlan-wpa3"
+  passphrase      = "testpassword123"
+  network_id      = "000000000000000000000000"
+  security        = "wpa3"
+  wpa3_support    = true
+  wpa3_transition = true
+}
+`,
+				ExpectError: regexp.MustCompile(`can't be true when security is "wpa3"`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name         = "tfacc-wlan-wpa3"
+  network_id   = "000000000000000000000000"
+  security     = "wpa3"
+  wpa3_support = true
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing WLAN Passphrase`),
+			},
+		},
+	})
+}
+
 func TestAccWLAN_import(t *testing.T) {
 	requireHardware(t)
 	suffix := randomSuffix()
@@ -1144,6 +1712,47 @@ resource "terrifi_wlan" "test" {
 	})
 }
 
+func TestAccWLAN_validationHotspotOnVLANOnlyNetwork(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	config := func(application string) string {
+		return fmt.Sprintf(`
+resource "terrifi_network" "wlan_test" {
+  name    = %q
+  purpose = "vlan-only"
+  vlan_id = %d
+}
+
+resource "terrifi_wlan" "test" {
+  name        = %q
+  passphrase  = "testpassword123"
+  network_id  = terrifi_network.wlan_test.id
+  application = %q
+}
+`, netName, vlan, wlanName, application)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("standard"),
+				Check:  resource.TestCheckResourceAttr("terrifi_wlan.test", "application", "standard"),
+			},
+			{
+				// Once the network exists its purpose is known at plan time.
+				Config:      config("hotspot"),
+				ExpectError: regexp.MustCompile(`Network Cannot Serve WLAN`),
+			},
+		},
+	})
+}
+
 func TestAccWLAN_applicationIot(t *testing.T) {
 	requireHardware(t)
 	suffix := randomSuffix()
@@ -1347,3 +1956,194 @@ resource "terrifi_wlan" "test" {
 		},
 	})
 }
+
+func TestAccWLAN_networkPool(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan1 := randomVLAN()
+	vlan2 := randomVLAN()
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	networks := fmt.Sprintf(`
+resource "terrifi_network" "net1" {
+  name    = "tfacc-wlan-pool1-%s"
+  purpose = "vlan-only"
+  vlan_id = %d
+}
+
+resource "terrifi_network" "net2" {
+  name    = "tfacc-wlan-pool2-%s"
+  purpose = "vlan-only"
+  vlan_id = %d
+}
+`, suffix, vlan1, suffix, vlan2)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: networks + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+
+  network_pool = {
+    network_ids = [terrifi_network.net1.id, terrifi_network.net2.id]
+  }
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "network_pool.network_ids.#", "2"),
+					resource.TestCheckResourceAttrPair("terrifi_wlan.test", "network_id", "terrifi_network.net1", "id"),
+				),
+			},
+			{
+				ResourceName:            "terrifi_wlan.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase"},
+			},
+			{
+				// Back to a single network.
+				Config: networks + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.net2.id
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_wlan.test", "network_pool.network_ids.#"),
+					resource.TestCheckResourceAttrPair("terrifi_wlan.test", "network_id", "terrifi_network.net2", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationNetworkPool(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-pool-both"
+  passphrase = "testpassword123"
+  network_id = "000000000000000000000001"
+
+  network_pool = {
+    network_ids = ["000000000000000000000001", "000000000000000000000002"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-pool-neither"
+  passphrase = "testpassword123"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-pool-single"
+  passphrase = "testpassword123"
+
+  network_pool = {
+    network_ids = ["000000000000000000000001"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`at least 2`),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_schedule(t *testing.T) {
+	requireHardware(t)
+	suffix := randomSuffix()
+	vlan := randomVLAN()
+	netName := fmt.Sprintf("tfacc-wlan-net-%s", suffix)
+	wlanName := fmt.Sprintf("tfacc-wlan-%s", suffix)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.wlan_test.id
+
+  schedule = [{
+    days_of_week     = ["mon", "tue", "wed", "thu", "fri"]
+    start_time       = "08:00"
+    duration_minutes = 600
+  }]
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "schedule.#", "1"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "schedule.0.days_of_week.#", "5"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "schedule.0.start_time", "08:00"),
+					resource.TestCheckResourceAttr("terrifi_wlan.test", "schedule.0.duration_minutes", "600"),
+				),
+			},
+			{
+				ResourceName:            "terrifi_wlan.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase"},
+			},
+			{
+				// Removing the schedule broadcasts at all times again.
+				Config: wlanTestNetwork(netName, vlan) + fmt.Sprintf(`
+resource "terrifi_wlan" "test" {
+  name       = %q
+  passphrase = "testpassword123"
+  network_id = terrifi_network.wlan_test.id
+}
+`, wlanName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("terrifi_wlan.test", "schedule.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWLAN_validationSchedule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "terrifi_wlan" "test" {
+  name       = "tfacc-wlan-schedule"
+  passphrase = "testpassword123"
+  network_id = "000000000000000000000000"
+
+  schedule = [{
+    days_of_week     = ["monday"]
+    start_time       = "8am"
+    duration_minutes = 0
+  }]
+}
+`,
+				ExpectError: regexp.MustCompile(`HH:MM format`),
+			},
+		},
+	})
+}
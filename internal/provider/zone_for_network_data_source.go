@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+var _ datasource.DataSource = &zoneForNetworkDataSource{}
+
+func NewZoneForNetworkDataSource() datasource.DataSource {
+	return &zoneForNetworkDataSource{}
+}
+
+type zoneForNetworkDataSource struct {
+	client ClientAPI
+}
+
+type zoneForNetworkDataSourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Site      types.String `tfsdk:"site"`
+	NetworkID types.String `tfsdk:"network_id"`
+	Name      types.String `tfsdk:"name"`
+}
+
+func (d *zoneForNetworkDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_zone_for_network"
+}
+
+func (d *zoneForNetworkDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up the firewall zone that contains a given network. Useful in modules that " +
+			"take a network and need its zone for `terrifi_firewall_policy` without the caller passing both.",
+
+		Attributes: map[string]schema.Attribute{
+			"network_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the network to look up.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+
+			"site": schema.StringAttribute{
+				MarkdownDescription: "The site to look up the zone in. Defaults to the provider site.",
+				Optional:            true,
+				Computed:            true,
+			},
+
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the zone containing the network.",
+				Computed:            true,
+			},
+
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the zone containing the network.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *zoneForNetworkDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *zoneForNetworkDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var config zoneForNetworkDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	site := d.client.SiteOrDefault(config.Site)
+	networkID := config.NetworkID.ValueString()
+
+	zones, err := d.client.ListFirewallZone(ctx, site)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Firewall Zones",
+			fmt.Sprintf("Could not list firewall zones in site %q: %s", site, err.Error()),
+		)
+		return
+	}
+
+	zone := findZoneForNetwork(zones, networkID)
+	if zone == nil {
+		resp.Diagnostics.AddError(
+			"Zone Not Found",
+			fmt.Sprintf("No firewall zone in site %q contains network %q.", site, networkID),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(zone.ID)
+	config.Site = types.StringValue(site)
+	config.Name = types.StringValue(zone.Name)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
+
+// findZoneForNetwork returns the zone whose network_ids include networkID, or
+// nil if there is none. A network belongs to at most one zone.
+func findZoneForNetwork(zones []unifi.FirewallZone, networkID string) *unifi.FirewallZone {
+	for i := range zones {
+		for _, id := range zones[i].NetworkIDs {
+			if id == networkID {
+				return &zones[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ubiquiti-community/go-unifi/unifi"
+)
+
+// ---------------------------------------------------------------------------
+// Unit tests — no TF_ACC, no network, no env vars needed
+// ---------------------------------------------------------------------------
+
+func TestFindZoneForNetwork(t *testing.T) {
+	zones := []unifi.FirewallZone{
+		{ID: "zone-internal", Name: "Internal", NetworkIDs: []string{"net-lan", "net-mgmt"}},
+		{ID: "zone-iot", Name: "IoT", NetworkIDs: []string{"net-iot"}},
+		{ID: "zone-empty", Name: "Empty"},
+	}
+
+	t.Run("found", func(t *testing.T) {
+		zone := findZoneForNetwork(zones, "net-mgmt")
+		require.NotNil(t, zone)
+		assert.Equal(t, "zone-internal", zone.ID)
+		assert.Equal(t, "Internal", zone.Name)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Nil(t, findZoneForNetwork(zones, "net-unknown"))
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Acceptance tests
+// ---------------------------------------------------------------------------
+
+func TestAccZoneForNetworkDataSource_basic(t *testing.T) {
+	zoneName := fmt.Sprintf("tfacc-zone-lookup-%s", randomSuffix())
+	netName := fmt.Sprintf("tfacc-net-%s", randomSuffix())
+	vlan := randomVLAN()
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t); requireHardware(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "terrifi_network" "test" {
+  name    = %q
+  purpose = "corporate"
+  vlan_id = %d
+  subnet  = "10.%d.%d.1/24"
+}
+
+resource "terrifi_firewall_zone" "test" {
+  name        = %q
+  network_ids = [terrifi_network.test.id]
+}
+
+data "terrifi_zone_for_network" "test" {
+  network_id = terrifi_network.test.id
+  depends_on = [terrifi_firewall_zone.test]
+}
+`, netName, vlan, vlan/256, vlan%256, zoneName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.terrifi_zone_for_network.test", "id",
+						"terrifi_firewall_zone.test", "id",
+					),
+					resource.TestCheckResourceAttr("data.terrifi_zone_for_network.test", "name", zoneName),
+				),
+			},
+		},
+	})
+}
+
+func TestAccZoneForNetworkDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { preCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "terrifi_zone_for_network" "test" {
+  network_id = "000000000000000000000000"
+}
+`,
+				ExpectError: regexp.MustCompile(`Zone Not Found`),
+			},
+		},
+	})
+}
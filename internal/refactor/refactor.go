@@ -0,0 +1,104 @@
+// Package refactor rewrites Terraform configuration when resources are
+// renamed. It is used by the terrifi CLI's refactor command to adopt the
+// names generate-imports produces after objects are renamed on the
+// controller, without destroying and recreating them.
+package refactor
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Address is a managed resource address, e.g. terrifi_network.iot.
+type Address struct {
+	Type string
+	Name string
+}
+
+func (a Address) String() string {
+	return a.Type + "." + a.Name
+}
+
+var nameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ParseAddress parses a "<type>.<name>" resource address. Module paths,
+// data sources, and instance keys are not supported.
+func ParseAddress(s string) (Address, error) {
+	typ, name, ok := strings.Cut(s, ".")
+	if !ok || !nameRegexp.MatchString(typ) || !nameRegexp.MatchString(name) {
+		return Address{}, fmt.Errorf("invalid resource address %q: expected <type>.<name>, e.g. terrifi_network.iot", s)
+	}
+	return Address{Type: typ, Name: name}, nil
+}
+
+// Rename rewrites one configuration file so that the resource from is called
+// to: its resource block is relabeled, and every reference to it (including
+// import block targets and depends_on) is updated. moved blocks are left
+// alone, since they record the resource's history. It reports whether the
+// file changed.
+func Rename(src []byte, filename string, from, to Address) ([]byte, bool, error) {
+	if from.Type != to.Type {
+		return nil, false, fmt.Errorf("cannot rename %s to %s: resources can only be renamed within the same type", from, to)
+	}
+
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, false, fmt.Errorf("parsing %s: %s", filename, diags.Error())
+	}
+	renameInBody(f.Body(), from, to)
+
+	out := f.Bytes()
+	return out, !bytes.Equal(out, src), nil
+}
+
+func renameInBody(body *hclwrite.Body, from, to Address) {
+	for _, attr := range body.Attributes() {
+		attr.Expr().RenameVariablePrefix(
+			[]string{from.Type, from.Name},
+			[]string{to.Type, to.Name},
+		)
+	}
+	for _, block := range body.Blocks() {
+		switch block.Type() {
+		case "moved":
+			continue
+		case "resource":
+			if labels := block.Labels(); len(labels) == 2 && labels[0] == from.Type && labels[1] == from.Name {
+				block.SetLabels([]string{to.Type, to.Name})
+			}
+		}
+		renameInBody(block.Body(), from, to)
+	}
+}
+
+// Declares reports whether a configuration file has a resource block for
+// addr.
+func Declares(src []byte, filename string, addr Address) (bool, error) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return false, fmt.Errorf("parsing %s: %s", filename, diags.Error())
+	}
+	return f.Body().FirstMatchingBlock("resource", []string{addr.Type, addr.Name}) != nil, nil
+}
+
+// MovedBlock returns a moved block recording the rename, so Terraform moves
+// the existing state entry instead of replacing the resource.
+func MovedBlock(from, to Address) []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("moved", nil).Body()
+	body.SetAttributeTraversal("from", traversal(from))
+	body.SetAttributeTraversal("to", traversal(to))
+	return f.Bytes()
+}
+
+func traversal(a Address) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: a.Type},
+		hcl.TraverseAttr{Name: a.Name},
+	}
+}
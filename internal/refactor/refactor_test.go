@@ -0,0 +1,135 @@
+package refactor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	addr, err := ParseAddress("terrifi_network.iot")
+	require.NoError(t, err)
+	assert.Equal(t, Address{Type: "terrifi_network", Name: "iot"}, addr)
+	assert.Equal(t, "terrifi_network.iot", addr.String())
+
+	for _, s := range []string{"", "terrifi_network", "terrifi_network.", ".iot", "terrifi_network.iot.id", "module.x.terrifi_network.iot", "terrifi_network.0iot"} {
+		_, err := ParseAddress(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRename(t *testing.T) {
+	src := `import {
+  to = terrifi_network.old_lan
+  id = "abc123"
+}
+
+resource "terrifi_network" "old_lan" {
+  name = "LAN"
+}
+
+resource "terrifi_network" "old_lan_2" {
+  name = "LAN 2"
+}
+
+resource "terrifi_wlan" "home" {
+  name       = "Home"
+  network_id = terrifi_network.old_lan.id
+  note       = "on ${terrifi_network.old_lan.name}"
+  depends_on = [terrifi_network.old_lan]
+
+  nested {
+    network_id = terrifi_network.old_lan.id
+  }
+}
+
+moved {
+  from = terrifi_network.lan
+  to   = terrifi_network.old_lan
+}
+`
+	want := `import {
+  to = terrifi_network.lan
+  id = "abc123"
+}
+
+resource "terrifi_network" "lan" {
+  name = "LAN"
+}
+
+resource "terrifi_network" "old_lan_2" {
+  name = "LAN 2"
+}
+
+resource "terrifi_wlan" "home" {
+  name       = "Home"
+  network_id = terrifi_network.lan.id
+  note       = "on ${terrifi_network.lan.name}"
+  depends_on = [terrifi_network.lan]
+
+  nested {
+    network_id = terrifi_network.lan.id
+  }
+}
+
+moved {
+  from = terrifi_network.lan
+  to   = terrifi_network.old_lan
+}
+`
+	from := Address{Type: "terrifi_network", Name: "old_lan"}
+	to := Address{Type: "terrifi_network", Name: "lan"}
+
+	out, changed, err := Rename([]byte(src), "main.tf", from, to)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, want, string(out))
+
+	t.Run("unrelated file", func(t *testing.T) {
+		src := `resource "terrifi_network" "other" {
+  name = "Other"
+}
+`
+		out, changed, err := Rename([]byte(src), "other.tf", from, to)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, src, string(out))
+	})
+
+	t.Run("type change", func(t *testing.T) {
+		_, _, err := Rename([]byte(src), "main.tf", from, Address{Type: "terrifi_wlan", Name: "lan"})
+		assert.ErrorContains(t, err, "same type")
+	})
+
+	t.Run("invalid HCL", func(t *testing.T) {
+		_, _, err := Rename([]byte(`resource "x" {`), "bad.tf", from, to)
+		assert.ErrorContains(t, err, "bad.tf")
+	})
+}
+
+func TestDeclares(t *testing.T) {
+	src := []byte(`resource "terrifi_network" "lan" {
+  name = "LAN"
+}
+`)
+	ok, err := Declares(src, "main.tf", Address{Type: "terrifi_network", Name: "lan"})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Declares(src, "main.tf", Address{Type: "terrifi_network", Name: "iot"})
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestMovedBlock(t *testing.T) {
+	got := MovedBlock(
+		Address{Type: "terrifi_client_device", Name: "printer"},
+		Address{Type: "terrifi_client_device", Name: "office_printer"},
+	)
+	assert.Equal(t, `moved {
+  from = terrifi_client_device.printer
+  to   = terrifi_client_device.office_printer
+}
+`, string(got))
+}
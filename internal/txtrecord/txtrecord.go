@@ -0,0 +1,126 @@
+// Package txtrecord converts between the TXT record values users write in
+// Terraform and the form the UniFi controller stores.
+//
+// A TXT record is a sequence of character-strings of at most 255 bytes each.
+// The controller passes the stored value to dnsmasq's txt-record option, which
+// takes one or more comma-separated strings, each optionally double-quoted
+// with backslash escapes. Long SPF/DKIM values and values containing commas
+// or quotes therefore have to be split and quoted before they are sent, or
+// dnsmasq splits them at the wrong places.
+package txtrecord
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxStringLength is the maximum length in bytes of a single TXT
+// character-string (RFC 1035 section 3.3.14).
+const MaxStringLength = 255
+
+// MaxLength is the maximum total length in bytes of a TXT value accepted by
+// the provider. It leaves room for the rest of the DNS response; DKIM keys up
+// to 4096 bits fit comfortably.
+const MaxLength = 4000
+
+// Encode converts a logical TXT value into the form stored on the controller.
+// Values that are already a list of quoted strings are returned unchanged, so
+// users who split their records by hand keep full control. Short values with
+// no special characters are also left as-is. Anything else is split into
+// 255-byte chunks (never inside a UTF-8 sequence) which are quoted, escaped
+// and joined with commas.
+func Encode(value string) string {
+	if _, ok := parseQuoted(value); ok {
+		return value
+	}
+	if len(value) <= MaxStringLength && !strings.ContainsAny(value, `",\`) && strings.TrimSpace(value) == value {
+		return value
+	}
+
+	var parts []string
+	for _, chunk := range split(value) {
+		parts = append(parts, quote(chunk))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Decode converts a stored TXT value back into its logical value by
+// unquoting and concatenating its strings. Values that aren't a list of
+// quoted strings are returned unchanged.
+func Decode(stored string) string {
+	parts, ok := parseQuoted(stored)
+	if !ok {
+		return stored
+	}
+	return strings.Join(parts, "")
+}
+
+// split breaks s into chunks of at most MaxStringLength bytes without
+// splitting a multi-byte UTF-8 character.
+func split(s string) []string {
+	if s == "" {
+		return []string{""}
+	}
+	var chunks []string
+	for len(s) > MaxStringLength {
+		n := MaxStringLength
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		chunks = append(chunks, s[:n])
+		s = s[n:]
+	}
+	return append(chunks, s)
+}
+
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// parseQuoted parses s as one or more double-quoted strings separated by
+// commas (with optional surrounding whitespace). It returns the unescaped
+// strings and whether s had that form.
+func parseQuoted(s string) ([]string, bool) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, `"`) {
+		return nil, false
+	}
+
+	var parts []string
+	for {
+		if !strings.HasPrefix(s, `"`) {
+			return nil, false
+		}
+		var b strings.Builder
+		i := 1
+		closed := false
+		for i < len(s) {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				b.WriteByte(s[i+1])
+				i += 2
+				continue
+			}
+			if c == '"' {
+				closed = true
+				i++
+				break
+			}
+			b.WriteByte(c)
+			i++
+		}
+		if !closed {
+			return nil, false
+		}
+		parts = append(parts, b.String())
+
+		s = strings.TrimSpace(s[i:])
+		if s == "" {
+			return parts, true
+		}
+		if !strings.HasPrefix(s, ",") {
+			return nil, false
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+}
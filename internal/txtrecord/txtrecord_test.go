@@ -0,0 +1,64 @@
+package txtrecord
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	t.Run("short plain value is unchanged", func(t *testing.T) {
+		assert.Equal(t, "v=spf1 include:_spf.example.com ~all", Encode("v=spf1 include:_spf.example.com ~all"))
+	})
+
+	t.Run("value with comma or quote is quoted and escaped", func(t *testing.T) {
+		assert.Equal(t, `"a,b"`, Encode("a,b"))
+		assert.Equal(t, `"say \"hi\" \\o/"`, Encode(`say "hi" \o/`))
+	})
+
+	t.Run("long value is split into 255-byte strings", func(t *testing.T) {
+		value := "v=DKIM1; k=rsa; p=" + strings.Repeat("A", 400)
+		encoded := Encode(value)
+
+		parts, ok := parseQuoted(encoded)
+		assert.True(t, ok)
+		assert.Len(t, parts, 2)
+		assert.Len(t, parts[0], MaxStringLength)
+		assert.Equal(t, value, strings.Join(parts, ""))
+	})
+
+	t.Run("split does not break multi-byte characters", func(t *testing.T) {
+		value := strings.Repeat("a", 254) + "é" + "tail"
+		parts, ok := parseQuoted(Encode(value))
+		assert.True(t, ok)
+		assert.Equal(t, strings.Repeat("a", 254), parts[0])
+		assert.Equal(t, "étail", parts[1])
+	})
+
+	t.Run("already quoted value is unchanged", func(t *testing.T) {
+		assert.Equal(t, `"part one", "part two"`, Encode(`"part one", "part two"`))
+	})
+}
+
+func TestDecode(t *testing.T) {
+	assert.Equal(t, "plain", Decode("plain"))
+	assert.Equal(t, "part onepart two", Decode(`"part one","part two"`))
+	assert.Equal(t, `a,"b"\c`, Decode(`"a,\"b\"\\c"`))
+	// Not a well-formed list of quoted strings: returned verbatim.
+	assert.Equal(t, `"unterminated`, Decode(`"unterminated`))
+	assert.Equal(t, `"a" b`, Decode(`"a" b`))
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"hello",
+		"a,b,c",
+		`quote " and backslash \`,
+		" leading space",
+		strings.Repeat("x", 1000),
+	} {
+		assert.Equal(t, value, Decode(Encode(value)), "value %q", value)
+	}
+}
@@ -0,0 +1,129 @@
+// Command gen-docs renders the Schema section of the registry docs under
+// docs/ from the provider's resource and data source schemas.
+//
+// Pages that don't exist yet are created. Pages whose Schema section carries
+// docgen.Marker have that section regenerated; the rest of the page (examples,
+// import instructions) is left alone. Hand-written pages without the marker
+// are only checked for attributes they don't mention.
+//
+// With -check, nothing is written and the command exits non-zero if any page
+// is missing, stale or lags the schema. CI runs it this way.
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexklibisz/terrifi/internal/docgen"
+	"github.com/alexklibisz/terrifi/internal/provider"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+func main() {
+	dir := flag.String("docs", "docs", "docs directory")
+	check := flag.Bool("check", false, "report stale docs instead of writing them")
+	flag.Parse()
+
+	docs, err := schemaDocs(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var problems []string
+	for _, d := range docs {
+		p, err := update(*dir, d, *check)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		problems = append(problems, p...)
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		if *check {
+			fmt.Fprintln(os.Stderr, "\nRun `task docs` to regenerate the docs.")
+		}
+		os.Exit(1)
+	}
+}
+
+// schemaDocs returns a Doc for every resource and data source of the provider.
+func schemaDocs(ctx context.Context) ([]docgen.Doc, error) {
+	p := provider.New()
+	var docs []docgen.Doc
+
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "terrifi"}, &meta)
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		if resp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("%s: invalid schema: %v", meta.TypeName, resp.Diagnostics)
+		}
+		docs = append(docs, docgen.ResourceDoc(meta.TypeName, resp.Schema))
+	}
+
+	for _, newDataSource := range p.DataSources(ctx) {
+		d := newDataSource()
+		var meta datasource.MetadataResponse
+		d.Metadata(ctx, datasource.MetadataRequest{ProviderTypeName: "terrifi"}, &meta)
+		var resp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+		if resp.Diagnostics.HasError() {
+			return nil, fmt.Errorf("%s: invalid schema: %v", meta.TypeName, resp.Diagnostics)
+		}
+		docs = append(docs, docgen.DataSourceDoc(meta.TypeName, resp.Schema))
+	}
+
+	return docs, nil
+}
+
+// update brings d's doc page up to date, or with check only reports whether
+// it is, and returns the problems found.
+func update(dir string, d docgen.Doc, check bool) ([]string, error) {
+	sub := "resources"
+	if d.Kind == "Data Source" {
+		sub = "data-sources"
+	}
+	path := filepath.Join(dir, sub, strings.TrimPrefix(d.Name, "terrifi_")+".md")
+
+	page, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if check {
+			return []string{fmt.Sprintf("%s: missing", path)}, nil
+		}
+		return nil, os.WriteFile(path, []byte(d.Render()), 0o644)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	updated, generated := docgen.Update(page, d)
+	if !generated {
+		var problems []string
+		for _, name := range docgen.Undocumented(page, d) {
+			problems = append(problems, fmt.Sprintf("%s: attribute `%s` is not documented", path, name))
+		}
+		return problems, nil
+	}
+	if bytes.Equal(updated, page) {
+		return nil, nil
+	}
+	if check {
+		return []string{fmt.Sprintf("%s: Schema section is out of date", path)}, nil
+	}
+	return nil, os.WriteFile(path, updated, 0o644)
+}